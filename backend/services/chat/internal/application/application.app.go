@@ -39,6 +39,9 @@ type Clients interface {
 
 	// Returns true if either user is following the other.
 	AreConnected(ctx context.Context, userA, userB ct.Id) (bool, *ce.Error)
+
+	// Marks the new message notifications of a conversation as read for userId.
+	MarkConversationNotificationsRead(ctx context.Context, userId, conversationId ct.Id) *ce.Error
 }
 
 func NewChatService(
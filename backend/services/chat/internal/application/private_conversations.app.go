@@ -200,9 +200,22 @@ func (c *ChatService) UpdateLastReadPrivateMsg(ctx context.Context, arg md.Updat
 		tele.Error(ctx, "failed to publish private message to nats: @1", "error", err.Error())
 		return ce.Wrap(nil, err, input)
 	}
+
+	c.markConversationNotificationsReadAsync(ctx, arg.UserId, arg.ConversationId)
 	return nil
 }
 
+// markConversationNotificationsReadAsync clears the new message notifications of a conversation the user has read.
+// Failures are logged, the read pointer is already stored.
+func (c *ChatService) markConversationNotificationsReadAsync(ctx context.Context, userId, conversationId ct.Id) {
+	go func() {
+		ctx := context.WithoutCancel(ctx)
+		if err := c.Clients.MarkConversationNotificationsRead(ctx, userId, conversationId); err != nil {
+			tele.Warn(ctx, "failed to mark notifications of conversation @1 as read: @2", "conversationId", conversationId, "error", err.Error())
+		}
+	}()
+}
+
 func (c *ChatService) retrievePrivateMessageSenders(ctx context.Context, msgs []md.PrivateMsg, input string) error {
 	allMemberIDs := make(ct.Ids, 0)
 	for _, r := range msgs {
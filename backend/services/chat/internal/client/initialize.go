@@ -4,6 +4,7 @@ import (
 	"context"
 	cm "social-network/shared/gen-go/common"
 	"social-network/shared/gen-go/media"
+	"social-network/shared/gen-go/notifications"
 	userpb "social-network/shared/gen-go/users"
 	rds "social-network/shared/go/redis"
 )
@@ -11,6 +12,7 @@ import (
 type Clients struct {
 	UserClient  userpb.UserServiceClient
 	MediaClient media.MediaServiceClient
+	NotifClient notifications.NotificationServiceClient
 	RedisClient *rds.RedisClient
 }
 
@@ -19,11 +21,13 @@ type RetriveUsers func(ctx context.Context, userIds *cm.UserIds) (*cm.ListUsers,
 func NewClients(
 	userClient userpb.UserServiceClient,
 	mediaClient media.MediaServiceClient,
+	notifClient notifications.NotificationServiceClient,
 	redis *rds.RedisClient) *Clients {
 
 	return &Clients{
 		UserClient:  userClient,
 		MediaClient: mediaClient,
+		NotifClient: notifClient,
 		RedisClient: redis,
 	}
 }
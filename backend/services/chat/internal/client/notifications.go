@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"social-network/shared/gen-go/notifications"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+)
+
+// Marks the new message notifications of a conversation as read for userId.
+func (c *Clients) MarkConversationNotificationsRead(ctx context.Context, userId, conversationId ct.Id) *ce.Error {
+	input := fmt.Sprintf("userId: %v, conversationId: %v", userId, conversationId)
+	if c.NotifClient == nil {
+		return ce.New(ce.ErrInternal, errors.New("notifications client is nil"), input)
+	}
+	_, err := c.NotifClient.MarkReadByEntity(ctx, &notifications.MarkReadByEntityRequest{
+		UserId:         userId.Int64(),
+		SourceService:  "chat",
+		SourceEntityId: conversationId.Int64(),
+	})
+	if err != nil {
+		return ce.DecodeProto(err, input)
+	}
+	return nil
+}
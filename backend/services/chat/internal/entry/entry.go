@@ -11,6 +11,7 @@ import (
 	"social-network/services/chat/internal/handler"
 	"social-network/shared/gen-go/chat"
 	"social-network/shared/gen-go/media"
+	"social-network/shared/gen-go/notifications"
 	"social-network/shared/gen-go/users"
 	configutil "social-network/shared/go/configs"
 	"social-network/shared/go/ct"
@@ -40,6 +41,7 @@ type configs struct {
 	GrpcServerPort            string   `env:"GRPC_SERVER_PORT"`
 	UsersAdress               string   `env:"USERS_GRPC_ADDR"`
 	MediaGRPCAddr             string   `env:"MEDIA_GRPC_ADDR"`
+	NotificationsGRPCAddr     string   `env:"NOTIFICATIONS_GRPC_ADDR"`
 	EnableDebugLogs           bool     `env:"ENABLE_DEBUG_LOGS"`
 	SimplePrint               bool     `env:"ENABLE_SIMPLE_PRINT"`
 	OtelResourceAttributes    string   `end:"OTEL_RESOURCE_ATTRIBUTES"`
@@ -224,6 +226,15 @@ func initClients() *client.Clients {
 		tele.Fatalf("failed to create media client: %s", err.Error())
 	}
 
+	notifClient, err := gorpc.GetGRpcClient(
+		notifications.NewNotificationServiceClient,
+		cfgs.NotificationsGRPCAddr,
+		ct.CommonKeys(),
+	)
+	if err != nil {
+		tele.Fatalf("failed to create notifications client: %s", err.Error())
+	}
+
 	//
 	//
 	//
@@ -238,6 +249,7 @@ func initClients() *client.Clients {
 	return client.NewClients(
 		userClient,
 		mediaClient,
+		notifClient,
 		redisClient,
 	)
 }
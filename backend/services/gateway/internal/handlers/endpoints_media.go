@@ -4,11 +4,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"social-network/shared/gen-go/media"
 	ct "social-network/shared/go/ct"
 	utils "social-network/shared/go/http-utils"
+	"social-network/shared/go/jwt"
 	"social-network/shared/go/mapping"
 	tele "social-network/shared/go/telemetry"
 )
@@ -16,6 +21,13 @@ import (
 func (h *Handlers) validateFileUpload() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			tele.Error(ctx, "problem fetching claims")
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "can't find claims")
+			return
+		}
+
 		type validateUploadReq struct {
 			FileId    ct.Id
 			ReturnURL bool `json:"return_url"`
@@ -39,11 +51,12 @@ func (h *Handlers) validateFileUpload() http.HandlerFunc {
 		res, err := h.MediaService.ValidateUpload(
 			r.Context(),
 			&media.ValidateUploadRequest{
-				FileId:    httpReq.FileId.Int64(),
-				ReturnUrl: httpReq.ReturnURL},
+				FileId:      httpReq.FileId.Int64(),
+				ReturnUrl:   httpReq.ReturnURL,
+				RequesterId: int64(claims.UserId)},
 		)
 		if err != nil {
-			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
+			utils.ReturnHttpError(ctx, w, err)
 			return
 		}
 
@@ -60,10 +73,91 @@ func (h *Handlers) validateFileUpload() http.HandlerFunc {
 	}
 }
 
-// Only valid for public images
+// Creates a video owned by the user and returns its upload url.
+// The upload is validated with /files/{file_id}/validate, the renditions
+// are transcoded after that. Videos are private unless requested public.
+func (h *Handlers) uploadVideo() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			tele.Error(ctx, "problem fetching claims")
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "can't find claims")
+			return
+		}
+
+		type uploadVideoReq struct {
+			Filename   string            `json:"filename"`
+			MimeType   string            `json:"mime_type"`
+			SizeBytes  int64             `json:"size_bytes"`
+			Visibility ct.FileVisibility `json:"visibility"`
+			Variants   []ct.FileVariant  `json:"variants"`
+		}
+		httpReq := uploadVideoReq{}
+
+		decoder := json.NewDecoder(r.Body)
+		defer r.Body.Close()
+		if err := decoder.Decode(&httpReq); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if httpReq.Visibility == "" {
+			httpReq.Visibility = ct.Private
+		}
+		if len(httpReq.Variants) == 0 {
+			httpReq.Variants = []ct.FileVariant{ct.VidMP4, ct.VidPoster}
+		}
+		variants := make([]media.FileVariant, len(httpReq.Variants))
+		for i, v := range httpReq.Variants {
+			variants[i] = mapping.CtToPbFileVariant(v)
+		}
+
+		exp := time.Duration(10 * time.Minute).Seconds()
+		res, err := h.MediaService.UploadVideo(ctx, &media.UploadVideoRequest{
+			Filename:          httpReq.Filename,
+			MimeType:          httpReq.MimeType,
+			SizeBytes:         httpReq.SizeBytes,
+			Visibility:        mapping.CtToPbFileVisibility(httpReq.Visibility),
+			Variants:          variants,
+			ExpirationSeconds: int64(exp),
+			OwnerId:           int64(claims.UserId),
+		})
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		tele.Info(ctx, "Gateway: created video upload @1", "fileId", res.GetFileId())
+
+		type httpResp struct {
+			FileId    ct.Id  `json:"file_id"`
+			UploadUrl string `json:"upload_url"`
+		}
+
+		httpRes := &httpResp{FileId: ct.Id(res.GetFileId()), UploadUrl: res.GetUploadUrl()}
+		if err := utils.WriteJSON(ctx, w, http.StatusCreated, httpRes); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "failed to send upload url")
+			return
+		}
+	}
+}
+
+// Private images are only returned to their owner and the users that can see an entity using them
 func (h *Handlers) getImageUrl() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			tele.Error(ctx, "problem fetching claims")
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "can't find claims")
+			return
+		}
+		// media trusts calls without requester
+		if claims.UserId == 0 {
+			utils.ErrorJSON(ctx, w, http.StatusUnauthorized, "missing user")
+			return
+		}
 
 		imageId, err1 := utils.PathValueGet(r, "image_id", ct.Id(0), true)
 		variant, err2 := utils.PathValueGet(r, "variant", ct.FileVariant("thumb"), false)
@@ -73,11 +167,12 @@ func (h *Handlers) getImageUrl() http.HandlerFunc {
 		}
 
 		res, err := h.MediaService.GetImage(r.Context(), &media.GetImageRequest{
-			ImageId: imageId.Int64(),
-			Variant: mapping.CtToPbFileVariant(variant),
+			ImageId:     imageId.Int64(),
+			Variant:     mapping.CtToPbFileVariant(variant),
+			RequesterId: int64(claims.UserId),
 		})
 		if err != nil {
-			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
+			utils.ReturnHttpError(ctx, w, err)
 			return
 		}
 
@@ -92,3 +187,202 @@ func (h *Handlers) getImageUrl() http.HandlerFunc {
 		}
 	}
 }
+
+// Signs a transform of an image for the user and returns the relative url serving it.
+// The url needs no auth, it can be shared until it expires.
+// Query: w, h, fit (contain, cover), format (webp, jpeg, png), preset (a named variant), ttl in seconds
+func (h *Handlers) signImageTransform() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			tele.Error(ctx, "problem fetching claims")
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "can't find claims")
+			return
+		}
+		// media trusts calls without requester
+		if claims.UserId == 0 {
+			utils.ErrorJSON(ctx, w, http.StatusUnauthorized, "missing user")
+			return
+		}
+
+		v := r.URL.Query()
+		imageId, err1 := utils.PathValueGet(r, "image_id", ct.Id(0), true)
+		width, err2 := utils.ParamGet(v, "w", int32(0), false)
+		height, err3 := utils.ParamGet(v, "h", int32(0), false)
+		fit, err4 := utils.ParamGet(v, "fit", "", false)
+		format, err5 := utils.ParamGet(v, "format", "", false)
+		preset, err6 := utils.ParamGet(v, "preset", ct.FileVariant(""), false)
+		ttl, err7 := utils.ParamGet(v, "ttl", int64(0), false)
+		if err := errors.Join(err1, err2, err3, err4, err5, err6, err7); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		res, err := h.MediaService.SignImageTransform(ctx, &media.SignImageTransformRequest{
+			ImageId: imageId.Int64(),
+			Transform: &media.ImageTransform{
+				Width:  width,
+				Height: height,
+				Fit:    fit,
+				Format: format,
+				Preset: mapping.CtToPbFileVariant(preset),
+			},
+			RequesterId:       int64(claims.UserId),
+			ExpirationSeconds: ttl,
+		})
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		encodedId, err := ct.EncodeId(imageId)
+		if err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "failed to encode image id")
+			return
+		}
+
+		t := res.GetTransform()
+		query := url.Values{}
+		query.Set("w", strconv.Itoa(int(t.GetWidth())))
+		query.Set("h", strconv.Itoa(int(t.GetHeight())))
+		query.Set("fit", t.GetFit())
+		query.Set("format", t.GetFormat())
+		query.Set("exp", strconv.FormatInt(res.GetExpiresAt(), 10))
+		query.Set("sig", res.GetSignature())
+
+		type httpResp struct {
+			Url       string `json:"url"`
+			ExpiresAt int64  `json:"expires_at"`
+		}
+
+		httpRes := &httpResp{
+			Url:       "/files/images/" + encodedId + "/transform?" + query.Encode(),
+			ExpiresAt: res.GetExpiresAt(),
+		}
+		if err := utils.WriteJSON(ctx, w, http.StatusOK, httpRes); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "failed to send transform url")
+			return
+		}
+	}
+}
+
+// Cache-Control of public files. The content of a file variant never changes,
+// deleted files may stay in caches until max-age.
+const publicFileCacheControl = "public, max-age=604800"
+
+// Response headers of file service passed on when serving public files.
+var publicFileHeaders = []string{
+	"Content-Type",
+	"Content-Length",
+	"Content-Range",
+	"Accept-Ranges",
+	"ETag",
+	"Last-Modified",
+}
+
+// Serves the stable url of a public file, streaming the object from file service.
+// Conditional and range requests are passed on, so unchanged files are answered
+// with 304 Not Modified. Private and missing files are not found.
+func (h *Handlers) getPublicFile() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		fileId, err1 := utils.PathValueGet(r, "file_id", ct.Id(0), true)
+		variant, err2 := utils.PathValueGet(r, "variant", ct.FileVariant(""), true)
+		if err := errors.Join(err1, err2); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		res, err := h.MediaService.GetPublicFile(ctx, &media.PublicFileRequest{
+			FileId:  fileId.Int64(),
+			Variant: mapping.CtToPbFileVariant(variant),
+		})
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		objReq, err := http.NewRequestWithContext(ctx, http.MethodGet, res.GetDownloadUrl(), nil)
+		if err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "failed to fetch file")
+			return
+		}
+		for _, header := range []string{"If-None-Match", "If-Modified-Since", "Range"} {
+			if v := r.Header.Get(header); v != "" {
+				objReq.Header.Set(header, v)
+			}
+		}
+
+		objRes, err := http.DefaultClient.Do(objReq)
+		if err != nil {
+			tele.Error(ctx, "failed to fetch public file @1. @2", "fileId", fileId, "error", err.Error())
+			utils.ErrorJSON(ctx, w, http.StatusBadGateway, "failed to fetch file")
+			return
+		}
+		defer objRes.Body.Close()
+
+		switch objRes.StatusCode {
+		case http.StatusOK, http.StatusPartialContent, http.StatusNotModified:
+		case http.StatusRequestedRangeNotSatisfiable:
+			utils.ErrorJSON(ctx, w, objRes.StatusCode, "range not satisfiable")
+			return
+		default:
+			tele.Error(ctx, "file service answered @1 for public file @2", "status", objRes.StatusCode, "fileId", fileId)
+			utils.ErrorJSON(ctx, w, http.StatusBadGateway, "failed to fetch file")
+			return
+		}
+
+		for _, header := range publicFileHeaders {
+			if v := objRes.Header.Get(header); v != "" {
+				w.Header().Set(header, v)
+			}
+		}
+		w.Header().Set("Cache-Control", publicFileCacheControl)
+		w.WriteHeader(objRes.StatusCode)
+
+		if _, err := io.Copy(w, objRes.Body); err != nil {
+			tele.Warn(ctx, "failed to stream public file @1. @2", "fileId", fileId, "error", err.Error())
+		}
+	}
+}
+
+// Redirects a signed transform url to the download url of the transform.
+// The transform is generated by the media service on the first request.
+func (h *Handlers) getImageTransform() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		v := r.URL.Query()
+		imageId, err1 := utils.PathValueGet(r, "image_id", ct.Id(0), true)
+		width, err2 := utils.ParamGet(v, "w", int32(0), true)
+		height, err3 := utils.ParamGet(v, "h", int32(0), true)
+		fit, err4 := utils.ParamGet(v, "fit", "", true)
+		format, err5 := utils.ParamGet(v, "format", "", true)
+		expiresAt, err6 := utils.ParamGet(v, "exp", int64(0), true)
+		signature, err7 := utils.ParamGet(v, "sig", "", true)
+		if err := errors.Join(err1, err2, err3, err4, err5, err6, err7); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		res, err := h.MediaService.GetImageTransform(ctx, &media.GetImageTransformRequest{
+			ImageId: imageId.Int64(),
+			Transform: &media.ImageTransform{
+				Width:  width,
+				Height: height,
+				Fit:    fit,
+				Format: format,
+			},
+			ExpiresAt: expiresAt,
+			Signature: signature,
+		})
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		http.Redirect(w, r, res.GetDownloadUrl(), http.StatusFound)
+	}
+}
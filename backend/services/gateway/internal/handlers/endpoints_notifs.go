@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"social-network/shared/gen-go/notifications"
@@ -11,7 +12,10 @@ import (
 	"social-network/shared/go/jwt"
 	"social-network/shared/go/mapping"
 	tele "social-network/shared/go/telemetry"
+	"time"
 
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -26,13 +30,15 @@ func (h *Handlers) GetUserNotifications() http.HandlerFunc {
 		}
 
 		v := r.URL.Query()
+		grouped, err4 := utils.ParamGet(v, "grouped", false, false)
 		limit, err1 := utils.ParamGet(v, "limit", 100, true)
-		offset, err2 := utils.ParamGet(v, "offset", 0, true)
+		offset, err2 := utils.ParamGet(v, "offset", 0, !grouped) // groups page with cursor instead
 		unreadOnly, err3 := utils.ParamGet(v, "read_only", false, true)
+		cursor, err5 := utils.ParamGet(v, "cursor", "", false)
 		userId := claims.UserId
 
 		notifTypes := []notifications.NotificationType{}
-		if err := errors.Join(err1, err2, err3); err != nil {
+		if err := errors.Join(err1, err2, err3, err4, err5); err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
 			return
 		}
@@ -43,15 +49,26 @@ func (h *Handlers) GetUserNotifications() http.HandlerFunc {
 			Offset:     int32(offset),
 			Types:      notifTypes,
 			UnreadOnly: unreadOnly,
+			Grouped:    grouped,
+			Cursor:     cursor,
+			Categories: v["category"],
 		})
-
+		httpCode, _ := gorpc.Classify(err)
 		if err != nil {
 			err = ce.DecodeProto(err)
-			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
+			utils.ErrorJSON(ctx, w, httpCode, err.Error())
+			return
+		}
+
+		if grouped {
+			utils.SetNextCursor(w, grpcResponse.NextCursor)
+			err = utils.WriteJSON(ctx, w, httpCode, mapping.PbToNotificationGroups(grpcResponse.Groups))
+			if err != nil {
+				utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
+			}
 			return
 		}
 
-		httpCode, _ := gorpc.Classify(err)
 		err = utils.WriteJSON(ctx, w, httpCode, mapping.PbToNotifications(grpcResponse.Notifications))
 		if err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
@@ -148,6 +165,39 @@ func (s *Handlers) MarkAllAsRead() http.HandlerFunc {
 	}
 }
 
+func (s *Handlers) MarkCategoryAsRead() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			tele.Error(ctx, "problem fetching claims")
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "can't find claims")
+			return
+		}
+
+		category, err := utils.ParamGet(r.URL.Query(), "category", "", true)
+		if err != nil || category == "" {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad category")
+			return
+		}
+
+		_, err = s.NotifService.MarkReadByCategory(ctx, &notifications.MarkReadByCategoryRequest{
+			UserId:   claims.UserId,
+			Category: category,
+		})
+		httpCode, _ := gorpc.Classify(err)
+		if err != nil {
+			err = ce.DecodeProto(err)
+			utils.ErrorJSON(ctx, w, httpCode, err.Error())
+			return
+		}
+
+		if err := utils.WriteJSON(ctx, w, http.StatusOK, nil); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
+		}
+	}
+}
+
 func (s *Handlers) DeleteNotification() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -180,6 +230,28 @@ func (s *Handlers) DeleteNotification() http.HandlerFunc {
 	}
 }
 
+type notificationMute struct {
+	TargetType string     `json:"target_type"`
+	TargetId   ct.Id      `json:"target_id"`
+	MutedUntil *time.Time `json:"muted_until,omitempty"`
+}
+
+// pushSubscription is a browser PushSubscription.toJSON()
+type pushSubscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+type notificationPreferences struct {
+	Preferences map[string]bool    `json:"preferences"`
+	Categories  map[string]bool    `json:"categories"`
+	Mutes       []notificationMute `json:"mutes"`
+	EmailMode   string             `json:"email_mode"`
+}
+
 func (s *Handlers) GetNotificationPreferences() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -194,18 +266,273 @@ func (s *Handlers) GetNotificationPreferences() http.HandlerFunc {
 			ctx,
 			&wrapperspb.Int64Value{Value: claims.UserId},
 		)
+		httpCode, _ := gorpc.Classify(err)
 		if err != nil {
 			err = ce.DecodeProto(err)
+			utils.ErrorJSON(ctx, w, httpCode, err.Error())
+			return
+		}
+
+		resp := notificationPreferences{
+			Preferences: grpcResponse.Preferences,
+			Categories:  grpcResponse.Categories,
+			Mutes:       make([]notificationMute, 0, len(grpcResponse.Mutes)),
+			EmailMode:   grpcResponse.EmailMode,
+		}
+		for _, m := range grpcResponse.Mutes {
+			mute := notificationMute{
+				TargetType: m.TargetType,
+				TargetId:   ct.Id(m.TargetId),
+			}
+			if m.MutedUntil != nil {
+				t := m.MutedUntil.AsTime()
+				mute.MutedUntil = &t
+			}
+			resp.Mutes = append(resp.Mutes, mute)
+		}
+
+		if err := utils.WriteJSON(ctx, w, httpCode, resp); err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
+		}
+	}
+}
+
+func (s *Handlers) UpdateNotificationPreferences() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			tele.Error(ctx, "problem fetching claims")
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "can't find claims")
 			return
 		}
 
+		type req struct {
+			Preferences map[string]bool `json:"preferences"`
+			Categories  map[string]bool `json:"categories"`
+			EmailMode   string          `json:"email_mode"`
+		}
+		httpReq := req{}
+
+		decoder := json.NewDecoder(r.Body)
+		defer r.Body.Close()
+		if err := decoder.Decode(&httpReq); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		_, err := s.NotifService.UpdateNotificationPreferences(ctx, &notifications.UpdateNotificationPreferencesRequest{
+			UserId:      claims.UserId,
+			Preferences: httpReq.Preferences,
+			Categories:  httpReq.Categories,
+			EmailMode:   httpReq.EmailMode,
+		})
 		httpCode, _ := gorpc.Classify(err)
-		if err := utils.WriteJSON(ctx, w, httpCode, grpcResponse); err != nil {
+		if err != nil {
+			err = ce.DecodeProto(err)
+			utils.ErrorJSON(ctx, w, httpCode, err.Error())
+			return
+		}
+
+		if err := utils.WriteJSON(ctx, w, http.StatusOK, nil); err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
 		}
+	}
+}
 
-		if err := utils.WriteJSON(ctx, w, http.StatusOK, grpcResponse); err != nil {
+func (s *Handlers) MuteNotifications() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			tele.Error(ctx, "problem fetching claims")
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "can't find claims")
+			return
+		}
+
+		httpReq := notificationMute{}
+
+		decoder := json.NewDecoder(r.Body)
+		defer r.Body.Close()
+		if err := decoder.Decode(&httpReq); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		grpcReq := &notifications.MuteNotificationsRequest{
+			UserId:     claims.UserId,
+			TargetType: httpReq.TargetType,
+			TargetId:   httpReq.TargetId.Int64(),
+		}
+		if httpReq.MutedUntil != nil {
+			grpcReq.MutedUntil = timestamppb.New(*httpReq.MutedUntil)
+		}
+
+		_, err := s.NotifService.MuteNotifications(ctx, grpcReq)
+		httpCode, _ := gorpc.Classify(err)
+		if err != nil {
+			err = ce.DecodeProto(err)
+			utils.ErrorJSON(ctx, w, httpCode, err.Error())
+			return
+		}
+
+		if err := utils.WriteJSON(ctx, w, http.StatusOK, nil); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
+		}
+	}
+}
+
+func (s *Handlers) UnmuteNotifications() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			tele.Error(ctx, "problem fetching claims")
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "can't find claims")
+			return
+		}
+
+		targetType, err1 := utils.PathValueGet(r, "target_type", "", true)
+		targetId, err2 := utils.PathValueGet(r, "target_id", ct.Id(0), true)
+		if err := errors.Join(err1, err2); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		_, err := s.NotifService.UnmuteNotifications(ctx, &notifications.UnmuteNotificationsRequest{
+			UserId:     claims.UserId,
+			TargetType: targetType,
+			TargetId:   targetId.Int64(),
+		})
+		httpCode, _ := gorpc.Classify(err)
+		if err != nil {
+			err = ce.DecodeProto(err)
+			utils.ErrorJSON(ctx, w, httpCode, err.Error())
+			return
+		}
+
+		if err := utils.WriteJSON(ctx, w, http.StatusOK, nil); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
+		}
+	}
+}
+
+// UnsubscribeEmail serves the unsubscribe links of notification emails, without login
+func (s *Handlers) UnsubscribeEmail() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token, err := utils.ParamGet(r.URL.Query(), "token", "", true)
+		if err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		_, err = s.NotifService.UnsubscribeEmail(ctx, &notifications.UnsubscribeEmailRequest{
+			Token: token,
+		})
+		httpCode, _ := gorpc.Classify(err)
+		if err != nil {
+			err = ce.DecodeProto(err)
+			utils.ErrorJSON(ctx, w, httpCode, err.Error())
+			return
+		}
+
+		if err := utils.WriteJSON(ctx, w, http.StatusOK, map[string]string{"message": "you will not get notification emails anymore"}); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
+		}
+	}
+}
+
+// GetWebPushPublicKey returns the key browsers pass as applicationServerKey when subscribing to push
+func (s *Handlers) GetWebPushPublicKey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		key, err := s.NotifService.GetWebPushPublicKey(ctx, &emptypb.Empty{})
+		httpCode, _ := gorpc.Classify(err)
+		if err != nil {
+			err = ce.DecodeProto(err)
+			utils.ErrorJSON(ctx, w, httpCode, err.Error())
+			return
+		}
+
+		if err := utils.WriteJSON(ctx, w, http.StatusOK, map[string]string{"public_key": key.GetValue()}); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
+		}
+	}
+}
+
+func (s *Handlers) RegisterPushSubscription() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			tele.Error(ctx, "problem fetching claims")
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "can't find claims")
+			return
+		}
+
+		httpReq := pushSubscription{}
+
+		decoder := json.NewDecoder(r.Body)
+		defer r.Body.Close()
+		if err := decoder.Decode(&httpReq); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		_, err := s.NotifService.RegisterPushSubscription(ctx, &notifications.RegisterPushSubscriptionRequest{
+			UserId:    claims.UserId,
+			Endpoint:  httpReq.Endpoint,
+			P256Dh:    httpReq.Keys.P256dh,
+			Auth:      httpReq.Keys.Auth,
+			UserAgent: r.UserAgent(),
+		})
+		httpCode, _ := gorpc.Classify(err)
+		if err != nil {
+			err = ce.DecodeProto(err)
+			utils.ErrorJSON(ctx, w, httpCode, err.Error())
+			return
+		}
+
+		if err := utils.WriteJSON(ctx, w, http.StatusOK, nil); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
+		}
+	}
+}
+
+func (s *Handlers) UnregisterPushSubscription() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			tele.Error(ctx, "problem fetching claims")
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "can't find claims")
+			return
+		}
+
+		httpReq := pushSubscription{}
+
+		decoder := json.NewDecoder(r.Body)
+		defer r.Body.Close()
+		if err := decoder.Decode(&httpReq); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		_, err := s.NotifService.UnregisterPushSubscription(ctx, &notifications.UnregisterPushSubscriptionRequest{
+			UserId:   claims.UserId,
+			Endpoint: httpReq.Endpoint,
+		})
+		httpCode, _ := gorpc.Classify(err)
+		if err != nil {
+			err = ce.DecodeProto(err)
+			utils.ErrorJSON(ctx, w, httpCode, err.Error())
+			return
+		}
+
+		if err := utils.WriteJSON(ctx, w, http.StatusOK, nil); err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
 		}
 	}
@@ -70,6 +70,7 @@ func (h *Handlers) createComment() http.HandlerFunc {
 				Visibility:        imageVisibility,
 				Variants:          []media.FileVariant{media.FileVariant_MEDIUM},
 				ExpirationSeconds: int64(exp),
+				OwnerId:           int64(claims.UserId),
 			})
 			if err != nil {
 				status, class := gorpc.Classify(err)
@@ -128,8 +129,9 @@ func (h *Handlers) getCommentsByParentId() http.HandlerFunc {
 		v := r.URL.Query()
 		entityId, err1 := utils.ParamGet(v, "entity_id", ct.Id(0), true)
 		limit, err2 := utils.ParamGet(v, "limit", int32(1), false)
-		offset, err3 := utils.ParamGet(v, "offset", int32(0), false)
-		if err := errors.Join(err1, err2, err3); err != nil {
+		offset, err3 := utils.ParamGet(v, "offset", int32(0), false) // deprecated, use cursor
+		cursor, err4 := utils.ParamGet(v, "cursor", "", false)
+		if err := errors.Join(err1, err2, err3, err4); err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
 			return
 		}
@@ -139,6 +141,7 @@ func (h *Handlers) getCommentsByParentId() http.HandlerFunc {
 			EntityId:    entityId.Int64(),
 			Limit:       limit,
 			Offset:      offset,
+			Cursor:      cursor,
 		}
 
 		grpcResp, err := h.PostsService.GetCommentsByParentId(ctx, &grpcReq)
@@ -172,6 +175,7 @@ func (h *Handlers) getCommentsByParentId() http.HandlerFunc {
 			commentsResponse = append(commentsResponse, comment)
 		}
 
+		utils.SetNextCursor(w, grpcResp.NextCursor)
 		err = utils.WriteJSON(ctx, w, http.StatusOK, commentsResponse)
 		if err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, fmt.Sprintf("failed to send comments for post %v : %v", entityId, err.Error()))
@@ -232,6 +236,7 @@ func (h *Handlers) editComment() http.HandlerFunc {
 				Visibility:        media.FileVisibility_PUBLIC,
 				Variants:          []media.FileVariant{media.FileVariant_MEDIUM},
 				ExpirationSeconds: int64(exp),
+				OwnerId:           int64(claims.UserId),
 			})
 			if err != nil {
 				utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
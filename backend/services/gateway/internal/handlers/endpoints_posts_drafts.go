@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"social-network/shared/gen-go/posts"
+	ct "social-network/shared/go/ct"
+	utils "social-network/shared/go/http-utils"
+	"social-network/shared/go/jwt"
+	"social-network/shared/go/models"
+	tele "social-network/shared/go/telemetry"
+)
+
+func (h *Handlers) getUserDrafts() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tele.Info(ctx, "getUserDrafts handler called")
+
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			panic(1)
+		}
+
+		v := r.URL.Query()
+		limit, err1 := utils.ParamGet(v, "limit", int32(1), false)
+		offset, err2 := utils.ParamGet(v, "offset", int32(0), false)
+		if err := errors.Join(err1, err2); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		grpcReq := posts.GenericPaginatedReq{
+			RequesterId: claims.UserId,
+			Limit:       limit,
+			Offset:      offset,
+		}
+
+		grpcResp, err := h.PostsService.GetUserDrafts(ctx, &grpcReq)
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		tele.Info(ctx, "retrieved user drafts. @1", "grpcResp", grpcResp)
+
+		postsResponse := []models.Post{}
+		for _, p := range grpcResp.Posts {
+			selectedAudience := make([]models.User, 0, len(p.SelectedAudienceUsers.GetUsers()))
+			for _, u := range p.SelectedAudienceUsers.GetUsers() {
+				selectedAudience = append(selectedAudience, models.User{
+					UserId:    ct.Id(u.UserId),
+					Username:  ct.Username(u.Username),
+					AvatarId:  ct.Id(u.Avatar),
+					AvatarURL: u.AvatarUrl,
+				})
+			}
+
+			postsResponse = append(postsResponse, models.Post{
+				PostId: ct.Id(p.PostId),
+				Body:   ct.PostBody(p.PostBody),
+				User: models.User{
+					UserId:    ct.Id(p.User.UserId),
+					Username:  ct.Username(p.User.Username),
+					AvatarId:  ct.Id(p.User.Avatar),
+					AvatarURL: p.User.AvatarUrl,
+				},
+				GroupId:               ct.Id(p.GroupId),
+				Audience:              ct.Audience(p.Audience),
+				CreatedAt:             ct.GenDateTime(p.CreatedAt.AsTime()),
+				UpdatedAt:             ct.GenDateTime(p.UpdatedAt.AsTime()),
+				ImageId:               ct.Id(p.ImageId),
+				ImageUrl:              p.ImageUrl,
+				Poll:                  pollFromProto(p.Poll),
+				SelectedAudienceUsers: selectedAudience,
+				Status:                ct.PostStatus(p.Status),
+				PublishAt:             ct.GenDateTime(p.PublishAt.AsTime()),
+			})
+		}
+
+		err = utils.WriteJSON(ctx, w, http.StatusOK, postsResponse)
+		if err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "failed to send drafts")
+			return
+		}
+	}
+}
+
+func (h *Handlers) updatePostStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tele.Info(ctx, "updatePostStatus handler called")
+
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			panic(1)
+		}
+
+		type UpdatePostStatusJSONRequest struct {
+			PostId    ct.Id
+			Status    ct.PostStatus  `json:"status"`
+			PublishAt ct.GenDateTime `json:"publish_at" validate:"nullable"`
+		}
+
+		httpReq := UpdatePostStatusJSONRequest{}
+
+		decoder := json.NewDecoder(r.Body)
+		defer r.Body.Close()
+		if err := decoder.Decode(&httpReq); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var err error
+		httpReq.PostId, err = utils.PathValueGet(r, "post_id", ct.Id(0), true)
+		if err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		if err := ct.ValidateStruct(httpReq); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		grpcReq := posts.UpdatePostStatusReq{
+			RequesterId: int64(claims.UserId),
+			PostId:      httpReq.PostId.Int64(),
+			Status:      httpReq.Status.String(),
+			PublishAt:   httpReq.PublishAt.ToProto(),
+		}
+
+		_, err = h.PostsService.UpdatePostStatus(ctx, &grpcReq)
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		tele.Info(ctx, "updated post status successfully")
+	}
+}
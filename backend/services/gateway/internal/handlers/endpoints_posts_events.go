@@ -66,6 +66,7 @@ func (h *Handlers) createEvent() http.HandlerFunc {
 				Visibility:        media.FileVisibility_PRIVATE,
 				Variants:          []media.FileVariant{media.FileVariant_MEDIUM},
 				ExpirationSeconds: int64(exp),
+				OwnerId:           int64(claims.UserId),
 			})
 			if err != nil {
 				utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
@@ -161,6 +162,7 @@ func (h *Handlers) editEvent() http.HandlerFunc {
 				Visibility:        media.FileVisibility_PRIVATE,
 				Variants:          []media.FileVariant{media.FileVariant_MEDIUM},
 				ExpirationSeconds: int64(exp),
+				OwnerId:           int64(claims.UserId),
 			})
 			if err != nil {
 				utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
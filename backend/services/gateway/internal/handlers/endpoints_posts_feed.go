@@ -26,9 +26,13 @@ func (h *Handlers) getPublicFeed() http.HandlerFunc {
 
 		limit, err1 := utils.ParamGet(v, "limit", int32(1), false)
 
-		offset, err2 := utils.ParamGet(v, "offset", int32(0), false)
+		offset, err2 := utils.ParamGet(v, "offset", int32(0), false) // deprecated, use cursor
 
-		if err := errors.Join(err1, err2); err != nil {
+		cursor, err3 := utils.ParamGet(v, "cursor", "", false)
+
+		sort, err4 := utils.ParamGet(v, "sort", "", false) // "recent" or "top"
+
+		if err := errors.Join(err1, err2, err3, err4); err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
 			return
 		}
@@ -37,6 +41,8 @@ func (h *Handlers) getPublicFeed() http.HandlerFunc {
 			RequesterId: claims.UserId,
 			Limit:       limit,
 			Offset:      offset,
+			Cursor:      cursor,
+			Sort:        sort,
 		}
 
 		grpcResp, err := h.PostsService.GetPublicFeed(ctx, &grpcReq)
@@ -64,16 +70,20 @@ func (h *Handlers) getPublicFeed() http.HandlerFunc {
 				Audience:        ct.Audience(p.Audience),
 				CommentsCount:   int(p.CommentsCount),
 				ReactionsCount:  int(p.ReactionsCount),
+				SharesCount:     int(p.SharesCount),
+				SharedPost:      sharedPostFromProto(p.SharedPost),
 				LastCommentedAt: ct.GenDateTime(p.LastCommentedAt.AsTime()),
 				CreatedAt:       ct.GenDateTime(p.CreatedAt.AsTime()),
 				UpdatedAt:       ct.GenDateTime(p.UpdatedAt.AsTime()),
 				LikedByUser:     p.LikedByUser,
 				ImageId:         ct.Id(p.ImageId),
 				ImageUrl:        p.ImageUrl,
+				Poll:            pollFromProto(p.Poll),
 			}
 			postsResponse = append(postsResponse, post)
 		}
 
+		utils.SetNextCursor(w, grpcResp.NextCursor)
 		err = utils.WriteJSON(ctx, w, http.StatusOK, postsResponse)
 		if err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "failed to send public feed")
@@ -83,6 +93,81 @@ func (h *Handlers) getPublicFeed() http.HandlerFunc {
 	}
 }
 
+func (h *Handlers) getHomeTimeline() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tele.Info(ctx, "getHomeTimeline handler called")
+
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			tele.Error(ctx, "problem fetching claims")
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "problem with jwt claims")
+			return
+		}
+
+		v := r.URL.Query()
+
+		limit, err1 := utils.ParamGet(v, "limit", int32(1), false)
+
+		cursor, err2 := utils.ParamGet(v, "cursor", "", false)
+
+		if err := errors.Join(err1, err2); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		grpcReq := posts.GenericPaginatedReq{
+			RequesterId: claims.UserId,
+			Limit:       limit,
+			Cursor:      cursor,
+		}
+
+		grpcResp, err := h.PostsService.GetHomeTimeline(ctx, &grpcReq)
+
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		tele.Info(ctx, "retrieved home timeline. @1", "grpcResp", grpcResp)
+
+		postsResponse := []models.Post{}
+		for _, p := range grpcResp.Posts {
+			post := models.Post{
+				PostId: ct.Id(p.PostId),
+				Body:   ct.PostBody(p.PostBody),
+				User: models.User{
+					UserId:    ct.Id(p.User.UserId),
+					Username:  ct.Username(p.User.Username),
+					AvatarId:  ct.Id(p.User.Avatar),
+					AvatarURL: p.User.AvatarUrl,
+				},
+				GroupId:         ct.Id(p.GroupId),
+				Audience:        ct.Audience(p.Audience),
+				CommentsCount:   int(p.CommentsCount),
+				ReactionsCount:  int(p.ReactionsCount),
+				SharesCount:     int(p.SharesCount),
+				SharedPost:      sharedPostFromProto(p.SharedPost),
+				LastCommentedAt: ct.GenDateTime(p.LastCommentedAt.AsTime()),
+				CreatedAt:       ct.GenDateTime(p.CreatedAt.AsTime()),
+				UpdatedAt:       ct.GenDateTime(p.UpdatedAt.AsTime()),
+				LikedByUser:     p.LikedByUser,
+				ImageId:         ct.Id(p.ImageId),
+				ImageUrl:        p.ImageUrl,
+				Poll:            pollFromProto(p.Poll),
+			}
+			postsResponse = append(postsResponse, post)
+		}
+
+		utils.SetNextCursor(w, grpcResp.NextCursor)
+		err = utils.WriteJSON(ctx, w, http.StatusOK, postsResponse)
+		if err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "failed to send home timeline")
+			return
+		}
+	}
+}
+
 func (h *Handlers) getPersonalizedFeed() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -100,9 +185,11 @@ func (h *Handlers) getPersonalizedFeed() http.HandlerFunc {
 
 		limit, err1 := utils.ParamGet(v, "limit", int32(1), false)
 
-		offset, err2 := utils.ParamGet(v, "offset", int32(0), false)
+		offset, err2 := utils.ParamGet(v, "offset", int32(0), false) // deprecated, use cursor
 
-		if err := errors.Join(err1, err2); err != nil {
+		cursor, err3 := utils.ParamGet(v, "cursor", "", false)
+
+		if err := errors.Join(err1, err2, err3); err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
 			return
 		}
@@ -111,6 +198,7 @@ func (h *Handlers) getPersonalizedFeed() http.HandlerFunc {
 			RequesterId: claims.UserId,
 			Limit:       limit,
 			Offset:      offset,
+			Cursor:      cursor,
 		}
 
 		grpcResp, err := h.PostsService.GetPersonalizedFeed(ctx, &grpcReq)
@@ -137,16 +225,20 @@ func (h *Handlers) getPersonalizedFeed() http.HandlerFunc {
 				Audience:        ct.Audience(p.Audience),
 				CommentsCount:   int(p.CommentsCount),
 				ReactionsCount:  int(p.ReactionsCount),
+				SharesCount:     int(p.SharesCount),
+				SharedPost:      sharedPostFromProto(p.SharedPost),
 				LastCommentedAt: ct.GenDateTime(p.LastCommentedAt.AsTime()),
 				CreatedAt:       ct.GenDateTime(p.CreatedAt.AsTime()),
 				UpdatedAt:       ct.GenDateTime(p.UpdatedAt.AsTime()),
 				LikedByUser:     p.LikedByUser,
 				ImageId:         ct.Id(p.ImageId),
 				ImageUrl:        p.ImageUrl,
+				Poll:            pollFromProto(p.Poll),
 			}
 			postsResponse = append(postsResponse, post)
 		}
 
+		utils.SetNextCursor(w, grpcResp.NextCursor)
 		err = utils.WriteJSON(ctx, w, http.StatusOK, postsResponse)
 		if err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "failed to send public feed")
@@ -169,8 +261,9 @@ func (h *Handlers) getUserPostsPaginated() http.HandlerFunc {
 		v := r.URL.Query()
 		creatorId, err1 := utils.PathValueGet(r, "user_id", ct.Id(0), true)
 		limit, err2 := utils.ParamGet(v, "limit", int32(1), false)
-		offset, err3 := utils.ParamGet(v, "offset", int32(0), false)
-		if err := errors.Join(err1, err2, err3); err != nil {
+		offset, err3 := utils.ParamGet(v, "offset", int32(0), false) // deprecated, use cursor
+		cursor, err4 := utils.ParamGet(v, "cursor", "", false)
+		if err := errors.Join(err1, err2, err3, err4); err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
 			return
 		}
@@ -180,6 +273,7 @@ func (h *Handlers) getUserPostsPaginated() http.HandlerFunc {
 			CreatorId:   creatorId.Int64(),
 			Limit:       limit,
 			Offset:      offset,
+			Cursor:      cursor,
 		}
 
 		grpcResp, err := h.PostsService.GetUserPostsPaginated(ctx, &grpcReq)
@@ -206,16 +300,20 @@ func (h *Handlers) getUserPostsPaginated() http.HandlerFunc {
 				Audience:        ct.Audience(p.Audience),
 				CommentsCount:   int(p.CommentsCount),
 				ReactionsCount:  int(p.ReactionsCount),
+				SharesCount:     int(p.SharesCount),
+				SharedPost:      sharedPostFromProto(p.SharedPost),
 				LastCommentedAt: ct.GenDateTime(p.LastCommentedAt.AsTime()),
 				CreatedAt:       ct.GenDateTime(p.CreatedAt.AsTime()),
 				UpdatedAt:       ct.GenDateTime(p.UpdatedAt.AsTime()),
 				LikedByUser:     p.LikedByUser,
 				ImageId:         ct.Id(p.ImageId),
 				ImageUrl:        p.ImageUrl,
+				Poll:            pollFromProto(p.Poll),
 			}
 			postsResponse = append(postsResponse, post)
 		}
 
+		utils.SetNextCursor(w, grpcResp.NextCursor)
 		err = utils.WriteJSON(ctx, w, http.StatusOK, postsResponse)
 		if err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, fmt.Sprintf("failed to send user %v posts: %v", creatorId, err.Error()))
@@ -238,8 +336,10 @@ func (h *Handlers) getGroupPostsPaginated() http.HandlerFunc {
 		v := r.URL.Query()
 		groupId, err1 := utils.PathValueGet(r, "group_id", ct.Id(0), true)
 		limit, err2 := utils.ParamGet(v, "limit", int32(1), false)
-		offset, err3 := utils.ParamGet(v, "offset", int32(0), false)
-		if err := errors.Join(err1, err2, err3); err != nil {
+		offset, err3 := utils.ParamGet(v, "offset", int32(0), false) // deprecated, use cursor
+		cursor, err4 := utils.ParamGet(v, "cursor", "", false)
+		sort, err5 := utils.ParamGet(v, "sort", "", false) // "recent" or "top"
+		if err := errors.Join(err1, err2, err3, err4, err5); err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
 			return
 		}
@@ -249,6 +349,8 @@ func (h *Handlers) getGroupPostsPaginated() http.HandlerFunc {
 			GroupId:     groupId.Int64(),
 			Limit:       limit,
 			Offset:      offset,
+			Cursor:      cursor,
+			Sort:        sort,
 		}
 
 		grpcResp, err := h.PostsService.GetGroupPostsPaginated(ctx, &grpcReq)
@@ -275,16 +377,20 @@ func (h *Handlers) getGroupPostsPaginated() http.HandlerFunc {
 				Audience:        ct.Audience(p.Audience),
 				CommentsCount:   int(p.CommentsCount),
 				ReactionsCount:  int(p.ReactionsCount),
+				SharesCount:     int(p.SharesCount),
+				SharedPost:      sharedPostFromProto(p.SharedPost),
 				LastCommentedAt: ct.GenDateTime(p.LastCommentedAt.AsTime()),
 				CreatedAt:       ct.GenDateTime(p.CreatedAt.AsTime()),
 				UpdatedAt:       ct.GenDateTime(p.UpdatedAt.AsTime()),
 				LikedByUser:     p.LikedByUser,
 				ImageId:         ct.Id(p.ImageId),
 				ImageUrl:        p.ImageUrl,
+				Poll:            pollFromProto(p.Poll),
 			}
 			postsResponse = append(postsResponse, post)
 		}
 
+		utils.SetNextCursor(w, grpcResp.NextCursor)
 		err = utils.WriteJSON(ctx, w, http.StatusOK, postsResponse)
 		if err != nil {
 			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, fmt.Sprintf("failed to send group %v posts: %v", groupId, err.Error()))
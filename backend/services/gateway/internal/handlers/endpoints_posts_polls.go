@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"social-network/shared/gen-go/posts"
+	ct "social-network/shared/go/ct"
+	utils "social-network/shared/go/http-utils"
+	"social-network/shared/go/jwt"
+	"social-network/shared/go/models"
+	tele "social-network/shared/go/telemetry"
+)
+
+func (h *Handlers) votePoll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tele.Info(ctx, "votePoll handler called")
+
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			panic(1)
+		}
+
+		type VotePollJSONRequest struct {
+			PollId    ct.Id
+			OptionIds ct.Ids `json:"option_ids"`
+		}
+
+		httpReq := VotePollJSONRequest{}
+
+		decoder := json.NewDecoder(r.Body)
+		defer r.Body.Close()
+		if err := decoder.Decode(&httpReq); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var err error
+		httpReq.PollId, err = utils.PathValueGet(r, "poll_id", ct.Id(0), true)
+		if err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		if err := ct.ValidateStruct(httpReq); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		grpcReq := posts.VotePollReq{
+			RequesterId: int64(claims.UserId),
+			PollId:      httpReq.PollId.Int64(),
+			OptionIds:   httpReq.OptionIds.Int64(),
+		}
+
+		_, err = h.PostsService.VotePoll(ctx, &grpcReq)
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		tele.Info(ctx, "voted on poll successfully")
+	}
+}
+
+func createPollReqToProto(p *models.CreatePollReq) *posts.CreatePollReq {
+	if p == nil {
+		return nil
+	}
+	return &posts.CreatePollReq{
+		Question:       p.Question.String(),
+		Options:        p.Options,
+		MultipleChoice: p.MultipleChoice,
+		ClosesAt:       p.ClosesAt.ToProto(),
+	}
+}
+
+func pollFromProto(p *posts.Poll) *models.Poll {
+	if p == nil {
+		return nil
+	}
+	options := make([]models.PollOption, 0, len(p.Options))
+	for _, o := range p.Options {
+		options = append(options, models.PollOption{
+			OptionId:    ct.Id(o.OptionId),
+			Text:        o.Text,
+			VotesCount:  int(o.VotesCount),
+			VotedByUser: o.VotedByUser,
+		})
+	}
+	var closesAt ct.GenDateTime
+	if p.ClosesAt != nil {
+		closesAt = ct.GenDateTime(p.ClosesAt.AsTime())
+	}
+	return &models.Poll{
+		PollId:         ct.Id(p.PollId),
+		Question:       ct.PollQuestion(p.Question),
+		Options:        options,
+		MultipleChoice: p.MultipleChoice,
+		ClosesAt:       closesAt,
+		Closed:         p.Closed,
+		VotersCount:    int(p.VotersCount),
+		ResultsVisible: p.ResultsVisible,
+	}
+}
@@ -68,13 +68,18 @@ func (h *Handlers) getPostById() http.HandlerFunc {
 			Audience:              ct.Audience(grpcResp.Audience),
 			CommentsCount:         int(grpcResp.CommentsCount),
 			ReactionsCount:        int(grpcResp.ReactionsCount),
+			SharesCount:           int(grpcResp.SharesCount),
+			SharedPost:            sharedPostFromProto(grpcResp.SharedPost),
 			LastCommentedAt:       ct.GenDateTime(grpcResp.LastCommentedAt.AsTime()),
 			CreatedAt:             ct.GenDateTime(grpcResp.CreatedAt.AsTime()),
 			UpdatedAt:             ct.GenDateTime(grpcResp.UpdatedAt.AsTime()),
 			LikedByUser:           grpcResp.LikedByUser,
 			ImageId:               ct.Id(grpcResp.ImageId),
 			ImageUrl:              grpcResp.ImageUrl,
+			Poll:                  pollFromProto(grpcResp.Poll),
 			SelectedAudienceUsers: selectedAudience,
+			Status:                ct.PostStatus(grpcResp.Status),
+			PublishAt:             ct.GenDateTime(grpcResp.PublishAt.AsTime()),
 		}
 
 		err = utils.WriteJSON(ctx, w, http.StatusOK, post)
@@ -127,12 +132,15 @@ func (h *Handlers) getMostPopularPostInGroup() http.HandlerFunc {
 				Audience:        ct.Audience(grpcResp.Audience),
 				CommentsCount:   int(grpcResp.CommentsCount),
 				ReactionsCount:  int(grpcResp.ReactionsCount),
+				SharesCount:     int(grpcResp.SharesCount),
+				SharedPost:      sharedPostFromProto(grpcResp.SharedPost),
 				LastCommentedAt: ct.GenDateTime(grpcResp.LastCommentedAt.AsTime()),
 				CreatedAt:       ct.GenDateTime(grpcResp.CreatedAt.AsTime()),
 				UpdatedAt:       ct.GenDateTime(grpcResp.UpdatedAt.AsTime()),
 				LikedByUser:     grpcResp.LikedByUser,
 				ImageId:         ct.Id(grpcResp.ImageId),
 				ImageUrl:        grpcResp.ImageUrl,
+				Poll:            pollFromProto(grpcResp.Poll),
 			}
 		}
 
@@ -161,6 +169,11 @@ func (h *Handlers) createPost() http.HandlerFunc {
 			Audience    ct.Audience `json:"audience"`
 			AudienceIds ct.Ids      `json:"audience_ids" validate:"nullable"`
 
+			Status    ct.PostStatus  `json:"status" validate:"nullable"`
+			PublishAt ct.GenDateTime `json:"publish_at" validate:"nullable"`
+
+			Poll *models.CreatePollReq `json:"poll"`
+
 			ImageName string `json:"image_name"`
 			ImageSize int64  `json:"image_size"`
 			ImageType string `json:"image_type"`
@@ -179,6 +192,12 @@ func (h *Handlers) createPost() http.HandlerFunc {
 			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
 			return
 		}
+		if httpReq.Poll != nil {
+			if err := ct.ValidateStruct(*httpReq.Poll); err != nil {
+				utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
 
 		imageVisibility := media.FileVisibility_PUBLIC
 		if httpReq.Audience.String() != "everyone" {
@@ -196,6 +215,7 @@ func (h *Handlers) createPost() http.HandlerFunc {
 				Visibility:        imageVisibility,
 				Variants:          []media.FileVariant{media.FileVariant_MEDIUM},
 				ExpirationSeconds: int64(exp),
+				OwnerId:           int64(claims.UserId),
 			})
 			if err != nil {
 				utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
@@ -213,7 +233,10 @@ func (h *Handlers) createPost() http.HandlerFunc {
 			AudienceIds: &common.UserIds{
 				Values: httpReq.AudienceIds.Int64(),
 			},
-			ImageId: ImageId.Int64(),
+			ImageId:   ImageId.Int64(),
+			Status:    httpReq.Status.String(),
+			PublishAt: httpReq.PublishAt.ToProto(),
+			Poll:      createPollReqToProto(httpReq.Poll),
 		}
 
 		postId, err := h.PostsService.CreatePost(ctx, &grpcReq)
@@ -298,6 +321,7 @@ func (h *Handlers) editPost() http.HandlerFunc {
 				Visibility:        imageVisibility,
 				Variants:          []media.FileVariant{media.FileVariant_MEDIUM},
 				ExpirationSeconds: int64(exp),
+				OwnerId:           int64(claims.UserId),
 			})
 			if err != nil {
 				utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"social-network/shared/gen-go/posts"
+	ct "social-network/shared/go/ct"
+	utils "social-network/shared/go/http-utils"
+	"social-network/shared/go/jwt"
+	"social-network/shared/go/models"
+	tele "social-network/shared/go/telemetry"
+)
+
+func (h *Handlers) getEntityRevisions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tele.Info(ctx, "getEntityRevisions handler called")
+
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			panic(1)
+		}
+
+		v := r.URL.Query()
+		entityId, err1 := utils.PathValueGet(r, "entity_id", ct.Id(0), true)
+		limit, err2 := utils.ParamGet(v, "limit", int32(1), false)
+		offset, err3 := utils.ParamGet(v, "offset", int32(0), false)
+		if err := errors.Join(err1, err2, err3); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		grpcReq := posts.EntityIdPaginatedReq{
+			RequesterId: claims.UserId,
+			EntityId:    entityId.Int64(),
+			Limit:       limit,
+			Offset:      offset,
+		}
+
+		grpcResp, err := h.PostsService.GetEntityRevisions(ctx, &grpcReq)
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		tele.Info(ctx, "retrieved entity revisions. @1", "grpcResp", grpcResp)
+
+		revisions := make([]models.Revision, 0, len(grpcResp.Revisions))
+		for _, rev := range grpcResp.Revisions {
+			selectedAudience := make([]models.User, 0, len(rev.SelectedAudienceUsers.GetUsers()))
+			for _, u := range rev.SelectedAudienceUsers.GetUsers() {
+				selectedAudience = append(selectedAudience, models.User{
+					UserId:    ct.Id(u.UserId),
+					Username:  ct.Username(u.Username),
+					AvatarId:  ct.Id(u.Avatar),
+					AvatarURL: u.AvatarUrl,
+				})
+			}
+
+			revision := models.Revision{
+				RevisionId:  ct.Id(rev.RevisionId),
+				EntityId:    ct.Id(rev.EntityId),
+				ContentType: rev.ContentType,
+				Editor: models.User{
+					UserId:    ct.Id(rev.Editor.UserId),
+					Username:  ct.Username(rev.Editor.Username),
+					AvatarId:  ct.Id(rev.Editor.Avatar),
+					AvatarURL: rev.Editor.AvatarUrl,
+				},
+				Title:                 ct.Title(rev.Title),
+				Body:                  rev.Body,
+				ImageIds:              ct.FromInt64s(rev.ImageIds),
+				ImageUrls:             rev.ImageUrls,
+				Audience:              ct.Audience(rev.Audience),
+				SelectedAudienceUsers: selectedAudience,
+				CreatedAt:             ct.GenDateTime(rev.CreatedAt.AsTime()),
+			}
+			if rev.EventDate != nil {
+				revision.EventDate = ct.GenDateTime(rev.EventDate.AsTime())
+			}
+			revisions = append(revisions, revision)
+		}
+
+		err = utils.WriteJSON(ctx, w, http.StatusOK, revisions)
+		if err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "failed to send entity revisions")
+			return
+		}
+	}
+}
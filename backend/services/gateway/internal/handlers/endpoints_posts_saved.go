@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"social-network/shared/gen-go/posts"
+	ct "social-network/shared/go/ct"
+	utils "social-network/shared/go/http-utils"
+	"social-network/shared/go/jwt"
+	"social-network/shared/go/models"
+	tele "social-network/shared/go/telemetry"
+)
+
+func (h *Handlers) saveEntity() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tele.Info(ctx, "saveEntity handler called")
+
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			panic(1)
+		}
+
+		type SaveEntityJSONRequest struct {
+			EntityId   ct.Id
+			Collection ct.Title `json:"collection" validate:"nullable"`
+		}
+
+		httpReq := SaveEntityJSONRequest{}
+
+		// the body is optional, it only carries the collection name
+		decoder := json.NewDecoder(r.Body)
+		defer r.Body.Close()
+		if err := decoder.Decode(&httpReq); err != nil && !errors.Is(err, io.EOF) {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var err error
+		httpReq.EntityId, err = utils.PathValueGet(r, "entity_id", ct.Id(0), true)
+		if err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		if err := ct.ValidateStruct(httpReq); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		grpcReq := posts.SaveEntityReq{
+			RequesterId: int64(claims.UserId),
+			EntityId:    httpReq.EntityId.Int64(),
+			Collection:  httpReq.Collection.String(),
+		}
+
+		_, err = h.PostsService.SaveEntity(ctx, &grpcReq)
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		tele.Info(ctx, "saved entity successfully")
+	}
+}
+
+func (h *Handlers) unsaveEntity() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tele.Info(ctx, "unsaveEntity handler called")
+
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			panic(1)
+		}
+
+		entityId, err := utils.PathValueGet(r, "entity_id", ct.Id(0), true)
+		if err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		grpcReq := posts.GenericReq{
+			RequesterId: int64(claims.UserId),
+			EntityId:    entityId.Int64(),
+		}
+
+		_, err = h.PostsService.UnsaveEntity(ctx, &grpcReq)
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		tele.Info(ctx, "unsaved entity successfully")
+	}
+}
+
+func (h *Handlers) listSaved() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tele.Info(ctx, "listSaved handler called")
+
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			panic(1)
+		}
+
+		v := r.URL.Query()
+		limit, err1 := utils.ParamGet(v, "limit", int32(1), false)
+		offset, err2 := utils.ParamGet(v, "offset", int32(0), false)
+		collection, err3 := utils.ParamGet(v, "collection", "", false)
+		if err := errors.Join(err1, err2, err3); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		grpcReq := posts.ListSavedReq{
+			RequesterId: claims.UserId,
+			Collection:  collection,
+			Limit:       limit,
+			Offset:      offset,
+		}
+
+		grpcResp, err := h.PostsService.ListSaved(ctx, &grpcReq)
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		tele.Info(ctx, "retrieved saved posts. @1", "grpcResp", grpcResp)
+
+		postsResponse := []models.Post{}
+		for _, p := range grpcResp.Posts {
+			postsResponse = append(postsResponse, models.Post{
+				PostId: ct.Id(p.PostId),
+				Body:   ct.PostBody(p.PostBody),
+				User: models.User{
+					UserId:    ct.Id(p.User.UserId),
+					Username:  ct.Username(p.User.Username),
+					AvatarId:  ct.Id(p.User.Avatar),
+					AvatarURL: p.User.AvatarUrl,
+				},
+				GroupId:         ct.Id(p.GroupId),
+				Audience:        ct.Audience(p.Audience),
+				CommentsCount:   int(p.CommentsCount),
+				ReactionsCount:  int(p.ReactionsCount),
+				SharesCount:     int(p.SharesCount),
+				SharedPost:      sharedPostFromProto(p.SharedPost),
+				LastCommentedAt: ct.GenDateTime(p.LastCommentedAt.AsTime()),
+				CreatedAt:       ct.GenDateTime(p.CreatedAt.AsTime()),
+				UpdatedAt:       ct.GenDateTime(p.UpdatedAt.AsTime()),
+				LikedByUser:     p.LikedByUser,
+				ImageId:         ct.Id(p.ImageId),
+				ImageUrl:        p.ImageUrl,
+				Poll:            pollFromProto(p.Poll),
+			})
+		}
+
+		err = utils.WriteJSON(ctx, w, http.StatusOK, postsResponse)
+		if err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "failed to send saved posts")
+			return
+		}
+	}
+}
+
+func (h *Handlers) getSavedCollections() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tele.Info(ctx, "getSavedCollections handler called")
+
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			panic(1)
+		}
+
+		grpcResp, err := h.PostsService.GetSavedCollections(ctx, &posts.SimpleIdReq{Id: claims.UserId})
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		collections := make([]models.SavedCollection, 0, len(grpcResp.Collections))
+		for _, c := range grpcResp.Collections {
+			collections = append(collections, models.SavedCollection{
+				Name:       ct.Title(c.Name),
+				SavedCount: int(c.SavedCount),
+			})
+		}
+
+		err = utils.WriteJSON(ctx, w, http.StatusOK, collections)
+		if err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusInternalServerError, "failed to send saved collections")
+			return
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"social-network/shared/gen-go/common"
+	"social-network/shared/gen-go/posts"
+	ct "social-network/shared/go/ct"
+	utils "social-network/shared/go/http-utils"
+	"social-network/shared/go/jwt"
+	"social-network/shared/go/models"
+	tele "social-network/shared/go/telemetry"
+)
+
+func (h *Handlers) sharePost() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tele.Info(ctx, "sharePost handler called")
+
+		claims, ok := utils.GetValue[jwt.Claims](r, ct.ClaimsKey)
+		if !ok {
+			panic(1)
+		}
+
+		type SharePostJSONRequest struct {
+			PostId      ct.Id
+			Body        ct.PostBody `json:"post_body" validate:"nullable"`
+			GroupId     ct.Id       `json:"group_id" validate:"nullable"`
+			Audience    ct.Audience `json:"audience"`
+			AudienceIds ct.Ids      `json:"audience_ids" validate:"nullable"`
+		}
+
+		httpReq := SharePostJSONRequest{}
+
+		decoder := json.NewDecoder(r.Body)
+		defer r.Body.Close()
+		if err := decoder.Decode(&httpReq); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var err error
+		httpReq.PostId, err = utils.PathValueGet(r, "post_id", ct.Id(0), true)
+		if err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, "bad url params: "+err.Error())
+			return
+		}
+
+		if err := ct.ValidateStruct(httpReq); err != nil {
+			utils.ErrorJSON(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		grpcReq := posts.SharePostReq{
+			RequesterId: int64(claims.UserId),
+			PostId:      httpReq.PostId.Int64(),
+			Body:        httpReq.Body.String(),
+			GroupId:     httpReq.GroupId.Int64(),
+			Audience:    httpReq.Audience.String(),
+			AudienceIds: &common.UserIds{
+				Values: httpReq.AudienceIds.Int64(),
+			},
+		}
+
+		shareId, err := h.PostsService.SharePost(ctx, &grpcReq)
+		if err != nil {
+			utils.ReturnHttpError(ctx, w, err)
+			return
+		}
+
+		type httpResponse struct {
+			PostId ct.Id
+		}
+		tele.Info(ctx, "shared post successfully")
+		utils.WriteJSON(ctx, w, http.StatusOK, httpResponse{PostId: ct.Id(shareId.Id)})
+	}
+}
+
+func sharedPostFromProto(p *posts.SharedPost) *models.SharedPost {
+	if p == nil {
+		return nil
+	}
+	if !p.Available {
+		return &models.SharedPost{PostId: ct.Id(p.PostId)}
+	}
+	return &models.SharedPost{
+		PostId:    ct.Id(p.PostId),
+		Available: true,
+		Body:      ct.PostBody(p.PostBody),
+		User: models.User{
+			UserId:    ct.Id(p.User.GetUserId()),
+			Username:  ct.Username(p.User.GetUsername()),
+			AvatarId:  ct.Id(p.User.GetAvatar()),
+			AvatarURL: p.User.GetAvatarUrl(),
+		},
+		GroupId:   ct.Id(p.GroupId),
+		Audience:  ct.Audience(p.Audience),
+		CreatedAt: ct.GenDateTime(p.CreatedAt.AsTime()),
+		UpdatedAt: ct.GenDateTime(p.UpdatedAt.AsTime()),
+		ImageId:   ct.Id(p.ImageId),
+		ImageUrl:  p.ImageUrl,
+	}
+}
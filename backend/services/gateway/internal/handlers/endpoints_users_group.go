@@ -58,6 +58,7 @@ func (s *Handlers) createGroup() http.HandlerFunc {
 				Visibility:        media.FileVisibility_PUBLIC,
 				Variants:          []media.FileVariant{media.FileVariant_SMALL},
 				ExpirationSeconds: int64(exp),
+				OwnerId:           int64(claims.UserId),
 			})
 			if err != nil {
 				utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
@@ -153,6 +154,7 @@ func (s *Handlers) updateGroup() http.HandlerFunc {
 				Visibility:        media.FileVisibility_PUBLIC,
 				Variants:          []media.FileVariant{media.FileVariant_SMALL},
 				ExpirationSeconds: int64(exp),
+				OwnerId:           int64(claims.UserId),
 			})
 			if err != nil {
 				utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
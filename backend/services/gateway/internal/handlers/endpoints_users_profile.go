@@ -230,6 +230,7 @@ func (s *Handlers) updateUserProfile() http.HandlerFunc {
 				Visibility:        media.FileVisibility_PUBLIC,
 				Variants:          []media.FileVariant{media.FileVariant_THUMBNAIL},
 				ExpirationSeconds: int64(exp),
+				OwnerId:           int64(claims.UserId),
 			})
 			if err != nil {
 				utils.ErrorJSON(ctx, w, http.StatusInternalServerError, err.Error())
@@ -85,6 +85,14 @@ func (h *Handlers) BuildMux(serviceName string) *http.ServeMux {
 		RateLimit(USERID, 5, 5).
 		Finalize(h.validateFileUpload())
 
+	SetEndpoint("/files/videos").
+		AllowedMethod("POST").
+		RateLimit(IP, 5, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 5, 5).
+		Finalize(h.uploadVideo())
+
 	//image id variant from url --DONE
 
 	SetEndpoint("/files/images/{image_id}/{variant}").
@@ -95,6 +103,28 @@ func (h *Handlers) BuildMux(serviceName string) *http.ServeMux {
 		RateLimit(USERID, 5, 5).
 		Finalize(h.getImageUrl())
 
+	SetEndpoint("/files/images/{image_id}/transform/sign").
+		AllowedMethod("GET").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 5, 5).
+		Finalize(h.signImageTransform())
+
+	// stable urls of public files, cacheable by browsers and CDNs
+	SetEndpoint("/files/public/{file_id}/{variant}").
+		AllowedMethod("GET").
+		RateLimit(IP, 100, 5).
+		EnrichContext().
+		Finalize(h.getPublicFile())
+
+	// signed, shareable without auth
+	SetEndpoint("/files/images/{image_id}/transform").
+		AllowedMethod("GET").
+		RateLimit(IP, 20, 5).
+		EnrichContext().
+		Finalize(h.getImageTransform())
+
 	SetEndpoint("/logout").
 		AllowedMethod("POST").
 		RateLimit(IP, 20, 5).
@@ -490,6 +520,14 @@ func (h *Handlers) BuildMux(serviceName string) *http.ServeMux {
 		RateLimit(USERID, 20, 5).
 		Finalize(h.getPersonalizedFeed())
 
+	SetEndpoint("/posts/home").
+		AllowedMethod("GET").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.getHomeTimeline())
+
 		//params, postsid url --DONE
 
 	SetEndpoint("/posts/{post_id}").
@@ -530,6 +568,70 @@ func (h *Handlers) BuildMux(serviceName string) *http.ServeMux {
 		RateLimit(USERID, 20, 5).
 		Finalize(h.deletePost())
 
+	SetEndpoint("/posts/{post_id}/status").
+		AllowedMethod("POST").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.updatePostStatus())
+
+	SetEndpoint("/my/drafts").
+		AllowedMethod("GET").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.getUserDrafts())
+
+	SetEndpoint("/polls/{poll_id}/vote").
+		AllowedMethod("POST").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.votePoll())
+
+	SetEndpoint("/posts/{post_id}/share").
+		AllowedMethod("POST").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.sharePost())
+
+	SetEndpoint("/saved").
+		AllowedMethod("GET").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.listSaved())
+
+	SetEndpoint("/saved/collections").
+		AllowedMethod("GET").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.getSavedCollections())
+
+	SetEndpoint("/saved/{entity_id}").
+		AllowedMethod("POST").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.saveEntity())
+
+	SetEndpoint("/saved/{entity_id}").
+		AllowedMethod("DELETE").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.unsaveEntity())
+
 		// COMMENTS ===================
 		// COMMENTS ===================
 		// COMMENTS ===================
@@ -635,6 +737,14 @@ func (h *Handlers) BuildMux(serviceName string) *http.ServeMux {
 		RateLimit(USERID, 20, 5).
 		Finalize(h.getWhoLikedEntityId())
 
+	SetEndpoint("/revisions/{entity_id}").
+		AllowedMethod("GET").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.getEntityRevisions())
+
 		// NOTIFICATIONS =====================
 		// NOTIFICATIONS =====================
 		// NOTIFICATIONS =====================
@@ -678,6 +788,14 @@ func (h *Handlers) BuildMux(serviceName string) *http.ServeMux {
 		RateLimit(USERID, 20, 5).
 		Finalize(h.MarkNotificationAsRead())
 
+	SetEndpoint("/notifications/mark-category").
+		AllowedMethod("POST").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.MarkCategoryAsRead())
+
 		//params
 
 	SetEndpoint("/notifications/{notification_id}").
@@ -688,6 +806,76 @@ func (h *Handlers) BuildMux(serviceName string) *http.ServeMux {
 		RateLimit(USERID, 20, 5).
 		Finalize(h.DeleteNotification())
 
+	SetEndpoint("/notifications/preferences").
+		AllowedMethod("GET").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.GetNotificationPreferences())
+
+	SetEndpoint("/notifications/preferences").
+		AllowedMethod("PUT").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.UpdateNotificationPreferences())
+
+	SetEndpoint("/notifications/mutes").
+		AllowedMethod("POST").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.MuteNotifications())
+
+	SetEndpoint("/notifications/mutes/{target_type}/{target_id}").
+		AllowedMethod("DELETE").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.UnmuteNotifications())
+
+	SetEndpoint("/notifications/push/key").
+		AllowedMethod("GET").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.GetWebPushPublicKey())
+
+	SetEndpoint("/notifications/push/subscriptions").
+		AllowedMethod("POST").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.RegisterPushSubscription())
+
+	SetEndpoint("/notifications/push/subscriptions").
+		AllowedMethod("DELETE").
+		RateLimit(IP, 20, 5).
+		Auth().
+		EnrichContext().
+		RateLimit(USERID, 20, 5).
+		Finalize(h.UnregisterPushSubscription())
+
+	// linked from emails, the signed token replaces auth
+	// POST is the one click unsubscribe of mail clients
+	SetEndpoint("/notifications/unsubscribe").
+		AllowedMethod("GET").
+		RateLimit(IP, 20, 5).
+		EnrichContext().
+		Finalize(h.UnsubscribeEmail())
+
+	SetEndpoint("/notifications/unsubscribe").
+		AllowedMethod("POST").
+		RateLimit(IP, 20, 5).
+		EnrichContext().
+		Finalize(h.UnsubscribeEmail())
+
 		// CHAT ============================================
 		// CHAT ============================================
 		// CHAT ============================================
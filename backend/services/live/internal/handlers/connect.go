@@ -87,10 +87,14 @@ func (h *Handlers) Connect() http.HandlerFunc {
 			tele.Info(ctx, "forwarded nats message to websocket @1", "connection", connectionId)
 		}
 
+		presenceCtx, stopPresence := context.WithCancel(ctx)
+
 		var wg sync.WaitGroup
 		wg.Go(func() { h.websocketSender(ctx, wsChannel, websocketConn) })
+		wg.Go(func() { h.keepPresence(presenceCtx, clientId, connectionId) })
 		h.websocketListener(ctx, websocketConn, clientId, connectionId, natsHandler, wsChannel)
 
+		stopPresence()
 		wg.Wait()
 
 		tele.Info(ctx, "ws handler closing")
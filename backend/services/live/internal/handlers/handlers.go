@@ -24,6 +24,8 @@ type CacheService interface {
 	SetObj(ctx context.Context, key string, value any, exp time.Duration) error
 	GetObj(ctx context.Context, key string, dest any) error
 	Del(ctx context.Context, key string) error
+	ZAddCapped(ctx context.Context, keys []string, score float64, member string, maxLen int64, exp time.Duration) error
+	ZRem(ctx context.Context, key string, members ...string) error
 }
 
 func NewHandlers(serviceName string, CacheService CacheService, nats *nats.Conn, ChatService chat.ChatServiceClient) *http.ServeMux {
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"social-network/shared/go/ct"
+	tele "social-network/shared/go/telemetry"
+	"time"
+)
+
+const (
+	// presenceRefresh is how often an open connection renews its presence
+	presenceRefresh = 30 * time.Second
+	// presenceTTL is how long a connection counts as online without renewal, covers a replica dying
+	presenceTTL = 3 * presenceRefresh
+	// maxPresenceConnections caps the connections tracked per user
+	maxPresenceConnections = 20
+)
+
+// keepPresence marks the user online for as long as ctx lives, then removes the connection.
+// Other services, like notifications, read the presence set to tell who is connected.
+func (h *Handlers) keepPresence(ctx context.Context, userId int64, connectionId string) {
+	defer catchPanic(ctx, "presence")
+	key, err := ct.PresenceKey{UserId: ct.Id(userId)}.GenKey()
+	if err != nil {
+		tele.Error(ctx, "presence key for @1: @2", "userId", userId, "error", err.Error())
+		return
+	}
+
+	refresh := func() {
+		expires := float64(time.Now().Add(presenceTTL).Unix())
+		if err := h.CacheService.ZAddCapped(ctx, []string{key}, expires, connectionId, maxPresenceConnections, 2*presenceTTL); err != nil {
+			tele.Warn(ctx, "failed to refresh presence of @1: @2", "userId", userId, "error", err.Error())
+		}
+	}
+
+	refresh()
+	ticker := time.NewTicker(presenceRefresh)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			refresh()
+		case <-ctx.Done():
+			// the request context is done too, remove with a fresh one
+			cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+			defer cancel()
+			if err := h.CacheService.ZRem(cleanupCtx, key, connectionId); err != nil {
+				tele.Warn(ctx, "failed to clear presence of @1: @2", "userId", userId, "error", err.Error())
+			}
+			return
+		}
+	}
+}
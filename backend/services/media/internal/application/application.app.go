@@ -2,11 +2,13 @@ package application
 
 import (
 	"context"
+	"crypto/rand"
 	"social-network/services/media/internal/client"
 	"social-network/services/media/internal/configs"
 	"social-network/services/media/internal/db/dbservice"
 
 	postgresql "social-network/shared/go/postgre"
+	tele "social-network/shared/go/telemetry"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -20,9 +22,16 @@ type TxRunner interface {
 type MediaService struct {
 	Pool     *pgxpool.Pool
 	S3       S3Service
+	Access   EntityAccess
+	Refs     FileReferences
 	Queries  dbservice.Querier
 	txRunner TxRunner
 	Cfgs     configs.Config
+	metrics  gcMetrics
+
+	scanMetrics scanMetrics
+
+	transformKey []byte // signs transform urls
 }
 
 func NewMediaService(
@@ -43,11 +52,33 @@ func NewMediaService(
 			return nil, err
 		}
 	}
+	metrics, err := newGCMetrics()
+	if err != nil {
+		return nil, err
+	}
+	scanMetrics, err := newScanMetrics()
+	if err != nil {
+		return nil, err
+	}
+	transformKey := []byte(cfgs.FileService.Transforms.SigningKey)
+	if len(transformKey) == 0 {
+		transformKey = make([]byte, 32)
+		if _, err := rand.Read(transformKey); err != nil {
+			return nil, err
+		}
+		tele.Warn(context.Background(), "TRANSFORM_SIGNING_KEY not set, transform urls signed by this instance are only valid on it")
+	}
 	return &MediaService{
 		Pool:     pool,
 		S3:       clients,
+		Access:   clients,
+		Refs:     clients,
 		Queries:  queries,
 		txRunner: txRunner,
 		Cfgs:     cfgs,
+		metrics:  metrics,
+
+		scanMetrics:  scanMetrics,
+		transformKey: transformKey,
 	}, nil
 }
@@ -0,0 +1,147 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"social-network/services/media/internal/db/dbservice"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	tele "social-network/shared/go/telemetry"
+)
+
+type AttachFileReq struct {
+	FileId   ct.Id
+	OwnerId  ct.Id // owner of the entity
+	Type     ct.AttachmentType
+	EntityId ct.Id
+}
+
+// Records that a file is used by an entity. A file without owner is claimed by
+// the owner of the entity, a file of another owner can't be attached.
+func (m *MediaService) AttachFile(ctx context.Context, req AttachFileReq) error {
+	input := fmt.Sprintf("req: %#v", req)
+
+	if err := ct.ValidateBatch(req.FileId, req.OwnerId, req.Type, req.EntityId); err != nil {
+		return ce.Wrap(ce.ErrInvalidArgument, err, input)
+	}
+
+	err := m.txRunner.RunTx(ctx, func(tx *dbservice.Queries) error {
+		fm, err := tx.GetFileById(ctx, req.FileId)
+		if err != nil {
+			return mapDBError(err)
+		}
+
+		if err := checkOwner(fm, req.OwnerId); err != nil {
+			return err
+		}
+
+		if fm.OwnerId == 0 {
+			if err := tx.ClaimFile(ctx, req.FileId, req.OwnerId); err != nil {
+				return mapDBError(err)
+			}
+		}
+
+		if err := tx.CreateAttachment(ctx, dbservice.Attachment{
+			FileId:     req.FileId,
+			EntityType: req.Type,
+			EntityId:   req.EntityId,
+		}); err != nil {
+			return mapDBError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return ce.Wrap(nil, err, input)
+	}
+	return nil
+}
+
+// Removes the attachment of a file to an entity.
+func (m *MediaService) DetachFile(ctx context.Context, req AttachFileReq) error {
+	input := fmt.Sprintf("req: %#v", req)
+
+	if err := ct.ValidateBatch(req.FileId, req.OwnerId, req.Type, req.EntityId); err != nil {
+		return ce.Wrap(ce.ErrInvalidArgument, err, input)
+	}
+
+	fm, err := m.Queries.GetFileById(ctx, req.FileId)
+	if err != nil {
+		return ce.Wrap(nil, mapDBError(err), input)
+	}
+
+	if err := checkOwner(fm, req.OwnerId); err != nil {
+		return ce.Wrap(nil, err, input)
+	}
+
+	if err := m.Queries.DeleteAttachment(ctx, dbservice.Attachment{
+		FileId:     req.FileId,
+		EntityType: req.Type,
+		EntityId:   req.EntityId,
+	}); err != nil {
+		return ce.Wrap(nil, mapDBError(err), input)
+	}
+	return nil
+}
+
+// Returns permission denied if the file belongs to a user other than userId.
+// Files without owner belong to everyone.
+func checkOwner(fm dbservice.File, userId ct.Id) *ce.Error {
+	if fm.OwnerId != 0 && fm.OwnerId != userId {
+		return ce.New(ce.ErrPermissionDenied, ErrPermissionDenied, fm).
+			WithPublic("file belongs to another user")
+	}
+	return nil
+}
+
+// Tells whether requesterId can download a file. Public files can be downloaded by everyone,
+// private ones by their owner and the users that can see an entity they are attached to.
+// A zero requesterId is a trusted service call, the calling service checks the access
+// to its entities itself. The gateway always sets the requester.
+func (m *MediaService) canDownload(
+	ctx context.Context,
+	fm dbservice.File,
+	requesterId ct.Id,
+) (bool, error) {
+
+	if fm.Visibility == ct.Public {
+		return true, nil
+	}
+
+	if requesterId == 0 {
+		return true, nil
+	}
+
+	if fm.OwnerId != 0 && fm.OwnerId == requesterId {
+		return true, nil
+	}
+
+	if m.Access == nil {
+		return false, nil
+	}
+
+	attachments, err := m.Queries.GetFileAttachments(ctx, fm.Id)
+	if err != nil {
+		return false, mapDBError(err)
+	}
+
+	var errs []error
+	for _, a := range attachments {
+		canView, err := m.Access.CanViewEntity(ctx, requesterId, a.EntityType, a.EntityId)
+		if err != nil {
+			tele.Warn(ctx, "failed to check access of @1 to @2 @3: @4",
+				"requesterId", requesterId, "entityType", a.EntityType, "entityId", a.EntityId, "error", err.Error())
+			errs = append(errs, err)
+			continue
+		}
+		if canView {
+			return true, nil
+		}
+	}
+
+	// denied by every entity that could be checked
+	if len(errs) > 0 && len(errs) == len(attachments) {
+		return false, ce.New(ce.ErrInternal, errors.Join(errs...)).WithPublic("internal media error")
+	}
+	return false, nil
+}
@@ -18,6 +18,13 @@ type S3Service interface {
 		expiry time.Duration,
 	) (*url.URL, error)
 
+	GenerateInternalDownloadURL(
+		ctx context.Context,
+		bucket string,
+		objectKey string,
+		expiry time.Duration,
+	) (*url.URL, error)
+
 	GenerateUploadURL(
 		ctx context.Context,
 		bucket string,
@@ -25,6 +32,49 @@ type S3Service interface {
 		expiry time.Duration,
 	) (*url.URL, error)
 
+	NewMultipartUpload(
+		ctx context.Context,
+		bucket string,
+		objectKey string,
+		mimeType string,
+	) (string, error)
+
+	GeneratePartUploadURL(
+		ctx context.Context,
+		bucket string,
+		objectKey string,
+		uploadId string,
+		partNumber int32,
+		expiry time.Duration,
+	) (*url.URL, error)
+
+	ListUploadedParts(
+		ctx context.Context,
+		bucket string,
+		objectKey string,
+		uploadId string,
+	) ([]md.UploadedPart, error)
+
+	CompleteMultipartUpload(
+		ctx context.Context,
+		bucket string,
+		objectKey string,
+		uploadId string,
+		parts []md.UploadedPart,
+	) error
+
+	AbortMultipartUpload(
+		ctx context.Context,
+		bucket string,
+		objectKey string,
+		uploadId string,
+	) error
+
+	ListMultipartUploads(
+		ctx context.Context,
+		bucket string,
+	) ([]md.MultipartInfo, error)
+
 	ValidateUpload(
 		ctx context.Context,
 		upload md.FileMeta,
@@ -34,7 +84,17 @@ type S3Service interface {
 		ctx context.Context,
 		fm md.FileMeta,
 		variants []client.VariantToGenerate,
-	) *ce.Error
+	) (md.SanitizedImage, *ce.Error)
+
+	ValidateVideoUpload(
+		ctx context.Context,
+		fm md.FileMeta,
+	) (hash string, Err *ce.Error)
+
+	ScanUpload(
+		ctx context.Context,
+		fm md.FileMeta,
+	) (md.ScanResult, *ce.Error)
 
 	GenerateVariant(
 		ctx context.Context,
@@ -45,8 +105,54 @@ type S3Service interface {
 		variant ct.FileVariant,
 	) (size int64, err error)
 
+	GenerateTransform(
+		ctx context.Context,
+		srcBucket string,
+		srcObjectKey string,
+		trgBucket string,
+		trgObjectKey string,
+		t md.Transform,
+	) (size int64, err error)
+
+	HashObject(
+		ctx context.Context,
+		bucket string,
+		objectKey string,
+	) (hash string, size int64, err error)
+
+	ListObjects(
+		ctx context.Context,
+		bucket string,
+		pageSize int,
+		fn func([]md.ObjectInfo) error,
+	) error
+
+	StatObject(
+		ctx context.Context,
+		bucket string,
+		objectKey string,
+	) (md.ObjectInfo, error)
+
 	DeleteFile(ctx context.Context,
 		bucket string,
 		objectKey string,
 	) error
 }
+
+// Tells whether a user can see an entity files are attached to.
+type EntityAccess interface {
+	CanViewEntity(
+		ctx context.Context,
+		requesterId ct.Id,
+		entityType ct.AttachmentType,
+		entityId ct.Id,
+	) (bool, error)
+}
+
+// Tells which files are still used by the services storing their ids.
+type FileReferences interface {
+	GetUsedFileIds(
+		ctx context.Context,
+		fileIds ct.Ids,
+	) (ct.Ids, error)
+}
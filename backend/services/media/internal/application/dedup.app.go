@@ -0,0 +1,186 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"social-network/services/media/internal/client"
+	"social-network/services/media/internal/db/dbservice"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	tele "social-network/shared/go/telemetry"
+)
+
+// Looks for a complete file with the same content as the upload fm. If there is one,
+// fm is pointed at its original and variants, it is marked complete without validation
+// as its content already passed it, and the uploaded object is deleted.
+// Variants requested for fm that the existing file lacks are added to it for the worker.
+//
+// Returns the updated file meta and true on a match. Otherwise the content hash of the upload
+// is returned, empty if it could not be read. It is not recorded on fm, the uploader can
+// replace the object before validation reads it again.
+func (m *MediaService) dedupUpload(
+	ctx context.Context,
+	fm dbservice.File,
+) (deduped dbservice.File, ok bool, hash string, err error) {
+	input := fmt.Sprintf("file id: %d", fm.Id)
+
+	hash, size, err := m.S3.HashObject(ctx, fm.Bucket, fm.ObjectKey)
+	if err != nil {
+		if errors.Is(err, ce.ErrInternal) {
+			return fm, false, "", ce.Wrap(nil, err, input)
+		}
+		return fm, false, "", nil // validation reports the missing upload
+	}
+	if size != fm.SizeBytes {
+		return fm, false, hash, nil // validation rejects the size mismatch
+	}
+
+	var canonical dbservice.File
+	errTx := m.txRunner.RunTx(ctx, func(tx *dbservice.Queries) error {
+		var err error
+		canonical, err = tx.GetCanonicalFileByHash(ctx, hash, fm.Id)
+		if err != nil {
+			return err
+		}
+		if isVideo(canonical.MimeType) != isVideo(fm.MimeType) {
+			return sql.ErrNoRows // variants of the other kind could not be generated
+		}
+
+		requested, err := tx.GetAllVariants(ctx, fm.Id)
+		if err != nil {
+			return err
+		}
+		existing, err := tx.GetAllVariants(ctx, canonical.Id)
+		if err != nil {
+			return err
+		}
+		has := make(map[ct.FileVariant]bool, len(existing))
+		for _, v := range existing {
+			has[v.Variant] = true
+		}
+
+		for _, v := range requested {
+			if has[v.Variant] {
+				continue
+			}
+			// canonical is complete, the worker picks processing variants up
+			if _, err := tx.CreateVariant(ctx, dbservice.File{
+				Id:         canonical.Id,
+				Filename:   canonical.Filename,
+				MimeType:   client.VariantMimeType(v.Variant),
+				SizeBytes:  canonical.SizeBytes,
+				Bucket:     m.Cfgs.FileService.Buckets.Variants,
+				ObjectKey:  canonical.ObjectKey + "/" + v.Variant.String(),
+				Visibility: canonical.Visibility,
+				Status:     ct.Processing,
+				Variant:    v.Variant,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.DeleteVariants(ctx, fm.Id); err != nil {
+			return err
+		}
+		return tx.DedupFile(ctx, fm.Id, canonical, hash)
+	})
+
+	if errors.Is(errTx, sql.ErrNoRows) {
+		return fm, false, hash, nil
+	}
+	if errTx != nil {
+		return fm, false, "", ce.Wrap(nil, mapDBError(errTx), input)
+	}
+
+	if err := m.S3.DeleteFile(ctx, fm.Bucket, fm.ObjectKey); err != nil {
+		tele.Warn(ctx, "Failed to delete duplicate upload @1. @2", "fileId", fm.Id, "error", err.Error())
+	}
+
+	deduped = fm
+	deduped.Bucket = canonical.Bucket
+	deduped.ObjectKey = canonical.ObjectKey
+	deduped.Status = ct.Complete
+	return deduped, true, hash, nil
+}
+
+// Deletes a file with its variants and attachments. Only the owner of a file can delete it.
+// The objects of the file are deleted from file service with the last file referencing them.
+func (m *MediaService) DeleteFile(ctx context.Context,
+	fileId ct.Id, requesterId ct.Id) error {
+	input := fmt.Sprintf("file id: %d, requester: %d", fileId, requesterId)
+
+	if err := ct.ValidateBatch(fileId, requesterId); err != nil {
+		return ce.Wrap(ce.ErrInvalidArgument, err, input)
+	}
+
+	var orphans []dbservice.File
+	err := m.txRunner.RunTx(ctx, func(tx *dbservice.Queries) error {
+		fm, err := tx.GetFileById(ctx, fileId)
+		if err != nil {
+			return mapDBError(err)
+		}
+		if fm.OwnerId != requesterId {
+			return ce.New(ce.ErrPermissionDenied, ErrPermissionDenied, fm).
+				WithPublic("only the owner can delete a file")
+		}
+
+		orphans, err = releaseFile(ctx, tx, fm)
+		if err != nil {
+			return mapDBError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return ce.Wrap(nil, err, input)
+	}
+
+	m.deleteObjects(ctx, orphans)
+	return nil
+}
+
+// Deletes the row of a file and returns the objects no other file references anymore.
+// A duplicate only releases its reference, a canonical file still referenced hands
+// its objects over to a duplicate.
+func releaseFile(
+	ctx context.Context,
+	tx *dbservice.Queries,
+	fm dbservice.File,
+) ([]dbservice.File, error) {
+	canonicalId, refCount, err := tx.GetFileRefs(ctx, fm.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if canonicalId != 0 {
+		return nil, tx.ReleaseDuplicate(ctx, fm.Id, canonicalId)
+	}
+
+	if refCount > 1 {
+		_, err := tx.PromoteCanonical(ctx, fm.Id)
+		return nil, err
+	}
+
+	variants, err := tx.GetAllVariants(ctx, fm.Id)
+	if err != nil {
+		return nil, err
+	}
+	transforms, err := tx.GetAllTransforms(ctx, fm.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.DeleteFile(ctx, fm.Id); err != nil {
+		return nil, err
+	}
+	return append(append(variants, transforms...), fm), nil
+}
+
+// Deletes objects from file service. Failures are logged, the rows are already gone.
+func (m *MediaService) deleteObjects(ctx context.Context, objects []dbservice.File) {
+	for _, o := range objects {
+		if err := m.S3.DeleteFile(ctx, o.Bucket, o.ObjectKey); err != nil {
+			tele.Warn(ctx, "Failed to delete object @1 @2. @3", "bucket", o.Bucket, "objectKey", o.ObjectKey, "error", err.Error())
+		}
+	}
+}
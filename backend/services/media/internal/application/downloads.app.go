@@ -0,0 +1,77 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"social-network/services/media/internal/db/dbservice"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	"strings"
+	"time"
+)
+
+// Expiration of presigned download urls by file visibility, from the downloads configs.
+func (m *MediaService) urlExpiry(v ct.FileVisibility) time.Duration {
+	switch v {
+	case ct.Private:
+		return m.Cfgs.FileService.Downloads.PrivateExpiry
+	case ct.Public:
+		return m.Cfgs.FileService.Downloads.PublicExpiry
+	}
+	return time.Duration(0)
+}
+
+// Returns the download url of a complete file or variant and its expiry.
+// Public files get a stable url served by the gateway when a public base url is configured,
+// it does not expire and the expiry is zero. Other files get a presigned url.
+func (m *MediaService) downloadURL(ctx context.Context,
+	fm dbservice.File) (string, time.Time, error) {
+
+	if base := m.Cfgs.FileService.Downloads.PublicBaseURL; base != "" && fm.Visibility == ct.Public {
+		u, err := publicFileURL(base, fm.Id, fm.Variant)
+		return u, time.Time{}, err
+	}
+
+	exp := m.urlExpiry(fm.Visibility)
+	expiresAt := time.Now().Add(exp)
+	u, err := m.S3.GenerateDownloadURL(ctx, fm.Bucket, fm.ObjectKey, exp)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return u.String(), expiresAt, nil
+}
+
+// Stable url of a public file variant, <base>/<encoded file id>/<variant>.
+// The url names the variant actually served, originals served in place of a
+// missing variant get the url of the original, so cached content never changes.
+func publicFileURL(base string, fileId ct.Id, variant ct.FileVariant) (string, error) {
+	encodedId, err := ct.EncodeId(fileId)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(base, "/") + "/" + url.PathEscape(encodedId) + "/" + url.PathEscape(variant.String()), nil
+}
+
+// Returns a short lived url of a public file on the internal endpoint, for the gateway
+// to serve the stable url of the file. Private files are not found.
+func (m *MediaService) GetPublicFile(ctx context.Context,
+	fileId ct.Id, variant ct.FileVariant) (string, error) {
+
+	input := fmt.Sprintf("id: %d variant: %s", fileId, variant)
+
+	fm, err := m.getDownloadableFile(ctx, fileId, variant, 0)
+	if err != nil {
+		if ce.IsClass(err, ce.ErrPermissionDenied) {
+			return "", ce.New(ce.ErrNotFound, err, input).WithPublic("not found")
+		}
+		return "", ce.Wrap(nil, err, input)
+	}
+
+	u, err := m.S3.GenerateInternalDownloadURL(ctx,
+		fm.Bucket, fm.ObjectKey, m.Cfgs.FileService.Downloads.ProxyExpiry)
+	if err != nil {
+		return "", ce.Wrap(ce.ErrInternal, err, input+": s3: generate url")
+	}
+	return u.String(), nil
+}
@@ -24,6 +24,13 @@ var (
 	ErrInvalidVisibility = errors.New("invalid visibility")
 	ErrInvalidExpiration = errors.New("invalid expiration")
 	ErrInvalidVariant    = errors.New("invalid variant")
+	ErrGCRunning         = errors.New("garbage collection already running")
+	ErrInvalidTransform  = errors.New("invalid transform")
+	ErrInvalidSignature  = errors.New("invalid transform signature")
+	ErrTooManyTransforms = errors.New("transform limit reached")
+	ErrQuarantined       = errors.New("file quarantined by content scan")
+	ErrUploadInProgress  = errors.New("multipart upload in progress")
+	ErrUploadIncomplete  = errors.New("multipart upload incomplete")
 )
 
 // Maps a file status to common errors and returns error with public message.
@@ -41,6 +48,11 @@ func parseFileStatus(fm dbservice.File) error {
 			WithPublic("file permenantly failed")
 	}
 
+	if fm.Status == ct.Quarantined {
+		return ce.Wrap(ce.ErrNotFound, ErrQuarantined, fm).
+			WithPublic("file unavailable")
+	}
+
 	if fm.Status == ct.Pending || fm.Status == ct.Processing {
 		// TODO: Think if I should validate here
 		return ce.Wrap(ce.ErrFailedPrecondition, ErrValidateStatus, fm).
@@ -64,25 +76,51 @@ func mapDBError(err error) error {
 	return ce.New(ce.ErrInternal, err).WithPublic("internal media error")
 }
 
-// validateUploadRequest validates all inputs required to create an image upload.
+// Limits of an upload request, those of images or of videos.
+type uploadLimits struct {
+	allowedMIMEs map[string]bool
+	maxSize      int64
+	video        bool // variants must be video variants
+}
+
+// Upload limits of images from the file constraints configs.
+func (m *MediaService) imageLimits() uploadLimits {
+	return uploadLimits{
+		allowedMIMEs: m.Cfgs.FileService.FileConstraints.AllowedMIMEs,
+		maxSize:      m.Cfgs.FileService.FileConstraints.MaxImageUpload,
+	}
+}
+
+// Upload limits of videos from the video constraints configs.
+func (m *MediaService) videoLimits() uploadLimits {
+	return uploadLimits{
+		allowedMIMEs: m.Cfgs.FileService.VideoConstraints.AllowedMIMEs,
+		maxSize:      m.Cfgs.FileService.VideoConstraints.MaxUpload,
+		video:        true,
+	}
+}
+
+// validateUploadRequest validates all inputs required to create an image or video upload.
 // It ensures the request metadata is well-formed, allowed by configuration,
 // and safe to process.
 //
 // Validation rules:
 //   - Filename must be non-empty.
-//   - MimeType must be provided and allowed by file service constraints.
-//   - SizeBytes must be greater than zero.
+//   - MimeType must be provided and allowed by the limits.
+//   - SizeBytes must be greater than zero and within the limits.
 //   - Visibility must be a valid enum value.
 //   - Expiration must be between 1 minute and 24 hours.
 //   - At least one file variant must be provided.
 //   - Each variant must be valid.
 //   - The ct.Original variant is not allowed, as it is created implicitly.
+//   - Video variants are only allowed for videos and image variants only for images.
 //
 // Returns a descriptive error on validation failure, or nil if the request is valid.
 func (m *MediaService) validateUploadRequest(
 	req UploadImageReq,
 	exp time.Duration,
 	variants []ct.FileVariant,
+	limits uploadLimits,
 ) *ce.Error {
 
 	if req.Filename == "" {
@@ -95,14 +133,14 @@ func (m *MediaService) validateUploadRequest(
 			WithPublic(fmt.Sprintf("missing mime type for file %v", req.Filename))
 	}
 
-	if !m.Cfgs.FileService.FileConstraints.AllowedMIMEs[req.MimeType] {
+	if !limits.allowedMIMEs[req.MimeType] {
 		return ce.New(ce.ErrInvalidArgument, ErrInvalidMime, req).
 			WithPublic(fmt.Sprintf("mime type %q not allowed", req.MimeType))
 	}
 
-	if req.SizeBytes < 1 || req.SizeBytes > m.Cfgs.FileService.FileConstraints.MaxImageUpload {
+	if req.SizeBytes < 1 || req.SizeBytes > limits.maxSize {
 		return ce.New(ce.ErrInvalidArgument, ErrInvalidSize, req).
-			WithPublic(fmt.Sprintf("file size %v exceeds allowed size %v", req.SizeBytes, m.Cfgs.FileService.FileConstraints.MaxImageUpload))
+			WithPublic(fmt.Sprintf("file size %v exceeds allowed size %v", req.SizeBytes, limits.maxSize))
 	}
 
 	if err := req.Visibility.Validate(); err != nil {
@@ -124,6 +162,10 @@ func (m *MediaService) validateUploadRequest(
 			return ce.New(ce.ErrInvalidArgument, ErrInvalidVariant, req).
 				WithPublic("original is not a variant")
 		}
+		if v.IsVideo() != limits.video {
+			return ce.New(ce.ErrInvalidArgument, ErrInvalidVariant, req).
+				WithPublic(fmt.Sprintf("variant %v not available for this file type", v))
+		}
 	}
 
 	return nil
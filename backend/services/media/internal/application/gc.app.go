@@ -0,0 +1,609 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"social-network/services/media/internal/configs"
+	"social-network/services/media/internal/db/dbservice"
+	md "social-network/services/media/internal/models"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	tele "social-network/shared/go/telemetry"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var collecting atomic.Bool
+
+// Counts of a garbage collection run. On dry runs nothing is changed
+// and the counts are of what would have been collected.
+type GCReport struct {
+	DryRun     bool
+	Reconciled bool // whether the buckets were compared with the db
+
+	ExpiredUploads    int   // pending files whose upload url expired
+	StuckVariants     int   // processing variants marked failed
+	FailedVariants    int   // failed variants deleted
+	FailedFiles       int   // failed files deleted
+	QuarantinedFiles  int   // quarantined files deleted
+	UnreferencedFiles int   // complete files no service uses anymore
+	OrphanObjects     int   // objects without file, variant or transform row
+	AbandonedUploads  int   // multipart uploads aborted with their parts
+	MissingOriginals  int   // files marked failed as their object is missing
+	MissingVariants   int   // variants generated again as their object is missing
+	FreedBytes        int64 // size of the deleted objects, only originals and orphans on dry runs
+}
+
+// StartGCWorker starts a background worker that periodically collects expired uploads,
+// failed and unreferenced files, and reconciles the buckets with the db on its own interval.
+func (m *MediaService) StartGCWorker(ctx context.Context) {
+	cfg := m.Cfgs.GC
+	tele.Info(ctx, "Initiating gc worker. @1 @2", "interval", cfg.Interval.String(), "dryRun", cfg.DryRun)
+	go func() {
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+
+		var lastReconcile time.Time
+		for {
+			select {
+			case <-ticker.C:
+				reconcile := time.Since(lastReconcile) >= cfg.ReconcileInterval
+				if _, err := m.CollectGarbage(ctx, cfg.DryRun, reconcile); err != nil {
+					tele.Warn(ctx, "Error collecting garbage. @1", "error", err.Error())
+					continue
+				}
+				if reconcile {
+					lastReconcile = time.Now()
+				}
+			case <-ctx.Done():
+				tele.Info(ctx, "GC worker stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Runs every garbage collection pass once and reports what was collected.
+// A pass stops at its first error, the following passes still run.
+// Unreferenced files are only collected once both posts and users service answered.
+func (m *MediaService) CollectGarbage(ctx context.Context,
+	dryRun bool, reconcile bool) (GCReport, error) {
+	input := fmt.Sprintf("dry run: %v, reconcile: %v", dryRun, reconcile)
+
+	if !collecting.CompareAndSwap(false, true) {
+		return GCReport{}, ce.New(ce.ErrUnavailable, ErrGCRunning, input).
+			WithPublic("garbage collection already running")
+	}
+	defer collecting.Store(false)
+
+	g := &gcRun{
+		m:      m,
+		cfg:    m.Cfgs.GC,
+		now:    time.Now(),
+		report: GCReport{DryRun: dryRun, Reconciled: reconcile},
+	}
+
+	passes := []func(context.Context) error{
+		g.expireUploads,
+		g.failStuckVariants,
+		g.deleteFailedVariants,
+		g.deleteFailedFiles,
+		g.deleteQuarantinedFiles,
+		g.collectUnreferenced,
+	}
+	if reconcile {
+		passes = append(passes, g.reconcile)
+	}
+
+	var errs []error
+	for _, pass := range passes {
+		if err := pass(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	err := errors.Join(errs...)
+
+	m.metrics.record(ctx, g.report, err)
+	tele.Info(ctx, "Garbage collection finished. @1", "report", fmt.Sprintf("%+v", g.report))
+
+	if err != nil {
+		return g.report, ce.Wrap(ce.ErrInternal, err, input)
+	}
+	return g.report, nil
+}
+
+// State of a single garbage collection run.
+type gcRun struct {
+	m      *MediaService
+	cfg    configs.GC
+	now    time.Time
+	report GCReport
+}
+
+func (g *gcRun) dryRun() bool {
+	return g.report.DryRun
+}
+
+// Collects pending files whose upload url expired more than the grace period ago.
+func (g *gcRun) expireUploads(ctx context.Context) error {
+	before := g.now.Add(-g.cfg.UploadGrace)
+	return g.eachFile(ctx,
+		func(afterId ct.Id) ([]dbservice.File, error) {
+			return g.m.Queries.GetExpiredUploads(ctx, before, afterId, g.cfg.BatchSize)
+		},
+		func(fm dbservice.File) error {
+			g.report.ExpiredUploads++
+			if err := g.abortUpload(ctx, fm); err != nil {
+				return err
+			}
+			return g.collectFile(ctx, fm)
+		},
+	)
+}
+
+// Aborts the multipart upload in progress of a file, if any, deleting its parts.
+func (g *gcRun) abortUpload(ctx context.Context, fm dbservice.File) error {
+	mu, err := g.m.Queries.GetMultipartUpload(ctx, fm.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	g.report.AbandonedUploads++
+	g.report.FreedBytes += mu.UploadedBytes
+	if g.dryRun() {
+		return nil
+	}
+	return g.m.S3.AbortMultipartUpload(ctx, fm.Bucket, fm.ObjectKey, mu.UploadId)
+}
+
+// Marks failed the variants the worker did not generate in time.
+func (g *gcRun) failStuckVariants(ctx context.Context) error {
+	before := g.now.Add(-g.cfg.StuckAfter)
+	return g.eachVariantBatch(ctx, ct.Processing, before,
+		func(vs []dbservice.Variant) error {
+			g.report.StuckVariants += len(vs)
+			if g.dryRun() {
+				return nil
+			}
+			return g.m.Queries.SetVariantsStatus(ctx, variantIds(vs), ct.Failed)
+		},
+	)
+}
+
+// Deletes failed variants after the retention period.
+// Reads of a file without the variant fall back to the original.
+func (g *gcRun) deleteFailedVariants(ctx context.Context) error {
+	before := g.now.Add(-g.cfg.FailedRetention)
+	return g.eachVariantBatch(ctx, ct.Failed, before,
+		func(vs []dbservice.Variant) error {
+			g.report.FailedVariants += len(vs)
+			if g.dryRun() {
+				return nil
+			}
+			if err := g.m.Queries.DeleteVariantsById(ctx, variantIds(vs)); err != nil {
+				return err
+			}
+			objects := make([]dbservice.File, 0, len(vs))
+			for _, v := range vs {
+				objects = append(objects, dbservice.File{
+					Bucket:    v.Bucket,
+					ObjectKey: v.ObjectKey,
+					SizeBytes: v.SizeBytes,
+				})
+			}
+			g.deleteObjects(ctx, objects)
+			return nil
+		},
+	)
+}
+
+// Collects failed files after the retention period.
+func (g *gcRun) deleteFailedFiles(ctx context.Context) error {
+	before := g.now.Add(-g.cfg.FailedRetention)
+	return g.eachFile(ctx,
+		func(afterId ct.Id) ([]dbservice.File, error) {
+			return g.m.Queries.GetFilesByStatus(ctx, ct.Failed, before, afterId, g.cfg.BatchSize)
+		},
+		func(fm dbservice.File) error {
+			g.report.FailedFiles++
+			return g.collectFile(ctx, fm)
+		},
+	)
+}
+
+// Collects quarantined files after the quarantine retention.
+func (g *gcRun) deleteQuarantinedFiles(ctx context.Context) error {
+	before := g.now.Add(-g.cfg.QuarantineRetention)
+	return g.eachFile(ctx,
+		func(afterId ct.Id) ([]dbservice.File, error) {
+			return g.m.Queries.GetFilesByStatus(ctx, ct.Quarantined, before, afterId, g.cfg.BatchSize)
+		},
+		func(fm dbservice.File) error {
+			g.report.QuarantinedFiles++
+			return g.collectFile(ctx, fm)
+		},
+	)
+}
+
+// Asks posts and users service which complete files they still use and collects the others.
+// Files found in use are not asked about again before the recheck period.
+func (g *gcRun) collectUnreferenced(ctx context.Context) error {
+	createdBefore := g.now.Add(-g.cfg.UnreferencedAfter)
+	checkedBefore := g.now.Add(-g.cfg.RecheckAfter)
+
+	var afterId ct.Id
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		files, err := g.m.Queries.GetGCCandidates(ctx,
+			createdBefore, checkedBefore, afterId, g.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			return nil
+		}
+
+		ids := make(ct.Ids, 0, len(files))
+		for _, fm := range files {
+			ids = append(ids, fm.Id)
+		}
+		used, err := g.m.Refs.GetUsedFileIds(ctx, ids)
+		if err != nil {
+			return err
+		}
+		inUse := make(map[ct.Id]bool, len(used))
+		for _, id := range used {
+			inUse[id] = true
+		}
+
+		var referenced ct.Ids
+		for _, fm := range files {
+			if inUse[fm.Id] {
+				referenced = append(referenced, fm.Id)
+				continue
+			}
+			g.report.UnreferencedFiles++
+			if err := g.collectFile(ctx, fm); err != nil {
+				return err
+			}
+		}
+
+		if !g.dryRun() {
+			if err := g.m.Queries.SetGCChecked(ctx, referenced); err != nil {
+				return err
+			}
+		}
+
+		if len(files) < int(g.cfg.BatchSize) {
+			return nil
+		}
+		afterId = files[len(files)-1].Id
+	}
+}
+
+// Compares the buckets with the db in both directions. The buckets are listed page by page,
+// objects without row are deleted once older than the orphan period. Complete files whose
+// object is missing are marked failed, complete variants whose object is missing are
+// generated again. Rows changed after the reconciliation started are left for the next run.
+func (g *gcRun) reconcile(ctx context.Context) error {
+	buckets := []string{
+		g.m.Cfgs.FileService.Buckets.Originals,
+		g.m.Cfgs.FileService.Buckets.Variants,
+	}
+
+	startedAt := time.Now()
+	for _, bucket := range buckets {
+		if err := g.m.S3.ListObjects(ctx, bucket, int(g.cfg.BatchSize),
+			func(page []md.ObjectInfo) error {
+				return g.deleteOrphans(ctx, bucket, page)
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	// Only originals are uploaded in parts
+	if err := g.abortAbandonedUploads(ctx, g.m.Cfgs.FileService.Buckets.Originals); err != nil {
+		return err
+	}
+
+	if err := g.eachFile(ctx,
+		func(afterId ct.Id) ([]dbservice.File, error) {
+			return g.m.Queries.GetFilesByStatus(ctx, ct.Complete, startedAt, afterId, g.cfg.BatchSize)
+		},
+		func(fm dbservice.File) error {
+			missing, err := g.objectMissing(ctx, fm.Bucket, fm.ObjectKey)
+			if err != nil || !missing {
+				return err
+			}
+			g.report.MissingOriginals++
+			tele.Warn(ctx, "Object of complete file @1 is missing from file service", "fileId", fm.Id)
+			if g.dryRun() {
+				return nil
+			}
+			_, err = g.m.Queries.MarkObjectFailed(ctx, fm.Bucket, fm.ObjectKey)
+			return err
+		},
+	); err != nil {
+		return err
+	}
+
+	return g.eachVariantBatch(ctx, ct.Complete, startedAt,
+		func(vs []dbservice.Variant) error {
+			var missing []ct.Id
+			for _, v := range vs {
+				isMissing, err := g.objectMissing(ctx, v.Bucket, v.ObjectKey)
+				if err != nil {
+					return err
+				}
+				if isMissing {
+					missing = append(missing, v.Id)
+				}
+			}
+			g.report.MissingVariants += len(missing)
+			if g.dryRun() {
+				return nil
+			}
+			// the worker generates processing variants of complete files
+			return g.m.Queries.SetVariantsStatus(ctx, missing, ct.Processing)
+		},
+	)
+}
+
+// Tells whether the object of a row is missing from the file service.
+func (g *gcRun) objectMissing(ctx context.Context, bucket, objectKey string) (bool, error) {
+	_, err := g.m.S3.StatObject(ctx, bucket, objectKey)
+	if errors.Is(err, ce.ErrNotFound) {
+		return true, nil
+	}
+	return false, err
+}
+
+// Deletes the objects of a listed page of a bucket that belong to no file, variant or
+// transform and are older than the orphan period.
+func (g *gcRun) deleteOrphans(ctx context.Context, bucket string, page []md.ObjectInfo) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	orphanBefore := g.now.Add(-g.cfg.OrphanAfter)
+
+	keys := make([]string, 0, len(page))
+	for _, o := range page {
+		if o.LastModified.Before(orphanBefore) {
+			keys = append(keys, o.Key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	known, err := g.m.Queries.GetKnownObjectKeys(ctx, bucket, keys)
+	if err != nil {
+		return err
+	}
+	isKnown := make(map[string]bool, len(known))
+	for _, k := range known {
+		isKnown[k] = true
+	}
+
+	for _, o := range page {
+		if !o.LastModified.Before(orphanBefore) || isKnown[o.Key] {
+			continue
+		}
+		g.report.OrphanObjects++
+		g.report.FreedBytes += o.Size
+		if g.dryRun() {
+			continue
+		}
+		g.m.deleteObjects(ctx, []dbservice.File{{Bucket: bucket, ObjectKey: o.Key}})
+	}
+	return nil
+}
+
+// Aborts the multipart uploads of a bucket that belong to no file and were
+// initiated before the orphan period, such as those of files deleted meanwhile.
+// Their parts are not listed as objects.
+func (g *gcRun) abortAbandonedUploads(ctx context.Context, bucket string) error {
+	orphanBefore := g.now.Add(-g.cfg.OrphanAfter)
+
+	uploads, err := g.m.S3.ListMultipartUploads(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	old := make([]md.MultipartInfo, 0, len(uploads))
+	ids := make([]string, 0, len(uploads))
+	for _, u := range uploads {
+		if u.Initiated.Before(orphanBefore) {
+			old = append(old, u)
+			ids = append(ids, u.UploadId)
+		}
+	}
+	known, err := g.m.Queries.GetKnownUploadIds(ctx, ids)
+	if err != nil {
+		return err
+	}
+	isKnown := make(map[string]bool, len(known))
+	for _, id := range known {
+		isKnown[id] = true
+	}
+
+	for _, u := range old {
+		if isKnown[u.UploadId] {
+			continue
+		}
+		g.report.AbandonedUploads++
+		if g.dryRun() {
+			continue
+		}
+		if err := g.m.S3.AbortMultipartUpload(ctx, bucket, u.Key, u.UploadId); err != nil {
+			tele.Warn(ctx, "Failed to abort multipart upload @1 of @2. @3", "uploadId", u.UploadId, "objectKey", u.Key, "error", err.Error())
+		}
+	}
+	return nil
+}
+
+// Deletes the row of a file and the objects no other file references.
+func (g *gcRun) collectFile(ctx context.Context, fm dbservice.File) error {
+	if g.dryRun() {
+		g.report.FreedBytes += fm.SizeBytes
+		return nil
+	}
+
+	var orphans []dbservice.File
+	err := g.m.txRunner.RunTx(ctx, func(tx *dbservice.Queries) error {
+		var err error
+		orphans, err = releaseFile(ctx, tx, fm)
+		return err
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil // deleted meanwhile
+	}
+	if err != nil {
+		return err
+	}
+
+	g.deleteObjects(ctx, orphans)
+	return nil
+}
+
+func (g *gcRun) deleteObjects(ctx context.Context, objects []dbservice.File) {
+	g.m.deleteObjects(ctx, objects)
+	for _, o := range objects {
+		g.report.FreedBytes += o.SizeBytes
+	}
+}
+
+// Pages through files in id order, calling fn on each.
+func (g *gcRun) eachFile(
+	ctx context.Context,
+	next func(afterId ct.Id) ([]dbservice.File, error),
+	fn func(dbservice.File) error,
+) error {
+	var afterId ct.Id
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		files, err := next(afterId)
+		if err != nil {
+			return err
+		}
+		for _, fm := range files {
+			if err := fn(fm); err != nil {
+				return err
+			}
+		}
+
+		if len(files) < int(g.cfg.BatchSize) {
+			return nil
+		}
+		afterId = files[len(files)-1].Id
+	}
+}
+
+// Pages through the variants in status last updated before the given time, calling fn on each page.
+func (g *gcRun) eachVariantBatch(
+	ctx context.Context,
+	status ct.UploadStatus,
+	before time.Time,
+	fn func([]dbservice.Variant) error,
+) error {
+	var afterId ct.Id
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		vs, err := g.m.Queries.GetVariantsByStatus(ctx, status, before, afterId, g.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(vs) > 0 {
+			if err := fn(vs); err != nil {
+				return err
+			}
+		}
+
+		if len(vs) < int(g.cfg.BatchSize) {
+			return nil
+		}
+		afterId = vs[len(vs)-1].Id
+	}
+}
+
+func variantIds(vs []dbservice.Variant) []ct.Id {
+	ids := make([]ct.Id, 0, len(vs))
+	for _, v := range vs {
+		ids = append(ids, v.Id)
+	}
+	return ids
+}
+
+// Counters of the garbage collector, exported with the telemetry of the service.
+type gcMetrics struct {
+	runs       metric.Int64Counter
+	collected  metric.Int64Counter
+	freedBytes metric.Int64Counter
+}
+
+func newGCMetrics() (gcMetrics, error) {
+	meter := otel.Meter("media")
+
+	runs, errRuns := meter.Int64Counter("media.gc.runs",
+		metric.WithDescription("Garbage collection runs by result"))
+	collected, errCollected := meter.Int64Counter("media.gc.collected",
+		metric.WithDescription("Files, variants and objects collected by kind"))
+	freedBytes, errFreed := meter.Int64Counter("media.gc.freed",
+		metric.WithDescription("Size of the objects deleted by the garbage collector"),
+		metric.WithUnit("By"))
+
+	return gcMetrics{
+		runs:       runs,
+		collected:  collected,
+		freedBytes: freedBytes,
+	}, errors.Join(errRuns, errCollected, errFreed)
+}
+
+func (g gcMetrics) record(ctx context.Context, r GCReport, err error) {
+	dryRun := attribute.Bool("dry_run", r.DryRun)
+
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	g.runs.Add(ctx, 1, metric.WithAttributes(dryRun, attribute.String("result", result)))
+
+	for kind, n := range map[string]int{
+		"expired_upload":   r.ExpiredUploads,
+		"stuck_variant":    r.StuckVariants,
+		"failed_variant":   r.FailedVariants,
+		"failed_file":      r.FailedFiles,
+		"quarantined_file": r.QuarantinedFiles,
+		"unreferenced":     r.UnreferencedFiles,
+		"orphan_object":    r.OrphanObjects,
+		"abandoned_upload": r.AbandonedUploads,
+		"missing_original": r.MissingOriginals,
+		"missing_variant":  r.MissingVariants,
+	} {
+		if n == 0 {
+			continue
+		}
+		g.collected.Add(ctx, int64(n), metric.WithAttributes(dryRun, attribute.String("kind", kind)))
+	}
+
+	g.freedBytes.Add(ctx, r.FreedBytes, metric.WithAttributes(dryRun))
+}
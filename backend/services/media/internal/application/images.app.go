@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"social-network/services/media/internal/client"
 	"social-network/services/media/internal/db/dbservice"
 	"social-network/services/media/internal/mapping"
 	ce "social-network/shared/go/commonerrors"
@@ -21,6 +22,7 @@ type UploadImageReq struct {
 	MimeType   string
 	SizeBytes  int64
 	Visibility ct.FileVisibility
+	OwnerId    ct.Id // uploader, zero if unknown
 }
 
 // Provides a fileId and an upload url targeted on bucket Originals defined on configs.
@@ -31,66 +33,38 @@ func (m *MediaService) UploadImage(ctx context.Context,
 	exp time.Duration,
 	variants []ct.FileVariant,
 ) (fileId ct.Id, upUrl string, err error) {
-	input := fmt.Sprintf("req: %#v, variants: %v", req, variants)
-
 	if err := m.validateUploadRequest(
 		req,
 		exp,
 		variants,
+		m.imageLimits(),
 	); err != nil {
 		return 0, "", ce.Wrap(nil, err, req, variants)
 	}
 
-	objectKey := uuid.NewString()
-	orignalsBucket := m.Cfgs.FileService.Buckets.Originals
-	variantsBucket := m.Cfgs.FileService.Buckets.Variants
+	return m.createUpload(ctx, req, exp, variants)
+}
+
+// Creates the db entries of a validated upload request, the original file
+// and its pending variants, and the upload url of the original.
+func (m *MediaService) createUpload(ctx context.Context,
+	req UploadImageReq,
+	exp time.Duration,
+	variants []ct.FileVariant,
+) (fileId ct.Id, upUrl string, err error) {
+	input := fmt.Sprintf("req: %#v, variants: %v", req, variants)
+
 	var url *url.URL
 
 	errTx := m.txRunner.RunTx(ctx,
 		func(tx *dbservice.Queries) error {
-			fileId, err = tx.CreateFile(ctx, dbservice.File{
-				Filename:   req.Filename,
-				MimeType:   req.MimeType,
-				SizeBytes:  req.SizeBytes,
-				Visibility: req.Visibility,
-				Bucket:     orignalsBucket,
-				ObjectKey:  objectKey,
-				Status:     ct.Pending,
-				Variant:    ct.Original,
-			})
-
+			var fm dbservice.File
+			fileId, fm, err = m.createFileRows(ctx, tx, req, exp, variants)
 			if err != nil {
-				return ce.Wrap(
-					ce.ErrInternal,
-					err,
-					"creating original file db entry error for file",
-					input+": db: create file",
-				).WithPublic("media service error")
+				return err
 			}
 
-			for _, v := range variants {
-				tele.Debug(ctx, "creating variants on db", "input", input, "variants", v)
-				_, err := tx.CreateVariant(ctx, dbservice.File{
-					Id:         fileId,
-					Filename:   req.Filename,
-					MimeType:   "image/webp",
-					SizeBytes:  req.SizeBytes,
-					Bucket:     variantsBucket,
-					ObjectKey:  objectKey + "/" + v.String(),
-					Visibility: req.Visibility,
-					Status:     ct.Pending,
-					Variant:    v,
-				})
-				if err != nil {
-					return ce.Wrap(
-						ce.ErrInternal,
-						err,
-						fmt.Sprintf("failed to create variant %s", v.String()),
-					).WithPublic("media service error")
-				}
-			}
-
-			url, err = m.S3.GenerateUploadURL(ctx, orignalsBucket, objectKey, exp)
+			url, err = m.S3.GenerateUploadURL(ctx, fm.Bucket, fm.ObjectKey, exp)
 			if err != nil {
 				return ce.Wrap(
 					ce.ErrInternal,
@@ -110,18 +84,113 @@ func (m *MediaService) UploadImage(ctx context.Context,
 	return fileId, url.String(), nil
 }
 
-// Returns an image download URL for the requested imageId and Variant.
+// Creates the original file row of an upload request, pending until its upload
+// expires, and the rows of its pending variants. Returns the original file.
+func (m *MediaService) createFileRows(ctx context.Context,
+	tx *dbservice.Queries,
+	req UploadImageReq,
+	exp time.Duration,
+	variants []ct.FileVariant,
+) (fileId ct.Id, fm dbservice.File, err error) {
+	input := fmt.Sprintf("req: %#v, variants: %v", req, variants)
+
+	objectKey := uuid.NewString()
+	orignalsBucket := m.Cfgs.FileService.Buckets.Originals
+	variantsBucket := m.Cfgs.FileService.Buckets.Variants
+
+	fm = dbservice.File{
+		Filename:   req.Filename,
+		MimeType:   req.MimeType,
+		SizeBytes:  req.SizeBytes,
+		Visibility: req.Visibility,
+		Bucket:     orignalsBucket,
+		ObjectKey:  objectKey,
+		Status:     ct.Pending,
+		OwnerId:    req.OwnerId,
+		Variant:    ct.Original,
+	}
+
+	fileId, err = tx.CreateFile(ctx, fm)
+	if err != nil {
+		return 0, fm, ce.Wrap(
+			ce.ErrInternal,
+			err,
+			"creating original file db entry error for file",
+			input+": db: create file",
+		).WithPublic("media service error")
+	}
+	fm.Id = fileId
+
+	// pending files are collected once the upload url expired
+	if err := tx.SetUploadExpiry(ctx, fileId, time.Now().Add(exp)); err != nil {
+		return 0, fm, ce.Wrap(
+			ce.ErrInternal,
+			err,
+			input+": db: set upload expiry",
+		).WithPublic("media service error")
+	}
+
+	for _, v := range variants {
+		tele.Debug(ctx, "creating variants on db", "input", input, "variants", v)
+		_, err := tx.CreateVariant(ctx, dbservice.File{
+			Id:         fileId,
+			Filename:   req.Filename,
+			MimeType:   client.VariantMimeType(v),
+			SizeBytes:  req.SizeBytes,
+			Bucket:     variantsBucket,
+			ObjectKey:  objectKey + "/" + v.String(),
+			Visibility: req.Visibility,
+			Status:     ct.Pending,
+			Variant:    v,
+		})
+		if err != nil {
+			return 0, fm, ce.Wrap(
+				ce.ErrInternal,
+				err,
+				fmt.Sprintf("failed to create variant %s", v.String()),
+			).WithPublic("media service error")
+		}
+	}
+	return fileId, fm, nil
+}
+
+// Returns an image download URL for the requested imageId and Variant, and its expiry,
+// zero for the stable URLs of public files.
 // If the variant is not available it falls back to the original file.
-func (m *MediaService) GetPublicImage(
+// Private images are only returned to requesters allowed by canDownload.
+func (m *MediaService) GetImage(
 	ctx context.Context,
 	imgId ct.Id,
 	variant ct.FileVariant,
-) (string, error) {
+	requesterId ct.Id,
+) (string, time.Time, error) {
 
-	input := fmt.Sprintf("id: %d variant: %s", imgId, variant)
+	input := fmt.Sprintf("id: %d variant: %s requester: %d", imgId, variant, requesterId)
+
+	fm, err := m.getDownloadableFile(ctx, imgId, variant, requesterId)
+	if err != nil {
+		return "", time.Time{}, ce.Wrap(nil, err, input)
+	}
+
+	url, expiresAt, err := m.downloadURL(ctx, fm)
+	if err != nil {
+		return "", time.Time{}, ce.Wrap(ce.ErrInternal, err, input+": s3: generate url")
+	}
+	return url, expiresAt, nil
+}
+
+// Returns the complete file or variant served for the requested imageId and Variant,
+// the original file if the variant is not available.
+// Private images are only returned to requesters allowed by canDownload.
+func (m *MediaService) getDownloadableFile(
+	ctx context.Context,
+	imgId ct.Id,
+	variant ct.FileVariant,
+	requesterId ct.Id,
+) (dbservice.File, error) {
 
 	if err := ct.ValidateBatch(imgId, variant); err != nil {
-		return "", ce.Wrap(ce.ErrInvalidArgument, err, input)
+		return dbservice.File{}, ce.Wrap(ce.ErrInvalidArgument, err)
 	}
 
 	var fm dbservice.File
@@ -146,21 +215,17 @@ func (m *MediaService) GetPublicImage(
 	})
 
 	if err != nil {
-		return "", ce.Wrap(nil, err, input)
-	}
-
-	if fm.Visibility != ct.Public {
-		return "", ce.New(ce.ErrPermissionDenied, ErrPermissionDenied, input).WithPublic("you don't have permission to view this image")
+		return fm, err
 	}
 
-	u, err := m.S3.GenerateDownloadURL(
-		ctx, fm.Bucket, fm.ObjectKey, setExp(fm.Visibility),
-	)
+	canDownload, err := m.canDownload(ctx, fm, requesterId)
 	if err != nil {
-		return "", ce.Wrap(ce.ErrInternal, err, input+": s3: generate url")
+		return fm, err
 	}
-
-	return u.String(), nil
+	if !canDownload {
+		return fm, ce.New(ce.ErrPermissionDenied, ErrPermissionDenied).WithPublic("you don't have permission to view this image")
+	}
+	return fm, nil
 }
 
 type FailedId struct {
@@ -174,14 +239,17 @@ type FailedId struct {
 // Variant is common for all ids. If a variant is present but not completed
 // returns url for the original format.
 // GetImages does not accept original variants in batch request
+// Returns the blurhash placeholders of the images that have one along the urls,
+// and the earliest expiry of the urls, zero if none expires.
+// Only trusted services call it, private images are returned as to a zero requester of canDownload.
 func (m *MediaService) GetImages(ctx context.Context,
 	imgIds ct.Ids, variant ct.FileVariant,
-) (downUrls map[ct.Id]string, failedIds []FailedId, err error) {
+) (downUrls map[ct.Id]string, placeholders map[ct.Id]string, expiresAt time.Time, failedIds []FailedId, err error) {
 
 	errMsg := fmt.Sprintf("get images: ids: %v variant: %s", imgIds, variant)
 
 	if err := ct.ValidateBatch(imgIds, variant); err != nil {
-		return nil, nil, ce.Wrap(ce.ErrInvalidArgument, err, errMsg)
+		return nil, nil, expiresAt, nil, ce.Wrap(ce.ErrInvalidArgument, err, errMsg)
 	}
 
 	var missingVariants ct.Ids
@@ -191,10 +259,24 @@ func (m *MediaService) GetImages(ctx context.Context,
 	err = m.txRunner.RunTx(ctx, func(tx *dbservice.Queries) error {
 		var err error
 		fms, missingVariants, err = tx.GetVariants(ctx, imgIds.Unique(), variant)
-		if err != nil {
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
 			return mapDBError(err)
 		}
 
+		// files whose variant row was never created or was collected fall back to the original
+		found := make(map[ct.Id]bool, len(fms)+len(missingVariants))
+		for _, fm := range fms {
+			found[fm.Id] = true
+		}
+		for _, id := range missingVariants {
+			found[id] = true
+		}
+		for _, id := range imgIds.Unique() {
+			if !found[id] {
+				missingVariants = append(missingVariants, id)
+			}
+		}
+
 		if len(missingVariants) != 0 {
 			originals, err := tx.GetFiles(ctx, missingVariants)
 			if err != nil {
@@ -202,14 +284,19 @@ func (m *MediaService) GetImages(ctx context.Context,
 			}
 			fms = append(fms, originals...)
 		}
+
+		if len(fms) == 0 {
+			return mapDBError(sql.ErrNoRows)
+		}
 		return nil
 	})
 
 	if err != nil {
-		return nil, nil, ce.Wrap(nil, err, errMsg+": tx error")
+		return nil, nil, expiresAt, nil, ce.Wrap(nil, err, errMsg+": tx error")
 	}
 
 	downUrls = make(map[ct.Id]string, len(fms))
+	placeholders = make(map[ct.Id]string, len(fms))
 	for _, fm := range fms {
 		if err := parseFileStatus(fm); err != nil {
 			failedIds = append(failedIds, FailedId{Id: fm.Id, Status: fm.Status})
@@ -217,14 +304,19 @@ func (m *MediaService) GetImages(ctx context.Context,
 				"failed to validate file status. @1", "error", err.Error())
 			continue
 		}
-		url, err := m.S3.GenerateDownloadURL(ctx,
-			fm.Bucket, fm.ObjectKey, setExp(fm.Visibility))
+		url, exp, err := m.downloadURL(ctx, fm)
 		if err != nil {
-			return nil, nil, ce.Wrap(ce.ErrInternal, err, errMsg+": s3: generate url")
+			return nil, nil, expiresAt, nil, ce.Wrap(ce.ErrInternal, err, errMsg+": s3: generate url")
+		}
+		downUrls[fm.Id] = url
+		if !exp.IsZero() && (expiresAt.IsZero() || exp.Before(expiresAt)) {
+			expiresAt = exp
+		}
+		if fm.Blurhash != "" {
+			placeholders[fm.Id] = fm.Blurhash
 		}
-		downUrls[fm.Id] = url.String()
 	}
-	return downUrls, failedIds, nil
+	return downUrls, placeholders, expiresAt, failedIds, nil
 }
 
 // This is a call to validate an already uploaded file.
@@ -245,7 +337,7 @@ func (m *MediaService) ValidateUpload(ctx context.Context,
 		return "", ce.Wrap(nil, mapDBError(err), input)
 	}
 
-	if fileMeta.Status == ct.Failed {
+	if fileMeta.Status == ct.Failed || fileMeta.Status == ct.Quarantined {
 		return url, ce.New(ce.ErrNotFound, ErrFailed, input).WithPublic("invalid file")
 	}
 
@@ -289,14 +381,3 @@ func (m *MediaService) ValidateUpload(ctx context.Context,
 	}
 	return url, nil
 }
-
-// Sets 3 minutes expiration for private and 7 days exp for public
-func setExp(v ct.FileVisibility) time.Duration {
-	switch v {
-	case ct.Private:
-		return time.Duration(3 * time.Minute)
-	case ct.Public:
-		return time.Duration(7 * 24 * time.Hour)
-	}
-	return time.Duration(0)
-}
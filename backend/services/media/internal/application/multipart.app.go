@@ -0,0 +1,307 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"social-network/services/media/internal/db/dbservice"
+	md "social-network/services/media/internal/models"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	tele "social-network/shared/go/telemetry"
+	"time"
+)
+
+// S3 limits of multipart uploads
+const (
+	minPartSize  = 5 << 20
+	maxPartCount = 10000
+)
+
+// Progress of a multipart upload, as listed by the file service.
+type UploadProgress struct {
+	FileId        ct.Id
+	PartSize      int64
+	PartCount     int32
+	UploadedParts []int32 // part numbers, in order
+	UploadedBytes int64
+	ExpiresAt     time.Time
+}
+
+// Presigned url of a part of a multipart upload.
+type PartURL struct {
+	PartNumber int32
+	URL        string
+}
+
+// Starts a multipart upload of an image or a video, for large files and unreliable connections.
+// The file is created as UploadImage or UploadVideo do, the original is uploaded in parts of
+// the configured part size, each to its own url from SignUploadParts, the last part may be smaller.
+// Parts can be uploaded in any order and again until the upload is completed, it is
+// validated as any other upload once CompleteMultipartUpload assembled it.
+func (m *MediaService) InitiateMultipartUpload(ctx context.Context,
+	req UploadImageReq,
+	exp time.Duration,
+	variants []ct.FileVariant,
+) (upload UploadProgress, err error) {
+	input := fmt.Sprintf("req: %#v, variants: %v", req, variants)
+
+	limits := m.imageLimits()
+	if isVideo(req.MimeType) {
+		limits = m.videoLimits()
+	}
+	if err := m.validateUploadRequest(req, exp, variants, limits); err != nil {
+		return upload, ce.Wrap(nil, err, input)
+	}
+
+	partSize := m.Cfgs.FileService.Multipart.PartSize
+	if partSize < minPartSize {
+		partSize = minPartSize
+	}
+	partCount := (req.SizeBytes + partSize - 1) / partSize
+	if partCount > maxPartCount {
+		return upload, ce.New(ce.ErrInvalidArgument, ErrInvalidSize, input).
+			WithPublic(fmt.Sprintf("file size %v needs more than %v parts", req.SizeBytes, maxPartCount))
+	}
+
+	var uploadId string
+	errTx := m.txRunner.RunTx(ctx,
+		func(tx *dbservice.Queries) error {
+			fileId, fm, err := m.createFileRows(ctx, tx, req, exp, variants)
+			if err != nil {
+				return err
+			}
+
+			uploadId, err = m.S3.NewMultipartUpload(ctx, fm.Bucket, fm.ObjectKey, fm.MimeType)
+			if err != nil {
+				return ce.Wrap(nil, err, input).WithPublic("media service error")
+			}
+
+			if err := tx.SetMultipartUpload(ctx, fileId, uploadId, partSize, int32(partCount)); err != nil {
+				// The upload has no row, it would only be aborted by reconciliation
+				if abortErr := m.S3.AbortMultipartUpload(ctx, fm.Bucket, fm.ObjectKey, uploadId); abortErr != nil {
+					tele.Warn(ctx, "Failed to abort multipart upload @1. @2", "uploadId", uploadId, "error", abortErr.Error())
+				}
+				return ce.Wrap(ce.ErrInternal, err, input+": db: set multipart upload").
+					WithPublic("media service error")
+			}
+
+			upload = UploadProgress{
+				FileId:    fileId,
+				PartSize:  partSize,
+				PartCount: int32(partCount),
+				ExpiresAt: time.Now().Add(exp),
+			}
+			return nil
+		},
+	)
+	if errTx != nil {
+		return UploadProgress{}, ce.Wrap(nil, errTx)
+	}
+
+	tele.Info(ctx, "Media Service: started multipart upload of @1 in @2 parts", "fileId", upload.FileId, "parts", partCount)
+	return upload, nil
+}
+
+// Returns presigned urls of parts of a multipart upload, those not uploaded yet if partNumbers is empty.
+// The urls expire with the configured part url expiry, at the latest with the upload.
+func (m *MediaService) SignUploadParts(ctx context.Context,
+	fileId ct.Id, requesterId ct.Id, partNumbers []int32,
+) ([]PartURL, error) {
+	input := fmt.Sprintf("file id: %d, requester: %d, parts: %v", fileId, requesterId, partNumbers)
+
+	fm, mu, err := m.getMultipartUpload(ctx, fileId, requesterId)
+	if err != nil {
+		return nil, ce.Wrap(nil, err, input)
+	}
+
+	exp := min(m.Cfgs.FileService.Multipart.PartURLExpiry, time.Until(mu.ExpiresAt))
+	if exp <= 0 {
+		return nil, ce.New(ce.ErrFailedPrecondition, ErrInvalidExpiration, input).
+			WithPublic("upload expired")
+	}
+
+	if len(partNumbers) == 0 {
+		uploaded, err := m.S3.ListUploadedParts(ctx, fm.Bucket, fm.ObjectKey, mu.UploadId)
+		if err != nil {
+			return nil, ce.Wrap(nil, err, input)
+		}
+		partNumbers = missingParts(mu.PartCount, uploaded)
+	}
+
+	urls := make([]PartURL, 0, len(partNumbers))
+	for _, n := range partNumbers {
+		if n < 1 || n > mu.PartCount {
+			return nil, ce.New(ce.ErrInvalidArgument, ErrReqValidation, input).
+				WithPublic(fmt.Sprintf("invalid part number %v, the upload has %v parts", n, mu.PartCount))
+		}
+		u, err := m.S3.GeneratePartUploadURL(ctx, fm.Bucket, fm.ObjectKey, mu.UploadId, n, exp)
+		if err != nil {
+			return nil, ce.Wrap(nil, err, input).WithPublic("media service error")
+		}
+		urls = append(urls, PartURL{PartNumber: n, URL: u.String()})
+	}
+	return urls, nil
+}
+
+// Returns the progress of a multipart upload and records it on the file.
+func (m *MediaService) GetUploadProgress(ctx context.Context,
+	fileId ct.Id, requesterId ct.Id,
+) (UploadProgress, error) {
+	input := fmt.Sprintf("file id: %d, requester: %d", fileId, requesterId)
+
+	fm, mu, err := m.getMultipartUpload(ctx, fileId, requesterId)
+	if err != nil {
+		return UploadProgress{}, ce.Wrap(nil, err, input)
+	}
+
+	uploaded, err := m.S3.ListUploadedParts(ctx, fm.Bucket, fm.ObjectKey, mu.UploadId)
+	if err != nil {
+		return UploadProgress{}, ce.Wrap(nil, err, input)
+	}
+
+	progress := newUploadProgress(mu, uploaded)
+	if err := m.Queries.SetUploadProgress(ctx, fileId,
+		int32(len(progress.UploadedParts)), progress.UploadedBytes); err != nil {
+		return UploadProgress{}, ce.Wrap(nil, mapDBError(err), input)
+	}
+	return progress, nil
+}
+
+// Assembles the original of a multipart upload once all its parts are uploaded.
+// The file stays pending until it is validated as any other upload.
+func (m *MediaService) CompleteMultipartUpload(ctx context.Context,
+	fileId ct.Id, requesterId ct.Id,
+) error {
+	input := fmt.Sprintf("file id: %d, requester: %d", fileId, requesterId)
+
+	fm, mu, err := m.getMultipartUpload(ctx, fileId, requesterId)
+	if err != nil {
+		return ce.Wrap(nil, err, input)
+	}
+
+	uploaded, err := m.S3.ListUploadedParts(ctx, fm.Bucket, fm.ObjectKey, mu.UploadId)
+	if err != nil {
+		return ce.Wrap(nil, err, input)
+	}
+
+	if missing := missingParts(mu.PartCount, uploaded); len(missing) > 0 {
+		return ce.New(ce.ErrFailedPrecondition, ErrUploadIncomplete, input).
+			WithPublic(fmt.Sprintf("missing parts %v", missing))
+	}
+
+	progress := newUploadProgress(mu, uploaded)
+	if progress.UploadedBytes != fm.SizeBytes {
+		return ce.New(ce.ErrFailedPrecondition, ErrUploadIncomplete, input).
+			WithPublic(fmt.Sprintf("uploaded %v bytes, expected %v", progress.UploadedBytes, fm.SizeBytes))
+	}
+
+	parts := make([]md.UploadedPart, 0, mu.PartCount)
+	for _, p := range uploaded {
+		if p.PartNumber <= mu.PartCount {
+			parts = append(parts, p)
+		}
+	}
+	if err := m.S3.CompleteMultipartUpload(ctx, fm.Bucket, fm.ObjectKey, mu.UploadId, parts); err != nil {
+		return ce.Wrap(nil, err, input).WithPublic("failed to complete upload")
+	}
+
+	err = m.txRunner.RunTx(ctx, func(tx *dbservice.Queries) error {
+		if err := tx.EndMultipartUpload(ctx, fileId); err != nil {
+			return mapDBError(err)
+		}
+		return mapDBError(tx.SetUploadProgress(ctx, fileId,
+			int32(len(progress.UploadedParts)), progress.UploadedBytes))
+	})
+	if err != nil {
+		return ce.Wrap(nil, err, input)
+	}
+
+	tele.Info(ctx, "Media Service: completed multipart upload of @1", "fileId", fileId)
+	return nil
+}
+
+// Aborts a multipart upload and deletes its file, with the parts uploaded so far.
+func (m *MediaService) AbortMultipartUpload(ctx context.Context,
+	fileId ct.Id, requesterId ct.Id,
+) error {
+	input := fmt.Sprintf("file id: %d, requester: %d", fileId, requesterId)
+
+	fm, mu, err := m.getMultipartUpload(ctx, fileId, requesterId)
+	if err != nil {
+		return ce.Wrap(nil, err, input)
+	}
+
+	if err := m.S3.AbortMultipartUpload(ctx, fm.Bucket, fm.ObjectKey, mu.UploadId); err != nil {
+		return ce.Wrap(nil, err, input).WithPublic("failed to abort upload")
+	}
+
+	var orphans []dbservice.File
+	err = m.txRunner.RunTx(ctx, func(tx *dbservice.Queries) error {
+		orphans, err = releaseFile(ctx, tx, fm)
+		return mapDBError(err)
+	})
+	if err != nil {
+		return ce.Wrap(nil, err, input)
+	}
+
+	m.deleteObjects(ctx, orphans)
+	return nil
+}
+
+// Returns a pending file of the requester and its multipart upload in progress.
+func (m *MediaService) getMultipartUpload(ctx context.Context,
+	fileId ct.Id, requesterId ct.Id,
+) (dbservice.File, dbservice.MultipartUpload, error) {
+	if err := fileId.Validate(); err != nil {
+		return dbservice.File{}, dbservice.MultipartUpload{}, ce.Wrap(ce.ErrInvalidArgument, err)
+	}
+
+	fm, err := m.Queries.GetFileById(ctx, fileId)
+	if err != nil {
+		return fm, dbservice.MultipartUpload{}, mapDBError(err)
+	}
+
+	if err := checkOwner(fm, requesterId); err != nil {
+		return fm, dbservice.MultipartUpload{}, err
+	}
+
+	mu, err := m.Queries.GetMultipartUpload(ctx, fileId)
+	if err != nil {
+		return fm, mu, ce.Wrap(nil, mapDBError(err)).WithPublic("no multipart upload in progress")
+	}
+	return fm, mu, nil
+}
+
+// Part numbers of a multipart upload of partCount parts that were not uploaded.
+func missingParts(partCount int32, uploaded []md.UploadedPart) []int32 {
+	done := make(map[int32]bool, len(uploaded))
+	for _, p := range uploaded {
+		done[p.PartNumber] = true
+	}
+
+	var missing []int32
+	for n := int32(1); n <= partCount; n++ {
+		if !done[n] {
+			missing = append(missing, n)
+		}
+	}
+	return missing
+}
+
+// Progress of a multipart upload from its uploaded parts, parts beyond its part count ignored.
+func newUploadProgress(mu dbservice.MultipartUpload, uploaded []md.UploadedPart) UploadProgress {
+	progress := UploadProgress{
+		FileId:    mu.FileId,
+		PartSize:  mu.PartSize,
+		PartCount: mu.PartCount,
+		ExpiresAt: mu.ExpiresAt,
+	}
+	for _, p := range uploaded {
+		if p.PartNumber > mu.PartCount {
+			continue
+		}
+		progress.UploadedParts = append(progress.UploadedParts, p.PartNumber)
+		progress.UploadedBytes += p.Size
+	}
+	return progress
+}
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"social-network/services/media/internal/db/dbservice"
+	md "social-network/services/media/internal/models"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	tele "social-network/shared/go/telemetry"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Quarantines a file flagged by the content scanner and reports it to the admins,
+// who list quarantined files with ListQuarantinedFiles.
+// The upload is kept for inspection until the garbage collector removes it after
+// the quarantine retention, no url is issued for it meanwhile.
+// Uploads that could not be quarantined stay pending and are scanned again on the next validation.
+func (m *MediaService) quarantineFile(ctx context.Context,
+	fm dbservice.File, scan md.ScanResult) *ce.Error {
+
+	if err := m.Queries.QuarantineFile(ctx, fm.Id, scan.Signature); err != nil {
+		return ce.Wrap(nil, mapDBError(err), fm)
+	}
+
+	tele.Error(ctx, "Media Service: quarantined file @1 uploaded by @2, flagged as @3. Object @4 @5 kept for inspection",
+		"fileId", fm.Id,
+		"ownerId", fm.OwnerId,
+		"signature", scan.Signature,
+		"bucket", fm.Bucket,
+		"objectKey", fm.ObjectKey,
+	)
+
+	return ce.New(ce.ErrPermissionDenied, ErrQuarantined, fm).
+		WithPublic("file rejected by content scan")
+}
+
+// Most quarantined files listed at once.
+const maxQuarantinedFiles = 500
+
+// A file quarantined by the content scanner, with the location of the object kept for inspection.
+type QuarantinedFile struct {
+	FileId        ct.Id
+	OwnerId       ct.Id // zero when unknown
+	Filename      string
+	MimeType      string
+	SizeBytes     int64
+	Signature     string // the scanner flagged the file with
+	Bucket        string
+	ObjectKey     string
+	QuarantinedAt time.Time
+	DeleteAt      time.Time // collected by the garbage collector after the quarantine retention
+}
+
+// Lists quarantined files in id order after afterId, for the admins.
+func (m *MediaService) ListQuarantinedFiles(ctx context.Context,
+	afterId ct.Id, limit int32) ([]QuarantinedFile, error) {
+	input := fmt.Sprintf("after id: %d, limit: %d", afterId, limit)
+
+	if limit < 1 || limit > maxQuarantinedFiles {
+		return nil, ce.New(ce.ErrInvalidArgument, ErrReqValidation, input).
+			WithPublic(fmt.Sprintf("limit must be between 1 and %d", maxQuarantinedFiles))
+	}
+
+	rows, err := m.Queries.GetQuarantinedFiles(ctx, afterId, limit)
+	if err != nil {
+		return nil, ce.Wrap(nil, mapDBError(err), input)
+	}
+
+	files := make([]QuarantinedFile, 0, len(rows))
+	for _, qf := range rows {
+		files = append(files, QuarantinedFile{
+			FileId:        qf.Id,
+			OwnerId:       qf.OwnerId,
+			Filename:      qf.Filename,
+			MimeType:      qf.MimeType,
+			SizeBytes:     qf.SizeBytes,
+			Signature:     qf.ScanSignature,
+			Bucket:        qf.Bucket,
+			ObjectKey:     qf.ObjectKey,
+			QuarantinedAt: qf.QuarantinedAt,
+			DeleteAt:      qf.QuarantinedAt.Add(m.Cfgs.GC.QuarantineRetention),
+		})
+	}
+	return files, nil
+}
+
+// Counters of the content scanner, exported with the telemetry of the service.
+// Quarantined uploads are counted with the result infected.
+type scanMetrics struct {
+	results metric.Int64Counter
+}
+
+func newScanMetrics() (scanMetrics, error) {
+	results, err := otel.Meter("media").Int64Counter("media.scan.results",
+		metric.WithDescription("Uploads scanned by result, clean, infected or error"))
+	return scanMetrics{results: results}, err
+}
+
+func (s scanMetrics) record(ctx context.Context, scan md.ScanResult, err *ce.Error) {
+	result := "clean"
+	switch {
+	case err != nil:
+		result = "error"
+	case scan.Infected:
+		result = "infected"
+	}
+	s.results.Add(ctx, 1, metric.WithAttributes(attribute.String("result", result)))
+}
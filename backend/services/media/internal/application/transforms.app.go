@@ -0,0 +1,273 @@
+package application
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"social-network/services/media/internal/db/dbservice"
+	md "social-network/services/media/internal/models"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	tele "social-network/shared/go/telemetry"
+	"strings"
+	"time"
+)
+
+// Transform requested by a client. Zero fields take their defaults.
+type TransformReq struct {
+	Width  int
+	Height int
+	Fit    string
+	Format string
+	Preset ct.FileVariant // named image variant whose transform is used instead, optional
+}
+
+// Signs a transform of an image for a requester allowed to download it.
+// The signature covers the image, the normalized transform and the expiry, which is
+// capped by the download url expiration of the image visibility.
+// Returns the normalized transform, to be passed as is to GetImageTransform.
+func (m *MediaService) SignImageTransform(ctx context.Context,
+	imgId ct.Id,
+	req TransformReq,
+	requesterId ct.Id,
+	exp time.Duration,
+) (t md.Transform, expiresAt int64, signature string, err error) {
+
+	input := fmt.Sprintf("id: %d transform: %#v requester: %d exp: %v", imgId, req, requesterId, exp)
+
+	if err := imgId.Validate(); err != nil {
+		return t, 0, "", ce.Wrap(ce.ErrInvalidArgument, err, input)
+	}
+
+	t, err = m.normalizeTransform(req)
+	if err != nil {
+		return t, 0, "", ce.Wrap(nil, err, input)
+	}
+
+	fm, err := m.Queries.GetFileById(ctx, imgId)
+	if err != nil {
+		return t, 0, "", ce.Wrap(nil, mapDBError(err), input)
+	}
+	if err := parseFileStatus(fm); err != nil {
+		return t, 0, "", ce.Wrap(nil, err, input)
+	}
+	if !strings.HasPrefix(fm.MimeType, "image/") {
+		return t, 0, "", ce.New(ce.ErrInvalidArgument, ErrInvalidTransform, input).
+			WithPublic("only images can be transformed")
+	}
+
+	canDownload, err := m.canDownload(ctx, fm, requesterId)
+	if err != nil {
+		return t, 0, "", ce.Wrap(nil, err, input)
+	}
+	if !canDownload {
+		return t, 0, "", ce.New(ce.ErrPermissionDenied, ErrPermissionDenied, input).
+			WithPublic("you don't have permission to view this image")
+	}
+
+	maxExp := m.urlExpiry(fm.Visibility)
+	if exp <= 0 || exp > maxExp {
+		exp = maxExp
+	}
+	expiresAt = time.Now().Add(exp).Unix()
+
+	return t, expiresAt, m.signTransform(imgId, t, expiresAt), nil
+}
+
+// Returns a download URL of a signed image transform.
+// Transforms of a named variant are served from the variant once complete,
+// from the original while the variant worker has yet to generate it.
+// Other transforms are generated on the first request and cached in the variants bucket,
+// up to the configured number of transforms per file.
+func (m *MediaService) GetImageTransform(ctx context.Context,
+	imgId ct.Id,
+	req TransformReq,
+	expiresAt int64,
+	signature string,
+) (string, error) {
+
+	input := fmt.Sprintf("id: %d transform: %#v expires: %d", imgId, req, expiresAt)
+
+	if err := imgId.Validate(); err != nil {
+		return "", ce.Wrap(ce.ErrInvalidArgument, err, input)
+	}
+
+	t, err := m.normalizeTransform(req)
+	if err != nil {
+		return "", ce.Wrap(nil, err, input)
+	}
+
+	expected := m.signTransform(imgId, t, expiresAt)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return "", ce.New(ce.ErrPermissionDenied, ErrInvalidSignature, input).
+			WithPublic("invalid signature")
+	}
+	if time.Now().Unix() > expiresAt {
+		return "", ce.New(ce.ErrPermissionDenied, ErrInvalidSignature, input).
+			WithPublic("url expired")
+	}
+
+	fm, err := m.getTransform(ctx, imgId, t)
+	if err != nil {
+		return "", ce.Wrap(nil, err, input)
+	}
+
+	u, err := m.S3.GenerateDownloadURL(
+		ctx, fm.Bucket, fm.ObjectKey, m.urlExpiry(fm.Visibility),
+	)
+	if err != nil {
+		return "", ce.Wrap(ce.ErrInternal, err, input+": s3: generate url")
+	}
+	return u.String(), nil
+}
+
+// Returns the object of a transform, generating it if it is not cached yet.
+// Transforms of a named variant still to be generated by the variant worker
+// are served from the original meanwhile, as GetImage does, without caching a transform.
+func (m *MediaService) getTransform(ctx context.Context,
+	imgId ct.Id,
+	t md.Transform,
+) (dbservice.File, error) {
+
+	if variant, ok := t.Preset(); ok {
+		fm, err := m.Queries.GetVariant(ctx, imgId, variant)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fm, mapDBError(err)
+		}
+		if err == nil {
+			switch fm.Status {
+			case ct.Complete:
+				return fm, nil
+			case ct.Pending, ct.Processing:
+				fm, err = m.Queries.GetFileById(ctx, imgId)
+				if err != nil {
+					return fm, mapDBError(err)
+				}
+				return fm, parseFileStatus(fm)
+			}
+		}
+	}
+
+	fm, err := m.Queries.GetTransform(ctx, imgId, t.Key())
+	if err == nil {
+		return fm, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fm, mapDBError(err)
+	}
+
+	fm, err = m.Queries.GetFileById(ctx, imgId)
+	if err != nil {
+		return fm, mapDBError(err)
+	}
+	if err := parseFileStatus(fm); err != nil {
+		return fm, err
+	}
+
+	// Concurrent first requests of distinct transforms may exceed the limit slightly
+	count, err := m.Queries.CountTransforms(ctx, imgId)
+	if err != nil {
+		return fm, mapDBError(err)
+	}
+	if count >= m.Cfgs.FileService.Transforms.MaxPerFile {
+		return fm, ce.New(ce.ErrResourceExhausted, ErrTooManyTransforms, count).
+			WithPublic("too many sizes requested for this image")
+	}
+
+	// Duplicates share the object key of their canonical file, and so its transforms.
+	// Concurrent requests of the same transform write the same object.
+	transform := dbservice.Transform{
+		FileId:    imgId,
+		Spec:      t.Key(),
+		MimeType:  t.MimeType(),
+		Bucket:    m.Cfgs.FileService.Buckets.Variants,
+		ObjectKey: fm.ObjectKey + "/" + t.Key(),
+	}
+	transform.SizeBytes, err = m.S3.GenerateTransform(ctx,
+		fm.Bucket, fm.ObjectKey, transform.Bucket, transform.ObjectKey, t)
+	if err != nil {
+		return fm, err
+	}
+
+	if _, err := m.Queries.CreateTransform(ctx, transform); err != nil {
+		return fm, mapDBError(err)
+	}
+	tele.Info(ctx, "Generated transform @1 of file @2", "transform", transform.Spec, "fileId", imgId)
+
+	fm.MimeType = transform.MimeType
+	fm.SizeBytes = transform.SizeBytes
+	fm.Bucket = transform.Bucket
+	fm.ObjectKey = transform.ObjectKey
+	return fm, nil
+}
+
+// Resolves the preset, applies the defaults and checks the transform against the limits.
+// Fit contain without width or height is bounded by the limit on that side only.
+func (m *MediaService) normalizeTransform(req TransformReq) (md.Transform, error) {
+	if req.Preset != "" {
+		t, ok := md.PresetTransform(req.Preset)
+		if !ok {
+			return t, ce.New(ce.ErrInvalidArgument, ErrInvalidTransform, req).
+				WithPublic(fmt.Sprintf("invalid preset %v", req.Preset))
+		}
+		return t, nil
+	}
+
+	limits := m.Cfgs.FileService.Transforms
+	t := md.Transform{
+		Width:  req.Width,
+		Height: req.Height,
+		Fit:    req.Fit,
+		Format: req.Format,
+	}
+
+	if t.Fit == "" {
+		t.Fit = md.FitContain
+	}
+	if t.Format == "" {
+		t.Format = md.FormatWebP
+	}
+
+	switch t.Fit {
+	case md.FitContain:
+		if t.Width == 0 {
+			t.Width = limits.MaxWidth
+		}
+		if t.Height == 0 {
+			t.Height = limits.MaxHeight
+		}
+	case md.FitCover:
+		if t.Width == 0 || t.Height == 0 {
+			return t, ce.New(ce.ErrInvalidArgument, ErrInvalidTransform, req).
+				WithPublic("fit cover requires width and height")
+		}
+	default:
+		return t, ce.New(ce.ErrInvalidArgument, ErrInvalidTransform, req).
+			WithPublic(fmt.Sprintf("invalid fit %q", t.Fit))
+	}
+
+	switch t.Format {
+	case md.FormatWebP, md.FormatJPEG, md.FormatPNG:
+	default:
+		return t, ce.New(ce.ErrInvalidArgument, ErrInvalidTransform, req).
+			WithPublic(fmt.Sprintf("invalid format %q", t.Format))
+	}
+
+	if t.Width < 1 || t.Width > limits.MaxWidth || t.Height < 1 || t.Height > limits.MaxHeight {
+		return t, ce.New(ce.ErrInvalidArgument, ErrInvalidTransform, req).
+			WithPublic(fmt.Sprintf("size must be between 1x1 and %vx%v", limits.MaxWidth, limits.MaxHeight))
+	}
+
+	return t, nil
+}
+
+// Hex encoded HMAC-SHA256 of the image id, transform key and expiry.
+func (m *MediaService) signTransform(imgId ct.Id, t md.Transform, expiresAt int64) string {
+	mac := hmac.New(sha256.New, m.transformKey)
+	fmt.Fprintf(mac, "%d:%s:%d", imgId, t.Key(), expiresAt)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -2,20 +2,23 @@ package application
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"social-network/services/media/internal/client"
 	"social-network/services/media/internal/db/dbservice"
 	"social-network/services/media/internal/mapping"
+	md "social-network/services/media/internal/models"
 	ce "social-network/shared/go/commonerrors"
 	ct "social-network/shared/go/ct"
 	tele "social-network/shared/go/telemetry"
 	"time"
 )
 
+// Validates an upload and generates its variants. Owned files can only be validated by their uploader.
 func (m *MediaService) ValidateAndGenerateVariants(ctx context.Context,
-	fileId ct.Id, returnURL bool) (url string, err error) {
-	input := fmt.Sprintf("file id: %d", fileId)
+	fileId ct.Id, requesterId ct.Id, returnURL bool) (url string, err error) {
+	input := fmt.Sprintf("file id: %d, requester: %d", fileId, requesterId)
 
 	if err := fileId.Validate(); err != nil {
 		return url, ce.Wrap(ce.ErrInvalidArgument, err, input)
@@ -26,7 +29,11 @@ func (m *MediaService) ValidateAndGenerateVariants(ctx context.Context,
 		return "", ce.Wrap(nil, mapDBError(err), input)
 	}
 
-	if fileMeta.Status == ct.Failed {
+	if err := checkOwner(fileMeta, requesterId); err != nil {
+		return "", ce.Wrap(nil, err, input)
+	}
+
+	if fileMeta.Status == ct.Failed || fileMeta.Status == ct.Quarantined {
 		return url, ce.New(ce.ErrNotFound, ErrFailed, input).WithPublic("invalid file")
 	}
 
@@ -35,15 +42,51 @@ func (m *MediaService) ValidateAndGenerateVariants(ctx context.Context,
 		return m.urlOption(ctx, fileMeta, returnURL)
 	}
 
-	variants, err := m.getAllVariants(ctx, fileId)
+	// The original of a multipart upload only exists once the upload is completed
+	if _, err := m.Queries.GetMultipartUpload(ctx, fileId); err == nil {
+		return url, ce.New(ce.ErrFailedPrecondition, ErrUploadInProgress, input).
+			WithPublic("multipart upload not completed")
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return "", ce.Wrap(nil, mapDBError(err), input)
+	}
+
+	// The same content was uploaded before, share its objects
+	deduped, isDup, uploadHash, err := m.dedupUpload(ctx, fileMeta)
 	if err != nil {
-		return url, ce.Wrap(nil, err, input)
+		return "", ce.Wrap(nil, err, input)
+	}
+	if isDup {
+		tele.Info(ctx, "Media Service: @1 is a duplicate upload, marked as Complete", "FileId", fileId)
+		return m.urlOption(ctx, deduped, returnURL)
 	}
 
-	Err := m.S3.ValidateAndCreateVariants(ctx,
-		mapping.DbToModel(fileMeta),
-		variants,
-	)
+	var variants []client.VariantToGenerate
+	var image md.SanitizedImage
+	var hash string // of the bytes validated, the upload url is still valid
+
+	// Scanned before anything is generated from the upload
+	scan, Err := m.S3.ScanUpload(ctx, mapping.DbToModel(fileMeta))
+	m.scanMetrics.record(ctx, scan, Err)
+	switch {
+	case Err != nil:
+	case scan.Infected:
+		return url, ce.Wrap(nil, m.quarantineFile(ctx, fileMeta, scan), input)
+	case isVideo(fileMeta.MimeType):
+		// Transcoding takes too long for a request, the variant worker picks
+		// the variants up once the file is marked complete
+		hash, Err = m.S3.ValidateVideoUpload(ctx, mapping.DbToModel(fileMeta))
+	default:
+		variants, err = m.getAllVariants(ctx, fileId)
+		if err != nil {
+			return url, ce.Wrap(nil, err, input)
+		}
+
+		image, Err = m.S3.ValidateAndCreateVariants(ctx,
+			mapping.DbToModel(fileMeta),
+			variants,
+		)
+		hash = image.ContentHash
+	}
 	if Err != nil {
 		if Err.IsClass(ce.ErrInternal) {
 			return "", ce.Wrap(nil, Err, input)
@@ -58,8 +101,12 @@ func (m *MediaService) ValidateAndGenerateVariants(ctx context.Context,
 		).WithPublic("invalid file")
 	}
 
+	if hash != uploadHash && uploadHash != "" {
+		tele.Warn(ctx, "Media Service: upload @1 changed during validation, recording the hash of the validated content", "FileId", fileId)
+	}
+
 	// Update database with new statuses
-	if err := m.markStatusComplete(ctx, fileId, variants); err != nil {
+	if err := m.markStatusComplete(ctx, fileId, variants, hash, image); err != nil {
 		return "", ce.Wrap(nil, err, input)
 	}
 
@@ -117,13 +164,29 @@ func (m *MediaService) deleteFailedFile(
 
 }
 
-// Marks status on db rows of file and variants as completed.
+// Marks status on db rows of file and variants as completed
+// and records the content hash of the file, if any, for later uploads to match.
+// The size and blurhash of sanitized images are recorded as well.
 func (m *MediaService) markStatusComplete(
 	ctx context.Context,
 	fileID ct.Id,
 	vars []client.VariantToGenerate,
+	hash string,
+	image md.SanitizedImage,
 ) error {
 	return m.txRunner.RunTx(ctx, func(tx *dbservice.Queries) error {
+		if hash != "" {
+			if err := tx.SetContentHash(ctx, fileID, hash); err != nil {
+				return ce.Wrap(ce.ErrInternal, fmt.Errorf("failed to set content hash: %w", err))
+			}
+		}
+
+		if image.SizeBytes > 0 {
+			if err := tx.SetSanitizedImage(ctx, fileID, image.SizeBytes, image.Blurhash); err != nil {
+				return ce.Wrap(ce.ErrInternal, fmt.Errorf("failed to set sanitized image: %w", err))
+			}
+		}
+
 		// Update file
 		if err := tx.UpdateFileStatus(ctx, fileID, ct.Complete); err != nil {
 			tele.Error(
@@ -0,0 +1,35 @@
+package application
+
+import (
+	"context"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	"strings"
+	"time"
+)
+
+// Provides a fileId and an upload url for a video, same as UploadImage.
+// The video variants (mp4, webm, poster) are transcoded by the variant worker
+// once the upload is validated.
+func (m *MediaService) UploadVideo(ctx context.Context,
+	req UploadImageReq,
+	exp time.Duration,
+	variants []ct.FileVariant,
+) (fileId ct.Id, upUrl string, err error) {
+	if err := m.validateUploadRequest(
+		req,
+		exp,
+		variants,
+		m.videoLimits(),
+	); err != nil {
+		return 0, "", ce.Wrap(nil, err, req, variants)
+	}
+
+	return m.createUpload(ctx, req, exp, variants)
+}
+
+// Tells videos from images by the mime type declared on upload,
+// validation checks it against the content.
+func isVideo(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "video/")
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"social-network/shared/gen-go/posts"
+	ct "social-network/shared/go/ct"
+)
+
+// Asks the service owning an entity a file is attached to whether requesterId can see it.
+func (c *Clients) CanViewEntity(
+	ctx context.Context,
+	requesterId ct.Id,
+	entityType ct.AttachmentType,
+	entityId ct.Id,
+) (bool, error) {
+
+	switch entityType {
+	case ct.AttachAvatar, ct.AttachGroup:
+		// shown with the basic info of users and groups to everyone
+		return true, nil
+
+	case ct.AttachPost, ct.AttachComment, ct.AttachEvent:
+		if c.PostsClient == nil {
+			return false, errors.New("posts client is nil")
+		}
+		resp, err := c.PostsClient.CanViewEntity(ctx, &posts.GenericReq{
+			RequesterId: requesterId.Int64(),
+			EntityId:    entityId.Int64(),
+		})
+		if err != nil {
+			return false, err
+		}
+		return resp.GetValue(), nil
+
+	default:
+		// chat messages are only visible to their owner
+		return false, nil
+	}
+}
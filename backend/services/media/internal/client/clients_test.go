@@ -0,0 +1,237 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	_ "golang.org/x/image/webp"
+
+	"social-network/services/media/internal/configs"
+	"social-network/services/media/internal/convertor"
+	md "social-network/services/media/internal/models"
+	"social-network/services/media/internal/scanner"
+	"social-network/services/media/internal/storage"
+	"social-network/services/media/internal/validator"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConstraints = configs.FileConstraints{
+	MaxImageUpload: 1 << 20,
+	MaxWidth:       4096,
+	MaxHeight:      4096,
+	AllowedMIMEs:   map[string]bool{"image/png": true},
+	AllowedExt:     map[string]bool{".png": true},
+}
+
+// Clients of an in-memory storage with the image validator and convertor.
+func newTestClients(t *testing.T) (*Clients, *storage.MemoryStorage) {
+	t.Helper()
+	s := storage.NewMemoryStorage()
+	return &Clients{
+		Configs: configs.FileService{
+			Buckets:         configs.Buckets{Originals: "originals", Variants: "variants"},
+			FileConstraints: testConstraints,
+		},
+		Storage:        s,
+		Validator:      &validator.ImageValidator{Config: testConstraints},
+		ImageConvertor: convertor.NewImageconvertor(testConstraints),
+		Scanner:        scanner.NewLocalScanner(),
+	}, s
+}
+
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := range w {
+		for y := range h {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 0, 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// Puts an upload to the originals bucket and returns its file meta.
+func putUpload(t *testing.T, s Storage, key string, content []byte) md.FileMeta {
+	t.Helper()
+	_, err := s.PutObject(context.Background(), "originals", key,
+		bytes.NewReader(content), int64(len(content)), "image/png")
+	require.NoError(t, err)
+	return md.FileMeta{
+		Id:        1,
+		Filename:  "photo.png",
+		MimeType:  "image/png",
+		SizeBytes: int64(len(content)),
+		Bucket:    "originals",
+		ObjectKey: key,
+	}
+}
+
+func TestValidateAndCreateVariants(t *testing.T) {
+	ctx := context.Background()
+	c, s := newTestClients(t)
+	fm := putUpload(t, s, "abc", testPNG(t, 600, 400))
+
+	variants := []VariantToGenerate{
+		{Id: 2, Bucket: "variants", ObjKey: "abc/thumb", Variant: ct.ImgThumbnail},
+		{Id: 3, Bucket: "variants", ObjKey: "abc/medium", Variant: ct.ImgMedium},
+	}
+	img, Err := c.ValidateAndCreateVariants(ctx, fm, variants)
+	require.Nil(t, Err)
+	assert.NotEmpty(t, img.Blurhash)
+	sum := sha256.Sum256(testPNG(t, 600, 400))
+	assert.Equal(t, hex.EncodeToString(sum[:]), img.ContentHash, "hash of the validated upload")
+
+	original, err := s.StatObject(ctx, "originals", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, original.Size, img.SizeBytes)
+
+	validated, err := c.CheckValidationStatus(ctx, fm)
+	require.NoError(t, err)
+	assert.True(t, validated)
+
+	for _, v := range variants {
+		info, err := s.StatObject(ctx, v.Bucket, v.ObjKey)
+		require.NoError(t, err, v.Variant)
+		assert.Equal(t, "image/webp", info.ContentType)
+		assert.Equal(t, info.Size, v.Size)
+
+		obj, err := s.GetObject(ctx, v.Bucket, v.ObjKey)
+		require.NoError(t, err)
+		_, format, err := image.DecodeConfig(obj)
+		obj.Close()
+		require.NoError(t, err)
+		assert.Equal(t, "webp", format)
+	}
+
+	// validated files are left as is
+	img, Err = c.ValidateAndCreateVariants(ctx, fm, nil)
+	require.Nil(t, Err)
+	assert.Zero(t, img)
+}
+
+func TestValidateAndCreateVariantsRejects(t *testing.T) {
+	ctx := context.Background()
+	c, s := newTestClients(t)
+
+	t.Run("missing upload", func(t *testing.T) {
+		fm := md.FileMeta{Filename: "photo.png", MimeType: "image/png", Bucket: "originals", ObjectKey: "missing"}
+		_, Err := c.ValidateAndCreateVariants(ctx, fm, nil)
+		assert.ErrorIs(t, Err, ce.ErrNotFound)
+	})
+
+	t.Run("size mismatch", func(t *testing.T) {
+		fm := putUpload(t, s, "mismatch", testPNG(t, 10, 10))
+		fm.SizeBytes++
+		_, Err := c.ValidateAndCreateVariants(ctx, fm, nil)
+		assert.ErrorIs(t, Err, ce.ErrPermissionDenied)
+	})
+
+	t.Run("not an image", func(t *testing.T) {
+		fm := putUpload(t, s, "text", []byte("not an image at all"))
+		variants := []VariantToGenerate{{Bucket: "variants", ObjKey: "text/thumb", Variant: ct.ImgThumbnail}}
+		_, Err := c.ValidateAndCreateVariants(ctx, fm, variants)
+		assert.ErrorIs(t, Err, ce.ErrPermissionDenied)
+
+		validated, err := c.CheckValidationStatus(ctx, fm)
+		require.NoError(t, err)
+		assert.False(t, validated)
+		_, err = s.StatObject(ctx, "variants", "text/thumb")
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+}
+
+func TestGenerateVariant(t *testing.T) {
+	ctx := context.Background()
+	c, s := newTestClients(t)
+	putUpload(t, s, "abc", testPNG(t, 600, 400))
+
+	size, err := c.GenerateVariant(ctx, "originals", "abc", "variants", "abc/small", ct.ImgSmall)
+	require.NoError(t, err)
+
+	info, err := s.StatObject(ctx, "variants", "abc/small")
+	require.NoError(t, err)
+	assert.Equal(t, info.Size, size)
+	assert.Equal(t, "image/webp", info.ContentType)
+
+	_, err = c.GenerateVariant(ctx, "originals", "missing", "variants", "missing/small", ct.ImgSmall)
+	assert.ErrorIs(t, err, ce.ErrNotFound)
+}
+
+func TestScanUpload(t *testing.T) {
+	ctx := context.Background()
+	c, s := newTestClients(t)
+
+	res, Err := c.ScanUpload(ctx, putUpload(t, s, "clean", testPNG(t, 10, 10)))
+	require.Nil(t, Err)
+	assert.False(t, res.Infected)
+
+	res, Err = c.ScanUpload(ctx, putUpload(t, s, "eicar", []byte(scanner.EICAR)))
+	require.Nil(t, Err)
+	assert.True(t, res.Infected)
+
+	_, Err = c.ScanUpload(ctx, md.FileMeta{Bucket: "originals", ObjectKey: "missing"})
+	assert.ErrorIs(t, Err, ce.ErrNotFound)
+}
+
+func TestHashObject(t *testing.T) {
+	ctx := context.Background()
+	c, s := newTestClients(t)
+	putUpload(t, s, "abc", []byte("content"))
+
+	hash, size, err := c.HashObject(ctx, "originals", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), size)
+	assert.Equal(t, "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", hash)
+
+	_, _, err = c.HashObject(ctx, "originals", "missing")
+	assert.ErrorIs(t, err, ce.ErrNotFound)
+}
+
+func TestWithoutMultipart(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestClients(t)
+
+	_, err := c.NewMultipartUpload(ctx, "originals", "abc", "video/mp4")
+	assert.ErrorIs(t, err, ce.ErrUnimplemented)
+
+	uploads, err := c.ListMultipartUploads(ctx, "originals")
+	require.NoError(t, err)
+	assert.Empty(t, uploads)
+	assert.NoError(t, c.AbortMultipartUpload(ctx, "originals", "abc", "upload"))
+}
+
+// Files are copied through temp files for ffmpeg, the copies must match the objects.
+func TestDownloadObject(t *testing.T) {
+	ctx := context.Background()
+	c, s := newTestClients(t)
+	content := strings.Repeat("video", 1000)
+	putUpload(t, s, "video", []byte(content))
+
+	path := t.TempDir() + "/original"
+	require.NoError(t, c.downloadObject(ctx, "originals", "video", path))
+
+	info, err := c.uploadFile(ctx, "variants", "video/mp4", path, "video/mp4")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), info.Size)
+
+	obj, err := s.GetObject(ctx, "variants", "video/mp4")
+	require.NoError(t, err)
+	defer obj.Close()
+	got, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(got))
+}
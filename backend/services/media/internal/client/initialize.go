@@ -4,18 +4,79 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"net/url"
 	"social-network/services/media/internal/configs"
+	md "social-network/services/media/internal/models"
+	"social-network/shared/gen-go/posts"
+	"social-network/shared/gen-go/users"
 	ct "social-network/shared/go/ct"
-
-	"github.com/minio/minio-go/v7"
+	"time"
 )
 
 type Clients struct {
-	Configs           configs.FileService
-	MinIOClient       *minio.Client // replace with proper S3 service on prod
-	PublicMinIOClient *minio.Client // only for development
-	Validator         Validator
-	ImageConvertor    ImageConvertor
+	Configs        configs.FileService
+	Storage        Storage
+	Multipart      MultipartStorage // nil if the storage has no multipart uploads
+	Validator      Validator
+	ImageConvertor ImageConvertor
+	VideoValidator VideoValidator
+	Transcoder     Transcoder
+	Scanner        Scanner
+	PostsClient    posts.PostsServiceClient
+	UsersClient    users.UserServiceClient
+}
+
+// Storage keeps the objects of the buckets, MinIO or the local filesystem.
+// Missing objects return storage.ErrNotFound.
+type Storage interface {
+	// PutObject stores size bytes of r as the object, replacing any object of the key.
+	PutObject(ctx context.Context, bucket, key string, r io.Reader, size int64, contentType string) (md.ObjectInfo, error)
+
+	// GetObject returns the content of an object, closed by the caller.
+	GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+
+	StatObject(ctx context.Context, bucket, key string) (md.ObjectInfo, error)
+
+	// DeleteObject deletes an object, a missing object is no error.
+	DeleteObject(ctx context.Context, bucket, key string) error
+
+	// ListObjects calls fn with the objects of a bucket in key order, in pages of at most
+	// pageSize objects. The next page is listed once fn returns, the first error stops the listing.
+	ListObjects(ctx context.Context, bucket string, pageSize int, fn func([]md.ObjectInfo) error) error
+
+	// PresignGet returns an url downloading the object, for clients.
+	PresignGet(ctx context.Context, bucket, key string, expiry time.Duration) (*url.URL, error)
+
+	// PresignInternalGet returns an url downloading the object, for services.
+	PresignInternalGet(ctx context.Context, bucket, key string, expiry time.Duration) (*url.URL, error)
+
+	// PresignPut returns an url uploading the object, for clients.
+	PresignPut(ctx context.Context, bucket, key string, expiry time.Duration) (*url.URL, error)
+
+	// SetTags replaces the tags of an object.
+	SetTags(ctx context.Context, bucket, key string, tags map[string]string) error
+
+	// GetTags returns the tags of an object, empty if it has none.
+	GetTags(ctx context.Context, bucket, key string) (map[string]string, error)
+}
+
+// MultipartStorage uploads objects in parts, each to its own presigned url.
+// Missing uploads return storage.ErrNoSuchUpload, invalid parts storage.ErrInvalidPart.
+type MultipartStorage interface {
+	NewMultipartUpload(ctx context.Context, bucket, key, contentType string) (uploadId string, err error)
+
+	// PresignPart returns an url uploading a part, for clients.
+	PresignPart(ctx context.Context, bucket, key, uploadId string, partNumber int32, expiry time.Duration) (*url.URL, error)
+
+	// ListParts lists the parts uploaded so far in part number order.
+	ListParts(ctx context.Context, bucket, key, uploadId string) ([]md.UploadedPart, error)
+
+	CompleteMultipartUpload(ctx context.Context, bucket, key, uploadId string, parts []md.UploadedPart) error
+
+	// AbortMultipartUpload deletes the parts of an upload, a missing upload is no error.
+	AbortMultipartUpload(ctx context.Context, bucket, key, uploadId string) error
+
+	ListMultipartUploads(ctx context.Context, bucket string) ([]md.MultipartInfo, error)
 }
 
 type Validator interface {
@@ -29,4 +90,34 @@ type ImageConvertor interface {
 	ConvertImageToVariant(
 		buf []byte, variant ct.FileVariant,
 	) (out bytes.Buffer, err error)
+
+	// TransformImage resizes an image to the transform box and encodes it in the transform format.
+	TransformImage(
+		buf []byte, t md.Transform,
+	) (out bytes.Buffer, err error)
+
+	// SanitizeImage removes the metadata of an image, keeping its format.
+	SanitizeImage(buf []byte) (out []byte, err error)
+
+	// Blurhash returns the blurhash placeholder of an image.
+	Blurhash(buf []byte) (string, error)
+}
+
+type Scanner interface {
+	// Scan reads the content of an upload and reports whether it is flagged as malware.
+	Scan(ctx context.Context, r io.Reader) (md.ScanResult, error)
+}
+
+type VideoValidator interface {
+	// ValidateVideo checks the probed container, codec, duration and dimensions of a video.
+	ValidateVideo(ctx context.Context, info md.VideoInfo) error
+}
+
+type Transcoder interface {
+	// Probe reads the container and stream info of the video file at path.
+	Probe(ctx context.Context, path string) (md.VideoInfo, error)
+
+	// TranscodeToVariant writes the variant of the video file at src to dst.
+	// Posters are written as PNG.
+	TranscodeToVariant(ctx context.Context, src, dst string, variant ct.FileVariant) error
 }
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	md "social-network/services/media/internal/models"
+	"social-network/services/media/internal/storage"
+	ce "social-network/shared/go/commonerrors"
+	"time"
+)
+
+var errNoMultipart = errors.New("storage has no multipart uploads")
+
+// Returns the multipart storage, unimplemented if the storage has none.
+func (c *Clients) multipart(errMsg string) (MultipartStorage, *ce.Error) {
+	if c.Multipart == nil {
+		return nil, ce.New(ce.ErrUnimplemented, errNoMultipart, errMsg).
+			WithPublic("multipart uploads are not supported")
+	}
+	return c.Multipart, nil
+}
+
+// Starts a multipart upload of an object and returns its upload id.
+func (c *Clients) NewMultipartUpload(
+	ctx context.Context,
+	bucket string,
+	objectKey string,
+	mimeType string,
+) (string, error) {
+	errMsg := fmt.Sprintf("S3 client: new multipart upload: bucket: %v object key: %v", bucket, objectKey)
+
+	mp, Err := c.multipart(errMsg)
+	if Err != nil {
+		return "", Err
+	}
+	uploadId, err := mp.NewMultipartUpload(ctx, bucket, objectKey, mimeType)
+	if err != nil {
+		return "", ce.Wrap(ce.ErrInternal, err, errMsg)
+	}
+	return uploadId, nil
+}
+
+// Returns a pre-signed url uploading a part of a multipart upload.
+// The ETag of the part is listed by ListUploadedParts, clients don't need to keep it.
+func (c *Clients) GeneratePartUploadURL(
+	ctx context.Context,
+	bucket string,
+	objectKey string,
+	uploadId string,
+	partNumber int32,
+	expiry time.Duration,
+) (*url.URL, error) {
+	errMsg := fmt.Sprintf("S3 client: generate part upload: bucket: %v object key: %v part: %v", bucket, objectKey, partNumber)
+
+	mp, Err := c.multipart(errMsg)
+	if Err != nil {
+		return nil, Err
+	}
+	url, err := mp.PresignPart(ctx, bucket, objectKey, uploadId, partNumber, expiry)
+	if err != nil {
+		return nil, ce.Wrap(ce.ErrInternal, err, errMsg)
+	}
+	return url, nil
+}
+
+// Lists the parts uploaded so far, in part number order.
+// A missing upload, completed or aborted, is not found.
+func (c *Clients) ListUploadedParts(
+	ctx context.Context,
+	bucket string,
+	objectKey string,
+	uploadId string,
+) ([]md.UploadedPart, error) {
+	errMsg := fmt.Sprintf("S3 client: list parts: bucket: %v object key: %v", bucket, objectKey)
+
+	mp, Err := c.multipart(errMsg)
+	if Err != nil {
+		return nil, Err
+	}
+	parts, err := mp.ListParts(ctx, bucket, objectKey, uploadId)
+	if err != nil {
+		return nil, storageError(err, errMsg)
+	}
+	return parts, nil
+}
+
+// Assembles the object of a multipart upload from its parts.
+func (c *Clients) CompleteMultipartUpload(
+	ctx context.Context,
+	bucket string,
+	objectKey string,
+	uploadId string,
+	parts []md.UploadedPart,
+) error {
+	errMsg := fmt.Sprintf("S3 client: complete multipart upload: bucket: %v object key: %v", bucket, objectKey)
+
+	mp, Err := c.multipart(errMsg)
+	if Err != nil {
+		return Err
+	}
+	if err := mp.CompleteMultipartUpload(ctx, bucket, objectKey, uploadId, parts); err != nil {
+		if errors.Is(err, storage.ErrInvalidPart) {
+			return ce.Wrap(ce.ErrFailedPrecondition, err, errMsg)
+		}
+		return ce.Wrap(ce.ErrInternal, err, errMsg)
+	}
+	return nil
+}
+
+// Aborts a multipart upload and deletes its parts. A missing upload is no error.
+func (c *Clients) AbortMultipartUpload(
+	ctx context.Context,
+	bucket string,
+	objectKey string,
+	uploadId string,
+) error {
+	errMsg := fmt.Sprintf("S3 client: abort multipart upload: bucket: %v object key: %v", bucket, objectKey)
+
+	if c.Multipart == nil {
+		return nil // no upload was ever started
+	}
+	if err := c.Multipart.AbortMultipartUpload(ctx, bucket, objectKey, uploadId); err != nil {
+		return ce.Wrap(ce.ErrInternal, err, errMsg)
+	}
+	return nil
+}
+
+// Lists the multipart uploads in progress of a bucket.
+func (c *Clients) ListMultipartUploads(
+	ctx context.Context,
+	bucket string,
+) ([]md.MultipartInfo, error) {
+	errMsg := fmt.Sprintf("S3 client: list multipart uploads: bucket: %v", bucket)
+
+	if c.Multipart == nil {
+		return nil, nil
+	}
+	uploads, err := c.Multipart.ListMultipartUploads(ctx, bucket)
+	if err != nil {
+		return nil, ce.Wrap(ce.ErrInternal, err, errMsg)
+	}
+	return uploads, nil
+}
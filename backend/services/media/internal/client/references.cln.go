@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"social-network/shared/gen-go/posts"
+	"social-network/shared/gen-go/users"
+	ct "social-network/shared/go/ct"
+)
+
+// Asks the services storing file ids which of fileIds they still use.
+// Posts report images of posts, comments and events, users report avatars and group images.
+func (c *Clients) GetUsedFileIds(
+	ctx context.Context,
+	fileIds ct.Ids,
+) (ct.Ids, error) {
+
+	if c.PostsClient == nil || c.UsersClient == nil {
+		return nil, errors.New("posts and users clients are required")
+	}
+
+	postsResp, err := c.PostsClient.GetUsedImageIds(ctx, &posts.ImageIds{
+		ImageIds: fileIds.Int64(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usersResp, err := c.UsersClient.GetUsedImageIds(ctx, &users.Ids{
+		Ids: fileIds.Int64(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	used := append(
+		ct.FromInt64s(postsResp.GetImageIds()),
+		ct.FromInt64s(usersResp.GetIds())...,
+	)
+	return used.Unique(), nil
+}
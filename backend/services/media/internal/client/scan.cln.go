@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	md "social-network/services/media/internal/models"
+	ce "social-network/shared/go/commonerrors"
+	"strings"
+)
+
+// Streams an upload to the content scanner.
+// Only the upload limit of the file type is read, larger uploads fail validation anyway.
+func (c *Clients) ScanUpload(
+	ctx context.Context,
+	fm md.FileMeta,
+) (md.ScanResult, *ce.Error) {
+	input := fmt.Sprintf("file meta: %#v", fm)
+
+	limit := c.Configs.FileConstraints.MaxImageUpload
+	if strings.HasPrefix(fm.MimeType, "video/") {
+		limit = c.Configs.VideoConstraints.MaxUpload
+	}
+
+	obj, err := c.Storage.GetObject(ctx, fm.Bucket, fm.ObjectKey)
+	if err != nil {
+		return md.ScanResult{}, storageError(err, input) // upload never completed if not found
+	}
+	defer obj.Close()
+
+	res, err := c.Scanner.Scan(ctx, io.LimitReader(obj, limit+1))
+	if err != nil {
+		return res, ce.Wrap(ce.ErrInternal, err, input+": scan")
+	}
+	return res, nil
+}
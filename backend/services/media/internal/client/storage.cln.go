@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	md "social-network/services/media/internal/models"
+	"social-network/services/media/internal/storage"
+	ce "social-network/shared/go/commonerrors"
+	"time"
+)
+
+// Wraps a storage error, missing objects and uploads are not found.
+func storageError(err error, input string) *ce.Error {
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrNoSuchUpload) {
+		return ce.Wrap(ce.ErrNotFound, err, input)
+	}
+	return ce.Wrap(ce.ErrInternal, err, input)
+}
+
+func (c *Clients) GenerateDownloadURL(
+	ctx context.Context,
+	bucket string,
+	objectKey string,
+	expiry time.Duration,
+) (*url.URL, error) {
+	errMsg := fmt.Sprintf("S3 client: generate download: file bucket: %v object key: %v", bucket, objectKey)
+
+	url, err := c.Storage.PresignGet(ctx, bucket, objectKey, expiry)
+	if err != nil {
+		return nil, ce.Wrap(ce.ErrInternal, err, errMsg)
+	}
+
+	return url, nil
+}
+
+// Returns a download url on the internal endpoint, for services fetching objects
+// on behalf of clients, such as the gateway serving public files.
+func (c *Clients) GenerateInternalDownloadURL(
+	ctx context.Context,
+	bucket string,
+	objectKey string,
+	expiry time.Duration,
+) (*url.URL, error) {
+	errMsg := fmt.Sprintf("S3 client: generate internal download: file bucket: %v object key: %v", bucket, objectKey)
+
+	url, err := c.Storage.PresignInternalGet(ctx, bucket, objectKey, expiry)
+	if err != nil {
+		return nil, ce.Wrap(ce.ErrInternal, err, errMsg)
+	}
+	return url, nil
+}
+
+func (c *Clients) GenerateUploadURL(
+	ctx context.Context,
+	bucket string,
+	objectKey string,
+	expiry time.Duration,
+) (*url.URL, error) {
+	errMsg := fmt.Sprintf("S3 client: generate upload: file bucket: %v object key: %v", bucket, objectKey)
+
+	url, err := c.Storage.PresignPut(ctx, bucket, objectKey, expiry)
+	if err != nil {
+		return nil, ce.Wrap(ce.ErrInternal, err, errMsg)
+	}
+
+	return url, nil
+}
+
+// Streams an object through sha256, returns the hex digest and the object size.
+func (c *Clients) HashObject(
+	ctx context.Context,
+	bucket string,
+	objectKey string,
+) (hash string, size int64, err error) {
+	errMsg := fmt.Sprintf("S3 client: hash object: file bucket: %v object key: %v", bucket, objectKey)
+
+	obj, err := c.Storage.GetObject(ctx, bucket, objectKey)
+	if err != nil {
+		return "", 0, storageError(err, errMsg) // upload never completed if not found
+	}
+	defer obj.Close()
+
+	h := sha256.New()
+	size, err = io.Copy(h, obj)
+	if err != nil {
+		return "", 0, ce.Wrap(ce.ErrInternal, err, errMsg)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// Returns the hex sha256 of a local file.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Lists the objects of a bucket page by page, errors of fn are returned as is.
+func (c *Clients) ListObjects(
+	ctx context.Context,
+	bucket string,
+	pageSize int,
+	fn func([]md.ObjectInfo) error,
+) error {
+	errMsg := fmt.Sprintf("S3 client: list objects: bucket: %v", bucket)
+
+	var fnErr error
+	err := c.Storage.ListObjects(ctx, bucket, pageSize, func(page []md.ObjectInfo) error {
+		fnErr = fn(page)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return ce.Wrap(ce.ErrInternal, err, errMsg)
+	}
+
+	return nil
+}
+
+// Returns the info of an object, missing objects are not found.
+func (c *Clients) StatObject(
+	ctx context.Context,
+	bucket string,
+	objectKey string,
+) (md.ObjectInfo, error) {
+	errMsg := fmt.Sprintf("S3 client: stat object: bucket: %v object key: %v", bucket, objectKey)
+
+	info, err := c.Storage.StatObject(ctx, bucket, objectKey)
+	if err != nil {
+		return md.ObjectInfo{}, storageError(err, errMsg)
+	}
+
+	return info, nil
+}
+
+func (c *Clients) DeleteFile(ctx context.Context,
+	bucket string,
+	objectKey string,
+) error {
+	return c.Storage.DeleteObject(ctx, bucket, objectKey)
+}
+
+// Marks an object as validated, unvalidated originals are expired by the storage lifecycle.
+func (c *Clients) markValidated(ctx context.Context, fm md.FileMeta) error {
+	return c.Storage.SetTags(ctx, fm.Bucket, fm.ObjectKey, map[string]string{
+		"validated": "true",
+	})
+}
+
+// Downloads an object to the file at path, for tools needing a seekable file.
+func (c *Clients) downloadObject(ctx context.Context, bucket, objectKey, path string) error {
+	obj, err := c.Storage.GetObject(ctx, bucket, objectKey)
+	if err != nil {
+		return err
+	}
+	defer obj.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, obj); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Uploads the file at path as an object.
+func (c *Clients) uploadFile(ctx context.Context, bucket, objectKey, path, contentType string) (md.ObjectInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return md.ObjectInfo{}, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return md.ObjectInfo{}, err
+	}
+	return c.Storage.PutObject(ctx, bucket, objectKey, f, fi.Size(), contentType)
+}
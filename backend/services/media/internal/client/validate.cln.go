@@ -3,6 +3,8 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -11,27 +13,16 @@ import (
 	ct "social-network/shared/go/ct"
 	tele "social-network/shared/go/telemetry"
 	"strings"
-
-	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/tags"
 )
 
 func (c *Clients) CheckValidationStatus(ctx context.Context,
 	fm md.FileMeta) (bool, error) {
 	errMsg := "s3 client: check validation status"
-	tagging, err := c.MinIOClient.GetObjectTagging(
-		ctx,
-		fm.Bucket,
-		fm.ObjectKey,
-		minio.GetObjectTaggingOptions{},
-	)
+	existingTags, err := c.Storage.GetTags(ctx, fm.Bucket, fm.ObjectKey)
 	if err != nil {
-		if minio.ToErrorResponse(err).Code != "NoSuchTagSet" {
-			return false, ce.Wrap(ce.ErrInternal, err, errMsg)
-		}
+		return false, storageError(err, errMsg)
 	}
 
-	existingTags := tagging.ToMap()
 	if existingTags["validated"] == "true" {
 		return true, nil
 	}
@@ -52,12 +43,7 @@ func (c *Clients) ValidateUpload(
 
 	fileCnstr := c.Configs.FileConstraints
 
-	info, err := c.MinIOClient.StatObject(
-		ctx,
-		fm.Bucket,
-		fm.ObjectKey,
-		minio.StatObjectOptions{},
-	)
+	info, err := c.Storage.StatObject(ctx, fm.Bucket, fm.ObjectKey)
 	if err != nil {
 		return ce.Wrap(ce.ErrNotFound, err, input) // upload never completed
 	}
@@ -74,32 +60,17 @@ func (c *Clients) ValidateUpload(
 		return ce.Wrap(nil, err, input)
 	}
 
-	obj, err := c.MinIOClient.GetObject(ctx, fm.Bucket, fm.ObjectKey, minio.GetObjectOptions{})
+	obj, err := c.Storage.GetObject(ctx, fm.Bucket, fm.ObjectKey)
 	if err != nil {
-		return ce.Wrap(ce.ErrInternal, err)
+		return storageError(err, input)
 	}
 	defer obj.Close()
 	if err := c.Validator.ValidateImage(ctx, obj); err != nil {
 		return ce.Wrap(nil, err, input) // Validate returns customerrors type with public message
 	}
 
-	tagSet, err := tags.NewTags(map[string]string{
-		"validated": "true",
-	}, true,
-	)
-	if err != nil {
-		return ce.Wrap(ce.ErrInternal, err, input)
-	}
-
-	err = c.MinIOClient.PutObjectTagging(
-		ctx,
-		fm.Bucket,
-		fm.ObjectKey,
-		tagSet,
-		minio.PutObjectTaggingOptions{},
-	)
-	if err != nil {
-		return ce.Wrap(ce.ErrInternal, err, input+": putObjectTagging")
+	if err := c.markValidated(ctx, fm); err != nil {
+		return ce.Wrap(ce.ErrInternal, err, input+": set tags")
 	}
 	return nil
 }
@@ -112,83 +83,94 @@ type VariantToGenerate struct {
 	Size    int64
 }
 
-// Validates file and creates all linked variants. If any part of the process returns error it
+// Validates file, replaces it with a copy without metadata and creates all linked variants
+// from that copy. The blurhash of the image and the content hash of the validated bytes
+// are computed on the way, the object may be replaced by the uploader meanwhile.
+// An already validated file is left as is and the returned image is zero.
+// If any part of the process returns error it
 func (c *Clients) ValidateAndCreateVariants(
 	ctx context.Context,
 	fm md.FileMeta,
 	variants []VariantToGenerate,
-) *ce.Error {
+) (image md.SanitizedImage, Err *ce.Error) {
 	input := fmt.Sprintf("file meta: %#v", fm)
 
 	validated, _ := c.CheckValidationStatus(ctx, fm)
 	if validated {
-		return nil
+		return image, nil
 	}
 
 	fileCnstr := c.Configs.FileConstraints
 
-	info, err := c.MinIOClient.StatObject(
-		ctx,
-		fm.Bucket,
-		fm.ObjectKey,
-		minio.StatObjectOptions{},
-	)
+	info, err := c.Storage.StatObject(ctx, fm.Bucket, fm.ObjectKey)
 	if err != nil {
-		return ce.Wrap(ce.ErrNotFound, err, input) // upload never completed
+		return image, ce.Wrap(ce.ErrNotFound, err, input) // upload never completed
 	}
 
 	if err := checkSize(fm.SizeBytes, info.Size, fileCnstr.MaxImageUpload); err != nil {
-		return ce.Wrap(nil, err, input)
+		return image, ce.Wrap(nil, err, input)
 	}
 
 	if err := checkExt(fileCnstr.AllowedExt, fm.Filename); err != nil {
-		return ce.Wrap(nil, err, input)
+		return image, ce.Wrap(nil, err, input)
 	}
 
 	if err := checkMime(fileCnstr.AllowedMIMEs, fm.MimeType); err != nil {
-		return ce.Wrap(nil, err, input)
+		return image, ce.Wrap(nil, err, input)
 	}
 
-	obj, err := c.MinIOClient.GetObject(ctx, fm.Bucket, fm.ObjectKey, minio.GetObjectOptions{})
+	obj, err := c.Storage.GetObject(ctx, fm.Bucket, fm.ObjectKey)
 	if err != nil {
-		return ce.Wrap(ce.ErrInternal, err)
+		return image, storageError(err, input)
 	}
 	defer obj.Close()
 
 	data, err := io.ReadAll(obj)
 	if err != nil {
-		return ce.Wrap(ce.ErrInternal, err, "failed to read original object")
+		return image, ce.Wrap(ce.ErrInternal, err, "failed to read original object")
 	}
+	sum := sha256.Sum256(data)
+	image.ContentHash = hex.EncodeToString(sum[:])
 
 	if err := c.Validator.ValidateImage(ctx, bytes.NewReader(data)); err != nil {
-		return ce.Wrap(nil, err, input) // Validate returns customerrors type with public message
+		return image, ce.Wrap(nil, err, input) // Validate returns customerrors type with public message
 	}
 
 	tele.Debug(ctx, "image validation success", "file meta", fm)
 
-	tagSet, err := tags.NewTags(map[string]string{
-		"validated": "true",
-	}, true,
-	)
+	// Location and device metadata must be gone before the file is complete and gets urls
+	clean, err := c.ImageConvertor.SanitizeImage(data)
 	if err != nil {
-		return ce.Wrap(ce.ErrInternal, err, input)
+		return image, ce.New(ce.ErrInvalidArgument, err, input).WithPublic("invalid image")
+	}
+	if !bytes.Equal(clean, data) {
+		if _, err := c.Storage.PutObject(
+			ctx,
+			fm.Bucket,
+			fm.ObjectKey,
+			bytes.NewReader(clean),
+			int64(len(clean)),
+			fm.MimeType,
+		); err != nil {
+			return image, ce.Wrap(ce.ErrInternal, err, input+": put sanitized object")
+		}
+		tele.Debug(ctx, "image metadata removed", "file meta", fm, "size", len(clean))
 	}
+	image.SizeBytes = int64(len(clean))
 
-	err = c.MinIOClient.PutObjectTagging(
-		ctx,
-		fm.Bucket,
-		fm.ObjectKey,
-		tagSet,
-		minio.PutObjectTaggingOptions{},
-	)
+	image.Blurhash, err = c.ImageConvertor.Blurhash(clean)
 	if err != nil {
-		return ce.Wrap(ce.ErrInternal, err, input+": putObjectTagging")
+		tele.Warn(ctx, "failed to compute blurhash of @1. @2", "objectKey", fm.ObjectKey, "error", err.Error())
 	}
 
-	if err := c.GenVariants(ctx, data, variants); err != nil {
-		return ce.Wrap(nil, err, input)
+	if err := c.markValidated(ctx, fm); err != nil {
+		return image, ce.Wrap(ce.ErrInternal, err, input+": set tags")
 	}
-	return nil
+
+	if err := c.GenVariants(ctx, clean, variants); err != nil {
+		return image, ce.Wrap(nil, err, input)
+	}
+	return image, nil
 }
 
 // Compares s3 object size with promised and max allowed size,
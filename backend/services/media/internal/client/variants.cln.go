@@ -4,11 +4,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	md "social-network/services/media/internal/models"
 	ce "social-network/shared/go/commonerrors"
 	ct "social-network/shared/go/ct"
 	tele "social-network/shared/go/telemetry"
-
-	"github.com/minio/minio-go/v7"
 )
 
 func (c *Clients) GenerateVariant(
@@ -20,10 +19,13 @@ func (c *Clients) GenerateVariant(
 	variant ct.FileVariant,
 ) (size int64, err error) {
 
-	obj, err := c.MinIOClient.GetObject(ctx,
-		srcBucket, srcObjectKey, minio.GetObjectOptions{})
+	if variant.IsVideo() {
+		return c.generateVideoVariant(ctx, srcBucket, srcObjectKey, trgBucket, trgObjectKey, variant)
+	}
+
+	obj, err := c.Storage.GetObject(ctx, srcBucket, srcObjectKey)
 	if err != nil {
-		return 0, ce.Wrap(ce.ErrNotFound, err)
+		return 0, storageError(err, fmt.Sprintf("src: %s/%s", srcBucket, srcObjectKey))
 	}
 	defer obj.Close()
 
@@ -33,22 +35,63 @@ func (c *Clients) GenerateVariant(
 	}
 
 	outBuf, err := c.ImageConvertor.ConvertImageToVariant(data, variant)
+	if err != nil {
+		return 0, ce.Wrap(ce.ErrInternal, err, "failed to convert image")
+	}
+
+	info, err := c.Storage.PutObject(
+		ctx,
+		trgBucket,
+		trgObjectKey,
+		&outBuf,
+		int64(outBuf.Len()),
+		"image/webp",
+	)
+	if err != nil {
+		return 0, ce.Wrap(ce.ErrInternal, err)
+	}
+	return info.Size, nil
+}
+
+// Generates a transform of the source image and puts it to file service.
+func (c *Clients) GenerateTransform(
+	ctx context.Context,
+	srcBucket string,
+	srcObjectKey string,
+	trgBucket string,
+	trgObjectKey string,
+	t md.Transform,
+) (size int64, err error) {
+
+	obj, err := c.Storage.GetObject(ctx, srcBucket, srcObjectKey)
+	if err != nil {
+		return 0, storageError(err, fmt.Sprintf("src: %s/%s", srcBucket, srcObjectKey))
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		return 0, ce.Wrap(ce.ErrInternal, err, "failed to read original object")
+	}
+
+	outBuf, err := c.ImageConvertor.TransformImage(data, t)
+	if err != nil {
+		return 0, ce.Wrap(ce.ErrInvalidArgument, err, "failed to transform image").
+			WithPublic("image cannot be transformed")
+	}
 
-	info, err := c.MinIOClient.PutObject(
+	info, err := c.Storage.PutObject(
 		ctx,
 		trgBucket,
 		trgObjectKey,
 		&outBuf,
 		int64(outBuf.Len()),
-		minio.PutObjectOptions{
-			ContentType: "image/webp",
-		},
+		t.MimeType(),
 	)
-	size = info.Size
 	if err != nil {
 		return 0, ce.Wrap(ce.ErrInternal, err)
 	}
-	return size, nil
+	return info.Size, nil
 }
 
 // Generates all variants in 'variants' argument puts them to file service
@@ -59,15 +102,13 @@ func (c *Clients) GenVariants(ctx context.Context, data []byte, variants []Varia
 		if err != nil {
 			return ce.New(ce.ErrInternal, err, fmt.Sprintf("failed to generate variant: %#v", variants[i]))
 		}
-		info, err := c.MinIOClient.PutObject(
+		info, err := c.Storage.PutObject(
 			ctx,
 			variants[i].Bucket,
 			variants[i].ObjKey,
 			&outBuf,
 			int64(outBuf.Len()),
-			minio.PutObjectOptions{
-				ContentType: "image/webp",
-			},
+			"image/webp",
 		)
 		variants[i].Size = info.Size
 		if err != nil {
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	md "social-network/services/media/internal/models"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	tele "social-network/shared/go/telemetry"
+)
+
+// Returns the content type a variant is stored with.
+func VariantMimeType(v ct.FileVariant) string {
+	switch v {
+	case ct.VidMP4:
+		return "video/mp4"
+	case ct.VidWebM:
+		return "video/webm"
+	default:
+		return "image/webp"
+	}
+}
+
+// Validates an uploaded video. Size, extension and declared mime type are checked first,
+// then the container, codec, duration and dimensions probed from the file itself.
+// Unlike images no variant is created here, transcoding is left to the variant worker.
+// Returns the content hash of the probed file, empty for an already validated file.
+func (c *Clients) ValidateVideoUpload(
+	ctx context.Context,
+	fm md.FileMeta,
+) (hash string, Err *ce.Error) {
+	input := fmt.Sprintf("file meta: %#v", fm)
+
+	validated, _ := c.CheckValidationStatus(ctx, fm)
+	if validated {
+		return "", nil
+	}
+
+	vidCnstr := c.Configs.VideoConstraints
+
+	info, err := c.Storage.StatObject(ctx, fm.Bucket, fm.ObjectKey)
+	if err != nil {
+		return "", ce.Wrap(ce.ErrNotFound, err, input) // upload never completed
+	}
+
+	if err := checkSize(fm.SizeBytes, info.Size, vidCnstr.MaxUpload); err != nil {
+		return "", ce.Wrap(nil, err, input)
+	}
+
+	if err := checkExt(vidCnstr.AllowedExt, fm.Filename); err != nil {
+		return "", ce.Wrap(nil, err, input)
+	}
+
+	if err := checkMime(vidCnstr.AllowedMIMEs, fm.MimeType); err != nil {
+		return "", ce.Wrap(nil, err, input)
+	}
+
+	// ffprobe needs a seekable file, mp4 files often keep their index at the end
+	dir, err := os.MkdirTemp("", "media-video-*")
+	if err != nil {
+		return "", ce.Wrap(ce.ErrInternal, err, input+": create temp dir")
+	}
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "original")
+	if err := c.downloadObject(ctx, fm.Bucket, fm.ObjectKey, src); err != nil {
+		return "", storageError(err, input+": download original")
+	}
+
+	probe, err := c.Transcoder.Probe(ctx, src)
+	if err != nil {
+		return "", ce.Wrap(nil, err, input) // Probe returns customerrors type with public message
+	}
+
+	if err := c.VideoValidator.ValidateVideo(ctx, probe); err != nil {
+		return "", ce.Wrap(nil, err, input) // Validate returns customerrors type with public message
+	}
+
+	tele.Debug(ctx, "video validation success", "file meta", fm, "probe", probe)
+
+	// the uploader may replace the object meanwhile, the hash is of the probed copy
+	hash, err = hashFile(src)
+	if err != nil {
+		return "", ce.Wrap(ce.ErrInternal, err, input+": hash original")
+	}
+
+	if err := c.markValidated(ctx, fm); err != nil {
+		return "", ce.Wrap(ce.ErrInternal, err, input+": set tags")
+	}
+	return hash, nil
+}
+
+// Transcodes a video to the variant and puts it to file service. Poster frames
+// are encoded as WebP by the image convertor like other image variants.
+func (c *Clients) generateVideoVariant(
+	ctx context.Context,
+	srcBucket string,
+	srcObjectKey string,
+	trgBucket string,
+	trgObjectKey string,
+	variant ct.FileVariant,
+) (size int64, err error) {
+	input := fmt.Sprintf("src: %s/%s variant: %s", srcBucket, srcObjectKey, variant)
+
+	dir, err := os.MkdirTemp("", "media-video-*")
+	if err != nil {
+		return 0, ce.Wrap(ce.ErrInternal, err, input+": create temp dir")
+	}
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "original")
+	if err := c.downloadObject(ctx, srcBucket, srcObjectKey, src); err != nil {
+		return 0, storageError(err, input)
+	}
+
+	dst := filepath.Join(dir, variant.String())
+	if err := c.Transcoder.TranscodeToVariant(ctx, src, dst, variant); err != nil {
+		return 0, ce.Wrap(nil, err, input)
+	}
+
+	if variant == ct.VidPoster {
+		frame, err := os.ReadFile(dst)
+		if err != nil {
+			return 0, ce.Wrap(ce.ErrInternal, err, input+": read poster")
+		}
+		outBuf, err := c.ImageConvertor.ConvertImageToVariant(frame, ct.ImgLarge)
+		if err != nil {
+			return 0, ce.Wrap(ce.ErrInternal, err, input+": convert poster")
+		}
+		info, err := c.Storage.PutObject(
+			ctx,
+			trgBucket,
+			trgObjectKey,
+			bytes.NewReader(outBuf.Bytes()),
+			int64(outBuf.Len()),
+			VariantMimeType(variant),
+		)
+		if err != nil {
+			return 0, ce.Wrap(ce.ErrInternal, err, input)
+		}
+		return info.Size, nil
+	}
+
+	info, err := c.uploadFile(ctx, trgBucket, trgObjectKey, dst, VariantMimeType(variant))
+	if err != nil {
+		return 0, ce.Wrap(ce.ErrInternal, err, input)
+	}
+	return info.Size, nil
+}
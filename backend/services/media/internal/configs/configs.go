@@ -7,6 +7,7 @@ type Config struct {
 	DB          Db
 	FileService FileService
 	Clients     Clients
+	GC          GC
 	Tele        Tele
 }
 
@@ -17,6 +18,12 @@ type FileService struct {
 	Secret                string `env:"MINIO_SECRET_KEY"`
 	Buckets               Buckets
 	FileConstraints       FileConstraints
+	VideoConstraints      VideoConstraints
+	Transforms            Transforms
+	Scanner               Scanner
+	Multipart             Multipart
+	Downloads             Downloads
+	Storage               Storage
 	VariantWorkerInterval time.Duration
 }
 
@@ -34,17 +41,91 @@ type FileConstraints struct {
 	MaxHeight      int
 }
 
+type VideoConstraints struct {
+	MaxUpload        int64
+	MaxDuration      time.Duration
+	MaxWidth         int
+	MaxHeight        int
+	AllowedMIMEs     map[string]bool
+	AllowedExt       map[string]bool
+	AllowedFormats   map[string]bool // container names as reported by ffprobe, e.g. mov, mp4, matroska, webm
+	AllowedCodecs    map[string]bool // video codec names as reported by ffprobe, e.g. h264, vp9
+	TranscodeTimeout time.Duration   // per rendition
+	FFmpegPath       string          `env:"FFMPEG_PATH"`
+	FFprobePath      string          `env:"FFPROBE_PATH"`
+}
+
+// Images generated on demand from signed urls
+type Transforms struct {
+	SigningKey string `env:"TRANSFORM_SIGNING_KEY"` // hmac key of the urls, must be shared by all replicas
+	MaxWidth   int
+	MaxHeight  int
+	MaxPerFile int32 // transforms cached per file, named variants served from their variant excluded
+}
+
+// Content scanning of uploads before they are validated
+type Scanner struct {
+	ClamdAddr string        `env:"CLAMD_ADDR"` // unix:///path/to/clamd.sock or tcp://host:port, uploads are not scanned if empty
+	Timeout   time.Duration // of a single scan
+	ChunkSize int           // of the stream sent to clamd, below its StreamMaxLength
+}
+
+// Download urls of complete files
+type Downloads struct {
+	PublicBaseURL string        `env:"PUBLIC_FILES_URL"` // stable urls of public files, served by the gateway. Public files get presigned urls if empty
+	PublicExpiry  time.Duration // of presigned urls of public files
+	PrivateExpiry time.Duration // of presigned urls of private files, longer than the url cache ttl of the services
+	ProxyExpiry   time.Duration // of the urls the gateway fetches public files from
+}
+
+// Uploads sent in parts, each to its own presigned url
+type Multipart struct {
+	PartSize      int64         // of every part but the last, at least 5MB as required by S3
+	PartURLExpiry time.Duration // of a presigned part url, capped by the expiry of the upload
+}
+
+// Backend keeping the objects of the buckets
+type Storage struct {
+	Backend string `env:"STORAGE_BACKEND"` // minio or local, minio if empty
+	Local   LocalStorage
+}
+
+// Objects kept on the local filesystem instead of MinIO, for development and single host setups
+type LocalStorage struct {
+	Root        string `env:"STORAGE_LOCAL_ROOT"`         // directory holding a directory per bucket
+	Addr        string `env:"STORAGE_LOCAL_ADDR"`         // listen address of the handler serving signed urls
+	BaseURL     string `env:"STORAGE_LOCAL_URL"`          // root of the handler as reached by clients
+	InternalURL string `env:"STORAGE_LOCAL_INTERNAL_URL"` // root of the handler as reached by services, BaseURL if empty
+	SigningKey  string `env:"STORAGE_SIGNING_KEY"`        // hmac key of the urls, random if empty
+}
+
 type Server struct {
 	GrpcServerPort string `env:"GRPC_SERVER_PORT"`
 	PprofPort      string `env:"PPROF_PORT"`
 }
 
 type Clients struct {
+	PostsGRPCAddr string `env:"POSTS_GRPC_ADDR"` // checks the visibility of the entities files are attached to
+	UsersGRPCAddr string `env:"USERS_GRPC_ADDR"` // reports the avatars and group images in use
 }
 
 type Db struct {
-	URL                      string `env:"DATABASE_URL"`
-	StaleFilesWorkerInterval time.Duration
+	URL string `env:"DATABASE_URL"`
+}
+
+// Garbage collection of files and objects. Ages are measured from the last change of a row.
+type GC struct {
+	Interval            time.Duration // between collection runs
+	ReconcileInterval   time.Duration // between comparisons of the buckets with the db, the most expensive pass
+	DryRun              bool          `env:"GC_DRY_RUN"` // only report what would be collected
+	BatchSize           int32
+	UploadGrace         time.Duration // kept after the upload url expired, for uploads still in flight
+	StuckAfter          time.Duration // processing variants are marked failed after it
+	FailedRetention     time.Duration // failed files and variants are kept for inspection
+	QuarantineRetention time.Duration // quarantined uploads are kept for the admins to inspect
+	UnreferencedAfter   time.Duration // age of a complete file before services are asked whether they use it
+	RecheckAfter        time.Duration // files found in use are asked about again after it
+	OrphanAfter         time.Duration // age of an object without row before it is deleted
 }
 
 type Tele struct {
@@ -6,13 +6,14 @@ import (
 	"image"
 	"image/gif"
 	_ "image/gif"
-	_ "image/jpeg"
-	_ "image/png"
+	"image/jpeg"
+	"image/png"
 
 	_ "golang.org/x/image/webp"
 
 	"math"
 	"social-network/services/media/internal/configs"
+	md "social-network/services/media/internal/models"
 	ct "social-network/shared/go/ct"
 
 	"github.com/chai2010/webp"
@@ -31,21 +32,23 @@ func NewImageconvertor(c configs.FileConstraints) *ImageConvertor {
 	}
 }
 
-// ConvertImageToVariant reads an image from r, resizes it according to the specified variant,
-// and encodes it as a WebP image. Variants control the target width and height (e.g., large, medium, small, thumbnail).
+// ConvertImageToVariant reads an image from buf, resizes it according to the preset transform
+// of the specified variant (e.g., large, medium, small, thumbnail) and encodes it as a WebP image.
+// Animated gifs are returned as is.
 // Returns a bytes.Buffer containing the converted image or an error if reading, decoding, resizing,
 // or encoding fails. Ensures the input does not exceed the maximum allowed upload size.
 func (i *ImageConvertor) ConvertImageToVariant(
 	buf []byte, variant ct.FileVariant,
 ) (out bytes.Buffer, err error) {
 
-	if int64(len(buf)) > i.Configs.MaxImageUpload {
-		return out, fmt.Errorf("image size exceeds limit")
+	t, ok := md.PresetTransform(variant)
+	if !ok {
+		return out, fmt.Errorf("no preset for variant %v", variant)
 	}
 
-	_, format, err := image.DecodeConfig(bytes.NewReader(buf))
+	format, err := i.checkImage(buf)
 	if err != nil {
-		return out, fmt.Errorf("failed to decode image: %w", err)
+		return out, err
 	}
 
 	// TODO: On upscale convert gifs to mp4
@@ -60,14 +63,69 @@ func (i *ImageConvertor) ConvertImageToVariant(
 		}
 	}
 
+	return i.transform(buf, t)
+}
+
+// TransformImage reads an image from buf, resizes it to fit or cover the transform box
+// and encodes it in the transform format. Images are never upscaled.
+// Animated gifs are rejected, only their first frame could be kept.
+func (i *ImageConvertor) TransformImage(
+	buf []byte, t md.Transform,
+) (out bytes.Buffer, err error) {
+
+	format, err := i.checkImage(buf)
+	if err != nil {
+		return out, err
+	}
+
+	if format == "gif" {
+		isAnimated, err := decodeGif(buf)
+		if err != nil {
+			return out, fmt.Errorf("failed to decode gif %w", err)
+		}
+		if isAnimated {
+			return out, fmt.Errorf("animated gifs cannot be transformed")
+		}
+	}
+
+	return i.transform(buf, t)
+}
+
+func (i *ImageConvertor) checkImage(buf []byte) (format string, err error) {
+	if int64(len(buf)) > i.Configs.MaxImageUpload {
+		return "", fmt.Errorf("image size exceeds limit")
+	}
+
+	_, format, err = image.DecodeConfig(bytes.NewReader(buf))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode image: %w", err)
+	}
+	return format, nil
+}
+
+func (i *ImageConvertor) transform(buf []byte, t md.Transform) (out bytes.Buffer, err error) {
 	img, err := decodeWithOrientation(buf)
 	if err != nil {
 		return out, fmt.Errorf("failed to decode with orientation %w", err)
 	}
 
-	resized := resizeForVariant(img, variant)
+	var resized image.Image
+	switch t.Fit {
+	case md.FitCover:
+		resized = resizeToCover(img, t.Width, t.Height)
+	default:
+		resized = resizeToFit(img, t.Width, t.Height)
+	}
 
-	if err := webp.Encode(&out, resized, &webp.Options{Quality: 80}); err != nil {
+	switch t.Format {
+	case md.FormatJPEG:
+		err = jpeg.Encode(&out, resized, &jpeg.Options{Quality: 85})
+	case md.FormatPNG:
+		err = png.Encode(&out, resized)
+	default:
+		err = webp.Encode(&out, resized, &webp.Options{Quality: 80})
+	}
+	if err != nil {
 		return out, err
 	}
 	return out, nil
@@ -197,10 +255,9 @@ func transformImage(
 	return dst
 }
 
-// Returns and image.Image object resized to the variant specs. If the image dimentions
-// are smaller that the variant specs then the object is returned as is.
-func resizeForVariant(src image.Image, variant ct.FileVariant) image.Image {
-	maxWidth, maxHeight := variantToSize(variant)
+// Returns an image.Image object resized to fit inside maxWidth x maxHeight, keeping the aspect ratio.
+// If the image dimentions are smaller than the box then the object is returned as is.
+func resizeToFit(src image.Image, maxWidth, maxHeight int) image.Image {
 	bounds := src.Bounds()
 	w := bounds.Dx()
 	h := bounds.Dy()
@@ -213,8 +270,8 @@ func resizeForVariant(src image.Image, variant ct.FileVariant) image.Image {
 	ratioH := float64(maxHeight) / float64(h)
 	ratio := math.Min(ratioW, ratioH)
 
-	newW := int(float64(w) * ratio)
-	newH := int(float64(h) * ratio)
+	newW := max(int(float64(w)*ratio), 1)
+	newH := max(int(float64(h)*ratio), 1)
 
 	dst := image.NewRGBA(image.Rect(0, 0, newW, newH))
 
@@ -230,22 +287,39 @@ func resizeForVariant(src image.Image, variant ct.FileVariant) image.Image {
 	return dst
 }
 
-func variantToSize(variant ct.FileVariant) (maxWidth, maxHeight int) {
-	switch variant {
-	case ct.ImgLarge:
-		return 1600, 1600
-
-	case ct.ImgMedium:
-		return 800, 800
+// Returns an image.Image object scaled to fill width x height and cropped around the center.
+// The image is never upscaled, a smaller image is only cropped to the box aspect ratio.
+func resizeToCover(src image.Image, width, height int) image.Image {
+	bounds := src.Bounds()
+	w := bounds.Dx()
+	h := bounds.Dy()
 
-	case ct.ImgSmall:
-		return 400, 400
+	// Largest part of the source with the aspect ratio of the box
+	cropW, cropH := w, int(float64(w)*float64(height)/float64(width))
+	if cropH > h {
+		cropW, cropH = int(float64(h)*float64(width)/float64(height)), h
+	}
+	cropW, cropH = max(cropW, 1), max(cropH, 1)
 
-	case ct.ImgThumbnail:
-		return 150, 150
+	x0 := bounds.Min.X + (w-cropW)/2
+	y0 := bounds.Min.Y + (h-cropH)/2
+	crop := image.Rect(x0, y0, x0+cropW, y0+cropH)
 
-	default:
-		// fallback (treat as medium)
-		return 800, 800
+	newW, newH := width, height
+	if cropW < width {
+		newW, newH = cropW, cropH
 	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, newW, newH))
+
+	draw.CatmullRom.Scale(
+		dst,
+		dst.Bounds(),
+		src,
+		crop,
+		draw.Over,
+		nil,
+	)
+
+	return dst
 }
@@ -0,0 +1,194 @@
+package convertor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"social-network/services/media/internal/configs"
+	md "social-network/services/media/internal/models"
+	ct "social-network/shared/go/ct"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := range w {
+		for y := range h {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 0, 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestTransformImage(t *testing.T) {
+	c := NewImageconvertor(configs.FileConstraints{MaxImageUpload: 10 << 20})
+	src := testPNG(t, 400, 200)
+
+	tests := []struct {
+		name          string
+		transform     md.Transform
+		format        string
+		width, height int
+	}{
+		{"contain", md.Transform{Width: 100, Height: 100, Fit: md.FitContain, Format: md.FormatPNG}, "png", 100, 50},
+		{"cover", md.Transform{Width: 100, Height: 100, Fit: md.FitCover, Format: md.FormatJPEG}, "jpeg", 100, 100},
+		{"no upscale contain", md.Transform{Width: 1000, Height: 1000, Fit: md.FitContain, Format: md.FormatWebP}, "webp", 400, 200},
+		{"no upscale cover", md.Transform{Width: 1000, Height: 1000, Fit: md.FitCover, Format: md.FormatPNG}, "png", 200, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := c.TransformImage(src, tt.transform)
+			require.NoError(t, err)
+
+			cfg, format, err := image.DecodeConfig(bytes.NewReader(out.Bytes()))
+			require.NoError(t, err)
+			assert.Equal(t, tt.format, format)
+			assert.Equal(t, tt.width, cfg.Width)
+			assert.Equal(t, tt.height, cfg.Height)
+		})
+	}
+
+	_, err := c.TransformImage([]byte("not an image"), md.Transform{Width: 10, Height: 10})
+	assert.Error(t, err)
+}
+
+func TestConvertImageToVariant(t *testing.T) {
+	c := NewImageconvertor(configs.FileConstraints{MaxImageUpload: 10 << 20})
+
+	out, err := c.ConvertImageToVariant(testPNG(t, 300, 600), ct.ImgThumbnail)
+	require.NoError(t, err)
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(out.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, "webp", format)
+	assert.Equal(t, 75, cfg.Width)
+	assert.Equal(t, 150, cfg.Height)
+
+	_, err = c.ConvertImageToVariant(testPNG(t, 10, 10), ct.VidMP4)
+	assert.Error(t, err, "videos have no image preset")
+}
+
+// Builds a JPEG with an EXIF segment holding the orientation and a comment.
+func testJPEGWithExif(t *testing.T, w, h int, orientation uint16) []byte {
+	t.Helper()
+	var enc bytes.Buffer
+	require.NoError(t, jpeg.Encode(&enc, image.NewRGBA(image.Rect(0, 0, w, h)), nil))
+
+	// little endian TIFF with a single IFD entry, the orientation
+	tiff := []byte{'I', 'I', 42, 0, 8, 0, 0, 0, 1, 0}
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	exifData := append([]byte("Exif\x00\x00"), tiff...)
+
+	segment := func(marker byte, data []byte) []byte {
+		s := []byte{0xFF, marker}
+		s = binary.BigEndian.AppendUint16(s, uint16(len(data)+2))
+		return append(s, data...)
+	}
+
+	src := enc.Bytes()
+	out := append([]byte{}, src[:2]...)
+	out = append(out, segment(0xE1, exifData)...)
+	out = append(out, segment(0xFE, []byte("taken at home"))...)
+	return append(out, src[2:]...)
+}
+
+func TestSanitizeImage(t *testing.T) {
+	c := NewImageconvertor(configs.FileConstraints{MaxImageUpload: 10 << 20})
+
+	t.Run("jpeg metadata is dropped", func(t *testing.T) {
+		src := testJPEGWithExif(t, 40, 20, 1)
+		require.Equal(t, 1, orientation(src))
+
+		out, err := c.SanitizeImage(src)
+		require.NoError(t, err)
+		assert.Zero(t, orientation(out))
+		assert.NotContains(t, string(out), "Exif")
+		assert.NotContains(t, string(out), "taken at home")
+
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, 40, cfg.Width)
+	})
+
+	t.Run("jpeg orientation is applied", func(t *testing.T) {
+		out, err := c.SanitizeImage(testJPEGWithExif(t, 40, 20, 6))
+		require.NoError(t, err)
+		assert.Zero(t, orientation(out))
+
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, 20, cfg.Width)
+		assert.Equal(t, 40, cfg.Height)
+	})
+
+	t.Run("png text chunks are dropped", func(t *testing.T) {
+		src := testPNG(t, 10, 10)
+		data := []byte("Comment\x00lat 37.97 lon 23.72")
+		chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+		chunk = append(chunk, "tEXt"...)
+		chunk = append(chunk, data...)
+		chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(append([]byte("tEXt"), data...)))
+		const afterIHDR = 8 + 25
+		withText := append(append(append([]byte{}, src[:afterIHDR]...), chunk...), src[afterIHDR:]...)
+		_, err := png.Decode(bytes.NewReader(withText))
+		require.NoError(t, err)
+
+		out, err := c.SanitizeImage(withText)
+		require.NoError(t, err)
+		assert.Equal(t, src, out)
+	})
+
+	_, err := c.SanitizeImage([]byte("not an image"))
+	assert.Error(t, err)
+}
+
+func TestBlurhash(t *testing.T) {
+	c := NewImageconvertor(configs.FileConstraints{MaxImageUpload: 10 << 20})
+
+	solid := func(w, h int) []byte {
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		for x := range w {
+			for y := range h {
+				img.Set(x, y, color.RGBA{127, 0, 255, 255})
+			}
+		}
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, img))
+		return buf.Bytes()
+	}
+
+	decode83 := func(s string) int {
+		v := 0
+		for _, r := range s {
+			v = v*83 + strings.IndexRune(base83, r)
+		}
+		return v
+	}
+
+	hash, err := c.Blurhash(solid(64, 48))
+	require.NoError(t, err)
+	assert.Len(t, hash, 28, "size, max, dc and 11 ac components")
+	assert.Equal(t, "L", hash[:1], "4x3 components")
+	assert.Equal(t, 127<<16|0<<8|255, decode83(hash[2:6]), "average color")
+
+	hash, err = c.Blurhash(solid(48, 64))
+	require.NoError(t, err)
+	assert.Equal(t, "T", hash[:1], "3x4 components")
+}
@@ -0,0 +1,254 @@
+package convertor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/chai2010/webp"
+	"github.com/rwcarlsen/goexif/exif"
+)
+
+var ErrMalformedImage = errors.New("malformed image")
+
+// SanitizeImage removes the metadata of an image, location, device, comments and
+// timestamps included. Segments and chunks carrying metadata are dropped without
+// re-encoding the pixels, except for images with an EXIF orientation, which are
+// re-encoded with the orientation applied, and gifs, which are always re-encoded.
+// Returns the sanitized image in the format of the input.
+func (i *ImageConvertor) SanitizeImage(buf []byte) (out []byte, err error) {
+	format, err := i.checkImage(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "jpeg":
+		return sanitizeJPEG(buf)
+	case "png":
+		return sanitizePNG(buf)
+	case "webp":
+		return sanitizeWebP(buf)
+	case "gif":
+		return sanitizeGIF(buf)
+	}
+	return nil, fmt.Errorf("unsupported image format %v", format)
+}
+
+// JPEG markers kept by sanitizeJPEG. APP0 holds JFIF, APP2 the ICC profile and APP14
+// the Adobe color transform. Other application segments and comments are dropped.
+var keptJPEGAppMarkers = map[byte]bool{
+	0xE0: true,
+	0xE2: true,
+	0xEE: true,
+}
+
+func sanitizeJPEG(buf []byte) ([]byte, error) {
+	if o := orientation(buf); o > 1 {
+		img, err := decodeWithOrientation(buf)
+		if err != nil {
+			return nil, err
+		}
+		var out bytes.Buffer
+		if err := jpeg.Encode(&out, img, &jpeg.Options{Quality: 92}); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
+	}
+
+	if len(buf) < 2 || buf[0] != 0xFF || buf[1] != 0xD8 {
+		return nil, ErrMalformedImage
+	}
+
+	out := make([]byte, 0, len(buf))
+	out = append(out, buf[:2]...)
+
+	for i := 2; i < len(buf); {
+		if buf[i] != 0xFF || i+1 >= len(buf) {
+			return nil, ErrMalformedImage
+		}
+		marker := buf[i+1]
+
+		switch {
+		case marker == 0xFF: // fill byte
+			i++
+			continue
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7): // no length
+			out = append(out, buf[i:i+2]...)
+			i += 2
+			continue
+		case marker == 0xD9: // end of image
+			return append(out, buf[i:i+2]...), nil
+		}
+
+		if i+4 > len(buf) {
+			return nil, ErrMalformedImage
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(buf[i+2:i+4]))
+		if end > len(buf) {
+			return nil, ErrMalformedImage
+		}
+
+		// Start of scan, the entropy coded data up to the end is kept as is
+		if marker == 0xDA {
+			return append(out, buf[i:]...), nil
+		}
+
+		isApp := marker >= 0xE0 && marker <= 0xEF
+		if (!isApp || keptJPEGAppMarkers[marker]) && marker != 0xFE {
+			out = append(out, buf[i:end]...)
+		}
+		i = end
+	}
+	return nil, ErrMalformedImage
+}
+
+// PNG chunks dropped by sanitizePNG, EXIF, text and modification time.
+var droppedPNGChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+func sanitizePNG(buf []byte) ([]byte, error) {
+	const sigLen = 8
+	if len(buf) < sigLen {
+		return nil, ErrMalformedImage
+	}
+
+	out := make([]byte, 0, len(buf))
+	out = append(out, buf[:sigLen]...)
+
+	for i := sigLen; i < len(buf); {
+		if i+8 > len(buf) {
+			return nil, ErrMalformedImage
+		}
+		length := int(binary.BigEndian.Uint32(buf[i : i+4]))
+		typ := string(buf[i+4 : i+8])
+		end := i + 8 + length + 4 // length, type, data, crc
+		if length < 0 || end > len(buf) {
+			return nil, ErrMalformedImage
+		}
+
+		if typ == "eXIf" {
+			if o := orientation(buf[i+8 : i+8+length]); o > 1 {
+				return reencode(buf, o, func(out *bytes.Buffer, img image.Image) error {
+					return png.Encode(out, img)
+				})
+			}
+		}
+		if !droppedPNGChunks[typ] {
+			out = append(out, buf[i:end]...)
+		}
+		i = end
+	}
+	return out, nil
+}
+
+// VP8X flags of the metadata chunks
+const (
+	webpFlagEXIF      = 0x08
+	webpFlagXMP       = 0x04
+	webpFlagAnimation = 0x02
+)
+
+func sanitizeWebP(buf []byte) ([]byte, error) {
+	const headerLen = 12 // RIFF, size, WEBP
+	if len(buf) < headerLen || string(buf[:4]) != "RIFF" || string(buf[8:12]) != "WEBP" {
+		return nil, ErrMalformedImage
+	}
+
+	out := make([]byte, 0, len(buf))
+	out = append(out, buf[:headerLen]...)
+	vp8x := -1 // offset of the VP8X chunk data in out
+
+	for i := headerLen; i < len(buf); {
+		if i+8 > len(buf) {
+			return nil, ErrMalformedImage
+		}
+		fourCC := string(buf[i : i+4])
+		length := int(binary.LittleEndian.Uint32(buf[i+4 : i+8]))
+		end := i + 8 + length + length%2 // chunks are padded to even sizes
+		if length < 0 || end > len(buf) {
+			return nil, ErrMalformedImage
+		}
+
+		switch fourCC {
+		case "EXIF":
+			if o := orientation(buf[i+8 : i+8+length]); o > 1 && !webpIsAnimated(buf) {
+				return reencode(buf, o, func(out *bytes.Buffer, img image.Image) error {
+					return webp.Encode(out, img, &webp.Options{Quality: 90})
+				})
+			}
+		case "XMP ":
+		default:
+			if fourCC == "VP8X" && length > 0 {
+				vp8x = len(out) + 8
+			}
+			out = append(out, buf[i:end]...)
+		}
+		i = end
+	}
+
+	if vp8x >= 0 {
+		out[vp8x] &^= webpFlagEXIF | webpFlagXMP
+	}
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out, nil
+}
+
+// Animated webps have the animation flag set on the VP8X chunk, always the first one.
+func webpIsAnimated(buf []byte) bool {
+	return len(buf) > 20 && string(buf[12:16]) == "VP8X" && buf[20]&webpFlagAnimation != 0
+}
+
+// Gifs carry metadata in comment and application extensions, which the encoder does not write.
+// Frames, delays, disposal and loop count are kept.
+func sanitizeGIF(buf []byte) ([]byte, error) {
+	g, err := gif.DecodeAll(bytes.NewReader(buf))
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := gif.EncodeAll(&out, g); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// Returns the EXIF orientation found in a JPEG or a raw EXIF block, zero if there is none.
+func orientation(buf []byte) int {
+	ex, err := exif.Decode(bytes.NewReader(buf))
+	if err != nil {
+		return 0
+	}
+	tag, err := ex.Get(exif.Orientation)
+	if err != nil {
+		return 0
+	}
+	o, err := tag.Int(0)
+	if err != nil {
+		return 0
+	}
+	return o
+}
+
+// Decodes an image, applies the orientation and encodes it again without metadata.
+func reencode(buf []byte, orientation int, encode func(*bytes.Buffer, image.Image) error) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(buf))
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := encode(&out, applyOrientation(img, orientation)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
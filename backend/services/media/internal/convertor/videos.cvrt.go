@@ -0,0 +1,205 @@
+package convertor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"social-network/services/media/internal/configs"
+	md "social-network/services/media/internal/models"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+)
+
+// Longest side of the transcoded renditions and of the poster frame.
+const maxRenditionSide = 1280
+
+// Scales down, keeping the aspect ratio, so that the longest side fits maxRenditionSide.
+// -2 keeps the other side even as required by yuv420p.
+var scaleFilter = fmt.Sprintf(
+	"scale='if(gte(iw,ih),min(%[1]d,iw),-2)':'if(gte(iw,ih),-2,min(%[1]d,ih))'",
+	maxRenditionSide,
+)
+
+// FFmpegTranscoder probes and transcodes videos by running the ffprobe and ffmpeg binaries.
+type FFmpegTranscoder struct {
+	Configs configs.VideoConstraints
+}
+
+func NewFFmpegTranscoder(c configs.VideoConstraints) *FFmpegTranscoder {
+	if c.FFmpegPath == "" {
+		c.FFmpegPath = "ffmpeg"
+	}
+	if c.FFprobePath == "" {
+		c.FFprobePath = "ffprobe"
+	}
+	return &FFmpegTranscoder{
+		Configs: c,
+	}
+}
+
+// Probe reads the container and stream info of the video file at path.
+// Files ffprobe can't read are rejected with a permission denied error.
+func (t *FFmpegTranscoder) Probe(ctx context.Context, path string) (md.VideoInfo, error) {
+	out, err := t.run(ctx, t.Configs.FFprobePath,
+		"-v", "error",
+		"-print_format", "json",
+		"-show_format",
+		"-show_streams",
+		path,
+	)
+	if err != nil {
+		return md.VideoInfo{}, mapExecError(err, "ffprobe").WithPublic("invalid video file")
+	}
+
+	info, err := parseProbe(out)
+	if err != nil {
+		return md.VideoInfo{}, ce.New(ce.ErrPermissionDenied, err, "ffprobe output").
+			WithPublic("invalid video file")
+	}
+	return info, nil
+}
+
+// TranscodeToVariant writes the variant of the video at src to dst.
+// VidMP4 and VidWebM are web friendly renditions, VidPoster is a PNG frame to be encoded by the image convertor.
+func (t *FFmpegTranscoder) TranscodeToVariant(
+	ctx context.Context, src, dst string, variant ct.FileVariant,
+) error {
+	args, err := transcodeArgs(src, dst, variant)
+	if err != nil {
+		return ce.New(ce.ErrInvalidArgument, err)
+	}
+	if _, err := t.run(ctx, t.Configs.FFmpegPath, args...); err != nil {
+		return mapExecError(err, fmt.Sprintf("ffmpeg: variant %s", variant))
+	}
+	return nil
+}
+
+// Runs a binary with the configured timeout returning its stdout.
+// Stderr is added to the error on failure.
+func (t *FFmpegTranscoder) run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	if t.Configs.TranscodeTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.Configs.TranscodeTimeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%s: %w", name, ctx.Err())
+		}
+		return nil, fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// A binary that ran and failed means a bad input file, anything else is internal.
+func mapExecError(err error, msg string) *ce.Error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return ce.New(ce.ErrPermissionDenied, err, msg)
+	}
+	return ce.New(ce.ErrInternal, err, msg)
+}
+
+// ffmpeg arguments for each video variant.
+func transcodeArgs(src, dst string, variant ct.FileVariant) ([]string, error) {
+	args := []string{"-y", "-v", "error", "-i", src}
+
+	switch variant {
+	case ct.VidMP4:
+		args = append(args,
+			"-map", "0:v:0", "-map", "0:a:0?",
+			"-vf", scaleFilter,
+			"-c:v", "libx264", "-preset", "veryfast", "-crf", "23", "-pix_fmt", "yuv420p",
+			"-c:a", "aac", "-b:a", "128k",
+			"-movflags", "+faststart",
+			"-f", "mp4",
+		)
+	case ct.VidWebM:
+		args = append(args,
+			"-map", "0:v:0", "-map", "0:a:0?",
+			"-vf", scaleFilter,
+			"-c:v", "libvpx-vp9", "-b:v", "0", "-crf", "33",
+			"-deadline", "realtime", "-cpu-used", "8", "-row-mt", "1",
+			"-pix_fmt", "yuv420p",
+			"-c:a", "libopus", "-b:a", "96k",
+			"-f", "webm",
+		)
+	case ct.VidPoster:
+		// thumbnail picks the most representative of the first frames, skipping black intros
+		args = append(args,
+			"-map", "0:v:0",
+			"-vf", "thumbnail,"+scaleFilter,
+			"-frames:v", "1",
+			"-c:v", "png",
+			"-f", "image2",
+		)
+	default:
+		return nil, fmt.Errorf("not a video variant: %q", variant)
+	}
+
+	return append(args, dst), nil
+}
+
+// ffprobe -print_format json output, only the fields used.
+type probeOutput struct {
+	Format struct {
+		FormatName string `json:"format_name"`
+		Duration   string `json:"duration"`
+	} `json:"format"`
+	Streams []struct {
+		CodecType string `json:"codec_type"`
+		CodecName string `json:"codec_name"`
+		Width     int    `json:"width"`
+		Height    int    `json:"height"`
+	} `json:"streams"`
+}
+
+// Converts ffprobe output to VideoInfo using the first video and audio streams.
+func parseProbe(out []byte) (info md.VideoInfo, err error) {
+	var probe probeOutput
+	if err := json.Unmarshal(out, &probe); err != nil {
+		return info, fmt.Errorf("failed to decode probe: %w", err)
+	}
+
+	if probe.Format.FormatName == "" {
+		return info, fmt.Errorf("missing container format")
+	}
+	info.Formats = strings.Split(probe.Format.FormatName, ",")
+
+	if probe.Format.Duration != "" {
+		seconds, err := strconv.ParseFloat(probe.Format.Duration, 64)
+		if err != nil {
+			return info, fmt.Errorf("invalid duration %q: %w", probe.Format.Duration, err)
+		}
+		info.Duration = time.Duration(seconds * float64(time.Second))
+	}
+
+	for _, s := range probe.Streams {
+		switch s.CodecType {
+		case "video":
+			if info.VideoCodec == "" {
+				info.VideoCodec = s.CodecName
+				info.Width = s.Width
+				info.Height = s.Height
+			}
+		case "audio":
+			if info.AudioCodec == "" {
+				info.AudioCodec = s.CodecName
+			}
+		}
+	}
+	return info, nil
+}
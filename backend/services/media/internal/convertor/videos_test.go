@@ -0,0 +1,122 @@
+package convertor
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"social-network/services/media/internal/configs"
+	ct "social-network/shared/go/ct"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProbe(t *testing.T) {
+	out := []byte(`{
+		"streams": [
+			{"codec_type": "audio", "codec_name": "aac"},
+			{"codec_type": "video", "codec_name": "h264", "width": 1920, "height": 1080},
+			{"codec_type": "video", "codec_name": "mjpeg", "width": 320, "height": 240}
+		],
+		"format": {"format_name": "mov,mp4,m4a,3gp,3g2,mj2", "duration": "12.500000"}
+	}`)
+
+	info, err := parseProbe(out)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mov", "mp4", "m4a", "3gp", "3g2", "mj2"}, info.Formats)
+	assert.Equal(t, 12500*time.Millisecond, info.Duration)
+	assert.Equal(t, "h264", info.VideoCodec, "first video stream is used")
+	assert.Equal(t, 1920, info.Width)
+	assert.Equal(t, 1080, info.Height)
+	assert.Equal(t, "aac", info.AudioCodec)
+
+	_, err = parseProbe([]byte(`{"format": {}}`))
+	assert.Error(t, err, "missing container")
+
+	_, err = parseProbe([]byte(`{"format": {"format_name": "webm", "duration": "N/A"}}`))
+	assert.Error(t, err, "invalid duration")
+
+	_, err = parseProbe([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestTranscodeArgs(t *testing.T) {
+	for _, v := range []ct.FileVariant{ct.VidMP4, ct.VidWebM, ct.VidPoster} {
+		args, err := transcodeArgs("in", "out", v)
+		require.NoError(t, err, v)
+		assert.Equal(t, []string{"-y", "-v", "error", "-i", "in"}, args[:5], v)
+		assert.Equal(t, "out", args[len(args)-1], v)
+	}
+
+	_, err := transcodeArgs("in", "out", ct.ImgLarge)
+	assert.Error(t, err)
+}
+
+// Runs the real binaries on a generated clip, skipped when ffmpeg is not installed.
+func TestFFmpegTranscoder(t *testing.T) {
+	for _, bin := range []string{"ffmpeg", "ffprobe"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			t.Skipf("%s not installed", bin)
+		}
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	src := filepath.Join(dir, "src.mp4")
+	gen := exec.CommandContext(ctx, "ffmpeg", "-v", "error",
+		"-f", "lavfi", "-i", "testsrc=duration=2:size=1920x1080:rate=25",
+		"-f", "lavfi", "-i", "sine=duration=2",
+		"-c:v", "libx264", "-pix_fmt", "yuv420p", "-c:a", "aac", "-shortest",
+		src)
+	if out, err := gen.CombinedOutput(); err != nil {
+		t.Skipf("ffmpeg can't generate the test clip: %v: %s", err, out)
+	}
+
+	tr := NewFFmpegTranscoder(configs.VideoConstraints{TranscodeTimeout: time.Minute})
+
+	info, err := tr.Probe(ctx, src)
+	require.NoError(t, err)
+	assert.Contains(t, info.Formats, "mp4")
+	assert.Equal(t, "h264", info.VideoCodec)
+	assert.Equal(t, "aac", info.AudioCodec)
+	assert.Equal(t, 1920, info.Width)
+	assert.InDelta(t, 2*time.Second, info.Duration, float64(200*time.Millisecond))
+
+	for _, tc := range []struct {
+		variant ct.FileVariant
+		format  string
+		codec   string
+	}{
+		{ct.VidMP4, "mp4", "h264"},
+		{ct.VidWebM, "webm", "vp9"},
+	} {
+		dst := filepath.Join(dir, tc.variant.String())
+		require.NoError(t, tr.TranscodeToVariant(ctx, src, dst, tc.variant), tc.variant)
+
+		out, err := tr.Probe(ctx, dst)
+		require.NoError(t, err, tc.variant)
+		assert.Contains(t, out.Formats, tc.format)
+		assert.Equal(t, tc.codec, out.VideoCodec)
+		assert.Equal(t, maxRenditionSide, out.Width, "scaled down to the rendition size")
+		assert.Equal(t, 720, out.Height, "aspect ratio kept")
+	}
+
+	poster := filepath.Join(dir, "poster")
+	require.NoError(t, tr.TranscodeToVariant(ctx, src, poster, ct.VidPoster))
+	frame, err := os.ReadFile(poster)
+	require.NoError(t, err)
+	cfg, err := png.DecodeConfig(bytes.NewReader(frame))
+	require.NoError(t, err)
+	assert.Equal(t, maxRenditionSide, cfg.Width)
+
+	notVideo := filepath.Join(dir, "not-a-video.mp4")
+	require.NoError(t, os.WriteFile(notVideo, []byte("plain text"), 0o600))
+	_, err = tr.Probe(ctx, notVideo)
+	assert.Error(t, err)
+}
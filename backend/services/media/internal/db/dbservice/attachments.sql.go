@@ -0,0 +1,113 @@
+package dbservice
+
+import (
+	"context"
+	"database/sql"
+	ct "social-network/shared/go/ct"
+)
+
+// Sets the owner of a file that has none. A file already owned is left as is.
+// Missing row is not an error
+func (q *Queries) ClaimFile(
+	ctx context.Context,
+	fileId ct.Id,
+	ownerId ct.Id,
+) error {
+
+	const query = `
+		UPDATE files
+		SET owner_id = $2
+		WHERE id = $1
+		  AND owner_id IS NULL
+	`
+
+	_, err := q.db.Exec(ctx, query, fileId, ownerId)
+	return err
+}
+
+// Attaching a file twice to the same entity is not an error
+func (q *Queries) CreateAttachment(
+	ctx context.Context,
+	a Attachment,
+) error {
+
+	const query = `
+		INSERT INTO file_attachments (
+			file_id,
+			entity_type,
+			entity_id
+		)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+
+	_, err := q.db.Exec(ctx, query, a.FileId, a.EntityType, a.EntityId)
+	return err
+}
+
+// No rows is error explicitly
+func (q *Queries) DeleteAttachment(
+	ctx context.Context,
+	a Attachment,
+) error {
+
+	const query = `
+		DELETE FROM file_attachments
+		WHERE file_id = $1
+		  AND entity_type = $2
+		  AND entity_id = $3
+	`
+
+	res, err := q.db.Exec(ctx, query, a.FileId, a.EntityType, a.EntityId)
+	if err != nil {
+		return err
+	}
+
+	if rows := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Missing rows is no error
+func (q *Queries) GetFileAttachments(
+	ctx context.Context,
+	fileId ct.Id,
+) ([]Attachment, error) {
+
+	const query = `
+		SELECT
+			file_id,
+			entity_type,
+			entity_id
+		FROM file_attachments
+		WHERE file_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := q.db.Query(ctx, query, fileId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []Attachment
+	for rows.Next() {
+		var a Attachment
+		if err := rows.Scan(
+			&a.FileId,
+			&a.EntityType,
+			&a.EntityId,
+		); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
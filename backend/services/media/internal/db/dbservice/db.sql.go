@@ -17,18 +17,10 @@ type Queries struct {
 	db DBTX
 }
 
-type Workers struct {
-	db *Queries
-}
-
 func NewQuerier(db DBTX) *Queries {
 	return &Queries{db: db}
 }
 
-func NewWorker(db *Queries) *Workers {
-	return &Workers{db: db}
-}
-
 func (q *Queries) WithTx(tx pgx.Tx) *Queries {
 	return &Queries{
 		db: tx,
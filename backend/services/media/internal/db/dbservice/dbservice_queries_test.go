@@ -2,11 +2,13 @@ package dbservice
 
 import (
 	"context"
+	"database/sql"
 	"os"
 	"path/filepath"
 	ct "social-network/shared/go/ct"
 	postgresql "social-network/shared/go/postgre"
 	"testing"
+	"time"
 
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/google/uuid"
@@ -94,4 +96,367 @@ func TestQuerier(t *testing.T) {
 		err = q.UpdateVariantStatusAndSize(ctx, fileId, ct.Complete, retrieved.SizeBytes/2)
 		require.NoError(t, err)
 	})
+
+	t.Run("ClaimFile, CreateAttachment, GetFileAttachments, DeleteAttachment", func(t *testing.T) {
+		ctx := context.Background()
+		fileId, err := q.CreateFile(ctx, File{
+			Filename:   "avatar.jpg",
+			MimeType:   "image/jpeg",
+			SizeBytes:  1024,
+			Bucket:     "test-bucket",
+			ObjectKey:  uuid.NewString(),
+			Visibility: ct.Private,
+		})
+		require.NoError(t, err)
+
+		retrieved, err := q.GetFileById(ctx, fileId)
+		require.NoError(t, err)
+		require.Zero(t, retrieved.OwnerId)
+
+		// Only the first claim sets the owner
+		require.NoError(t, q.ClaimFile(ctx, fileId, 7))
+		require.NoError(t, q.ClaimFile(ctx, fileId, 8))
+		retrieved, err = q.GetFileById(ctx, fileId)
+		require.NoError(t, err)
+		require.Equal(t, ct.Id(7), retrieved.OwnerId)
+
+		attachment := Attachment{FileId: fileId, EntityType: ct.AttachAvatar, EntityId: 7}
+		require.NoError(t, q.CreateAttachment(ctx, attachment))
+		require.NoError(t, q.CreateAttachment(ctx, attachment))
+
+		attachments, err := q.GetFileAttachments(ctx, fileId)
+		require.NoError(t, err)
+		require.Equal(t, []Attachment{attachment}, attachments)
+
+		require.NoError(t, q.DeleteAttachment(ctx, attachment))
+		require.ErrorIs(t, q.DeleteAttachment(ctx, attachment), sql.ErrNoRows)
+	})
+
+	t.Run("GetCanonicalFileByHash, DedupFile, GetFileRefs, PromoteCanonical", func(t *testing.T) {
+		ctx := context.Background()
+		hash := uuid.NewString()
+		newFile := func(name string) ct.Id {
+			key := uuid.NewString()
+			fileId, err := q.CreateFile(ctx, File{
+				Filename:   name,
+				MimeType:   "image/jpeg",
+				SizeBytes:  1024,
+				Bucket:     "test-bucket",
+				ObjectKey:  key,
+				Visibility: ct.Public,
+			})
+			require.NoError(t, err)
+			_, err = q.CreateVariant(ctx, File{
+				Id:        fileId,
+				MimeType:  "image/webp",
+				SizeBytes: 1024,
+				Variant:   ct.ImgThumbnail,
+				Bucket:    "test-variants",
+				ObjectKey: key + "/thumb",
+				Status:    ct.Pending,
+			})
+			require.NoError(t, err)
+			return fileId
+		}
+
+		canonicalId := newFile("meme.jpg")
+		require.NoError(t, q.UpdateFileStatus(ctx, canonicalId, ct.Complete))
+		require.NoError(t, q.SetContentHash(ctx, canonicalId, hash))
+		require.NoError(t, q.SetSanitizedImage(ctx, canonicalId, 900, "LEHV6nWB2yk8pyo0adR*.7kCMdnj"))
+		dupId := newFile("meme-again.jpg")
+
+		_, err := q.GetCanonicalFileByHash(ctx, hash, canonicalId)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		canonical, err := q.GetCanonicalFileByHash(ctx, hash, dupId)
+		require.NoError(t, err)
+		require.Equal(t, canonicalId, canonical.Id)
+
+		require.NoError(t, q.DeleteVariants(ctx, dupId))
+		require.NoError(t, q.DedupFile(ctx, dupId, canonical, hash))
+
+		dup, err := q.GetFileById(ctx, dupId)
+		require.NoError(t, err)
+		require.Equal(t, ct.Complete, dup.Status)
+		require.Equal(t, canonical.ObjectKey, dup.ObjectKey)
+		require.Equal(t, "meme-again.jpg", dup.Filename)
+		require.Equal(t, int64(900), dup.SizeBytes, "size of the sanitized canonical file")
+
+		dups, err := q.GetFiles(ctx, ct.Ids{dupId})
+		require.NoError(t, err)
+		require.Len(t, dups, 1)
+		require.Equal(t, "LEHV6nWB2yk8pyo0adR*.7kCMdnj", dups[0].Blurhash)
+
+		// Variants are read through the canonical file
+		thumb, err := q.GetVariant(ctx, dupId, ct.ImgThumbnail)
+		require.NoError(t, err)
+		require.Equal(t, canonical.ObjectKey+"/thumb", thumb.ObjectKey)
+
+		ref, count, err := q.GetFileRefs(ctx, canonicalId)
+		require.NoError(t, err)
+		require.Zero(t, ref)
+		require.Equal(t, int32(2), count)
+		ref, _, err = q.GetFileRefs(ctx, dupId)
+		require.NoError(t, err)
+		require.Equal(t, canonicalId, ref)
+
+		// Deleting the canonical file hands its objects over to the duplicate
+		successorId, err := q.PromoteCanonical(ctx, canonicalId)
+		require.NoError(t, err)
+		require.Equal(t, dupId, successorId)
+		_, err = q.GetFileById(ctx, canonicalId)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		ref, count, err = q.GetFileRefs(ctx, dupId)
+		require.NoError(t, err)
+		require.Zero(t, ref)
+		require.Equal(t, int32(1), count)
+		thumb, err = q.GetVariant(ctx, dupId, ct.ImgThumbnail)
+		require.NoError(t, err)
+		require.Equal(t, canonical.ObjectKey+"/thumb", thumb.ObjectKey)
+
+		require.NoError(t, q.DeleteFile(ctx, dupId))
+		require.ErrorIs(t, q.DeleteFile(ctx, dupId), sql.ErrNoRows)
+	})
+
+	t.Run("GetExpiredUploads, GetGCCandidates, GetKnownObjectKeys, MarkObjectFailed", func(t *testing.T) {
+		ctx := context.Background()
+		key := uuid.NewString()
+		fileId, err := q.CreateFile(ctx, File{
+			Filename:   "late.jpg",
+			MimeType:   "image/jpeg",
+			SizeBytes:  1024,
+			Bucket:     "test-bucket",
+			ObjectKey:  key,
+			Visibility: ct.Public,
+		})
+		require.NoError(t, err)
+		variantId, err := q.CreateVariant(ctx, File{
+			Id:        fileId,
+			MimeType:  "image/webp",
+			SizeBytes: 1024,
+			Variant:   ct.ImgThumbnail,
+			Bucket:    "test-variants",
+			ObjectKey: key + "/thumb",
+			Status:    ct.Pending,
+		})
+		require.NoError(t, err)
+
+		contains := func(files []File, id ct.Id) bool {
+			for _, f := range files {
+				if f.Id == id {
+					return true
+				}
+			}
+			return false
+		}
+
+		now := time.Now()
+		require.NoError(t, q.SetUploadExpiry(ctx, fileId, now.Add(time.Minute)))
+		expired, err := q.GetExpiredUploads(ctx, now, fileId-1, 10)
+		require.NoError(t, err)
+		require.False(t, contains(expired, fileId), "upload url still valid")
+		expired, err = q.GetExpiredUploads(ctx, now.Add(time.Hour), fileId-1, 10)
+		require.NoError(t, err)
+		require.True(t, contains(expired, fileId))
+
+		require.NoError(t, q.UpdateFileStatus(ctx, fileId, ct.Complete))
+		later := time.Now().Add(time.Hour)
+		candidates, err := q.GetGCCandidates(ctx, later, now, fileId-1, 10)
+		require.NoError(t, err)
+		require.True(t, contains(candidates, fileId))
+
+		// Files found referenced are not asked about again before the recheck
+		require.NoError(t, q.SetGCChecked(ctx, ct.Ids{fileId}))
+		candidates, err = q.GetGCCandidates(ctx, later, now, fileId-1, 10)
+		require.NoError(t, err)
+		require.False(t, contains(candidates, fileId))
+
+		// No service reports the videos it uses, validated videos are never unreferenced
+		videoId, err := q.CreateFile(ctx, File{
+			Filename:   "clip.mp4",
+			MimeType:   "video/mp4",
+			SizeBytes:  4096,
+			Bucket:     "test-bucket",
+			ObjectKey:  uuid.NewString(),
+			Visibility: ct.Private,
+		})
+		require.NoError(t, err)
+		require.NoError(t, q.UpdateFileStatus(ctx, videoId, ct.Complete))
+		candidates, err = q.GetGCCandidates(ctx, later, now, videoId-1, 10)
+		require.NoError(t, err)
+		require.False(t, contains(candidates, videoId))
+		require.NoError(t, q.DeleteFile(ctx, videoId))
+
+		variants, err := q.GetVariantsByStatus(ctx, ct.Processing, later, variantId-1, 10)
+		require.NoError(t, err)
+		require.NotEmpty(t, variants)
+		require.Equal(t, variantId, variants[0].Id)
+		require.Equal(t, key, variants[0].SrcObjectKey)
+
+		known, err := q.GetKnownObjectKeys(ctx, "test-variants", []string{key + "/thumb", key + "/orphan"})
+		require.NoError(t, err)
+		require.Equal(t, []string{key + "/thumb"}, known)
+
+		marked, err := q.MarkObjectFailed(ctx, "test-bucket", key)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), marked)
+		failed, err := q.GetFilesByStatus(ctx, ct.Failed, later, fileId-1, 10)
+		require.NoError(t, err)
+		require.True(t, contains(failed, fileId))
+
+		require.NoError(t, q.DeleteVariantsById(ctx, []ct.Id{variantId}))
+		_, err = q.GetVariant(ctx, fileId, ct.ImgThumbnail)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		require.NoError(t, q.DeleteFile(ctx, fileId))
+	})
+	t.Run("CreateTransform, GetTransform, CountTransforms, GetAllTransforms", func(t *testing.T) {
+		ctx := context.Background()
+		key := uuid.NewString()
+		fileId, err := q.CreateFile(ctx, File{
+			Filename:   "wide.png",
+			MimeType:   "image/png",
+			SizeBytes:  1024,
+			Bucket:     "test-bucket",
+			ObjectKey:  key,
+			Visibility: ct.Public,
+		})
+		require.NoError(t, err)
+
+		spec := "w300-h200-cover.webp"
+		transform := Transform{
+			FileId:    fileId,
+			Spec:      spec,
+			MimeType:  "image/webp",
+			SizeBytes: 512,
+			Bucket:    "test-variants",
+			ObjectKey: key + "/" + spec,
+		}
+
+		_, err = q.GetTransform(ctx, fileId, spec)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		created, err := q.CreateTransform(ctx, transform)
+		require.NoError(t, err)
+		require.True(t, created)
+		created, err = q.CreateTransform(ctx, transform)
+		require.NoError(t, err)
+		require.False(t, created, "generated concurrently")
+
+		fm, err := q.GetTransform(ctx, fileId, spec)
+		require.NoError(t, err)
+		require.Equal(t, key+"/"+spec, fm.ObjectKey)
+		require.Equal(t, ct.Public, fm.Visibility)
+		require.Equal(t, ct.Complete, fm.Status)
+
+		count, err := q.CountTransforms(ctx, fileId)
+		require.NoError(t, err)
+		require.Equal(t, int32(1), count)
+
+		known, err := q.GetKnownObjectKeys(ctx, "test-variants", []string{key + "/" + spec})
+		require.NoError(t, err)
+		require.Equal(t, []string{key + "/" + spec}, known)
+
+		all, err := q.GetAllTransforms(ctx, fileId)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+
+		// Transforms are deleted with their file
+		require.NoError(t, q.DeleteFile(ctx, fileId))
+		count, err = q.CountTransforms(ctx, fileId)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+
+	t.Run("QuarantineFile, GetFilesByStatus", func(t *testing.T) {
+		ctx := context.Background()
+		key := uuid.NewString()
+		fileId, err := q.CreateFile(ctx, File{
+			Filename:   "invoice.png",
+			MimeType:   "image/png",
+			SizeBytes:  1024,
+			Bucket:     "test-bucket",
+			ObjectKey:  key,
+			Visibility: ct.Public,
+		})
+		require.NoError(t, err)
+		_, err = q.CreateVariant(ctx, File{
+			Id:        fileId,
+			MimeType:  "image/webp",
+			Variant:   ct.ImgThumbnail,
+			Bucket:    "test-variants",
+			ObjectKey: key + "/thumb",
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, q.QuarantineFile(ctx, fileId, "Win.Test.EICAR_HDB-1"))
+
+		fm, err := q.GetFileById(ctx, fileId)
+		require.NoError(t, err)
+		require.Equal(t, ct.Quarantined, fm.Status)
+
+		vs, err := q.GetAllVariants(ctx, fileId)
+		require.NoError(t, err)
+		require.Len(t, vs, 1)
+		for _, v := range vs {
+			require.Equal(t, ct.Failed, v.Status)
+		}
+
+		quarantined, err := q.GetFilesByStatus(ctx, ct.Quarantined, time.Now().Add(time.Minute), fileId-1, 10)
+		require.NoError(t, err)
+		require.NotEmpty(t, quarantined)
+		require.Equal(t, fileId, quarantined[0].Id)
+
+		listed, err := q.GetQuarantinedFiles(ctx, fileId-1, 10)
+		require.NoError(t, err)
+		require.NotEmpty(t, listed)
+		require.Equal(t, fileId, listed[0].Id)
+		require.Equal(t, "Win.Test.EICAR_HDB-1", listed[0].ScanSignature)
+		require.Equal(t, key, listed[0].ObjectKey)
+		require.WithinDuration(t, time.Now(), listed[0].QuarantinedAt, time.Minute)
+
+		listed, err = q.GetQuarantinedFiles(ctx, fileId, 10)
+		require.NoError(t, err)
+		for _, qf := range listed {
+			require.NotEqual(t, fileId, qf.Id)
+		}
+
+		require.ErrorIs(t, q.QuarantineFile(ctx, -1, "sig"), sql.ErrNoRows)
+	})
+
+	t.Run("SetMultipartUpload, GetMultipartUpload, SetUploadProgress, EndMultipartUpload", func(t *testing.T) {
+		ctx := context.Background()
+		fileId, err := q.CreateFile(ctx, File{
+			Filename:   "clip.mp4",
+			MimeType:   "video/mp4",
+			SizeBytes:  20 << 20,
+			Bucket:     "test-bucket",
+			ObjectKey:  uuid.NewString(),
+			Visibility: ct.Public,
+		})
+		require.NoError(t, err)
+
+		_, err = q.GetMultipartUpload(ctx, fileId)
+		require.ErrorIs(t, err, sql.ErrNoRows, "single put upload")
+
+		uploadId := uuid.NewString()
+		require.NoError(t, q.SetMultipartUpload(ctx, fileId, uploadId, 8<<20, 3))
+		require.NoError(t, q.SetUploadProgress(ctx, fileId, 2, 16<<20))
+
+		mu, err := q.GetMultipartUpload(ctx, fileId)
+		require.NoError(t, err)
+		require.Equal(t, uploadId, mu.UploadId)
+		require.Equal(t, int32(3), mu.PartCount)
+		require.Equal(t, int32(2), mu.UploadedParts)
+		require.Equal(t, int64(16<<20), mu.UploadedBytes)
+		require.False(t, mu.ExpiresAt.IsZero())
+
+		known, err := q.GetKnownUploadIds(ctx, []string{uploadId, "unknown"})
+		require.NoError(t, err)
+		require.Equal(t, []string{uploadId}, known)
+
+		require.NoError(t, q.EndMultipartUpload(ctx, fileId))
+		require.ErrorIs(t, q.EndMultipartUpload(ctx, fileId), sql.ErrNoRows, "already ended")
+		_, err = q.GetMultipartUpload(ctx, fileId)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
 }
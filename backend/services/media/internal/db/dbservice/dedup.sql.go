@@ -0,0 +1,286 @@
+package dbservice
+
+import (
+	"context"
+	"database/sql"
+	ct "social-network/shared/go/ct"
+)
+
+// Returns the canonical complete file with the content hash, other than excludeId,
+// and locks it until the end of the transaction.
+// No rows is error
+func (q *Queries) GetCanonicalFileByHash(
+	ctx context.Context,
+	hash string,
+	excludeId ct.Id,
+) (fm File, err error) {
+
+	const query = `
+		SELECT
+			id,
+			filename,
+			mime_type,
+			size_bytes,
+			bucket,
+			object_key,
+			visibility,
+			status,
+			COALESCE(owner_id, 0)
+		FROM files
+		WHERE content_hash = $1
+		  AND canonical_id IS NULL
+		  AND status = 'complete'
+		  AND id <> $2
+		ORDER BY id
+		LIMIT 1
+		FOR UPDATE
+	`
+
+	err = q.db.QueryRow(ctx, query, hash, excludeId).Scan(
+		&fm.Id,
+		&fm.Filename,
+		&fm.MimeType,
+		&fm.SizeBytes,
+		&fm.Bucket,
+		&fm.ObjectKey,
+		&fm.Visibility,
+		&fm.Status,
+		&fm.OwnerId,
+	)
+
+	fm.Variant = ct.Original
+
+	return fm, err
+}
+
+// Records the content hash of a file.
+// No rows is error explicitly
+func (q *Queries) SetContentHash(
+	ctx context.Context,
+	fileId ct.Id,
+	hash string,
+) error {
+
+	const query = `
+		UPDATE files
+		SET content_hash = $2
+		WHERE id = $1
+	`
+
+	res, err := q.db.Exec(ctx, query, fileId, hash)
+	if err != nil {
+		return err
+	}
+
+	if rows := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Points a file at the objects of its canonical file, marks it complete, copies
+// its size and blurhash and adds its reference to the canonical file.
+// The variant rows of the file must be deleted beforehand.
+// No rows is error explicitly
+func (q *Queries) DedupFile(
+	ctx context.Context,
+	fileId ct.Id,
+	canonical File,
+	hash string,
+) error {
+
+	const pointQuery = `
+		UPDATE files f
+		SET
+			canonical_id = c.id,
+			bucket = $3,
+			object_key = $4,
+			content_hash = $5,
+			size_bytes = c.size_bytes,
+			blurhash = c.blurhash,
+			status = 'complete'
+		FROM files c
+		WHERE f.id = $1
+		  AND f.canonical_id IS NULL
+		  AND c.id = $2
+	`
+
+	res, err := q.db.Exec(ctx, pointQuery,
+		fileId,
+		canonical.Id,
+		canonical.Bucket,
+		canonical.ObjectKey,
+		hash,
+	)
+	if err != nil {
+		return err
+	}
+	if rows := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	const refQuery = `
+		UPDATE files
+		SET ref_count = ref_count + 1
+		WHERE id = $1
+	`
+
+	res, err = q.db.Exec(ctx, refQuery, canonical.Id)
+	if err != nil {
+		return err
+	}
+	if rows := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Deletes the variant rows of a file.
+// Missing rows is no error
+func (q *Queries) DeleteVariants(
+	ctx context.Context,
+	fileId ct.Id,
+) error {
+
+	const query = `
+		DELETE FROM file_variants
+		WHERE file_id = $1
+	`
+
+	_, err := q.db.Exec(ctx, query, fileId)
+	return err
+}
+
+// Returns the canonical file of a file, zero if it is canonical itself, and its
+// reference count, and locks the file until the end of the transaction.
+// No rows is error
+func (q *Queries) GetFileRefs(
+	ctx context.Context,
+	fileId ct.Id,
+) (canonicalId ct.Id, refCount int32, err error) {
+
+	const query = `
+		SELECT
+			COALESCE(canonical_id, 0),
+			ref_count
+		FROM files
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	err = q.db.QueryRow(ctx, query, fileId).Scan(&canonicalId, &refCount)
+	return canonicalId, refCount, err
+}
+
+// Removes the reference of a duplicate from its canonical file and deletes the duplicate row.
+// No rows is error explicitly
+func (q *Queries) ReleaseDuplicate(
+	ctx context.Context,
+	fileId ct.Id,
+	canonicalId ct.Id,
+) error {
+
+	const refQuery = `
+		UPDATE files
+		SET ref_count = ref_count - 1
+		WHERE id = $1
+	`
+
+	res, err := q.db.Exec(ctx, refQuery, canonicalId)
+	if err != nil {
+		return err
+	}
+	if rows := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return q.DeleteFile(ctx, fileId)
+}
+
+// Hands the objects of a canonical file that is being deleted, variants and transforms
+// included, over to its oldest duplicate and deletes the canonical row. The other duplicates point at the successor.
+// No rows is error
+func (q *Queries) PromoteCanonical(
+	ctx context.Context,
+	fileId ct.Id,
+) (successorId ct.Id, err error) {
+
+	const successorQuery = `
+		SELECT id
+		FROM files
+		WHERE canonical_id = $1
+		ORDER BY id
+		LIMIT 1
+		FOR UPDATE
+	`
+
+	if err := q.db.QueryRow(ctx, successorQuery, fileId).Scan(&successorId); err != nil {
+		return 0, err
+	}
+
+	// The canonical row points at the successor first so that both are
+	// never canonical with the same object key at once.
+	const handOverQuery = `
+		WITH moved AS (
+			UPDATE file_variants
+			SET file_id = $2
+			WHERE file_id = $1
+		), moved_transforms AS (
+			UPDATE file_transforms
+			SET file_id = $2
+			WHERE file_id = $1
+		)
+		UPDATE files
+		SET canonical_id = $2
+		WHERE canonical_id = $1
+		   OR id = $1
+	`
+
+	if _, err := q.db.Exec(ctx, handOverQuery, fileId, successorId); err != nil {
+		return 0, err
+	}
+
+	const promoteQuery = `
+		UPDATE files
+		SET
+			canonical_id = NULL,
+			ref_count = (SELECT ref_count - 1 FROM files WHERE id = $2)
+		WHERE id = $1
+	`
+
+	if _, err := q.db.Exec(ctx, promoteQuery, successorId, fileId); err != nil {
+		return 0, err
+	}
+
+	if err := q.DeleteFile(ctx, fileId); err != nil {
+		return 0, err
+	}
+
+	return successorId, nil
+}
+
+// Deletes a file row, its variants, transforms and attachments are deleted on cascade.
+// No rows is error explicitly
+func (q *Queries) DeleteFile(
+	ctx context.Context,
+	fileId ct.Id,
+) error {
+
+	const query = `
+		DELETE FROM files
+		WHERE id = $1
+	`
+
+	res, err := q.db.Exec(ctx, query, fileId)
+	if err != nil {
+		return err
+	}
+
+	if rows := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,383 @@
+package dbservice
+
+import (
+	"context"
+	"database/sql"
+	ct "social-network/shared/go/ct"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Records when the upload url of a pending file expires.
+// No rows is error explicitly
+func (q *Queries) SetUploadExpiry(
+	ctx context.Context,
+	fileId ct.Id,
+	expiresAt time.Time,
+) error {
+
+	const query = `
+		UPDATE files
+		SET upload_expires_at = $2
+		WHERE id = $1
+	`
+
+	res, err := q.db.Exec(ctx, query, fileId, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	if rows := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Returns pending files whose upload url expired before the given time, in id order after afterId.
+// Files without recorded expiry expire 24 hours after creation.
+// Missing rows is no error
+func (q *Queries) GetExpiredUploads(
+	ctx context.Context,
+	before time.Time,
+	afterId ct.Id,
+	limit int32,
+) ([]File, error) {
+
+	const query = `
+		SELECT
+			id,
+			filename,
+			mime_type,
+			size_bytes,
+			bucket,
+			object_key,
+			visibility,
+			status,
+			COALESCE(owner_id, 0)
+		FROM files
+		WHERE status = 'pending'
+		  AND COALESCE(upload_expires_at, created_at + interval '24 hours') < $1
+		  AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+
+	rows, err := q.db.Query(ctx, query, before, afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanFiles(rows)
+}
+
+// Returns canonical files in status last updated before the given time, in id order after afterId.
+// Duplicates are left out, they share the objects of their canonical file.
+// Missing rows is no error
+func (q *Queries) GetFilesByStatus(
+	ctx context.Context,
+	status ct.UploadStatus,
+	updatedBefore time.Time,
+	afterId ct.Id,
+	limit int32,
+) ([]File, error) {
+
+	const query = `
+		SELECT
+			id,
+			filename,
+			mime_type,
+			size_bytes,
+			bucket,
+			object_key,
+			visibility,
+			status,
+			COALESCE(owner_id, 0)
+		FROM files
+		WHERE status = $1
+		  AND canonical_id IS NULL
+		  AND updated_at < $2
+		  AND id > $3
+		ORDER BY id
+		LIMIT $4
+	`
+
+	rows, err := q.db.Query(ctx, query, status, updatedBefore, afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanFiles(rows)
+}
+
+// Returns variants in status last updated before the given time, in id order after afterId.
+// Missing rows is no error
+func (q *Queries) GetVariantsByStatus(
+	ctx context.Context,
+	status ct.UploadStatus,
+	updatedBefore time.Time,
+	afterId ct.Id,
+	limit int32,
+) (variants []Variant, err error) {
+
+	const query = `
+		SELECT
+			fv.id,
+			f.id,
+			f.filename,
+			fv.mime_type,
+			COALESCE(fv.size_bytes, 0),
+			fv.bucket,
+			fv.object_key,
+			fv.status,
+			fv.variant,
+			f.visibility,
+			f.bucket,
+			f.object_key
+		FROM file_variants fv
+		JOIN files f ON f.id = fv.file_id
+		WHERE fv.status = $1
+		  AND fv.updated_at < $2
+		  AND fv.id > $3
+		ORDER BY fv.id
+		LIMIT $4
+	`
+
+	rows, err := q.db.Query(ctx, query, status, updatedBefore, afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v Variant
+		if err := rows.Scan(
+			&v.Id,
+			&v.FileId,
+			&v.Filename,
+			&v.MimeType,
+			&v.SizeBytes,
+			&v.Bucket,
+			&v.ObjectKey,
+			&v.Status,
+			&v.Variant,
+			&v.Visibility,
+			&v.SrcBucket,
+			&v.SrcObjectKey,
+		); err != nil {
+			return nil, err
+		}
+		variants = append(variants, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+// Missing rows is no error
+func (q *Queries) SetVariantsStatus(
+	ctx context.Context,
+	ids []ct.Id,
+	status ct.UploadStatus,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	const query = `
+		UPDATE file_variants
+		SET status = $2
+		WHERE id = ANY($1)
+	`
+
+	_, err := q.db.Exec(ctx, query, ids, status)
+	return err
+}
+
+// Missing rows is no error
+func (q *Queries) DeleteVariantsById(
+	ctx context.Context,
+	ids []ct.Id,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	const query = `
+		DELETE FROM file_variants
+		WHERE id = ANY($1)
+	`
+
+	_, err := q.db.Exec(ctx, query, ids)
+	return err
+}
+
+// Returns complete files created before createdBefore that were not found referenced
+// since checkedBefore, in id order after afterId.
+// Files attached to chat messages are left out, chat does not report the files it uses.
+// So are videos, posts and users only report the images they use.
+// Missing rows is no error
+func (q *Queries) GetGCCandidates(
+	ctx context.Context,
+	createdBefore time.Time,
+	checkedBefore time.Time,
+	afterId ct.Id,
+	limit int32,
+) ([]File, error) {
+
+	const query = `
+		SELECT
+			f.id,
+			f.filename,
+			f.mime_type,
+			f.size_bytes,
+			f.bucket,
+			f.object_key,
+			f.visibility,
+			f.status,
+			COALESCE(f.owner_id, 0)
+		FROM files f
+		WHERE f.status = 'complete'
+		  AND f.mime_type NOT LIKE 'video/%'
+		  AND f.created_at < $1
+		  AND (f.gc_checked_at IS NULL OR f.gc_checked_at < $2)
+		  AND f.id > $3
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM file_attachments a
+			WHERE a.file_id = f.id
+			  AND a.entity_type = 'message'
+		  )
+		ORDER BY f.id
+		LIMIT $4
+	`
+
+	rows, err := q.db.Query(ctx, query, createdBefore, checkedBefore, afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanFiles(rows)
+}
+
+// Records that the files were found referenced.
+// Missing rows is no error
+func (q *Queries) SetGCChecked(
+	ctx context.Context,
+	ids ct.Ids,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	const query = `
+		UPDATE files
+		SET gc_checked_at = now()
+		WHERE id = ANY($1)
+	`
+
+	_, err := q.db.Exec(ctx, query, ids)
+	return err
+}
+
+// Returns which of the object keys of a bucket belong to a file, a variant or a transform.
+// Missing rows is no error
+func (q *Queries) GetKnownObjectKeys(
+	ctx context.Context,
+	bucket string,
+	keys []string,
+) (known []string, err error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	const query = `
+		SELECT object_key
+		FROM files
+		WHERE bucket = $1
+		  AND object_key = ANY($2)
+		UNION
+		SELECT object_key
+		FROM file_variants
+		WHERE bucket = $1
+		  AND object_key = ANY($2)
+		UNION
+		SELECT object_key
+		FROM file_transforms
+		WHERE bucket = $1
+		  AND object_key = ANY($2)
+	`
+
+	rows, err := q.db.Query(ctx, query, bucket, keys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		known = append(known, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return known, nil
+}
+
+// Marks failed the files using an object, duplicates included.
+// Missing rows is no error
+func (q *Queries) MarkObjectFailed(
+	ctx context.Context,
+	bucket string,
+	objectKey string,
+) (int64, error) {
+
+	const query = `
+		UPDATE files
+		SET status = 'failed'
+		WHERE bucket = $1
+		  AND object_key = $2
+	`
+
+	res, err := q.db.Exec(ctx, query, bucket, objectKey)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
+
+func scanFiles(rows pgx.Rows) ([]File, error) {
+	defer rows.Close()
+
+	var files []File
+	for rows.Next() {
+		var fm File
+		if err := rows.Scan(
+			&fm.Id,
+			&fm.Filename,
+			&fm.MimeType,
+			&fm.SizeBytes,
+			&fm.Bucket,
+			&fm.ObjectKey,
+			&fm.Visibility,
+			&fm.Status,
+			&fm.OwnerId,
+		); err != nil {
+			return nil, err
+		}
+		fm.Variant = ct.Original
+		files = append(files, fm)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
@@ -21,9 +21,10 @@ func (q *Queries) CreateFile(
 			bucket,
 			object_key,
 			visibility,
-			status
+			status,
+			owner_id
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, 0))
 		RETURNING id
 	`
 
@@ -37,6 +38,7 @@ func (q *Queries) CreateFile(
 		fm.ObjectKey,
 		fm.Visibility,
 		ct.Pending,
+		fm.OwnerId,
 	).Scan(&fileId)
 
 	return fileId, err
@@ -57,7 +59,8 @@ func (q *Queries) GetFileById(
 			bucket,
 			object_key,
 			visibility,
-			status
+			status,
+			COALESCE(owner_id, 0)
 		FROM files
 		WHERE id = $1
 	`
@@ -71,6 +74,7 @@ func (q *Queries) GetFileById(
 		&fm.ObjectKey,
 		&fm.Visibility,
 		&fm.Status,
+		&fm.OwnerId,
 	)
 
 	fm.Variant = ct.Original
@@ -97,7 +101,8 @@ func (q *Queries) GetFiles(
 			bucket,
 			object_key,
 			visibility,
-			status
+			status,
+			COALESCE(blurhash, '')
 		FROM files
 		WHERE id = ANY($1)
 	`
@@ -120,6 +125,7 @@ func (q *Queries) GetFiles(
 			&fm.ObjectKey,
 			&fm.Visibility,
 			&fm.Status,
+			&fm.Blurhash,
 		); err != nil {
 			return nil, err
 		}
@@ -190,9 +196,10 @@ func (q *Queries) GetVariant(
 			v.object_key,
 			f.visibility,
 			v.status,
-			v.variant
+			v.variant,
+			COALESCE(f.owner_id, 0)
 		FROM files f
-		JOIN file_variants v ON v.file_id = f.id
+		JOIN file_variants v ON v.file_id = COALESCE(f.canonical_id, f.id) -- duplicates read the variants of their canonical file
 		WHERE f.id = $1
 		  AND v.variant = $2
 	`
@@ -207,6 +214,7 @@ func (q *Queries) GetVariant(
 		&fm.Visibility,
 		&fm.Status,
 		&fm.Variant,
+		&fm.OwnerId,
 	)
 
 	return fm, err
@@ -229,7 +237,7 @@ func (q *Queries) GetAllVariants(
 			v.status,
 			v.variant
 		FROM files f
-		JOIN file_variants v ON v.file_id = f.id
+		JOIN file_variants v ON v.file_id = COALESCE(f.canonical_id, f.id) -- duplicates read the variants of their canonical file
 		WHERE f.id = $1
 	`
 
@@ -276,9 +284,10 @@ func (q *Queries) GetVariants(
 			v.object_key,
 			f.visibility,
 			v.status,
-			v.variant
+			v.variant,
+			COALESCE(f.blurhash, '')
 		FROM files f
-		JOIN file_variants v ON v.file_id = f.id
+		JOIN file_variants v ON v.file_id = COALESCE(f.canonical_id, f.id) -- duplicates read the variants of their canonical file
 		WHERE f.id = ANY($1)
 		  AND v.variant = $2
 	`
@@ -305,6 +314,7 @@ func (q *Queries) GetVariants(
 			&file.Visibility,
 			&file.Status,
 			&file.Variant,
+			&file.Blurhash,
 		); err != nil {
 			return nil, nil, err
 		}
@@ -414,6 +424,132 @@ func (q *Queries) UpdateFileStatus(
 	return nil
 }
 
+// Records the size of an image after its metadata was removed and its blurhash.
+// No rows is error explicitly
+func (q *Queries) SetSanitizedImage(
+	ctx context.Context,
+	fileId ct.Id,
+	sizeBytes int64,
+	blurhash string,
+) error {
+
+	const query = `
+		UPDATE files
+		SET
+			size_bytes = $2,
+			blurhash = NULLIF($3, '')
+		WHERE id = $1
+	`
+
+	res, err := q.db.Exec(ctx, query, fileId, sizeBytes, blurhash)
+	if err != nil {
+		return err
+	}
+
+	if rows := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Marks a file quarantined with the signature it was flagged with.
+// Its variants are marked failed, they are never generated.
+// No rows is error explicitly
+func (q *Queries) QuarantineFile(
+	ctx context.Context,
+	fileId ct.Id,
+	signature string,
+) error {
+
+	const query = `
+		WITH failed_variants AS (
+			UPDATE file_variants
+			SET status = 'failed'
+			WHERE file_id = $1
+		)
+		UPDATE files
+		SET
+			status = 'quarantined',
+			scan_signature = NULLIF($2, '')
+		WHERE id = $1
+	`
+
+	res, err := q.db.Exec(ctx, query, fileId, signature)
+	if err != nil {
+		return err
+	}
+
+	if rows := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Returns quarantined files in id order after afterId.
+// Missing rows is no error
+func (q *Queries) GetQuarantinedFiles(
+	ctx context.Context,
+	afterId ct.Id,
+	limit int32,
+) ([]QuarantinedFile, error) {
+
+	const query = `
+		SELECT
+			id,
+			filename,
+			mime_type,
+			size_bytes,
+			bucket,
+			object_key,
+			visibility,
+			status,
+			COALESCE(owner_id, 0),
+			COALESCE(scan_signature, ''),
+			updated_at
+		FROM files
+		WHERE status = 'quarantined'
+		  AND id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+
+	rows, err := q.db.Query(ctx, query, afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var files []QuarantinedFile
+	for rows.Next() {
+		var qf QuarantinedFile
+		if err := rows.Scan(
+			&qf.Id,
+			&qf.Filename,
+			&qf.MimeType,
+			&qf.SizeBytes,
+			&qf.Bucket,
+			&qf.ObjectKey,
+			&qf.Visibility,
+			&qf.Status,
+			&qf.OwnerId,
+			&qf.ScanSignature,
+			&qf.QuarantinedAt,
+		); err != nil {
+			return nil, err
+		}
+		qf.Variant = ct.Original
+		files = append(files, qf)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
 // Missing rows is no error
 func (q *Queries) GetPendingVariants(
 	ctx context.Context) (pending []Variant, err error) {
@@ -1,6 +1,9 @@
 package dbservice
 
-import ct "social-network/shared/go/ct"
+import (
+	ct "social-network/shared/go/ct"
+	"time"
+)
 
 // General file meta struct mirroring the files table. In some cases it refers to a file's variant
 // when the Variant field is not missing or marked as Original.
@@ -14,10 +17,19 @@ type File struct {
 
 	Visibility ct.FileVisibility
 	Status     ct.UploadStatus // pending, processing, complete, failed
+	OwnerId    ct.Id           `validation:"nullable"` // uploader, zero when unknown
+	Blurhash   string          // placeholder of images, empty until validated and for videos
 
 	Variant ct.FileVariant `validation:"nullable"` // thumb, small, medium, large, original
 }
 
+// Quarantined file with the signature the content scanner flagged it with.
+type QuarantinedFile struct {
+	File
+	ScanSignature string
+	QuarantinedAt time.Time // last update of the row, the quarantine retention counts from it
+}
+
 // Refers to file_variants table. It contains fields that are joined from file table row
 // when retrieving a variant from db.
 type Variant struct {
@@ -37,3 +49,32 @@ type Variant struct {
 	SrcObjectKey string // the variants origin key
 
 }
+
+// Refers to file_attachments table. The entity of another service using a file.
+type Attachment struct {
+	FileId     ct.Id
+	EntityType ct.AttachmentType // post, comment, event, avatar, group, message
+	EntityId   ct.Id
+}
+
+// Refers to file_transforms table. An image generated on demand from the original of a file.
+type Transform struct {
+	Id        ct.Id  `validation:"nullable"` // transform Id
+	FileId    ct.Id  // the canonical file it was generated from
+	Spec      string // size, fit and format, e.g. w300-h200-cover.webp
+	MimeType  string // content type
+	SizeBytes int64
+	Bucket    string // the variants bucket
+	ObjectKey string // the name given to file in fileservice
+}
+
+// Multipart upload in progress of the original of a pending file, columns of the files table.
+type MultipartUpload struct {
+	FileId        ct.Id
+	UploadId      string // file service upload id
+	PartSize      int64  // size of every part but the last
+	PartCount     int32
+	UploadedParts int32 // progress last listed from file service
+	UploadedBytes int64
+	ExpiresAt     time.Time // parts are not signed after it
+}
@@ -0,0 +1,164 @@
+package dbservice
+
+import (
+	"context"
+	"database/sql"
+	ct "social-network/shared/go/ct"
+)
+
+// Records the multipart upload of the original of a pending file.
+// No rows is error explicitly
+func (q *Queries) SetMultipartUpload(
+	ctx context.Context,
+	fileId ct.Id,
+	uploadId string,
+	partSize int64,
+	partCount int32,
+) error {
+
+	const query = `
+		UPDATE files
+		SET
+			multipart_upload_id = $2,
+			part_size = $3,
+			part_count = $4,
+			uploaded_parts = 0,
+			uploaded_bytes = 0
+		WHERE id = $1
+	`
+
+	res, err := q.db.Exec(ctx, query, fileId, uploadId, partSize, partCount)
+	if err != nil {
+		return err
+	}
+
+	if rows := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Returns the multipart upload in progress of a file.
+// No rows is error, files without upload in progress included
+func (q *Queries) GetMultipartUpload(
+	ctx context.Context,
+	fileId ct.Id,
+) (mu MultipartUpload, err error) {
+
+	const query = `
+		SELECT
+			id,
+			multipart_upload_id,
+			part_size,
+			part_count,
+			uploaded_parts,
+			uploaded_bytes,
+			COALESCE(upload_expires_at, created_at + interval '24 hours')
+		FROM files
+		WHERE id = $1
+		  AND multipart_upload_id IS NOT NULL
+	`
+
+	err = q.db.QueryRow(ctx, query, fileId).Scan(
+		&mu.FileId,
+		&mu.UploadId,
+		&mu.PartSize,
+		&mu.PartCount,
+		&mu.UploadedParts,
+		&mu.UploadedBytes,
+		&mu.ExpiresAt,
+	)
+	return mu, err
+}
+
+// Records the parts uploaded so far of a multipart upload.
+// No rows is error explicitly
+func (q *Queries) SetUploadProgress(
+	ctx context.Context,
+	fileId ct.Id,
+	uploadedParts int32,
+	uploadedBytes int64,
+) error {
+
+	const query = `
+		UPDATE files
+		SET
+			uploaded_parts = $2,
+			uploaded_bytes = $3
+		WHERE id = $1
+	`
+
+	res, err := q.db.Exec(ctx, query, fileId, uploadedParts, uploadedBytes)
+	if err != nil {
+		return err
+	}
+
+	if rows := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Ends the multipart upload of a file once completed or aborted, the progress is kept.
+// No rows is error explicitly
+func (q *Queries) EndMultipartUpload(
+	ctx context.Context,
+	fileId ct.Id,
+) error {
+
+	const query = `
+		UPDATE files
+		SET multipart_upload_id = NULL
+		WHERE id = $1
+		  AND multipart_upload_id IS NOT NULL
+	`
+
+	res, err := q.db.Exec(ctx, query, fileId)
+	if err != nil {
+		return err
+	}
+
+	if rows := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Returns the upload ids among ids that belong to a multipart upload in progress.
+// Missing rows is no error
+func (q *Queries) GetKnownUploadIds(
+	ctx context.Context,
+	ids []string,
+) (known []string, err error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	const query = `
+		SELECT multipart_upload_id
+		FROM files
+		WHERE multipart_upload_id = ANY($1)
+	`
+
+	rows, err := q.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		known = append(known, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return known, nil
+}
@@ -3,6 +3,7 @@ package dbservice
 import (
 	"context"
 	ct "social-network/shared/go/ct"
+	"time"
 )
 
 type Querier interface {
@@ -20,6 +21,12 @@ type Querier interface {
 		status ct.UploadStatus,
 	) error
 
+	SetSanitizedImage(ctx context.Context, fileId ct.Id, sizeBytes int64, blurhash string) error
+
+	QuarantineFile(ctx context.Context, fileId ct.Id, signature string) error
+
+	GetQuarantinedFiles(ctx context.Context, afterId ct.Id, limit int32) ([]QuarantinedFile, error)
+
 	CreateVariant(ctx context.Context, fm File) (fileId ct.Id, err error)
 
 	GetVariant(ctx context.Context, fileId ct.Id,
@@ -46,8 +53,123 @@ type Querier interface {
 	GetPendingVariants(
 		ctx context.Context) (pending []Variant, err error)
 
-	// StartStaleFilesWorker(ctx context.Context)
-	MarkStaleFilesFailed(ctx context.Context) error
+	ClaimFile(ctx context.Context, fileId ct.Id, ownerId ct.Id) error
+
+	CreateAttachment(ctx context.Context, a Attachment) error
+
+	DeleteAttachment(ctx context.Context, a Attachment) error
+
+	GetFileAttachments(
+		ctx context.Context,
+		fileId ct.Id,
+	) ([]Attachment, error)
+
+	GetCanonicalFileByHash(
+		ctx context.Context,
+		hash string,
+		excludeId ct.Id,
+	) (fm File, err error)
+
+	SetContentHash(ctx context.Context, fileId ct.Id, hash string) error
+
+	DedupFile(
+		ctx context.Context,
+		fileId ct.Id,
+		canonical File,
+		hash string,
+	) error
+
+	DeleteVariants(ctx context.Context, fileId ct.Id) error
+
+	GetFileRefs(
+		ctx context.Context,
+		fileId ct.Id,
+	) (canonicalId ct.Id, refCount int32, err error)
+
+	ReleaseDuplicate(ctx context.Context, fileId ct.Id, canonicalId ct.Id) error
+
+	PromoteCanonical(ctx context.Context, fileId ct.Id) (successorId ct.Id, err error)
+
+	DeleteFile(ctx context.Context, fileId ct.Id) error
+
+	SetUploadExpiry(ctx context.Context, fileId ct.Id, expiresAt time.Time) error
+
+	GetExpiredUploads(
+		ctx context.Context,
+		before time.Time,
+		afterId ct.Id,
+		limit int32,
+	) ([]File, error)
+
+	GetFilesByStatus(
+		ctx context.Context,
+		status ct.UploadStatus,
+		updatedBefore time.Time,
+		afterId ct.Id,
+		limit int32,
+	) ([]File, error)
+
+	GetVariantsByStatus(
+		ctx context.Context,
+		status ct.UploadStatus,
+		updatedBefore time.Time,
+		afterId ct.Id,
+		limit int32,
+	) (variants []Variant, err error)
+
+	SetVariantsStatus(ctx context.Context, ids []ct.Id, status ct.UploadStatus) error
+
+	DeleteVariantsById(ctx context.Context, ids []ct.Id) error
+
+	GetGCCandidates(
+		ctx context.Context,
+		createdBefore time.Time,
+		checkedBefore time.Time,
+		afterId ct.Id,
+		limit int32,
+	) ([]File, error)
+
+	SetGCChecked(ctx context.Context, ids ct.Ids) error
+
+	GetKnownObjectKeys(
+		ctx context.Context,
+		bucket string,
+		keys []string,
+	) (known []string, err error)
+
+	MarkObjectFailed(ctx context.Context, bucket string, objectKey string) (int64, error)
+
+	GetTransform(ctx context.Context, fileId ct.Id, spec string) (fm File, err error)
+
+	CountTransforms(ctx context.Context, fileId ct.Id) (count int32, err error)
+
+	CreateTransform(ctx context.Context, t Transform) (created bool, err error)
+
+	GetAllTransforms(
+		ctx context.Context,
+		fileId ct.Id,
+	) (fms []File, err error)
+
+	SetMultipartUpload(
+		ctx context.Context,
+		fileId ct.Id,
+		uploadId string,
+		partSize int64,
+		partCount int32,
+	) error
+
+	GetMultipartUpload(ctx context.Context, fileId ct.Id) (mu MultipartUpload, err error)
+
+	SetUploadProgress(
+		ctx context.Context,
+		fileId ct.Id,
+		uploadedParts int32,
+		uploadedBytes int64,
+	) error
+
+	EndMultipartUpload(ctx context.Context, fileId ct.Id) error
+
+	GetKnownUploadIds(ctx context.Context, ids []string) (known []string, err error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,154 @@
+package dbservice
+
+import (
+	"context"
+	ct "social-network/shared/go/ct"
+)
+
+// Returns the transform of a file as a file with the visibility and owner of the file.
+// Duplicates read the transforms of their canonical file.
+// No rows is error
+func (q *Queries) GetTransform(
+	ctx context.Context,
+	fileId ct.Id,
+	spec string,
+) (fm File, err error) {
+
+	const query = `
+		SELECT
+			f.id,
+			f.filename,
+			t.mime_type,
+			t.size_bytes,
+			t.bucket,
+			t.object_key,
+			f.visibility,
+			COALESCE(f.owner_id, 0)
+		FROM files f
+		JOIN file_transforms t ON t.file_id = COALESCE(f.canonical_id, f.id)
+		WHERE f.id = $1
+		  AND t.spec = $2
+	`
+
+	err = q.db.QueryRow(ctx, query, fileId, spec).Scan(
+		&fm.Id,
+		&fm.Filename,
+		&fm.MimeType,
+		&fm.SizeBytes,
+		&fm.Bucket,
+		&fm.ObjectKey,
+		&fm.Visibility,
+		&fm.OwnerId,
+	)
+
+	// transform rows are only created once generated
+	fm.Status = ct.Complete
+
+	return fm, err
+}
+
+// Returns the number of transforms generated for a file or its canonical file.
+// Missing rows is no error
+func (q *Queries) CountTransforms(
+	ctx context.Context,
+	fileId ct.Id,
+) (count int32, err error) {
+
+	const query = `
+		SELECT count(*)
+		FROM files f
+		JOIN file_transforms t ON t.file_id = COALESCE(f.canonical_id, f.id)
+		WHERE f.id = $1
+	`
+
+	err = q.db.QueryRow(ctx, query, fileId).Scan(&count)
+	return count, err
+}
+
+// Records a generated transform on the canonical file of t.FileId.
+// A transform already recorded with the same spec is kept, created is then false.
+// Missing file is no error, created is false.
+func (q *Queries) CreateTransform(
+	ctx context.Context,
+	t Transform,
+) (created bool, err error) {
+
+	const query = `
+		INSERT INTO file_transforms (
+			file_id,
+			spec,
+			mime_type,
+			size_bytes,
+			bucket,
+			object_key
+		)
+		SELECT COALESCE(canonical_id, id), $2, $3, $4, $5, $6
+		FROM files
+		WHERE id = $1
+		ON CONFLICT DO NOTHING
+	`
+
+	res, err := q.db.Exec(ctx, query,
+		t.FileId,
+		t.Spec,
+		t.MimeType,
+		t.SizeBytes,
+		t.Bucket,
+		t.ObjectKey,
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+// Returns the transforms of a file or its canonical file as files.
+// Missing rows is no error
+func (q *Queries) GetAllTransforms(
+	ctx context.Context,
+	fileId ct.Id,
+) (fms []File, err error) {
+
+	const query = `
+		SELECT
+			f.id,
+			f.filename,
+			t.mime_type,
+			t.size_bytes,
+			t.bucket,
+			t.object_key,
+			f.visibility
+		FROM files f
+		JOIN file_transforms t ON t.file_id = COALESCE(f.canonical_id, f.id)
+		WHERE f.id = $1
+	`
+
+	rows, err := q.db.Query(ctx, query, fileId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fm File
+		if err := rows.Scan(
+			&fm.Id,
+			&fm.Filename,
+			&fm.MimeType,
+			&fm.SizeBytes,
+			&fm.Bucket,
+			&fm.ObjectKey,
+			&fm.Visibility,
+		); err != nil {
+			return nil, err
+		}
+		fm.Status = ct.Complete
+		fms = append(fms, fm)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return fms, nil
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS file_attachments;
+DROP TYPE IF EXISTS attachment_type;
+
+DROP INDEX IF EXISTS idx_files_owner_id;
+ALTER TABLE files DROP COLUMN IF EXISTS owner_id;
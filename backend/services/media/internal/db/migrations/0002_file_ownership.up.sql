@@ -0,0 +1,24 @@
+-- Uploader of the file. NULL for files uploaded before their user existed, like
+-- registration avatars, and for files created before ownership was recorded.
+-- Such files are claimed by the owner of the first entity they are attached to.
+ALTER TABLE files ADD COLUMN IF NOT EXISTS owner_id BIGINT;
+
+CREATE INDEX IF NOT EXISTS idx_files_owner_id
+    ON files(owner_id);
+
+CREATE TYPE attachment_type AS ENUM ('post', 'comment', 'event', 'avatar', 'group', 'message');
+
+-- Entities using a file. Downloads of private files are allowed to the owner
+-- and to the users that can see one of these entities.
+CREATE TABLE IF NOT EXISTS file_attachments (
+    file_id       BIGINT NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    entity_type   attachment_type NOT NULL,
+    entity_id     BIGINT NOT NULL,
+
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (file_id, entity_type, entity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_file_attachments_entity
+    ON file_attachments(entity_type, entity_id);
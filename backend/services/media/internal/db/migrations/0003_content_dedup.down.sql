@@ -0,0 +1,12 @@
+-- Duplicates share the object keys of their canonical file and can't be kept unique.
+DELETE FROM files WHERE canonical_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_files_canonical_id;
+DROP INDEX IF EXISTS idx_files_content_hash;
+DROP INDEX IF EXISTS uq_files_canonical_object;
+
+ALTER TABLE files ADD CONSTRAINT files_bucket_object_key_key UNIQUE (bucket, object_key);
+
+ALTER TABLE files DROP COLUMN IF EXISTS ref_count;
+ALTER TABLE files DROP COLUMN IF EXISTS canonical_id;
+ALTER TABLE files DROP COLUMN IF EXISTS content_hash;
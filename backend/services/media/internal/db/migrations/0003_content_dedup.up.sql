@@ -0,0 +1,25 @@
+-- sha256 of the original upload, set on validation.
+ALTER TABLE files ADD COLUMN IF NOT EXISTS content_hash TEXT;
+
+-- A file uploaded again points at the first complete upload of the same content,
+-- the canonical file, and shares its original and variant objects.
+-- Duplicates have no variant rows of their own, variants are read through the canonical file.
+ALTER TABLE files ADD COLUMN IF NOT EXISTS canonical_id BIGINT REFERENCES files(id);
+
+-- On canonical files, the number of files sharing their objects, themselves included.
+-- The objects are deleted from file service with the last of them.
+ALTER TABLE files ADD COLUMN IF NOT EXISTS ref_count INT NOT NULL DEFAULT 1 CHECK (ref_count >= 0);
+
+-- Duplicates have the bucket and object key of their canonical file.
+ALTER TABLE files DROP CONSTRAINT IF EXISTS files_bucket_object_key_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_files_canonical_object
+    ON files(bucket, object_key)
+    WHERE canonical_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_files_content_hash
+    ON files(content_hash)
+    WHERE canonical_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_files_canonical_id
+    ON files(canonical_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_file_variants_status_updated;
+DROP INDEX IF EXISTS idx_files_status_created;
+
+ALTER TABLE files DROP COLUMN IF EXISTS gc_checked_at;
+ALTER TABLE files DROP COLUMN IF EXISTS upload_expires_at;
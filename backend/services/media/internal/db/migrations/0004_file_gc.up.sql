@@ -0,0 +1,14 @@
+-- Expiry of the upload URL of a pending file. Uploads not validated by then are
+-- collected by the garbage collector. NULL for files created before it was recorded,
+-- those expire 24 hours after creation.
+ALTER TABLE files ADD COLUMN IF NOT EXISTS upload_expires_at TIMESTAMPTZ;
+
+-- Last time the garbage collector found the file referenced by another service.
+-- Files are asked about again once it is older than the recheck interval.
+ALTER TABLE files ADD COLUMN IF NOT EXISTS gc_checked_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_files_status_created
+    ON files(status, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_file_variants_status_updated
+    ON file_variants(status, updated_at);
@@ -0,0 +1,19 @@
+-- Images generated on demand from signed transform urls, cached in the variants bucket.
+-- Transforms belong to the canonical file, duplicates read those of their canonical file.
+-- Rows are only created once the object is stored, they are always complete.
+CREATE TABLE IF NOT EXISTS file_transforms (
+    id            BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+
+    file_id       BIGINT NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    spec          TEXT NOT NULL,        -- e.g. w300-h200-cover.webp
+    mime_type     TEXT NOT NULL,
+    size_bytes    BIGINT NOT NULL CHECK (size_bytes >= 0),
+
+    bucket        TEXT NOT NULL,
+    object_key    TEXT NOT NULL,
+
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    UNIQUE (file_id, spec),
+    UNIQUE (bucket, object_key)
+);
@@ -0,0 +1,4 @@
+-- Blurhash of an image, computed on validation for clients to render a placeholder
+-- while the image loads. NULL for videos and files validated before it was recorded.
+-- Duplicates copy the blurhash of their canonical file.
+ALTER TABLE files ADD COLUMN IF NOT EXISTS blurhash TEXT;
@@ -0,0 +1,2 @@
+UPDATE files SET status = 'failed' WHERE status = 'quarantined';
+ALTER TABLE files DROP COLUMN IF EXISTS scan_signature;
@@ -0,0 +1,3 @@
+-- Signature the content scanner flagged a quarantined file with.
+-- Quarantined files are never served and are collected after the quarantine retention.
+ALTER TABLE files ADD COLUMN IF NOT EXISTS scan_signature TEXT;
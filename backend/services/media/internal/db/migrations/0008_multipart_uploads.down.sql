@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_files_multipart_upload;
+ALTER TABLE files DROP COLUMN IF EXISTS uploaded_bytes;
+ALTER TABLE files DROP COLUMN IF EXISTS uploaded_parts;
+ALTER TABLE files DROP COLUMN IF EXISTS part_count;
+ALTER TABLE files DROP COLUMN IF EXISTS part_size;
+ALTER TABLE files DROP COLUMN IF EXISTS multipart_upload_id;
@@ -0,0 +1,12 @@
+-- Multipart upload of the original of a pending file. The upload id is cleared once
+-- the upload is completed or aborted. Parts and bytes uploaded are the progress last
+-- listed from file service, for clients resuming an upload.
+ALTER TABLE files ADD COLUMN IF NOT EXISTS multipart_upload_id TEXT;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS part_size BIGINT CHECK (part_size > 0);
+ALTER TABLE files ADD COLUMN IF NOT EXISTS part_count INT CHECK (part_count > 0);
+ALTER TABLE files ADD COLUMN IF NOT EXISTS uploaded_parts INT NOT NULL DEFAULT 0;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS uploaded_bytes BIGINT NOT NULL DEFAULT 0;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_files_multipart_upload
+    ON files(multipart_upload_id)
+    WHERE multipart_upload_id IS NOT NULL;
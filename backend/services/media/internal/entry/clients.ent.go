@@ -2,8 +2,14 @@ package entry
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"reflect"
+	"social-network/services/media/internal/client"
 	"social-network/services/media/internal/configs"
+	"social-network/services/media/internal/scanner"
+	"social-network/services/media/internal/storage"
 	tele "social-network/shared/go/telemetry"
 	"time"
 
@@ -103,3 +109,78 @@ func EnsureBuckets(ctx context.Context, client *minio.Client, buckets configs.Bu
 
 	return nil
 }
+
+// Returns the storage of the configured backend, and its multipart uploads if it has them.
+// The local storage serves its signed urls on its own address until stop is called.
+func newStorage(ctx context.Context, cfgs configs.FileService) (
+	s client.Storage, mp client.MultipartStorage, stop func(), err error) {
+
+	switch cfgs.Storage.Backend {
+	case "", "minio":
+		// Internal client for backend operations
+		fileServiceClient, err := NewMinIOConn(ctx, cfgs, cfgs.Endpoint, false)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		// Optional public client for URL generation (e.g. localhost in dev)
+		var publicFileServiceClient *minio.Client
+		if cfgs.PublicEndpoint != "" {
+			publicFileServiceClient, err = NewMinIOConn(ctx, cfgs, cfgs.PublicEndpoint, true)
+			if err != nil {
+				tele.Info(ctx, "Warning: failed to initialize public MinIO client: @1", "error", err)
+			} else {
+				tele.Info(ctx, "Initialized public MinIO client for URL generation")
+			}
+		}
+
+		minIOStorage := storage.NewMinIOStorage(fileServiceClient, publicFileServiceClient)
+		return minIOStorage, minIOStorage, func() {}, nil
+
+	case "local":
+		if cfgs.Storage.Local.Addr == "" {
+			return nil, nil, nil, errors.New("STORAGE_LOCAL_ADDR not set")
+		}
+		localStorage, err := storage.NewLocalStorage(cfgs.Storage.Local)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if cfgs.Storage.Local.SigningKey == "" {
+			tele.Warn(ctx, "STORAGE_SIGNING_KEY not set, storage urls are only valid until restart")
+		}
+
+		srv := &http.Server{
+			Addr:              cfgs.Storage.Local.Addr,
+			Handler:           localStorage.Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				tele.Error(ctx, "local storage server failed. @1", "error", err.Error())
+			}
+		}()
+
+		tele.Info(ctx, "Serving local storage. @1", "root", cfgs.Storage.Local.Root)
+		tele.Warn(ctx, "local storage has no multipart uploads")
+		return localStorage, nil, func() { srv.Shutdown(context.Background()) }, nil
+	}
+	return nil, nil, nil, fmt.Errorf("unknown storage backend %q", cfgs.Storage.Backend)
+}
+
+// Returns the clamd scanner of the configured address, or a scanner passing all uploads
+// if there is none. An unreachable daemon is only reported, uploads fail to validate until it is up.
+func newScanner(ctx context.Context, cfg configs.Scanner) (client.Scanner, error) {
+	if cfg.ClamdAddr == "" {
+		tele.Warn(ctx, "CLAMD_ADDR not set, uploads are not scanned for malware")
+		return scanner.NoopScanner{}, nil
+	}
+
+	clamd, err := scanner.NewClamdScanner(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := clamd.Ping(ctx); err != nil {
+		tele.Warn(ctx, "clamd not reachable. @1", "error", err.Error())
+	}
+	return clamd, nil
+}
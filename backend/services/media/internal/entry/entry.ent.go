@@ -15,6 +15,8 @@ import (
 	"social-network/services/media/internal/handler"
 	"social-network/services/media/internal/validator"
 	"social-network/shared/gen-go/media"
+	"social-network/shared/gen-go/posts"
+	"social-network/shared/gen-go/users"
 	"social-network/shared/go/ct"
 	"social-network/shared/go/gorpc"
 	postgresql "social-network/shared/go/postgre"
@@ -22,8 +24,6 @@ import (
 	tele "social-network/shared/go/telemetry"
 
 	"syscall"
-
-	"github.com/minio/minio-go/v7"
 )
 
 func Run() error {
@@ -56,35 +56,55 @@ func Run() error {
 
 	tele.Info(ctx, "Connected to media database")
 
-	// Internal client for backend operations
-	fileServiceClient, err := NewMinIOConn(ctx, cfgs.FileService, cfgs.FileService.Endpoint, false)
+	objectStorage, multipartStorage, stopStorage, err := newStorage(ctx, cfgs.FileService)
 	if err != nil {
 		return err
 	}
+	defer stopStorage()
 
-	// Optional public client for URL generation (e.g. localhost in dev)
-	var publicFileServiceClient *minio.Client
-	if cfgs.FileService.PublicEndpoint != "" {
-		publicFileServiceClient, err = NewMinIOConn(ctx, cfgs.FileService, cfgs.FileService.PublicEndpoint, true)
-		if err != nil {
-			tele.Info(ctx, "Warning: failed to initialize public MinIO client: @1", "error", err)
-		} else {
-			tele.Info(ctx, "Initialized public MinIO client for URL generation")
-		}
+	postsClient, err := gorpc.GetGRpcClient(
+		posts.NewPostsServiceClient,
+		cfgs.Clients.PostsGRPCAddr,
+		ct.CommonKeys(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to connect to posts service: %v", err)
+	}
+
+	usersClient, err := gorpc.GetGRpcClient(
+		users.NewUserServiceClient,
+		cfgs.Clients.UsersGRPCAddr,
+		ct.CommonKeys(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to connect to users service: %v", err)
+	}
+
+	contentScanner, err := newScanner(ctx, cfgs.FileService.Scanner)
+	if err != nil {
+		return err
 	}
 
 	querier := dbservice.NewQuerier(pool)
 	app, err := application.NewMediaService(
 		pool,
 		&client.Clients{
-			Configs:           cfgs.FileService,
-			MinIOClient:       fileServiceClient,
-			PublicMinIOClient: publicFileServiceClient,
+			Configs:   cfgs.FileService,
+			Storage:   objectStorage,
+			Multipart: multipartStorage,
 			Validator: &validator.ImageValidator{
 				Config: cfgs.FileService.FileConstraints,
 			},
 			ImageConvertor: convertor.NewImageconvertor(
 				cfgs.FileService.FileConstraints),
+			VideoValidator: &validator.VideoValidator{
+				Config: cfgs.FileService.VideoConstraints,
+			},
+			Transcoder: convertor.NewFFmpegTranscoder(
+				cfgs.FileService.VideoConstraints),
+			Scanner:     contentScanner,
+			PostsClient: postsClient,
+			UsersClient: usersClient,
 		},
 		querier,
 		cfgs,
@@ -93,10 +113,8 @@ func Run() error {
 		tele.Fatalf("failed to initialize media application: %v", err)
 	}
 
-	w := dbservice.NewWorker(querier)
-
 	app.StartVariantWorker(ctx, cfgs.FileService.VariantWorkerInterval)
-	w.StartStaleFilesWorker(ctx, cfgs.DB.StaleFilesWorkerInterval)
+	app.StartGCWorker(ctx)
 
 	service := &handler.MediaHandler{
 		Application: app,
@@ -144,8 +162,24 @@ func getConfigs() configs.Config {
 			PprofPort:      os.Getenv("PPROF_PORT"),
 		},
 		DB: configs.Db{
-			URL:                      os.Getenv("DATABASE_URL"),
-			StaleFilesWorkerInterval: 1 * time.Hour,
+			URL: os.Getenv("DATABASE_URL"),
+		},
+		Clients: configs.Clients{
+			PostsGRPCAddr: os.Getenv("POSTS_GRPC_ADDR"),
+			UsersGRPCAddr: os.Getenv("USERS_GRPC_ADDR"),
+		},
+		GC: configs.GC{
+			Interval:            1 * time.Hour,
+			ReconcileInterval:   24 * time.Hour,
+			DryRun:              os.Getenv("GC_DRY_RUN") == "true",
+			BatchSize:           500,
+			UploadGrace:         1 * time.Hour,
+			StuckAfter:          2 * time.Hour,
+			FailedRetention:     7 * 24 * time.Hour,
+			QuarantineRetention: 30 * 24 * time.Hour,
+			UnreferencedAfter:   24 * time.Hour,
+			RecheckAfter:        7 * 24 * time.Hour,
+			OrphanAfter:         24 * time.Hour,
 		},
 		FileService: configs.FileService{
 			Buckets: configs.Buckets{
@@ -153,6 +187,37 @@ func getConfigs() configs.Config {
 				Variants:  "uploads-variants",
 			},
 			VariantWorkerInterval: 30 * time.Second,
+			Scanner: configs.Scanner{
+				ClamdAddr: os.Getenv("CLAMD_ADDR"),
+				Timeout:   2 * time.Minute,
+				ChunkSize: 64 << 10,
+			},
+			Downloads: configs.Downloads{
+				PublicBaseURL: os.Getenv("PUBLIC_FILES_URL"),
+				PublicExpiry:  7 * 24 * time.Hour,
+				PrivateExpiry: 10 * time.Minute,
+				ProxyExpiry:   1 * time.Minute,
+			},
+			Multipart: configs.Multipart{
+				PartSize:      8 << 20, // 8MB
+				PartURLExpiry: 1 * time.Hour,
+			},
+			Storage: configs.Storage{
+				Backend: os.Getenv("STORAGE_BACKEND"),
+				Local: configs.LocalStorage{
+					Root:        os.Getenv("STORAGE_LOCAL_ROOT"),
+					Addr:        os.Getenv("STORAGE_LOCAL_ADDR"),
+					BaseURL:     os.Getenv("STORAGE_LOCAL_URL"),
+					InternalURL: os.Getenv("STORAGE_LOCAL_INTERNAL_URL"),
+					SigningKey:  os.Getenv("STORAGE_SIGNING_KEY"),
+				},
+			},
+			Transforms: configs.Transforms{
+				SigningKey: os.Getenv("TRANSFORM_SIGNING_KEY"),
+				MaxWidth:   2048,
+				MaxHeight:  2048,
+				MaxPerFile: 20,
+			},
 			FileConstraints: configs.FileConstraints{
 				MaxImageUpload: 5 << 20, // 5MB
 				MaxWidth:       4096,
@@ -172,6 +237,38 @@ func getConfigs() configs.Config {
 					".webp": true,
 				},
 			},
+			VideoConstraints: configs.VideoConstraints{
+				MaxUpload:        50 << 20, // 50MB
+				MaxDuration:      60 * time.Second,
+				MaxWidth:         3840,
+				MaxHeight:        3840,
+				TranscodeTimeout: 5 * time.Minute,
+				AllowedMIMEs: map[string]bool{
+					"video/mp4":       true,
+					"video/quicktime": true,
+					"video/webm":      true,
+				},
+				AllowedExt: map[string]bool{
+					".mp4":  true,
+					".m4v":  true,
+					".mov":  true,
+					".webm": true,
+				},
+				AllowedFormats: map[string]bool{
+					"mov":  true,
+					"mp4":  true,
+					"webm": true,
+				},
+				AllowedCodecs: map[string]bool{
+					"h264": true,
+					"hevc": true,
+					"vp8":  true,
+					"vp9":  true,
+					"av1":  true,
+				},
+				FFmpegPath:  os.Getenv("FFMPEG_PATH"),
+				FFprobePath: os.Getenv("FFPROBE_PATH"),
+			},
 			Endpoint:       os.Getenv("MINIO_ENDPOINT"),
 			PublicEndpoint: os.Getenv("MINIO_PUBLIC_ENDPOINT"),
 			AccessKey:      os.Getenv("MINIO_ACCESS_KEY"),
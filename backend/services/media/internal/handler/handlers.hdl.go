@@ -13,6 +13,7 @@ import (
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type MediaHandler struct {
@@ -58,6 +59,7 @@ func (m *MediaHandler) UploadImage(ctx context.Context,
 		MimeType:   req.MimeType,
 		SizeBytes:  req.SizeBytes,
 		Visibility: mapping.PbToCtFileVisibility(req.Visibility),
+		OwnerId:    ct.Id(req.OwnerId),
 	}
 	// Call application
 	fileId, upUrl, err := m.Application.UploadImage(
@@ -78,11 +80,69 @@ func (m *MediaHandler) UploadImage(ctx context.Context,
 	return res, nil
 }
 
+// Provides video id and an upload URL, same flow as UploadImage. After ValidateUpload
+// the requested renditions are transcoded in the background and downloaded with GetImage.
+//
+// Usage:
+//
+//	mediaRes, err := MediaService.UploadVideo(r.Context(), &media.UploadVideoRequest{
+//		Filename:   "clip.mp4",
+//		MimeType:   "video/mp4",
+//		SizeBytes:  size,
+//		Visibility: media.FileVisibility_PUBLIC,
+//		Variants: []media.FileVariant{
+//			media.FileVariant_VIDEO_MP4,
+//			media.FileVariant_VIDEO_WEBM,
+//			media.FileVariant_VIDEO_POSTER,
+//		},
+//		ExpirationSeconds: int64(exp),
+//		OwnerId:           userId,
+//	})
+func (m *MediaHandler) UploadVideo(ctx context.Context,
+	req *pb.UploadVideoRequest) (*pb.UploadVideoResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	tele.Info(ctx, "upload video called @1", "request", req.String())
+
+	variants := make([]ct.FileVariant, len(req.Variants))
+	for i, v := range req.Variants {
+		variants[i] = mapping.PbToCtFileVariant(v)
+	}
+	appReq := application.UploadImageReq{
+		Filename:   req.Filename,
+		MimeType:   req.MimeType,
+		SizeBytes:  req.SizeBytes,
+		Visibility: mapping.PbToCtFileVisibility(req.Visibility),
+		OwnerId:    ct.Id(req.OwnerId),
+	}
+	fileId, upUrl, err := m.Application.UploadVideo(
+		ctx,
+		appReq,
+		time.Duration(req.ExpirationSeconds)*time.Second,
+		variants,
+	)
+	if err != nil {
+		tele.Error(ctx, "failed to generate upload video url. @1 @2", "request:", req.String(), "error:", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	res := &pb.UploadVideoResponse{
+		FileId:    int64(fileId),
+		UploadUrl: upUrl,
+	}
+	tele.Info(ctx, "upload video url generation success. @1 @2", "request", appReq, "response", res.String())
+	return res, nil
+}
+
 // GetImage handles the gRPC request for retrieving an image download URL.
 // Expiration time of link is set according to image visibility settings set on upload and
-// is defined withing the methods of custom type 'FileVisibility'.
+// the downloads configs, it is returned as expires_at. Public images get a stable url
+// served by the gateway if configured, without expires_at.
 // Unvalidated uploads wont be fetched. In this case most likelly you will get a codes.NotFound error.
 // If variant requested is not yet created the handler returns original
+// Private images are returned only to their owner and to the users that can see an entity they are attached to,
+// identified by requester_id. Calls without requester_id are trusted service calls and get every image,
+// the calling service checks the access to its entities.
 //
 // Usage:
 //
@@ -99,7 +159,11 @@ func (m *MediaHandler) GetImage(ctx context.Context,
 	tele.Info(ctx, "get image called @1", "request", req.String())
 
 	// Call application
-	downUrl, err := m.Application.GetPublicImage(ctx, ct.Id(req.ImageId), mapping.PbToCtFileVariant(req.Variant))
+	downUrl, expiresAt, err := m.Application.GetImage(ctx,
+		ct.Id(req.ImageId),
+		mapping.PbToCtFileVariant(req.Variant),
+		ct.Id(req.RequesterId),
+	)
 	if err != nil {
 		tele.Error(ctx, "get image error", "request", req.String(), "error", err.Error())
 		return nil, ce.EncodeProto(err)
@@ -107,11 +171,14 @@ func (m *MediaHandler) GetImage(ctx context.Context,
 
 	res := &pb.GetImageResponse{
 		DownloadUrl: downUrl,
+		ExpiresAt:   expiresAtUnix(expiresAt),
 	}
 	tele.Info(ctx, "get image success. @1 @2", "request", req.String(), "response", res.String())
 	return res, nil
 }
 
+// GetImages returns the download urls of a batch of images for trusted service calls,
+// which check the access to the entities the images belong to themselves.
 func (m *MediaHandler) GetImages(ctx context.Context,
 	req *pb.GetImagesRequest) (*pb.GetImagesResponse, error) {
 	if req == nil || req.ImgIds == nil {
@@ -125,7 +192,7 @@ func (m *MediaHandler) GetImages(ctx context.Context,
 	}
 
 	// Call application
-	downUrls, failedIds, err := m.Application.GetImages(ctx, ids, mapping.PbToCtFileVariant(req.Variant))
+	downUrls, placeholders, expiresAt, failedIds, err := m.Application.GetImages(ctx, ids, mapping.PbToCtFileVariant(req.Variant))
 	if err != nil {
 		tele.Error(ctx, "get images error", "request", req.String(), "error", err.Error())
 		return nil, ce.EncodeProto(err)
@@ -137,6 +204,11 @@ func (m *MediaHandler) GetImages(ctx context.Context,
 		downloadUrls[int64(id)] = url
 	}
 
+	blurhashes := make(map[int64]string, len(placeholders))
+	for id, hash := range placeholders {
+		blurhashes[int64(id)] = hash
+	}
+
 	pbFailedIds := make([]*pb.FailedId, len(failedIds))
 	for i, fId := range failedIds {
 		pbFailedIds[i] = &pb.FailedId{
@@ -147,16 +219,46 @@ func (m *MediaHandler) GetImages(ctx context.Context,
 	res := &pb.GetImagesResponse{
 		DownloadUrls: downloadUrls,
 		FailedIds:    pbFailedIds,
+		Placeholders: blurhashes,
+		ExpiresAt:    expiresAtUnix(expiresAt),
 	}
 	tele.Info(ctx, "get images success. @1 @2", "request", req.String(), "response", res.String())
 	return res, nil
 }
 
+// Returns a short lived url of a public file on the internal endpoint of file service.
+// Used by the gateway to serve the stable urls of public files. Private files are not found.
+func (m *MediaHandler) GetPublicFile(ctx context.Context,
+	req *pb.PublicFileRequest) (*pb.GetImageResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+
+	downUrl, err := m.Application.GetPublicFile(ctx,
+		ct.Id(req.FileId),
+		mapping.PbToCtFileVariant(req.Variant),
+	)
+	if err != nil {
+		tele.Warn(ctx, "get public file error. @1 @2", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	return &pb.GetImageResponse{DownloadUrl: downUrl}, nil
+}
+
+// Unix seconds of an url expiry, zero for urls that don't expire.
+func expiresAtUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
 // Checks if the upload matches the pre defined file metadata and configs FileService file constraints.
 // Upon success all requested variants are generated, placed on file service and marked as completed on db rows.
 // If validation fails the file cannot be retrived and will be deleted from file service after 24 hours
 // As an exemption returns a FailedPrecondition upon ErrFailed return thus giving a chance for the file to be validated
 // in the future.
+// Files uploaded with an owner can only be validated by that owner given as requester_id.
 func (m *MediaHandler) ValidateUpload(ctx context.Context,
 	req *pb.ValidateUploadRequest) (*pb.ValidateUploadResponse, error) {
 	if req == nil || req.FileId < 1 {
@@ -166,7 +268,7 @@ func (m *MediaHandler) ValidateUpload(ctx context.Context,
 	tele.Info(ctx, "validate image called. @1", "request", req.String())
 
 	// Call application
-	url, err := m.Application.ValidateAndGenerateVariants(ctx, ct.Id(req.FileId), req.ReturnUrl)
+	url, err := m.Application.ValidateAndGenerateVariants(ctx, ct.Id(req.FileId), ct.Id(req.RequesterId), req.ReturnUrl)
 	if err != nil {
 		tele.Error(ctx, "validate image error", "request", req.String(), "error", err.Error())
 		return nil, ce.EncodeProto(err)
@@ -175,3 +277,354 @@ func (m *MediaHandler) ValidateUpload(ctx context.Context,
 	tele.Info(ctx, "validate image success. @1 @2", "request", req.String(), "response", res.String())
 	return res, nil
 }
+
+// Records that a file is used by an entity of another service.
+// Files uploaded without owner are claimed by owner_id, files of another owner are rejected with permission denied.
+//
+// Usage:
+//
+//	_, err := MediaService.AttachFile(ctx, &media.FileAttachment{
+//		FileId:   imageId,
+//		OwnerId:  creatorId,
+//		Type:     media.AttachmentType_ATTACHMENT_POST,
+//		EntityId: postId,
+//	})
+func (m *MediaHandler) AttachFile(ctx context.Context,
+	req *pb.FileAttachment) (*emptypb.Empty, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	tele.Info(ctx, "attach file called. @1", "request", req.String())
+
+	err := m.Application.AttachFile(ctx, fileAttachmentToReq(req))
+	if err != nil {
+		tele.Error(ctx, "attach file error", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	tele.Info(ctx, "attach file success. @1", "request", req.String())
+	return &emptypb.Empty{}, nil
+}
+
+// Removes the attachment of a file to an entity.
+func (m *MediaHandler) DetachFile(ctx context.Context,
+	req *pb.FileAttachment) (*emptypb.Empty, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	tele.Info(ctx, "detach file called. @1", "request", req.String())
+
+	err := m.Application.DetachFile(ctx, fileAttachmentToReq(req))
+	if err != nil {
+		tele.Error(ctx, "detach file error", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	tele.Info(ctx, "detach file success. @1", "request", req.String())
+	return &emptypb.Empty{}, nil
+}
+
+// DeleteFile deletes a file of the requester with its variants and attachments.
+// The objects in file service are kept while other uploads of the same content use them.
+func (m *MediaHandler) DeleteFile(ctx context.Context,
+	req *pb.DeleteFileRequest) (*emptypb.Empty, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	tele.Info(ctx, "delete file called. @1", "request", req.String())
+
+	err := m.Application.DeleteFile(ctx, ct.Id(req.FileId), ct.Id(req.RequesterId))
+	if err != nil {
+		tele.Error(ctx, "delete file error", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	tele.Info(ctx, "delete file success. @1", "request", req.String())
+	return &emptypb.Empty{}, nil
+}
+
+func (m *MediaHandler) CollectGarbage(ctx context.Context,
+	req *pb.CollectGarbageRequest) (*pb.CollectGarbageResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	tele.Info(ctx, "collect garbage called. @1", "request", req.String())
+
+	report, err := m.Application.CollectGarbage(ctx, req.DryRun, req.Reconcile)
+	if err != nil {
+		tele.Error(ctx, "collect garbage error", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	return &pb.CollectGarbageResponse{
+		DryRun:            report.DryRun,
+		Reconciled:        report.Reconciled,
+		ExpiredUploads:    int64(report.ExpiredUploads),
+		StuckVariants:     int64(report.StuckVariants),
+		FailedVariants:    int64(report.FailedVariants),
+		FailedFiles:       int64(report.FailedFiles),
+		UnreferencedFiles: int64(report.UnreferencedFiles),
+		OrphanObjects:     int64(report.OrphanObjects),
+		MissingOriginals:  int64(report.MissingOriginals),
+		MissingVariants:   int64(report.MissingVariants),
+		FreedBytes:        report.FreedBytes,
+		AbandonedUploads:  int64(report.AbandonedUploads),
+	}, nil
+}
+
+// Lists the files quarantined by the content scanner, page by page.
+//
+// Usage:
+//
+//	var MediaService media.MediaServiceClient
+//	res, err := MediaService.ListQuarantinedFiles(ctx, &media.ListQuarantinedFilesRequest{
+//		AfterId: lastFileId,
+//		Limit:   100,
+//	})
+func (m *MediaHandler) ListQuarantinedFiles(ctx context.Context,
+	req *pb.ListQuarantinedFilesRequest) (*pb.ListQuarantinedFilesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	tele.Info(ctx, "list quarantined files called. @1", "request", req.String())
+
+	files, err := m.Application.ListQuarantinedFiles(ctx, ct.Id(req.AfterId), req.Limit)
+	if err != nil {
+		tele.Error(ctx, "list quarantined files error", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+
+	res := &pb.ListQuarantinedFilesResponse{
+		Files: make([]*pb.QuarantinedFile, 0, len(files)),
+	}
+	for _, f := range files {
+		res.Files = append(res.Files, &pb.QuarantinedFile{
+			FileId:        int64(f.FileId),
+			OwnerId:       int64(f.OwnerId),
+			Filename:      f.Filename,
+			MimeType:      f.MimeType,
+			SizeBytes:     f.SizeBytes,
+			ScanSignature: f.Signature,
+			Bucket:        f.Bucket,
+			ObjectKey:     f.ObjectKey,
+			QuarantinedAt: f.QuarantinedAt.Unix(),
+			DeleteAt:      f.DeleteAt.Unix(),
+		})
+	}
+	return res, nil
+}
+
+// Starts a multipart upload of a large image or video. The client then asks for the
+// part urls with SignUploadParts, PUTs every part and calls CompleteMultipartUpload.
+// Interrupted uploads resume with GetUploadProgress and new part urls.
+//
+// Usage:
+//
+//	var MediaService media.MediaServiceClient
+//	upload, err := MediaService.InitiateMultipartUpload(r.Context(), &media.InitiateMultipartUploadRequest{
+//		Filename:          "clip.mp4",
+//		MimeType:          "video/mp4",
+//		SizeBytes:         size,
+//		Visibility:        media.FileVisibility_PRIVATE,
+//		ExpirationSeconds: int64(time.Hour.Seconds()),
+//		Variants:          []media.FileVariant{media.FileVariant_VIDEO_MP4},
+//		OwnerId:           userId,
+//	})
+//	parts, err := MediaService.SignUploadParts(r.Context(), &media.SignUploadPartsRequest{
+//		FileId:      upload.FileId,
+//		RequesterId: userId,
+//	})
+func (m *MediaHandler) InitiateMultipartUpload(ctx context.Context,
+	req *pb.InitiateMultipartUploadRequest) (*pb.InitiateMultipartUploadResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	tele.Info(ctx, "initiate multipart upload called @1", "request", req.String())
+
+	variants := make([]ct.FileVariant, len(req.Variants))
+	for i, v := range req.Variants {
+		variants[i] = mapping.PbToCtFileVariant(v)
+	}
+	upload, err := m.Application.InitiateMultipartUpload(ctx,
+		application.UploadImageReq{
+			Filename:   req.Filename,
+			MimeType:   req.MimeType,
+			SizeBytes:  req.SizeBytes,
+			Visibility: mapping.PbToCtFileVisibility(req.Visibility),
+			OwnerId:    ct.Id(req.OwnerId),
+		},
+		time.Duration(req.ExpirationSeconds)*time.Second,
+		variants,
+	)
+	if err != nil {
+		tele.Error(ctx, "initiate multipart upload error", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	return &pb.InitiateMultipartUploadResponse{
+		FileId:    int64(upload.FileId),
+		PartSize:  upload.PartSize,
+		PartCount: upload.PartCount,
+		ExpiresAt: upload.ExpiresAt.Unix(),
+	}, nil
+}
+
+// Returns upload urls of the requested parts, of the parts not uploaded yet if none are requested.
+func (m *MediaHandler) SignUploadParts(ctx context.Context,
+	req *pb.SignUploadPartsRequest) (*pb.SignUploadPartsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	tele.Info(ctx, "sign upload parts called @1", "request", req.String())
+
+	urls, err := m.Application.SignUploadParts(ctx,
+		ct.Id(req.FileId),
+		ct.Id(req.RequesterId),
+		req.PartNumbers,
+	)
+	if err != nil {
+		tele.Error(ctx, "sign upload parts error", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+
+	res := &pb.SignUploadPartsResponse{Parts: make([]*pb.UploadPart, 0, len(urls))}
+	for _, u := range urls {
+		res.Parts = append(res.Parts, &pb.UploadPart{PartNumber: u.PartNumber, UploadUrl: u.URL})
+	}
+	return res, nil
+}
+
+// Returns the parts of a multipart upload uploaded so far.
+func (m *MediaHandler) GetUploadProgress(ctx context.Context,
+	req *pb.MultipartUploadRequest) (*pb.UploadProgress, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	tele.Info(ctx, "get upload progress called @1", "request", req.String())
+
+	progress, err := m.Application.GetUploadProgress(ctx, ct.Id(req.FileId), ct.Id(req.RequesterId))
+	if err != nil {
+		tele.Error(ctx, "get upload progress error", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	return &pb.UploadProgress{
+		FileId:        int64(progress.FileId),
+		PartSize:      progress.PartSize,
+		PartCount:     progress.PartCount,
+		UploadedParts: progress.UploadedParts,
+		UploadedBytes: progress.UploadedBytes,
+		ExpiresAt:     progress.ExpiresAt.Unix(),
+	}, nil
+}
+
+// Assembles the parts of a multipart upload. The file is then validated with ValidateUpload.
+func (m *MediaHandler) CompleteMultipartUpload(ctx context.Context,
+	req *pb.MultipartUploadRequest) (*emptypb.Empty, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	tele.Info(ctx, "complete multipart upload called. @1", "request", req.String())
+
+	if err := m.Application.CompleteMultipartUpload(ctx, ct.Id(req.FileId), ct.Id(req.RequesterId)); err != nil {
+		tele.Error(ctx, "complete multipart upload error", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	tele.Info(ctx, "complete multipart upload success. @1", "request", req.String())
+	return &emptypb.Empty{}, nil
+}
+
+// Aborts a multipart upload and deletes its file.
+func (m *MediaHandler) AbortMultipartUpload(ctx context.Context,
+	req *pb.MultipartUploadRequest) (*emptypb.Empty, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	tele.Info(ctx, "abort multipart upload called. @1", "request", req.String())
+
+	if err := m.Application.AbortMultipartUpload(ctx, ct.Id(req.FileId), ct.Id(req.RequesterId)); err != nil {
+		tele.Error(ctx, "abort multipart upload error", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	tele.Info(ctx, "abort multipart upload success. @1", "request", req.String())
+	return &emptypb.Empty{}, nil
+}
+
+// Signs a transform of an image for the requester. The returned transform, expiry and signature
+// are passed as they are to GetImageTransform, usually through a public url of the gateway.
+// Private images are only signed for the users allowed to download them.
+//
+// Usage:
+//
+//	var MediaService media.MediaServiceClient
+//	signed, err := MediaService.SignImageTransform(r.Context(), &media.SignImageTransformRequest{
+//		ImageId:     1,
+//		Transform:   &media.ImageTransform{Width: 300, Height: 200, Fit: "cover"},
+//		RequesterId: userId,
+//	})
+func (m *MediaHandler) SignImageTransform(ctx context.Context,
+	req *pb.SignImageTransformRequest) (*pb.SignImageTransformResponse, error) {
+	if req == nil || req.Transform == nil {
+		return nil, status.Error(codes.InvalidArgument, "request or transform is nil")
+	}
+	tele.Info(ctx, "sign image transform called @1", "request", req.String())
+
+	t, expiresAt, signature, err := m.Application.SignImageTransform(ctx,
+		ct.Id(req.ImageId),
+		transformToReq(req.Transform),
+		ct.Id(req.RequesterId),
+		time.Duration(req.ExpirationSeconds)*time.Second,
+	)
+	if err != nil {
+		tele.Error(ctx, "sign image transform error", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+
+	return &pb.SignImageTransformResponse{
+		Transform: &pb.ImageTransform{
+			Width:  int32(t.Width),
+			Height: int32(t.Height),
+			Fit:    t.Fit,
+			Format: t.Format,
+		},
+		ExpiresAt: expiresAt,
+		Signature: signature,
+	}, nil
+}
+
+// Returns a download url of a signed image transform, generated on the first request.
+func (m *MediaHandler) GetImageTransform(ctx context.Context,
+	req *pb.GetImageTransformRequest) (*pb.GetImageResponse, error) {
+	if req == nil || req.Transform == nil {
+		return nil, status.Error(codes.InvalidArgument, "request or transform is nil")
+	}
+	tele.Info(ctx, "get image transform called @1", "request", req.String())
+
+	downUrl, err := m.Application.GetImageTransform(ctx,
+		ct.Id(req.ImageId),
+		transformToReq(req.Transform),
+		req.ExpiresAt,
+		req.Signature,
+	)
+	if err != nil {
+		tele.Error(ctx, "get image transform error", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	return &pb.GetImageResponse{DownloadUrl: downUrl}, nil
+}
+
+func transformToReq(t *pb.ImageTransform) application.TransformReq {
+	req := application.TransformReq{
+		Width:  int(t.Width),
+		Height: int(t.Height),
+		Fit:    t.Fit,
+		Format: t.Format,
+	}
+	if t.Preset != pb.FileVariant_IMG_VARIANT_UNSPECIFIED {
+		req.Preset = mapping.PbToCtFileVariant(t.Preset)
+	}
+	return req
+}
+
+func fileAttachmentToReq(req *pb.FileAttachment) application.AttachFileReq {
+	return application.AttachFileReq{
+		FileId:   ct.Id(req.FileId),
+		OwnerId:  ct.Id(req.OwnerId),
+		Type:     mapping.PbToCtAttachmentType(req.Type),
+		EntityId: ct.Id(req.EntityId),
+	}
+}
@@ -1,6 +1,10 @@
 package models
 
-import ct "social-network/shared/go/ct"
+import (
+	"fmt"
+	ct "social-network/shared/go/ct"
+	"time"
+)
 
 type FileMeta struct {
 	Id        ct.Id  // db row Id
@@ -13,3 +17,104 @@ type FileMeta struct {
 	Visibility ct.FileVisibility // public, private
 	Variant    ct.FileVariant    // thumb, small, medium, large, original
 }
+
+// Original of a validated image once its metadata was removed.
+type SanitizedImage struct {
+	SizeBytes int64  // size of the stored original
+	Blurhash  string // placeholder, empty if it could not be computed
+
+	ContentHash string // sha256 of the validated upload the image was sanitized from
+}
+
+// Verdict of the content scanner on an upload.
+type ScanResult struct {
+	Infected  bool
+	Signature string // name of the matched signature, empty if clean
+}
+
+// A part of a multipart upload, as listed from file service.
+type UploadedPart struct {
+	PartNumber int32
+	ETag       string
+	Size       int64
+}
+
+// A multipart upload in progress, listed from file service.
+type MultipartInfo struct {
+	Key       string
+	UploadId  string
+	Initiated time.Time
+}
+
+// An object listed from file service.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	ContentType  string
+	ETag         string
+}
+
+// Container and stream info of a video as probed by ffprobe.
+type VideoInfo struct {
+	Formats    []string // container format names, e.g. mov, mp4, m4a
+	Duration   time.Duration
+	VideoCodec string // h264, vp9 etc
+	AudioCodec string // empty when the video has no audio stream
+	Width      int
+	Height     int
+}
+
+// Fits of a transform.
+const (
+	FitContain = "contain" // scaled down to fit inside the box, the whole image is kept
+	FitCover   = "cover"   // scaled down to fill the box, the overflow is cropped around the center
+)
+
+// Output formats of a transform.
+const (
+	FormatWebP = "webp"
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+)
+
+// Size, fit and format of an image generated on demand. Images are never upscaled.
+// The named variants are presets of it.
+type Transform struct {
+	Width  int
+	Height int
+	Fit    string // contain or cover
+	Format string // webp, jpeg or png
+}
+
+var presets = map[ct.FileVariant]Transform{
+	ct.ImgThumbnail: {Width: 150, Height: 150, Fit: FitContain, Format: FormatWebP},
+	ct.ImgSmall:     {Width: 400, Height: 400, Fit: FitContain, Format: FormatWebP},
+	ct.ImgMedium:    {Width: 800, Height: 800, Fit: FitContain, Format: FormatWebP},
+	ct.ImgLarge:     {Width: 1600, Height: 1600, Fit: FitContain, Format: FormatWebP},
+}
+
+// Returns the transform a named image variant is generated with.
+func PresetTransform(v ct.FileVariant) (Transform, bool) {
+	t, ok := presets[v]
+	return t, ok
+}
+
+// Returns the named image variant generated with the same transform, if any.
+func (t Transform) Preset() (ct.FileVariant, bool) {
+	for v, p := range presets {
+		if p == t {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Identifies the transform among the transforms of a file, e.g. w150-h150-contain.webp
+func (t Transform) Key() string {
+	return fmt.Sprintf("w%d-h%d-%s.%s", t.Width, t.Height, t.Fit, t.Format)
+}
+
+func (t Transform) MimeType() string {
+	return "image/" + t.Format
+}
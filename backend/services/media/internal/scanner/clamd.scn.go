@@ -0,0 +1,189 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"social-network/services/media/internal/configs"
+	md "social-network/services/media/internal/models"
+)
+
+var (
+	ErrInvalidAddr = errors.New("invalid clamd address")
+	ErrScanFailed  = errors.New("clamd scan failed")
+)
+
+// ClamdScanner streams content to a clamd daemon with the INSTREAM command.
+// Streams longer than the StreamMaxLength of the daemon fail the scan,
+// it must allow at least the largest upload.
+type ClamdScanner struct {
+	network   string // unix or tcp
+	address   string
+	timeout   time.Duration
+	chunkSize int
+}
+
+// NewClamdScanner returns a scanner of the clamd daemon at cfg.ClamdAddr,
+// unix:///path/to/clamd.sock or tcp://host:port. Addresses without scheme are tcp.
+func NewClamdScanner(cfg configs.Scanner) (*ClamdScanner, error) {
+	c := &ClamdScanner{
+		network:   "tcp",
+		address:   cfg.ClamdAddr,
+		timeout:   cfg.Timeout,
+		chunkSize: cfg.ChunkSize,
+	}
+
+	if strings.Contains(cfg.ClamdAddr, "://") {
+		u, err := url.Parse(cfg.ClamdAddr)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidAddr, cfg.ClamdAddr, err)
+		}
+		switch u.Scheme {
+		case "unix":
+			c.network, c.address = "unix", u.Path
+		case "tcp":
+			c.address = u.Host
+		default:
+			return nil, fmt.Errorf("%w %q: unsupported scheme %q", ErrInvalidAddr, cfg.ClamdAddr, u.Scheme)
+		}
+	}
+	if c.address == "" {
+		return nil, fmt.Errorf("%w %q", ErrInvalidAddr, cfg.ClamdAddr)
+	}
+
+	if c.timeout <= 0 {
+		c.timeout = time.Minute
+	}
+	if c.chunkSize <= 0 {
+		c.chunkSize = 64 << 10
+	}
+	return c, nil
+}
+
+// Ping checks that the daemon is reachable.
+func (c *ClamdScanner) Ping(ctx context.Context) error {
+	reply, err := c.command(ctx, "PING", nil)
+	if err != nil {
+		return err
+	}
+	if reply != "PONG" {
+		return fmt.Errorf("%w: unexpected reply %q", ErrScanFailed, reply)
+	}
+	return nil
+}
+
+// Scan streams r to the daemon and returns its verdict.
+// Errors of the daemon, size limit exceeded included, are returned as ErrScanFailed.
+func (c *ClamdScanner) Scan(ctx context.Context, r io.Reader) (md.ScanResult, error) {
+	reply, err := c.command(ctx, "INSTREAM", func(w io.Writer) error {
+		return c.writeChunks(w, r)
+	})
+	if err != nil {
+		return md.ScanResult{}, err
+	}
+	return parseReply(reply)
+}
+
+// Sends a null terminated command and its payload, if any, and reads the null terminated reply.
+func (c *ClamdScanner) command(ctx context.Context,
+	cmd string, payload func(io.Writer) error) (string, error) {
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return "", fmt.Errorf("%w: dial: %w", ErrScanFailed, err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	// Unblock reads and writes once the context is done
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Unix(1, 0))
+	})
+	defer stop()
+
+	if _, err := io.WriteString(conn, "z"+cmd+"\x00"); err != nil {
+		return "", fmt.Errorf("%w: write command: %w", ErrScanFailed, err)
+	}
+
+	if payload != nil {
+		if err := payload(conn); err != nil {
+			// The daemon replies before closing on errors such as the size limit,
+			// it does not when the content could not be read
+			if errors.Is(err, ErrScanFailed) {
+				if reply, rErr := readReply(conn); rErr == nil && reply != "" {
+					return "", fmt.Errorf("%w: %s", ErrScanFailed, reply)
+				}
+			}
+			return "", err
+		}
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return "", fmt.Errorf("%w: read reply: %w", ErrScanFailed, err)
+	}
+	return reply, nil
+}
+
+// Writes r as length prefixed chunks, terminated by a zero length chunk.
+func (c *ClamdScanner) writeChunks(w io.Writer, r io.Reader) error {
+	buf := make([]byte, 4+c.chunkSize)
+	for {
+		n, err := io.ReadFull(r, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, err := w.Write(buf[:4+n]); err != nil {
+				return fmt.Errorf("%w: write chunk: %w", ErrScanFailed, err)
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read content: %w", err)
+		}
+	}
+
+	if _, err := w.Write([]byte{0, 0, 0, 0}); err != nil {
+		return fmt.Errorf("%w: write end of stream: %w", ErrScanFailed, err)
+	}
+	return nil
+}
+
+func readReply(r io.Reader) (string, error) {
+	reply, err := bufio.NewReader(r).ReadString(0)
+	if err != nil && !(errors.Is(err, io.EOF) && reply != "") {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimSuffix(reply, "\x00")), nil
+}
+
+// Parses replies of the form "stream: OK", "stream: <signature> FOUND" and "<message> ERROR".
+func parseReply(reply string) (md.ScanResult, error) {
+	_, verdict, _ := strings.Cut(reply, ": ")
+
+	switch {
+	case verdict == "OK":
+		return md.ScanResult{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return md.ScanResult{
+			Infected:  true,
+			Signature: strings.TrimSuffix(verdict, " FOUND"),
+		}, nil
+	}
+	return md.ScanResult{}, fmt.Errorf("%w: %s", ErrScanFailed, reply)
+}
@@ -0,0 +1,155 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"social-network/services/media/internal/configs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Serves the PING and INSTREAM commands of clamd on a unix socket, flagging the EICAR test file.
+// Streams longer than maxLen are refused as clamd does.
+func fakeClamd(t *testing.T, maxLen int) string {
+	t.Helper()
+	sock := filepath.Join(t.TempDir(), "clamd.sock")
+	l, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveClamd(conn, maxLen)
+		}
+	}()
+	return "unix://" + sock
+}
+
+func serveClamd(conn net.Conn, maxLen int) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	cmd, err := r.ReadString(0)
+	if err != nil {
+		return
+	}
+
+	switch cmd {
+	case "zPING\x00":
+		io.WriteString(conn, "PONG\x00")
+	case "zINSTREAM\x00":
+		var content bytes.Buffer
+		for {
+			var size uint32
+			if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+				return
+			}
+			if size == 0 {
+				break
+			}
+			if content.Len()+int(size) > maxLen {
+				io.WriteString(conn, "INSTREAM size limit exceeded. ERROR\x00")
+				return
+			}
+			if _, err := io.CopyN(&content, r, int64(size)); err != nil {
+				return
+			}
+		}
+		if bytes.Contains(content.Bytes(), []byte(EICAR)) {
+			io.WriteString(conn, "stream: Win.Test.EICAR_HDB-1 FOUND\x00")
+			return
+		}
+		io.WriteString(conn, "stream: OK\x00")
+	default:
+		io.WriteString(conn, "UNKNOWN COMMAND\x00")
+	}
+}
+
+func TestClamdScanner(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewClamdScanner(configs.Scanner{ClamdAddr: fakeClamd(t, 1<<20), ChunkSize: 16})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Ping(ctx))
+
+	res, err := s.Scan(ctx, strings.NewReader(strings.Repeat("clean content ", 100)))
+	require.NoError(t, err)
+	assert.False(t, res.Infected)
+
+	// The signature spans several chunks
+	res, err = s.Scan(ctx, strings.NewReader("prefix "+EICAR+" suffix"))
+	require.NoError(t, err)
+	assert.True(t, res.Infected)
+	assert.Equal(t, "Win.Test.EICAR_HDB-1", res.Signature)
+
+	res, err = s.Scan(ctx, strings.NewReader(""))
+	require.NoError(t, err)
+	assert.False(t, res.Infected)
+
+	small, err := NewClamdScanner(configs.Scanner{ClamdAddr: fakeClamd(t, 64), ChunkSize: 16})
+	require.NoError(t, err)
+	_, err = small.Scan(ctx, strings.NewReader(strings.Repeat("a", 1<<10)))
+	assert.ErrorIs(t, err, ErrScanFailed)
+	assert.ErrorContains(t, err, "size limit exceeded")
+}
+
+func TestNewClamdScanner(t *testing.T) {
+	tests := []struct {
+		addr    string
+		network string
+		address string
+	}{
+		{"unix:///run/clamav/clamd.ctl", "unix", "/run/clamav/clamd.ctl"},
+		{"tcp://clamav:3310", "tcp", "clamav:3310"},
+		{"clamav:3310", "tcp", "clamav:3310"},
+	}
+	for _, tt := range tests {
+		s, err := NewClamdScanner(configs.Scanner{ClamdAddr: tt.addr})
+		require.NoError(t, err, tt.addr)
+		assert.Equal(t, tt.network, s.network, tt.addr)
+		assert.Equal(t, tt.address, s.address, tt.addr)
+	}
+
+	for _, addr := range []string{"", "http://clamav:3310", "tcp://"} {
+		_, err := NewClamdScanner(configs.Scanner{ClamdAddr: addr})
+		assert.ErrorIs(t, err, ErrInvalidAddr, addr)
+	}
+}
+
+func TestParseReply(t *testing.T) {
+	res, err := parseReply("stream: OK")
+	require.NoError(t, err)
+	assert.False(t, res.Infected)
+
+	res, err = parseReply("stream: Eicar-Signature FOUND")
+	require.NoError(t, err)
+	assert.Equal(t, "Eicar-Signature", res.Signature)
+
+	_, err = parseReply("INSTREAM size limit exceeded. ERROR")
+	assert.ErrorIs(t, err, ErrScanFailed)
+}
+
+func TestLocalScanner(t *testing.T) {
+	s := NewLocalScanner()
+
+	res, err := s.Scan(context.Background(), strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.False(t, res.Infected)
+
+	res, err = s.Scan(context.Background(), strings.NewReader(EICAR))
+	require.NoError(t, err)
+	assert.True(t, res.Infected)
+	assert.NotEmpty(t, res.Signature)
+}
@@ -0,0 +1,45 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	md "social-network/services/media/internal/models"
+)
+
+// NoopScanner reports all content as clean, used when no clamd daemon is configured.
+type NoopScanner struct{}
+
+func (NoopScanner) Scan(ctx context.Context, r io.Reader) (md.ScanResult, error) {
+	return md.ScanResult{}, nil
+}
+
+// The EICAR anti-malware test file, flagged by every scanner and harmless.
+const EICAR = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// LocalScanner flags content containing one of its signatures, for tests and development.
+// It is no malware scanner.
+type LocalScanner struct {
+	Signatures map[string][]byte // content by signature name
+}
+
+// NewLocalScanner returns a scanner flagging the EICAR test file, as clamd does.
+func NewLocalScanner() *LocalScanner {
+	return &LocalScanner{Signatures: map[string][]byte{
+		"Win.Test.EICAR_HDB-1": []byte(EICAR),
+	}}
+}
+
+func (s *LocalScanner) Scan(ctx context.Context, r io.Reader) (md.ScanResult, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return md.ScanResult{}, err
+	}
+	for name, sig := range s.Signatures {
+		if bytes.Contains(buf, sig) {
+			return md.ScanResult{Infected: true, Signature: name}, nil
+		}
+	}
+	return md.ScanResult{}, nil
+}
@@ -0,0 +1,459 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"social-network/services/media/internal/configs"
+	md "social-network/services/media/internal/models"
+)
+
+// Directories of the root kept apart from the buckets, bucket names can't start with a dot.
+const (
+	metaDir = ".meta" // content type, etag and tags of an object, as json next to its path
+	tmpDir  = ".tmp"  // objects being written, renamed in place once complete
+)
+
+// LocalStorage keeps objects as files under root/<bucket>/<key>.
+// Presigned urls are signed with hmac and served by Handler, so clients upload
+// and download files as they do with MinIO. As on a filesystem a key can't be
+// both an object and the prefix of another object of the same bucket.
+type LocalStorage struct {
+	root        string
+	baseURL     *url.URL
+	internalURL *url.URL
+	signingKey  []byte
+	mu          sync.Mutex // serializes the updates of object metadata
+}
+
+type localMeta struct {
+	ContentType string            `json:"content_type"`
+	ETag        string            `json:"etag"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+// NewLocalStorage returns the storage of the cfg.Root directory, creating it if missing.
+// Without signing key urls are signed with a random key and only valid until restart.
+func NewLocalStorage(cfg configs.LocalStorage) (*LocalStorage, error) {
+	if cfg.Root == "" {
+		return nil, errors.New("local storage: root directory not set")
+	}
+	baseURL, err := url.Parse(cfg.BaseURL)
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("local storage: invalid base url %q", cfg.BaseURL)
+	}
+	internalURL := baseURL
+	if cfg.InternalURL != "" {
+		internalURL, err = url.Parse(cfg.InternalURL)
+		if err != nil || internalURL.Scheme == "" || internalURL.Host == "" {
+			return nil, fmt.Errorf("local storage: invalid internal url %q", cfg.InternalURL)
+		}
+	}
+
+	signingKey := []byte(cfg.SigningKey)
+	if len(signingKey) == 0 {
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, dir := range []string{cfg.Root, filepath.Join(cfg.Root, metaDir), filepath.Join(cfg.Root, tmpDir)} {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, fmt.Errorf("local storage: %w", err)
+		}
+	}
+
+	return &LocalStorage{
+		root:        cfg.Root,
+		baseURL:     baseURL,
+		internalURL: internalURL,
+		signingKey:  signingKey,
+	}, nil
+}
+
+// Paths of the file and metadata of an object.
+func (s *LocalStorage) paths(bucket, key string) (obj string, meta string, err error) {
+	if err := checkKey(bucket, key); err != nil {
+		return "", "", err
+	}
+	rel := filepath.Join(bucket, filepath.FromSlash(key))
+	return filepath.Join(s.root, rel), filepath.Join(s.root, metaDir, rel+".json"), nil
+}
+
+// Maps missing files to ErrNotFound, a path leading through a file is missing too.
+func localError(err error, bucket, key string) error {
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ENOTDIR) {
+		return fmt.Errorf("%w: %s/%s", ErrNotFound, bucket, key)
+	}
+	return err
+}
+
+func readMeta(path string) (localMeta, error) {
+	var meta localMeta
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return meta, nil // files copied into the bucket by hand
+		}
+		return meta, err
+	}
+	return meta, json.Unmarshal(buf, &meta)
+}
+
+func writeMeta(path string, meta localMeta) error {
+	buf, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf, 0o640)
+}
+
+// Stats the file of an object, directories are no objects.
+func (s *LocalStorage) stat(bucket, key string) (md.ObjectInfo, error) {
+	objPath, metaPath, err := s.paths(bucket, key)
+	if err != nil {
+		return md.ObjectInfo{}, err
+	}
+	fi, err := os.Stat(objPath)
+	if err != nil {
+		return md.ObjectInfo{}, localError(err, bucket, key)
+	}
+	if fi.IsDir() {
+		return md.ObjectInfo{}, fmt.Errorf("%w: %s/%s", ErrNotFound, bucket, key)
+	}
+	meta, err := readMeta(metaPath)
+	if err != nil {
+		return md.ObjectInfo{}, err
+	}
+	return md.ObjectInfo{
+		Key:          key,
+		Size:         fi.Size(),
+		LastModified: fi.ModTime(),
+		ContentType:  meta.ContentType,
+		ETag:         meta.ETag,
+	}, nil
+}
+
+// Writes the object to a temporary file first, readers never see a partial object.
+// A size of -1 reads r to its end.
+func (s *LocalStorage) PutObject(ctx context.Context, bucket, key string,
+	r io.Reader, size int64, contentType string) (md.ObjectInfo, error) {
+
+	objPath, metaPath, err := s.paths(bucket, key)
+	if err != nil {
+		return md.ObjectInfo{}, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Join(s.root, tmpDir), "put-*")
+	if err != nil {
+		return md.ObjectInfo{}, err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	h := md5.New()
+	if size >= 0 {
+		r = io.LimitReader(r, size)
+	}
+	n, err := io.Copy(io.MultiWriter(tmp, h), r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return md.ObjectInfo{}, err
+	}
+	if size >= 0 && n != size {
+		return md.ObjectInfo{}, fmt.Errorf("read object: %w", io.ErrUnexpectedEOF)
+	}
+
+	meta := localMeta{ContentType: contentType, ETag: hex.EncodeToString(h.Sum(nil))}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(objPath), 0o750); err != nil {
+		return md.ObjectInfo{}, err
+	}
+	if err := os.Rename(tmp.Name(), objPath); err != nil {
+		return md.ObjectInfo{}, err
+	}
+	if err := writeMeta(metaPath, meta); err != nil {
+		return md.ObjectInfo{}, err
+	}
+	return s.stat(bucket, key)
+}
+
+func (s *LocalStorage) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	return s.open(bucket, key)
+}
+
+// Opens the file of an object, directories are no objects.
+func (s *LocalStorage) open(bucket, key string) (*os.File, error) {
+	objPath, _, err := s.paths(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(objPath)
+	if err != nil {
+		return nil, localError(err, bucket, key)
+	}
+	if fi, err := f.Stat(); err != nil || fi.IsDir() {
+		f.Close()
+		return nil, fmt.Errorf("%w: %s/%s", ErrNotFound, bucket, key)
+	}
+	return f, nil
+}
+
+func (s *LocalStorage) StatObject(ctx context.Context, bucket, key string) (md.ObjectInfo, error) {
+	return s.stat(bucket, key)
+}
+
+// Deleting a missing object is no error. Directories left empty are removed.
+func (s *LocalStorage) DeleteObject(ctx context.Context, bucket, key string) error {
+	objPath, metaPath, err := s.paths(bucket, key)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range []string{objPath, metaPath} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	removeEmptyDirs(filepath.Dir(objPath), filepath.Join(s.root, bucket))
+	removeEmptyDirs(filepath.Dir(metaPath), filepath.Join(s.root, metaDir, bucket))
+	return nil
+}
+
+// Removes dir and its parents up to stop while they are empty.
+func removeEmptyDirs(dir, stop string) {
+	for dir != stop && strings.HasPrefix(dir, stop) {
+		if os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// Lists the objects of a bucket in key order, a missing bucket is empty.
+// Objects can be deleted by fn while listing.
+func (s *LocalStorage) ListObjects(ctx context.Context, bucket string, pageSize int, fn func([]md.ObjectInfo) error) error {
+	if err := checkKey(bucket, "_"); err != nil {
+		return err
+	}
+	bucketDir := filepath.Join(s.root, bucket)
+
+	pages := newPager(pageSize, fn)
+	err := filepath.WalkDir(bucketDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				if p == bucketDir {
+					return fs.SkipAll
+				}
+				return nil // deleted while listing
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(bucketDir, p)
+		if err != nil {
+			return err
+		}
+		info, err := s.stat(bucket, filepath.ToSlash(rel))
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return nil // deleted while listing
+			}
+			return err
+		}
+		return pages.add(info)
+	})
+	if err != nil {
+		return err
+	}
+	return pages.flush()
+}
+
+// Signs the method, object and expiry of an url.
+func (s *LocalStorage) signature(method, bucket, key string, expires int64) []byte {
+	mac := hmac.New(sha256.New, s.signingKey)
+	fmt.Fprintf(mac, "%s\n%s/%s\n%d", method, bucket, key, expires)
+	return mac.Sum(nil)
+}
+
+// <base>/<bucket>/<key>?expires=<unix seconds>&signature=<hex hmac>
+func (s *LocalStorage) presign(base *url.URL, method, bucket, key string, expiry time.Duration) (*url.URL, error) {
+	if err := checkKey(bucket, key); err != nil {
+		return nil, err
+	}
+	expires := time.Now().Add(expiry).Unix()
+	u := base.JoinPath(bucket, key)
+	if !strings.HasPrefix(u.Path, "/") {
+		u.Path = "/" + u.Path // base without path
+	}
+	params := url.Values{}
+	params.Set("expires", strconv.FormatInt(expires, 10))
+	params.Set("signature", hex.EncodeToString(s.signature(method, bucket, key, expires)))
+	u.RawQuery = params.Encode()
+	return u, nil
+}
+
+func (s *LocalStorage) PresignGet(ctx context.Context, bucket, key string, expiry time.Duration) (*url.URL, error) {
+	return s.presign(s.baseURL, http.MethodGet, bucket, key, expiry)
+}
+
+func (s *LocalStorage) PresignInternalGet(ctx context.Context, bucket, key string, expiry time.Duration) (*url.URL, error) {
+	return s.presign(s.internalURL, http.MethodGet, bucket, key, expiry)
+}
+
+func (s *LocalStorage) PresignPut(ctx context.Context, bucket, key string, expiry time.Duration) (*url.URL, error) {
+	return s.presign(s.baseURL, http.MethodPut, bucket, key, expiry)
+}
+
+// Checks the signature and expiry of a presigned url.
+func (s *LocalStorage) verify(method, bucket, key string, params url.Values) error {
+	expires, err := strconv.ParseInt(params.Get("expires"), 10, 64)
+	if err != nil {
+		return ErrInvalidURL
+	}
+	sig, err := hex.DecodeString(params.Get("signature"))
+	if err != nil || !hmac.Equal(sig, s.signature(method, bucket, key, expires)) {
+		return ErrInvalidURL
+	}
+	if time.Now().Unix() > expires {
+		return ErrExpiredURL
+	}
+	return nil
+}
+
+// Replaces the tags of an object.
+func (s *LocalStorage) SetTags(ctx context.Context, bucket, key string, tags map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.stat(bucket, key); err != nil {
+		return err
+	}
+	_, metaPath, _ := s.paths(bucket, key)
+	meta, err := readMeta(metaPath)
+	if err != nil {
+		return err
+	}
+	meta.Tags = tags
+	return writeMeta(metaPath, meta)
+}
+
+// Objects without tags have an empty map.
+func (s *LocalStorage) GetTags(ctx context.Context, bucket, key string) (map[string]string, error) {
+	if _, err := s.stat(bucket, key); err != nil {
+		return nil, err
+	}
+	_, metaPath, _ := s.paths(bucket, key)
+	meta, err := readMeta(metaPath)
+	if err != nil {
+		return nil, err
+	}
+	if meta.Tags == nil {
+		meta.Tags = map[string]string{}
+	}
+	return meta.Tags, nil
+}
+
+// Handler serves the presigned urls of the storage, GET and HEAD downloads
+// with range and conditional requests, and PUT uploads. Bucket and key are
+// read from the path, the handler must be mounted at the root of the urls.
+func (s *LocalStorage) Handler() http.Handler {
+	return http.HandlerFunc(s.serveSigned)
+}
+
+func (s *LocalStorage) serveSigned(w http.ResponseWriter, r *http.Request) {
+	// Browsers upload straight to the urls, the signature is the authorization
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Expose-Headers", "ETag")
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, PUT")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	method := r.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	if method != http.MethodGet && method != http.MethodPut {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if err := checkKey(bucket, key); err != nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	if err := s.verify(method, bucket, key, r.URL.Query()); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	if method == http.MethodPut {
+		info, err := s.PutObject(r.Context(), bucket, key, r.Body, r.ContentLength, r.Header.Get("Content-Type"))
+		if err != nil {
+			http.Error(w, "upload failed", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("ETag", strconv.Quote(info.ETag))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	info, err := s.stat(bucket, key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "download failed", http.StatusInternalServerError)
+		return
+	}
+	obj, err := s.open(bucket, key)
+	if err != nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	defer obj.Close()
+
+	if info.ContentType != "" {
+		w.Header().Set("Content-Type", info.ContentType)
+	}
+	if info.ETag != "" {
+		w.Header().Set("ETag", strconv.Quote(info.ETag))
+	}
+	http.ServeContent(w, r, "", info.LastModified, obj)
+}
@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	md "social-network/services/media/internal/models"
+)
+
+// MemoryStorage keeps objects in memory, for unit tests. Its presigned urls
+// use the memory scheme and can't be fetched.
+type MemoryStorage struct {
+	mu      sync.RWMutex
+	buckets map[string]map[string]*memoryObject
+}
+
+type memoryObject struct {
+	data        []byte
+	contentType string
+	etag        string
+	modified    time.Time
+	tags        map[string]string
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{buckets: map[string]map[string]*memoryObject{}}
+}
+
+func (o *memoryObject) info(key string) md.ObjectInfo {
+	return md.ObjectInfo{
+		Key:          key,
+		Size:         int64(len(o.data)),
+		LastModified: o.modified,
+		ContentType:  o.contentType,
+		ETag:         o.etag,
+	}
+}
+
+// Returns the object, the caller holds the lock.
+func (s *MemoryStorage) object(bucket, key string) (*memoryObject, error) {
+	if err := checkKey(bucket, key); err != nil {
+		return nil, err
+	}
+	obj, ok := s.buckets[bucket][key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s/%s", ErrNotFound, bucket, key)
+	}
+	return obj, nil
+}
+
+// A size of -1 reads r to its end.
+func (s *MemoryStorage) PutObject(ctx context.Context, bucket, key string,
+	r io.Reader, size int64, contentType string) (md.ObjectInfo, error) {
+
+	if err := checkKey(bucket, key); err != nil {
+		return md.ObjectInfo{}, err
+	}
+	data, err := readObject(r, size)
+	if err != nil {
+		return md.ObjectInfo{}, err
+	}
+	sum := md5.Sum(data)
+	obj := &memoryObject{
+		data:        data,
+		contentType: contentType,
+		etag:        hex.EncodeToString(sum[:]),
+		modified:    time.Now(),
+		tags:        map[string]string{},
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.buckets[bucket] == nil {
+		s.buckets[bucket] = map[string]*memoryObject{}
+	}
+	s.buckets[bucket][key] = obj
+	return obj.info(key), nil
+}
+
+func (s *MemoryStorage) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	obj, err := s.object(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(obj.data)), nil // objects are replaced, never changed
+}
+
+func (s *MemoryStorage) StatObject(ctx context.Context, bucket, key string) (md.ObjectInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	obj, err := s.object(bucket, key)
+	if err != nil {
+		return md.ObjectInfo{}, err
+	}
+	return obj.info(key), nil
+}
+
+// Deleting a missing object is no error.
+func (s *MemoryStorage) DeleteObject(ctx context.Context, bucket, key string) error {
+	if err := checkKey(bucket, key); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.buckets[bucket], key)
+	return nil
+}
+
+// Lists the objects of a bucket in key order. Objects can be deleted by fn while listing.
+func (s *MemoryStorage) ListObjects(ctx context.Context, bucket string, pageSize int, fn func([]md.ObjectInfo) error) error {
+	s.mu.RLock()
+	keys := slices.Sorted(maps.Keys(s.buckets[bucket]))
+	s.mu.RUnlock()
+
+	pages := newPager(pageSize, fn)
+	for _, key := range keys {
+		s.mu.RLock()
+		obj, ok := s.buckets[bucket][key]
+		s.mu.RUnlock()
+		if !ok {
+			continue
+		}
+		if err := pages.add(obj.info(key)); err != nil {
+			return err
+		}
+	}
+	return pages.flush()
+}
+
+// memory://<bucket>/<key>?method=<method>&expires=<unix seconds>
+func (s *MemoryStorage) presign(method, bucket, key string, expiry time.Duration) (*url.URL, error) {
+	if err := checkKey(bucket, key); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("method", method)
+	params.Set("expires", strconv.FormatInt(time.Now().Add(expiry).Unix(), 10))
+	return &url.URL{Scheme: "memory", Host: bucket, Path: "/" + key, RawQuery: params.Encode()}, nil
+}
+
+func (s *MemoryStorage) PresignGet(ctx context.Context, bucket, key string, expiry time.Duration) (*url.URL, error) {
+	return s.presign("GET", bucket, key, expiry)
+}
+
+func (s *MemoryStorage) PresignInternalGet(ctx context.Context, bucket, key string, expiry time.Duration) (*url.URL, error) {
+	return s.presign("GET", bucket, key, expiry)
+}
+
+func (s *MemoryStorage) PresignPut(ctx context.Context, bucket, key string, expiry time.Duration) (*url.URL, error) {
+	return s.presign("PUT", bucket, key, expiry)
+}
+
+// Replaces the tags of an object.
+func (s *MemoryStorage) SetTags(ctx context.Context, bucket, key string, tags map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, err := s.object(bucket, key)
+	if err != nil {
+		return err
+	}
+	obj.tags = maps.Clone(tags)
+	return nil
+}
+
+// Objects without tags have an empty map.
+func (s *MemoryStorage) GetTags(ctx context.Context, bucket, key string) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	obj, err := s.object(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	tags := maps.Clone(obj.tags)
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	return tags, nil
+}
+
+// Reads exactly size bytes of r, or all of it if size is negative.
+func readObject(r io.Reader, size int64) ([]byte, error) {
+	if size < 0 {
+		return io.ReadAll(r)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("read object: %w", err)
+	}
+	return data, nil
+}
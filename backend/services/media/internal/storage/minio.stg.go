@@ -0,0 +1,257 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	md "social-network/services/media/internal/models"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+// MinIOStorage keeps objects in a MinIO or other S3 compatible service.
+// It also supports multipart uploads.
+type MinIOStorage struct {
+	client *minio.Client
+	public *minio.Client // signs the urls handed to clients, only for development
+}
+
+// NewMinIOStorage returns the storage of the client. Urls handed to clients are
+// signed by public if not nil, for services reaching MinIO on another host than clients.
+func NewMinIOStorage(client, public *minio.Client) *MinIOStorage {
+	return &MinIOStorage{client: client, public: public}
+}
+
+// Client signing the urls handed to clients.
+func (s *MinIOStorage) publicClient() *minio.Client {
+	if s.public != nil {
+		return s.public
+	}
+	return s.client
+}
+
+// Maps the error responses of MinIO to the errors of the package.
+func minIOError(err error) error {
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey", "NoSuchBucket":
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case "NoSuchUpload":
+		return fmt.Errorf("%w: %w", ErrNoSuchUpload, err)
+	case "InvalidPart", "InvalidPartOrder", "EntityTooSmall":
+		return fmt.Errorf("%w: %w", ErrInvalidPart, err)
+	}
+	return err
+}
+
+func (s *MinIOStorage) PutObject(ctx context.Context, bucket, key string,
+	r io.Reader, size int64, contentType string) (md.ObjectInfo, error) {
+
+	info, err := s.client.PutObject(ctx, bucket, key, r, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return md.ObjectInfo{}, minIOError(err)
+	}
+	return md.ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		LastModified: info.LastModified,
+		ContentType:  contentType,
+		ETag:         info.ETag,
+	}, nil
+}
+
+// Missing objects are reported here rather than on the first read.
+func (s *MinIOStorage) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, minIOError(err)
+	}
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		return nil, minIOError(err)
+	}
+	return obj, nil
+}
+
+func (s *MinIOStorage) StatObject(ctx context.Context, bucket, key string) (md.ObjectInfo, error) {
+	info, err := s.client.StatObject(ctx, bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return md.ObjectInfo{}, minIOError(err)
+	}
+	return md.ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		LastModified: info.LastModified,
+		ContentType:  info.ContentType,
+		ETag:         info.ETag,
+	}, nil
+}
+
+// Deleting a missing object is no error.
+func (s *MinIOStorage) DeleteObject(ctx context.Context, bucket, key string) error {
+	if err := s.client.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return minIOError(err)
+	}
+	return nil
+}
+
+func (s *MinIOStorage) ListObjects(ctx context.Context, bucket string, pageSize int, fn func([]md.ObjectInfo) error) error {
+	// stops the listing when fn fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := newPager(pageSize, fn)
+	for obj := range s.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{
+		Recursive: true,
+	}) {
+		if obj.Err != nil {
+			return minIOError(obj.Err)
+		}
+		if err := pages.add(md.ObjectInfo{
+			Key:          obj.Key,
+			Size:         obj.Size,
+			LastModified: obj.LastModified,
+			ContentType:  obj.ContentType,
+			ETag:         obj.ETag,
+		}); err != nil {
+			return err
+		}
+	}
+	return pages.flush()
+}
+
+func (s *MinIOStorage) PresignGet(ctx context.Context, bucket, key string, expiry time.Duration) (*url.URL, error) {
+	return s.publicClient().PresignedGetObject(ctx, bucket, key, expiry, nil)
+}
+
+func (s *MinIOStorage) PresignInternalGet(ctx context.Context, bucket, key string, expiry time.Duration) (*url.URL, error) {
+	return s.client.PresignedGetObject(ctx, bucket, key, expiry, nil)
+}
+
+func (s *MinIOStorage) PresignPut(ctx context.Context, bucket, key string, expiry time.Duration) (*url.URL, error) {
+	return s.publicClient().PresignedPutObject(ctx, bucket, key, expiry)
+}
+
+// Replaces the tags of an object.
+func (s *MinIOStorage) SetTags(ctx context.Context, bucket, key string, objTags map[string]string) error {
+	tagSet, err := tags.NewTags(objTags, true)
+	if err != nil {
+		return err
+	}
+	if err := s.client.PutObjectTagging(ctx, bucket, key, tagSet, minio.PutObjectTaggingOptions{}); err != nil {
+		return minIOError(err)
+	}
+	return nil
+}
+
+// Objects without tags have an empty map.
+func (s *MinIOStorage) GetTags(ctx context.Context, bucket, key string) (map[string]string, error) {
+	tagging, err := s.client.GetObjectTagging(ctx, bucket, key, minio.GetObjectTaggingOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchTagSet" {
+			return map[string]string{}, nil
+		}
+		return nil, minIOError(err)
+	}
+	return tagging.ToMap(), nil
+}
+
+func (s *MinIOStorage) NewMultipartUpload(ctx context.Context, bucket, key, contentType string) (string, error) {
+	core := minio.Core{Client: s.client}
+	uploadId, err := core.NewMultipartUpload(ctx, bucket, key, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", minIOError(err)
+	}
+	return uploadId, nil
+}
+
+// The ETag of the part is listed by ListParts, clients don't need to keep it.
+func (s *MinIOStorage) PresignPart(ctx context.Context, bucket, key, uploadId string,
+	partNumber int32, expiry time.Duration) (*url.URL, error) {
+
+	params := url.Values{}
+	params.Set("uploadId", uploadId)
+	params.Set("partNumber", strconv.Itoa(int(partNumber)))
+	return s.publicClient().Presign(ctx, http.MethodPut, bucket, key, expiry, params)
+}
+
+// Lists the parts uploaded so far, in part number order.
+func (s *MinIOStorage) ListParts(ctx context.Context, bucket, key, uploadId string) ([]md.UploadedPart, error) {
+	core := minio.Core{Client: s.client}
+	var parts []md.UploadedPart
+	marker := 0
+	for {
+		res, err := core.ListObjectParts(ctx, bucket, key, uploadId, marker, 1000)
+		if err != nil {
+			return nil, minIOError(err)
+		}
+		for _, p := range res.ObjectParts {
+			parts = append(parts, md.UploadedPart{
+				PartNumber: int32(p.PartNumber),
+				ETag:       p.ETag,
+				Size:       p.Size,
+			})
+		}
+		if !res.IsTruncated {
+			return parts, nil
+		}
+		marker = res.NextPartNumberMarker
+	}
+}
+
+func (s *MinIOStorage) CompleteMultipartUpload(ctx context.Context, bucket, key, uploadId string,
+	parts []md.UploadedPart) error {
+
+	complete := make([]minio.CompletePart, 0, len(parts))
+	for _, p := range parts {
+		complete = append(complete, minio.CompletePart{
+			PartNumber: int(p.PartNumber),
+			ETag:       p.ETag,
+		})
+	}
+
+	core := minio.Core{Client: s.client}
+	if _, err := core.CompleteMultipartUpload(ctx, bucket, key, uploadId,
+		complete, minio.PutObjectOptions{}); err != nil {
+		return minIOError(err)
+	}
+	return nil
+}
+
+// Aborting a missing upload is no error.
+func (s *MinIOStorage) AbortMultipartUpload(ctx context.Context, bucket, key, uploadId string) error {
+	core := minio.Core{Client: s.client}
+	if err := core.AbortMultipartUpload(ctx, bucket, key, uploadId); err != nil {
+		err = minIOError(err)
+		if errors.Is(err, ErrNoSuchUpload) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *MinIOStorage) ListMultipartUploads(ctx context.Context, bucket string) ([]md.MultipartInfo, error) {
+	var uploads []md.MultipartInfo
+	for u := range s.client.ListIncompleteUploads(ctx, bucket, "", true) {
+		if u.Err != nil {
+			return nil, minIOError(u.Err)
+		}
+		uploads = append(uploads, md.MultipartInfo{
+			Key:       u.Key,
+			UploadId:  u.UploadID,
+			Initiated: u.Initiated,
+		})
+	}
+	return uploads, nil
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	md "social-network/services/media/internal/models"
+)
+
+var (
+	ErrNotFound     = errors.New("object not found")
+	ErrNoSuchUpload = errors.New("multipart upload not found")
+	ErrInvalidPart  = errors.New("invalid multipart upload part")
+	ErrInvalidKey   = errors.New("invalid bucket or object key")
+	ErrInvalidURL   = errors.New("invalid signed url")
+	ErrExpiredURL   = errors.New("signed url expired")
+)
+
+// Collects listed objects into pages of at most size objects.
+type pager struct {
+	size int
+	page []md.ObjectInfo
+	fn   func([]md.ObjectInfo) error
+}
+
+func newPager(size int, fn func([]md.ObjectInfo) error) *pager {
+	size = max(size, 1)
+	return &pager{size: size, page: make([]md.ObjectInfo, 0, size), fn: fn}
+}
+
+// Adds an object, passing the page on once full.
+func (p *pager) add(o md.ObjectInfo) error {
+	p.page = append(p.page, o)
+	if len(p.page) < p.size {
+		return nil
+	}
+	return p.flush()
+}
+
+// Passes on the objects of an incomplete last page.
+func (p *pager) flush() error {
+	if len(p.page) == 0 {
+		return nil
+	}
+	err := p.fn(p.page)
+	p.page = make([]md.ObjectInfo, 0, p.size)
+	return err
+}
+
+// Checks that a bucket and key name an object inside the bucket.
+// Bucket names can't start with a dot, keys may contain slashes but no empty, '.' or '..' segments.
+func checkKey(bucket, key string) error {
+	if bucket == "" || strings.ContainsAny(bucket, `/\`) || strings.HasPrefix(bucket, ".") {
+		return fmt.Errorf("%w: bucket %q", ErrInvalidKey, bucket)
+	}
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) || path.Clean(key) != key {
+		return fmt.Errorf("%w: key %q", ErrInvalidKey, key)
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "." || seg == ".." {
+			return fmt.Errorf("%w: key %q", ErrInvalidKey, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"social-network/services/media/internal/configs"
+	md "social-network/services/media/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The operations shared by all storages, as used by the client package.
+type objectStorage interface {
+	PutObject(ctx context.Context, bucket, key string, r io.Reader, size int64, contentType string) (md.ObjectInfo, error)
+	GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+	StatObject(ctx context.Context, bucket, key string) (md.ObjectInfo, error)
+	DeleteObject(ctx context.Context, bucket, key string) error
+	ListObjects(ctx context.Context, bucket string, pageSize int, fn func([]md.ObjectInfo) error) error
+	SetTags(ctx context.Context, bucket, key string, tags map[string]string) error
+	GetTags(ctx context.Context, bucket, key string) (map[string]string, error)
+}
+
+func newTestLocalStorage(t *testing.T, baseURL string) *LocalStorage {
+	t.Helper()
+	s, err := NewLocalStorage(configs.LocalStorage{
+		Root:       t.TempDir(),
+		BaseURL:    baseURL,
+		SigningKey: "test-key",
+	})
+	require.NoError(t, err)
+	return s
+}
+
+// Lists the keys of a bucket by pages.
+func listKeys(t *testing.T, s objectStorage, bucket string, pageSize int) [][]string {
+	t.Helper()
+	var pages [][]string
+	err := s.ListObjects(context.Background(), bucket, pageSize, func(page []md.ObjectInfo) error {
+		var keys []string
+		for _, o := range page {
+			keys = append(keys, o.Key)
+		}
+		pages = append(pages, keys)
+		return nil
+	})
+	require.NoError(t, err)
+	return pages
+}
+
+func readAll(t *testing.T, s objectStorage, bucket, key string) []byte {
+	t.Helper()
+	obj, err := s.GetObject(context.Background(), bucket, key)
+	require.NoError(t, err)
+	defer obj.Close()
+	buf, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	return buf
+}
+
+func TestStorages(t *testing.T) {
+	storages := map[string]objectStorage{
+		"memory": NewMemoryStorage(),
+		"local":  newTestLocalStorage(t, "http://localhost:9100"),
+	}
+
+	for name, s := range storages {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			content := []byte("original content")
+			sum := md5.Sum(content)
+
+			info, err := s.PutObject(ctx, "originals", "abc", bytes.NewReader(content), int64(len(content)), "image/png")
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(content)), info.Size)
+			assert.Equal(t, hex.EncodeToString(sum[:]), info.ETag)
+
+			_, err = s.PutObject(ctx, "variants", "abc/thumb", strings.NewReader("thumb"), -1, "image/webp")
+			require.NoError(t, err)
+			_, err = s.PutObject(ctx, "variants", "abc/large", strings.NewReader("large"), 5, "image/webp")
+			require.NoError(t, err)
+
+			assert.Equal(t, content, readAll(t, s, "originals", "abc"))
+
+			stat, err := s.StatObject(ctx, "variants", "abc/thumb")
+			require.NoError(t, err)
+			assert.Equal(t, "abc/thumb", stat.Key)
+			assert.Equal(t, int64(5), stat.Size)
+			assert.Equal(t, "image/webp", stat.ContentType)
+
+			_, err = s.PutObject(ctx, "originals", "abc", strings.NewReader("clean"), 5, "image/png")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("clean"), readAll(t, s, "originals", "abc"))
+
+			assert.Equal(t, [][]string{{"abc/large", "abc/thumb"}}, listKeys(t, s, "variants", 10))
+			assert.Empty(t, listKeys(t, s, "missing", 10))
+
+			tags, err := s.GetTags(ctx, "originals", "abc")
+			require.NoError(t, err)
+			assert.Empty(t, tags)
+			require.NoError(t, s.SetTags(ctx, "originals", "abc", map[string]string{"validated": "true"}))
+			tags, err = s.GetTags(ctx, "originals", "abc")
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"validated": "true"}, tags)
+
+			require.NoError(t, s.DeleteObject(ctx, "variants", "abc/thumb"))
+			require.NoError(t, s.DeleteObject(ctx, "variants", "abc/thumb"))
+
+			_, err = s.StatObject(ctx, "variants", "abc/thumb")
+			assert.ErrorIs(t, err, ErrNotFound)
+			_, err = s.GetObject(ctx, "variants", "abc/thumb")
+			assert.ErrorIs(t, err, ErrNotFound)
+			_, err = s.GetTags(ctx, "variants", "abc/thumb")
+			assert.ErrorIs(t, err, ErrNotFound)
+			assert.ErrorIs(t, s.SetTags(ctx, "variants", "abc/thumb", nil), ErrNotFound)
+			_, err = s.StatObject(ctx, "variants", "abc") // prefix of an object
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			_, err = s.PutObject(ctx, "originals", "short", strings.NewReader("abc"), 10, "image/png")
+			assert.Error(t, err)
+			_, err = s.StatObject(ctx, "originals", "short")
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
+
+func TestListObjectsPages(t *testing.T) {
+	storages := map[string]objectStorage{
+		"memory": NewMemoryStorage(),
+		"local":  newTestLocalStorage(t, "http://localhost:9100"),
+	}
+
+	for name, s := range storages {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			for _, key := range []string{"a", "b/1", "b/2", "c", "d/1"} {
+				_, err := s.PutObject(ctx, "variants", key, strings.NewReader(key), -1, "image/webp")
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, [][]string{{"a", "b/1"}, {"b/2", "c"}, {"d/1"}}, listKeys(t, s, "variants", 2))
+
+			// objects are deleted while listing, as by the garbage collector
+			var listed []string
+			err := s.ListObjects(ctx, "variants", 2, func(page []md.ObjectInfo) error {
+				for _, o := range page {
+					listed = append(listed, o.Key)
+					require.NoError(t, s.DeleteObject(ctx, "variants", o.Key))
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a", "b/1", "b/2", "c", "d/1"}, listed)
+			assert.Empty(t, listKeys(t, s, "variants", 2))
+
+			// an error of fn stops the listing
+			_, err = s.PutObject(ctx, "variants", "e", strings.NewReader("e"), -1, "image/webp")
+			require.NoError(t, err)
+			_, err = s.PutObject(ctx, "variants", "f", strings.NewReader("f"), -1, "image/webp")
+			require.NoError(t, err)
+			stop := errors.New("stop")
+			calls := 0
+			err = s.ListObjects(ctx, "variants", 1, func(page []md.ObjectInfo) error {
+				calls++
+				return stop
+			})
+			assert.ErrorIs(t, err, stop)
+			assert.Equal(t, 1, calls)
+		})
+	}
+}
+
+func TestCheckKey(t *testing.T) {
+	valid := [][2]string{
+		{"originals", "abc"},
+		{"variants", "abc/thumb"},
+		{"variants", "abc/t_1.webp"},
+	}
+	for _, k := range valid {
+		assert.NoError(t, checkKey(k[0], k[1]), k)
+	}
+
+	invalid := [][2]string{
+		{"", "abc"},
+		{".meta", "abc"},
+		{"..", "abc"},
+		{"a/b", "abc"},
+		{"originals", ""},
+		{"originals", "/abc"},
+		{"originals", "../abc"},
+		{"originals", "abc/../../x"},
+		{"originals", "abc/"},
+		{"originals", "abc//thumb"},
+		{"originals", "./abc"},
+		{"originals", `abc\thumb`},
+	}
+	for _, k := range invalid {
+		assert.ErrorIs(t, checkKey(k[0], k[1]), ErrInvalidKey, k)
+	}
+}
+
+func TestLocalStorageHandler(t *testing.T) {
+	ctx := context.Background()
+	var s *LocalStorage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Handler().ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	s = newTestLocalStorage(t, srv.URL)
+
+	do := func(method, u string, body io.Reader, header http.Header) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, u, body)
+		require.NoError(t, err)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { res.Body.Close() })
+		return res
+	}
+
+	// upload to a presigned put url
+	putURL, err := s.PresignPut(ctx, "originals", "abc", time.Minute)
+	require.NoError(t, err)
+	res := do(http.MethodPut, putURL.String(), strings.NewReader("hello world"),
+		http.Header{"Content-Type": {"image/png"}})
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	etag := res.Header.Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	info, err := s.StatObject(ctx, "originals", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), info.Size)
+	assert.Equal(t, "image/png", info.ContentType)
+
+	// a put url doesn't download
+	res = do(http.MethodGet, putURL.String(), nil, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	getURL, err := s.PresignGet(ctx, "originals", "abc", time.Minute)
+	require.NoError(t, err)
+
+	t.Run("download", func(t *testing.T) {
+		res := do(http.MethodGet, getURL.String(), nil, nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(body))
+		assert.Equal(t, "image/png", res.Header.Get("Content-Type"))
+		assert.Equal(t, etag, res.Header.Get("ETag"))
+	})
+
+	t.Run("range", func(t *testing.T) {
+		res := do(http.MethodGet, getURL.String(), nil, http.Header{"Range": {"bytes=6-"}})
+		require.Equal(t, http.StatusPartialContent, res.StatusCode)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "world", string(body))
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		res := do(http.MethodGet, getURL.String(), nil, http.Header{"If-None-Match": {etag}})
+		assert.Equal(t, http.StatusNotModified, res.StatusCode)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		u := *getURL
+		u.Path = "/originals/other"
+		res := do(http.MethodGet, u.String(), nil, nil)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+		params := getURL.Query()
+		params.Set("expires", params.Get("expires")+"0")
+		u = *getURL
+		u.RawQuery = params.Encode()
+		res = do(http.MethodGet, u.String(), nil, nil)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		u, err := s.PresignGet(ctx, "originals", "abc", -time.Minute)
+		require.NoError(t, err)
+		res := do(http.MethodGet, u.String(), nil, nil)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		u, err := s.PresignGet(ctx, "originals", "missing", time.Minute)
+		require.NoError(t, err)
+		res := do(http.MethodGet, u.String(), nil, nil)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("internal url", func(t *testing.T) {
+		internal, err := NewLocalStorage(configs.LocalStorage{
+			Root:        t.TempDir(),
+			BaseURL:     "http://localhost:9100/storage",
+			InternalURL: "http://media:9100",
+		})
+		require.NoError(t, err)
+		u, err := internal.PresignGet(ctx, "variants", "abc/thumb", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "localhost:9100", u.Host)
+		assert.Equal(t, "/storage/variants/abc/thumb", u.Path)
+		u, err = internal.PresignInternalGet(ctx, "variants", "abc/thumb", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "media:9100", u.Host)
+		assert.Equal(t, "/variants/abc/thumb", u.Path)
+	})
+}
+
+func TestMemoryStoragePresign(t *testing.T) {
+	s := NewMemoryStorage()
+	u, err := s.PresignPut(context.Background(), "originals", "abc", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "memory", u.Scheme)
+	assert.Equal(t, "originals", u.Host)
+	assert.Equal(t, "/abc", u.Path)
+	assert.Equal(t, http.MethodPut, u.Query().Get("method"))
+
+	_, err = s.PresignGet(context.Background(), "originals", "../abc", time.Minute)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
@@ -0,0 +1,91 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"social-network/services/media/internal/configs"
+	md "social-network/services/media/internal/models"
+	ce "social-network/shared/go/commonerrors"
+)
+
+var (
+	ErrInvalidVideo     = errors.New("invalid video")
+	ErrVideoTooLong     = errors.New("video exceeds duration limit")
+	ErrUnsupportedCodec = errors.New("unsupported video codec")
+)
+
+type VideoValidator struct {
+	Config configs.VideoConstraints
+}
+
+// ValidateVideo checks the probed info of an uploaded video against the configured constraints.
+//
+// Validation Steps:
+//
+// 1️⃣ Container: at least one of the format names ffprobe reports must be allowed.
+// A mov/mp4 file is reported as "mov,mp4,m4a,3gp,3g2,mj2", a webm one as "matroska,webm".
+//
+// 2️⃣ Video stream: the file must have one, encoded with an allowed codec.
+//
+// 3️⃣ Duration: must be known, positive and within MaxDuration.
+//
+// 4️⃣ Dimensions: must be positive and within MaxWidth and MaxHeight.
+//
+// The video content itself is checked when transcoding, which fails on corrupted streams.
+func (v *VideoValidator) ValidateVideo(ctx context.Context, info md.VideoInfo) error {
+
+	// 1️⃣ Container
+	allowedFormat := false
+	for _, f := range info.Formats {
+		if v.Config.AllowedFormats[f] {
+			allowedFormat = true
+			break
+		}
+	}
+	if !allowedFormat {
+		return ce.New(ce.ErrPermissionDenied,
+			fmt.Errorf("%w: container %v", ErrUnsupportedType, info.Formats),
+			"container check",
+		).WithPublic("unsupported video container")
+	}
+
+	// 2️⃣ Video stream
+	if info.VideoCodec == "" {
+		return ce.New(ce.ErrPermissionDenied,
+			fmt.Errorf("%w: no video stream", ErrInvalidVideo),
+			"stream check",
+		).WithPublic("invalid video file")
+	}
+	if !v.Config.AllowedCodecs[info.VideoCodec] {
+		return ce.New(ce.ErrPermissionDenied,
+			fmt.Errorf("%w: %s", ErrUnsupportedCodec, info.VideoCodec),
+			"codec check",
+		).WithPublic(fmt.Sprintf("unsupported video codec %v", info.VideoCodec))
+	}
+
+	// 3️⃣ Duration
+	if info.Duration <= 0 {
+		return ce.New(ce.ErrPermissionDenied,
+			fmt.Errorf("%w: unknown duration", ErrInvalidVideo),
+			"duration check",
+		).WithPublic("invalid video file")
+	}
+	if info.Duration > v.Config.MaxDuration {
+		return ce.New(ce.ErrPermissionDenied,
+			fmt.Errorf("%w: actual: %v, max allowed: %v", ErrVideoTooLong, info.Duration, v.Config.MaxDuration),
+			"duration check",
+		).WithPublic(fmt.Sprintf("video too long. Max duration %v", v.Config.MaxDuration))
+	}
+
+	// 4️⃣ Dimensions
+	if info.Width <= 0 || info.Height <= 0 ||
+		info.Width > v.Config.MaxWidth || info.Height > v.Config.MaxHeight {
+		return ce.New(ce.ErrPermissionDenied,
+			fmt.Errorf("%w: %dx%d", ErrInvalidDimension, info.Width, info.Height),
+			"dimensions check",
+		).WithPublic("invalid dimensions")
+	}
+
+	return nil
+}
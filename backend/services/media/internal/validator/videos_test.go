@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"social-network/services/media/internal/configs"
+	md "social-network/services/media/internal/models"
+	ce "social-network/shared/go/commonerrors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVideo(t *testing.T) {
+	v := &VideoValidator{Config: configs.VideoConstraints{
+		MaxDuration:    time.Minute,
+		MaxWidth:       1920,
+		MaxHeight:      1920,
+		AllowedFormats: map[string]bool{"mp4": true, "webm": true},
+		AllowedCodecs:  map[string]bool{"h264": true, "vp9": true},
+	}}
+	valid := md.VideoInfo{
+		Formats:    []string{"mov", "mp4", "m4a"},
+		Duration:   30 * time.Second,
+		VideoCodec: "h264",
+		AudioCodec: "aac",
+		Width:      1920,
+		Height:     1080,
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*md.VideoInfo)
+		err    error
+	}{
+		{"valid", func(*md.VideoInfo) {}, nil},
+		{"no audio", func(i *md.VideoInfo) { i.AudioCodec = "" }, nil},
+		{"container", func(i *md.VideoInfo) { i.Formats = []string{"avi"} }, ErrUnsupportedType},
+		{"no video stream", func(i *md.VideoInfo) { i.VideoCodec = "" }, ErrInvalidVideo},
+		{"codec", func(i *md.VideoInfo) { i.VideoCodec = "mpeg4" }, ErrUnsupportedCodec},
+		{"unknown duration", func(i *md.VideoInfo) { i.Duration = 0 }, ErrInvalidVideo},
+		{"too long", func(i *md.VideoInfo) { i.Duration = time.Minute + time.Second }, ErrVideoTooLong},
+		{"zero size", func(i *md.VideoInfo) { i.Width = 0 }, ErrInvalidDimension},
+		{"too large", func(i *md.VideoInfo) { i.Width, i.Height = 3840, 2160 }, ErrInvalidDimension},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := valid
+			tt.modify(&info)
+			err := v.ValidateVideo(context.Background(), info)
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.err)
+			assert.ErrorIs(t, err, ce.ErrPermissionDenied)
+		})
+	}
+}
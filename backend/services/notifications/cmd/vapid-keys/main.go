@@ -0,0 +1,19 @@
+// Command vapid-keys prints a new VAPID key pair for Web Push.
+// Set VAPID_PRIVATE_KEY of the notifications service to the private key.
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"social-network/services/notifications/internal/webpush"
+)
+
+func main() {
+	private, public, err := webpush.GenerateVAPIDKeys()
+	if err != nil {
+		log.Fatal("failed to generate keys: ", err)
+	}
+	fmt.Println("VAPID_PRIVATE_KEY=" + private)
+	fmt.Println("public key:        " + public)
+}
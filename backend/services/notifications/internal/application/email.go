@@ -0,0 +1,321 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	db "social-network/services/notifications/internal/db/sqlc"
+	"social-network/services/notifications/internal/email"
+	tele "social-network/shared/go/telemetry"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// EmailMode is how a user gets notifications by email.
+type EmailMode string
+
+const (
+	EmailOff       EmailMode = "off"
+	EmailImmediate EmailMode = "immediate" // one email per new notification
+	EmailDaily     EmailMode = "daily"     // a digest of unread notifications once a day
+	EmailWeekly    EmailMode = "weekly"    // a digest of unread notifications once a week
+)
+
+func (m EmailMode) Valid() bool {
+	switch m {
+	case EmailOff, EmailImmediate, EmailDaily, EmailWeekly:
+		return true
+	}
+	return false
+}
+
+// digestPeriods are the time between digests of each digest mode, and how the period reads in the email
+var digestPeriods = []struct {
+	mode   EmailMode
+	period time.Duration
+	name   string
+}{
+	{EmailDaily, 24 * time.Hour, "day"},
+	{EmailWeekly, 7 * 24 * time.Hour, "week"},
+}
+
+const (
+	digestBatchSize        = 100 // users handled per query while sending digests
+	digestMaxNotifications = 200 // notifications read per digest, the email lists fewer
+	emailSendTimeout       = 30 * time.Second
+)
+
+// EmailDB represents the database operations for email delivery
+type EmailDB interface {
+	GetEmailSettings(ctx context.Context, userID int64) (db.NotificationEmailSetting, error)
+	UpsertEmailMode(ctx context.Context, arg db.UpsertEmailModeParams) error
+	GetDueEmailDigests(ctx context.Context, arg db.GetDueEmailDigestsParams) ([]db.GetDueEmailDigestsRow, error)
+	ClaimEmailDigest(ctx context.Context, arg db.ClaimEmailDigestParams) (int64, error)
+	GetEmailDigestNotifications(ctx context.Context, arg db.GetEmailDigestNotificationsParams) ([]db.Notification, error)
+}
+
+// EmailAddresses looks up where to email a user
+type EmailAddresses interface {
+	GetUserEmail(ctx context.Context, userID int64) (string, error)
+}
+
+type EmailConfig struct {
+	AppURL            string        // public address of the frontend, linked from emails
+	UnsubscribeURL    string        // public address of the unsubscribe endpoint, the token is added as query param
+	UnsubscribeSecret []byte        // signs unsubscribe tokens
+	DefaultMode       EmailMode     // for users that never chose, daily if empty
+	DigestInterval    time.Duration // how often due digests are looked for, 15 minutes if zero
+}
+
+// EmailChannel delivers notifications by email, immediately or as digests.
+type EmailChannel struct {
+	db        EmailDB
+	addresses EmailAddresses
+	sender    email.Sender
+	renderer  *email.Renderer
+	cfg       EmailConfig
+}
+
+func NewEmailChannel(emailDB EmailDB, addresses EmailAddresses, sender email.Sender, cfg EmailConfig) (*EmailChannel, error) {
+	if len(cfg.UnsubscribeSecret) == 0 {
+		return nil, fmt.Errorf("email channel needs an unsubscribe secret")
+	}
+	if cfg.DefaultMode == "" {
+		cfg.DefaultMode = EmailDaily
+	}
+	if !cfg.DefaultMode.Valid() {
+		return nil, fmt.Errorf("bad default email mode %q", cfg.DefaultMode)
+	}
+	if cfg.DigestInterval <= 0 {
+		cfg.DigestInterval = 15 * time.Minute
+	}
+
+	renderer, err := email.NewRenderer(cfg.AppURL)
+	if err != nil {
+		return nil, err
+	}
+	return &EmailChannel{
+		db:        emailDB,
+		addresses: addresses,
+		sender:    sender,
+		renderer:  renderer,
+		cfg:       cfg,
+	}, nil
+}
+
+func (e *EmailChannel) unsubscribeURL(userID int64) string {
+	return e.cfg.UnsubscribeURL + "?token=" + url.QueryEscape(email.UnsubscribeToken(e.cfg.UnsubscribeSecret, userID))
+}
+
+// mode returns the email mode of a user, the default if they never chose one
+func (e *EmailChannel) mode(ctx context.Context, userID int64) (EmailMode, error) {
+	settings, err := e.db.GetEmailSettings(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return e.cfg.DefaultMode, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get email settings: %w", err)
+	}
+	return EmailMode(settings.Mode), nil
+}
+
+// emailMode returns the email mode of a user, off when email is not set up
+func (a *Application) emailMode(ctx context.Context, userID int64) (EmailMode, error) {
+	if a.Email == nil {
+		return EmailOff, nil
+	}
+	return a.Email.mode(ctx, userID)
+}
+
+// sendImmediateEmailAsync emails a new notification to users on immediate mode.
+// Failures are logged, the notification itself is already stored.
+func (a *Application) sendImmediateEmailAsync(n email.Notification, userID int64) {
+	if a.Email == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), emailSendTimeout)
+		defer cancel()
+		if err := a.sendImmediateEmail(ctx, n, userID); err != nil {
+			tele.Warn(ctx, "failed to email notification to user @1: @2", "userId", userID, "error", err.Error())
+		}
+	}()
+}
+
+func (a *Application) sendImmediateEmail(ctx context.Context, n email.Notification, userID int64) error {
+	mode, err := a.Email.mode(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if mode != EmailImmediate {
+		return nil
+	}
+
+	to, err := a.Email.addresses.GetUserEmail(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get email address: %w", err)
+	}
+	if to == "" {
+		return nil
+	}
+
+	msg, err := a.Email.renderer.Immediate(to, n, a.Email.unsubscribeURL(userID))
+	if err != nil {
+		return err
+	}
+	return a.Email.sender.Send(ctx, msg)
+}
+
+// UnsubscribeEmail turns email off for the user an unsubscribe token was made for.
+func (a *Application) UnsubscribeEmail(ctx context.Context, token string) error {
+	if a.Email == nil {
+		return fmt.Errorf("email notifications are not available")
+	}
+	userID, err := email.ParseUnsubscribeToken(a.Email.cfg.UnsubscribeSecret, token)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPreference, err)
+	}
+	if err := a.Email.db.UpsertEmailMode(ctx, db.UpsertEmailModeParams{UserID: userID, Mode: string(EmailOff)}); err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+	return nil
+}
+
+func (a *Application) setEmailMode(ctx context.Context, userID int64, mode EmailMode) error {
+	if a.Email == nil {
+		return fmt.Errorf("email notifications are not available")
+	}
+	if !mode.Valid() {
+		return fmt.Errorf("%w: unknown email mode %q", ErrInvalidPreference, mode)
+	}
+	if err := a.Email.db.UpsertEmailMode(ctx, db.UpsertEmailModeParams{UserID: userID, Mode: string(mode)}); err != nil {
+		return fmt.Errorf("failed to update email mode: %w", err)
+	}
+	return nil
+}
+
+// RunEmailDigests sends due digests every DigestInterval until ctx is done.
+func (a *Application) RunEmailDigests(ctx context.Context) {
+	if a.Email == nil {
+		return
+	}
+	ticker := time.NewTicker(a.Email.cfg.DigestInterval)
+	defer ticker.Stop()
+	for {
+		if err := a.SendDueDigests(ctx, time.Now()); err != nil {
+			tele.Error(ctx, "failed to send email digests: @1", "error", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDueDigests emails a digest to every user on a digest mode whose last digest is a period older than now
+// and who has unread notifications since. A digest is claimed before it is sent, so several replicas
+// never send the same digest twice, at the cost of losing it if sending fails.
+func (a *Application) SendDueDigests(ctx context.Context, now time.Time) error {
+	if a.Email == nil {
+		return nil
+	}
+	for _, p := range digestPeriods {
+		dueBefore := pgtype.Timestamptz{Time: now.Add(-p.period), Valid: true}
+		for {
+			due, err := a.Email.db.GetDueEmailDigests(ctx, db.GetDueEmailDigestsParams{
+				DueBefore:   dueBefore,
+				DefaultMode: string(a.Email.cfg.DefaultMode),
+				Mode:        string(p.mode),
+				MaxUsers:    digestBatchSize,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get due digests: %w", err)
+			}
+
+			for _, d := range due {
+				claimed, err := a.claimDigest(ctx, d.UserID, p.mode, now, dueBefore)
+				if err != nil {
+					return err
+				}
+				if !claimed {
+					continue
+				}
+				if err := a.sendDigest(ctx, d.UserID, d.Since, p.name); err != nil {
+					tele.Warn(ctx, "failed to send email digest to user @1: @2", "userId", d.UserID, "error", err.Error())
+				}
+			}
+
+			// claimed users are not due anymore, so the next query returns the next batch
+			if len(due) < digestBatchSize {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (a *Application) claimDigest(ctx context.Context, userID int64, mode EmailMode, now time.Time, dueBefore pgtype.Timestamptz) (bool, error) {
+	_, err := a.Email.db.ClaimEmailDigest(ctx, db.ClaimEmailDigestParams{
+		UserID:    userID,
+		Mode:      string(mode),
+		SentAt:    pgtype.Timestamptz{Time: now, Valid: true},
+		DueBefore: dueBefore,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// another replica got it
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim digest: %w", err)
+	}
+	return true, nil
+}
+
+func (a *Application) sendDigest(ctx context.Context, userID int64, since pgtype.Timestamptz, period string) error {
+	rows, err := a.Email.db.GetEmailDigestNotifications(ctx, db.GetEmailDigestNotificationsParams{
+		UserID:    userID,
+		CreatedAt: since,
+		Limit:     digestMaxNotifications,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get digest notifications: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	notifications := make([]email.Notification, 0, len(rows))
+	for _, r := range rows {
+		n := email.Notification{
+			Type:      r.NotifType,
+			Count:     r.Count.Int32,
+			CreatedAt: r.CreatedAt.Time,
+		}
+		if len(r.Payload) > 0 {
+			if err := json.Unmarshal(r.Payload, &n.Payload); err != nil {
+				return fmt.Errorf("failed to unmarshal payload: %w", err)
+			}
+		}
+		notifications = append(notifications, n)
+	}
+
+	to, err := a.Email.addresses.GetUserEmail(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get email address: %w", err)
+	}
+	if to == "" {
+		return nil
+	}
+
+	msg, err := a.Email.renderer.Digest(to, period, notifications, a.Email.unsubscribeURL(userID))
+	if err != nil {
+		return err
+	}
+	return a.Email.sender.Send(ctx, msg)
+}
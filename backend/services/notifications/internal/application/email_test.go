@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"social-network/services/notifications/internal/db/sqlc"
+	"social-network/services/notifications/internal/email"
+)
+
+var testUnsubscribeSecret = []byte("test-secret")
+
+// newEmailTestApp returns an application emailing into a temporary directory
+func newEmailTestApp(t *testing.T) (*Application, *MockEmailDB, string) {
+	dir := t.TempDir()
+	sender, err := email.NewFileSender(dir, "from@test.local")
+	require.NoError(t, err)
+
+	mockEmailDB := new(MockEmailDB)
+	channel, err := NewEmailChannel(mockEmailDB, fakeEmailAddresses{}, sender, EmailConfig{
+		AppURL:            "http://app",
+		UnsubscribeURL:    "http://api/notifications/unsubscribe",
+		UnsubscribeSecret: testUnsubscribeSecret,
+		DefaultMode:       EmailDaily,
+	})
+	require.NoError(t, err)
+
+	app := NewApplicationWithMocks(new(MockDB))
+	app.Email = channel
+	return app, mockEmailDB, dir
+}
+
+// sentEmails returns the content of the emails written into dir
+func sentEmails(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	emails := make([]string, 0, len(files))
+	for _, f := range files {
+		raw, err := os.ReadFile(f)
+		require.NoError(t, err)
+		emails = append(emails, string(raw))
+	}
+	return emails
+}
+
+// Test that users on immediate mode get an email per notification and others don't
+func TestSendImmediateEmail(t *testing.T) {
+	app, mockEmailDB, dir := newEmailTestApp(t)
+	ctx := context.Background()
+
+	mockEmailDB.On("GetEmailSettings", ctx, int64(1)).Return(sqlc.NotificationEmailSetting{UserID: 1, Mode: string(EmailImmediate)}, nil)
+	mockEmailDB.On("GetEmailSettings", ctx, int64(2)).Return(sqlc.NotificationEmailSetting{}, pgx.ErrNoRows)
+
+	n := email.Notification{Type: string(PostLike), Count: 1, Payload: map[string]string{"liker_name": "alice"}}
+	require.NoError(t, app.sendImmediateEmail(ctx, n, 1))
+	require.NoError(t, app.sendImmediateEmail(ctx, n, 2)) // default mode is daily
+
+	emails := sentEmails(t, dir)
+	require.Len(t, emails, 1)
+	assert.Contains(t, emails[0], "To: 1@test.local")
+	assert.Contains(t, emails[0], "Subject: alice liked your post")
+	assert.Contains(t, emails[0], "http://api/notifications/unsubscribe?token=")
+}
+
+// Test that due digests are claimed, then sent with the unread notifications since the last digest
+func TestSendDueDigests(t *testing.T) {
+	app, mockEmailDB, dir := newEmailTestApp(t)
+	ctx := context.Background()
+	now := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+	since := pgtype.Timestamptz{Time: now.Add(-30 * time.Hour), Valid: true}
+
+	mockEmailDB.On("GetDueEmailDigests", ctx, mock.MatchedBy(func(arg sqlc.GetDueEmailDigestsParams) bool {
+		return arg.Mode == string(EmailDaily) && arg.DefaultMode == string(EmailDaily) && arg.DueBefore.Time.Equal(now.Add(-24*time.Hour))
+	})).Return([]sqlc.GetDueEmailDigestsRow{{UserID: 5, Since: since}, {UserID: 6, Since: since}}, nil)
+	mockEmailDB.On("GetDueEmailDigests", ctx, mock.MatchedBy(func(arg sqlc.GetDueEmailDigestsParams) bool {
+		return arg.Mode == string(EmailWeekly)
+	})).Return([]sqlc.GetDueEmailDigestsRow{}, nil)
+
+	mockEmailDB.On("ClaimEmailDigest", ctx, mock.MatchedBy(func(arg sqlc.ClaimEmailDigestParams) bool { return arg.UserID == 5 })).Return(int64(5), nil)
+	// another replica already sent this one
+	mockEmailDB.On("ClaimEmailDigest", ctx, mock.MatchedBy(func(arg sqlc.ClaimEmailDigestParams) bool { return arg.UserID == 6 })).Return(int64(0), pgx.ErrNoRows)
+
+	mockEmailDB.On("GetEmailDigestNotifications", ctx, sqlc.GetEmailDigestNotificationsParams{
+		UserID:    5,
+		CreatedAt: since,
+		Limit:     digestMaxNotifications,
+	}).Return([]sqlc.Notification{
+		{UserID: 5, NotifType: string(NewFollower), Count: pgtype.Int4{Int32: 2, Valid: true}, Payload: []byte(`{"follower_name":"bob"}`), CreatedAt: pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true}},
+		{UserID: 5, NotifType: string(GroupInvite), Count: pgtype.Int4{Int32: 1, Valid: true}, Payload: []byte(`{"inviter_name":"carol","group_name":"Hikers"}`), CreatedAt: pgtype.Timestamptz{Time: now.Add(-2 * time.Hour), Valid: true}},
+	}, nil)
+
+	require.NoError(t, app.SendDueDigests(ctx, now))
+
+	emails := sentEmails(t, dir)
+	require.Len(t, emails, 1)
+	assert.Contains(t, emails[0], "To: 5@test.local")
+	assert.Contains(t, emails[0], "You have 2 unread notifications from the last day")
+	assert.Contains(t, emails[0], "- bob and 1 other started following you")
+	assert.Contains(t, emails[0], "- carol invited you to join Hikers")
+	mockEmailDB.AssertNotCalled(t, "GetEmailDigestNotifications", ctx, mock.MatchedBy(func(arg sqlc.GetEmailDigestNotificationsParams) bool { return arg.UserID == 6 }))
+}
+
+// Test that unsubscribe links turn email off, and only for valid tokens
+func TestUnsubscribeEmail(t *testing.T) {
+	app, mockEmailDB, _ := newEmailTestApp(t)
+	ctx := context.Background()
+
+	mockEmailDB.On("UpsertEmailMode", ctx, sqlc.UpsertEmailModeParams{UserID: 9, Mode: string(EmailOff)}).Return(nil)
+
+	assert.NoError(t, app.UnsubscribeEmail(ctx, email.UnsubscribeToken(testUnsubscribeSecret, 9)))
+	assert.ErrorIs(t, app.UnsubscribeEmail(ctx, email.UnsubscribeToken([]byte("forged"), 9)), ErrInvalidPreference)
+	mockEmailDB.AssertNumberOfCalls(t, "UpsertEmailMode", 1)
+}
@@ -0,0 +1,242 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	db "social-network/services/notifications/internal/db/sqlc"
+	ct "social-network/shared/go/ct"
+	"social-network/shared/go/models"
+	tele "social-network/shared/go/telemetry"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	groupBucket    = 24 * time.Hour // notifications are grouped with those of the same day
+	maxGroupActors = 3              // actors named per group, the latest
+)
+
+var ErrInvalidCursor = errors.New("invalid notifications cursor")
+
+// UserRetriever resolves user ids to their basic info, see the retrieveusers package
+type UserRetriever interface {
+	GetUsers(ctx context.Context, userIds ct.Ids) (map[ct.Id]models.User, error)
+}
+
+// NotificationActor is a user a group of notifications is about
+type NotificationActor struct {
+	UserID    ct.Id  `json:"user_id"`
+	Username  string `json:"username"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// NotificationGroup is a set of notifications about the same entity within a time bucket, shown as one.
+// Likes and comments are grouped by post and new followers together, other notifications are groups of their own.
+type NotificationGroup struct {
+	Type            NotificationType    `json:"type"`
+	SourceService   string              `json:"source_service"`
+	SourceEntityID  ct.Id               `json:"source_entity_id"` // of the latest notification
+	NotificationIDs []ct.Id             `json:"notification_ids"` // latest first
+	Count           int64               `json:"count"`            // events, aggregated notifications count for several
+	Actors          []NotificationActor `json:"actors"`           // latest first, at most maxGroupActors
+	OtherActors     int64               `json:"other_actors"`     // distinct users not in Actors
+	ActorsSummary   string              `json:"actors_summary"`   // e.g. "Alice, Bob and 12 others"
+	Seen            bool                `json:"seen"`
+	NeedsAction     bool                `json:"needs_action"`
+	Acted           bool                `json:"acted"`
+	Payload         map[string]string   `json:"payload"` // of the latest notification
+	LatestAt        time.Time           `json:"latest_at"`
+	EarliestAt      time.Time           `json:"earliest_at"`
+}
+
+// GroupsQuery selects a page of notification groups
+type GroupsQuery struct {
+	Limit      int32
+	Cursor     ct.Cursor // empty for the first page
+	Categories []string  // empty for all categories
+	UnreadOnly bool
+}
+
+// GetUserNotificationGroups returns a page of the notifications of a user grouped by entity, newest first,
+// and the cursor of the next page, empty on the last one
+func (a *Application) GetUserNotificationGroups(ctx context.Context, userID int64, query GroupsQuery) ([]*NotificationGroup, ct.Cursor, error) {
+	params := db.GetUserNotificationGroupsParams{
+		BucketSeconds: int64(groupBucket.Seconds()),
+		UserID:        userID,
+		Categories:    query.Categories,
+		UnreadOnly:    query.UnreadOnly,
+		Limit:         query.Limit,
+	}
+	if params.Categories == nil {
+		params.Categories = []string{}
+	}
+	if query.Cursor != "" {
+		createdAt, id, err := query.Cursor.Decode()
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+		}
+		params.CursorAt = pgtype.Timestamptz{Time: createdAt, Valid: true}
+		params.CursorID = pgtype.Int8{Int64: id, Valid: true}
+	}
+
+	rows, err := a.DB.GetUserNotificationGroups(ctx, params)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get notification groups: %w", err)
+	}
+
+	groups := make([]*NotificationGroup, len(rows))
+	payloads := make([][]map[string]string, len(rows))
+	for i, row := range rows {
+		groups[i], payloads[i], err = groupFromRow(row)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	a.setGroupActors(ctx, groups, payloads)
+
+	var next ct.Cursor
+	if len(rows) > 0 && len(rows) == int(query.Limit) {
+		last := rows[len(rows)-1]
+		next, err = ct.NewCursor(last.LatestAt.Time, last.LatestID)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	return groups, next, nil
+}
+
+// groupFromRow converts a group row, its payloads are returned raw, latest first, to find its actors.
+// OtherActors holds all distinct actors of the group until the named ones are known.
+func groupFromRow(row db.GetUserNotificationGroupsRow) (*NotificationGroup, []map[string]string, error) {
+	group := &NotificationGroup{
+		Type:            NotificationType(row.NotifType),
+		SourceService:   row.SourceService,
+		SourceEntityID:  ct.Id(row.SourceEntityID),
+		NotificationIDs: make([]ct.Id, len(row.NotificationIds)),
+		Count:           row.TotalCount,
+		OtherActors:     row.ActorCount,
+		Seen:            row.Seen,
+		NeedsAction:     row.NeedsAction,
+		Acted:           row.Acted,
+		LatestAt:        row.LatestAt.Time,
+		EarliestAt:      row.EarliestAt.Time,
+	}
+	for i, id := range row.NotificationIds {
+		group.NotificationIDs[i] = ct.Id(id)
+	}
+
+	payloads := make([]map[string]string, len(row.Payloads))
+	for i, raw := range row.Payloads {
+		if len(raw) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(raw, &payloads[i]); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+	}
+	if len(payloads) > 0 && payloads[0] != nil {
+		// encoding replaces the ids in place, the raw ones are still needed for the actors
+		payload, err := encodePayloadIds(maps.Clone(payloads[0]))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode payload ids: %w", err)
+		}
+		group.Payload = payload
+	}
+	return group, payloads, nil
+}
+
+// setGroupActors names the latest actors of each group, resolving all of them in one call.
+// Users that can't be resolved keep the name the notification was created with.
+func (a *Application) setGroupActors(ctx context.Context, groups []*NotificationGroup, payloads [][]map[string]string) {
+	var ids ct.Ids
+	for i, group := range groups {
+		key := actorPayloadKey[group.Type]
+		for _, payload := range payloads[i] {
+			id := payloadId(payload, key)
+			if !id.Valid {
+				continue
+			}
+			actorID := ct.Id(id.Int64)
+			if !hasActor(group.Actors, actorID) {
+				if len(group.Actors) == maxGroupActors {
+					continue
+				}
+				group.Actors = append(group.Actors, NotificationActor{
+					UserID:   actorID,
+					Username: payload[strings.TrimSuffix(key, "_id")+"_name"],
+				})
+				if !slices.Contains(ids, actorID) {
+					ids = append(ids, actorID)
+				}
+			}
+		}
+		// notifications from before actors were recorded may name actors that weren't counted
+		group.OtherActors = max(group.OtherActors-int64(len(group.Actors)), 0)
+	}
+
+	if a.Users != nil && len(ids) > 0 {
+		users, err := a.Users.GetUsers(ctx, ids)
+		if err != nil {
+			tele.Warn(ctx, "failed to get notification actors: @1", "error", err.Error())
+		}
+		for _, group := range groups {
+			for i, actor := range group.Actors {
+				if user, ok := users[actor.UserID]; ok {
+					group.Actors[i].Username = user.Username.String()
+					group.Actors[i].AvatarURL = user.AvatarURL
+				}
+			}
+		}
+	}
+
+	for _, group := range groups {
+		group.ActorsSummary = actorsSummary(group.Actors, group.OtherActors)
+	}
+}
+
+func hasActor(actors []NotificationActor, id ct.Id) bool {
+	for _, actor := range actors {
+		if actor.UserID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// actorsSummary names the actors of a group: "Alice", "Alice and Bob", "Alice, Bob and Carol",
+// and when there are more than that "Alice, Bob and 12 others"
+func actorsSummary(actors []NotificationActor, others int64) string {
+	names := make([]string, 0, len(actors))
+	for _, actor := range actors {
+		if actor.Username != "" {
+			names = append(names, actor.Username)
+		} else {
+			others++
+		}
+	}
+	if others > 0 && len(names) > 2 {
+		others += int64(len(names) - 2)
+		names = names[:2]
+	}
+
+	switch {
+	case len(names) == 0:
+		return ""
+	case others == 1:
+		return strings.Join(names, ", ") + " and 1 other"
+	case others > 1:
+		return strings.Join(names, ", ") + " and " + strconv.FormatInt(others, 10) + " others"
+	case len(names) == 1:
+		return names[0]
+	default:
+		return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	}
+}
@@ -0,0 +1,209 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"social-network/services/notifications/internal/db/sqlc"
+	ct "social-network/shared/go/ct"
+)
+
+// Test that actors are resolved, capped and summed up, and that a full page has a next cursor
+func TestGetUserNotificationGroups(t *testing.T) {
+	mockDB := new(MockDB)
+	app := NewApplicationWithMocks(mockDB)
+	app.Users = fakeUsers{
+		1: {UserId: 1, Username: "Alice", AvatarURL: "https://media/alice.png"},
+		2: {UserId: 2, Username: "Bob"},
+		3: {UserId: 3, Username: "Carol"},
+	}
+	ctx := context.Background()
+	latest := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+
+	mockDB.On("GetUserNotificationGroups", ctx, sqlc.GetUserNotificationGroupsParams{
+		BucketSeconds: int64(groupBucket.Seconds()),
+		UserID:        7,
+		Categories:    []string{"social"},
+		Limit:         2,
+	}).Return([]sqlc.GetUserNotificationGroupsRow{
+		{
+			NotifType:       string(PostLike),
+			SourceService:   "posts",
+			SourceEntityID:  40,
+			NotificationIds: []int64{13, 12, 11},
+			Payloads: [][]byte{
+				[]byte(`{"liker_id":"3","liker_name":"carol","post_id":"40"}`),
+				[]byte(`{"liker_id":"2","liker_name":"bob","post_id":"40"}`),
+				[]byte(`{"liker_id":"1","liker_name":"alice","post_id":"40"}`),
+			},
+			TotalCount: 14, // the first like of the post was aggregated with 11 more
+			ActorCount: 14,
+			LatestID:   13,
+			LatestAt:   pgtype.Timestamptz{Time: latest, Valid: true},
+			EarliestAt: pgtype.Timestamptz{Time: latest.Add(-time.Hour), Valid: true},
+		},
+		{
+			NotifType:       string(NewFollower),
+			SourceService:   "users",
+			SourceEntityID:  5,
+			NotificationIds: []int64{10},
+			Payloads:        [][]byte{[]byte(`{"follower_id":"5","follower_name":"Eve"}`)},
+			TotalCount:      1,
+			ActorCount:      1,
+			Seen:            true,
+			LatestID:        10,
+			LatestAt:        pgtype.Timestamptz{Time: latest.Add(-2 * time.Hour), Valid: true},
+			EarliestAt:      pgtype.Timestamptz{Time: latest.Add(-2 * time.Hour), Valid: true},
+		},
+	}, nil)
+
+	groups, next, err := app.GetUserNotificationGroups(ctx, 7, GroupsQuery{Limit: 2, Categories: []string{"social"}})
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	likes := groups[0]
+	assert.Equal(t, PostLike, likes.Type)
+	assert.Equal(t, []ct.Id{13, 12, 11}, likes.NotificationIDs)
+	assert.Equal(t, int64(14), likes.Count)
+	assert.Equal(t, []NotificationActor{
+		{UserID: 3, Username: "Carol"},
+		{UserID: 2, Username: "Bob"},
+		{UserID: 1, Username: "Alice", AvatarURL: "https://media/alice.png"},
+	}, likes.Actors)
+	assert.Equal(t, int64(11), likes.OtherActors)
+	assert.Equal(t, "Carol, Bob and 12 others", likes.ActorsSummary)
+	assert.NotEmpty(t, likes.Payload["post_id"])
+
+	followers := groups[1]
+	assert.Equal(t, []NotificationActor{{UserID: 5, Username: "Eve"}}, followers.Actors, "unresolved actors keep their payload name")
+	assert.Equal(t, "Eve", followers.ActorsSummary)
+	assert.True(t, followers.Seen)
+
+	createdAt, id, err := next.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), id)
+	assert.True(t, createdAt.Equal(latest.Add(-2*time.Hour)))
+	mockDB.AssertExpectations(t)
+}
+
+// Test that users acting several times are counted once, as are notifications without actor
+func TestGetUserNotificationGroupsRepeatedActors(t *testing.T) {
+	mockDB := new(MockDB)
+	app := NewApplicationWithMocks(mockDB)
+	app.Users = fakeUsers{
+		1: {UserId: 1, Username: "Alice"},
+		2: {UserId: 2, Username: "Bob"},
+	}
+	ctx := context.Background()
+	latest := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+
+	mockDB.On("GetUserNotificationGroups", ctx, mock.AnythingOfType("sqlc.GetUserNotificationGroupsParams")).Return([]sqlc.GetUserNotificationGroupsRow{
+		{
+			// Alice commented once, Bob three times, aggregated into Alice's unread notification
+			NotifType:       string(PostComment),
+			SourceService:   "posts",
+			SourceEntityID:  40,
+			NotificationIds: []int64{20},
+			Payloads:        [][]byte{[]byte(`{"commenter_id":"1","commenter_name":"alice","post_id":"40"}`)},
+			TotalCount:      4,
+			ActorCount:      2,
+			LatestID:        20,
+			LatestAt:        pgtype.Timestamptz{Time: latest, Valid: true},
+			EarliestAt:      pgtype.Timestamptz{Time: latest, Valid: true},
+		},
+		{
+			// Bob liked twice within the day, the likes were read in between
+			NotifType:       string(PostLike),
+			SourceService:   "posts",
+			SourceEntityID:  41,
+			NotificationIds: []int64{19, 18},
+			Payloads: [][]byte{
+				[]byte(`{"liker_id":"2","liker_name":"bob","post_id":"41"}`),
+				[]byte(`{"liker_id":"2","liker_name":"bob","post_id":"41"}`),
+			},
+			TotalCount: 2,
+			ActorCount: 1,
+			LatestID:   19,
+			LatestAt:   pgtype.Timestamptz{Time: latest.Add(-time.Hour), Valid: true},
+			EarliestAt: pgtype.Timestamptz{Time: latest.Add(-2 * time.Hour), Valid: true},
+		},
+	}, nil)
+
+	groups, _, err := app.GetUserNotificationGroups(ctx, 7, GroupsQuery{Limit: 20})
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	comments := groups[0]
+	assert.Equal(t, int64(4), comments.Count)
+	assert.Equal(t, []NotificationActor{{UserID: 1, Username: "Alice"}}, comments.Actors)
+	assert.Equal(t, int64(1), comments.OtherActors)
+	assert.Equal(t, "Alice and 1 other", comments.ActorsSummary)
+
+	likes := groups[1]
+	assert.Equal(t, int64(2), likes.Count)
+	assert.Equal(t, []NotificationActor{{UserID: 2, Username: "Bob"}}, likes.Actors)
+	assert.Zero(t, likes.OtherActors)
+	assert.Equal(t, "Bob", likes.ActorsSummary)
+}
+
+func TestGetUserNotificationGroupsCursor(t *testing.T) {
+	mockDB := new(MockDB)
+	app := NewApplicationWithMocks(mockDB)
+	ctx := context.Background()
+	at := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+	cursor, err := ct.NewCursor(at, 42)
+	require.NoError(t, err)
+
+	mockDB.On("GetUserNotificationGroups", ctx, sqlc.GetUserNotificationGroupsParams{
+		BucketSeconds: int64(groupBucket.Seconds()),
+		UserID:        7,
+		Categories:    []string{},
+		UnreadOnly:    true,
+		CursorAt:      pgtype.Timestamptz{Time: at, Valid: true},
+		CursorID:      pgtype.Int8{Int64: 42, Valid: true},
+		Limit:         20,
+	}).Return([]sqlc.GetUserNotificationGroupsRow{}, nil)
+
+	groups, next, err := app.GetUserNotificationGroups(ctx, 7, GroupsQuery{Limit: 20, Cursor: cursor, UnreadOnly: true})
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+	assert.Empty(t, next, "no next page after a short one")
+
+	_, _, err = app.GetUserNotificationGroups(ctx, 7, GroupsQuery{Limit: 20, Cursor: "not-a-cursor"})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	mockDB.AssertNumberOfCalls(t, "GetUserNotificationGroups", 1)
+}
+
+func TestActorsSummary(t *testing.T) {
+	actors := func(names ...string) []NotificationActor {
+		list := make([]NotificationActor, len(names))
+		for i, name := range names {
+			list[i] = NotificationActor{UserID: ct.Id(i + 1), Username: name}
+		}
+		return list
+	}
+
+	tests := []struct {
+		actors []NotificationActor
+		others int64
+		want   string
+	}{
+		{actors(), 0, ""},
+		{actors("Alice"), 0, "Alice"},
+		{actors("Alice", "Bob"), 0, "Alice and Bob"},
+		{actors("Alice", "Bob", "Carol"), 0, "Alice, Bob and Carol"},
+		{actors("Alice"), 1, "Alice and 1 other"},
+		{actors("Alice", "Bob"), 12, "Alice, Bob and 12 others"},
+		{actors("Alice", "Bob", "Carol"), 11, "Alice, Bob and 12 others"},
+		{actors("Alice", ""), 0, "Alice and 1 other"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, actorsSummary(tt.actors, tt.others))
+	}
+}
@@ -14,10 +14,13 @@ type DBInterface interface {
 	CreateNotification(ctx context.Context, arg sqlc.CreateNotificationParams) (sqlc.Notification, error)
 	GetNotificationByID(ctx context.Context, id int64) (sqlc.Notification, error)
 	GetUserNotifications(ctx context.Context, arg sqlc.GetUserNotificationsParams) ([]sqlc.Notification, error)
+	GetUserNotificationGroups(ctx context.Context, arg sqlc.GetUserNotificationGroupsParams) ([]sqlc.GetUserNotificationGroupsRow, error)
 	GetUserNotificationsCount(ctx context.Context, userID int64) (int64, error)
 	GetUserUnreadNotificationsCount(ctx context.Context, userID int64) (int64, error)
 	MarkNotificationAsRead(ctx context.Context, arg sqlc.MarkNotificationAsReadParams) error
 	MarkAllAsRead(ctx context.Context, userID int64) error
+	MarkReadByEntity(ctx context.Context, arg sqlc.MarkReadByEntityParams) ([]int64, error)
+	MarkReadByCategory(ctx context.Context, arg sqlc.MarkReadByCategoryParams) ([]int64, error)
 	DeleteNotification(ctx context.Context, arg sqlc.DeleteNotificationParams) error
 	CreateNotificationType(ctx context.Context, arg sqlc.CreateNotificationTypeParams) error
 	GetNotificationType(ctx context.Context, notifType string) (sqlc.NotificationType, error)
@@ -27,18 +30,35 @@ type DBInterface interface {
 	MarkNotificationAsActed(ctx context.Context, arg sqlc.MarkNotificationAsActedParams) error
 }
 
+// PreferencesDB represents the database operations for notification preferences and mutes
+type PreferencesDB interface {
+	IsNotificationEnabled(ctx context.Context, arg sqlc.IsNotificationEnabledParams) (bool, error)
+	ListNotificationTypes(ctx context.Context) ([]sqlc.NotificationType, error)
+	GetNotificationPreferences(ctx context.Context, userID int64) ([]sqlc.NotificationPreference, error)
+	UpsertNotificationPreference(ctx context.Context, arg sqlc.UpsertNotificationPreferenceParams) error
+	UpsertNotificationMute(ctx context.Context, arg sqlc.UpsertNotificationMuteParams) error
+	DeleteNotificationMute(ctx context.Context, arg sqlc.DeleteNotificationMuteParams) error
+	GetActiveNotificationMutes(ctx context.Context, userID int64) ([]sqlc.NotificationMute, error)
+}
+
 // Update the Application struct to use the interface
 type Application struct {
-	DB       DBInterface
-	Clients  *client.Clients
-	NatsConn *nats.Conn
+	DB          DBInterface
+	Preferences PreferencesDB // nil sends every notification
+	Email       *EmailChannel // nil sends no emails
+	Push        *PushChannel  // nil sends no push notifications
+	Retention   *Retention    // nil never purges, notifications expire after 30 days
+	Users       UserRetriever // nil names grouped notification actors from their payloads
+	Clients     *client.Clients
+	NatsConn    *nats.Conn
 }
 
 // NewApplication creates a new notification application service
-func NewApplication(db DBInterface, clients *client.Clients, natsConn *nats.Conn) *Application {
+func NewApplication(db DBInterface, prefs PreferencesDB, clients *client.Clients, natsConn *nats.Conn) *Application {
 	return &Application{
-		DB:       db,
-		Clients:  clients,
-		NatsConn: natsConn,
+		DB:          db,
+		Preferences: prefs,
+		Clients:     clients,
+		NatsConn:    natsConn,
 	}
 }
@@ -24,6 +24,8 @@ const (
 	GroupInviteRejected      NotificationType = "group_invite_rejected"
 	GroupJoinRequestAccepted NotificationType = "group_join_request_accepted"
 	GroupJoinRequestRejected NotificationType = "group_join_request_rejected"
+	PostPublished            NotificationType = "post_published"
+	PostShared               NotificationType = "post_shared"
 )
 
 // Notification represents a notification entity
@@ -57,6 +57,7 @@ func TestNATSIntegration(t *testing.T) {
 		Payload:        []byte(`{"liker_id":"789","liker_name":"test_user","post_id":"456","action":"view_post"}`),
 		ExpiresAt:      pgtype.Timestamptz{Time: time.Now().Add(30 * 24 * time.Hour), Valid: true},
 		Count:          pgtype.Int4{Int32: 1, Valid: true},
+		ActorIds:       []int64{},
 	}
 
 	expectedDBNotification := db.Notification{
@@ -133,6 +134,7 @@ func TestNATSErrorHandling(t *testing.T) {
 		Payload:        []byte(`{"liker_id":"789","liker_name":"test_user","post_id":"456","action":"view_post"}`),
 		ExpiresAt:      pgtype.Timestamptz{Time: time.Now().Add(30 * 24 * time.Hour), Valid: true},
 		Count:          pgtype.Int4{Int32: 1, Valid: true},
+		ActorIds:       []int64{},
 	}
 
 	expectedDBNotification := db.Notification{
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	pb "social-network/shared/gen-go/notifications"
+
 	"github.com/nats-io/nats.go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -96,3 +98,44 @@ func TestPublishNotificationToNATSWithNilConnection(t *testing.T) {
 	err := app.publishNotificationToNATS(ctx, testNotification)
 	assert.NoError(t, err)
 }
+
+// TestPublishUnreadCountToNATS tests the publishUnreadCountToNATS method
+func TestPublishUnreadCountToNATS(t *testing.T) {
+	nc, err := nats.Connect("nats://127.0.0.1:4222")
+	if err != nil {
+		t.Skip("NATS server not available, skipping test")
+	}
+	defer nc.Close()
+
+	mockDB := new(MockDB)
+	app := &Application{
+		DB:       mockDB,
+		NatsConn: nc,
+	}
+	ctx := context.Background()
+	mockDB.On("GetUserUnreadNotificationsCount", ctx, int64(123)).Return(int64(4), nil)
+
+	received := make(chan *pb.UnreadCountUpdate, 1)
+	sub, err := nc.Subscribe("ntf.123", func(m *nats.Msg) {
+		var update pb.UnreadCountUpdate
+		if err := json.Unmarshal(m.Data, &update); err != nil {
+			t.Errorf("Failed to unmarshal unread count: %v", err)
+			return
+		}
+		received <- &update
+	})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	err = app.publishUnreadCountToNATS(ctx, 123, []int64{7, 8})
+	require.NoError(t, err)
+
+	select {
+	case update := <-received:
+		assert.Equal(t, int64(123), update.UserId)
+		assert.Equal(t, int64(4), update.UnreadCount)
+		assert.Equal(t, []int64{7, 8}, update.ReadNotificationIds)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for NATS message")
+	}
+}
@@ -215,6 +215,67 @@ func (a *Application) CreatePostLikeNotification(ctx context.Context, userID, li
 	return nil
 }
 
+// CreatePostPublishedNotification creates a notification when a user's scheduled post gets published
+func (a *Application) CreatePostPublishedNotification(ctx context.Context, userID, postID int64, postContent string) error {
+	title := "Post Published"
+	message := "Your scheduled post is now live"
+
+	payload := map[string]string{
+		"post_id":      fmt.Sprintf("%d", postID),
+		"post_content": postContent,
+		"action":       "view_post",
+	}
+
+	_, err := a.CreateNotification(
+		ctx,
+		userID,        // recipient (the post creator)
+		PostPublished, // type
+		title,         // title
+		message,       // message
+		"posts",       // source service
+		postID,        // source entity ID
+		false,         // doesn't need action
+		payload,       // payload
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create post published notification: %w", err)
+	}
+
+	return nil
+}
+
+// CreatePostShareNotification creates a notification when someone shares a user's post
+func (a *Application) CreatePostShareNotification(ctx context.Context, userID, sharerID, postID, shareID int64, sharerUsername, quote string) error {
+	title := "Post Shared"
+	message := fmt.Sprintf("%s shared your post", sharerUsername)
+
+	payload := map[string]string{
+		"sharer_id":   fmt.Sprintf("%d", sharerID),
+		"sharer_name": sharerUsername,
+		"post_id":     fmt.Sprintf("%d", postID),
+		"share_id":    fmt.Sprintf("%d", shareID),
+		"quote":       quote,
+		"action":      "view_post",
+	}
+
+	_, err := a.CreateNotification(
+		ctx,
+		userID,     // recipient (the original post creator)
+		PostShared, // type
+		title,      // title
+		message,    // message
+		"posts",    // source service
+		shareID,    // source entity ID
+		false,      // doesn't need action
+		payload,    // payload
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create post share notification: %w", err)
+	}
+
+	return nil
+}
+
 // CreatePostCommentNotification creates a notification when someone comments on a user's post
 func (a *Application) CreatePostCommentNotification(ctx context.Context, userID, commenterID, postID int64, commenterUsername, postContent string, aggregate bool) error {
 	title := "New Comment"
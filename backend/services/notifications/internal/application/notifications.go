@@ -10,6 +10,7 @@ import (
 	"time"
 
 	db "social-network/services/notifications/internal/db/sqlc"
+	"social-network/services/notifications/internal/email"
 	pb "social-network/shared/gen-go/notifications"
 	ct "social-network/shared/go/ct"
 	tele "social-network/shared/go/telemetry"
@@ -26,6 +27,18 @@ func (a *Application) CreateNotification(ctx context.Context, userID int64, noti
 
 // CreateNotificationWithAggregation creates a new notification or aggregates with an existing one if applicable
 func (a *Application) CreateNotificationWithAggregation(ctx context.Context, userID int64, notifType NotificationType, title, message, sourceService string, sourceEntityID int64, needsAction bool, payload map[string]string, aggregate bool) (*Notification, error) {
+	// Users can switch notification types off and mute groups, conversations and users.
+	// A suppressed response still closes the request it answers.
+	if !a.notificationEnabled(ctx, userID, notifType, payload) {
+		switch notifType {
+		case FollowRequestAccepted, FollowRequestRejected, GroupInviteAccepted, GroupInviteRejected, GroupJoinRequestAccepted, GroupJoinRequestRejected:
+			if err := a.MarkRelatedNotificationAsActed(ctx, notifType, userID, sourceEntityID, payload); err != nil {
+				tele.Error(ctx, "failed to mark related notification as acted: @1", "error", err.Error())
+			}
+		}
+		return nil, nil
+	}
+
 	if !aggregate {
 		// If aggregation is disabled, create a new notification as before
 		return a.createNotification(ctx, userID, notifType, title, message, sourceService, sourceEntityID, needsAction, payload, 1)
@@ -49,9 +62,10 @@ func (a *Application) CreateNotificationWithAggregation(ctx context.Context, use
 	// If an existing unread notification is found, increment its count and update it
 	newCount := existingNotification.Count.Int32 + 1
 	err = a.DB.UpdateNotificationCount(ctx, db.UpdateNotificationCountParams{
-		Count:  pgtype.Int4{Int32: newCount, Valid: true},
-		ID:     existingNotification.ID,
-		UserID: userID,
+		Count:   pgtype.Int4{Int32: newCount, Valid: true},
+		ActorID: payloadId(payload, actorPayloadKey[notifType]),
+		ID:      existingNotification.ID,
+		UserID:  userID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update notification count: %w", err)
@@ -138,8 +152,8 @@ func (a *Application) createNotification(ctx context.Context, userID int64, noti
 		return nil, fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	// Calculate expiration time (default 30 days if not specified)
-	expiresAt := time.Now().Add(30 * 24 * time.Hour)
+	// Calculate expiration time, the database applies the type's own retention if it has one
+	expiresAt := a.expiresAt(time.Now())
 
 	// Create the notification in the database
 	dbNotification, err := a.DB.CreateNotification(ctx, db.CreateNotificationParams{
@@ -152,6 +166,7 @@ func (a *Application) createNotification(ctx context.Context, userID int64, noti
 		Payload:        payloadJSON,
 		ExpiresAt:      pgtype.Timestamptz{Time: expiresAt, Valid: true},
 		Count:          pgtype.Int4{Int32: count, Valid: true},
+		ActorIds:       actorIds(notifType, payload),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create notification: %w", err)
@@ -221,6 +236,15 @@ func (a *Application) createNotification(ctx context.Context, userID int64, noti
 		}
 	}()
 
+	// Only new notifications are emailed and pushed, aggregated ones would send every like
+	a.sendImmediateEmailAsync(email.Notification{
+		Type:      string(notifType),
+		Count:     count,
+		Payload:   payload,
+		CreatedAt: notification.CreatedAt,
+	}, userID)
+	a.sendPushAsync(notification)
+
 	return notification, nil
 }
 
@@ -266,7 +290,8 @@ func (a *Application) formatAggregatedMessage(originalMessage string, count int6
 	}
 }
 
-// CreateNotifications creates multiple notifications in a batch
+// CreateNotifications creates multiple notifications in a batch.
+// Notifications suppressed by the preferences of their recipient are left out of the result.
 func (a *Application) CreateNotifications(ctx context.Context, notifications []struct {
 	UserID         int64
 	Type           NotificationType
@@ -284,6 +309,9 @@ func (a *Application) CreateNotifications(ctx context.Context, notifications []s
 		if err != nil {
 			return nil, err
 		}
+		if notification == nil {
+			continue
+		}
 		result = append(result, notification)
 	}
 
@@ -430,6 +458,7 @@ func (a *Application) MarkNotificationAsRead(ctx context.Context, notificationID
 	if err != nil {
 		return fmt.Errorf("failed to mark notification as read: %w", err)
 	}
+	a.publishUnreadCountAsync(userID, []int64{notificationID})
 	return nil
 }
 
@@ -451,9 +480,42 @@ func (a *Application) MarkAllAsRead(ctx context.Context, userID int64) error {
 	if err != nil {
 		return fmt.Errorf("failed to mark all notifications as read: %w", err)
 	}
+	a.publishUnreadCountAsync(userID, nil)
 	return nil
 }
 
+// MarkReadByEntity marks the unread notifications of a user about an entity as read, e.g. the new messages
+// of a conversation once it is opened, and returns how many were marked
+func (a *Application) MarkReadByEntity(ctx context.Context, userID int64, sourceService string, sourceEntityID int64) (int, error) {
+	ids, err := a.DB.MarkReadByEntity(ctx, db.MarkReadByEntityParams{
+		UserID:         userID,
+		SourceService:  sourceService,
+		SourceEntityID: pgtype.Int8{Int64: sourceEntityID, Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications of entity as read: %w", err)
+	}
+	if len(ids) > 0 {
+		a.publishUnreadCountAsync(userID, ids)
+	}
+	return len(ids), nil
+}
+
+// MarkReadByCategory marks the unread notifications of a user in a category as read and returns how many were marked
+func (a *Application) MarkReadByCategory(ctx context.Context, userID int64, category string) (int, error) {
+	ids, err := a.DB.MarkReadByCategory(ctx, db.MarkReadByCategoryParams{
+		UserID:   userID,
+		Category: pgtype.Text{String: category, Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications of category as read: %w", err)
+	}
+	if len(ids) > 0 {
+		a.publishUnreadCountAsync(userID, ids)
+	}
+	return len(ids), nil
+}
+
 // DeleteNotification soft deletes a notification
 func (a *Application) DeleteNotification(ctx context.Context, notificationID, userID int64) error {
 	err := a.DB.DeleteNotification(ctx, db.DeleteNotificationParams{
@@ -488,6 +550,8 @@ func (a *Application) CreateDefaultNotificationTypes(ctx context.Context) error
 		{string(GroupInviteRejected), "group", true},
 		{string(GroupJoinRequestAccepted), "group", true},
 		{string(GroupJoinRequestRejected), "group", true},
+		{string(PostPublished), "posts", true},
+		{string(PostShared), "posts", true},
 	}
 
 	for _, nt := range defaultTypes {
@@ -806,3 +870,55 @@ func encodePayloadIds(payload map[string]string) (map[string]string, error) {
 	}
 	return payload, nil
 }
+
+// publishUnreadCountAsync tells the live connections of a user how many notifications are left unread
+func (a *Application) publishUnreadCountAsync(userID int64, readIDs []int64) {
+	if a.NatsConn == nil {
+		return
+	}
+	go func() {
+		// Create a background context for the NATS publish operation
+		natsCtx := context.Background()
+		if err := a.publishUnreadCountToNATS(natsCtx, userID, readIDs); err != nil {
+			tele.Error(natsCtx, "failed to publish unread count to nats in background: @1", "error", err.Error())
+		}
+	}()
+}
+
+// publishUnreadCountToNATS publishes the unread count of a user to NATS for real-time delivery to the live service
+func (a *Application) publishUnreadCountToNATS(ctx context.Context, userID int64, readIDs []int64) error {
+	if a.NatsConn == nil {
+		tele.Warn(ctx, "NATS connection is nil, skipping unread count publish")
+		return nil
+	}
+
+	count, err := a.DB.GetUserUnreadNotificationsCount(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get unread notifications count: %w", err)
+	}
+
+	updateJSON, err := json.Marshal(&pb.UnreadCountUpdate{
+		UserId:              userID,
+		UnreadCount:         count,
+		ReadNotificationIds: readIDs,
+		UpdatedAt:           timestamppb.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal unread count to JSON: %w", err)
+	}
+
+	// Publish to the user-specific NATS subject
+	err = a.NatsConn.Publish(ct.UserKey(userID), updateJSON)
+	if err != nil {
+		return fmt.Errorf("failed to publish unread count to nats: %w", err)
+	}
+
+	// Flush to ensure the message is sent
+	err = a.NatsConn.Flush()
+	if err != nil {
+		tele.Error(ctx, "failed to flush nats connection: @1", "error", err.Error())
+	}
+
+	tele.Info(ctx, "Published unread count @1 to nats for user @2", "unreadCount", count, "userId", userID)
+	return nil
+}
@@ -236,6 +236,49 @@ func TestMarkAllAsRead(t *testing.T) {
 	mockDB.AssertExpectations(t)
 }
 
+// Test MarkReadByEntity function
+func TestMarkReadByEntity(t *testing.T) {
+	mockDB := new(MockDB)
+	app := NewApplicationWithMocks(mockDB)
+
+	ctx := context.Background()
+	userID := int64(10)
+
+	mockDB.On("MarkReadByEntity", ctx, sqlc.MarkReadByEntityParams{
+		UserID:         userID,
+		SourceService:  "chat",
+		SourceEntityID: pgtype.Int8{Int64: 55, Valid: true},
+	}).Return([]int64{3, 4}, nil)
+
+	marked, err := app.MarkReadByEntity(ctx, userID, "chat", 55)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, marked)
+
+	mockDB.AssertExpectations(t)
+}
+
+// Test MarkReadByCategory function
+func TestMarkReadByCategory(t *testing.T) {
+	mockDB := new(MockDB)
+	app := NewApplicationWithMocks(mockDB)
+
+	ctx := context.Background()
+	userID := int64(10)
+
+	mockDB.On("MarkReadByCategory", ctx, sqlc.MarkReadByCategoryParams{
+		UserID:   userID,
+		Category: pgtype.Text{String: "social", Valid: true},
+	}).Return([]int64{}, nil)
+
+	marked, err := app.MarkReadByCategory(ctx, userID, "social")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, marked)
+
+	mockDB.AssertExpectations(t)
+}
+
 // Test DeleteNotification function
 func TestDeleteNotification(t *testing.T) {
 	mockDB := new(MockDB)
@@ -534,8 +577,13 @@ func TestCreateNotificationWithAggregationExisting(t *testing.T) {
 
 	mockDB.On("GetUnreadNotificationByTypeAndEntity", ctx, mock.AnythingOfType("sqlc.GetUnreadNotificationByTypeAndEntityParams")).Return(existingNotification, nil)
 
-	// Then expect UpdateNotificationCount to be called to increment the count to 3
-	mockDB.On("UpdateNotificationCount", ctx, mock.AnythingOfType("sqlc.UpdateNotificationCountParams")).Return(nil)
+	// Then expect UpdateNotificationCount to be called to increment the count to 3 and record the liker
+	mockDB.On("UpdateNotificationCount", ctx, sqlc.UpdateNotificationCountParams{
+		Count:   pgtype.Int4{Int32: 3, Valid: true},
+		ActorID: pgtype.Int8{Int64: 2, Valid: true},
+		ID:      10,
+		UserID:  userID,
+	}).Return(nil)
 
 	// Finally expect GetNotificationByID to fetch the updated notification
 	updatedNotification := sqlc.Notification{
@@ -0,0 +1,297 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	db "social-network/services/notifications/internal/db/sqlc"
+	tele "social-network/shared/go/telemetry"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// MuteTarget is what a mute silences: every notification about a group, a conversation or from a user.
+type MuteTarget string
+
+const (
+	MuteGroup        MuteTarget = "group"
+	MuteConversation MuteTarget = "conversation"
+	MuteUser         MuteTarget = "user"
+)
+
+func (t MuteTarget) Valid() bool {
+	switch t {
+	case MuteGroup, MuteConversation, MuteUser:
+		return true
+	}
+	return false
+}
+
+// preference scopes, see migration 0006
+const (
+	scopeType     = "type"
+	scopeCategory = "category"
+)
+
+var ErrInvalidPreference = errors.New("invalid notification preference")
+
+// NotificationPreferences are the effective settings of a user.
+type NotificationPreferences struct {
+	UserID     int64
+	Types      map[string]bool // every notification type, with category settings and defaults applied
+	Categories map[string]bool // only the categories the user has set
+	Mutes      []Mute          // active mutes
+	EmailMode  EmailMode
+}
+
+type Mute struct {
+	TargetType MuteTarget
+	TargetID   int64
+	MutedUntil *time.Time // nil until unmuted
+}
+
+// actorPayloadKey names the payload entry holding the user a notification of each type is about, for user mutes
+var actorPayloadKey = map[NotificationType]string{
+	FollowRequest:            "requester_id",
+	NewFollower:              "follower_id",
+	GroupInvite:              "inviter_id",
+	GroupJoinRequest:         "requester_id",
+	PostLike:                 "liker_id",
+	PostComment:              "commenter_id",
+	Mention:                  "mentioner_id",
+	NewMessage:               "sender_id",
+	FollowRequestAccepted:    "target_id",
+	FollowRequestRejected:    "target_id",
+	GroupInviteAccepted:      "invited_id",
+	GroupInviteRejected:      "invited_id",
+	GroupJoinRequestAccepted: "group_owner_id",
+	GroupJoinRequestRejected: "group_owner_id",
+	PostShared:               "sharer_id",
+}
+
+// payloadId reads an id from a raw (not yet encoded) payload
+func payloadId(payload map[string]string, key string) pgtype.Int8 {
+	v, ok := payload[key]
+	if !ok || key == "" {
+		return pgtype.Int8{}
+	}
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return pgtype.Int8{}
+	}
+	return pgtype.Int8{Int64: id, Valid: true}
+}
+
+// actorIds lists the user a new notification is about, empty for types without actor
+func actorIds(notifType NotificationType, payload map[string]string) []int64 {
+	id := payloadId(payload, actorPayloadKey[notifType])
+	if !id.Valid {
+		return []int64{}
+	}
+	return []int64{id.Int64}
+}
+
+// notificationEnabled reports whether userID wants notifications of notifType with this payload,
+// given their type and category settings and their mutes.
+// Fails open, a notification is rather sent twice than lost because preferences could not be read.
+func (a *Application) notificationEnabled(ctx context.Context, userID int64, notifType NotificationType, payload map[string]string) bool {
+	if a.Preferences == nil {
+		return true
+	}
+
+	enabled, err := a.Preferences.IsNotificationEnabled(ctx, db.IsNotificationEnabledParams{
+		UserID:         userID,
+		NotifType:      string(notifType),
+		GroupID:        payloadId(payload, "group_id"),
+		ConversationID: payloadId(payload, "chat_id"),
+		ActorID:        payloadId(payload, actorPayloadKey[notifType]),
+	})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			tele.Warn(ctx, "failed to read notification preferences of user @1: @2", "userId", userID, "error", err.Error())
+		}
+		return true
+	}
+	return enabled
+}
+
+// GetNotificationPreferences returns the effective notification settings of a user.
+func (a *Application) GetNotificationPreferences(ctx context.Context, userID int64) (*NotificationPreferences, error) {
+	if a.Preferences == nil {
+		return nil, fmt.Errorf("notification preferences are not available")
+	}
+
+	types, err := a.Preferences.ListNotificationTypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification types: %w", err)
+	}
+
+	rows, err := a.Preferences.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	typeSettings := make(map[string]bool)
+	categorySettings := make(map[string]bool)
+	for _, r := range rows {
+		switch r.Scope {
+		case scopeType:
+			typeSettings[r.Name] = r.Enabled
+		case scopeCategory:
+			categorySettings[r.Name] = r.Enabled
+		}
+	}
+
+	prefs := &NotificationPreferences{
+		UserID:     userID,
+		Types:      make(map[string]bool, len(types)),
+		Categories: categorySettings,
+	}
+	for _, t := range types {
+		enabled := !t.DefaultEnabled.Valid || t.DefaultEnabled.Bool
+		if v, ok := categorySettings[t.Category.String]; ok && t.Category.Valid {
+			enabled = v
+		}
+		if v, ok := typeSettings[t.NotifType]; ok {
+			enabled = v
+		}
+		prefs.Types[t.NotifType] = enabled
+	}
+
+	mutes, err := a.Preferences.GetActiveNotificationMutes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification mutes: %w", err)
+	}
+	prefs.Mutes = make([]Mute, 0, len(mutes))
+	for _, m := range mutes {
+		mute := Mute{
+			TargetType: MuteTarget(m.TargetType),
+			TargetID:   m.TargetID,
+		}
+		if m.MutedUntil.Valid {
+			mute.MutedUntil = &m.MutedUntil.Time
+		}
+		prefs.Mutes = append(prefs.Mutes, mute)
+	}
+
+	prefs.EmailMode, err = a.emailMode(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return prefs, nil
+}
+
+// UpdateNotificationPreferences stores type and category settings and the email mode of a user.
+// Settings not mentioned, or an empty email mode, are left as they are.
+func (a *Application) UpdateNotificationPreferences(ctx context.Context, userID int64, types, categories map[string]bool, emailMode EmailMode) error {
+	if a.Preferences == nil {
+		return fmt.Errorf("notification preferences are not available")
+	}
+
+	known, err := a.Preferences.ListNotificationTypes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list notification types: %w", err)
+	}
+	knownTypes := make(map[string]struct{}, len(known))
+	knownCategories := make(map[string]struct{})
+	for _, t := range known {
+		knownTypes[t.NotifType] = struct{}{}
+		if t.Category.Valid {
+			knownCategories[t.Category.String] = struct{}{}
+		}
+	}
+	for name := range types {
+		if _, ok := knownTypes[name]; !ok {
+			return fmt.Errorf("%w: unknown notification type %q", ErrInvalidPreference, name)
+		}
+	}
+	for name := range categories {
+		if _, ok := knownCategories[name]; !ok {
+			return fmt.Errorf("%w: unknown notification category %q", ErrInvalidPreference, name)
+		}
+	}
+	if emailMode != "" && !emailMode.Valid() {
+		return fmt.Errorf("%w: unknown email mode %q", ErrInvalidPreference, emailMode)
+	}
+	if emailMode != "" && a.Email == nil {
+		return fmt.Errorf("email notifications are not available")
+	}
+
+	for name, enabled := range categories {
+		if err := a.Preferences.UpsertNotificationPreference(ctx, db.UpsertNotificationPreferenceParams{
+			UserID:  userID,
+			Scope:   scopeCategory,
+			Name:    name,
+			Enabled: enabled,
+		}); err != nil {
+			return fmt.Errorf("failed to update notification preference: %w", err)
+		}
+	}
+	for name, enabled := range types {
+		if err := a.Preferences.UpsertNotificationPreference(ctx, db.UpsertNotificationPreferenceParams{
+			UserID:  userID,
+			Scope:   scopeType,
+			Name:    name,
+			Enabled: enabled,
+		}); err != nil {
+			return fmt.Errorf("failed to update notification preference: %w", err)
+		}
+	}
+	if emailMode != "" {
+		return a.setEmailMode(ctx, userID, emailMode)
+	}
+	return nil
+}
+
+// MuteNotifications silences notifications about a group, conversation or user until mutedUntil, or until unmuted if nil.
+func (a *Application) MuteNotifications(ctx context.Context, userID int64, target MuteTarget, targetID int64, mutedUntil *time.Time) error {
+	if a.Preferences == nil {
+		return fmt.Errorf("notification preferences are not available")
+	}
+	if !target.Valid() || targetID <= 0 {
+		return fmt.Errorf("%w: bad mute target %q %d", ErrInvalidPreference, target, targetID)
+	}
+
+	var until pgtype.Timestamptz
+	if mutedUntil != nil {
+		if !mutedUntil.After(time.Now()) {
+			return fmt.Errorf("%w: muted_until is in the past", ErrInvalidPreference)
+		}
+		until = pgtype.Timestamptz{Time: *mutedUntil, Valid: true}
+	}
+
+	err := a.Preferences.UpsertNotificationMute(ctx, db.UpsertNotificationMuteParams{
+		UserID:     userID,
+		TargetType: string(target),
+		TargetID:   targetID,
+		MutedUntil: until,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mute notifications: %w", err)
+	}
+	return nil
+}
+
+// UnmuteNotifications removes a mute. Removing a mute that does not exist is not an error.
+func (a *Application) UnmuteNotifications(ctx context.Context, userID int64, target MuteTarget, targetID int64) error {
+	if a.Preferences == nil {
+		return fmt.Errorf("notification preferences are not available")
+	}
+	if !target.Valid() {
+		return fmt.Errorf("%w: bad mute target %q", ErrInvalidPreference, target)
+	}
+
+	err := a.Preferences.DeleteNotificationMute(ctx, db.DeleteNotificationMuteParams{
+		UserID:     userID,
+		TargetType: string(target),
+		TargetID:   targetID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unmute notifications: %w", err)
+	}
+	return nil
+}
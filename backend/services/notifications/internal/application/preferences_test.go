@@ -0,0 +1,195 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"social-network/services/notifications/internal/db/sqlc"
+	ct "social-network/shared/go/ct"
+)
+
+// Test that a disabled notification type is neither stored nor published
+func TestCreateNotification_DisabledByPreferences(t *testing.T) {
+	mockDB := new(MockDB)
+	mockPrefs := new(MockPreferencesDB)
+	app := NewApplicationWithMocks(mockDB)
+	app.Preferences = mockPrefs
+
+	ctx := context.Background()
+	payload := map[string]string{"liker_id": "7", "post_id": "3"}
+
+	mockPrefs.On("IsNotificationEnabled", ctx, sqlc.IsNotificationEnabledParams{
+		UserID:    1,
+		NotifType: string(PostLike),
+		ActorID:   pgtype.Int8{Int64: 7, Valid: true},
+	}).Return(false, nil)
+
+	notification, err := app.CreateNotificationWithAggregation(ctx, 1, PostLike, "Post Liked", "liked", "posts", 3, false, payload, true)
+
+	assert.NoError(t, err)
+	assert.Nil(t, notification)
+	mockPrefs.AssertExpectations(t)
+	mockDB.AssertNotCalled(t, "CreateNotification", mock.Anything, mock.Anything)
+	mockDB.AssertNotCalled(t, "GetUnreadNotificationByTypeAndEntity", mock.Anything, mock.Anything)
+}
+
+// Test that group and conversation ids of the payload are checked against mutes
+func TestCreateNotification_MuteTargetsFromPayload(t *testing.T) {
+	mockDB := new(MockDB)
+	mockPrefs := new(MockPreferencesDB)
+	app := NewApplicationWithMocks(mockDB)
+	app.Preferences = mockPrefs
+
+	ctx := context.Background()
+	payload := map[string]string{"sender_id": "4", "chat_id": "9", "group_id": "12"}
+
+	mockPrefs.On("IsNotificationEnabled", ctx, sqlc.IsNotificationEnabledParams{
+		UserID:         1,
+		NotifType:      string(NewMessage),
+		GroupID:        pgtype.Int8{Int64: 12, Valid: true},
+		ConversationID: pgtype.Int8{Int64: 9, Valid: true},
+		ActorID:        pgtype.Int8{Int64: 4, Valid: true},
+	}).Return(false, nil)
+
+	notification, err := app.CreateNotification(ctx, 1, NewMessage, "New Message", "hi", "chat", 9, false, payload)
+
+	assert.NoError(t, err)
+	assert.Nil(t, notification)
+	mockPrefs.AssertExpectations(t)
+}
+
+// Test that suppressed notifications are left out of a batch
+func TestCreateNotifications_SkipsSuppressed(t *testing.T) {
+	mockDB := new(MockDB)
+	mockPrefs := new(MockPreferencesDB)
+	app := NewApplicationWithMocks(mockDB)
+	app.Preferences = mockPrefs
+
+	ctx := context.Background()
+
+	mockPrefs.On("IsNotificationEnabled", ctx, mock.MatchedBy(func(p sqlc.IsNotificationEnabledParams) bool {
+		return p.UserID == 1
+	})).Return(false, nil)
+	mockPrefs.On("IsNotificationEnabled", ctx, mock.MatchedBy(func(p sqlc.IsNotificationEnabledParams) bool {
+		return p.UserID == 2
+	})).Return(true, nil)
+	mockDB.On("CreateNotification", ctx, mock.MatchedBy(func(p sqlc.CreateNotificationParams) bool {
+		return p.UserID == 2
+	})).Return(sqlc.Notification{
+		ID:        5,
+		UserID:    2,
+		NotifType: string(NewEvent),
+		Payload:   []byte(`{"event_id":"8"}`),
+	}, nil)
+
+	type batchNotification = struct {
+		UserID         int64
+		Type           NotificationType
+		Title          string
+		Message        string
+		SourceService  string
+		SourceEntityID int64
+		NeedsAction    bool
+		Payload        map[string]string
+	}
+	notifications, err := app.CreateNotifications(ctx, []batchNotification{
+		{UserID: 1, Type: NewEvent, Title: "New Event", Message: "event", SourceService: "posts", SourceEntityID: 8, Payload: map[string]string{"event_id": "8"}},
+		{UserID: 2, Type: NewEvent, Title: "New Event", Message: "event", SourceService: "posts", SourceEntityID: 8, Payload: map[string]string{"event_id": "8"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, notifications, 1)
+	assert.Equal(t, ct.Id(2), notifications[0].UserID)
+	mockPrefs.AssertExpectations(t)
+	mockDB.AssertExpectations(t)
+}
+
+// Test that notifications are still sent when preferences can't be read
+func TestCreateNotification_PreferencesErrorFailsOpen(t *testing.T) {
+	mockDB := new(MockDB)
+	mockPrefs := new(MockPreferencesDB)
+	app := NewApplicationWithMocks(mockDB)
+	app.Preferences = mockPrefs
+
+	ctx := context.Background()
+	payload := map[string]string{"follower_id": "2"}
+
+	mockPrefs.On("IsNotificationEnabled", ctx, mock.AnythingOfType("sqlc.IsNotificationEnabledParams")).Return(false, errors.New("connection refused"))
+	mockDB.On("CreateNotification", ctx, mock.AnythingOfType("sqlc.CreateNotificationParams")).Return(sqlc.Notification{
+		ID:        1,
+		UserID:    1,
+		NotifType: string(NewFollower),
+		Payload:   []byte(`{"follower_id":"2"}`),
+	}, nil)
+
+	notification, err := app.CreateNotification(ctx, 1, NewFollower, "New Follower", "followed you", "users", 2, false, payload)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, notification)
+	mockDB.AssertExpectations(t)
+}
+
+// Test that type settings override category settings, which override defaults
+func TestGetNotificationPreferences(t *testing.T) {
+	mockPrefs := new(MockPreferencesDB)
+	app := NewApplicationWithMocks(new(MockDB))
+	app.Preferences = mockPrefs
+
+	ctx := context.Background()
+	userID := int64(1)
+
+	mockPrefs.On("ListNotificationTypes", ctx).Return([]sqlc.NotificationType{
+		{NotifType: "like", Category: pgtype.Text{String: "posts", Valid: true}, DefaultEnabled: pgtype.Bool{Bool: true, Valid: true}},
+		{NotifType: "post_reply", Category: pgtype.Text{String: "posts", Valid: true}, DefaultEnabled: pgtype.Bool{Bool: true, Valid: true}},
+		{NotifType: "new_event", Category: pgtype.Text{String: "groups", Valid: true}, DefaultEnabled: pgtype.Bool{Bool: false, Valid: true}},
+	}, nil)
+	mockPrefs.On("GetNotificationPreferences", ctx, userID).Return([]sqlc.NotificationPreference{
+		{UserID: userID, Scope: "category", Name: "posts", Enabled: false},
+		{UserID: userID, Scope: "type", Name: "post_reply", Enabled: true},
+	}, nil)
+	mockPrefs.On("GetActiveNotificationMutes", ctx, userID).Return([]sqlc.NotificationMute{
+		{UserID: userID, TargetType: "group", TargetID: 5},
+	}, nil)
+
+	prefs, err := app.GetNotificationPreferences(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"like": false, "post_reply": true, "new_event": false}, prefs.Types)
+	assert.Equal(t, map[string]bool{"posts": false}, prefs.Categories)
+	assert.Equal(t, []Mute{{TargetType: MuteGroup, TargetID: 5}}, prefs.Mutes)
+}
+
+// Test that unknown types are rejected before anything is stored
+func TestUpdateNotificationPreferences_UnknownType(t *testing.T) {
+	mockPrefs := new(MockPreferencesDB)
+	app := NewApplicationWithMocks(new(MockDB))
+	app.Preferences = mockPrefs
+
+	ctx := context.Background()
+
+	mockPrefs.On("ListNotificationTypes", ctx).Return([]sqlc.NotificationType{
+		{NotifType: "like", Category: pgtype.Text{String: "posts", Valid: true}},
+	}, nil)
+
+	err := app.UpdateNotificationPreferences(ctx, 1, map[string]bool{"like": false, "nope": false}, nil, "")
+
+	assert.ErrorIs(t, err, ErrInvalidPreference)
+	mockPrefs.AssertNotCalled(t, "UpsertNotificationPreference", mock.Anything, mock.Anything)
+}
+
+// Test that mutes need a known target
+func TestMuteNotifications_InvalidTarget(t *testing.T) {
+	mockPrefs := new(MockPreferencesDB)
+	app := NewApplicationWithMocks(new(MockDB))
+	app.Preferences = mockPrefs
+
+	err := app.MuteNotifications(context.Background(), 1, MuteTarget("post"), 3, nil)
+
+	assert.ErrorIs(t, err, ErrInvalidPreference)
+	mockPrefs.AssertNotCalled(t, "UpsertNotificationMute", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,196 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "social-network/services/notifications/internal/db/sqlc"
+	"social-network/services/notifications/internal/webpush"
+	tele "social-network/shared/go/telemetry"
+)
+
+const (
+	maxPushSubscriptions = 10 // browsers pushed to per user, the most recently subscribed
+	maxUserAgentLength   = 255
+	pushSendTimeout      = 30 * time.Second
+)
+
+var (
+	ErrInvalidPushSubscription = errors.New("invalid push subscription")
+	ErrPushUnavailable         = errors.New("push notifications are not available")
+)
+
+// PushDB represents the database operations for Web Push subscriptions
+type PushDB interface {
+	UpsertPushSubscription(ctx context.Context, arg db.UpsertPushSubscriptionParams) error
+	DeletePushSubscription(ctx context.Context, arg db.DeletePushSubscriptionParams) error
+	DeletePushSubscriptionByEndpoint(ctx context.Context, endpoint string) error
+	GetUserPushSubscriptions(ctx context.Context, arg db.GetUserPushSubscriptionsParams) ([]db.PushSubscription, error)
+}
+
+// Presence tells whether a user has a live connection open
+type Presence interface {
+	IsOnline(ctx context.Context, userID int64) (bool, error)
+}
+
+// PushChannel delivers notifications as Web Push messages to users that are not connected.
+// Connected users already get them over the live service.
+type PushChannel struct {
+	db       PushDB
+	presence Presence // nil pushes to every user
+	client   *webpush.Client
+}
+
+func NewPushChannel(pushDB PushDB, presence Presence, client *webpush.Client) *PushChannel {
+	return &PushChannel{db: pushDB, presence: presence, client: client}
+}
+
+// PushSubscription is a browser subscribed to push notifications, as given by PushSubscription.toJSON()
+type PushSubscription struct {
+	Endpoint  string
+	P256dh    string
+	Auth      string
+	UserAgent string
+}
+
+// PushPublicKey returns the VAPID public key browsers subscribe with
+func (a *Application) PushPublicKey() (string, error) {
+	if a.Push == nil {
+		return "", ErrPushUnavailable
+	}
+	return a.Push.client.PublicKey(), nil
+}
+
+// RegisterPushSubscription stores a browser subscription for a user.
+// A browser subscribing again, maybe for another user, replaces its previous subscription.
+func (a *Application) RegisterPushSubscription(ctx context.Context, userID int64, sub PushSubscription) error {
+	if a.Push == nil {
+		return ErrPushUnavailable
+	}
+	if err := (webpush.Subscription{Endpoint: sub.Endpoint, P256dh: sub.P256dh, Auth: sub.Auth}).Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPushSubscription, err)
+	}
+	userAgent := sub.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	if err := a.Push.db.UpsertPushSubscription(ctx, db.UpsertPushSubscriptionParams{
+		Endpoint:  sub.Endpoint,
+		UserID:    userID,
+		P256dh:    sub.P256dh,
+		Auth:      sub.Auth,
+		UserAgent: userAgent,
+	}); err != nil {
+		return fmt.Errorf("failed to save push subscription: %w", err)
+	}
+	return nil
+}
+
+// UnregisterPushSubscription removes a browser subscription of a user
+func (a *Application) UnregisterPushSubscription(ctx context.Context, userID int64, endpoint string) error {
+	if a.Push == nil {
+		return ErrPushUnavailable
+	}
+	if err := a.Push.db.DeletePushSubscription(ctx, db.DeletePushSubscriptionParams{
+		UserID:   userID,
+		Endpoint: endpoint,
+	}); err != nil {
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+	return nil
+}
+
+// sendPushAsync pushes a new notification to the browsers of its user, if they are not connected.
+// Failures are logged, the notification itself is already stored.
+func (a *Application) sendPushAsync(notification *Notification) {
+	if a.Push == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), pushSendTimeout)
+		defer cancel()
+		if err := a.sendPush(ctx, notification); err != nil {
+			tele.Warn(ctx, "failed to push notification to user @1: @2", "userId", notification.UserID, "error", err.Error())
+		}
+	}()
+}
+
+func (a *Application) sendPush(ctx context.Context, notification *Notification) error {
+	userID := notification.UserID.Int64()
+
+	if a.Push.presence != nil {
+		online, err := a.Push.presence.IsOnline(ctx, userID)
+		if err != nil {
+			// better a push the user may also see live than none
+			tele.Warn(ctx, "failed to get presence of user @1, pushing anyway: @2", "userId", userID, "error", err.Error())
+		}
+		if online {
+			return nil
+		}
+	}
+
+	subs, err := a.Push.db.GetUserPushSubscriptions(ctx, db.GetUserPushSubscriptionsParams{
+		UserID: userID,
+		Limit:  maxPushSubscriptions,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get push subscriptions: %w", err)
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	payload, err := pushPayload(notification)
+	if err != nil {
+		return err
+	}
+	urgency := webpush.UrgencyNormal
+	if notification.NeedsAction || notification.Type == NewMessage {
+		urgency = webpush.UrgencyHigh
+	}
+
+	var errs []error
+	for _, sub := range subs {
+		err := a.Push.client.Send(ctx, webpush.Subscription{
+			Endpoint: sub.Endpoint,
+			P256dh:   sub.P256dh,
+			Auth:     sub.Auth,
+		}, payload, urgency)
+		if errors.Is(err, webpush.ErrSubscriptionGone) || errors.Is(err, webpush.ErrBadSubscription) {
+			if err := a.Push.db.DeletePushSubscriptionByEndpoint(ctx, sub.Endpoint); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete dead push subscription: %w", err))
+			}
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// pushPayload is the notification as the live service sends it, without its payload if that does not fit a push
+func pushPayload(notification *Notification) ([]byte, error) {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal push payload: %w", err)
+	}
+	if len(payload) <= webpush.MaxPayload {
+		return payload, nil
+	}
+
+	trimmed := *notification
+	trimmed.Payload = nil
+	payload, err = json.Marshal(&trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal push payload: %w", err)
+	}
+	if len(payload) > webpush.MaxPayload {
+		return nil, fmt.Errorf("push payload of %d bytes is too large", len(payload))
+	}
+	return payload, nil
+}
@@ -0,0 +1,128 @@
+package application
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"social-network/services/notifications/internal/db/sqlc"
+	"social-network/services/notifications/internal/webpush"
+	"social-network/shared/go/ct"
+)
+
+// newPushTestApp returns an application pushing to server
+func newPushTestApp(t *testing.T, server *httptest.Server, presence Presence) (*Application, *MockPushDB) {
+	private, _, err := webpush.GenerateVAPIDKeys()
+	require.NoError(t, err)
+	keys, err := webpush.ParseVAPIDKeys(private)
+	require.NoError(t, err)
+
+	mockPushDB := new(MockPushDB)
+	app := NewApplicationWithMocks(new(MockDB))
+	app.Push = NewPushChannel(mockPushDB, presence, webpush.NewClient(keys, "mailto:ops@test.local", time.Hour, server.Client()))
+	return app, mockPushDB
+}
+
+// testSubscription makes the keys of a browser subscribing at endpoint
+func testSubscription(t *testing.T, endpoint string) sqlc.PushSubscription {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	auth := make([]byte, 16)
+	_, err = rand.Read(auth)
+	require.NoError(t, err)
+	return sqlc.PushSubscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(auth),
+	}
+}
+
+// Test that offline users are pushed to, and subscriptions the push service reports gone are deleted
+func TestSendPushToOfflineUser(t *testing.T) {
+	var pushed atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		pushed.Add(1)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	app, mockPushDB := newPushTestApp(t, server, fakePresence{})
+	ctx := context.Background()
+
+	mockPushDB.On("GetUserPushSubscriptions", ctx, sqlc.GetUserPushSubscriptionsParams{UserID: 3, Limit: maxPushSubscriptions}).
+		Return([]sqlc.PushSubscription{testSubscription(t, server.URL+"/live"), testSubscription(t, server.URL+"/gone")}, nil)
+	mockPushDB.On("DeletePushSubscriptionByEndpoint", ctx, server.URL+"/gone").Return(nil)
+
+	err := app.sendPush(ctx, &Notification{ID: 1, UserID: 3, Type: PostLike, Title: "Post Liked", Payload: map[string]string{"liker_name": "alice"}})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), pushed.Load())
+	mockPushDB.AssertExpectations(t)
+}
+
+// Test that users with a live connection are not pushed to
+func TestSendPushSkipsOnlineUser(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("online user was pushed to")
+	}))
+	defer server.Close()
+
+	app, mockPushDB := newPushTestApp(t, server, fakePresence{3: true})
+
+	err := app.sendPush(context.Background(), &Notification{ID: 1, UserID: 3, Type: PostLike})
+	require.NoError(t, err)
+	mockPushDB.AssertNotCalled(t, "GetUserPushSubscriptions", mock.Anything, mock.Anything)
+}
+
+func TestRegisterPushSubscription(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	app, mockPushDB := newPushTestApp(t, server, nil)
+	ctx := context.Background()
+
+	sub := testSubscription(t, "https://push.example.com/abc")
+	mockPushDB.On("UpsertPushSubscription", ctx, sqlc.UpsertPushSubscriptionParams{
+		Endpoint:  sub.Endpoint,
+		UserID:    4,
+		P256dh:    sub.P256dh,
+		Auth:      sub.Auth,
+		UserAgent: "Firefox",
+	}).Return(nil)
+
+	err := app.RegisterPushSubscription(ctx, 4, PushSubscription{Endpoint: sub.Endpoint, P256dh: sub.P256dh, Auth: sub.Auth, UserAgent: "Firefox"})
+	assert.NoError(t, err)
+
+	err = app.RegisterPushSubscription(ctx, 4, PushSubscription{Endpoint: "http://push.example.com/abc", P256dh: sub.P256dh, Auth: sub.Auth})
+	assert.ErrorIs(t, err, ErrInvalidPushSubscription)
+	mockPushDB.AssertNumberOfCalls(t, "UpsertPushSubscription", 1)
+
+	app.Push = nil
+	err = app.RegisterPushSubscription(ctx, 4, PushSubscription{Endpoint: sub.Endpoint, P256dh: sub.P256dh, Auth: sub.Auth})
+	assert.ErrorIs(t, err, ErrPushUnavailable)
+}
+
+// Test that payloads too large for a push are sent without their payload map
+func TestPushPayloadTrimsLargePayload(t *testing.T) {
+	big := make([]byte, webpush.MaxPayload)
+	for i := range big {
+		big[i] = 'a'
+	}
+	payload, err := pushPayload(&Notification{ID: ct.Id(1), UserID: 3, Type: PostComment, Title: "New Comment", Payload: map[string]string{"post_content": string(big)}})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(payload), webpush.MaxPayload)
+	assert.Contains(t, string(payload), "New Comment")
+	assert.NotContains(t, string(payload), "aaaa")
+}
@@ -0,0 +1,155 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "social-network/services/notifications/internal/db/sqlc"
+	tele "social-network/shared/go/telemetry"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// defaultRetention is how long notifications live when neither their type nor the config says otherwise
+const defaultRetention = 30 * 24 * time.Hour
+
+// RetentionDB represents the database operations for purging old notifications
+type RetentionDB interface {
+	PurgeExpiredNotifications(ctx context.Context, limit int32) (int64, error)
+	PurgeDeletedNotifications(ctx context.Context, arg db.PurgeDeletedNotificationsParams) (int64, error)
+	SetNotificationTypeRetention(ctx context.Context, arg db.SetNotificationTypeRetentionParams) (int64, error)
+}
+
+type RetentionConfig struct {
+	DefaultRetention time.Duration // expiry of types without their own retention_days, 30 days if zero
+	DeletedGrace     time.Duration // how long soft deleted notifications are kept, 24 hours if zero
+	Interval         time.Duration // time between purges, 1 hour if zero
+	BatchSize        int32         // rows deleted per statement, 1000 if zero
+
+	// TypeRetention overrides the retention of notification types, in days. Types not in it keep their retention_days
+	TypeRetention map[NotificationType]int
+}
+
+// Retention hard deletes expired and soft deleted notifications.
+// Expired notifications that still need an action, like pending follow requests and invites, are kept until acted on.
+type Retention struct {
+	db  RetentionDB
+	cfg RetentionConfig
+}
+
+func NewRetention(retentionDB RetentionDB, cfg RetentionConfig) *Retention {
+	if cfg.DefaultRetention <= 0 {
+		cfg.DefaultRetention = defaultRetention
+	}
+	if cfg.DeletedGrace <= 0 {
+		cfg.DeletedGrace = 24 * time.Hour
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Hour
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 1000
+	}
+	return &Retention{db: retentionDB, cfg: cfg}
+}
+
+// ApplyTypeRetention stores the configured retention of each notification type, used for new notifications
+func (a *Application) ApplyTypeRetention(ctx context.Context) error {
+	if a.Retention == nil {
+		return nil
+	}
+	for notifType, days := range a.Retention.cfg.TypeRetention {
+		if days <= 0 {
+			return fmt.Errorf("retention of %s must be positive, got %d days", notifType, days)
+		}
+		n, err := a.Retention.db.SetNotificationTypeRetention(ctx, db.SetNotificationTypeRetentionParams{
+			NotifType:     string(notifType),
+			RetentionDays: pgtype.Int4{Int32: int32(days), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set retention of %s: %w", notifType, err)
+		}
+		if n == 0 {
+			return fmt.Errorf("unknown notification type %q in retention config", notifType)
+		}
+	}
+	return nil
+}
+
+// expiresAt is when a notification created at now expires, unless its type has its own retention
+func (a *Application) expiresAt(now time.Time) time.Time {
+	if a.Retention == nil {
+		return now.Add(defaultRetention)
+	}
+	return now.Add(a.Retention.cfg.DefaultRetention)
+}
+
+// RunRetention purges old notifications every interval until ctx is done
+func (a *Application) RunRetention(ctx context.Context) {
+	if a.Retention == nil {
+		return
+	}
+	ticker := time.NewTicker(a.Retention.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		expired, deleted, err := a.PurgeNotifications(ctx, time.Now())
+		if err != nil {
+			tele.Error(ctx, "failed to purge notifications: @1", "error", err.Error())
+		}
+		if expired+deleted > 0 {
+			tele.Info(ctx, "purged @1 expired and @2 deleted notifications", "expired", expired, "deleted", deleted)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeNotifications hard deletes, in batches, the expired notifications nobody has to act on
+// and the notifications soft deleted a grace period before now. Safe to run on several replicas at once.
+func (a *Application) PurgeNotifications(ctx context.Context, now time.Time) (expired, deleted int64, err error) {
+	if a.Retention == nil {
+		return 0, 0, nil
+	}
+	batch := a.Retention.cfg.BatchSize
+
+	expired, err = purgeInBatches(ctx, batch, func() (int64, error) {
+		return a.Retention.db.PurgeExpiredNotifications(ctx, batch)
+	})
+	if err != nil {
+		return expired, 0, fmt.Errorf("failed to purge expired notifications: %w", err)
+	}
+
+	deletedBefore := pgtype.Timestamptz{Time: now.Add(-a.Retention.cfg.DeletedGrace), Valid: true}
+	deleted, err = purgeInBatches(ctx, batch, func() (int64, error) {
+		return a.Retention.db.PurgeDeletedNotifications(ctx, db.PurgeDeletedNotificationsParams{
+			DeletedAt: deletedBefore,
+			Limit:     batch,
+		})
+	})
+	if err != nil {
+		return expired, deleted, fmt.Errorf("failed to purge deleted notifications: %w", err)
+	}
+	return expired, deleted, nil
+}
+
+// purgeInBatches calls purge until it deletes less than a full batch, short statements keep locks short
+func purgeInBatches(ctx context.Context, batch int32, purge func() (int64, error)) (int64, error) {
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		n, err := purge()
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < int64(batch) {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"social-network/services/notifications/internal/db/sqlc"
+)
+
+// Test that purges repeat while batches come back full, and soft deleted rows get their grace period
+func TestPurgeNotifications(t *testing.T) {
+	mockRetentionDB := new(MockRetentionDB)
+	app := NewApplicationWithMocks(new(MockDB))
+	app.Retention = NewRetention(mockRetentionDB, RetentionConfig{BatchSize: 10, DeletedGrace: 2 * time.Hour})
+	ctx := context.Background()
+	now := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+
+	mockRetentionDB.On("PurgeExpiredNotifications", ctx, int32(10)).Return(int64(10), nil).Twice()
+	mockRetentionDB.On("PurgeExpiredNotifications", ctx, int32(10)).Return(int64(3), nil).Once()
+	mockRetentionDB.On("PurgeDeletedNotifications", ctx, sqlc.PurgeDeletedNotificationsParams{
+		DeletedAt: pgtype.Timestamptz{Time: now.Add(-2 * time.Hour), Valid: true},
+		Limit:     10,
+	}).Return(int64(4), nil).Once()
+
+	expired, deleted, err := app.PurgeNotifications(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(23), expired)
+	assert.Equal(t, int64(4), deleted)
+	mockRetentionDB.AssertExpectations(t)
+}
+
+func TestApplyTypeRetention(t *testing.T) {
+	mockRetentionDB := new(MockRetentionDB)
+	app := NewApplicationWithMocks(new(MockDB))
+	ctx := context.Background()
+
+	mockRetentionDB.On("SetNotificationTypeRetention", ctx, sqlc.SetNotificationTypeRetentionParams{
+		NotifType:     string(NewMessage),
+		RetentionDays: pgtype.Int4{Int32: 7, Valid: true},
+	}).Return(int64(1), nil)
+	mockRetentionDB.On("SetNotificationTypeRetention", ctx, sqlc.SetNotificationTypeRetentionParams{
+		NotifType:     "no_such_type",
+		RetentionDays: pgtype.Int4{Int32: 7, Valid: true},
+	}).Return(int64(0), nil)
+
+	app.Retention = NewRetention(mockRetentionDB, RetentionConfig{TypeRetention: map[NotificationType]int{NewMessage: 7}})
+	assert.NoError(t, app.ApplyTypeRetention(ctx))
+
+	app.Retention = NewRetention(mockRetentionDB, RetentionConfig{TypeRetention: map[NotificationType]int{"no_such_type": 7}})
+	assert.Error(t, app.ApplyTypeRetention(ctx))
+
+	app.Retention = NewRetention(mockRetentionDB, RetentionConfig{TypeRetention: map[NotificationType]int{NewMessage: 0}})
+	assert.Error(t, app.ApplyTypeRetention(ctx))
+	mockRetentionDB.AssertNumberOfCalls(t, "SetNotificationTypeRetention", 2)
+}
+
+func TestExpiresAt(t *testing.T) {
+	app := NewApplicationWithMocks(new(MockDB))
+	now := time.Now()
+	assert.Equal(t, now.Add(30*24*time.Hour), app.expiresAt(now))
+
+	app.Retention = NewRetention(new(MockRetentionDB), RetentionConfig{DefaultRetention: 5 * 24 * time.Hour})
+	assert.Equal(t, now.Add(5*24*time.Hour), app.expiresAt(now))
+}
@@ -2,8 +2,11 @@ package application
 
 import (
 	"context"
+	"fmt"
 
 	"social-network/services/notifications/internal/db/sqlc"
+	ct "social-network/shared/go/ct"
+	"social-network/shared/go/models"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -28,6 +31,11 @@ func (m *MockDB) GetUserNotifications(ctx context.Context, arg sqlc.GetUserNotif
 	return args.Get(0).([]sqlc.Notification), args.Error(1)
 }
 
+func (m *MockDB) GetUserNotificationGroups(ctx context.Context, arg sqlc.GetUserNotificationGroupsParams) ([]sqlc.GetUserNotificationGroupsRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.GetUserNotificationGroupsRow), args.Error(1)
+}
+
 func (m *MockDB) GetUserNotificationsCount(ctx context.Context, userID int64) (int64, error) {
 	args := m.Called(ctx, userID)
 	return args.Get(0).(int64), args.Error(1)
@@ -48,6 +56,16 @@ func (m *MockDB) MarkAllAsRead(ctx context.Context, userID int64) error {
 	return args.Error(0)
 }
 
+func (m *MockDB) MarkReadByEntity(ctx context.Context, arg sqlc.MarkReadByEntityParams) ([]int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockDB) MarkReadByCategory(ctx context.Context, arg sqlc.MarkReadByCategoryParams) ([]int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]int64), args.Error(1)
+}
+
 func (m *MockDB) DeleteNotification(ctx context.Context, arg sqlc.DeleteNotificationParams) error {
 	args := m.Called(ctx, arg)
 	return args.Error(0)
@@ -83,6 +101,46 @@ func (m *MockDB) MarkNotificationAsActed(ctx context.Context, arg sqlc.MarkNotif
 	return args.Error(0)
 }
 
+// MockPreferencesDB is a mock implementation of the notification preferences queries
+type MockPreferencesDB struct {
+	mock.Mock
+}
+
+func (m *MockPreferencesDB) IsNotificationEnabled(ctx context.Context, arg sqlc.IsNotificationEnabledParams) (bool, error) {
+	args := m.Called(ctx, arg)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPreferencesDB) ListNotificationTypes(ctx context.Context) ([]sqlc.NotificationType, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]sqlc.NotificationType), args.Error(1)
+}
+
+func (m *MockPreferencesDB) GetNotificationPreferences(ctx context.Context, userID int64) ([]sqlc.NotificationPreference, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]sqlc.NotificationPreference), args.Error(1)
+}
+
+func (m *MockPreferencesDB) UpsertNotificationPreference(ctx context.Context, arg sqlc.UpsertNotificationPreferenceParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockPreferencesDB) UpsertNotificationMute(ctx context.Context, arg sqlc.UpsertNotificationMuteParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockPreferencesDB) DeleteNotificationMute(ctx context.Context, arg sqlc.DeleteNotificationMuteParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockPreferencesDB) GetActiveNotificationMutes(ctx context.Context, userID int64) ([]sqlc.NotificationMute, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]sqlc.NotificationMute), args.Error(1)
+}
+
 // NewApplicationWithMocks creates a new application service with mocked dependencies for testing
 func NewApplicationWithMocks(db DBInterface) *Application {
 	return &Application{
@@ -90,4 +148,105 @@ func NewApplicationWithMocks(db DBInterface) *Application {
 		Clients: nil, // nil for tests
 		NatsConn: nil, // nil for tests
 	}
-}
\ No newline at end of file
+}
+// MockEmailDB is a mock implementation of the email delivery queries
+type MockEmailDB struct {
+	mock.Mock
+}
+
+func (m *MockEmailDB) GetEmailSettings(ctx context.Context, userID int64) (sqlc.NotificationEmailSetting, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(sqlc.NotificationEmailSetting), args.Error(1)
+}
+
+func (m *MockEmailDB) UpsertEmailMode(ctx context.Context, arg sqlc.UpsertEmailModeParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockEmailDB) GetDueEmailDigests(ctx context.Context, arg sqlc.GetDueEmailDigestsParams) ([]sqlc.GetDueEmailDigestsRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.GetDueEmailDigestsRow), args.Error(1)
+}
+
+func (m *MockEmailDB) ClaimEmailDigest(ctx context.Context, arg sqlc.ClaimEmailDigestParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockEmailDB) GetEmailDigestNotifications(ctx context.Context, arg sqlc.GetEmailDigestNotificationsParams) ([]sqlc.Notification, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.Notification), args.Error(1)
+}
+
+// fakeEmailAddresses gives every user the address <id>@test.local
+type fakeEmailAddresses struct{}
+
+func (fakeEmailAddresses) GetUserEmail(ctx context.Context, userID int64) (string, error) {
+	return fmt.Sprintf("%d@test.local", userID), nil
+}
+
+// MockPushDB is a mock implementation of the push subscription queries
+type MockPushDB struct {
+	mock.Mock
+}
+
+func (m *MockPushDB) UpsertPushSubscription(ctx context.Context, arg sqlc.UpsertPushSubscriptionParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockPushDB) DeletePushSubscription(ctx context.Context, arg sqlc.DeletePushSubscriptionParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockPushDB) DeletePushSubscriptionByEndpoint(ctx context.Context, endpoint string) error {
+	args := m.Called(ctx, endpoint)
+	return args.Error(0)
+}
+
+func (m *MockPushDB) GetUserPushSubscriptions(ctx context.Context, arg sqlc.GetUserPushSubscriptionsParams) ([]sqlc.PushSubscription, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.PushSubscription), args.Error(1)
+}
+
+// fakePresence reports the users in the map as online
+type fakePresence map[int64]bool
+
+func (p fakePresence) IsOnline(ctx context.Context, userID int64) (bool, error) {
+	return p[userID], nil
+}
+
+// MockRetentionDB is a mock implementation of the retention queries
+type MockRetentionDB struct {
+	mock.Mock
+}
+
+func (m *MockRetentionDB) PurgeExpiredNotifications(ctx context.Context, limit int32) (int64, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRetentionDB) PurgeDeletedNotifications(ctx context.Context, arg sqlc.PurgeDeletedNotificationsParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRetentionDB) SetNotificationTypeRetention(ctx context.Context, arg sqlc.SetNotificationTypeRetentionParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// fakeUsers resolves the users in the map
+type fakeUsers map[ct.Id]models.User
+
+func (u fakeUsers) GetUsers(ctx context.Context, userIds ct.Ids) (map[ct.Id]models.User, error) {
+	users := make(map[ct.Id]models.User, len(userIds))
+	for _, id := range userIds {
+		if user, ok := u[id]; ok {
+			users[id] = user
+		}
+	}
+	return users, nil
+}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"context"
+
 	chatPb "social-network/shared/gen-go/chat"
 	postsPb "social-network/shared/gen-go/posts"
 	usersPb "social-network/shared/gen-go/users"
@@ -21,3 +23,15 @@ func NewClients(usersClient usersPb.UserServiceClient, chatClient chatPb.ChatSer
 		PostsClient: postsClient,
 	}
 }
+
+// GetUserEmail returns the email address of a user.
+func (c *Clients) GetUserEmail(ctx context.Context, userID int64) (string, error) {
+	profile, err := c.UsersClient.GetUserProfile(ctx, &usersPb.GetUserProfileRequest{
+		UserId:      userID,
+		RequesterId: userID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return profile.Email, nil
+}
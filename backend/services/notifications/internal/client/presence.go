@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"social-network/shared/go/ct"
+	redis_connector "social-network/shared/go/redis"
+)
+
+// Presence reads the live connections the live service keeps in redis
+type Presence struct {
+	Redis *redis_connector.RedisClient
+}
+
+// IsOnline reports whether a user has a live connection that has not expired
+func (p *Presence) IsOnline(ctx context.Context, userID int64) (bool, error) {
+	key, err := ct.PresenceKey{UserId: ct.Id(userID)}.GenKey()
+	if err != nil {
+		return false, err
+	}
+	n, err := p.Redis.ZCountAbove(ctx, key, float64(time.Now().Unix()))
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,6 @@
+-- Notification sent to the creator of a scheduled post when it gets published
+
+INSERT INTO notification_types (notif_type, category, default_enabled)
+VALUES
+  ('post_published', 'posts',  TRUE)
+ON CONFLICT (notif_type) DO NOTHING;
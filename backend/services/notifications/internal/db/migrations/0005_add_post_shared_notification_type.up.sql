@@ -0,0 +1,6 @@
+-- Notification sent to the creator of a post when someone shares it
+
+INSERT INTO notification_types (notif_type, category, default_enabled)
+VALUES
+  ('post_shared', 'posts',  TRUE)
+ON CONFLICT (notif_type) DO NOTHING;
@@ -0,0 +1,30 @@
+------------------------------------------
+-- Notification preferences
+------------------------------------------
+
+-- Per-user overrides of notification_types.default_enabled, either for one
+-- notification type (scope 'type') or for every type of a category (scope 'category').
+-- A type setting wins over the setting of its category.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id BIGINT NOT NULL,
+    scope TEXT NOT NULL CHECK (scope IN ('type', 'category')),
+    name TEXT NOT NULL,              -- notif_type or category
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, scope, name)
+);
+
+------------------------------------------
+-- Notification mutes
+------------------------------------------
+
+-- Silences every notification about a group, a conversation or from a user,
+-- whatever its type. A null muted_until mutes until the user unmutes.
+CREATE TABLE IF NOT EXISTS notification_mutes (
+    user_id BIGINT NOT NULL,
+    target_type TEXT NOT NULL CHECK (target_type IN ('group', 'conversation', 'user')),
+    target_id BIGINT NOT NULL,
+    muted_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, target_type, target_id)
+);
@@ -0,0 +1,13 @@
+------------------------------------------
+-- Email delivery
+------------------------------------------
+
+-- How a user gets notifications by email. Users without a row use the
+-- service default. last_digest_at is when the last digest was claimed,
+-- digests list the unread notifications created since.
+CREATE TABLE IF NOT EXISTS notification_email_settings (
+    user_id BIGINT PRIMARY KEY,
+    mode TEXT NOT NULL CHECK (mode IN ('off', 'immediate', 'daily', 'weekly')),
+    last_digest_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
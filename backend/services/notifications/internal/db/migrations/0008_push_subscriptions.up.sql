@@ -0,0 +1,17 @@
+------------------------------------------
+-- Web Push subscriptions
+------------------------------------------
+
+-- Browsers a user allowed to receive push notifications, as given by
+-- PushSubscription.toJSON(). An endpoint belongs to one browser profile,
+-- subscribing it again (maybe for another user) replaces the row.
+CREATE TABLE IF NOT EXISTS push_subscriptions (
+    endpoint TEXT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    p256dh TEXT NOT NULL,            -- browser public key, base64url
+    auth TEXT NOT NULL,              -- auth secret, base64url
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_push_subscriptions_user_id ON push_subscriptions(user_id);
@@ -0,0 +1,17 @@
+------------------------------------------
+-- Notification retention
+------------------------------------------
+
+-- How long notifications of a type live, in days. Null uses the service
+-- default (NOTIFICATION_RETENTION_DAYS). Sets expires_at of new notifications.
+ALTER TABLE notification_types
+    ADD COLUMN IF NOT EXISTS retention_days INT CHECK (retention_days > 0);
+
+-- the retention worker finds rows by expiry and by deletion time
+CREATE INDEX IF NOT EXISTS idx_notifications_expires
+ON notifications (expires_at)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_deleted
+ON notifications (deleted_at)
+WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,32 @@
+------------------------------------------
+-- Notification actors
+------------------------------------------
+
+-- Distinct users an aggregated notification is about, the payload only names the first.
+-- Groups count their actors from it.
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS actor_ids BIGINT[] NOT NULL DEFAULT '{}';
+
+-- Existing notifications keep the actor of their payload, users aggregated into them are lost
+UPDATE notifications n
+SET actor_ids = ARRAY[(n.payload->>k.actor_key)::bigint]
+FROM (VALUES
+    ('follow_request', 'requester_id'),
+    ('new_follower', 'follower_id'),
+    ('group_invite', 'inviter_id'),
+    ('group_join_request', 'requester_id'),
+    ('like', 'liker_id'),
+    ('post_reply', 'commenter_id'),
+    ('mention', 'mentioner_id'),
+    ('new_message', 'sender_id'),
+    ('follow_request_accepted', 'target_id'),
+    ('follow_request_rejected', 'target_id'),
+    ('group_invite_accepted', 'invited_id'),
+    ('group_invite_rejected', 'invited_id'),
+    ('group_join_request_accepted', 'group_owner_id'),
+    ('group_join_request_rejected', 'group_owner_id'),
+    ('post_shared', 'sharer_id')
+) AS k(notif_type, actor_key)
+WHERE n.notif_type = k.notif_type
+  AND cardinality(n.actor_ids) = 0
+  AND n.payload->>k.actor_key ~ '^[0-9]+$';
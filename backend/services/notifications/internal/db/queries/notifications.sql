@@ -9,33 +9,84 @@ INSERT INTO notifications (
     payload,
     created_at,
     expires_at,
-    count
+    count,
+    actor_ids
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, NOW(), $8, $9
+    $1, $2, $3, $4, $5, $6, $7, NOW(),
+    -- the type's retention if it has one, else the given default
+    COALESCE(NOW() + make_interval(days => (SELECT nt.retention_days FROM notification_types nt WHERE nt.notif_type = $2)), $8),
+    $9, $10
 ) RETURNING id, user_id, notif_type, source_service, source_entity_id, seen, needs_action, acted, payload, created_at, expires_at, deleted_at, count;
 
 -- name: GetNotificationByID :one
 SELECT id, user_id, notif_type, source_service, source_entity_id, seen, needs_action, acted, payload, created_at, expires_at, deleted_at, count
 FROM notifications
-WHERE id = $1 AND deleted_at IS NULL;
+WHERE id = $1 AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW() OR (needs_action IS TRUE AND acted IS NOT TRUE));
 
 -- name: GetUserNotifications :many
 SELECT id, user_id, notif_type, source_service, source_entity_id, seen, needs_action, acted, payload, created_at, expires_at, deleted_at, count
 FROM notifications
 WHERE user_id = $1
   AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW() OR (needs_action IS TRUE AND acted IS NOT TRUE))
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
+-- name: GetUserNotificationGroups :many
+-- likes and comments grouped by post and new followers grouped together, within time buckets of bucket_seconds,
+-- every other notification is a group of its own. Newest group first, keyset paginated on (latest_at, latest_id)
+WITH visible AS (
+    SELECT n.id, n.notif_type, n.source_service, n.source_entity_id, n.seen, n.needs_action, n.acted, n.payload, n.created_at, n.count, n.actor_ids,
+        CASE
+            WHEN n.notif_type IN ('like', 'post_reply') THEN n.notif_type || ':' || n.source_entity_id
+            WHEN n.notif_type = 'new_follower' THEN n.notif_type
+            ELSE 'id:' || n.id
+        END AS group_key,
+        FLOOR(EXTRACT(EPOCH FROM n.created_at) / sqlc.arg('bucket_seconds')::bigint)::bigint AS bucket
+    FROM notifications n
+    JOIN notification_types nt ON nt.notif_type = n.notif_type
+    WHERE n.user_id = sqlc.arg('user_id')
+      AND n.deleted_at IS NULL
+      AND (n.expires_at IS NULL OR n.expires_at > NOW() OR (n.needs_action IS TRUE AND n.acted IS NOT TRUE))
+      AND (cardinality(sqlc.arg('categories')::text[]) = 0 OR nt.category = ANY(sqlc.arg('categories')::text[]))
+      AND (NOT sqlc.arg('unread_only')::boolean OR n.seen IS NOT TRUE)
+)
+SELECT
+    (array_agg(notif_type ORDER BY created_at DESC, id DESC))[1]::text AS notif_type,
+    (array_agg(source_service ORDER BY created_at DESC, id DESC))[1]::text AS source_service,
+    COALESCE((array_agg(source_entity_id ORDER BY created_at DESC, id DESC))[1], 0)::bigint AS source_entity_id,
+    COALESCE((array_agg(needs_action ORDER BY created_at DESC, id DESC))[1], FALSE)::boolean AS needs_action,
+    COALESCE((array_agg(acted ORDER BY created_at DESC, id DESC))[1], FALSE)::boolean AS acted,
+    (array_agg(id ORDER BY created_at DESC, id DESC))::bigint[] AS notification_ids,
+    -- payloads of the latest notifications only, enough to name a few actors
+    (array_agg(payload ORDER BY created_at DESC, id DESC))[1:10]::jsonb[] AS payloads,
+    SUM(COALESCE(count, 1))::bigint AS total_count,
+    -- distinct users of the whole group, the payloads only name the first of each notification
+    (SELECT COUNT(DISTINCT a.actor_id) FROM visible v, unnest(v.actor_ids) AS a(actor_id)
+     WHERE v.group_key = visible.group_key AND v.bucket = visible.bucket)::bigint AS actor_count,
+    BOOL_AND(COALESCE(seen, FALSE))::boolean AS seen,
+    MAX(id)::bigint AS latest_id,
+    MAX(created_at)::timestamptz AS latest_at,
+    MIN(created_at)::timestamptz AS earliest_at
+FROM visible
+GROUP BY group_key, bucket
+HAVING sqlc.narg('cursor_at')::timestamptz IS NULL
+    OR (MAX(created_at), MAX(id)) < (sqlc.narg('cursor_at')::timestamptz, sqlc.narg('cursor_id')::bigint)
+ORDER BY latest_at DESC, latest_id DESC
+LIMIT sqlc.arg('limit');
+
 -- name: GetUserNotificationsCount :one
 SELECT COUNT(*)
 FROM notifications
-WHERE user_id = $1 AND deleted_at IS NULL;
+WHERE user_id = $1 AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW() OR (needs_action IS TRUE AND acted IS NOT TRUE));
 
 -- name: GetUserUnreadNotificationsCount :one
 SELECT COUNT(*)
 FROM notifications
-WHERE user_id = $1 AND seen = false AND deleted_at IS NULL;
+WHERE user_id = $1 AND seen = false AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW() OR (needs_action IS TRUE AND acted IS NOT TRUE));
 
 -- name: MarkNotificationAsRead :exec
 UPDATE notifications SET seen = true WHERE id = $1 AND user_id = $2;
@@ -46,22 +97,43 @@ UPDATE notifications SET acted = true WHERE id = $1 AND user_id = $2;
 -- name: MarkAllAsRead :exec
 UPDATE notifications SET seen = true WHERE user_id = $1 AND seen = false;
 
+-- name: MarkReadByEntity :many
+UPDATE notifications SET seen = true
+WHERE user_id = $1 AND source_service = $2 AND source_entity_id = $3 AND seen = false AND deleted_at IS NULL
+RETURNING id;
+
+-- name: MarkReadByCategory :many
+UPDATE notifications n SET seen = true
+FROM notification_types nt
+WHERE nt.notif_type = n.notif_type AND nt.category = $2
+  AND n.user_id = $1 AND n.seen = false AND n.deleted_at IS NULL
+RETURNING n.id;
+
 -- name: DeleteNotification :exec
 UPDATE notifications SET deleted_at = NOW() WHERE id = $1 AND user_id = $2;
 
 -- name: UpdateNotificationCount :exec
-UPDATE notifications SET count = $1 WHERE id = $2 AND user_id = $3;
+-- the actor is added to the actors of the notification unless it is one already
+UPDATE notifications
+SET count = sqlc.arg('count'),
+    actor_ids = CASE
+        WHEN sqlc.narg('actor_id')::bigint IS NULL OR sqlc.narg('actor_id')::bigint = ANY(actor_ids) THEN actor_ids
+        ELSE array_append(actor_ids, sqlc.narg('actor_id')::bigint)
+    END
+WHERE id = sqlc.arg('id') AND user_id = sqlc.arg('user_id');
 
 -- name: GetUnreadNotificationByTypeAndEntity :one
 SELECT id, user_id, notif_type, source_service, source_entity_id, seen, needs_action, acted, payload, created_at, expires_at, deleted_at, count
 FROM notifications
 WHERE user_id = $1 AND notif_type = $2 AND source_entity_id = $3 AND seen = false AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW() OR (needs_action IS TRUE AND acted IS NOT TRUE))
 LIMIT 1;
 
 -- name: GetNotificationByTypeAndEntity :one
 SELECT id, user_id, notif_type, source_service, source_entity_id, seen, needs_action, acted, payload, created_at, expires_at, deleted_at, count
 FROM notifications
 WHERE user_id = $1 AND notif_type = $2 AND source_entity_id = $3 AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW() OR (needs_action IS TRUE AND acted IS NOT TRUE))
 LIMIT 1;
 
 -- name: CreateNotificationType :exec
@@ -70,6 +142,161 @@ VALUES ($1, $2, $3)
 ON CONFLICT (notif_type) DO NOTHING;
 
 -- name: GetNotificationType :one
-SELECT notif_type, category, default_enabled
+SELECT notif_type, category, default_enabled, retention_days
 FROM notification_types
 WHERE notif_type = $1;
+
+-- name: ListNotificationTypes :many
+SELECT notif_type, category, default_enabled, retention_days
+FROM notification_types
+ORDER BY notif_type;
+
+-- name: GetNotificationPreferences :many
+SELECT user_id, scope, name, enabled, updated_at
+FROM notification_preferences
+WHERE user_id = $1;
+
+-- name: UpsertNotificationPreference :exec
+INSERT INTO notification_preferences (user_id, scope, name, enabled, updated_at)
+VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT (user_id, scope, name)
+DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = NOW();
+
+-- name: IsNotificationEnabled :one
+-- type setting, else category setting, else the type's default,
+-- and no active mute of the group, conversation or user the notification is about
+SELECT (
+    COALESCE(
+        (SELECT p.enabled FROM notification_preferences p
+         WHERE p.user_id = $1 AND p.scope = 'type' AND p.name = nt.notif_type),
+        (SELECT p.enabled FROM notification_preferences p
+         WHERE p.user_id = $1 AND p.scope = 'category' AND p.name = nt.category),
+        nt.default_enabled,
+        TRUE
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM notification_mutes m
+        WHERE m.user_id = $1
+          AND (m.muted_until IS NULL OR m.muted_until > NOW())
+          AND (
+               (m.target_type = 'group' AND m.target_id = sqlc.narg('group_id'))
+            OR (m.target_type = 'conversation' AND m.target_id = sqlc.narg('conversation_id'))
+            OR (m.target_type = 'user' AND m.target_id = sqlc.narg('actor_id'))
+          )
+    )
+)::boolean AS enabled
+FROM notification_types nt
+WHERE nt.notif_type = $2;
+
+-- name: UpsertNotificationMute :exec
+INSERT INTO notification_mutes (user_id, target_type, target_id, muted_until)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, target_type, target_id)
+DO UPDATE SET muted_until = EXCLUDED.muted_until;
+
+-- name: DeleteNotificationMute :exec
+DELETE FROM notification_mutes
+WHERE user_id = $1 AND target_type = $2 AND target_id = $3;
+
+-- name: GetActiveNotificationMutes :many
+SELECT user_id, target_type, target_id, muted_until, created_at
+FROM notification_mutes
+WHERE user_id = $1
+  AND (muted_until IS NULL OR muted_until > NOW())
+ORDER BY created_at DESC;
+
+-- name: GetEmailSettings :one
+SELECT user_id, mode, last_digest_at, updated_at
+FROM notification_email_settings
+WHERE user_id = $1;
+
+-- name: UpsertEmailMode :exec
+INSERT INTO notification_email_settings (user_id, mode, updated_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (user_id)
+DO UPDATE SET mode = EXCLUDED.mode, updated_at = NOW();
+
+-- name: GetDueEmailDigests :many
+-- users on the given digest mode, whose last digest is older than due_before,
+-- with unread notifications created since their last digest (or since due_before for a first digest)
+SELECT n.user_id, COALESCE(s.last_digest_at, sqlc.arg('due_before')::timestamptz)::timestamptz AS since
+FROM notifications n
+LEFT JOIN notification_email_settings s ON s.user_id = n.user_id
+WHERE n.seen IS NOT TRUE
+  AND n.deleted_at IS NULL
+  AND (n.expires_at IS NULL OR n.expires_at > NOW())
+  AND COALESCE(s.mode, sqlc.arg('default_mode')::text) = sqlc.arg('mode')::text
+  AND (s.last_digest_at IS NULL OR s.last_digest_at <= sqlc.arg('due_before')::timestamptz)
+  AND n.created_at > COALESCE(s.last_digest_at, sqlc.arg('due_before')::timestamptz)
+GROUP BY n.user_id, s.last_digest_at
+ORDER BY n.user_id
+LIMIT sqlc.arg('max_users');
+
+-- name: ClaimEmailDigest :one
+-- records a digest as sent at sent_at, unless another worker already did since due_before
+INSERT INTO notification_email_settings (user_id, mode, last_digest_at)
+VALUES (sqlc.arg('user_id'), sqlc.arg('mode'), sqlc.arg('sent_at'))
+ON CONFLICT (user_id)
+DO UPDATE SET last_digest_at = EXCLUDED.last_digest_at
+WHERE notification_email_settings.last_digest_at IS NULL
+   OR notification_email_settings.last_digest_at <= sqlc.arg('due_before')::timestamptz
+RETURNING user_id;
+
+-- name: GetEmailDigestNotifications :many
+SELECT id, user_id, notif_type, source_service, source_entity_id, seen, needs_action, acted, payload, created_at, expires_at, deleted_at, count
+FROM notifications
+WHERE user_id = $1
+  AND seen IS NOT TRUE
+  AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW())
+  AND created_at > $2
+ORDER BY created_at DESC
+LIMIT $3;
+
+-- name: UpsertPushSubscription :exec
+INSERT INTO push_subscriptions (endpoint, user_id, p256dh, auth, user_agent)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (endpoint)
+DO UPDATE SET user_id = EXCLUDED.user_id, p256dh = EXCLUDED.p256dh, auth = EXCLUDED.auth,
+              user_agent = EXCLUDED.user_agent, created_at = NOW();
+
+-- name: DeletePushSubscription :exec
+DELETE FROM push_subscriptions
+WHERE user_id = $1 AND endpoint = $2;
+
+-- name: DeletePushSubscriptionByEndpoint :exec
+-- for subscriptions the push service reports gone
+DELETE FROM push_subscriptions
+WHERE endpoint = $1;
+
+-- name: GetUserPushSubscriptions :many
+SELECT endpoint, user_id, p256dh, auth, user_agent, created_at
+FROM push_subscriptions
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: PurgeExpiredNotifications :execrows
+-- hard deletes up to limit expired notifications, keeping the ones still waiting for an action
+DELETE FROM notifications
+WHERE id IN (
+    SELECT id FROM notifications
+    WHERE deleted_at IS NULL
+      AND expires_at <= NOW()
+      AND NOT (needs_action IS TRUE AND acted IS NOT TRUE)
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+);
+
+-- name: PurgeDeletedNotifications :execrows
+-- hard deletes up to limit notifications soft deleted before deleted_at
+DELETE FROM notifications
+WHERE id IN (
+    SELECT id FROM notifications
+    WHERE deleted_at <= $1
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+);
+
+-- name: SetNotificationTypeRetention :execrows
+UPDATE notification_types SET retention_days = $2 WHERE notif_type = $1;
@@ -22,10 +22,44 @@ type Notification struct {
 	ExpiresAt      pgtype.Timestamptz
 	DeletedAt      pgtype.Timestamptz
 	Count          pgtype.Int4
+	ActorIds       []int64
+}
+
+type NotificationEmailSetting struct {
+	UserID       int64
+	Mode         string
+	LastDigestAt pgtype.Timestamptz
+	UpdatedAt    pgtype.Timestamptz
+}
+
+type NotificationMute struct {
+	UserID     int64
+	TargetType string
+	TargetID   int64
+	MutedUntil pgtype.Timestamptz
+	CreatedAt  pgtype.Timestamptz
+}
+
+type NotificationPreference struct {
+	UserID    int64
+	Scope     string
+	Name      string
+	Enabled   bool
+	UpdatedAt pgtype.Timestamptz
 }
 
 type NotificationType struct {
 	NotifType      string
 	Category       pgtype.Text
 	DefaultEnabled pgtype.Bool
+	RetentionDays  pgtype.Int4
+}
+
+type PushSubscription struct {
+	Endpoint  string
+	UserID    int64
+	P256dh    string
+	Auth      string
+	UserAgent string
+	CreatedAt pgtype.Timestamptz
 }
@@ -11,6 +11,36 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const claimEmailDigest = `-- name: ClaimEmailDigest :one
+INSERT INTO notification_email_settings (user_id, mode, last_digest_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id)
+DO UPDATE SET last_digest_at = EXCLUDED.last_digest_at
+WHERE notification_email_settings.last_digest_at IS NULL
+   OR notification_email_settings.last_digest_at <= $4::timestamptz
+RETURNING user_id
+`
+
+type ClaimEmailDigestParams struct {
+	UserID    int64
+	Mode      string
+	SentAt    pgtype.Timestamptz
+	DueBefore pgtype.Timestamptz
+}
+
+// records a digest as sent at sent_at, unless another worker already did since due_before
+func (q *Queries) ClaimEmailDigest(ctx context.Context, arg ClaimEmailDigestParams) (int64, error) {
+	row := q.db.QueryRow(ctx, claimEmailDigest,
+		arg.UserID,
+		arg.Mode,
+		arg.SentAt,
+		arg.DueBefore,
+	)
+	var user_id int64
+	err := row.Scan(&user_id)
+	return user_id, err
+}
+
 const createNotification = `-- name: CreateNotification :one
 INSERT INTO notifications (
     user_id,
@@ -22,9 +52,13 @@ INSERT INTO notifications (
     payload,
     created_at,
     expires_at,
-    count
+    count,
+    actor_ids
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, NOW(), $8, $9
+    $1, $2, $3, $4, $5, $6, $7, NOW(),
+    -- the type's retention if it has one, else the given default
+    COALESCE(NOW() + make_interval(days => (SELECT nt.retention_days FROM notification_types nt WHERE nt.notif_type = $2)), $8),
+    $9, $10
 ) RETURNING id, user_id, notif_type, source_service, source_entity_id, seen, needs_action, acted, payload, created_at, expires_at, deleted_at, count
 `
 
@@ -38,6 +72,7 @@ type CreateNotificationParams struct {
 	Payload        []byte
 	ExpiresAt      pgtype.Timestamptz
 	Count          pgtype.Int4
+	ActorIds       []int64
 }
 
 func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error) {
@@ -51,6 +86,7 @@ func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotification
 		arg.Payload,
 		arg.ExpiresAt,
 		arg.Count,
+		arg.ActorIds,
 	)
 	var i Notification
 	err := row.Scan(
@@ -102,10 +138,211 @@ func (q *Queries) DeleteNotification(ctx context.Context, arg DeleteNotification
 	return err
 }
 
+const deleteNotificationMute = `-- name: DeleteNotificationMute :exec
+DELETE FROM notification_mutes
+WHERE user_id = $1 AND target_type = $2 AND target_id = $3
+`
+
+type DeleteNotificationMuteParams struct {
+	UserID     int64
+	TargetType string
+	TargetID   int64
+}
+
+func (q *Queries) DeleteNotificationMute(ctx context.Context, arg DeleteNotificationMuteParams) error {
+	_, err := q.db.Exec(ctx, deleteNotificationMute, arg.UserID, arg.TargetType, arg.TargetID)
+	return err
+}
+
+const deletePushSubscription = `-- name: DeletePushSubscription :exec
+DELETE FROM push_subscriptions
+WHERE user_id = $1 AND endpoint = $2
+`
+
+type DeletePushSubscriptionParams struct {
+	UserID   int64
+	Endpoint string
+}
+
+func (q *Queries) DeletePushSubscription(ctx context.Context, arg DeletePushSubscriptionParams) error {
+	_, err := q.db.Exec(ctx, deletePushSubscription, arg.UserID, arg.Endpoint)
+	return err
+}
+
+const deletePushSubscriptionByEndpoint = `-- name: DeletePushSubscriptionByEndpoint :exec
+DELETE FROM push_subscriptions
+WHERE endpoint = $1
+`
+
+// for subscriptions the push service reports gone
+func (q *Queries) DeletePushSubscriptionByEndpoint(ctx context.Context, endpoint string) error {
+	_, err := q.db.Exec(ctx, deletePushSubscriptionByEndpoint, endpoint)
+	return err
+}
+
+const getActiveNotificationMutes = `-- name: GetActiveNotificationMutes :many
+SELECT user_id, target_type, target_id, muted_until, created_at
+FROM notification_mutes
+WHERE user_id = $1
+  AND (muted_until IS NULL OR muted_until > NOW())
+ORDER BY created_at DESC
+`
+
+func (q *Queries) GetActiveNotificationMutes(ctx context.Context, userID int64) ([]NotificationMute, error) {
+	rows, err := q.db.Query(ctx, getActiveNotificationMutes, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NotificationMute{}
+	for rows.Next() {
+		var i NotificationMute
+		if err := rows.Scan(
+			&i.UserID,
+			&i.TargetType,
+			&i.TargetID,
+			&i.MutedUntil,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDueEmailDigests = `-- name: GetDueEmailDigests :many
+SELECT n.user_id, COALESCE(s.last_digest_at, $1::timestamptz)::timestamptz AS since
+FROM notifications n
+LEFT JOIN notification_email_settings s ON s.user_id = n.user_id
+WHERE n.seen IS NOT TRUE
+  AND n.deleted_at IS NULL
+  AND (n.expires_at IS NULL OR n.expires_at > NOW())
+  AND COALESCE(s.mode, $2::text) = $3::text
+  AND (s.last_digest_at IS NULL OR s.last_digest_at <= $1::timestamptz)
+  AND n.created_at > COALESCE(s.last_digest_at, $1::timestamptz)
+GROUP BY n.user_id, s.last_digest_at
+ORDER BY n.user_id
+LIMIT $4
+`
+
+type GetDueEmailDigestsParams struct {
+	DueBefore   pgtype.Timestamptz
+	DefaultMode string
+	Mode        string
+	MaxUsers    int32
+}
+
+type GetDueEmailDigestsRow struct {
+	UserID int64
+	Since  pgtype.Timestamptz
+}
+
+// users on the given digest mode, whose last digest is older than due_before,
+// with unread notifications created since their last digest (or since due_before for a first digest)
+func (q *Queries) GetDueEmailDigests(ctx context.Context, arg GetDueEmailDigestsParams) ([]GetDueEmailDigestsRow, error) {
+	rows, err := q.db.Query(ctx, getDueEmailDigests,
+		arg.DueBefore,
+		arg.DefaultMode,
+		arg.Mode,
+		arg.MaxUsers,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDueEmailDigestsRow{}
+	for rows.Next() {
+		var i GetDueEmailDigestsRow
+		if err := rows.Scan(&i.UserID, &i.Since); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEmailDigestNotifications = `-- name: GetEmailDigestNotifications :many
+SELECT id, user_id, notif_type, source_service, source_entity_id, seen, needs_action, acted, payload, created_at, expires_at, deleted_at, count
+FROM notifications
+WHERE user_id = $1
+  AND seen IS NOT TRUE
+  AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW())
+  AND created_at > $2
+ORDER BY created_at DESC
+LIMIT $3
+`
+
+type GetEmailDigestNotificationsParams struct {
+	UserID    int64
+	CreatedAt pgtype.Timestamptz
+	Limit     int32
+}
+
+func (q *Queries) GetEmailDigestNotifications(ctx context.Context, arg GetEmailDigestNotificationsParams) ([]Notification, error) {
+	rows, err := q.db.Query(ctx, getEmailDigestNotifications, arg.UserID, arg.CreatedAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Notification{}
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.NotifType,
+			&i.SourceService,
+			&i.SourceEntityID,
+			&i.Seen,
+			&i.NeedsAction,
+			&i.Acted,
+			&i.Payload,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.DeletedAt,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEmailSettings = `-- name: GetEmailSettings :one
+SELECT user_id, mode, last_digest_at, updated_at
+FROM notification_email_settings
+WHERE user_id = $1
+`
+
+func (q *Queries) GetEmailSettings(ctx context.Context, userID int64) (NotificationEmailSetting, error) {
+	row := q.db.QueryRow(ctx, getEmailSettings, userID)
+	var i NotificationEmailSetting
+	err := row.Scan(
+		&i.UserID,
+		&i.Mode,
+		&i.LastDigestAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getNotificationByID = `-- name: GetNotificationByID :one
 SELECT id, user_id, notif_type, source_service, source_entity_id, seen, needs_action, acted, payload, created_at, expires_at, deleted_at, count
 FROM notifications
 WHERE id = $1 AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW() OR (needs_action IS TRUE AND acted IS NOT TRUE))
 `
 
 func (q *Queries) GetNotificationByID(ctx context.Context, id int64) (Notification, error) {
@@ -133,6 +370,7 @@ const getNotificationByTypeAndEntity = `-- name: GetNotificationByTypeAndEntity
 SELECT id, user_id, notif_type, source_service, source_entity_id, seen, needs_action, acted, payload, created_at, expires_at, deleted_at, count
 FROM notifications
 WHERE user_id = $1 AND notif_type = $2 AND source_entity_id = $3 AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW() OR (needs_action IS TRUE AND acted IS NOT TRUE))
 LIMIT 1
 `
 
@@ -163,8 +401,40 @@ func (q *Queries) GetNotificationByTypeAndEntity(ctx context.Context, arg GetNot
 	return i, err
 }
 
+const getNotificationPreferences = `-- name: GetNotificationPreferences :many
+SELECT user_id, scope, name, enabled, updated_at
+FROM notification_preferences
+WHERE user_id = $1
+`
+
+func (q *Queries) GetNotificationPreferences(ctx context.Context, userID int64) ([]NotificationPreference, error) {
+	rows, err := q.db.Query(ctx, getNotificationPreferences, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NotificationPreference{}
+	for rows.Next() {
+		var i NotificationPreference
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Scope,
+			&i.Name,
+			&i.Enabled,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getNotificationType = `-- name: GetNotificationType :one
-SELECT notif_type, category, default_enabled
+SELECT notif_type, category, default_enabled, retention_days
 FROM notification_types
 WHERE notif_type = $1
 `
@@ -172,7 +442,12 @@ WHERE notif_type = $1
 func (q *Queries) GetNotificationType(ctx context.Context, notifType string) (NotificationType, error) {
 	row := q.db.QueryRow(ctx, getNotificationType, notifType)
 	var i NotificationType
-	err := row.Scan(&i.NotifType, &i.Category, &i.DefaultEnabled)
+	err := row.Scan(
+		&i.NotifType,
+		&i.Category,
+		&i.DefaultEnabled,
+		&i.RetentionDays,
+	)
 	return i, err
 }
 
@@ -180,6 +455,7 @@ const getUnreadNotificationByTypeAndEntity = `-- name: GetUnreadNotificationByTy
 SELECT id, user_id, notif_type, source_service, source_entity_id, seen, needs_action, acted, payload, created_at, expires_at, deleted_at, count
 FROM notifications
 WHERE user_id = $1 AND notif_type = $2 AND source_entity_id = $3 AND seen = false AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW() OR (needs_action IS TRUE AND acted IS NOT TRUE))
 LIMIT 1
 `
 
@@ -210,11 +486,124 @@ func (q *Queries) GetUnreadNotificationByTypeAndEntity(ctx context.Context, arg
 	return i, err
 }
 
+const getUserNotificationGroups = `-- name: GetUserNotificationGroups :many
+WITH visible AS (
+    SELECT n.id, n.notif_type, n.source_service, n.source_entity_id, n.seen, n.needs_action, n.acted, n.payload, n.created_at, n.count, n.actor_ids,
+        CASE
+            WHEN n.notif_type IN ('like', 'post_reply') THEN n.notif_type || ':' || n.source_entity_id
+            WHEN n.notif_type = 'new_follower' THEN n.notif_type
+            ELSE 'id:' || n.id
+        END AS group_key,
+        FLOOR(EXTRACT(EPOCH FROM n.created_at) / $1::bigint)::bigint AS bucket
+    FROM notifications n
+    JOIN notification_types nt ON nt.notif_type = n.notif_type
+    WHERE n.user_id = $2
+      AND n.deleted_at IS NULL
+      AND (n.expires_at IS NULL OR n.expires_at > NOW() OR (n.needs_action IS TRUE AND n.acted IS NOT TRUE))
+      AND (cardinality($3::text[]) = 0 OR nt.category = ANY($3::text[]))
+      AND (NOT $4::boolean OR n.seen IS NOT TRUE)
+)
+SELECT
+    (array_agg(notif_type ORDER BY created_at DESC, id DESC))[1]::text AS notif_type,
+    (array_agg(source_service ORDER BY created_at DESC, id DESC))[1]::text AS source_service,
+    COALESCE((array_agg(source_entity_id ORDER BY created_at DESC, id DESC))[1], 0)::bigint AS source_entity_id,
+    COALESCE((array_agg(needs_action ORDER BY created_at DESC, id DESC))[1], FALSE)::boolean AS needs_action,
+    COALESCE((array_agg(acted ORDER BY created_at DESC, id DESC))[1], FALSE)::boolean AS acted,
+    (array_agg(id ORDER BY created_at DESC, id DESC))::bigint[] AS notification_ids,
+    -- payloads of the latest notifications only, enough to name a few actors
+    (array_agg(payload ORDER BY created_at DESC, id DESC))[1:10]::jsonb[] AS payloads,
+    SUM(COALESCE(count, 1))::bigint AS total_count,
+    -- distinct users of the whole group, the payloads only name the first of each notification
+    (SELECT COUNT(DISTINCT a.actor_id) FROM visible v, unnest(v.actor_ids) AS a(actor_id)
+     WHERE v.group_key = visible.group_key AND v.bucket = visible.bucket)::bigint AS actor_count,
+    BOOL_AND(COALESCE(seen, FALSE))::boolean AS seen,
+    MAX(id)::bigint AS latest_id,
+    MAX(created_at)::timestamptz AS latest_at,
+    MIN(created_at)::timestamptz AS earliest_at
+FROM visible
+GROUP BY group_key, bucket
+HAVING $5::timestamptz IS NULL
+    OR (MAX(created_at), MAX(id)) < ($5::timestamptz, $6::bigint)
+ORDER BY latest_at DESC, latest_id DESC
+LIMIT $7
+`
+
+type GetUserNotificationGroupsParams struct {
+	BucketSeconds int64
+	UserID        int64
+	Categories    []string
+	UnreadOnly    bool
+	CursorAt      pgtype.Timestamptz
+	CursorID      pgtype.Int8
+	Limit         int32
+}
+
+type GetUserNotificationGroupsRow struct {
+	NotifType       string
+	SourceService   string
+	SourceEntityID  int64
+	NeedsAction     bool
+	Acted           bool
+	NotificationIds []int64
+	Payloads        [][]byte
+	TotalCount      int64
+	ActorCount      int64
+	Seen            bool
+	LatestID        int64
+	LatestAt        pgtype.Timestamptz
+	EarliestAt      pgtype.Timestamptz
+}
+
+// likes and comments grouped by post and new followers grouped together, within time buckets of bucket_seconds,
+// every other notification is a group of its own. Newest group first, keyset paginated on (latest_at, latest_id)
+func (q *Queries) GetUserNotificationGroups(ctx context.Context, arg GetUserNotificationGroupsParams) ([]GetUserNotificationGroupsRow, error) {
+	rows, err := q.db.Query(ctx, getUserNotificationGroups,
+		arg.BucketSeconds,
+		arg.UserID,
+		arg.Categories,
+		arg.UnreadOnly,
+		arg.CursorAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUserNotificationGroupsRow{}
+	for rows.Next() {
+		var i GetUserNotificationGroupsRow
+		if err := rows.Scan(
+			&i.NotifType,
+			&i.SourceService,
+			&i.SourceEntityID,
+			&i.NeedsAction,
+			&i.Acted,
+			&i.NotificationIds,
+			&i.Payloads,
+			&i.TotalCount,
+			&i.ActorCount,
+			&i.Seen,
+			&i.LatestID,
+			&i.LatestAt,
+			&i.EarliestAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserNotifications = `-- name: GetUserNotifications :many
 SELECT id, user_id, notif_type, source_service, source_entity_id, seen, needs_action, acted, payload, created_at, expires_at, deleted_at, count
 FROM notifications
 WHERE user_id = $1
   AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW() OR (needs_action IS TRUE AND acted IS NOT TRUE))
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -263,6 +652,7 @@ const getUserNotificationsCount = `-- name: GetUserNotificationsCount :one
 SELECT COUNT(*)
 FROM notifications
 WHERE user_id = $1 AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW() OR (needs_action IS TRUE AND acted IS NOT TRUE))
 `
 
 func (q *Queries) GetUserNotificationsCount(ctx context.Context, userID int64) (int64, error) {
@@ -272,10 +662,51 @@ func (q *Queries) GetUserNotificationsCount(ctx context.Context, userID int64) (
 	return count, err
 }
 
+const getUserPushSubscriptions = `-- name: GetUserPushSubscriptions :many
+SELECT endpoint, user_id, p256dh, auth, user_agent, created_at
+FROM push_subscriptions
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2
+`
+
+type GetUserPushSubscriptionsParams struct {
+	UserID int64
+	Limit  int32
+}
+
+func (q *Queries) GetUserPushSubscriptions(ctx context.Context, arg GetUserPushSubscriptionsParams) ([]PushSubscription, error) {
+	rows, err := q.db.Query(ctx, getUserPushSubscriptions, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PushSubscription{}
+	for rows.Next() {
+		var i PushSubscription
+		if err := rows.Scan(
+			&i.Endpoint,
+			&i.UserID,
+			&i.P256dh,
+			&i.Auth,
+			&i.UserAgent,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserUnreadNotificationsCount = `-- name: GetUserUnreadNotificationsCount :one
 SELECT COUNT(*)
 FROM notifications
 WHERE user_id = $1 AND seen = false AND deleted_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW() OR (needs_action IS TRUE AND acted IS NOT TRUE))
 `
 
 func (q *Queries) GetUserUnreadNotificationsCount(ctx context.Context, userID int64) (int64, error) {
@@ -285,6 +716,85 @@ func (q *Queries) GetUserUnreadNotificationsCount(ctx context.Context, userID in
 	return count, err
 }
 
+const isNotificationEnabled = `-- name: IsNotificationEnabled :one
+SELECT (
+    COALESCE(
+        (SELECT p.enabled FROM notification_preferences p
+         WHERE p.user_id = $1 AND p.scope = 'type' AND p.name = nt.notif_type),
+        (SELECT p.enabled FROM notification_preferences p
+         WHERE p.user_id = $1 AND p.scope = 'category' AND p.name = nt.category),
+        nt.default_enabled,
+        TRUE
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM notification_mutes m
+        WHERE m.user_id = $1
+          AND (m.muted_until IS NULL OR m.muted_until > NOW())
+          AND (
+               (m.target_type = 'group' AND m.target_id = $3)
+            OR (m.target_type = 'conversation' AND m.target_id = $4)
+            OR (m.target_type = 'user' AND m.target_id = $5)
+          )
+    )
+)::boolean AS enabled
+FROM notification_types nt
+WHERE nt.notif_type = $2
+`
+
+type IsNotificationEnabledParams struct {
+	UserID         int64
+	NotifType      string
+	GroupID        pgtype.Int8
+	ConversationID pgtype.Int8
+	ActorID        pgtype.Int8
+}
+
+// type setting, else category setting, else the type's default,
+// and no active mute of the group, conversation or user the notification is about
+func (q *Queries) IsNotificationEnabled(ctx context.Context, arg IsNotificationEnabledParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isNotificationEnabled,
+		arg.UserID,
+		arg.NotifType,
+		arg.GroupID,
+		arg.ConversationID,
+		arg.ActorID,
+	)
+	var enabled bool
+	err := row.Scan(&enabled)
+	return enabled, err
+}
+
+const listNotificationTypes = `-- name: ListNotificationTypes :many
+SELECT notif_type, category, default_enabled, retention_days
+FROM notification_types
+ORDER BY notif_type
+`
+
+func (q *Queries) ListNotificationTypes(ctx context.Context) ([]NotificationType, error) {
+	rows, err := q.db.Query(ctx, listNotificationTypes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NotificationType{}
+	for rows.Next() {
+		var i NotificationType
+		if err := rows.Scan(
+			&i.NotifType,
+			&i.Category,
+			&i.DefaultEnabled,
+			&i.RetentionDays,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const markAllAsRead = `-- name: MarkAllAsRead :exec
 UPDATE notifications SET seen = true WHERE user_id = $1 AND seen = false
 `
@@ -322,17 +832,249 @@ func (q *Queries) MarkNotificationAsRead(ctx context.Context, arg MarkNotificati
 	return err
 }
 
+const markReadByCategory = `-- name: MarkReadByCategory :many
+UPDATE notifications n SET seen = true
+FROM notification_types nt
+WHERE nt.notif_type = n.notif_type AND nt.category = $2
+  AND n.user_id = $1 AND n.seen = false AND n.deleted_at IS NULL
+RETURNING n.id
+`
+
+type MarkReadByCategoryParams struct {
+	UserID   int64
+	Category pgtype.Text
+}
+
+func (q *Queries) MarkReadByCategory(ctx context.Context, arg MarkReadByCategoryParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, markReadByCategory, arg.UserID, arg.Category)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markReadByEntity = `-- name: MarkReadByEntity :many
+UPDATE notifications SET seen = true
+WHERE user_id = $1 AND source_service = $2 AND source_entity_id = $3 AND seen = false AND deleted_at IS NULL
+RETURNING id
+`
+
+type MarkReadByEntityParams struct {
+	UserID         int64
+	SourceService  string
+	SourceEntityID pgtype.Int8
+}
+
+func (q *Queries) MarkReadByEntity(ctx context.Context, arg MarkReadByEntityParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, markReadByEntity, arg.UserID, arg.SourceService, arg.SourceEntityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeDeletedNotifications = `-- name: PurgeDeletedNotifications :execrows
+DELETE FROM notifications
+WHERE id IN (
+    SELECT id FROM notifications
+    WHERE deleted_at <= $1
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+`
+
+type PurgeDeletedNotificationsParams struct {
+	DeletedAt pgtype.Timestamptz
+	Limit     int32
+}
+
+// hard deletes up to limit notifications soft deleted before deleted_at
+func (q *Queries) PurgeDeletedNotifications(ctx context.Context, arg PurgeDeletedNotificationsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeDeletedNotifications, arg.DeletedAt, arg.Limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const purgeExpiredNotifications = `-- name: PurgeExpiredNotifications :execrows
+DELETE FROM notifications
+WHERE id IN (
+    SELECT id FROM notifications
+    WHERE deleted_at IS NULL
+      AND expires_at <= NOW()
+      AND NOT (needs_action IS TRUE AND acted IS NOT TRUE)
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+`
+
+// hard deletes up to limit expired notifications, keeping the ones still waiting for an action
+func (q *Queries) PurgeExpiredNotifications(ctx context.Context, limit int32) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeExpiredNotifications, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setNotificationTypeRetention = `-- name: SetNotificationTypeRetention :execrows
+UPDATE notification_types SET retention_days = $2 WHERE notif_type = $1
+`
+
+type SetNotificationTypeRetentionParams struct {
+	NotifType     string
+	RetentionDays pgtype.Int4
+}
+
+func (q *Queries) SetNotificationTypeRetention(ctx context.Context, arg SetNotificationTypeRetentionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setNotificationTypeRetention, arg.NotifType, arg.RetentionDays)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateNotificationCount = `-- name: UpdateNotificationCount :exec
-UPDATE notifications SET count = $1 WHERE id = $2 AND user_id = $3
+UPDATE notifications
+SET count = $1,
+    actor_ids = CASE
+        WHEN $2::bigint IS NULL OR $2::bigint = ANY(actor_ids) THEN actor_ids
+        ELSE array_append(actor_ids, $2::bigint)
+    END
+WHERE id = $3 AND user_id = $4
 `
 
 type UpdateNotificationCountParams struct {
-	Count  pgtype.Int4
-	ID     int64
-	UserID int64
+	Count   pgtype.Int4
+	ActorID pgtype.Int8
+	ID      int64
+	UserID  int64
 }
 
+// the actor is added to the actors of the notification unless it is one already
 func (q *Queries) UpdateNotificationCount(ctx context.Context, arg UpdateNotificationCountParams) error {
-	_, err := q.db.Exec(ctx, updateNotificationCount, arg.Count, arg.ID, arg.UserID)
+	_, err := q.db.Exec(ctx, updateNotificationCount,
+		arg.Count,
+		arg.ActorID,
+		arg.ID,
+		arg.UserID,
+	)
+	return err
+}
+
+const upsertEmailMode = `-- name: UpsertEmailMode :exec
+INSERT INTO notification_email_settings (user_id, mode, updated_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (user_id)
+DO UPDATE SET mode = EXCLUDED.mode, updated_at = NOW()
+`
+
+type UpsertEmailModeParams struct {
+	UserID int64
+	Mode   string
+}
+
+func (q *Queries) UpsertEmailMode(ctx context.Context, arg UpsertEmailModeParams) error {
+	_, err := q.db.Exec(ctx, upsertEmailMode, arg.UserID, arg.Mode)
+	return err
+}
+
+const upsertNotificationMute = `-- name: UpsertNotificationMute :exec
+INSERT INTO notification_mutes (user_id, target_type, target_id, muted_until)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, target_type, target_id)
+DO UPDATE SET muted_until = EXCLUDED.muted_until
+`
+
+type UpsertNotificationMuteParams struct {
+	UserID     int64
+	TargetType string
+	TargetID   int64
+	MutedUntil pgtype.Timestamptz
+}
+
+func (q *Queries) UpsertNotificationMute(ctx context.Context, arg UpsertNotificationMuteParams) error {
+	_, err := q.db.Exec(ctx, upsertNotificationMute,
+		arg.UserID,
+		arg.TargetType,
+		arg.TargetID,
+		arg.MutedUntil,
+	)
+	return err
+}
+
+const upsertNotificationPreference = `-- name: UpsertNotificationPreference :exec
+INSERT INTO notification_preferences (user_id, scope, name, enabled, updated_at)
+VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT (user_id, scope, name)
+DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = NOW()
+`
+
+type UpsertNotificationPreferenceParams struct {
+	UserID  int64
+	Scope   string
+	Name    string
+	Enabled bool
+}
+
+func (q *Queries) UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) error {
+	_, err := q.db.Exec(ctx, upsertNotificationPreference,
+		arg.UserID,
+		arg.Scope,
+		arg.Name,
+		arg.Enabled,
+	)
+	return err
+}
+
+const upsertPushSubscription = `-- name: UpsertPushSubscription :exec
+INSERT INTO push_subscriptions (endpoint, user_id, p256dh, auth, user_agent)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (endpoint)
+DO UPDATE SET user_id = EXCLUDED.user_id, p256dh = EXCLUDED.p256dh, auth = EXCLUDED.auth,
+              user_agent = EXCLUDED.user_agent, created_at = NOW()
+`
+
+type UpsertPushSubscriptionParams struct {
+	Endpoint  string
+	UserID    int64
+	P256dh    string
+	Auth      string
+	UserAgent string
+}
+
+func (q *Queries) UpsertPushSubscription(ctx context.Context, arg UpsertPushSubscriptionParams) error {
+	_, err := q.db.Exec(ctx, upsertPushSubscription,
+		arg.Endpoint,
+		arg.UserID,
+		arg.P256dh,
+		arg.Auth,
+		arg.UserAgent,
+	)
 	return err
 }
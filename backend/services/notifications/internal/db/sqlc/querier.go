@@ -9,20 +9,52 @@ import (
 )
 
 type Querier interface {
+	// records a digest as sent at sent_at, unless another worker already did since due_before
+	ClaimEmailDigest(ctx context.Context, arg ClaimEmailDigestParams) (int64, error)
 	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
 	CreateNotificationType(ctx context.Context, arg CreateNotificationTypeParams) error
 	DeleteNotification(ctx context.Context, arg DeleteNotificationParams) error
+	DeleteNotificationMute(ctx context.Context, arg DeleteNotificationMuteParams) error
+	DeletePushSubscription(ctx context.Context, arg DeletePushSubscriptionParams) error
+	// for subscriptions the push service reports gone
+	DeletePushSubscriptionByEndpoint(ctx context.Context, endpoint string) error
+	GetActiveNotificationMutes(ctx context.Context, userID int64) ([]NotificationMute, error)
+	// users on the given digest mode, whose last digest is older than due_before,
+	// with unread notifications created since their last digest (or since due_before for a first digest)
+	GetDueEmailDigests(ctx context.Context, arg GetDueEmailDigestsParams) ([]GetDueEmailDigestsRow, error)
+	GetEmailDigestNotifications(ctx context.Context, arg GetEmailDigestNotificationsParams) ([]Notification, error)
+	GetEmailSettings(ctx context.Context, userID int64) (NotificationEmailSetting, error)
 	GetNotificationByID(ctx context.Context, id int64) (Notification, error)
 	GetNotificationByTypeAndEntity(ctx context.Context, arg GetNotificationByTypeAndEntityParams) (Notification, error)
+	GetNotificationPreferences(ctx context.Context, userID int64) ([]NotificationPreference, error)
 	GetNotificationType(ctx context.Context, notifType string) (NotificationType, error)
 	GetUnreadNotificationByTypeAndEntity(ctx context.Context, arg GetUnreadNotificationByTypeAndEntityParams) (Notification, error)
+	// likes and comments grouped by post and new followers grouped together, within time buckets of bucket_seconds,
+	// every other notification is a group of its own. Newest group first, keyset paginated on (latest_at, latest_id)
+	GetUserNotificationGroups(ctx context.Context, arg GetUserNotificationGroupsParams) ([]GetUserNotificationGroupsRow, error)
 	GetUserNotifications(ctx context.Context, arg GetUserNotificationsParams) ([]Notification, error)
 	GetUserNotificationsCount(ctx context.Context, userID int64) (int64, error)
+	GetUserPushSubscriptions(ctx context.Context, arg GetUserPushSubscriptionsParams) ([]PushSubscription, error)
 	GetUserUnreadNotificationsCount(ctx context.Context, userID int64) (int64, error)
+	// type setting, else category setting, else the type's default,
+	// and no active mute of the group, conversation or user the notification is about
+	IsNotificationEnabled(ctx context.Context, arg IsNotificationEnabledParams) (bool, error)
+	ListNotificationTypes(ctx context.Context) ([]NotificationType, error)
 	MarkAllAsRead(ctx context.Context, userID int64) error
 	MarkNotificationAsActed(ctx context.Context, arg MarkNotificationAsActedParams) error
 	MarkNotificationAsRead(ctx context.Context, arg MarkNotificationAsReadParams) error
+	MarkReadByCategory(ctx context.Context, arg MarkReadByCategoryParams) ([]int64, error)
+	MarkReadByEntity(ctx context.Context, arg MarkReadByEntityParams) ([]int64, error)
+	// hard deletes up to limit notifications soft deleted before deleted_at
+	PurgeDeletedNotifications(ctx context.Context, arg PurgeDeletedNotificationsParams) (int64, error)
+	// hard deletes up to limit expired notifications, keeping the ones still waiting for an action
+	PurgeExpiredNotifications(ctx context.Context, limit int32) (int64, error)
+	SetNotificationTypeRetention(ctx context.Context, arg SetNotificationTypeRetentionParams) (int64, error)
 	UpdateNotificationCount(ctx context.Context, arg UpdateNotificationCountParams) error
+	UpsertEmailMode(ctx context.Context, arg UpsertEmailModeParams) error
+	UpsertNotificationMute(ctx context.Context, arg UpsertNotificationMuteParams) error
+	UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) error
+	UpsertPushSubscription(ctx context.Context, arg UpsertPushSubscriptionParams) error
 }
 
 var _ Querier = (*Queries)(nil)
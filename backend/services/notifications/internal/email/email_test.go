@@ -0,0 +1,122 @@
+package email
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsubscribeToken(t *testing.T) {
+	secret := []byte("secret")
+	token := UnsubscribeToken(secret, 42)
+
+	userID, err := ParseUnsubscribeToken(secret, token)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), userID)
+
+	_, err = ParseUnsubscribeToken([]byte("other"), token)
+	assert.ErrorIs(t, err, ErrBadUnsubscribeToken)
+
+	_, err = ParseUnsubscribeToken(secret, "43"+strings.TrimPrefix(token, "42"))
+	assert.ErrorIs(t, err, ErrBadUnsubscribeToken)
+
+	_, err = ParseUnsubscribeToken(secret, "garbage")
+	assert.ErrorIs(t, err, ErrBadUnsubscribeToken)
+}
+
+func TestEveryTemplateRenders(t *testing.T) {
+	r, err := NewRenderer("http://app/")
+	require.NoError(t, err)
+
+	for notifType := range summaries {
+		msg, err := r.Immediate("a@b.c", Notification{Type: notifType, Count: 1}, "http://unsub")
+		assert.NoError(t, err, notifType)
+		assert.NotEmpty(t, msg.Subject, notifType)
+		assert.NotContains(t, msg.Body, "<no value>", notifType)
+	}
+}
+
+func TestImmediate(t *testing.T) {
+	r, err := NewRenderer("http://app/")
+	require.NoError(t, err)
+
+	msg, err := r.Immediate("a@b.c", Notification{
+		Type:    "post_reply",
+		Count:   3,
+		Payload: map[string]string{"commenter_name": "alice", "post_content": "hello\n  world"},
+	}, "http://unsub?token=x")
+	require.NoError(t, err)
+
+	assert.Equal(t, "a@b.c", msg.To)
+	assert.Equal(t, "alice and 2 others commented on your post", msg.Subject)
+	assert.Contains(t, msg.Body, `On your post: "hello world"`)
+	assert.Contains(t, msg.Body, "http://app/notifications")
+	assert.Contains(t, msg.Body, "Unsubscribe: http://unsub?token=x")
+	assert.Equal(t, "<http://unsub?token=x>", msg.Headers["List-Unsubscribe"])
+}
+
+func TestUnknownTypeFallsBack(t *testing.T) {
+	r, err := NewRenderer("http://app")
+	require.NoError(t, err)
+
+	msg, err := r.Immediate("a@b.c", Notification{Type: "something_new"}, "http://unsub")
+	require.NoError(t, err)
+	assert.Equal(t, genericSummary, msg.Subject)
+}
+
+func TestDigest(t *testing.T) {
+	r, err := NewRenderer("http://app")
+	require.NoError(t, err)
+
+	ns := make([]Notification, 0, maxDigestLines+2)
+	for range maxDigestLines + 2 {
+		ns = append(ns, Notification{
+			Type:      "new_follower",
+			Count:     1,
+			Payload:   map[string]string{"follower_name": "bob"},
+			CreatedAt: time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC),
+		})
+	}
+
+	msg, err := r.Digest("a@b.c", "week", ns, "http://unsub")
+	require.NoError(t, err)
+
+	assert.Equal(t, "You have 52 unread notifications", msg.Subject)
+	assert.Contains(t, msg.Body, "52 unread notifications from the last week")
+	assert.Contains(t, msg.Body, "- bob started following you (Mar 1, 10:30)")
+	assert.Equal(t, maxDigestLines, strings.Count(msg.Body, "bob started following you"))
+	assert.Contains(t, msg.Body, "- and 2 more")
+}
+
+func TestFileSender(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileSender(dir, "from@x.y")
+	require.NoError(t, err)
+
+	err = s.Send(context.Background(), Message{
+		To:      "a@b.c",
+		Subject: "Héllo",
+		Body:    "line one\nline two",
+		Headers: map[string]string{"List-Unsubscribe": "<http://unsub>"},
+	})
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	raw, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	content := string(raw)
+	assert.Contains(t, content, "From: from@x.y\r\n")
+	assert.Contains(t, content, "To: a@b.c\r\n")
+	assert.Contains(t, content, "Subject: =?utf-8?q?H=C3=A9llo?=\r\n")
+	assert.Contains(t, content, "List-Unsubscribe: <http://unsub>\r\n")
+	assert.True(t, strings.HasSuffix(content, "\r\n\r\nline one\r\nline two"))
+}
@@ -0,0 +1,40 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync/atomic"
+	"time"
+)
+
+// FileSender writes every message as an .eml file into a directory instead of sending it.
+// Meant for local development and tests.
+type FileSender struct {
+	dir  string
+	from string
+	seq  atomic.Int64
+}
+
+func NewFileSender(dir, from string) (*FileSender, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create email directory: %w", err)
+	}
+	return &FileSender{dir: dir, from: from}, nil
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9@._-]`)
+
+func (s *FileSender) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	now := time.Now()
+	name := fmt.Sprintf("%d-%04d-%s.eml", now.UnixNano(), s.seq.Add(1), unsafeFileChars.ReplaceAllString(msg.To, "_"))
+	if err := os.WriteFile(filepath.Join(s.dir, name), msg.format(s.from, now), 0o644); err != nil {
+		return fmt.Errorf("failed to write email to %s: %w", msg.To, err)
+	}
+	return nil
+}
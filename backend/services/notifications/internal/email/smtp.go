@@ -0,0 +1,44 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// SMTPSender sends through an SMTP server. Without a username no authentication is attempted,
+// which is what local catchers like mailhog expect.
+type SMTPSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPSender(addr, username, password, from string) (*SMTPSender, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("bad smtp address %q: %w", addr, err)
+	}
+	if from == "" {
+		return nil, fmt.Errorf("smtp sender needs a from address")
+	}
+
+	s := &SMTPSender{addr: addr, from: from}
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return s, nil
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err := smtp.SendMail(s.addr, s.auth, s.from, []string{msg.To}, msg.format(s.from, time.Now()))
+	if err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", msg.To, err)
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Notification is what templates know about a notification.
+// Payload holds the raw payload as stored, names are used, ids are not.
+type Notification struct {
+	Type      string
+	Count     int32
+	Payload   map[string]string
+	CreatedAt time.Time
+}
+
+// summaries are one line per notification type, the subject of immediate emails and a line of digests.
+// Types without an entry fall back to genericSummary.
+var summaries = map[string]string{
+	"follow_request":              `{{.Payload.requester_name}} wants to follow you`,
+	"new_follower":                `{{actors .Payload.follower_name .Count}} started following you`,
+	"group_invite":                `{{.Payload.inviter_name}} invited you to join {{.Payload.group_name}}`,
+	"group_join_request":          `{{.Payload.requester_name}} wants to join {{.Payload.group_name}}`,
+	"new_event":                   `New event in {{.Payload.group_name}}: {{.Payload.event_title}}`,
+	"like":                        `{{actors .Payload.liker_name .Count}} liked your post`,
+	"post_reply":                  `{{actors .Payload.commenter_name .Count}} commented on your post`,
+	"mention":                     `{{.Payload.mentioner_name}} mentioned you`,
+	"new_message":                 `{{actors .Payload.sender_name .Count}} sent you a message`,
+	"follow_request_accepted":     `{{.Payload.target_name}} accepted your follow request`,
+	"follow_request_rejected":     `{{.Payload.target_name}} declined your follow request`,
+	"group_invite_accepted":       `{{.Payload.invited_name}} joined {{.Payload.group_name}}`,
+	"group_invite_rejected":       `{{.Payload.invited_name}} declined your invitation to {{.Payload.group_name}}`,
+	"group_join_request_accepted": `You are now a member of {{.Payload.group_name}}`,
+	"group_join_request_rejected": `Your request to join {{.Payload.group_name}} was declined`,
+	"post_published":              `Your scheduled post was published`,
+	"post_shared":                 `{{actors .Payload.sharer_name .Count}} shared your post`,
+}
+
+const genericSummary = `You have a new notification`
+
+// details are optional quotes shown under the summary of immediate emails
+var details = map[string]string{
+	"post_reply":     `{{with .Payload.post_content}}On your post: "{{excerpt .}}"{{end}}`,
+	"mention":        `{{with .Payload.mention_text}}"{{excerpt .}}"{{end}}`,
+	"new_message":    `{{with .Payload.message_content}}"{{excerpt .}}"{{end}}`,
+	"post_published": `{{with .Payload.post_content}}"{{excerpt .}}"{{end}}`,
+	"post_shared":    `{{with .Payload.quote}}"{{excerpt .}}"{{end}}`,
+}
+
+const immediateBody = `{{.Summary}}
+{{with .Detail}}
+{{.}}
+{{end}}
+See it on {{.AppURL}}/notifications
+{{template "footer" .}}`
+
+const digestBody = `You have {{len .Lines}} unread notification{{if ne (len .Lines) 1}}s{{end}} from the last {{.Period}}:
+{{range .Shown}}
+- {{.Summary}} ({{.At.Format "Jan 2, 15:04"}}){{end}}{{with .Hidden}}
+- and {{.}} more{{end}}
+
+See them on {{.AppURL}}/notifications
+{{template "footer" .}}`
+
+const footer = `{{define "footer"}}
+--
+You get these emails because email notifications are on for your account.
+Unsubscribe: {{.UnsubscribeURL}}
+{{end}}`
+
+// maxDigestLines caps the notifications listed in a digest, the rest are counted
+const maxDigestLines = 50
+
+var funcs = template.FuncMap{
+	// actors names the first actor and counts the others of an aggregated notification
+	"actors": func(name string, count int32) string {
+		if name == "" {
+			name = "Someone"
+		}
+		switch {
+		case count <= 1:
+			return name
+		case count == 2:
+			return name + " and 1 other"
+		default:
+			return fmt.Sprintf("%s and %d others", name, count-1)
+		}
+	},
+	"excerpt": func(s string) string {
+		s = strings.Join(strings.Fields(s), " ")
+		if r := []rune(s); len(r) > 140 {
+			return string(r[:140]) + "…"
+		}
+		return s
+	},
+}
+
+// Renderer turns notifications into messages.
+type Renderer struct {
+	appURL    string
+	summaries map[string]*template.Template
+	details   map[string]*template.Template
+	generic   *template.Template
+	immediate *template.Template
+	digest    *template.Template
+}
+
+// NewRenderer parses the templates. appURL is the public address of the frontend, linked from every email.
+func NewRenderer(appURL string) (*Renderer, error) {
+	r := &Renderer{
+		appURL:    strings.TrimRight(appURL, "/"),
+		summaries: make(map[string]*template.Template, len(summaries)),
+		details:   make(map[string]*template.Template, len(details)),
+	}
+
+	parse := func(name, text string) (*template.Template, error) {
+		t, err := template.New(name).Funcs(funcs).Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse email template %s: %w", name, err)
+		}
+		return t, nil
+	}
+
+	var err error
+	for name, text := range summaries {
+		if r.summaries[name], err = parse("summary_"+name, text); err != nil {
+			return nil, err
+		}
+	}
+	for name, text := range details {
+		if r.details[name], err = parse("detail_"+name, text); err != nil {
+			return nil, err
+		}
+	}
+	if r.generic, err = parse("summary", genericSummary); err != nil {
+		return nil, err
+	}
+	if r.immediate, err = parse("immediate", immediateBody+footer); err != nil {
+		return nil, err
+	}
+	if r.digest, err = parse("digest", digestBody+footer); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func execute(t *template.Template, data any) (string, error) {
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render email template %s: %w", t.Name(), err)
+	}
+	return b.String(), nil
+}
+
+func (r *Renderer) summary(n Notification) (string, error) {
+	t, ok := r.summaries[n.Type]
+	if !ok {
+		t = r.generic
+	}
+	return execute(t, n)
+}
+
+// Immediate renders the email for a single notification.
+func (r *Renderer) Immediate(to string, n Notification, unsubscribeURL string) (Message, error) {
+	summary, err := r.summary(n)
+	if err != nil {
+		return Message{}, err
+	}
+	var detail string
+	if t, ok := r.details[n.Type]; ok {
+		if detail, err = execute(t, n); err != nil {
+			return Message{}, err
+		}
+	}
+
+	body, err := execute(r.immediate, map[string]any{
+		"Summary":        summary,
+		"Detail":         detail,
+		"AppURL":         r.appURL,
+		"UnsubscribeURL": unsubscribeURL,
+	})
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{
+		To:      to,
+		Subject: summary,
+		Body:    body,
+		Headers: unsubscribeHeaders(unsubscribeURL),
+	}, nil
+}
+
+type digestLine struct {
+	Summary string
+	At      time.Time
+}
+
+// Digest renders the email listing the unread notifications of a period, newest first.
+// period is how the period reads in a sentence, like "day" or "week".
+func (r *Renderer) Digest(to, period string, ns []Notification, unsubscribeURL string) (Message, error) {
+	lines := make([]digestLine, 0, len(ns))
+	for _, n := range ns {
+		summary, err := r.summary(n)
+		if err != nil {
+			return Message{}, err
+		}
+		lines = append(lines, digestLine{Summary: summary, At: n.CreatedAt})
+	}
+
+	shown := lines[:min(len(lines), maxDigestLines)]
+	body, err := execute(r.digest, map[string]any{
+		"Lines":          lines,
+		"Shown":          shown,
+		"Hidden":         len(lines) - len(shown),
+		"Period":         period,
+		"AppURL":         r.appURL,
+		"UnsubscribeURL": unsubscribeURL,
+	})
+	if err != nil {
+		return Message{}, err
+	}
+
+	subject := fmt.Sprintf("You have %d unread notification", len(lines))
+	if len(lines) != 1 {
+		subject += "s"
+	}
+	return Message{
+		To:      to,
+		Subject: subject,
+		Body:    body,
+		Headers: unsubscribeHeaders(unsubscribeURL),
+	}, nil
+}
+
+// unsubscribeHeaders let mail clients offer one click unsubscribe (RFC 8058)
+func unsubscribeHeaders(url string) map[string]string {
+	return map[string]string{
+		"List-Unsubscribe":      "<" + url + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+}
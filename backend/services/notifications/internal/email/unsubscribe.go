@@ -0,0 +1,41 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrBadUnsubscribeToken = errors.New("bad unsubscribe token")
+
+// UnsubscribeToken returns a token identifying userID, signed with secret, for unsubscribe links
+// that work without logging in.
+func UnsubscribeToken(secret []byte, userID int64) string {
+	id := strconv.FormatInt(userID, 10)
+	return id + "." + sign(secret, id)
+}
+
+// ParseUnsubscribeToken returns the user a token was made for.
+func ParseUnsubscribeToken(secret []byte, token string) (int64, error) {
+	id, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, ErrBadUnsubscribeToken
+	}
+	if !hmac.Equal([]byte(sig), []byte(sign(secret, id))) {
+		return 0, ErrBadUnsubscribeToken
+	}
+	userID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, ErrBadUnsubscribeToken
+	}
+	return userID, nil
+}
+
+func sign(secret []byte, id string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("unsubscribe:" + id))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -29,6 +29,10 @@ func (h *EventHandler) Handle(ctx context.Context, event *pb.NotificationEvent)
 		return h.handlePostCommentCreated(ctx, payload.PostCommentCreated)
 	case *pb.NotificationEvent_PostLiked:
 		return h.handlePostLiked(ctx, payload.PostLiked)
+	case *pb.NotificationEvent_PostPublished:
+		return h.handlePostPublished(ctx, payload.PostPublished)
+	case *pb.NotificationEvent_PostShared:
+		return h.handlePostShared(ctx, payload.PostShared)
 	case *pb.NotificationEvent_FollowRequestCreated:
 		return h.handleFollowRequestCreated(ctx, payload.FollowRequestCreated)
 	case *pb.NotificationEvent_NewFollowerCreated:
@@ -88,6 +92,27 @@ func (h *EventHandler) handlePostLiked(ctx context.Context, event *pb.PostLiked)
 	)
 }
 
+func (h *EventHandler) handlePostPublished(ctx context.Context, event *pb.PostPublished) error {
+	return h.App.CreatePostPublishedNotification(
+		ctx,
+		event.PostCreatorId, // userId (post owner)
+		event.PostId,        // postId
+		event.Body,          // postContent
+	)
+}
+
+func (h *EventHandler) handlePostShared(ctx context.Context, event *pb.PostShared) error {
+	return h.App.CreatePostShareNotification(
+		ctx,
+		event.PostCreatorId,  // userId (original post owner)
+		event.SharerUserId,   // sharerId
+		event.PostId,         // postId (original)
+		event.ShareId,        // shareId
+		event.SharerUsername, // sharerUsername
+		event.Body,           // quote
+	)
+}
+
 func (h *EventHandler) handleFollowRequestCreated(ctx context.Context, event *pb.FollowRequestCreated) error {
 	return h.App.CreateFollowRequestNotification(
 		ctx,
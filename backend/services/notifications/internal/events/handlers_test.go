@@ -26,6 +26,16 @@ func (m *MockApplication) CreatePostLikeNotification(ctx context.Context, userID
 	return args.Error(0)
 }
 
+func (m *MockApplication) CreatePostPublishedNotification(ctx context.Context, userID, postID int64, postContent string) error {
+	args := m.Called(ctx, userID, postID, postContent)
+	return args.Error(0)
+}
+
+func (m *MockApplication) CreatePostShareNotification(ctx context.Context, userID, sharerID, postID, shareID int64, sharerUsername, quote string) error {
+	args := m.Called(ctx, userID, sharerID, postID, shareID, sharerUsername, quote)
+	return args.Error(0)
+}
+
 func (m *MockApplication) CreateFollowRequestNotification(ctx context.Context, targetUserID, requesterUserID int64, requesterUsername string) error {
 	args := m.Called(ctx, targetUserID, requesterUserID, requesterUsername)
 	return args.Error(0)
@@ -255,6 +265,76 @@ func TestEventHandler_HandlePostLiked(t *testing.T) {
 	mockApp.AssertExpectations(t)
 }
 
+func TestEventHandler_HandlePostPublished(t *testing.T) {
+	mockApp := new(MockApplication)
+	eventHandler := &EventHandler{App: mockApp}
+
+	event := &pb.NotificationEvent{
+		EventId:   "test-event-id",
+		EventType: pb.EventType_POST_PUBLISHED,
+		Payload: &pb.NotificationEvent_PostPublished{
+			PostPublished: &pb.PostPublished{
+				PostCreatorId: 123,
+				PostId:        456,
+				Body:          "scheduled post",
+			},
+		},
+	}
+
+	// Set up expectations
+	mockApp.On("CreatePostPublishedNotification",
+		mock.Anything,
+		int64(123),       // userID (post owner)
+		int64(456),       // postID
+		"scheduled post", // postContent
+	).Return(nil)
+
+	// Execute
+	err := eventHandler.Handle(context.Background(), event)
+
+	// Assert
+	assert.NoError(t, err)
+	mockApp.AssertExpectations(t)
+}
+
+func TestEventHandler_HandlePostShared(t *testing.T) {
+	mockApp := new(MockApplication)
+	eventHandler := &EventHandler{App: mockApp}
+
+	event := &pb.NotificationEvent{
+		EventId:   "test-event-id",
+		EventType: pb.EventType_POST_SHARED,
+		Payload: &pb.NotificationEvent_PostShared{
+			PostShared: &pb.PostShared{
+				PostCreatorId:  123,
+				PostId:         456,
+				ShareId:        789,
+				SharerUserId:   321,
+				SharerUsername: "sharer",
+				Body:           "look at this",
+			},
+		},
+	}
+
+	// Set up expectations
+	mockApp.On("CreatePostShareNotification",
+		mock.Anything,
+		int64(123),     // userID (original post owner)
+		int64(321),     // sharerID
+		int64(456),     // postID
+		int64(789),     // shareID
+		"sharer",       // sharerUsername
+		"look at this", // quote
+	).Return(nil)
+
+	// Execute
+	err := eventHandler.Handle(context.Background(), event)
+
+	// Assert
+	assert.NoError(t, err)
+	mockApp.AssertExpectations(t)
+}
+
 func TestEventHandler_HandleFollowRequestCreated(t *testing.T) {
 	mockApp := new(MockApplication)
 	eventHandler := &EventHandler{App: mockApp}
@@ -15,6 +15,8 @@ type EventProcessor interface {
 type ApplicationService interface {
 	CreatePostCommentNotification(ctx context.Context, userID, commenterID, postID int64, commenterUsername, commentContent string, aggregate bool) error
 	CreatePostLikeNotification(ctx context.Context, userID, likerID, postID int64, likerUsername string, aggregate bool) error
+	CreatePostPublishedNotification(ctx context.Context, userID, postID int64, postContent string) error
+	CreatePostShareNotification(ctx context.Context, userID, sharerID, postID, shareID int64, sharerUsername, quote string) error
 	CreateFollowRequestNotification(ctx context.Context, targetUserID, requesterUserID int64, requesterUsername string) error
 	CreateNewFollowerNotification(ctx context.Context, targetUserID, followerUserID int64, followerUsername string, aggregate bool) error
 	CreateGroupInviteNotification(ctx context.Context, invitedUserID, inviterUserID, groupID int64, groupName, inviterUsername string) error
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -11,6 +13,7 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"social-network/services/notifications/internal/application"
+	"social-network/shared/gen-go/common"
 	pb "social-network/shared/gen-go/notifications"
 	"social-network/shared/go/ct"
 )
@@ -79,6 +82,10 @@ func (s *Server) CreateNotifications(ctx context.Context, req *pb.CreateNotifica
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to create notification: %v", err)
 		}
+		if notification == nil {
+			// suppressed by the recipient's preferences
+			continue
+		}
 
 		createdNotifications = append(createdNotifications, notification)
 	}
@@ -103,15 +110,38 @@ func (s *Server) GetUserNotifications(ctx context.Context, req *pb.GetUserNotifi
 		req.Limit = 20 // default limit
 	}
 
-	notifications, err := s.Application.GetUserNotifications(ctx, req.UserId, req.Limit, req.Offset)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get user notifications: %v", err)
-	}
+	var pbNotifications []*pb.Notification
+	var pbGroups []*pb.NotificationGroup
+	var nextCursor ct.Cursor
+	if req.Grouped {
+		groups, next, err := s.Application.GetUserNotificationGroups(ctx, req.UserId, application.GroupsQuery{
+			Limit:      req.Limit,
+			Cursor:     ct.Cursor(req.Cursor),
+			Categories: req.Categories,
+			UnreadOnly: req.UnreadOnly,
+		})
+		if errors.Is(err, application.ErrInvalidCursor) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get notification groups: %v", err)
+		}
+		pbGroups = make([]*pb.NotificationGroup, len(groups))
+		for i, g := range groups {
+			pbGroups[i] = convertToProtoNotificationGroup(g)
+		}
+		nextCursor = next
+	} else {
+		notifications, err := s.Application.GetUserNotifications(ctx, req.UserId, req.Limit, req.Offset)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get user notifications: %v", err)
+		}
 
-	// Convert to protobuf notifications
-	pbNotifications := make([]*pb.Notification, len(notifications))
-	for i, n := range notifications {
-		pbNotifications[i] = s.convertToProtoNotification(n)
+		// Convert to protobuf notifications
+		pbNotifications = make([]*pb.Notification, len(notifications))
+		for i, n := range notifications {
+			pbNotifications[i] = s.convertToProtoNotification(n)
+		}
 	}
 
 	// Get total count
@@ -130,6 +160,8 @@ func (s *Server) GetUserNotifications(ctx context.Context, req *pb.GetUserNotifi
 		Notifications: pbNotifications,
 		TotalCount:    int32(totalCount),
 		UnreadCount:   int32(unreadCount),
+		Groups:        pbGroups,
+		NextCursor:    nextCursor.String(),
 	}, nil
 }
 
@@ -191,6 +223,34 @@ func (s *Server) MarkAllAsRead(ctx context.Context, req *wrapperspb.Int64Value)
 	return &emptypb.Empty{}, nil
 }
 
+// MarkReadByEntity marks the notifications of a user about an entity as read
+func (s *Server) MarkReadByEntity(ctx context.Context, req *pb.MarkReadByEntityRequest) (*emptypb.Empty, error) {
+	if req.UserId == 0 || req.SourceService == "" || req.SourceEntityId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id, source_service and source_entity_id are required")
+	}
+
+	_, err := s.Application.MarkReadByEntity(ctx, req.UserId, req.SourceService, req.SourceEntityId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to mark notifications as read: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// MarkReadByCategory marks the notifications of a user in a category as read
+func (s *Server) MarkReadByCategory(ctx context.Context, req *pb.MarkReadByCategoryRequest) (*emptypb.Empty, error) {
+	if req.UserId == 0 || req.Category == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and category are required")
+	}
+
+	_, err := s.Application.MarkReadByCategory(ctx, req.UserId, req.Category)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to mark notifications as read: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
 // DeleteNotification deletes a notification -0
 func (s *Server) DeleteNotification(ctx context.Context, req *pb.DeleteNotificationRequest) (*emptypb.Empty, error) {
 	if req.NotificationId == 0 || req.UserId == 0 {
@@ -707,30 +767,173 @@ func (s *Server) CreateGroupJoinRequestRejected(ctx context.Context, req *pb.Cre
 	return notification, nil
 }
 
-// GetNotificationPreferences returns notification preferences for a user -0 not yet
+// GetNotificationPreferences returns the effective notification preferences of a user
 func (s *Server) GetNotificationPreferences(ctx context.Context, req *wrapperspb.Int64Value) (*pb.NotificationPreferences, error) {
-	// For now, return default preferences
-	// In a real implementation, this would fetch from a user preferences table
-	defaultPrefs := make(map[string]bool)
-	for _, notifType := range pb.NotificationType_name {
-		defaultPrefs[notifType] = true
+	if req.Value == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	prefs, err := s.Application.GetNotificationPreferences(ctx, req.Value)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get notification preferences: %v", err)
+	}
+
+	mutes := make([]*pb.NotificationMute, 0, len(prefs.Mutes))
+	for _, m := range prefs.Mutes {
+		mute := &pb.NotificationMute{
+			TargetType: string(m.TargetType),
+			TargetId:   m.TargetID,
+		}
+		if m.MutedUntil != nil {
+			mute.MutedUntil = timestamppb.New(*m.MutedUntil)
+		}
+		mutes = append(mutes, mute)
 	}
 
 	return &pb.NotificationPreferences{
-		UserId:      req.Value,
-		Preferences: defaultPrefs,
+		UserId:      prefs.UserID,
+		Preferences: prefs.Types,
+		Categories:  prefs.Categories,
+		Mutes:       mutes,
+		EmailMode:   string(prefs.EmailMode),
 	}, nil
 }
 
-// UpdateNotificationPreferences updates notification preferences for a user
+// UpdateNotificationPreferences enables or disables notification types and categories for a user
 func (s *Server) UpdateNotificationPreferences(ctx context.Context, req *pb.UpdateNotificationPreferencesRequest) (*emptypb.Empty, error) {
-	// For now, just return success
-	// In a real implementation, this would update a user preferences table
+	if req.UserId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	err := s.Application.UpdateNotificationPreferences(ctx, req.UserId, req.Preferences, req.Categories, application.EmailMode(req.EmailMode))
+	if errors.Is(err, application.ErrInvalidPreference) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update notification preferences: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// MuteNotifications silences notifications about a group, conversation or user
+func (s *Server) MuteNotifications(ctx context.Context, req *pb.MuteNotificationsRequest) (*emptypb.Empty, error) {
+	if req.UserId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	var mutedUntil *time.Time
+	if req.MutedUntil != nil {
+		t := req.MutedUntil.AsTime()
+		mutedUntil = &t
+	}
+
+	err := s.Application.MuteNotifications(ctx, req.UserId, application.MuteTarget(req.TargetType), req.TargetId, mutedUntil)
+	if errors.Is(err, application.ErrInvalidPreference) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to mute notifications: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// UnmuteNotifications removes a mute
+func (s *Server) UnmuteNotifications(ctx context.Context, req *pb.UnmuteNotificationsRequest) (*emptypb.Empty, error) {
+	if req.UserId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	err := s.Application.UnmuteNotifications(ctx, req.UserId, application.MuteTarget(req.TargetType), req.TargetId)
+	if errors.Is(err, application.ErrInvalidPreference) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmute notifications: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// UnsubscribeEmail turns email off for the user of an unsubscribe link
+func (s *Server) UnsubscribeEmail(ctx context.Context, req *pb.UnsubscribeEmailRequest) (*emptypb.Empty, error) {
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	err := s.Application.UnsubscribeEmail(ctx, req.Token)
+	if errors.Is(err, application.ErrInvalidPreference) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unsubscribe: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetWebPushPublicKey returns the VAPID public key browsers subscribe with
+func (s *Server) GetWebPushPublicKey(ctx context.Context, req *emptypb.Empty) (*wrapperspb.StringValue, error) {
+	key, err := s.Application.PushPublicKey()
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return wrapperspb.String(key), nil
+}
+
+// RegisterPushSubscription stores a browser push subscription
+func (s *Server) RegisterPushSubscription(ctx context.Context, req *pb.RegisterPushSubscriptionRequest) (*emptypb.Empty, error) {
+	if req.UserId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	err := s.Application.RegisterPushSubscription(ctx, req.UserId, application.PushSubscription{
+		Endpoint:  req.Endpoint,
+		P256dh:    req.P256Dh,
+		Auth:      req.Auth,
+		UserAgent: req.UserAgent,
+	})
+	if errors.Is(err, application.ErrInvalidPushSubscription) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, application.ErrPushUnavailable) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to register push subscription: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// UnregisterPushSubscription removes a browser push subscription
+func (s *Server) UnregisterPushSubscription(ctx context.Context, req *pb.UnregisterPushSubscriptionRequest) (*emptypb.Empty, error) {
+	if req.UserId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.Endpoint == "" {
+		return nil, status.Error(codes.InvalidArgument, "endpoint is required")
+	}
+
+	err := s.Application.UnregisterPushSubscription(ctx, req.UserId, req.Endpoint)
+	if errors.Is(err, application.ErrPushUnavailable) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unregister push subscription: %v", err)
+	}
+
 	return &emptypb.Empty{}, nil
 }
 
 // convertToProtoNotification converts our internal notification model to protobuf format
 func (s *Server) convertToProtoNotification(notification *application.Notification) *pb.Notification {
+	if notification == nil {
+		// suppressed by the recipient's preferences
+		return &pb.Notification{}
+	}
+
 	// Convert map[string]string to map[string]string (which protobuf handles as map<string, string>)
 	payload := make(map[string]string)
 	for k, v := range notification.Payload {
@@ -836,6 +1039,10 @@ func convertApplicationNotificationTypeToProto(appType application.NotificationT
 		return pb.NotificationType_NOTIFICATION_TYPE_GROUP_JOIN_REQUEST_ACCEPTED
 	case application.GroupJoinRequestRejected:
 		return pb.NotificationType_NOTIFICATION_TYPE_GROUP_JOIN_REQUEST_REJECTED
+	case application.PostPublished:
+		return pb.NotificationType_NOTIFICATION_TYPE_POST_PUBLISHED
+	case application.PostShared:
+		return pb.NotificationType_NOTIFICATION_TYPE_POST_SHARED
 	default:
 		return pb.NotificationType_NOTIFICATION_TYPE_UNSPECIFIED
 	}
@@ -872,7 +1079,43 @@ func convertProtoNotificationTypeToApplication(protoType pb.NotificationType) ap
 		return application.GroupJoinRequestAccepted
 	case pb.NotificationType_NOTIFICATION_TYPE_GROUP_JOIN_REQUEST_REJECTED:
 		return application.GroupJoinRequestRejected
+	case pb.NotificationType_NOTIFICATION_TYPE_POST_PUBLISHED:
+		return application.PostPublished
+	case pb.NotificationType_NOTIFICATION_TYPE_POST_SHARED:
+		return application.PostShared
 	default:
 		return application.NotificationType("")
 	}
 }
+
+func convertToProtoNotificationGroup(group *application.NotificationGroup) *pb.NotificationGroup {
+	ids := make([]int64, len(group.NotificationIDs))
+	for i, id := range group.NotificationIDs {
+		ids[i] = id.Int64()
+	}
+	actors := make([]*common.User, len(group.Actors))
+	for i, actor := range group.Actors {
+		actors[i] = &common.User{
+			UserId:    actor.UserID.Int64(),
+			Username:  actor.Username,
+			AvatarUrl: actor.AvatarURL,
+		}
+	}
+
+	return &pb.NotificationGroup{
+		Type:            string(group.Type),
+		SourceService:   group.SourceService,
+		SourceEntityId:  group.SourceEntityID.Int64(),
+		NotificationIds: ids,
+		Count:           group.Count,
+		Actors:          actors,
+		OtherActors:     group.OtherActors,
+		ActorsSummary:   group.ActorsSummary,
+		Seen:            group.Seen,
+		NeedsAction:     group.NeedsAction,
+		Acted:           group.Acted,
+		Payload:         group.Payload,
+		LatestAt:        timestamppb.New(group.LatestAt),
+		EarliestAt:      timestamppb.New(group.EarliestAt),
+	}
+}
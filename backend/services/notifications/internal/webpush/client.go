@@ -0,0 +1,131 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ErrSubscriptionGone means the browser unsubscribed or the subscription expired, it should be deleted.
+var ErrSubscriptionGone = errors.New("push subscription is gone")
+
+var ErrBadSubscription = errors.New("bad push subscription")
+
+// Subscription is where and how to reach one browser, as given by PushSubscription.toJSON().
+type Subscription struct {
+	Endpoint string
+	P256dh   string // base64url browser public key
+	Auth     string // base64url auth secret
+}
+
+// Validate checks the subscription can be pushed to. Endpoints must be https,
+// the server posts to them on the user's behalf.
+func (s Subscription) Validate() error {
+	u, err := url.Parse(s.Endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("%w: endpoint must be an https url", ErrBadSubscription)
+	}
+	_, _, err = s.keys()
+	return err
+}
+
+func (s Subscription) keys() (uaPublic, authSecret []byte, err error) {
+	uaPublic, err = decodeKey(s.P256dh)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: bad p256dh key", ErrBadSubscription)
+	}
+	if _, err := ecdh.P256().NewPublicKey(uaPublic); err != nil {
+		return nil, nil, fmt.Errorf("%w: bad p256dh key", ErrBadSubscription)
+	}
+	authSecret, err = decodeKey(s.Auth)
+	if err != nil || len(authSecret) != 16 {
+		return nil, nil, fmt.Errorf("%w: bad auth secret", ErrBadSubscription)
+	}
+	return uaPublic, authSecret, nil
+}
+
+// Urgency tells push services how soon to wake the device (RFC 8030 section 5.3).
+type Urgency string
+
+const (
+	UrgencyLow    Urgency = "low"
+	UrgencyNormal Urgency = "normal"
+	UrgencyHigh   Urgency = "high"
+)
+
+// Client sends push messages. Safe for concurrent use.
+type Client struct {
+	keys       *VAPIDKeys
+	subject    string
+	httpClient *http.Client
+	ttl        time.Duration
+}
+
+// NewClient returns a client signing with keys. subject is a mailto: or https: contact for push service operators.
+// ttl is how long push services keep messages for offline devices. A nil httpClient uses a default one.
+func NewClient(keys *VAPIDKeys, subject string, ttl time.Duration, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Client{keys: keys, subject: subject, httpClient: httpClient, ttl: ttl}
+}
+
+// PublicKey is the applicationServerKey browsers subscribe with.
+func (c *Client) PublicKey() string {
+	return c.keys.PublicKey()
+}
+
+// Send encrypts payload for sub and hands it to its push service.
+func (c *Client) Send(ctx context.Context, sub Subscription, payload []byte, urgency Urgency) error {
+	if err := sub.Validate(); err != nil {
+		return err
+	}
+	uaPublic, authSecret, err := sub.keys()
+	if err != nil {
+		return err
+	}
+
+	body, err := encrypt(payload, uaPublic, authSecret)
+	if err != nil {
+		return err
+	}
+	authorization, err := c.keys.authorization(sub.Endpoint, c.subject, time.Now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(c.ttl.Seconds())))
+	if urgency != "" {
+		req.Header.Set("Urgency", string(urgency))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach push service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionGone
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	default:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("push service answered %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+}
@@ -0,0 +1,105 @@
+// Package webpush sends Web Push messages (RFC 8030), encrypted for the browser (RFC 8291)
+// and signed by the application server with VAPID (RFC 8292).
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// vapidTokenTTL is how long a VAPID token is valid, push services reject more than 24 hours
+const vapidTokenTTL = 12 * time.Hour
+
+// VAPIDKeys identify this server to push services. Browsers subscribe with the public key,
+// and push services only accept messages signed with the matching private key.
+type VAPIDKeys struct {
+	private *ecdsa.PrivateKey
+	public  []byte // uncompressed P-256 point
+}
+
+// GenerateVAPIDKeys returns a new key pair, base64url encoded.
+func GenerateVAPIDKeys() (privateKey, publicKey string, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	priv, err := key.Bytes()
+	if err != nil {
+		return "", "", err
+	}
+	pub, err := key.PublicKey.Bytes()
+	if err != nil {
+		return "", "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(priv), base64.RawURLEncoding.EncodeToString(pub), nil
+}
+
+// ParseVAPIDKeys reads a base64url encoded private key, as made by GenerateVAPIDKeys.
+func ParseVAPIDKeys(privateKey string) (*VAPIDKeys, error) {
+	raw, err := decodeKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("bad vapid private key: %w", err)
+	}
+	key, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+	if err != nil {
+		return nil, fmt.Errorf("bad vapid private key: %w", err)
+	}
+	pub, err := key.PublicKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return &VAPIDKeys{private: key, public: pub}, nil
+}
+
+// PublicKey is the base64url encoded public key, the applicationServerKey browsers subscribe with.
+func (k *VAPIDKeys) PublicKey() string {
+	return base64.RawURLEncoding.EncodeToString(k.public)
+}
+
+// authorization returns the Authorization header for a push to endpoint.
+// subject is a mailto: or https: contact for the push service operator.
+func (k *VAPIDKeys) authorization(endpoint, subject string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("bad push endpoint: %w", err)
+	}
+
+	header, _ := json.Marshal(map[string]string{"typ": "JWT", "alg": "ES256"})
+	claims, err := json.Marshal(map[string]any{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(vapidTokenTTL).Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	r, s, err := ecdsa.Sign(rand.Reader, k.private, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign vapid token: %w", err)
+	}
+	// JWS wants the raw r || s, not ASN.1
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	token := unsigned + "." + base64.RawURLEncoding.EncodeToString(sig)
+	return "vapid t=" + token + ", k=" + k.PublicKey(), nil
+}
+
+// decodeKey decodes base64url keys, with or without padding. Some clients send standard base64.
+func decodeKey(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	return base64.RawURLEncoding.DecodeString(s)
+}
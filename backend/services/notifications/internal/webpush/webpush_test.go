@@ -0,0 +1,187 @@
+package webpush
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBrowser holds the keys a browser makes when subscribing
+type fakeBrowser struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+}
+
+func newFakeBrowser(t *testing.T) *fakeBrowser {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	auth := make([]byte, 16)
+	_, err = rand.Read(auth)
+	require.NoError(t, err)
+	return &fakeBrowser{key: key, auth: auth}
+}
+
+func (b *fakeBrowser) subscription(endpoint string) Subscription {
+	return Subscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(b.key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(b.auth),
+	}
+}
+
+// decrypt does what the browser does with an aes128gcm body
+func (b *fakeBrowser) decrypt(t *testing.T, body []byte) []byte {
+	require.Greater(t, len(body), headerSize)
+	salt := body[:16]
+	assert.Equal(t, uint32(recordSize), binary.BigEndian.Uint32(body[16:20]))
+	require.Equal(t, byte(65), body[20])
+	asPublic := body[21:headerSize]
+
+	asKey, err := ecdh.P256().NewPublicKey(asPublic)
+	require.NoError(t, err)
+	secret, err := b.key.ECDH(asKey)
+	require.NoError(t, err)
+
+	cek, nonce, err := deriveKeys(secret, b.auth, b.key.PublicKey().Bytes(), asPublic, salt)
+	require.NoError(t, err)
+	block, err := aes.NewCipher(cek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	plaintext, err := gcm.Open(nil, nonce, body[headerSize:], nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x02), plaintext[len(plaintext)-1], "last record delimiter")
+	return plaintext[:len(plaintext)-1]
+}
+
+// verifyVAPID checks the Authorization header the way a push service does
+func verifyVAPID(t *testing.T, header, audience string) {
+	require.True(t, strings.HasPrefix(header, "vapid t="), header)
+	token, pub, ok := strings.Cut(strings.TrimPrefix(header, "vapid t="), ", k=")
+	require.True(t, ok, header)
+
+	rawPub, err := base64.RawURLEncoding.DecodeString(pub)
+	require.NoError(t, err)
+	key, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), rawPub)
+	require.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	require.Len(t, sig, 64)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.True(t, ecdsa.Verify(key, digest[:], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])), "vapid signature")
+
+	rawClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims map[string]any
+	require.NoError(t, json.Unmarshal(rawClaims, &claims))
+	assert.Equal(t, audience, claims["aud"])
+	assert.Equal(t, "mailto:ops@test.local", claims["sub"])
+}
+
+func newTestClient(t *testing.T, server *httptest.Server) *Client {
+	private, public, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	keys, err := ParseVAPIDKeys(private)
+	require.NoError(t, err)
+	assert.Equal(t, public, keys.PublicKey())
+	return NewClient(keys, "mailto:ops@test.local", time.Hour, server.Client())
+}
+
+func TestSend(t *testing.T) {
+	browser := newFakeBrowser(t)
+	var received []byte
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verifyVAPID(t, r.Header.Get("Authorization"), "https://"+r.Host)
+		assert.Equal(t, "aes128gcm", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "high", r.Header.Get("Urgency"))
+		assert.NotEmpty(t, r.Header.Get("TTL"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = browser.decrypt(t, body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.Send(context.Background(), browser.subscription(server.URL+"/push/abc"), []byte(`{"title":"hi"}`), UrgencyHigh)
+	require.NoError(t, err)
+	assert.Equal(t, `{"title":"hi"}`, string(received))
+}
+
+func TestSendGone(t *testing.T) {
+	browser := newFakeBrowser(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.Send(context.Background(), browser.subscription(server.URL), []byte("x"), UrgencyNormal)
+	assert.ErrorIs(t, err, ErrSubscriptionGone)
+}
+
+func TestSendRejected(t *testing.T) {
+	browser := newFakeBrowser(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad jwt", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.Send(context.Background(), browser.subscription(server.URL), []byte("x"), UrgencyNormal)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrSubscriptionGone)
+	assert.Contains(t, err.Error(), "403")
+}
+
+func TestSubscriptionValidate(t *testing.T) {
+	browser := newFakeBrowser(t)
+	valid := browser.subscription("https://push.example.com/abc")
+	assert.NoError(t, valid.Validate())
+
+	padded := valid
+	padded.P256dh = base64.URLEncoding.EncodeToString(browser.key.PublicKey().Bytes())
+	assert.NoError(t, padded.Validate())
+
+	plainHTTP := valid
+	plainHTTP.Endpoint = "http://push.example.com/abc"
+	assert.ErrorIs(t, plainHTTP.Validate(), ErrBadSubscription)
+
+	badKey := valid
+	badKey.P256dh = base64.RawURLEncoding.EncodeToString(make([]byte, 65))
+	assert.ErrorIs(t, badKey.Validate(), ErrBadSubscription)
+
+	badAuth := valid
+	badAuth.Auth = "AAAA"
+	assert.ErrorIs(t, badAuth.Validate(), ErrBadSubscription)
+}
+
+func TestPayloadTooLarge(t *testing.T) {
+	browser := newFakeBrowser(t)
+	_, err := encrypt(make([]byte, MaxPayload+1), browser.key.PublicKey().Bytes(), browser.auth)
+	assert.Error(t, err)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"social-network/services/posts/internal/entry"
+)
+
+// Rebuilds the cached personalized feed timelines, e.g. after enabling them or after a redis flush.
+// Timelines that are missing are also rebuilt on their owner's next feed request.
+//
+//	rebuild-timelines -all
+//	rebuild-timelines -users 1,2,3
+func main() {
+	all := flag.Bool("all", false, "rebuild the timelines of all users")
+	usersFlag := flag.String("users", "", "comma separated ids of the users whose timelines to rebuild")
+	flag.Parse()
+
+	var userIds []int64
+	for _, s := range strings.Split(*usersFlag, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid user id %q\n", s)
+			os.Exit(2)
+		}
+		userIds = append(userIds, id)
+	}
+
+	if !*all && len(userIds) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := entry.RebuildTimelines(userIds, *all); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
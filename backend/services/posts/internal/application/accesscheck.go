@@ -2,12 +2,38 @@ package application
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	ds "social-network/services/posts/internal/db/dbservice"
 	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	"social-network/shared/go/models"
 	tele "social-network/shared/go/telemetry"
 )
 
+// CanViewEntity tells whether requester can see a post, comment or event. Nobody can see a missing entity.
+// Used by the media service for the images attached to them.
+func (s *Application) CanViewEntity(ctx context.Context, req models.GenericReq) (bool, error) {
+	input := fmt.Sprintf("%#v", req)
+
+	if err := ct.ValidateStruct(req); err != nil {
+		return false, ce.Wrap(ce.ErrInvalidArgument, err, input).WithPublic("invalid data received")
+	}
+
+	canSee, err := s.hasRightToView(ctx, accessContext{
+		requesterId: req.RequesterId.Int64(),
+		entityId:    req.EntityId.Int64(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, ce.Wrap(nil, err, input)
+	}
+	return canSee, nil
+}
+
 // group and post audience=group: only members can see
 // post audience=everyone: everyone can see (can we check this before all the fetches from users?)
 // post audience=followers: requester can see if they follow creator
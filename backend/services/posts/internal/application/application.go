@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"social-network/services/posts/internal/client"
 	ds "social-network/services/posts/internal/db/dbservice"
+	"social-network/shared/gen-go/media"
 	ct "social-network/shared/go/ct"
 	"social-network/shared/go/kafgo"
 	"social-network/shared/go/models"
@@ -31,6 +32,9 @@ type Application struct {
 	userRetriever  UserRetriever
 	mediaRetriever *retrievemedia.MediaRetriever
 	eventProducer  *notifevents.EventCreator
+	timelines      TimelineCache
+	snapshots      RedisCache
+	ranking        RankingConfig
 }
 
 // UsersBatchClient abstracts the single RPC used by the hydrator to fetch basic user info.
@@ -51,20 +55,40 @@ type UserRetriever interface {
 	GetUser(ctx context.Context, userID ct.Id) (models.User, error)
 }
 
+// TimelineCache defines the Redis operations used by the fan-out timelines.
+type TimelineCache interface {
+	GetObj(ctx context.Context, key string, dest any) error
+	SetObj(ctx context.Context, key string, value any, exp time.Duration) error
+	SetStr(ctx context.Context, key string, value string, exp time.Duration) error
+	Exists(ctx context.Context, key string) (bool, error)
+	ZAddCapped(ctx context.Context, keys []string, score float64, member string, maxLen int64, exp time.Duration) error
+	ZRemFromMany(ctx context.Context, keys []string, member string) error
+	ZRem(ctx context.Context, key string, members ...string) error
+	ZRevRangeByScore(ctx context.Context, key string, max float64, count int64) ([]rds.ZEntry, error)
+	ZCard(ctx context.Context, key string) (int64, error)
+	ReplaceSortedSet(ctx context.Context, key string, entries []rds.ZEntry, exp time.Duration) error
+	SAdd(ctx context.Context, key string, members ...string) error
+	SMembers(ctx context.Context, key string) ([]string, error)
+}
+
 // ClientsInterface defines the methods that Application needs from clients.
 type ClientsInterface interface {
 	IsFollowing(ctx context.Context, userId, targetUserId int64) (bool, error)
 	IsGroupMember(ctx context.Context, userId, groupId int64) (bool, error)
 	GetFollowingIds(ctx context.Context, userId int64) ([]int64, error)
+	GetUserGroupIds(ctx context.Context, userId int64) ([]int64, error)
+	GetFollowerIds(ctx context.Context, userId int64, max int) (ids []int64, exceeded bool, err error)
 	CreateNewEvent(ctx context.Context, userId, groupId, eventId int64, groupName, eventTitle string) error
 	CreatePostLike(ctx context.Context, userId, likerUserId, postId int64, likerUsername string) error
 	CreatePostComment(ctx context.Context, userId, commenterId, postId int64, commenterUsername, commentContent string) error
+	MarkPostNotificationsRead(ctx context.Context, userId, postId int64) error
+	AttachImage(ctx context.Context, imageId, ownerId, entityId int64, entityType media.AttachmentType) error
 	GetGroupBasicInfo(ctx context.Context, groupId int64) (models.Group, error)
 	GetAllGroupMemberIds(ctx context.Context, groupId int64) ([]int64, error)
 }
 
 // NewApplication constructs a new Application with transaction support
-func NewApplication(db *ds.Queries, pool *pgxpool.Pool, clients *client.Clients, redisConnector *rds.RedisClient, eventProducer *kafgo.KafkaProducer, localCache *ristretto.Cache[ct.Id, *models.User]) (*Application, error) {
+func NewApplication(db *ds.Queries, pool *pgxpool.Pool, clients *client.Clients, redisConnector *rds.RedisClient, eventProducer *kafgo.KafkaProducer, localCache *ristretto.Cache[ct.Id, *models.User], ranking RankingConfig) (*Application, error) {
 	var txRunner TxRunner
 	var err error
 	if pool != nil {
@@ -83,6 +107,9 @@ func NewApplication(db *ds.Queries, pool *pgxpool.Pool, clients *client.Clients,
 		mediaRetriever: retrieveMedia,
 		userRetriever:  ur.NewUserRetriever(clients.UserClient, redisConnector, retrieveMedia, 3*time.Minute, localCache),
 		eventProducer:  notifevents.NewEventProducer(eventProducer),
+		timelines:      redisConnector,
+		snapshots:      redisConnector,
+		ranking:        ranking,
 	}, nil
 }
 
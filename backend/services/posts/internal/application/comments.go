@@ -53,6 +53,9 @@ func (s *Application) CreateComment(ctx context.Context, req models.CreateCommen
 			if err != nil {
 				return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
 			}
+			if err := s.clients.AttachImage(ctx, req.ImageId.Int64(), req.CreatorId.Int64(), commentId, media.AttachmentType_ATTACHMENT_COMMENT); err != nil {
+				return ce.DecodeProto(err, input)
+			}
 		}
 		return nil
 	})
@@ -121,7 +124,19 @@ func (s *Application) EditComment(ctx context.Context, req models.EditCommentReq
 	}
 
 	err = s.txRunner.RunTx(ctx, func(q *ds.Queries) error {
-		rowsAffected, err := q.EditComment(ctx, ds.EditCommentParams{
+		//keep the previous version in the edit history
+		rowsAffected, err := q.CreateCommentRevision(ctx, ds.CreateCommentRevisionParams{
+			CommentID: req.CommentId.Int64(),
+			EditorID:  req.CreatorId.Int64(),
+		})
+		if err != nil {
+			return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+		}
+		if rowsAffected != 1 {
+			return ce.New(ce.ErrNotFound, fmt.Errorf("comment %v not found or not owned by user %v", req.CommentId, req.CreatorId), input).WithPublic("not found")
+		}
+
+		rowsAffected, err = q.EditComment(ctx, ds.EditCommentParams{
 			CommentBody:      req.Body.String(),
 			ID:               req.CommentId.Int64(),
 			CommentCreatorID: req.CreatorId.Int64(),
@@ -141,6 +156,9 @@ func (s *Application) EditComment(ctx context.Context, req models.EditCommentReq
 			if err != nil {
 				return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
 			}
+			if err := s.clients.AttachImage(ctx, req.ImageId.Int64(), req.CreatorId.Int64(), req.CommentId.Int64(), media.AttachmentType_ATTACHMENT_COMMENT); err != nil {
+				return ce.DecodeProto(err, input)
+			}
 		}
 
 		if req.DeleteImage {
@@ -214,11 +232,18 @@ func (s *Application) GetCommentsByParentId(ctx context.Context, req models.Enti
 		return nil, ce.New(ce.ErrPermissionDenied, fmt.Errorf("user has no permission to view comments of entity %v", req.EntityId), input).WithPublic("permission denied")
 	}
 
+	pg, err := pageFrom(req.Cursor, req.Offset)
+	if err != nil {
+		return nil, ce.New(ce.ErrInvalidArgument, err, input).WithPublic("invalid cursor")
+	}
+
 	rows, err := s.db.GetCommentsByPostId(ctx, ds.GetCommentsByPostIdParams{
-		ParentID: req.EntityId.Int64(),
-		UserID:   req.RequesterId.Int64(),
-		Limit:    req.Limit.Int32(),
-		Offset:   req.Offset.Int32(),
+		ParentID:        req.EntityId.Int64(),
+		UserID:          req.RequesterId.Int64(),
+		Limit:           req.Limit.Int32(),
+		Offset:          pg.offset,
+		CursorCreatedAt: pg.createdAt,
+		CursorID:        pg.id,
 	})
 	if err != nil {
 		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
@@ -0,0 +1,220 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	ds "social-network/services/posts/internal/db/dbservice"
+	"social-network/shared/gen-go/media"
+	notifpb "social-network/shared/gen-go/notifications"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	"social-network/shared/go/models"
+	tele "social-network/shared/go/telemetry"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// max number of scheduled posts published in one worker run
+const publishBatchSize = 100
+
+var publishingPosts atomic.Bool
+
+// resolvePostStatus converts a requested status and publish time to their db values.
+// An empty status means the post is published right away.
+// A scheduled post needs a publish time in the future, the other statuses ignore it.
+func resolvePostStatus(status ct.PostStatus, publishAt ct.GenDateTime) (ds.PostStatus, pgtype.Timestamptz, error) {
+	if status == "" {
+		return ds.PostStatusPublished, pgtype.Timestamptz{}, nil
+	}
+
+	dbStatus := ds.PostStatus(strings.ToLower(status.String()))
+	if !dbStatus.Valid() {
+		return "", pgtype.Timestamptz{}, fmt.Errorf("invalid post status %q", status)
+	}
+
+	if dbStatus != ds.PostStatusScheduled {
+		return dbStatus, pgtype.Timestamptz{}, nil
+	}
+
+	if !publishAt.Time().After(time.Now()) {
+		return "", pgtype.Timestamptz{}, fmt.Errorf("publish time %v is not in the future", publishAt)
+	}
+	return dbStatus, pgtype.Timestamptz{Time: publishAt.Time(), Valid: true}, nil
+}
+
+// GetUserDrafts returns the requester's drafts and scheduled posts.
+func (s *Application) GetUserDrafts(ctx context.Context, req models.GenericPaginatedReq) ([]models.Post, error) {
+	input := fmt.Sprintf("%#v", req)
+
+	if err := ct.ValidateStruct(req); err != nil {
+		return nil, ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
+	}
+
+	rows, err := s.db.GetUserDrafts(ctx, ds.GetUserDraftsParams{
+		CreatorID: req.RequesterId.Int64(),
+		Offset:    req.Offset.Int32(),
+		Limit:     req.Limit.Int32(),
+	})
+	if err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	if len(rows) == 0 {
+		return []models.Post{}, nil
+	}
+
+	userIds := ct.Ids{req.RequesterId}
+	postImageIds := make(ct.Ids, 0, len(rows))
+	for _, r := range rows {
+		userIds = append(userIds, ct.FromInt64s(r.SelectedAudience)...)
+		if r.Image > 0 {
+			postImageIds = append(postImageIds, ct.Id(r.Image))
+		}
+	}
+
+	userMap, err := s.userRetriever.GetUsers(ctx, userIds.Unique())
+	if err != nil {
+		return nil, ce.Wrap(nil, err, input).WithPublic("error retrieving user's info")
+	}
+
+	var imageMap map[int64]string
+	if len(postImageIds) > 0 {
+		var failedImageIds []int64
+		imageMap, failedImageIds, err = s.mediaRetriever.GetImages(ctx, postImageIds, media.FileVariant_MEDIUM)
+		if err != nil {
+			tele.Error(ctx, "media retriever failed for @1", "request", postImageIds, "error", err.Error()) //log error instead of returning
+		} else {
+			s.removeFailedImagesAsync(ctx, failedImageIds)
+		}
+	}
+
+	posts := make([]models.Post, 0, len(rows))
+	for _, r := range rows {
+		selectedUsers := make([]models.User, 0, len(r.SelectedAudience))
+		for _, id := range r.SelectedAudience {
+			selectedUsers = append(selectedUsers, userMap[ct.Id(id)])
+		}
+
+		posts = append(posts, models.Post{
+			PostId:                ct.Id(r.ID),
+			Body:                  ct.PostBody(r.PostBody),
+			User:                  userMap[req.RequesterId],
+			GroupId:               ct.Id(r.GroupID),
+			Audience:              ct.Audience(r.Audience),
+			CreatedAt:             ct.GenDateTime(r.CreatedAt.Time),
+			UpdatedAt:             ct.GenDateTime(r.UpdatedAt.Time),
+			ImageId:               ct.Id(r.Image),
+			ImageUrl:              imageMap[r.Image],
+			SelectedAudienceUsers: selectedUsers,
+			Status:                ct.PostStatus(r.Status),
+			PublishAt:             ct.GenDateTime(r.PublishAt.Time),
+		})
+	}
+
+	if err := s.attachPolls(ctx, req.RequesterId.Int64(), posts); err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	return posts, nil
+}
+
+// UpdatePostStatus moves a draft or scheduled post of the requester to another status.
+// Publishing through this method does not send notification events,
+// as the only one notified would be the requester themselves.
+func (s *Application) UpdatePostStatus(ctx context.Context, req models.UpdatePostStatusReq) error {
+	input := fmt.Sprintf("%#v", req)
+
+	if err := ct.ValidateStruct(req); err != nil {
+		return ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
+	}
+
+	status, publishAt, err := resolvePostStatus(req.Status, req.PublishAt)
+	if err != nil {
+		return ce.New(ce.ErrInvalidArgument, err, input).WithPublic("scheduled posts need a publish time in the future")
+	}
+
+	rowsAffected, err := s.db.UpdatePostStatus(ctx, ds.UpdatePostStatusParams{
+		ID:        req.PostId.Int64(),
+		CreatorID: req.RequesterId.Int64(),
+		Status:    status,
+		PublishAt: publishAt,
+	})
+	if err != nil {
+		return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+	if rowsAffected != 1 {
+		return ce.New(ce.ErrNotFound, fmt.Errorf("unpublished post %v not found or not owned by user %v", req.PostId, req.RequesterId), input).WithPublic("not found")
+	}
+
+	if status == ds.PostStatusPublished {
+		s.syncPostTimelinesAsync(ctx, req.PostId.Int64())
+	}
+	return nil
+}
+
+// StartScheduledPostsWorker starts a background worker that periodically publishes scheduled posts that are due
+func (s *Application) StartScheduledPostsWorker(ctx context.Context, interval time.Duration) {
+	tele.Info(ctx, "Initiating scheduled posts worker. @1", "interval", interval.String())
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if !publishingPosts.CompareAndSwap(false, true) {
+					continue
+				}
+				if err := s.publishDuePosts(ctx); err != nil {
+					tele.Warn(ctx, "Error publishing scheduled posts. @1", "error", err.Error())
+				}
+			case <-ctx.Done():
+				tele.Info(ctx, "Scheduled posts worker stopped")
+				return
+			}
+		}
+	}()
+}
+
+// publishDuePosts publishes scheduled posts whose publish time has passed
+// and notifies their creators
+func (s *Application) publishDuePosts(ctx context.Context) error {
+	defer publishingPosts.Store(false)
+
+	for {
+		posts, err := s.db.PublishDuePosts(ctx, publishBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range posts {
+			tele.Info(ctx, "Published scheduled post. @1 @2", "postId", p.ID, "creatorId", p.CreatorID)
+
+			if p.Audience != ds.IntendedAudienceEveryone {
+				s.syncPostTimelinesAsync(ctx, p.ID)
+			}
+
+			event := &notifpb.NotificationEvent{
+				EventType: notifpb.EventType_POST_PUBLISHED,
+				Payload: &notifpb.NotificationEvent_PostPublished{
+					PostPublished: &notifpb.PostPublished{
+						PostCreatorId: p.CreatorID,
+						PostId:        p.ID,
+						Body:          p.PostBody,
+					},
+				},
+			}
+
+			if err := s.eventProducer.CreateAndSendNotificationEvent(ctx, event); err != nil {
+				tele.Error(ctx, "failed to send post published notification: @1", "error", err.Error())
+			}
+		}
+
+		if len(posts) < publishBatchSize {
+			return nil
+		}
+	}
+}
@@ -59,6 +59,9 @@ func (s *Application) CreateEvent(ctx context.Context, req models.CreateEventReq
 			if err != nil {
 				return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
 			}
+			if err := s.clients.AttachImage(ctx, req.ImageId.Int64(), req.CreatorId.Int64(), eventId, media.AttachmentType_ATTACHMENT_EVENT); err != nil {
+				return ce.DecodeProto(err, input)
+			}
 		}
 
 		return nil
@@ -163,7 +166,20 @@ func (s *Application) EditEvent(ctx context.Context, req models.EditEventReq) er
 			Time:  req.EventDate.Time(),
 			Valid: true,
 		}
-		rowsAffected, err := q.EditEvent(ctx, ds.EditEventParams{
+
+		//keep the previous version in the edit history
+		rowsAffected, err := q.CreateEventRevision(ctx, ds.CreateEventRevisionParams{
+			EventID:  req.EventId.Int64(),
+			EditorID: req.RequesterId.Int64(),
+		})
+		if err != nil {
+			return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+		}
+		if rowsAffected != 1 {
+			return ce.New(ce.ErrNotFound, fmt.Errorf("event %v not found or not owned by user %v", req.EventId, req.RequesterId), input).WithPublic("not found")
+		}
+
+		rowsAffected, err = q.EditEvent(ctx, ds.EditEventParams{
 			EventTitle:     req.Title.String(),
 			EventBody:      req.Body.String(),
 			EventDate:      eventDate,
@@ -185,6 +201,9 @@ func (s *Application) EditEvent(ctx context.Context, req models.EditEventReq) er
 			if err != nil {
 				return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
 			}
+			if err := s.clients.AttachImage(ctx, req.Image.Int64(), req.RequesterId.Int64(), req.EventId.Int64(), media.AttachmentType_ATTACHMENT_EVENT); err != nil {
+				return ce.DecodeProto(err, input)
+			}
 		}
 		if req.DeleteImage {
 			rowsAffected, err := q.DeleteImage(ctx, req.EventId.Int64())
@@ -20,16 +20,65 @@ func (s *Application) GetPersonalizedFeed(ctx context.Context, req models.GetPer
 		return nil, ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
 	}
 
-	idsRequesterFollows, err := s.clients.GetFollowingIds(ctx, req.RequesterId.Int64())
+	pg, err := pageFrom(req.Cursor, req.Offset)
+	if err != nil {
+		return nil, ce.New(ce.ErrInvalidArgument, err, input).WithPublic("invalid cursor")
+	}
+
+	// offset pages are always read from the database
+	if s.timelines != nil && pg.offset == 0 {
+		posts, ok, err := s.readTimeline(ctx, req.RequesterId.Int64(), req.Limit.Int32(), pg)
+		if err != nil {
+			tele.Warn(ctx, "failed to read timeline, falling back to database: @1", "error", err.Error())
+		}
+		if err == nil && ok {
+			return s.hydrateFeedPosts(ctx, req.RequesterId.Int64(), posts)
+		}
+	}
+
+	idsRequesterFollows, err := s.getFollowingIds(ctx, req.RequesterId.Int64())
 	if err != nil {
 		return nil, ce.DecodeProto(err, input)
 	}
 
 	rows, err := s.db.GetPersonalizedFeed(ctx, ds.GetPersonalizedFeedParams{
-		UserID:  req.RequesterId.Int64(),
-		Column2: idsRequesterFollows,
-		Offset:  req.Offset.Int32(),
-		Limit:   req.Limit.Int32(),
+		UserID:          req.RequesterId.Int64(),
+		Column2:         idsRequesterFollows,
+		Offset:          pg.offset,
+		Limit:           req.Limit.Int32(),
+		CursorCreatedAt: pg.createdAt,
+		CursorID:        pg.id,
+	})
+	if err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	posts := make([]models.Post, 0, len(rows))
+	for _, r := range rows {
+		posts = append(posts, personalizedRowToPost(r))
+	}
+
+	return s.hydrateFeedPosts(ctx, req.RequesterId.Int64(), posts)
+}
+
+func (s *Application) GetPublicFeed(ctx context.Context, req models.GenericPaginatedReq) ([]models.Post, error) {
+	input := fmt.Sprintf("%#v", req)
+
+	if err := ct.ValidateStruct(req); err != nil {
+		return nil, ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
+	}
+
+	pg, err := pageFrom(req.Cursor, req.Offset)
+	if err != nil {
+		return nil, ce.New(ce.ErrInvalidArgument, err, input).WithPublic("invalid cursor")
+	}
+
+	rows, err := s.db.GetPublicFeed(ctx, ds.GetPublicFeedParams{
+		UserID:          req.RequesterId.Int64(),
+		Offset:          pg.offset,
+		Limit:           req.Limit.Int32(),
+		CursorCreatedAt: pg.createdAt,
+		CursorID:        pg.id,
 	})
 	if err != nil {
 		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
@@ -48,7 +97,6 @@ func (s *Application) GetPersonalizedFeed(ctx context.Context, req models.GetPer
 		userIDs = append(userIDs, ct.Id(uid))
 
 		posts = append(posts, models.Post{
-
 			PostId: ct.Id(r.ID),
 			Body:   ct.PostBody(r.PostBody),
 			User: models.User{
@@ -56,13 +104,14 @@ func (s *Application) GetPersonalizedFeed(ctx context.Context, req models.GetPer
 			},
 			CommentsCount:   int(r.CommentsCount),
 			ReactionsCount:  int(r.ReactionsCount),
+			SharesCount:     int(r.SharesCount),
+			SharedPost:      sharedPostStub(r.SharedPostID),
 			LastCommentedAt: ct.GenDateTime(r.LastCommentedAt.Time),
 			CreatedAt:       ct.GenDateTime(r.CreatedAt.Time),
 			UpdatedAt:       ct.GenDateTime(r.UpdatedAt.Time),
 			LikedByUser:     r.LikedByUser,
 			ImageId:         ct.Id(r.Image),
 		})
-
 		if r.Image > 0 {
 			postImageIds = append(postImageIds, ct.Id(r.Image))
 		}
@@ -93,20 +142,49 @@ func (s *Application) GetPersonalizedFeed(ctx context.Context, req models.GetPer
 		s.removeFailedImagesAsync(ctx, failedImageIds)
 	}
 
+	if err := s.attachPolls(ctx, req.RequesterId.Int64(), posts); err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	if err := s.attachSharedPosts(ctx, req.RequesterId.Int64(), posts); err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
 	return posts, nil
 }
 
-func (s *Application) GetPublicFeed(ctx context.Context, req models.GenericPaginatedReq) ([]models.Post, error) {
+// GetHomeTimeline merges the posts of followed users that the requester may see, the requester's own posts
+// and the posts of the requester's groups into one feed, newest first.
+func (s *Application) GetHomeTimeline(ctx context.Context, req models.GenericPaginatedReq) ([]models.Post, error) {
 	input := fmt.Sprintf("%#v", req)
 
 	if err := ct.ValidateStruct(req); err != nil {
 		return nil, ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
 	}
 
-	rows, err := s.db.GetPublicFeed(ctx, ds.GetPublicFeedParams{
-		UserID: req.RequesterId.Int64(),
-		Offset: req.Offset.Int32(),
-		Limit:  req.Limit.Int32(),
+	pg, err := pageFrom(req.Cursor, req.Offset)
+	if err != nil {
+		return nil, ce.New(ce.ErrInvalidArgument, err, input).WithPublic("invalid cursor")
+	}
+
+	idsRequesterFollows, err := s.clients.GetFollowingIds(ctx, req.RequesterId.Int64())
+	if err != nil {
+		return nil, ce.DecodeProto(err, input)
+	}
+
+	groupIds, err := s.clients.GetUserGroupIds(ctx, req.RequesterId.Int64())
+	if err != nil {
+		return nil, ce.DecodeProto(err, input)
+	}
+
+	rows, err := s.db.GetHomeTimeline(ctx, ds.GetHomeTimelineParams{
+		UserID:          req.RequesterId.Int64(),
+		FollowingIds:    idsRequesterFollows,
+		GroupIds:        groupIds,
+		Offset:          pg.offset,
+		Limit:           req.Limit.Int32(),
+		CursorCreatedAt: pg.createdAt,
+		CursorID:        pg.id,
 	})
 	if err != nil {
 		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
@@ -121,51 +199,61 @@ func (s *Application) GetPublicFeed(ctx context.Context, req models.GenericPagin
 	postImageIds := make(ct.Ids, 0, len(rows))
 
 	for _, r := range rows {
-		uid := r.CreatorID
-		userIDs = append(userIDs, ct.Id(uid))
+		userIDs = append(userIDs, ct.Id(r.CreatorID))
 
 		posts = append(posts, models.Post{
 			PostId: ct.Id(r.ID),
 			Body:   ct.PostBody(r.PostBody),
 			User: models.User{
-				UserId: ct.Id(uid),
+				UserId: ct.Id(r.CreatorID),
 			},
+			GroupId:         ct.Id(r.GroupID.Int64),
+			Audience:        ct.Audience(r.Audience),
 			CommentsCount:   int(r.CommentsCount),
 			ReactionsCount:  int(r.ReactionsCount),
+			SharesCount:     int(r.SharesCount),
+			SharedPost:      sharedPostStub(r.SharedPostID),
 			LastCommentedAt: ct.GenDateTime(r.LastCommentedAt.Time),
 			CreatedAt:       ct.GenDateTime(r.CreatedAt.Time),
 			UpdatedAt:       ct.GenDateTime(r.UpdatedAt.Time),
 			LikedByUser:     r.LikedByUser,
 			ImageId:         ct.Id(r.Image),
 		})
+
 		if r.Image > 0 {
 			postImageIds = append(postImageIds, ct.Id(r.Image))
 		}
-
 	}
 
-	userMap, err := s.userRetriever.GetUsers(ctx, userIDs)
+	userMap, err := s.userRetriever.GetUsers(ctx, userIDs.Unique())
 	if err != nil {
 		return nil, ce.Wrap(nil, err, input).WithPublic("error retrieving user's info")
 	}
 
 	var imageMap map[int64]string
-	var failedImageIds []int64
 	if len(postImageIds) > 0 {
+		var failedImageIds []int64
 		imageMap, failedImageIds, err = s.mediaRetriever.GetImages(ctx, postImageIds, media.FileVariant_MEDIUM)
+		if err != nil {
+			tele.Error(ctx, "media retriever failed for @1", "request", postImageIds, "error", err.Error()) //log error instead of returning
+		} else {
+			s.removeFailedImagesAsync(ctx, failedImageIds)
+		}
 	}
-	if err != nil {
-		tele.Error(ctx, "media retriever failed for @1", "request", postImageIds, "error", err.Error()) //log error instead of returning
-	} else {
 
-		for i := range posts {
-			uid := posts[i].User.UserId
-			if u, ok := userMap[uid]; ok {
-				posts[i].User = u
-			}
-			posts[i].ImageUrl = imageMap[posts[i].ImageId.Int64()]
+	for i := range posts {
+		if u, ok := userMap[posts[i].User.UserId]; ok {
+			posts[i].User = u
 		}
-		s.removeFailedImagesAsync(ctx, failedImageIds)
+		posts[i].ImageUrl = imageMap[posts[i].ImageId.Int64()]
+	}
+
+	if err := s.attachPolls(ctx, req.RequesterId.Int64(), posts); err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	if err := s.attachSharedPosts(ctx, req.RequesterId.Int64(), posts); err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
 	}
 
 	return posts, nil
@@ -178,17 +266,24 @@ func (s *Application) GetUserPostsPaginated(ctx context.Context, req models.GetU
 		return nil, ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
 	}
 
+	pg, err := pageFrom(req.Cursor, req.Offset)
+	if err != nil {
+		return nil, ce.New(ce.ErrInvalidArgument, err, input).WithPublic("invalid cursor")
+	}
+
 	isFollowing, err := s.clients.IsFollowing(ctx, req.RequesterId.Int64(), int64(req.CreatorId))
 	if err != nil {
 		return nil, ce.DecodeProto(err, input)
 	}
 
 	rows, err := s.db.GetUserPostsPaginated(ctx, ds.GetUserPostsPaginatedParams{
-		CreatorID: req.CreatorId.Int64(),
-		UserID:    req.RequesterId.Int64(),
-		Column3:   isFollowing,
-		Limit:     req.Limit.Int32(),
-		Offset:    req.Offset.Int32(),
+		CreatorID:       req.CreatorId.Int64(),
+		UserID:          req.RequesterId.Int64(),
+		Column3:         isFollowing,
+		Limit:           req.Limit.Int32(),
+		Offset:          pg.offset,
+		CursorCreatedAt: pg.createdAt,
+		CursorID:        pg.id,
 	})
 	if err != nil {
 		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
@@ -214,6 +309,8 @@ func (s *Application) GetUserPostsPaginated(ctx context.Context, req models.GetU
 			},
 			CommentsCount:   int(r.CommentsCount),
 			ReactionsCount:  int(r.ReactionsCount),
+			SharesCount:     int(r.SharesCount),
+			SharedPost:      sharedPostStub(r.SharedPostID),
 			LastCommentedAt: ct.GenDateTime(r.LastCommentedAt.Time),
 			CreatedAt:       ct.GenDateTime(r.CreatedAt.Time),
 			UpdatedAt:       ct.GenDateTime(r.UpdatedAt.Time),
@@ -250,6 +347,14 @@ func (s *Application) GetUserPostsPaginated(ctx context.Context, req models.GetU
 		s.removeFailedImagesAsync(ctx, failedImageIds)
 	}
 
+	if err := s.attachPolls(ctx, req.RequesterId.Int64(), posts); err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	if err := s.attachSharedPosts(ctx, req.RequesterId.Int64(), posts); err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
 	return posts, nil
 }
 
@@ -268,6 +373,11 @@ func (s *Application) GetGroupPostsPaginated(ctx context.Context, req models.Get
 	}
 	groupId.Valid = true
 
+	pg, err := pageFrom(req.Cursor, req.Offset)
+	if err != nil {
+		return nil, ce.New(ce.ErrInvalidArgument, err, input).WithPublic("invalid cursor")
+	}
+
 	isMember, err := s.clients.IsGroupMember(ctx, req.RequesterId.Int64(), req.GroupId.Int64())
 	if err != nil {
 		return nil, ce.DecodeProto(err, input)
@@ -277,10 +387,12 @@ func (s *Application) GetGroupPostsPaginated(ctx context.Context, req models.Get
 	}
 
 	rows, err := s.db.GetGroupPostsPaginated(ctx, ds.GetGroupPostsPaginatedParams{
-		GroupID: groupId,
-		UserID:  req.RequesterId.Int64(),
-		Limit:   req.Limit.Int32(),
-		Offset:  req.Offset.Int32(),
+		GroupID:         groupId,
+		UserID:          req.RequesterId.Int64(),
+		Limit:           req.Limit.Int32(),
+		Offset:          pg.offset,
+		CursorCreatedAt: pg.createdAt,
+		CursorID:        pg.id,
 	})
 	if err != nil {
 		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
@@ -306,6 +418,8 @@ func (s *Application) GetGroupPostsPaginated(ctx context.Context, req models.Get
 			Audience:        ct.Audience(r.Audience),
 			CommentsCount:   int(r.CommentsCount),
 			ReactionsCount:  int(r.ReactionsCount),
+			SharesCount:     int(r.SharesCount),
+			SharedPost:      sharedPostStub(r.SharedPostID),
 			LastCommentedAt: ct.GenDateTime(r.LastCommentedAt.Time),
 			CreatedAt:       ct.GenDateTime(r.CreatedAt.Time),
 			UpdatedAt:       ct.GenDateTime(r.UpdatedAt.Time),
@@ -342,5 +456,13 @@ func (s *Application) GetGroupPostsPaginated(ctx context.Context, req models.Get
 		s.removeFailedImagesAsync(ctx, failedImageIds)
 	}
 
+	if err := s.attachPolls(ctx, req.RequesterId.Int64(), posts); err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	if err := s.attachSharedPosts(ctx, req.RequesterId.Int64(), posts); err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
 	return posts, nil
 }
@@ -0,0 +1,30 @@
+package application
+
+import (
+	ct "social-network/shared/go/ct"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// page holds the query arguments of one page of a (created_at, id) ordered list.
+type page struct {
+	createdAt pgtype.Timestamptz // null for the first page
+	id        int64
+	offset    int32
+}
+
+// pageFrom turns the cursor of a request into query arguments.
+// Offset is kept for clients that don't send cursors yet and is ignored when a cursor is given.
+func pageFrom(cursor ct.Cursor, offset ct.Offset) (page, error) {
+	if cursor == "" {
+		return page{offset: offset.Int32()}, nil
+	}
+	createdAt, id, err := cursor.Decode()
+	if err != nil {
+		return page{}, err
+	}
+	return page{
+		createdAt: pgtype.Timestamptz{Time: createdAt, Valid: true},
+		id:        id,
+	}, nil
+}
@@ -0,0 +1,204 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	ds "social-network/services/posts/internal/db/dbservice"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	"social-network/shared/go/models"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// validatePoll checks the poll part of a create post request.
+func validatePoll(req *models.CreatePollReq) error {
+	if err := ct.ValidateStruct(*req); err != nil {
+		return err
+	}
+	if req.ClosesAt.Time().IsZero() {
+		return nil
+	}
+	if !req.ClosesAt.Time().After(time.Now()) {
+		return fmt.Errorf("poll closing time %v is not in the future", req.ClosesAt)
+	}
+	return nil
+}
+
+// createPoll attaches a poll to a newly created post. Meant to run within the create post transaction.
+func createPoll(ctx context.Context, q *ds.Queries, postId int64, req *models.CreatePollReq) error {
+	var closesAt pgtype.Timestamptz
+	if !req.ClosesAt.Time().IsZero() {
+		closesAt = pgtype.Timestamptz{Time: req.ClosesAt.Time(), Valid: true}
+	}
+
+	pollId, err := q.CreatePoll(ctx, ds.CreatePollParams{
+		ParentID:       postId,
+		Question:       strings.TrimSpace(req.Question.String()),
+		MultipleChoice: req.MultipleChoice,
+		ClosesAt:       closesAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	options := make([]string, 0, len(req.Options))
+	for _, o := range req.Options {
+		options = append(options, strings.TrimSpace(o))
+	}
+	rowsAffected, err := q.InsertPollOptions(ctx, ds.InsertPollOptionsParams{
+		PollID:  pollId,
+		Options: options,
+	})
+	if err != nil {
+		return err
+	}
+	if rowsAffected != int64(len(options)) {
+		return fmt.Errorf("unexpected rows returned: expected %v, got %v", len(options), rowsAffected)
+	}
+	return nil
+}
+
+// attachPolls fills in the poll of every post that has one.
+// Vote counts are left out unless the requester has voted or the poll has closed.
+func (s *Application) attachPolls(ctx context.Context, requesterId int64, posts []models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIds := make([]int64, 0, len(posts))
+	for _, p := range posts {
+		postIds = append(postIds, p.PostId.Int64())
+	}
+
+	pollRows, err := s.db.GetPollsByParentIds(ctx, ds.GetPollsByParentIdsParams{
+		ParentIds: postIds,
+		UserID:    requesterId,
+	})
+	if err != nil {
+		return err
+	}
+	if len(pollRows) == 0 {
+		return nil
+	}
+
+	pollIds := make([]int64, 0, len(pollRows))
+	for _, r := range pollRows {
+		pollIds = append(pollIds, r.ID)
+	}
+
+	optionRows, err := s.db.GetPollOptions(ctx, pollIds)
+	if err != nil {
+		return err
+	}
+	optionsByPoll := make(map[int64][]ds.GetPollOptionsRow, len(pollRows))
+	for _, o := range optionRows {
+		optionsByPoll[o.PollID] = append(optionsByPoll[o.PollID], o)
+	}
+
+	pollsByPost := make(map[int64]*models.Poll, len(pollRows))
+	for _, r := range pollRows {
+		voted := make(map[int64]struct{}, len(r.VotedOptionIds))
+		for _, id := range r.VotedOptionIds {
+			voted[id] = struct{}{}
+		}
+		resultsVisible := r.Closed || len(voted) > 0
+
+		options := make([]models.PollOption, 0, len(optionsByPoll[r.ID]))
+		for _, o := range optionsByPoll[r.ID] {
+			_, votedByUser := voted[o.ID]
+			option := models.PollOption{
+				OptionId:    ct.Id(o.ID),
+				Text:        o.OptionText,
+				VotedByUser: votedByUser,
+			}
+			if resultsVisible {
+				option.VotesCount = int(o.VotesCount)
+			}
+			options = append(options, option)
+		}
+
+		pollsByPost[r.ParentID] = &models.Poll{
+			PollId:         ct.Id(r.ID),
+			Question:       ct.PollQuestion(r.Question),
+			Options:        options,
+			MultipleChoice: r.MultipleChoice,
+			ClosesAt:       ct.GenDateTime(r.ClosesAt.Time),
+			Closed:         r.Closed,
+			VotersCount:    int(r.VotersCount),
+			ResultsVisible: resultsVisible,
+		}
+	}
+
+	for i := range posts {
+		posts[i].Poll = pollsByPost[posts[i].PostId.Int64()]
+	}
+	return nil
+}
+
+// VotePoll casts the requester's vote on a poll, replacing any previous vote.
+// The poll row is locked for the transaction, so concurrent votes of the same user
+// apply one after the other instead of racing on the votes primary key.
+func (s *Application) VotePoll(ctx context.Context, req models.VotePollReq) error {
+	input := fmt.Sprintf("%#v", req)
+
+	if err := ct.ValidateStruct(req); err != nil {
+		return ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
+	}
+
+	accessCtx := accessContext{
+		requesterId: req.RequesterId.Int64(),
+		entityId:    req.PollId.Int64(),
+	}
+
+	hasAccess, err := s.hasRightToView(ctx, accessCtx)
+	if err != nil {
+		return ce.Wrap(ce.ErrInternal, err, fmt.Sprintf("%#v", accessCtx)).WithPublic(genericPublic)
+	}
+	if !hasAccess {
+		return ce.New(ce.ErrPermissionDenied, fmt.Errorf("user has no permission to vote on poll %v", req.PollId), input).WithPublic("permission denied")
+	}
+
+	optionIds := req.OptionIds.Unique()
+
+	return s.txRunner.RunTx(ctx, func(q *ds.Queries) error {
+		poll, err := q.GetPollForVote(ctx, req.PollId.Int64())
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ce.New(ce.ErrNotFound, err, input).WithPublic("not found")
+			}
+			return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+		}
+		if poll.Closed {
+			return ce.New(ce.ErrFailedPrecondition, fmt.Errorf("poll %v is closed", req.PollId), input).WithPublic("poll is closed")
+		}
+		if !poll.MultipleChoice && len(optionIds) != 1 {
+			return ce.New(ce.ErrInvalidArgument, fmt.Errorf("single choice poll got %v options", len(optionIds)), input).WithPublic("only one option can be chosen")
+		}
+
+		_, err = q.DeletePollVotes(ctx, ds.DeletePollVotesParams{
+			PollID: poll.ID,
+			UserID: req.RequesterId.Int64(),
+		})
+		if err != nil {
+			return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+		}
+
+		rowsAffected, err := q.InsertPollVotes(ctx, ds.InsertPollVotesParams{
+			PollID:    poll.ID,
+			UserID:    req.RequesterId.Int64(),
+			OptionIds: optionIds.Int64(),
+		})
+		if err != nil {
+			return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+		}
+		if rowsAffected != int64(len(optionIds)) {
+			return ce.New(ce.ErrInvalidArgument, fmt.Errorf("options %v do not all belong to poll %v", optionIds, req.PollId), input).WithPublic("invalid poll options")
+		}
+		return nil
+	})
+}
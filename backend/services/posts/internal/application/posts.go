@@ -36,6 +36,17 @@ func (s *Application) CreatePost(ctx context.Context, req models.CreatePostReq)
 		return 0, ce.New(ce.ErrInvalidArgument, fmt.Errorf("no group id given"), input).WithPublic("invalid arguments")
 	}
 
+	status, publishAt, err := resolvePostStatus(req.Status, req.PublishAt)
+	if err != nil {
+		return 0, ce.New(ce.ErrInvalidArgument, err, input).WithPublic("scheduled posts need a publish time in the future")
+	}
+
+	if req.Poll != nil {
+		if err := validatePoll(req.Poll); err != nil {
+			return 0, ce.Wrap(ce.ErrInvalidArgument, err, input).WithPublic("invalid poll")
+		}
+	}
+
 	if groupId.Valid {
 		isMember, err := s.clients.IsGroupMember(ctx, req.CreatorId.Int64(), req.GroupId.Int64())
 		if err != nil {
@@ -53,6 +64,8 @@ func (s *Application) CreatePost(ctx context.Context, req models.CreatePostReq)
 			CreatorID: req.CreatorId.Int64(),
 			GroupID:   groupId,
 			Audience:  audience,
+			Status:    status,
+			PublishAt: publishAt,
 		})
 		if err != nil {
 			return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
@@ -83,6 +96,15 @@ func (s *Application) CreatePost(ctx context.Context, req models.CreatePostReq)
 			if err != nil {
 				return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
 			}
+			if err := s.clients.AttachImage(ctx, req.ImageId.Int64(), req.CreatorId.Int64(), postId, media.AttachmentType_ATTACHMENT_POST); err != nil {
+				return ce.DecodeProto(err, input)
+			}
+		}
+
+		if req.Poll != nil {
+			if err := createPoll(ctx, q, postId, req.Poll); err != nil {
+				return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+			}
 		}
 
 		return nil
@@ -90,6 +112,10 @@ func (s *Application) CreatePost(ctx context.Context, req models.CreatePostReq)
 	if err != nil {
 		return 0, ce.Wrap(nil, err)
 	}
+
+	if status == ds.PostStatusPublished && !groupId.Valid && audience != ds.IntendedAudienceEveryone {
+		s.syncPostTimelinesAsync(ctx, postId)
+	}
 	return postId, nil
 }
 
@@ -123,6 +149,8 @@ func (s *Application) DeletePost(ctx context.Context, req models.GenericReq) err
 	if rowsAffected != 1 {
 		return ce.New(ce.ErrNotFound, fmt.Errorf("post %v not found or not owned by user %v", req.EntityId, req.RequesterId), input).WithPublic("not found")
 	}
+
+	s.syncPostTimelinesAsync(ctx, req.EntityId.Int64())
 	return nil
 }
 
@@ -146,7 +174,19 @@ func (s *Application) EditPost(ctx context.Context, req models.EditPostReq) erro
 		return ce.New(ce.ErrPermissionDenied, fmt.Errorf("user has no permission to view or edit entity %v", req.PostId), input).WithPublic("permission denied")
 	}
 
-	return s.txRunner.RunTx(ctx, func(q *ds.Queries) error {
+	err = s.txRunner.RunTx(ctx, func(q *ds.Queries) error {
+		//keep the previous version in the edit history
+		rowsAffected, err := q.CreatePostRevision(ctx, ds.CreatePostRevisionParams{
+			PostID:   req.PostId.Int64(),
+			EditorID: req.RequesterId.Int64(),
+		})
+		if err != nil {
+			return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+		}
+		if rowsAffected != 1 {
+			return ce.New(ce.ErrNotFound, fmt.Errorf("post %v not found or not owned by user %v", req.PostId, req.RequesterId), input).WithPublic("not found")
+		}
+
 		//edit content
 		if len(req.NewBody) > 0 {
 			rowsAffected, err := q.EditPostContent(ctx, ds.EditPostContentParams{
@@ -171,6 +211,9 @@ func (s *Application) EditPost(ctx context.Context, req models.EditPostReq) erro
 			if err != nil {
 				return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
 			}
+			if err := s.clients.AttachImage(ctx, req.ImageId.Int64(), req.RequesterId.Int64(), req.PostId.Int64(), media.AttachmentType_ATTACHMENT_POST); err != nil {
+				return ce.DecodeProto(err, input)
+			}
 		}
 		//delete image
 		if req.DeleteImage {
@@ -183,7 +226,7 @@ func (s *Application) EditPost(ctx context.Context, req models.EditPostReq) erro
 			}
 		}
 		// edit audience
-		_, err := q.UpdatePostAudience(ctx, ds.UpdatePostAudienceParams{
+		_, err = q.UpdatePostAudience(ctx, ds.UpdatePostAudienceParams{
 			ID:        req.PostId.Int64(),
 			CreatorID: req.RequesterId.Int64(),
 			Audience:  ds.IntendedAudience(req.Audience),
@@ -221,7 +264,13 @@ func (s *Application) EditPost(ctx context.Context, req models.EditPostReq) erro
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
 
+	//the audience may have changed
+	s.syncPostTimelinesAsync(ctx, req.PostId.Int64())
+	return nil
 }
 
 func (s *Application) GetMostPopularPostInGroup(ctx context.Context, req models.SimpleIdReq) (models.Post, error) {
@@ -259,6 +308,8 @@ func (s *Application) GetMostPopularPostInGroup(ctx context.Context, req models.
 		Audience:        ct.Audience(p.Audience),
 		CommentsCount:   int(p.CommentsCount),
 		ReactionsCount:  int(p.ReactionsCount),
+		SharesCount:     int(p.SharesCount),
+		SharedPost:      sharedPostStub(p.SharedPostID),
 		LastCommentedAt: ct.GenDateTime(p.LastCommentedAt.Time),
 		CreatedAt:       ct.GenDateTime(p.CreatedAt.Time),
 		UpdatedAt:       ct.GenDateTime(p.UpdatedAt.Time),
@@ -276,7 +327,16 @@ func (s *Application) GetMostPopularPostInGroup(ctx context.Context, req models.
 		}
 	}
 
-	return post, nil
+	posts := []models.Post{post}
+	if err := s.attachPolls(ctx, 0, posts); err != nil {
+		return models.Post{}, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	if err := s.attachSharedPosts(ctx, 0, posts); err != nil {
+		return models.Post{}, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	return posts[0], nil
 }
 
 func (s *Application) GetPostById(ctx context.Context, req models.GenericReq) (models.Post, error) {
@@ -342,12 +402,16 @@ func (s *Application) GetPostById(ctx context.Context, req models.GenericReq) (m
 		Audience:              ct.Audience(p.Audience),
 		CommentsCount:         int(p.CommentsCount),
 		ReactionsCount:        int(p.ReactionsCount),
+		SharesCount:           int(p.SharesCount),
+		SharedPost:            sharedPostStub(p.SharedPostID),
 		LastCommentedAt:       ct.GenDateTime(p.LastCommentedAt.Time),
 		CreatedAt:             ct.GenDateTime(p.CreatedAt.Time),
 		UpdatedAt:             ct.GenDateTime(p.UpdatedAt.Time),
 		LikedByUser:           p.LikedByUser,
 		ImageId:               ct.Id(p.Image),
 		SelectedAudienceUsers: selectedUsers,
+		Status:                ct.PostStatus(p.Status),
+		PublishAt:             ct.GenDateTime(p.PublishAt.Time),
 	}
 
 	if post.ImageId > 0 {
@@ -361,5 +425,26 @@ func (s *Application) GetPostById(ctx context.Context, req models.GenericReq) (m
 		}
 	}
 
-	return post, nil
+	posts := []models.Post{post}
+	if err := s.attachPolls(ctx, req.RequesterId.Int64(), posts); err != nil {
+		return models.Post{}, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	if err := s.attachSharedPosts(ctx, req.RequesterId.Int64(), posts); err != nil {
+		return models.Post{}, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	s.markPostNotificationsReadAsync(ctx, req.RequesterId.Int64(), req.EntityId.Int64())
+	return posts[0], nil
+}
+
+// markPostNotificationsReadAsync clears the notifications of the viewer about a post they opened,
+// like its new likes and comments. Failures are logged, the view itself succeeded.
+func (s *Application) markPostNotificationsReadAsync(ctx context.Context, userId, postId int64) {
+	go func() {
+		ctx := context.WithoutCancel(ctx)
+		if err := s.clients.MarkPostNotificationsRead(ctx, userId, postId); err != nil {
+			tele.Warn(ctx, "failed to mark notifications of post @1 as read: @2", "postId", postId, "error", err.Error())
+		}
+	}()
 }
@@ -0,0 +1,242 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	ds "social-network/services/posts/internal/db/dbservice"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	"social-network/shared/go/models"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// RankingConfig tunes the scores of the "top" feeds. Nil weights and zero other fields fall back
+// to DefaultRankingConfig, a weight set to 0 turns its signal off.
+//
+// A post scores (ReactionWeight*reactions + CommentWeight*comments + 1), halved every HalfLife since
+// it was created, plus ActivityWeight halved every HalfLife since its last comment.
+type RankingConfig struct {
+	ReactionWeight *float64
+	CommentWeight  *float64
+	ActivityWeight *float64
+	HalfLife       time.Duration
+	Window         time.Duration // only posts younger than this are ranked
+	SnapshotSize   int32         // ranked posts kept per snapshot, the feed ends after them
+	SnapshotTTL    time.Duration // how long a client can page through a snapshot
+}
+
+func DefaultRankingConfig() RankingConfig {
+	reactionWeight, commentWeight, activityWeight := 1.0, 2.0, 3.0
+	return RankingConfig{
+		ReactionWeight: &reactionWeight,
+		CommentWeight:  &commentWeight,
+		ActivityWeight: &activityWeight,
+		HalfLife:       12 * time.Hour,
+		Window:         7 * 24 * time.Hour,
+		SnapshotSize:   500,
+		SnapshotTTL:    30 * time.Minute,
+	}
+}
+
+func (c RankingConfig) withDefaults() RankingConfig {
+	d := DefaultRankingConfig()
+	if c.ReactionWeight == nil {
+		c.ReactionWeight = d.ReactionWeight
+	}
+	if c.CommentWeight == nil {
+		c.CommentWeight = d.CommentWeight
+	}
+	if c.ActivityWeight == nil {
+		c.ActivityWeight = d.ActivityWeight
+	}
+	if c.HalfLife <= 0 {
+		c.HalfLife = d.HalfLife
+	}
+	if c.Window <= 0 {
+		c.Window = d.Window
+	}
+	if c.SnapshotSize <= 0 {
+		c.SnapshotSize = d.SnapshotSize
+	}
+	if c.SnapshotTTL <= 0 {
+		c.SnapshotTTL = d.SnapshotTTL
+	}
+	return c
+}
+
+// rankingSnapshot is the order of a "top" feed frozen when its first page was requested,
+// so that later pages neither repeat nor skip posts while scores keep changing.
+type rankingSnapshot struct {
+	GroupId int64   `json:"group_id"` // 0 for the public feed
+	PostIds []int64 `json:"post_ids"`
+}
+
+// GetTopPublicFeed returns public posts ranked by engagement with time decay, and the cursor of the next page.
+func (s *Application) GetTopPublicFeed(ctx context.Context, req models.GenericPaginatedReq) ([]models.Post, ct.Cursor, error) {
+	input := fmt.Sprintf("%#v", req)
+
+	if err := ct.ValidateStruct(req); err != nil {
+		return nil, "", ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
+	}
+
+	posts, next, err := s.rankedPage(ctx, req.RequesterId.Int64(), pgtype.Int8{}, req.Limit, req.Cursor)
+	if err != nil {
+		return nil, "", ce.Wrap(nil, err, input)
+	}
+	return posts, next, nil
+}
+
+// GetTopGroupPosts returns the posts of a group ranked by engagement with time decay, and the cursor of the next page.
+func (s *Application) GetTopGroupPosts(ctx context.Context, req models.GetGroupPostsReq) ([]models.Post, ct.Cursor, error) {
+	input := fmt.Sprintf("%#v", req)
+
+	if err := ct.ValidateStruct(req); err != nil {
+		return nil, "", ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
+	}
+
+	isMember, err := s.clients.IsGroupMember(ctx, req.RequesterId.Int64(), req.GroupId.Int64())
+	if err != nil {
+		return nil, "", ce.DecodeProto(err, input)
+	}
+	if !isMember {
+		return nil, "", ce.New(ce.ErrPermissionDenied, fmt.Errorf("user is not group member"), input).WithPublic("permission denied")
+	}
+
+	groupId := pgtype.Int8{Int64: req.GroupId.Int64(), Valid: true}
+	posts, next, err := s.rankedPage(ctx, req.RequesterId.Int64(), groupId, req.Limit, req.Cursor)
+	if err != nil {
+		return nil, "", ce.Wrap(nil, err, input)
+	}
+	return posts, next, nil
+}
+
+// rankedPage returns one page of the ranked public feed, or of the group feed if groupId is set.
+// The first page takes a new snapshot, the cursor of later pages points into it.
+func (s *Application) rankedPage(ctx context.Context, requesterId int64, groupId pgtype.Int8, limit ct.Limit, cursor ct.Cursor) ([]models.Post, ct.Cursor, error) {
+	var (
+		snapshot   rankingSnapshot
+		snapshotId int64
+		position   int64
+		err        error
+	)
+	if cursor == "" {
+		snapshotId, snapshot, err = s.takeRankingSnapshot(ctx, groupId)
+		if err != nil {
+			return nil, "", err
+		}
+	} else {
+		snapshotId, position, err = cursor.DecodeRanked()
+		if err != nil {
+			return nil, "", ce.New(ce.ErrInvalidArgument, err).WithPublic("invalid cursor")
+		}
+		if s.snapshots == nil {
+			return nil, "", ce.New(ce.ErrFailedPrecondition, fmt.Errorf("no snapshot storage")).WithPublic("feed expired, reload it")
+		}
+		if err := s.snapshots.GetObj(ctx, ct.FeedSnapshotKey{SnapshotId: snapshotId}.String(), &snapshot); err != nil {
+			return nil, "", ce.New(ce.ErrFailedPrecondition, err, fmt.Sprintf("snapshot: %v", snapshotId)).WithPublic("feed expired, reload it")
+		}
+		if snapshot.GroupId != groupId.Int64 {
+			return nil, "", ce.New(ce.ErrInvalidArgument, fmt.Errorf("snapshot %v belongs to group %v", snapshotId, snapshot.GroupId)).WithPublic("invalid cursor")
+		}
+	}
+
+	start := min(position, int64(len(snapshot.PostIds)))
+	end := min(start+int64(limit), int64(len(snapshot.PostIds)))
+	ids := snapshot.PostIds[start:end]
+
+	var next ct.Cursor
+	if end < int64(len(snapshot.PostIds)) && snapshotId != 0 {
+		next, err = ct.NewRankedCursor(snapshotId, end)
+		if err != nil {
+			return nil, "", ce.New(ce.ErrInternal, err).WithPublic(genericPublic)
+		}
+	}
+
+	if len(ids) == 0 {
+		return []models.Post{}, next, nil
+	}
+
+	rows, err := s.db.GetRankedFeedPosts(ctx, ds.GetRankedFeedPostsParams{
+		UserID:  requesterId,
+		Ids:     ids,
+		GroupID: groupId,
+	})
+	if err != nil {
+		return nil, "", ce.New(ce.ErrInternal, err).WithPublic(genericPublic)
+	}
+
+	//keep the snapshot order, posts deleted or hidden since then are skipped
+	byId := make(map[int64]ds.GetRankedFeedPostsRow, len(rows))
+	for _, r := range rows {
+		byId[r.ID] = r
+	}
+	posts := make([]models.Post, 0, len(rows))
+	for _, id := range ids {
+		if r, ok := byId[id]; ok {
+			posts = append(posts, rankedRowToPost(r))
+		}
+	}
+
+	posts, err = s.hydrateFeedPosts(ctx, requesterId, posts)
+	if err != nil {
+		return nil, "", err
+	}
+	return posts, next, nil
+}
+
+// takeRankingSnapshot ranks the feed now and stores the order for later pages.
+// Without snapshot storage only the first page can be served, reported by a zero snapshot id.
+func (s *Application) takeRankingSnapshot(ctx context.Context, groupId pgtype.Int8) (int64, rankingSnapshot, error) {
+	cfg := s.ranking.withDefaults()
+	now := time.Now()
+
+	ids, err := s.db.GetRankedPostIds(ctx, ds.GetRankedPostIdsParams{
+		GroupID:        groupId,
+		AsOf:           pgtype.Timestamptz{Time: now, Valid: true},
+		Since:          pgtype.Timestamptz{Time: now.Add(-cfg.Window), Valid: true},
+		ReactionWeight: *cfg.ReactionWeight,
+		CommentWeight:  *cfg.CommentWeight,
+		ActivityWeight: *cfg.ActivityWeight,
+		HalfLifeHours:  cfg.HalfLife.Hours(),
+		Limit:          cfg.SnapshotSize,
+	})
+	if err != nil {
+		return 0, rankingSnapshot{}, ce.New(ce.ErrInternal, err).WithPublic(genericPublic)
+	}
+
+	snapshot := rankingSnapshot{GroupId: groupId.Int64, PostIds: ids}
+	if s.snapshots == nil || len(ids) == 0 {
+		return 0, snapshot, nil
+	}
+
+	snapshotId := rand.Int64N(math.MaxInt64-1) + 1
+	if err := s.snapshots.SetObj(ctx, ct.FeedSnapshotKey{SnapshotId: snapshotId}.String(), snapshot, cfg.SnapshotTTL); err != nil {
+		return 0, rankingSnapshot{}, ce.New(ce.ErrInternal, err).WithPublic(genericPublic)
+	}
+	return snapshotId, snapshot, nil
+}
+
+func rankedRowToPost(r ds.GetRankedFeedPostsRow) models.Post {
+	return models.Post{
+		PostId: ct.Id(r.ID),
+		Body:   ct.PostBody(r.PostBody),
+		User: models.User{
+			UserId: ct.Id(r.CreatorID),
+		},
+		GroupId:         ct.Id(r.GroupID.Int64),
+		Audience:        ct.Audience(r.Audience),
+		CommentsCount:   int(r.CommentsCount),
+		ReactionsCount:  int(r.ReactionsCount),
+		SharesCount:     int(r.SharesCount),
+		SharedPost:      sharedPostStub(r.SharedPostID),
+		LastCommentedAt: ct.GenDateTime(r.LastCommentedAt.Time),
+		CreatedAt:       ct.GenDateTime(r.CreatedAt.Time),
+		UpdatedAt:       ct.GenDateTime(r.UpdatedAt.Time),
+		LikedByUser:     r.LikedByUser,
+		ImageId:         ct.Id(r.Image),
+	}
+}
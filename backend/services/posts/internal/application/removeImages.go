@@ -3,7 +3,9 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
 	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
 	tele "social-network/shared/go/telemetry"
 )
 
@@ -17,6 +19,22 @@ func (s *Application) RemoveImages(ctx context.Context, failedImages []int64) er
 	return nil
 }
 
+// GetUsedImageIds returns which of the given images are still attached to a post, comment or event,
+// or kept in their edit history. Used by the media service to collect unreferenced files.
+func (s *Application) GetUsedImageIds(ctx context.Context, imageIds ct.Ids) (ct.Ids, error) {
+	input := fmt.Sprintf("image ids: %v", imageIds)
+
+	if len(imageIds) == 0 {
+		return ct.Ids{}, nil
+	}
+
+	used, err := s.db.GetUsedImageIds(ctx, imageIds.Int64())
+	if err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+	return ct.FromInt64s(used), nil
+}
+
 func (s *Application) removeFailedImages(ctx context.Context, imagesToDelete []int64) {
 
 	if len(imagesToDelete) > 0 {
@@ -0,0 +1,115 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	ds "social-network/services/posts/internal/db/dbservice"
+	"social-network/shared/gen-go/media"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	"social-network/shared/go/models"
+	tele "social-network/shared/go/telemetry"
+)
+
+// GetEntityRevisions returns the edit history of a post, comment or event.
+// Anyone who can view the entity can view its history,
+// the users selected as audience of each revision are only returned to the creator.
+func (s *Application) GetEntityRevisions(ctx context.Context, req models.EntityIdPaginatedReq) ([]models.Revision, error) {
+	input := fmt.Sprintf("%#v", req)
+
+	if err := ct.ValidateStruct(req); err != nil {
+		return nil, ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
+	}
+
+	accessCtx := accessContext{
+		requesterId: req.RequesterId.Int64(),
+		entityId:    req.EntityId.Int64(),
+	}
+
+	hasAccess, err := s.hasRightToView(ctx, accessCtx)
+	if err != nil {
+		return nil, ce.Wrap(ce.ErrInternal, err, fmt.Sprintf("%#v", accessCtx)).WithPublic(genericPublic)
+	}
+	if !hasAccess {
+		return nil, ce.New(ce.ErrPermissionDenied, fmt.Errorf("user has no permission to view history of entity %v", req.EntityId), input).WithPublic("permission denied")
+	}
+
+	entity, err := s.db.GetEntityCreatorAndGroup(ctx, req.EntityId.Int64())
+	if err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+	isCreator := entity.CreatorID == req.RequesterId.Int64()
+
+	rows, err := s.db.GetEntityRevisions(ctx, ds.GetEntityRevisionsParams{
+		EntityID: req.EntityId.Int64(),
+		Limit:    req.Limit.Int32(),
+		Offset:   req.Offset.Int32(),
+	})
+	if err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	if len(rows) == 0 {
+		return []models.Revision{}, nil
+	}
+
+	userIds := make(ct.Ids, 0, len(rows))
+	imageIds := make(ct.Ids, 0, len(rows))
+	for _, r := range rows {
+		userIds = append(userIds, ct.Id(r.EditorID))
+		if isCreator {
+			userIds = append(userIds, ct.FromInt64s(r.AudienceIds)...)
+		}
+		imageIds = append(imageIds, ct.FromInt64s(r.ImageIds)...)
+	}
+
+	userMap, err := s.userRetriever.GetUsers(ctx, userIds.Unique())
+	if err != nil {
+		return nil, ce.Wrap(nil, err, input).WithPublic("error retrieving user's info")
+	}
+
+	// images of older revisions may have been replaced or removed since,
+	// so failed ids are only left without url and not removed from posts
+	var imageMap map[int64]string
+	if len(imageIds) > 0 {
+		imageMap, _, err = s.mediaRetriever.GetImages(ctx, imageIds.Unique(), media.FileVariant_MEDIUM)
+		if err != nil {
+			tele.Error(ctx, "media retriever failed for @1", "request", imageIds, "error", err.Error()) //log error instead of returning
+		}
+	}
+
+	revisions := make([]models.Revision, 0, len(rows))
+	for _, r := range rows {
+		selectedUsers := []models.User{}
+		if isCreator {
+			for _, id := range r.AudienceIds {
+				selectedUsers = append(selectedUsers, userMap[ct.Id(id)])
+			}
+		}
+
+		imageUrls := make([]string, 0, len(r.ImageIds))
+		for _, id := range r.ImageIds {
+			imageUrls = append(imageUrls, imageMap[id])
+		}
+
+		revision := models.Revision{
+			RevisionId:            ct.Id(r.ID),
+			EntityId:              ct.Id(r.EntityID),
+			ContentType:           string(r.ContentType),
+			Editor:                userMap[ct.Id(r.EditorID)],
+			Title:                 ct.Title(r.Title),
+			Body:                  r.Body,
+			ImageIds:              ct.FromInt64s(r.ImageIds),
+			ImageUrls:             imageUrls,
+			Audience:              ct.Audience(r.Audience),
+			SelectedAudienceUsers: selectedUsers,
+			CreatedAt:             ct.GenDateTime(r.CreatedAt.Time),
+		}
+		if r.EventDate.Valid {
+			revision.EventDate = ct.GenDateTime(r.EventDate.Time)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
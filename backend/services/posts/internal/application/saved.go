@@ -0,0 +1,215 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	ds "social-network/services/posts/internal/db/dbservice"
+	"social-network/shared/gen-go/media"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	"social-network/shared/go/models"
+	tele "social-network/shared/go/telemetry"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SaveEntity saves a post for the requester, optionally in a named collection.
+// Saving an already saved post moves it to the given collection.
+func (s *Application) SaveEntity(ctx context.Context, req models.SaveEntityReq) error {
+	input := fmt.Sprintf("%#v", req)
+
+	if err := ct.ValidateStruct(req); err != nil {
+		return ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
+	}
+
+	entity, err := s.db.GetEntityCreatorAndGroup(ctx, req.EntityId.Int64())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ce.New(ce.ErrNotFound, err, input).WithPublic("not found")
+		}
+		return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+	if entity.ContentType != ds.ContentTypePost {
+		return ce.New(ce.ErrInvalidArgument, fmt.Errorf("entity %v is a %v, only posts can be saved", req.EntityId, entity.ContentType), input).WithPublic("only posts can be saved")
+	}
+
+	accessCtx := accessContext{
+		requesterId: req.RequesterId.Int64(),
+		entityId:    req.EntityId.Int64(),
+	}
+
+	hasAccess, err := s.hasRightToView(ctx, accessCtx)
+	if err != nil {
+		return ce.Wrap(ce.ErrInternal, err, fmt.Sprintf("%#v", accessCtx)).WithPublic(genericPublic)
+	}
+	if !hasAccess {
+		return ce.New(ce.ErrPermissionDenied, fmt.Errorf("user has no permission to view entity %v", req.EntityId), input).WithPublic("permission denied")
+	}
+
+	return s.txRunner.RunTx(ctx, func(q *ds.Queries) error {
+		var collectionId pgtype.Int8
+		if name := strings.TrimSpace(req.Collection.String()); name != "" {
+			id, err := q.GetOrCreateSavedCollection(ctx, ds.GetOrCreateSavedCollectionParams{
+				UserID: req.RequesterId.Int64(),
+				Name:   name,
+			})
+			if err != nil {
+				return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+			}
+			collectionId = pgtype.Int8{Int64: id, Valid: true}
+		}
+
+		err := q.SaveEntity(ctx, ds.SaveEntityParams{
+			UserID:       req.RequesterId.Int64(),
+			EntityID:     req.EntityId.Int64(),
+			CollectionID: collectionId,
+		})
+		if err != nil {
+			return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+		}
+		return nil
+	})
+}
+
+func (s *Application) UnsaveEntity(ctx context.Context, req models.GenericReq) error {
+	input := fmt.Sprintf("%#v", req)
+
+	if err := ct.ValidateStruct(req); err != nil {
+		return ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
+	}
+
+	rowsAffected, err := s.db.UnsaveEntity(ctx, ds.UnsaveEntityParams{
+		UserID:   req.RequesterId.Int64(),
+		EntityID: req.EntityId.Int64(),
+	})
+	if err != nil {
+		return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+	if rowsAffected != 1 {
+		return ce.New(ce.ErrNotFound, fmt.Errorf("entity %v is not saved by user %v", req.EntityId, req.RequesterId), input).WithPublic("not found")
+	}
+	return nil
+}
+
+// ListSaved returns the requester's saved posts. Access to every post is checked again,
+// so saved posts that the requester can no longer see are left out.
+func (s *Application) ListSaved(ctx context.Context, req models.ListSavedReq) ([]models.Post, error) {
+	input := fmt.Sprintf("%#v", req)
+
+	if err := ct.ValidateStruct(req); err != nil {
+		return nil, ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
+	}
+
+	rows, err := s.db.GetSavedPosts(ctx, ds.GetSavedPostsParams{
+		UserID:     req.RequesterId.Int64(),
+		Collection: strings.TrimSpace(req.Collection.String()),
+		Offset:     req.Offset.Int32(),
+		Limit:      req.Limit.Int32(),
+	})
+	if err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	posts := make([]models.Post, 0, len(rows))
+	userIDs := make(ct.Ids, 0, len(rows))
+	postImageIds := make(ct.Ids, 0, len(rows))
+
+	for _, r := range rows {
+		accessCtx := accessContext{
+			requesterId: req.RequesterId.Int64(),
+			entityId:    r.ID,
+		}
+		hasAccess, err := s.hasRightToView(ctx, accessCtx)
+		if err != nil {
+			return nil, ce.Wrap(ce.ErrInternal, err, fmt.Sprintf("%#v", accessCtx)).WithPublic(genericPublic)
+		}
+		if !hasAccess {
+			continue
+		}
+
+		userIDs = append(userIDs, ct.Id(r.CreatorID))
+		posts = append(posts, models.Post{
+			PostId: ct.Id(r.ID),
+			Body:   ct.PostBody(r.PostBody),
+			User: models.User{
+				UserId: ct.Id(r.CreatorID),
+			},
+			GroupId:         ct.Id(r.GroupID.Int64),
+			Audience:        ct.Audience(r.Audience),
+			CommentsCount:   int(r.CommentsCount),
+			ReactionsCount:  int(r.ReactionsCount),
+			SharesCount:     int(r.SharesCount),
+			SharedPost:      sharedPostStub(r.SharedPostID),
+			LastCommentedAt: ct.GenDateTime(r.LastCommentedAt.Time),
+			CreatedAt:       ct.GenDateTime(r.CreatedAt.Time),
+			UpdatedAt:       ct.GenDateTime(r.UpdatedAt.Time),
+			LikedByUser:     r.LikedByUser,
+			ImageId:         ct.Id(r.Image),
+		})
+		if r.Image > 0 {
+			postImageIds = append(postImageIds, ct.Id(r.Image))
+		}
+	}
+
+	if len(posts) == 0 {
+		return []models.Post{}, nil
+	}
+
+	userMap, err := s.userRetriever.GetUsers(ctx, userIDs.Unique())
+	if err != nil {
+		return nil, ce.Wrap(nil, err, input).WithPublic("error retrieving user's info")
+	}
+
+	var imageMap map[int64]string
+	if len(postImageIds) > 0 {
+		var failedImageIds []int64
+		imageMap, failedImageIds, err = s.mediaRetriever.GetImages(ctx, postImageIds, media.FileVariant_MEDIUM)
+		if err != nil {
+			tele.Error(ctx, "media retriever failed for @1", "request", postImageIds, "error", err.Error()) //log error instead of returning
+		} else {
+			s.removeFailedImagesAsync(ctx, failedImageIds)
+		}
+	}
+
+	for i := range posts {
+		if u, ok := userMap[posts[i].User.UserId]; ok {
+			posts[i].User = u
+		}
+		posts[i].ImageUrl = imageMap[posts[i].ImageId.Int64()]
+	}
+
+	if err := s.attachPolls(ctx, req.RequesterId.Int64(), posts); err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	if err := s.attachSharedPosts(ctx, req.RequesterId.Int64(), posts); err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	return posts, nil
+}
+
+func (s *Application) GetSavedCollections(ctx context.Context, req models.SimpleIdReq) ([]models.SavedCollection, error) {
+	input := fmt.Sprintf("%#v", req)
+
+	if err := ct.ValidateStruct(req); err != nil {
+		return nil, ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
+	}
+
+	rows, err := s.db.GetSavedCollections(ctx, req.Id.Int64())
+	if err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	collections := make([]models.SavedCollection, 0, len(rows))
+	for _, r := range rows {
+		collections = append(collections, models.SavedCollection{
+			Name:       ct.Title(r.Name),
+			SavedCount: int(r.SavedCount),
+		})
+	}
+	return collections, nil
+}
@@ -0,0 +1,307 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	ds "social-network/services/posts/internal/db/dbservice"
+	"social-network/shared/gen-go/media"
+	notifpb "social-network/shared/gen-go/notifications"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	"social-network/shared/go/models"
+	tele "social-network/shared/go/telemetry"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SharePost creates a new post that shares (reposts) another post, with optional quote text.
+// Sharing a share shares its original. The share's audience can't reach anyone
+// who is not allowed to see the original.
+func (s *Application) SharePost(ctx context.Context, req models.SharePostReq) (shareId int64, err error) {
+	input := fmt.Sprintf("%#v", req)
+
+	if err := ct.ValidateStruct(req); err != nil {
+		return 0, ce.Wrap(ce.ErrInvalidArgument, err, input).WithPublic("invalid data received")
+	}
+
+	var groupId pgtype.Int8
+	if req.GroupId > 0 {
+		groupId = pgtype.Int8{Int64: req.GroupId.Int64(), Valid: true}
+	}
+
+	audience := ds.IntendedAudience(req.Audience.String())
+
+	if !groupId.Valid && audience == "group" {
+		return 0, ce.New(ce.ErrInvalidArgument, fmt.Errorf("no group id given"), input).WithPublic("invalid arguments")
+	}
+
+	audienceIds := req.AudienceIds.Unique()
+	if audience == "selected" && len(audienceIds) < 1 {
+		return 0, ce.New(ce.ErrInvalidArgument, fmt.Errorf("no audience given for share with audience=selected"), input).WithPublic("invalid arguments")
+	}
+
+	original, err := s.getShareableOriginal(ctx, req.RequesterId.Int64(), req.PostId.Int64())
+	if err != nil {
+		return 0, ce.Wrap(nil, err, input)
+	}
+
+	allowed, err := s.shareAudienceAllowed(ctx, original, req.RequesterId.Int64(), groupId, audience, audienceIds)
+	if err != nil {
+		return 0, ce.Wrap(nil, err, input)
+	}
+	if !allowed {
+		return 0, ce.New(ce.ErrPermissionDenied, fmt.Errorf("audience %v of share is wider than audience %v of post %v", audience, original.Audience, original.ID), input).
+			WithPublic("the post can't be shared with this audience")
+	}
+
+	if groupId.Valid {
+		isMember, err := s.clients.IsGroupMember(ctx, req.RequesterId.Int64(), groupId.Int64)
+		if err != nil {
+			return 0, ce.DecodeProto(err, input)
+		}
+		if !isMember {
+			return 0, ce.New(ce.ErrPermissionDenied, fmt.Errorf("user is not a member of group %v", req.GroupId), input).WithPublic("permission denied")
+		}
+	}
+
+	err = s.txRunner.RunTx(ctx, func(q *ds.Queries) error {
+		shareId, err = q.CreateShare(ctx, ds.CreateShareParams{
+			PostBody:     req.Body.String(),
+			CreatorID:    req.RequesterId.Int64(),
+			GroupID:      groupId,
+			Audience:     audience,
+			SharedPostID: original.ID,
+		})
+		if err != nil {
+			return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+		}
+
+		if audience == "selected" {
+			rowsAffected, err := q.InsertPostAudience(ctx, ds.InsertPostAudienceParams{
+				PostID:         shareId,
+				AllowedUserIds: audienceIds.Int64(),
+			})
+			if err != nil {
+				return ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+			}
+			if rowsAffected < int64(len(audienceIds)) {
+				return ce.New(ce.ErrInternal, fmt.Errorf("unexpected rows returned: expected %v, got %v", len(audienceIds), rowsAffected), input).WithPublic(genericPublic)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, ce.Wrap(nil, err)
+	}
+
+	if !groupId.Valid && audience != ds.IntendedAudienceEveryone {
+		s.syncPostTimelinesAsync(ctx, shareId)
+	}
+
+	//sharing your own post doesn't notify anyone
+	if original.CreatorID == req.RequesterId.Int64() {
+		return shareId, nil
+	}
+
+	sharer, err := s.userRetriever.GetUser(ctx, req.RequesterId)
+	if err != nil {
+		tele.Error(ctx, "Could not get basic user info for id @1 for post shared event: @2", "userId", req.RequesterId, "error", err.Error())
+	}
+
+	event := &notifpb.NotificationEvent{
+		EventType: notifpb.EventType_POST_SHARED,
+		Payload: &notifpb.NotificationEvent_PostShared{
+			PostShared: &notifpb.PostShared{
+				PostCreatorId:  original.CreatorID,
+				PostId:         original.ID,
+				ShareId:        shareId,
+				SharerUserId:   req.RequesterId.Int64(),
+				SharerUsername: sharer.Username.String(),
+				Body:           req.Body.String(),
+			},
+		},
+	}
+
+	if err := s.eventProducer.CreateAndSendNotificationEvent(ctx, event); err != nil {
+		tele.Error(ctx, "failed to send post shared notification: @1", "error", err.Error())
+	}
+	tele.Info(ctx, "post shared notification event created")
+
+	return shareId, nil
+}
+
+// getShareableOriginal returns the post that a share of postId should point to,
+// checking that the requester can see it.
+func (s *Application) getShareableOriginal(ctx context.Context, requesterId, postId int64) (ds.GetPostForShareRow, error) {
+	input := fmt.Sprintf("requester: %v, post: %v", requesterId, postId)
+
+	original, err := s.db.GetPostForShare(ctx, postId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ds.GetPostForShareRow{}, ce.New(ce.ErrNotFound, err, input).WithPublic("not found")
+		}
+		return ds.GetPostForShareRow{}, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	if original.SharedPostID.Valid {
+		original, err = s.db.GetPostForShare(ctx, original.SharedPostID.Int64)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ds.GetPostForShareRow{}, ce.New(ce.ErrFailedPrecondition, err, input).WithPublic("the shared post is no longer available")
+			}
+			return ds.GetPostForShareRow{}, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+		}
+	}
+
+	hasAccess, err := s.hasRightToView(ctx, accessContext{requesterId: requesterId, entityId: original.ID})
+	if err != nil {
+		return ds.GetPostForShareRow{}, ce.Wrap(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+	if !hasAccess {
+		return ds.GetPostForShareRow{}, ce.New(ce.ErrPermissionDenied, fmt.Errorf("user has no permission to view post %v", original.ID), input).WithPublic("permission denied")
+	}
+	return original, nil
+}
+
+// shareAudienceAllowed reports whether everyone in the share's audience can see the original:
+//   - public originals can be shared with any audience
+//   - group originals only within the same group
+//   - followers originals only by their creator to their followers
+//   - any original can be shared with selected users that can all see it
+func (s *Application) shareAudienceAllowed(ctx context.Context, original ds.GetPostForShareRow, requesterId int64, groupId pgtype.Int8, audience ds.IntendedAudience, audienceIds ct.Ids) (bool, error) {
+	if original.GroupID.Valid {
+		return groupId.Valid && groupId.Int64 == original.GroupID.Int64, nil
+	}
+	if original.Audience == ds.IntendedAudienceEveryone {
+		return true, nil
+	}
+
+	switch audience {
+	case ds.IntendedAudienceFollowers:
+		return original.Audience == ds.IntendedAudienceFollowers && original.CreatorID == requesterId, nil
+	case ds.IntendedAudienceSelected:
+		for _, id := range audienceIds {
+			canSee, err := s.hasRightToView(ctx, accessContext{requesterId: id.Int64(), entityId: original.ID})
+			if err != nil {
+				return false, err
+			}
+			if !canSee {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// sharedPostStub returns the placeholder for the original of a share, filled in by attachSharedPosts.
+func sharedPostStub(sharedPostId pgtype.Int8) *models.SharedPost {
+	if !sharedPostId.Valid {
+		return nil
+	}
+	return &models.SharedPost{PostId: ct.Id(sharedPostId.Int64)}
+}
+
+// attachSharedPosts fills in the original of every share in posts.
+// Originals that were deleted, unpublished or that the requester can no longer see
+// (e.g. after an audience change) are left unavailable.
+func (s *Application) attachSharedPosts(ctx context.Context, requesterId int64, posts []models.Post) error {
+	var originalIds ct.Ids
+	for _, p := range posts {
+		if p.SharedPost != nil {
+			originalIds = append(originalIds, p.SharedPost.PostId)
+		}
+	}
+	if len(originalIds) == 0 {
+		return nil
+	}
+
+	rows, err := s.db.GetSharedPosts(ctx, ds.GetSharedPostsParams{
+		Ids:    originalIds.Unique().Int64(),
+		UserID: requesterId,
+	})
+	if err != nil {
+		return err
+	}
+
+	var followingIds map[int64]struct{}
+	originals := make(map[int64]ds.GetSharedPostsRow, len(rows))
+	userIds := make(ct.Ids, 0, len(rows))
+	imageIds := make(ct.Ids, 0, len(rows))
+	for _, r := range rows {
+		if r.Audience == ds.IntendedAudienceFollowers && requesterId > 0 && r.CreatorID != requesterId && followingIds == nil {
+			ids, err := s.clients.GetFollowingIds(ctx, requesterId)
+			if err != nil {
+				return err
+			}
+			followingIds = make(map[int64]struct{}, len(ids))
+			for _, id := range ids {
+				followingIds[id] = struct{}{}
+			}
+		}
+		originals[r.ID] = r
+		userIds = append(userIds, ct.Id(r.CreatorID))
+		if r.Image > 0 {
+			imageIds = append(imageIds, ct.Id(r.Image))
+		}
+	}
+
+	userMap, err := s.userRetriever.GetUsers(ctx, userIds.Unique())
+	if err != nil {
+		return err
+	}
+
+	var imageMap map[int64]string
+	if len(imageIds) > 0 {
+		var failedImageIds []int64
+		imageMap, failedImageIds, err = s.mediaRetriever.GetImages(ctx, imageIds, media.FileVariant_MEDIUM)
+		if err != nil {
+			tele.Error(ctx, "media retriever failed for @1", "request", imageIds, "error", err.Error()) //log error instead of returning
+		} else {
+			s.removeFailedImagesAsync(ctx, failedImageIds)
+		}
+	}
+
+	for i := range posts {
+		if posts[i].SharedPost == nil {
+			continue
+		}
+		r, ok := originals[posts[i].SharedPost.PostId.Int64()]
+		if !ok {
+			continue
+		}
+
+		var visible bool
+		switch {
+		case r.CreatorID == requesterId:
+			visible = true
+		case r.GroupID.Valid:
+			visible = posts[i].GroupId.Int64() == r.GroupID.Int64
+		case r.Audience == ds.IntendedAudienceEveryone:
+			visible = true
+		case r.Audience == ds.IntendedAudienceFollowers:
+			_, visible = followingIds[r.CreatorID]
+		case r.Audience == ds.IntendedAudienceSelected:
+			visible = r.UserInAudience
+		}
+		if !visible {
+			continue
+		}
+
+		posts[i].SharedPost = &models.SharedPost{
+			PostId:    ct.Id(r.ID),
+			Available: true,
+			Body:      ct.PostBody(r.PostBody),
+			User:      userMap[ct.Id(r.CreatorID)],
+			GroupId:   ct.Id(r.GroupID.Int64),
+			Audience:  ct.Audience(r.Audience),
+			CreatedAt: ct.GenDateTime(r.CreatedAt.Time),
+			UpdatedAt: ct.GenDateTime(r.UpdatedAt.Time),
+			ImageId:   ct.Id(r.Image),
+			ImageUrl:  imageMap[r.Image],
+		}
+	}
+	return nil
+}
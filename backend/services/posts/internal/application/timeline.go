@@ -0,0 +1,478 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	ds "social-network/services/posts/internal/db/dbservice"
+	"social-network/shared/gen-go/media"
+	ce "social-network/shared/go/commonerrors"
+	ct "social-network/shared/go/ct"
+	"social-network/shared/go/models"
+	rds "social-network/shared/go/redis"
+	tele "social-network/shared/go/telemetry"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Personalized feed timelines (fan-out on write).
+//
+// Every user has a Redis sorted set of the ids of the followers-only and selected posts
+// fanned out to them on publish, scored by creation time. Reads re-check every entry against the
+// database, so entries left behind by unfollows, deletions and audience changes are never shown
+// and are removed from the timeline when found. Posts of authors with more than
+// celebrityFollowers followers are not fanned out and are read on request instead.
+const (
+	timelineMaxLen     = 1000               // newest entries kept per timeline, older pages are read from the database
+	timelineTTL        = 7 * 24 * time.Hour // inactive timelines expire and are rebuilt on the next read
+	followingIdsTTL    = time.Minute        // how long an unfollow can take to hide posts from the unfollowed user
+	celebrityFollowers = 5000               // authors with more followers are read on request instead of fanned out
+	timelineReadRounds = 3                  // batches read from a timeline for one page before falling back to the database
+	rebuildBatchSize   = 500
+	celebritiesKey     = "timeline_celebrities"
+)
+
+// user ids whose timeline is being rebuilt in the background
+var rebuildingTimelines sync.Map
+
+func timelineScore(t time.Time) float64 {
+	return float64(t.UnixMicro())
+}
+
+// syncPostTimelines adds a post to the timelines of its audience,
+// or removes it if it no longer belongs there (deleted, unpublished or audience changed).
+func (s *Application) syncPostTimelines(ctx context.Context, postId int64) error {
+	p, err := s.db.GetPostForTimeline(ctx, postId)
+	if err != nil {
+		return err
+	}
+	if p.GroupID.Valid {
+		return nil
+	}
+
+	onTimeline := p.Status == ds.PostStatusPublished && !p.DeletedAt.Valid &&
+		(p.Audience == ds.IntendedAudienceFollowers || p.Audience == ds.IntendedAudienceSelected)
+
+	var recipients []int64
+	if p.Audience == ds.IntendedAudienceSelected {
+		recipients, err = s.db.GetPostAudience(ctx, p.ID)
+		if err != nil {
+			return err
+		}
+	} else {
+		if p.Audience == ds.IntendedAudienceEveryone && p.DeletedAt.Valid {
+			//public posts are only on timelines if they were followers-only before an edit, which already removed them
+			return nil
+		}
+		var celebrity bool
+		recipients, celebrity, err = s.clients.GetFollowerIds(ctx, p.CreatorID, celebrityFollowers)
+		if err != nil {
+			return err
+		}
+		if celebrity {
+			return s.timelines.SAdd(ctx, celebritiesKey, strconv.FormatInt(p.CreatorID, 10))
+		}
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(recipients))
+	for _, id := range recipients {
+		keys = append(keys, ct.TimelineKey{UserId: ct.Id(id)}.String())
+	}
+	member := strconv.FormatInt(p.ID, 10)
+
+	if !onTimeline {
+		return s.timelines.ZRemFromMany(ctx, keys, member)
+	}
+	return s.timelines.ZAddCapped(ctx, keys, timelineScore(p.CreatedAt.Time), member, timelineMaxLen, timelineTTL)
+}
+
+func (s *Application) syncPostTimelinesAsync(ctx context.Context, postId int64) {
+	if s.timelines == nil {
+		return
+	}
+	go func() {
+		ctx := context.WithoutCancel(ctx)
+		if err := s.syncPostTimelines(ctx, postId); err != nil {
+			tele.Warn(ctx, "failed to update timelines for post @1: @2", "postId", postId, "error", err.Error())
+		}
+	}()
+}
+
+// getFollowingIds returns the ids of the users that userId follows, cached for followingIdsTTL.
+func (s *Application) getFollowingIds(ctx context.Context, userId int64) ([]int64, error) {
+	if s.timelines == nil {
+		return s.clients.GetFollowingIds(ctx, userId)
+	}
+
+	key := ct.FollowingIdsKey{UserId: ct.Id(userId)}.String()
+	var ids []int64
+	if err := s.timelines.GetObj(ctx, key, &ids); err == nil {
+		return ids, nil
+	}
+
+	ids, err := s.clients.GetFollowingIds(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.timelines.SetObj(ctx, key, ids, followingIdsTTL); err != nil {
+		tele.Warn(ctx, "failed to cache following ids of user @1: @2", "userId", userId, "error", err.Error())
+	}
+	return ids, nil
+}
+
+// celebrities returns the authors whose posts are not fanned out.
+func (s *Application) celebrities(ctx context.Context) (map[int64]struct{}, error) {
+	members, err := s.timelines.SMembers(ctx, celebritiesKey)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[int64]struct{}, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseInt(m, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids[id] = struct{}{}
+	}
+	return ids, nil
+}
+
+// RebuildTimeline replaces the timeline of userId with the newest posts of the users they follow.
+// A post fanned out while the rebuild runs can be lost; it is still shown once the timeline ages out.
+func (s *Application) RebuildTimeline(ctx context.Context, userId int64) error {
+	following, err := s.clients.GetFollowingIds(ctx, userId)
+	if err != nil {
+		return ce.DecodeProto(err, fmt.Sprintf("user: %v", userId))
+	}
+
+	celebrities, err := s.celebrities(ctx)
+	if err != nil {
+		return err
+	}
+	fannedOut := make([]int64, 0, len(following))
+	for _, id := range following {
+		if _, ok := celebrities[id]; !ok {
+			fannedOut = append(fannedOut, id)
+		}
+	}
+
+	rows, err := s.db.GetPersonalizedFeed(ctx, ds.GetPersonalizedFeedParams{
+		UserID:  userId,
+		Column2: fannedOut,
+		Limit:   timelineMaxLen,
+	})
+	if err != nil {
+		return err
+	}
+
+	entries := make([]rds.ZEntry, 0, len(rows))
+	for _, r := range rows {
+		entries = append(entries, rds.ZEntry{
+			Member: strconv.FormatInt(r.ID, 10),
+			Score:  timelineScore(r.CreatedAt.Time),
+		})
+	}
+
+	if err := s.timelines.ReplaceSortedSet(ctx, ct.TimelineKey{UserId: ct.Id(userId)}.String(), entries, timelineTTL); err != nil {
+		return err
+	}
+	if err := s.timelines.SetObj(ctx, ct.FollowingIdsKey{UserId: ct.Id(userId)}.String(), following, followingIdsTTL); err != nil {
+		tele.Warn(ctx, "failed to cache following ids of user @1: @2", "userId", userId, "error", err.Error())
+	}
+	return s.timelines.SetStr(ctx, ct.TimelineReadyKey{UserId: ct.Id(userId)}.String(), "1", timelineTTL)
+}
+
+// RebuildAllTimelines rebuilds the timeline of every user known to the posts database.
+// Users without any activity on posts get theirs rebuilt on their first read.
+func (s *Application) RebuildAllTimelines(ctx context.Context) (rebuilt int, err error) {
+	var afterId int64
+	for {
+		ids, err := s.db.GetTimelineUserIds(ctx, ds.GetTimelineUserIdsParams{
+			AfterID: afterId,
+			Limit:   rebuildBatchSize,
+		})
+		if err != nil {
+			return rebuilt, err
+		}
+
+		for _, id := range ids {
+			if err := s.RebuildTimeline(ctx, id); err != nil {
+				tele.Warn(ctx, "failed to rebuild timeline of user @1: @2", "userId", id, "error", err.Error())
+				continue
+			}
+			rebuilt++
+		}
+
+		if len(ids) < rebuildBatchSize {
+			return rebuilt, nil
+		}
+		afterId = ids[len(ids)-1]
+	}
+}
+
+func (s *Application) rebuildTimelineAsync(ctx context.Context, userId int64) {
+	if _, running := rebuildingTimelines.LoadOrStore(userId, struct{}{}); running {
+		return
+	}
+	go func() {
+		defer rebuildingTimelines.Delete(userId)
+		ctx := context.WithoutCancel(ctx)
+		if err := s.RebuildTimeline(ctx, userId); err != nil {
+			tele.Warn(ctx, "failed to rebuild timeline of user @1: @2", "userId", userId, "error", err.Error())
+		}
+	}()
+}
+
+// readTimeline returns one page of the personalized feed from the requester's timeline,
+// merged with the posts of followed celebrities. Reports false if the page has to be read
+// from the database instead, e.g. because the timeline is not built yet.
+func (s *Application) readTimeline(ctx context.Context, requesterId int64, limit int32, pg page) ([]models.Post, bool, error) {
+	ready, err := s.timelines.Exists(ctx, ct.TimelineReadyKey{UserId: ct.Id(requesterId)}.String())
+	if err != nil {
+		return nil, false, err
+	}
+	if !ready {
+		s.rebuildTimelineAsync(ctx, requesterId)
+		return nil, false, nil
+	}
+
+	following, err := s.getFollowingIds(ctx, requesterId)
+	if err != nil {
+		return nil, false, err
+	}
+
+	key := ct.TimelineKey{UserId: ct.Id(requesterId)}.String()
+	cursorScore := math.Inf(1)
+	if pg.createdAt.Valid {
+		cursorScore = timelineScore(pg.createdAt.Time)
+	}
+
+	var (
+		posts     []models.Post
+		stale     []string
+		exhausted bool
+		oldest    = pg // position of the oldest entry read, where the database takes over
+		maxScore  = cursorScore
+		batch     = int64(limit) * 2
+		seen      = make(map[int64]struct{})
+	)
+	for round := 0; round < timelineReadRounds && len(posts) < int(limit); round++ {
+		entries, err := s.timelines.ZRevRangeByScore(ctx, key, maxScore, batch)
+		if err != nil {
+			return nil, false, err
+		}
+		exhausted = int64(len(entries)) < batch
+
+		ids := make([]int64, 0, len(entries))
+		for _, e := range entries {
+			id, err := strconv.ParseInt(e.Member, 10, 64)
+			if err != nil {
+				stale = append(stale, e.Member)
+				continue
+			}
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			if e.Score > cursorScore || (e.Score == cursorScore && id >= pg.id) {
+				continue
+			}
+			ids = append(ids, id)
+			oldest = page{
+				createdAt: pgtype.Timestamptz{Time: time.UnixMicro(int64(e.Score)).UTC(), Valid: true},
+				id:        id,
+			}
+		}
+		if len(entries) > 0 {
+			maxScore = entries[len(entries)-1].Score
+		}
+
+		if len(ids) > 0 {
+			rows, err := s.db.GetTimelinePosts(ctx, ds.GetTimelinePostsParams{
+				UserID:       requesterId,
+				Ids:          ids,
+				FollowingIds: following,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+			valid := make(map[int64]struct{}, len(rows))
+			for _, r := range rows {
+				valid[r.ID] = struct{}{}
+				posts = append(posts, timelineRowToPost(r))
+			}
+			for _, id := range ids {
+				if _, ok := valid[id]; !ok {
+					stale = append(stale, strconv.FormatInt(id, 10))
+				}
+			}
+		}
+
+		if exhausted || len(ids) == 0 {
+			break
+		}
+	}
+	s.removeStaleTimelineEntriesAsync(ctx, key, stale)
+
+	if len(posts) < int(limit) && !exhausted {
+		//too many stale entries in a row, let the database answer this page
+		return nil, false, nil
+	}
+
+	if len(posts) < int(limit) {
+		size, err := s.timelines.ZCard(ctx, key)
+		if err != nil {
+			return nil, false, err
+		}
+		if size >= timelineMaxLen {
+			//older entries were trimmed from the timeline
+			rows, err := s.db.GetPersonalizedFeed(ctx, ds.GetPersonalizedFeedParams{
+				UserID:          requesterId,
+				Column2:         following,
+				Limit:           limit,
+				CursorCreatedAt: oldest.createdAt,
+				CursorID:        oldest.id,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+			for _, r := range rows {
+				posts = append(posts, personalizedRowToPost(r))
+			}
+		}
+	}
+
+	celebrities, err := s.celebrities(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	var followedCelebrities []int64
+	for _, id := range following {
+		if _, ok := celebrities[id]; ok {
+			followedCelebrities = append(followedCelebrities, id)
+		}
+	}
+	if len(followedCelebrities) > 0 {
+		rows, err := s.db.GetPersonalizedFeed(ctx, ds.GetPersonalizedFeedParams{
+			UserID:          requesterId,
+			Column2:         followedCelebrities,
+			Limit:           limit,
+			CursorCreatedAt: pg.createdAt,
+			CursorID:        pg.id,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		for _, r := range rows {
+			posts = append(posts, personalizedRowToPost(r))
+		}
+	}
+
+	slices.SortFunc(posts, func(a, b models.Post) int {
+		if c := b.CreatedAt.Time().Compare(a.CreatedAt.Time()); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.PostId, a.PostId)
+	})
+	posts = slices.CompactFunc(posts, func(a, b models.Post) bool {
+		return a.PostId == b.PostId
+	})
+	if len(posts) > int(limit) {
+		posts = posts[:limit]
+	}
+	return posts, true, nil
+}
+
+func (s *Application) removeStaleTimelineEntriesAsync(ctx context.Context, key string, members []string) {
+	if len(members) == 0 {
+		return
+	}
+	go func() {
+		ctx := context.WithoutCancel(ctx)
+		if err := s.timelines.ZRem(ctx, key, members...); err != nil {
+			tele.Warn(ctx, "failed to remove stale entries from timeline @1: @2", "key", key, "error", err.Error())
+		}
+	}()
+}
+
+// hydrateFeedPosts fills in users, images, polls and shared posts of feed posts.
+func (s *Application) hydrateFeedPosts(ctx context.Context, requesterId int64, posts []models.Post) ([]models.Post, error) {
+	input := fmt.Sprintf("requester: %v", requesterId)
+
+	if len(posts) == 0 {
+		return []models.Post{}, nil
+	}
+
+	userIDs := make(ct.Ids, 0, len(posts))
+	postImageIds := make(ct.Ids, 0, len(posts))
+	for _, p := range posts {
+		userIDs = append(userIDs, p.User.UserId)
+		if p.ImageId > 0 {
+			postImageIds = append(postImageIds, p.ImageId)
+		}
+	}
+
+	userMap, err := s.userRetriever.GetUsers(ctx, userIDs.Unique())
+	if err != nil {
+		return nil, ce.Wrap(nil, err, input).WithPublic("error retrieving user's info")
+	}
+
+	var imageMap map[int64]string
+	if len(postImageIds) > 0 {
+		var failedImageIds []int64
+		imageMap, failedImageIds, err = s.mediaRetriever.GetImages(ctx, postImageIds, media.FileVariant_MEDIUM)
+		if err != nil {
+			tele.Error(ctx, "media retriever failed for @1", "request", postImageIds, "error", err.Error()) //log error instead of returning
+		} else {
+			s.removeFailedImagesAsync(ctx, failedImageIds)
+		}
+	}
+
+	for i := range posts {
+		if u, ok := userMap[posts[i].User.UserId]; ok {
+			posts[i].User = u
+		}
+		posts[i].ImageUrl = imageMap[posts[i].ImageId.Int64()]
+	}
+
+	if err := s.attachPolls(ctx, requesterId, posts); err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	if err := s.attachSharedPosts(ctx, requesterId, posts); err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+
+	return posts, nil
+}
+
+func personalizedRowToPost(r ds.GetPersonalizedFeedRow) models.Post {
+	return models.Post{
+		PostId: ct.Id(r.ID),
+		Body:   ct.PostBody(r.PostBody),
+		User: models.User{
+			UserId: ct.Id(r.CreatorID),
+		},
+		CommentsCount:   int(r.CommentsCount),
+		ReactionsCount:  int(r.ReactionsCount),
+		SharesCount:     int(r.SharesCount),
+		SharedPost:      sharedPostStub(r.SharedPostID),
+		LastCommentedAt: ct.GenDateTime(r.LastCommentedAt.Time),
+		CreatedAt:       ct.GenDateTime(r.CreatedAt.Time),
+		UpdatedAt:       ct.GenDateTime(r.UpdatedAt.Time),
+		LikedByUser:     r.LikedByUser,
+		ImageId:         ct.Id(r.Image),
+	}
+}
+
+func timelineRowToPost(r ds.GetTimelinePostsRow) models.Post {
+	return personalizedRowToPost(ds.GetPersonalizedFeedRow(r))
+}
@@ -85,6 +85,53 @@ func (c *Clients) GetFollowingIds(ctx context.Context, userId int64) ([]int64, e
 	return resp.Values, nil
 }
 
+// GetFollowerIds returns the ids of the user's followers, paging through GetFollowersPaginated.
+// Stops early and reports exceeded if the user has more than max followers.
+func (c *Clients) GetFollowerIds(ctx context.Context, userId int64, max int) (ids []int64, exceeded bool, err error) {
+	const pageSize = 500
+	for offset := int32(0); ; offset += pageSize {
+		resp, err := c.UserClient.GetFollowersPaginated(ctx, &userpb.Pagination{
+			UserId: userId,
+			Limit:  pageSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		for _, u := range resp.Users {
+			ids = append(ids, u.UserId)
+		}
+		if len(ids) > max {
+			return nil, true, nil
+		}
+		if len(resp.Users) < pageSize {
+			return ids, false, nil
+		}
+	}
+}
+
+// GetUserGroupIds returns the ids of all groups the user is a member of, paging through GetUserGroupsPaginated.
+func (c *Clients) GetUserGroupIds(ctx context.Context, userId int64) ([]int64, error) {
+	const pageSize = 500
+	var ids []int64
+	for offset := int32(0); ; offset += pageSize {
+		resp, err := c.UserClient.GetUserGroupsPaginated(ctx, &userpb.Pagination{
+			UserId: userId,
+			Limit:  pageSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range resp.GroupArr {
+			ids = append(ids, g.GroupId)
+		}
+		if len(resp.GroupArr) < pageSize {
+			return ids, nil
+		}
+	}
+}
+
 func (c *Clients) GetAllGroupMemberIds(ctx context.Context, groupId int64) ([]int64, error) {
 
 	resp, err := c.UserClient.GetAllGroupMemberIds(ctx, &userpb.IdReq{Id: groupId})
@@ -141,6 +188,32 @@ func (c *Clients) CreatePostComment(ctx context.Context, userId, commenterId, po
 	return err
 }
 
+// MarkPostNotificationsRead marks the notifications of userId about a post as read
+func (c *Clients) MarkPostNotificationsRead(ctx context.Context, userId, postId int64) error {
+	req := &notifpb.MarkReadByEntityRequest{
+		UserId:         userId,
+		SourceService:  "posts",
+		SourceEntityId: postId,
+	}
+	if c.NotifsClient == nil {
+		return fmt.Errorf("NotifsClient is nil")
+	}
+	_, err := c.NotifsClient.MarkReadByEntity(ctx, req)
+	return err
+}
+
+// AttachImage records in the media service that an image is used by an entity of ownerId.
+// Fails with permission denied if the image belongs to another user.
+func (c *Clients) AttachImage(ctx context.Context, imageId, ownerId, entityId int64, entityType mediapb.AttachmentType) error {
+	_, err := c.MediaClient.AttachFile(ctx, &mediapb.FileAttachment{
+		FileId:   imageId,
+		OwnerId:  ownerId,
+		Type:     entityType,
+		EntityId: entityId,
+	})
+	return err
+}
+
 func (c *Clients) GetGroupBasicInfo(ctx context.Context, groupId int64) (models.Group, error) {
 	g, err := c.UserClient.GetGroupBasicInfo(ctx, &userpb.IdReq{Id: groupId})
 	if err != nil {
@@ -102,16 +102,19 @@ SELECT
 FROM comments c
 WHERE c.parent_id = $1
   AND c.deleted_at IS NULL
-ORDER BY c.created_at DESC 
+  AND ($5::timestamptz IS NULL OR (c.created_at, c.id) < ($5::timestamptz, $6::bigint))
+ORDER BY c.created_at DESC, c.id DESC
 OFFSET $3
 LIMIT $4
 `
 
 type GetCommentsByPostIdParams struct {
-	ParentID int64
-	UserID   int64
-	Offset   int32
-	Limit    int32
+	ParentID        int64
+	UserID          int64
+	Offset          int32
+	Limit           int32
+	CursorCreatedAt pgtype.Timestamptz
+	CursorID        int64
 }
 
 type GetCommentsByPostIdRow struct {
@@ -132,6 +135,8 @@ func (q *Queries) GetCommentsByPostId(ctx context.Context, arg GetCommentsByPost
 		arg.UserID,
 		arg.Offset,
 		arg.Limit,
+		arg.CursorCreatedAt,
+		arg.CursorID,
 	)
 	if err != nil {
 		return nil, err
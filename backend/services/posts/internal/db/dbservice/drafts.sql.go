@@ -0,0 +1,190 @@
+package dbservice
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getUserDrafts = `-- name: GetUserDrafts :many
+SELECT
+    p.id,
+    p.post_body,
+    COALESCE(p.group_id, 0)::bigint AS group_id,
+    p.audience,
+    p.status,
+    p.publish_at,
+    p.created_at,
+    p.updated_at,
+
+COALESCE(
+    (SELECT i.id
+     FROM images i
+     WHERE i.parent_id = p.id AND i.deleted_at IS NULL
+     ORDER BY i.sort_order ASC
+     LIMIT 1
+    ), 0
+)::bigint AS image,
+
+ COALESCE(
+        (
+            SELECT array_agg(pa.allowed_user_id ORDER BY pa.allowed_user_id)
+            FROM post_audience pa
+            WHERE pa.post_id = p.id
+              AND p.audience = 'selected'
+        ),
+        ARRAY[]::bigint[]
+    ) AS selected_audience
+
+FROM posts p
+WHERE p.creator_id = $1
+  AND p.status <> 'published'
+  AND p.deleted_at IS NULL
+ORDER BY p.created_at DESC, p.id DESC
+OFFSET $2
+LIMIT $3
+`
+
+type GetUserDraftsParams struct {
+	CreatorID int64
+	Offset    int32
+	Limit     int32
+}
+
+type GetUserDraftsRow struct {
+	ID               int64
+	PostBody         string
+	GroupID          int64
+	Audience         IntendedAudience
+	Status           PostStatus
+	PublishAt        pgtype.Timestamptz
+	CreatedAt        pgtype.Timestamptz
+	UpdatedAt        pgtype.Timestamptz
+	Image            int64
+	SelectedAudience []int64
+}
+
+// returns the creator's drafts and scheduled posts, newest first, paginated
+func (q *Queries) GetUserDrafts(ctx context.Context, arg GetUserDraftsParams) ([]GetUserDraftsRow, error) {
+	rows, err := q.db.Query(ctx, getUserDrafts, arg.CreatorID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUserDraftsRow{}
+	for rows.Next() {
+		var i GetUserDraftsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostBody,
+			&i.GroupID,
+			&i.Audience,
+			&i.Status,
+			&i.PublishAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Image,
+			&i.SelectedAudience,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updatePostStatus = `-- name: UpdatePostStatus :execrows
+UPDATE posts
+SET status = $3,
+    publish_at = $4,
+    -- a post enters the feeds at the time it is published
+    created_at = CASE WHEN $3 = 'published'::post_status THEN CURRENT_TIMESTAMP ELSE created_at END
+WHERE id = $1
+  AND creator_id = $2
+  AND status <> 'published'
+  AND deleted_at IS NULL
+`
+
+type UpdatePostStatusParams struct {
+	ID        int64
+	CreatorID int64
+	Status    PostStatus
+	PublishAt pgtype.Timestamptz
+}
+
+// moves a draft or scheduled post to another status
+// returns rows affected
+// 0 rows could mean the post was not found, is not owned by the creator, is deleted or is already published
+func (q *Queries) UpdatePostStatus(ctx context.Context, arg UpdatePostStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updatePostStatus,
+		arg.ID,
+		arg.CreatorID,
+		arg.Status,
+		arg.PublishAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const publishDuePosts = `-- name: PublishDuePosts :many
+UPDATE posts
+SET status = 'published',
+    created_at = CURRENT_TIMESTAMP
+WHERE id IN (
+    SELECT id
+    FROM posts
+    WHERE status = 'scheduled'
+      AND publish_at <= CURRENT_TIMESTAMP
+      AND deleted_at IS NULL
+    ORDER BY publish_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING
+    id,
+    post_body,
+    creator_id,
+    COALESCE(group_id, 0)::bigint AS group_id,
+    audience
+`
+
+type PublishDuePostsRow struct {
+	ID        int64
+	PostBody  string
+	CreatorID int64
+	GroupID   int64
+	Audience  IntendedAudience
+}
+
+// publishes up to limit scheduled posts whose publish time has passed
+// and returns them
+func (q *Queries) PublishDuePosts(ctx context.Context, limit int32) ([]PublishDuePostsRow, error) {
+	rows, err := q.db.Query(ctx, publishDuePosts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PublishDuePostsRow{}
+	for rows.Next() {
+		var i PublishDuePostsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostBody,
+			&i.CreatorID,
+			&i.GroupID,
+			&i.Audience,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
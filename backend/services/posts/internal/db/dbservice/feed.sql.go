@@ -15,6 +15,8 @@ SELECT
     p.audience,
     p.comments_count,
     p.reactions_count,
+    p.shares_count,
+    p.shared_post_id,
     p.last_commented_at,
     p.created_at,
     p.updated_at,
@@ -43,16 +45,20 @@ FROM posts p
 
 WHERE p.group_id = $1                    -- group id filter
   AND p.deleted_at IS NULL
+  AND p.status = 'published'               -- no drafts or scheduled posts
+  AND ($5::timestamptz IS NULL OR (p.created_at, p.id) < ($5::timestamptz, $6::bigint)) -- cursor: older than the last seen post
 GROUP BY p.id
-ORDER BY p.created_at DESC               -- newest first
+ORDER BY p.created_at DESC, p.id DESC    -- newest first
 LIMIT $3 OFFSET $4
 `
 
 type GetGroupPostsPaginatedParams struct {
-	GroupID pgtype.Int8
-	UserID  int64
-	Limit   int32
-	Offset  int32
+	GroupID         pgtype.Int8
+	UserID          int64
+	Limit           int32
+	Offset          int32
+	CursorCreatedAt pgtype.Timestamptz
+	CursorID        int64
 }
 
 type GetGroupPostsPaginatedRow struct {
@@ -63,6 +69,8 @@ type GetGroupPostsPaginatedRow struct {
 	Audience        IntendedAudience
 	CommentsCount   int32
 	ReactionsCount  int32
+	SharesCount     int32
+	SharedPostID    pgtype.Int8
 	LastCommentedAt pgtype.Timestamptz
 	CreatedAt       pgtype.Timestamptz
 	UpdatedAt       pgtype.Timestamptz
@@ -76,6 +84,8 @@ func (q *Queries) GetGroupPostsPaginated(ctx context.Context, arg GetGroupPostsP
 		arg.UserID,
 		arg.Limit,
 		arg.Offset,
+		arg.CursorCreatedAt,
+		arg.CursorID,
 	)
 	if err != nil {
 		return nil, err
@@ -92,6 +102,140 @@ func (q *Queries) GetGroupPostsPaginated(ctx context.Context, arg GetGroupPostsP
 			&i.Audience,
 			&i.CommentsCount,
 			&i.ReactionsCount,
+			&i.SharesCount,
+			&i.SharedPostID,
+			&i.LastCommentedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LikedByUser,
+			&i.Image,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getHomeTimeline = `-- name: GetHomeTimeline :many
+SELECT
+    p.id,
+    p.post_body,
+    p.creator_id,
+    p.group_id,
+    p.audience,
+    p.comments_count,
+    p.reactions_count,
+    p.shares_count,
+    p.shared_post_id,
+    p.last_commented_at,
+    p.created_at,
+    p.updated_at,
+
+    EXISTS (
+        SELECT 1 FROM reactions r
+        WHERE r.content_id = p.id
+          AND r.user_id = $1
+          AND r.deleted_at IS NULL
+    ) AS liked_by_user,
+
+COALESCE(
+    (SELECT i.id
+     FROM images i
+     WHERE i.parent_id = p.id AND i.deleted_at IS NULL
+     ORDER BY i.sort_order ASC
+     LIMIT 1
+    ), 0
+)::bigint AS image
+
+FROM posts p
+
+WHERE p.deleted_at IS NULL
+  AND p.status = 'published'
+  AND (
+       -- posts in the requester's groups (membership checked in users service)
+       p.group_id = ANY($3::bigint[])
+
+       -- the requester's own posts
+       OR (p.group_id IS NULL AND p.creator_id = $1)
+
+       -- followed users' posts the requester may see
+       OR (p.group_id IS NULL
+           AND p.creator_id = ANY($2::bigint[])
+           AND (
+                p.audience IN ('everyone', 'followers')
+                OR (p.audience = 'selected' AND EXISTS (
+                    SELECT 1
+                    FROM post_audience pa
+                    WHERE pa.post_id = p.id
+                      AND pa.allowed_user_id = $1
+                ))
+           ))
+  )
+  AND ($6::timestamptz IS NULL OR (p.created_at, p.id) < ($6::timestamptz, $7::bigint))
+ORDER BY p.created_at DESC, p.id DESC
+OFFSET $4 LIMIT $5
+`
+
+type GetHomeTimelineParams struct {
+	UserID          int64
+	FollowingIds    []int64
+	GroupIds        []int64
+	Offset          int32
+	Limit           int32
+	CursorCreatedAt pgtype.Timestamptz
+	CursorID        int64
+}
+
+type GetHomeTimelineRow struct {
+	ID              int64
+	PostBody        string
+	CreatorID       int64
+	GroupID         pgtype.Int8
+	Audience        IntendedAudience
+	CommentsCount   int32
+	ReactionsCount  int32
+	SharesCount     int32
+	SharedPostID    pgtype.Int8
+	LastCommentedAt pgtype.Timestamptz
+	CreatedAt       pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+	LikedByUser     bool
+	Image           int64
+}
+
+// home timeline: followed users' posts of every audience the requester may see,
+// the requester's own posts and posts of the requester's groups, newest first
+func (q *Queries) GetHomeTimeline(ctx context.Context, arg GetHomeTimelineParams) ([]GetHomeTimelineRow, error) {
+	rows, err := q.db.Query(ctx, getHomeTimeline,
+		arg.UserID,
+		arg.FollowingIds,
+		arg.GroupIds,
+		arg.Offset,
+		arg.Limit,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetHomeTimelineRow{}
+	for rows.Next() {
+		var i GetHomeTimelineRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostBody,
+			&i.CreatorID,
+			&i.GroupID,
+			&i.Audience,
+			&i.CommentsCount,
+			&i.ReactionsCount,
+			&i.SharesCount,
+			&i.SharedPostID,
 			&i.LastCommentedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -115,6 +259,8 @@ SELECT
     p.creator_id,
     p.comments_count,
     p.reactions_count,
+    p.shares_count,
+    p.shared_post_id,
     p.last_commented_at,
     p.created_at,
     p.updated_at,
@@ -142,6 +288,7 @@ COALESCE(
 
 
 WHERE p.deleted_at IS NULL
+  AND p.status = 'published'
   AND (
       -- SELECTED audience → must be selected AND still following creator
        (p.audience = 'selected' 
@@ -156,15 +303,18 @@ WHERE p.deleted_at IS NULL
        -- FOLLOWERS → allowed if creator ∈ list passed in
        OR (p.audience = 'followers' AND p.creator_id = ANY($2::bigint[]))
   )
-ORDER BY p.created_at DESC
+  AND ($5::timestamptz IS NULL OR (p.created_at, p.id) < ($5::timestamptz, $6::bigint))
+ORDER BY p.created_at DESC, p.id DESC
 OFFSET $3 LIMIT $4
 `
 
 type GetPersonalizedFeedParams struct {
-	UserID  int64
-	Column2 []int64
-	Offset  int32
-	Limit   int32
+	UserID          int64
+	Column2         []int64
+	Offset          int32
+	Limit           int32
+	CursorCreatedAt pgtype.Timestamptz
+	CursorID        int64
 }
 
 type GetPersonalizedFeedRow struct {
@@ -173,6 +323,8 @@ type GetPersonalizedFeedRow struct {
 	CreatorID       int64
 	CommentsCount   int32
 	ReactionsCount  int32
+	SharesCount     int32
+	SharedPostID    pgtype.Int8
 	LastCommentedAt pgtype.Timestamptz
 	CreatedAt       pgtype.Timestamptz
 	UpdatedAt       pgtype.Timestamptz
@@ -186,6 +338,8 @@ func (q *Queries) GetPersonalizedFeed(ctx context.Context, arg GetPersonalizedFe
 		arg.Column2,
 		arg.Offset,
 		arg.Limit,
+		arg.CursorCreatedAt,
+		arg.CursorID,
 	)
 	if err != nil {
 		return nil, err
@@ -200,6 +354,8 @@ func (q *Queries) GetPersonalizedFeed(ctx context.Context, arg GetPersonalizedFe
 			&i.CreatorID,
 			&i.CommentsCount,
 			&i.ReactionsCount,
+			&i.SharesCount,
+			&i.SharedPostID,
 			&i.LastCommentedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -223,6 +379,8 @@ SELECT
     p.creator_id,
     p.comments_count,
     p.reactions_count,
+    p.shares_count,
+    p.shared_post_id,
     p.last_commented_at,
     p.created_at,
     p.updated_at,
@@ -248,15 +406,19 @@ FROM posts p
 
 
 WHERE p.deleted_at IS NULL
+  AND p.status = 'published'
   AND p.audience = 'everyone'
-ORDER BY p.created_at DESC
+  AND ($4::timestamptz IS NULL OR (p.created_at, p.id) < ($4::timestamptz, $5::bigint))
+ORDER BY p.created_at DESC, p.id DESC
 OFFSET $2 LIMIT $3
 `
 
 type GetPublicFeedParams struct {
-	UserID int64
-	Offset int32
-	Limit  int32
+	UserID          int64
+	Offset          int32
+	Limit           int32
+	CursorCreatedAt pgtype.Timestamptz
+	CursorID        int64
 }
 
 type GetPublicFeedRow struct {
@@ -265,6 +427,8 @@ type GetPublicFeedRow struct {
 	CreatorID       int64
 	CommentsCount   int32
 	ReactionsCount  int32
+	SharesCount     int32
+	SharedPostID    pgtype.Int8
 	LastCommentedAt pgtype.Timestamptz
 	CreatedAt       pgtype.Timestamptz
 	UpdatedAt       pgtype.Timestamptz
@@ -273,7 +437,13 @@ type GetPublicFeedRow struct {
 }
 
 func (q *Queries) GetPublicFeed(ctx context.Context, arg GetPublicFeedParams) ([]GetPublicFeedRow, error) {
-	rows, err := q.db.Query(ctx, getPublicFeed, arg.UserID, arg.Offset, arg.Limit)
+	rows, err := q.db.Query(ctx, getPublicFeed,
+		arg.UserID,
+		arg.Offset,
+		arg.Limit,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -287,6 +457,8 @@ func (q *Queries) GetPublicFeed(ctx context.Context, arg GetPublicFeedParams) ([
 			&i.CreatorID,
 			&i.CommentsCount,
 			&i.ReactionsCount,
+			&i.SharesCount,
+			&i.SharedPostID,
 			&i.LastCommentedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -311,6 +483,8 @@ SELECT
     p.creator_id,
     p.comments_count,
     p.reactions_count,
+    p.shares_count,
+    p.shared_post_id,
     p.last_commented_at,
     p.created_at,
     p.updated_at,
@@ -339,6 +513,7 @@ FROM posts p
 WHERE p.creator_id = $1                      -- target user we are viewing
   AND p.group_id IS NULL                     -- exclude group posts
   AND p.deleted_at IS NULL
+  AND p.status = 'published'                 -- drafts are listed separately
 
   AND (                    
         p.creator_id = $2    -- If viewer *is* the creator — show all posts                
@@ -355,18 +530,21 @@ WHERE p.creator_id = $1                      -- target user we are viewing
             )
         )
      )
+  AND ($6::timestamptz IS NULL OR (p.created_at, p.id) < ($6::timestamptz, $7::bigint))
 
 GROUP BY p.id
-ORDER BY p.created_at DESC
+ORDER BY p.created_at DESC, p.id DESC
 LIMIT $4 OFFSET $5
 `
 
 type GetUserPostsPaginatedParams struct {
-	CreatorID int64
-	UserID    int64
-	Column3   bool
-	Limit     int32
-	Offset    int32
+	CreatorID       int64
+	UserID          int64
+	Column3         bool
+	Limit           int32
+	Offset          int32
+	CursorCreatedAt pgtype.Timestamptz
+	CursorID        int64
 }
 
 type GetUserPostsPaginatedRow struct {
@@ -375,6 +553,8 @@ type GetUserPostsPaginatedRow struct {
 	CreatorID       int64
 	CommentsCount   int32
 	ReactionsCount  int32
+	SharesCount     int32
+	SharedPostID    pgtype.Int8
 	LastCommentedAt pgtype.Timestamptz
 	CreatedAt       pgtype.Timestamptz
 	UpdatedAt       pgtype.Timestamptz
@@ -390,6 +570,8 @@ func (q *Queries) GetUserPostsPaginated(ctx context.Context, arg GetUserPostsPag
 		arg.Column3,
 		arg.Limit,
 		arg.Offset,
+		arg.CursorCreatedAt,
+		arg.CursorID,
 	)
 	if err != nil {
 		return nil, err
@@ -404,6 +586,8 @@ func (q *Queries) GetUserPostsPaginated(ctx context.Context, arg GetUserPostsPag
 			&i.CreatorID,
 			&i.CommentsCount,
 			&i.ReactionsCount,
+			&i.SharesCount,
+			&i.SharedPostID,
 			&i.LastCommentedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -15,6 +15,7 @@ u1 AS (
     JOIN posts p ON p.id = r.content_id
     WHERE p.creator_id = $1
       AND p.audience = 'everyone'
+      AND p.status = 'published'
       AND r.user_id <> $1
 ),
 
@@ -26,6 +27,7 @@ u2 AS (
     JOIN posts p ON p.id = c.parent_id
     WHERE p.creator_id = $1
       AND p.audience = 'everyone'
+      AND p.status = 'published'
       AND c.comment_creator_id <> $1
 ),
 
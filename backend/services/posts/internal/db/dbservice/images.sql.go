@@ -72,3 +72,42 @@ func (q *Queries) RemoveImages(ctx context.Context, arg []int64) error {
 	_, err := q.db.Exec(ctx, removeImages, arg)
 	return err
 }
+
+const getUsedImageIds = `-- name: GetUsedImageIds :many
+SELECT i.id
+FROM images i
+WHERE i.id = ANY($1::bigint[])
+  AND i.deleted_at IS NULL
+UNION
+SELECT img.id
+FROM entity_revisions r
+CROSS JOIN LATERAL unnest(r.image_ids) AS img(id)
+LEFT JOIN posts p ON p.id = r.entity_id
+LEFT JOIN comments c ON c.id = r.entity_id
+LEFT JOIN events e ON e.id = r.entity_id
+WHERE r.image_ids && $1::bigint[]
+  AND img.id = ANY($1::bigint[])
+  AND COALESCE(p.deleted_at, c.deleted_at, e.deleted_at) IS NULL
+`
+
+// returns the given image ids that are attached to a not deleted entity,
+// either currently or in a revision of it
+func (q *Queries) GetUsedImageIds(ctx context.Context, arg []int64) ([]int64, error) {
+	rows, err := q.db.Query(ctx, getUsedImageIds, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -13,6 +13,7 @@ const (
 	ContentTypePost    ContentType = "post"
 	ContentTypeComment ContentType = "comment"
 	ContentTypeEvent   ContentType = "event"
+	ContentTypePoll    ContentType = "poll"
 )
 
 func (e *ContentType) Scan(src interface{}) error {
@@ -54,7 +55,8 @@ func (e ContentType) Valid() bool {
 	switch e {
 	case ContentTypePost,
 		ContentTypeComment,
-		ContentTypeEvent:
+		ContentTypeEvent,
+		ContentTypePoll:
 		return true
 	}
 	return false
@@ -115,6 +117,59 @@ func (e IntendedAudience) Valid() bool {
 	return false
 }
 
+type PostStatus string
+
+const (
+	PostStatusDraft     PostStatus = "draft"
+	PostStatusScheduled PostStatus = "scheduled"
+	PostStatusPublished PostStatus = "published"
+)
+
+func (e *PostStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PostStatus(s)
+	case string:
+		*e = PostStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PostStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPostStatus struct {
+	PostStatus PostStatus
+	Valid      bool // Valid is true if PostStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPostStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PostStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PostStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPostStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PostStatus), nil
+}
+
+func (e PostStatus) Valid() bool {
+	switch e {
+	case PostStatusDraft,
+		PostStatusScheduled,
+		PostStatusPublished:
+		return true
+	}
+	return false
+}
+
 type Comment struct {
 	ID               int64
 	CommentCreatorID int64
@@ -179,6 +234,35 @@ type Post struct {
 	CreatedAt       pgtype.Timestamptz
 	UpdatedAt       pgtype.Timestamptz
 	DeletedAt       pgtype.Timestamptz
+	Status          PostStatus
+	PublishAt       pgtype.Timestamptz
+	SharedPostID    pgtype.Int8
+	SharesCount     int32
+}
+
+type Poll struct {
+	ID             int64
+	ParentID       int64
+	Question       string
+	MultipleChoice bool
+	ClosesAt       pgtype.Timestamptz
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+	DeletedAt      pgtype.Timestamptz
+}
+
+type PollOption struct {
+	ID         int64
+	PollID     int64
+	OptionText string
+	SortOrder  int32
+}
+
+type PollVote struct {
+	PollID    int64
+	OptionID  int64
+	UserID    int64
+	CreatedAt pgtype.Timestamptz
 }
 
 type PostAudience struct {
@@ -0,0 +1,253 @@
+package dbservice
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createPoll = `-- name: CreatePoll :one
+INSERT INTO polls (parent_id, question, multiple_choice, closes_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id
+`
+
+type CreatePollParams struct {
+	ParentID       int64
+	Question       string
+	MultipleChoice bool
+	ClosesAt       pgtype.Timestamptz
+}
+
+func (q *Queries) CreatePoll(ctx context.Context, arg CreatePollParams) (int64, error) {
+	row := q.db.QueryRow(ctx, createPoll,
+		arg.ParentID,
+		arg.Question,
+		arg.MultipleChoice,
+		arg.ClosesAt,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertPollOptions = `-- name: InsertPollOptions :execrows
+INSERT INTO poll_options (poll_id, option_text, sort_order)
+SELECT $1::bigint,
+       o.option_text,
+       o.sort_order
+FROM unnest($2::text[]) WITH ORDINALITY AS o(option_text, sort_order)
+`
+
+type InsertPollOptionsParams struct {
+	PollID  int64
+	Options []string
+}
+
+// options are stored in the given order
+func (q *Queries) InsertPollOptions(ctx context.Context, arg InsertPollOptionsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertPollOptions, arg.PollID, arg.Options)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getPollsByParentIds = `-- name: GetPollsByParentIds :many
+SELECT
+    pl.id,
+    pl.parent_id,
+    pl.question,
+    pl.multiple_choice,
+    pl.closes_at,
+    (pl.closes_at IS NOT NULL AND pl.closes_at <= CURRENT_TIMESTAMP) AS closed,
+
+    (
+        SELECT COUNT(DISTINCT v.user_id)
+        FROM poll_votes v
+        WHERE v.poll_id = pl.id
+    )::int AS voters_count,
+
+    COALESCE(
+        (
+            SELECT array_agg(v.option_id ORDER BY v.option_id)
+            FROM poll_votes v
+            WHERE v.poll_id = pl.id
+              AND v.user_id = $2::bigint
+        ),
+        ARRAY[]::bigint[]
+    ) AS voted_option_ids
+
+FROM polls pl
+WHERE pl.parent_id = ANY($1::bigint[])
+  AND pl.deleted_at IS NULL
+`
+
+type GetPollsByParentIdsParams struct {
+	ParentIds []int64
+	UserID    int64
+}
+
+type GetPollsByParentIdsRow struct {
+	ID             int64
+	ParentID       int64
+	Question       string
+	MultipleChoice bool
+	ClosesAt       pgtype.Timestamptz
+	Closed         bool
+	VotersCount    int32
+	VotedOptionIds []int64
+}
+
+// returns the polls attached to the given posts,
+// along with the options the user has voted for
+func (q *Queries) GetPollsByParentIds(ctx context.Context, arg GetPollsByParentIdsParams) ([]GetPollsByParentIdsRow, error) {
+	rows, err := q.db.Query(ctx, getPollsByParentIds, arg.ParentIds, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPollsByParentIdsRow{}
+	for rows.Next() {
+		var i GetPollsByParentIdsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ParentID,
+			&i.Question,
+			&i.MultipleChoice,
+			&i.ClosesAt,
+			&i.Closed,
+			&i.VotersCount,
+			&i.VotedOptionIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollOptions = `-- name: GetPollOptions :many
+SELECT
+    o.id,
+    o.poll_id,
+    o.option_text,
+    (
+        SELECT COUNT(*)
+        FROM poll_votes v
+        WHERE v.option_id = o.id
+    )::int AS votes_count
+FROM poll_options o
+WHERE o.poll_id = ANY($1::bigint[])
+ORDER BY o.poll_id, o.sort_order
+`
+
+type GetPollOptionsRow struct {
+	ID         int64
+	PollID     int64
+	OptionText string
+	VotesCount int32
+}
+
+// returns the options of the given polls in display order, with their vote counts
+func (q *Queries) GetPollOptions(ctx context.Context, pollIds []int64) ([]GetPollOptionsRow, error) {
+	rows, err := q.db.Query(ctx, getPollOptions, pollIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPollOptionsRow{}
+	for rows.Next() {
+		var i GetPollOptionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PollID,
+			&i.OptionText,
+			&i.VotesCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollForVote = `-- name: GetPollForVote :one
+SELECT
+    pl.id,
+    pl.multiple_choice,
+    (pl.closes_at IS NOT NULL AND pl.closes_at <= CURRENT_TIMESTAMP) AS closed
+FROM polls pl
+JOIN posts p ON p.id = pl.parent_id
+WHERE pl.id = $1
+  AND pl.deleted_at IS NULL
+  AND p.deleted_at IS NULL
+  AND p.status = 'published'
+FOR UPDATE OF pl
+`
+
+type GetPollForVoteRow struct {
+	ID             int64
+	MultipleChoice bool
+	Closed         bool
+}
+
+// locks the poll row so that a vote can't race with another vote of the same user
+func (q *Queries) GetPollForVote(ctx context.Context, id int64) (GetPollForVoteRow, error) {
+	row := q.db.QueryRow(ctx, getPollForVote, id)
+	var i GetPollForVoteRow
+	err := row.Scan(&i.ID, &i.MultipleChoice, &i.Closed)
+	return i, err
+}
+
+const deletePollVotes = `-- name: DeletePollVotes :execrows
+DELETE FROM poll_votes
+WHERE poll_id = $1
+  AND user_id = $2
+`
+
+type DeletePollVotesParams struct {
+	PollID int64
+	UserID int64
+}
+
+func (q *Queries) DeletePollVotes(ctx context.Context, arg DeletePollVotesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePollVotes, arg.PollID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertPollVotes = `-- name: InsertPollVotes :execrows
+INSERT INTO poll_votes (poll_id, option_id, user_id)
+SELECT o.poll_id,
+       o.id,
+       $2::bigint
+FROM poll_options o
+WHERE o.poll_id = $1::bigint
+  AND o.id = ANY($3::bigint[])
+ON CONFLICT (option_id, user_id) DO NOTHING
+`
+
+type InsertPollVotesParams struct {
+	PollID    int64
+	UserID    int64
+	OptionIds []int64
+}
+
+// option ids that don't belong to the poll are skipped,
+// so rows affected lower than the number of options means some were invalid
+func (q *Queries) InsertPollVotes(ctx context.Context, arg InsertPollVotesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertPollVotes, arg.PollID, arg.UserID, arg.OptionIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -17,8 +17,8 @@ func (q *Queries) ClearPostAudience(ctx context.Context, postID int64) error {
 }
 
 const createPost = `-- name: CreatePost :one
-INSERT INTO posts (post_body, creator_id, group_id, audience)
-VALUES ($1, $2, $3, $4)
+INSERT INTO posts (post_body, creator_id, group_id, audience, status, publish_at)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING id
 `
 
@@ -27,6 +27,8 @@ type CreatePostParams struct {
 	CreatorID int64
 	GroupID   pgtype.Int8
 	Audience  IntendedAudience
+	Status    PostStatus
+	PublishAt pgtype.Timestamptz
 }
 
 func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (int64, error) {
@@ -35,6 +37,8 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (int64,
 		arg.CreatorID,
 		arg.GroupID,
 		arg.Audience,
+		arg.Status,
+		arg.PublishAt,
 	)
 	var id int64
 	err := row.Scan(&id)
@@ -89,6 +93,8 @@ SELECT
     p.audience,
     p.comments_count,
     p.reactions_count,
+    p.shares_count,
+    p.shared_post_id,
     p.last_commented_at,
     p.created_at,
     p.updated_at,
@@ -111,6 +117,7 @@ SELECT
 FROM posts p
 WHERE p.group_id = $1
   AND p.deleted_at IS NULL
+  AND p.status = 'published'
 
 ORDER BY popularity_score DESC, p.created_at DESC
 LIMIT 1
@@ -124,6 +131,8 @@ type GetMostPopularPostInGroupRow struct {
 	Audience        IntendedAudience
 	CommentsCount   int32
 	ReactionsCount  int32
+	SharesCount     int32
+	SharedPostID    pgtype.Int8
 	LastCommentedAt pgtype.Timestamptz
 	CreatedAt       pgtype.Timestamptz
 	UpdatedAt       pgtype.Timestamptz
@@ -142,6 +151,8 @@ func (q *Queries) GetMostPopularPostInGroup(ctx context.Context, groupID pgtype.
 		&i.Audience,
 		&i.CommentsCount,
 		&i.ReactionsCount,
+		&i.SharesCount,
+		&i.SharedPostID,
 		&i.LastCommentedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -187,9 +198,13 @@ SELECT
     p.audience,
     p.comments_count,
     p.reactions_count,
+    p.shares_count,
+    p.shared_post_id,
     p.last_commented_at,
     p.created_at,
     p.updated_at,
+    p.status,
+    p.publish_at,
 
     EXISTS (
         SELECT 1 FROM reactions r
@@ -236,9 +251,13 @@ type GetPostByIDRow struct {
 	Audience         IntendedAudience
 	CommentsCount    int32
 	ReactionsCount   int32
+	SharesCount      int32
+	SharedPostID     pgtype.Int8
 	LastCommentedAt  pgtype.Timestamptz
 	CreatedAt        pgtype.Timestamptz
 	UpdatedAt        pgtype.Timestamptz
+	Status           PostStatus
+	PublishAt        pgtype.Timestamptz
 	LikedByUser      bool
 	Image            int64
 	SelectedAudience []int64
@@ -255,9 +274,13 @@ func (q *Queries) GetPostByID(ctx context.Context, arg GetPostByIDParams) (GetPo
 		&i.Audience,
 		&i.CommentsCount,
 		&i.ReactionsCount,
+		&i.SharesCount,
+		&i.SharedPostID,
 		&i.LastCommentedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
 		&i.LikedByUser,
 		&i.Image,
 		&i.SelectedAudience,
@@ -10,12 +10,18 @@ type Querier interface {
 	CanUserSeeEntity(ctx context.Context, arg CanUserSeeEntityParams) (bool, error)
 	ClearPostAudience(ctx context.Context, postID int64) error
 	CreateComment(ctx context.Context, arg CreateCommentParams) (int64, error)
+	CreateCommentRevision(ctx context.Context, arg CreateCommentRevisionParams) (int64, error)
 	CreateEvent(ctx context.Context, arg CreateEventParams) (int64, error)
+	CreateEventRevision(ctx context.Context, arg CreateEventRevisionParams) (int64, error)
+	CreatePoll(ctx context.Context, arg CreatePollParams) (int64, error)
 	CreatePost(ctx context.Context, arg CreatePostParams) (int64, error)
+	CreatePostRevision(ctx context.Context, arg CreatePostRevisionParams) (int64, error)
+	CreateShare(ctx context.Context, arg CreateShareParams) (int64, error)
 	DeleteComment(ctx context.Context, arg DeleteCommentParams) (int64, error)
 	DeleteEvent(ctx context.Context, arg DeleteEventParams) (int64, error)
 	DeleteEventResponse(ctx context.Context, arg DeleteEventResponseParams) (int64, error)
 	DeleteImage(ctx context.Context, id int64) (int64, error)
+	DeletePollVotes(ctx context.Context, arg DeletePollVotesParams) (int64, error)
 	DeletePost(ctx context.Context, arg DeletePostParams) (int64, error)
 	EditComment(ctx context.Context, arg EditCommentParams) (int64, error)
 	EditEvent(ctx context.Context, arg EditEventParams) (int64, error)
@@ -23,20 +29,54 @@ type Querier interface {
 	GetBasicPostByID(ctx context.Context, postId int64) (GetBasicPostByIDRow, error)
 	GetCommentsByPostId(ctx context.Context, arg GetCommentsByPostIdParams) ([]GetCommentsByPostIdRow, error)
 	GetEntityCreatorAndGroup(ctx context.Context, id int64) (GetEntityCreatorAndGroupRow, error)
+	GetEntityRevisions(ctx context.Context, arg GetEntityRevisionsParams) ([]GetEntityRevisionsRow, error)
 	GetEventsByGroupId(ctx context.Context, arg GetEventsByGroupIdParams) ([]GetEventsByGroupIdRow, error)
 	GetGroupPostsPaginated(ctx context.Context, arg GetGroupPostsPaginatedParams) ([]GetGroupPostsPaginatedRow, error)
+	// home timeline: followed users' posts of every audience the requester may see,
+	// the requester's own posts and posts of the requester's groups, newest first
+	GetHomeTimeline(ctx context.Context, arg GetHomeTimelineParams) ([]GetHomeTimelineRow, error)
 	GetImages(ctx context.Context, parentID int64) (int64, error)
 	GetLatestCommentforPostId(ctx context.Context, arg GetLatestCommentforPostIdParams) (GetLatestCommentforPostIdRow, error)
 	GetMostPopularPostInGroup(ctx context.Context, groupID pgtype.Int8) (GetMostPopularPostInGroupRow, error)
+	GetOrCreateSavedCollection(ctx context.Context, arg GetOrCreateSavedCollectionParams) (int64, error)
 	GetPostAudienceForComment(ctx context.Context, postID int64) (string, error)
 	GetPersonalizedFeed(ctx context.Context, arg GetPersonalizedFeedParams) ([]GetPersonalizedFeedRow, error)
+	GetPollForVote(ctx context.Context, id int64) (GetPollForVoteRow, error)
+	GetPollOptions(ctx context.Context, pollIds []int64) ([]GetPollOptionsRow, error)
+	GetPollsByParentIds(ctx context.Context, arg GetPollsByParentIdsParams) ([]GetPollsByParentIdsRow, error)
 	GetPostAudience(ctx context.Context, postID int64) ([]int64, error)
 	GetPostByID(ctx context.Context, arg GetPostByIDParams) (GetPostByIDRow, error)
+	GetPostForShare(ctx context.Context, id int64) (GetPostForShareRow, error)
+	// returns the fields deciding whether a post belongs on its audience's timelines,
+	// including deleted and unpublished posts so they can be removed
+	GetPostForTimeline(ctx context.Context, id int64) (GetPostForTimelineRow, error)
 	GetPublicFeed(ctx context.Context, arg GetPublicFeedParams) ([]GetPublicFeedRow, error)
+	// returns the posts among ids of a ranked snapshot that are still visible,
+	// public posts when group id is null, else posts of the group. Rows are in no particular order
+	GetRankedFeedPosts(ctx context.Context, arg GetRankedFeedPostsParams) ([]GetRankedFeedPostsRow, error)
+	// ranks the posts created between since and as of by reactions and comments with time decay,
+	// public posts when group id is null, else posts of the group. Scores are computed at as of,
+	// so the same arguments always give the same order for the same counts
+	GetRankedPostIds(ctx context.Context, arg GetRankedPostIdsParams) ([]int64, error)
+	GetSavedCollections(ctx context.Context, userID int64) ([]GetSavedCollectionsRow, error)
+	GetSavedPosts(ctx context.Context, arg GetSavedPostsParams) ([]GetSavedPostsRow, error)
+	GetSharedPosts(ctx context.Context, arg GetSharedPostsParams) ([]GetSharedPostsRow, error)
+	// returns the posts among ids that still belong in the user's personalized feed
+	GetTimelinePosts(ctx context.Context, arg GetTimelinePostsParams) ([]GetTimelinePostsRow, error)
+	// returns, in pages, the ids of every user that has interacted with posts, for timeline backfills
+	GetTimelineUserIds(ctx context.Context, arg GetTimelineUserIdsParams) ([]int64, error)
+	// returns the given image ids that are attached to a not deleted entity,
+	// either currently or in a revision of it
+	GetUsedImageIds(ctx context.Context, arg []int64) ([]int64, error)
+	GetUserDrafts(ctx context.Context, arg GetUserDraftsParams) ([]GetUserDraftsRow, error)
 	// pagination
 	GetUserPostsPaginated(ctx context.Context, arg GetUserPostsPaginatedParams) ([]GetUserPostsPaginatedRow, error)
 	GetWhoLikedEntityId(ctx context.Context, arg GetWhoLikedEntityIdParams) ([]int64, error)
+	InsertPollOptions(ctx context.Context, arg InsertPollOptionsParams) (int64, error)
+	InsertPollVotes(ctx context.Context, arg InsertPollVotesParams) (int64, error)
 	InsertPostAudience(ctx context.Context, arg InsertPostAudienceParams) (int64, error)
+	PublishDuePosts(ctx context.Context, limit int32) ([]PublishDuePostsRow, error)
+	SaveEntity(ctx context.Context, arg SaveEntityParams) error
 	// U1: Users who liked one or more of *your public posts*
 	// U2: Users who commented on your public posts
 	// U3: Users who liked the same posts as you
@@ -44,7 +84,9 @@ type Querier interface {
 	// Combine scores
 	SuggestUsersByPostActivity(ctx context.Context, creatorID int64) ([]int64, error)
 	ToggleOrInsertReaction(ctx context.Context, arg ToggleOrInsertReactionParams) (ToggleOrInsertReactionResult, error)
+	UnsaveEntity(ctx context.Context, arg UnsaveEntityParams) (int64, error)
 	UpdatePostAudience(ctx context.Context, arg UpdatePostAudienceParams) (int64, error)
+	UpdatePostStatus(ctx context.Context, arg UpdatePostStatusParams) (int64, error)
 	UpsertEventResponse(ctx context.Context, arg UpsertEventResponseParams) (int64, error)
 	UpsertImage(ctx context.Context, arg UpsertImageParams) error
 }
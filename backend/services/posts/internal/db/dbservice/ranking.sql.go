@@ -0,0 +1,176 @@
+package dbservice
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getRankedFeedPosts = `-- name: GetRankedFeedPosts :many
+SELECT
+    p.id,
+    p.post_body,
+    p.creator_id,
+    p.group_id,
+    p.audience,
+    p.comments_count,
+    p.reactions_count,
+    p.shares_count,
+    p.shared_post_id,
+    p.last_commented_at,
+    p.created_at,
+    p.updated_at,
+
+    EXISTS (
+        SELECT 1 FROM reactions r
+        WHERE r.content_id = p.id
+          AND r.user_id = $1
+          AND r.deleted_at IS NULL
+    ) AS liked_by_user,
+
+COALESCE(
+    (SELECT i.id
+     FROM images i
+     WHERE i.parent_id = p.id AND i.deleted_at IS NULL
+     ORDER BY i.sort_order ASC
+     LIMIT 1
+    ), 0
+)::bigint AS image
+
+FROM posts p
+WHERE p.id = ANY($2::bigint[])
+  AND p.deleted_at IS NULL
+  AND p.status = 'published'
+  -- checked again as posts may have changed since the snapshot was taken
+  AND (
+       ($3::bigint IS NULL AND p.audience = 'everyone')
+       OR p.group_id = $3::bigint
+  )
+`
+
+type GetRankedFeedPostsParams struct {
+	UserID  int64
+	Ids     []int64
+	GroupID pgtype.Int8
+}
+
+type GetRankedFeedPostsRow struct {
+	ID              int64
+	PostBody        string
+	CreatorID       int64
+	GroupID         pgtype.Int8
+	Audience        IntendedAudience
+	CommentsCount   int32
+	ReactionsCount  int32
+	SharesCount     int32
+	SharedPostID    pgtype.Int8
+	LastCommentedAt pgtype.Timestamptz
+	CreatedAt       pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+	LikedByUser     bool
+	Image           int64
+}
+
+// returns the posts among ids of a ranked snapshot that are still visible,
+// public posts when group id is null, else posts of the group. Rows are in no particular order
+func (q *Queries) GetRankedFeedPosts(ctx context.Context, arg GetRankedFeedPostsParams) ([]GetRankedFeedPostsRow, error) {
+	rows, err := q.db.Query(ctx, getRankedFeedPosts, arg.UserID, arg.Ids, arg.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetRankedFeedPostsRow{}
+	for rows.Next() {
+		var i GetRankedFeedPostsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostBody,
+			&i.CreatorID,
+			&i.GroupID,
+			&i.Audience,
+			&i.CommentsCount,
+			&i.ReactionsCount,
+			&i.SharesCount,
+			&i.SharedPostID,
+			&i.LastCommentedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LikedByUser,
+			&i.Image,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRankedPostIds = `-- name: GetRankedPostIds :many
+SELECT p.id
+FROM posts p
+WHERE p.deleted_at IS NULL
+  AND p.status = 'published'
+  AND (
+       ($1::bigint IS NULL AND p.audience = 'everyone')
+       OR p.group_id = $1::bigint
+  )
+  AND p.created_at >= $3::timestamptz       -- ranking window
+  AND p.created_at <= $2::timestamptz       -- snapshot time
+ORDER BY (
+    -- engagement, halving every half life since creation
+    ($4::float8 * p.reactions_count + $5::float8 * p.comments_count + 1)
+        * power(0.5, GREATEST(EXTRACT(EPOCH FROM ($2::timestamptz - p.created_at))::float8, 0) / 3600 / $7::float8)
+    -- recent comment activity, halving every half life since the last comment
+    + $6::float8 * COALESCE(
+        power(0.5, GREATEST(EXTRACT(EPOCH FROM ($2::timestamptz - p.last_commented_at))::float8, 0) / 3600 / $7::float8),
+        0
+    )
+) DESC, p.id DESC
+LIMIT $8
+`
+
+type GetRankedPostIdsParams struct {
+	GroupID        pgtype.Int8
+	AsOf           pgtype.Timestamptz
+	Since          pgtype.Timestamptz
+	ReactionWeight float64
+	CommentWeight  float64
+	ActivityWeight float64
+	HalfLifeHours  float64
+	Limit          int32
+}
+
+// ranks the posts created between since and as of by reactions and comments with time decay,
+// public posts when group id is null, else posts of the group. Scores are computed at as of,
+// so the same arguments always give the same order for the same counts
+func (q *Queries) GetRankedPostIds(ctx context.Context, arg GetRankedPostIdsParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, getRankedPostIds,
+		arg.GroupID,
+		arg.AsOf,
+		arg.Since,
+		arg.ReactionWeight,
+		arg.CommentWeight,
+		arg.ActivityWeight,
+		arg.HalfLifeHours,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,206 @@
+package dbservice
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createPostRevision = `-- name: CreatePostRevision :execrows
+INSERT INTO entity_revisions (entity_id, content_type, editor_id, body, image_ids, audience, audience_ids)
+SELECT
+    p.id,
+    'post',
+    $2,
+    p.post_body,
+    COALESCE(
+        (
+            SELECT array_agg(i.id ORDER BY i.sort_order)
+            FROM images i
+            WHERE i.parent_id = p.id
+              AND i.deleted_at IS NULL
+        ),
+        ARRAY[]::bigint[]
+    ),
+    p.audience,
+    COALESCE(
+        (
+            SELECT array_agg(pa.allowed_user_id ORDER BY pa.allowed_user_id)
+            FROM post_audience pa
+            WHERE pa.post_id = p.id
+              AND p.audience = 'selected'
+        ),
+        ARRAY[]::bigint[]
+    )
+FROM posts p
+WHERE p.id = $1
+  AND p.creator_id = $2
+  AND p.deleted_at IS NULL
+`
+
+type CreatePostRevisionParams struct {
+	PostID   int64
+	EditorID int64
+}
+
+// stores a snapshot of the post (body, images, audience) as it is before an edit
+// returns rows affected
+// 0 rows could mean the post was not found, is not owned by the editor or is already marked deleted
+func (q *Queries) CreatePostRevision(ctx context.Context, arg CreatePostRevisionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createPostRevision, arg.PostID, arg.EditorID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createCommentRevision = `-- name: CreateCommentRevision :execrows
+INSERT INTO entity_revisions (entity_id, content_type, editor_id, body, image_ids)
+SELECT
+    c.id,
+    'comment',
+    $2,
+    c.comment_body,
+    COALESCE(
+        (
+            SELECT array_agg(i.id ORDER BY i.sort_order)
+            FROM images i
+            WHERE i.parent_id = c.id
+              AND i.deleted_at IS NULL
+        ),
+        ARRAY[]::bigint[]
+    )
+FROM comments c
+WHERE c.id = $1
+  AND c.comment_creator_id = $2
+  AND c.deleted_at IS NULL
+`
+
+type CreateCommentRevisionParams struct {
+	CommentID int64
+	EditorID  int64
+}
+
+// stores a snapshot of the comment (body, images) as it is before an edit
+// returns rows affected
+// 0 rows could mean the comment was not found, is not owned by the editor or is already marked deleted
+func (q *Queries) CreateCommentRevision(ctx context.Context, arg CreateCommentRevisionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createCommentRevision, arg.CommentID, arg.EditorID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createEventRevision = `-- name: CreateEventRevision :execrows
+INSERT INTO entity_revisions (entity_id, content_type, editor_id, title, body, image_ids, event_date)
+SELECT
+    e.id,
+    'event',
+    $2,
+    e.event_title,
+    e.event_body,
+    COALESCE(
+        (
+            SELECT array_agg(i.id ORDER BY i.sort_order)
+            FROM images i
+            WHERE i.parent_id = e.id
+              AND i.deleted_at IS NULL
+        ),
+        ARRAY[]::bigint[]
+    ),
+    e.event_date
+FROM events e
+WHERE e.id = $1
+  AND e.event_creator_id = $2
+  AND e.deleted_at IS NULL
+`
+
+type CreateEventRevisionParams struct {
+	EventID  int64
+	EditorID int64
+}
+
+// stores a snapshot of the event (title, body, images, date) as it is before an edit
+// returns rows affected
+// 0 rows could mean the event was not found, is not owned by the editor or is already marked deleted
+func (q *Queries) CreateEventRevision(ctx context.Context, arg CreateEventRevisionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createEventRevision, arg.EventID, arg.EditorID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getEntityRevisions = `-- name: GetEntityRevisions :many
+SELECT
+    id,
+    entity_id,
+    content_type,
+    editor_id,
+    COALESCE(title, '')::text AS title,
+    body,
+    image_ids,
+    COALESCE(audience::text, '')::text AS audience,
+    audience_ids,
+    event_date,
+    created_at
+FROM entity_revisions
+WHERE entity_id = $1
+ORDER BY created_at DESC, id DESC
+OFFSET $2
+LIMIT $3
+`
+
+type GetEntityRevisionsParams struct {
+	EntityID int64
+	Offset   int32
+	Limit    int32
+}
+
+type GetEntityRevisionsRow struct {
+	ID          int64
+	EntityID    int64
+	ContentType ContentType
+	EditorID    int64
+	Title       string
+	Body        string
+	ImageIds    []int64
+	Audience    string
+	AudienceIds []int64
+	EventDate   pgtype.Date
+	CreatedAt   pgtype.Timestamptz
+}
+
+// returns the stored revisions of an entity, newest first, paginated
+func (q *Queries) GetEntityRevisions(ctx context.Context, arg GetEntityRevisionsParams) ([]GetEntityRevisionsRow, error) {
+	rows, err := q.db.Query(ctx, getEntityRevisions, arg.EntityID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetEntityRevisionsRow{}
+	for rows.Next() {
+		var i GetEntityRevisionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EntityID,
+			&i.ContentType,
+			&i.EditorID,
+			&i.Title,
+			&i.Body,
+			&i.ImageIds,
+			&i.Audience,
+			&i.AudienceIds,
+			&i.EventDate,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,215 @@
+package dbservice
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getOrCreateSavedCollection = `-- name: GetOrCreateSavedCollection :one
+INSERT INTO saved_collections (user_id, name)
+VALUES ($1, $2)
+ON CONFLICT (user_id, name) DO UPDATE
+    SET name = EXCLUDED.name
+RETURNING id
+`
+
+type GetOrCreateSavedCollectionParams struct {
+	UserID int64
+	Name   string
+}
+
+func (q *Queries) GetOrCreateSavedCollection(ctx context.Context, arg GetOrCreateSavedCollectionParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getOrCreateSavedCollection, arg.UserID, arg.Name)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const saveEntity = `-- name: SaveEntity :exec
+INSERT INTO saved_entities (user_id, entity_id, collection_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, entity_id) DO UPDATE
+    SET collection_id = EXCLUDED.collection_id
+`
+
+type SaveEntityParams struct {
+	UserID       int64
+	EntityID     int64
+	CollectionID pgtype.Int8
+}
+
+// saving an already saved entity moves it to the given collection
+func (q *Queries) SaveEntity(ctx context.Context, arg SaveEntityParams) error {
+	_, err := q.db.Exec(ctx, saveEntity, arg.UserID, arg.EntityID, arg.CollectionID)
+	return err
+}
+
+const unsaveEntity = `-- name: UnsaveEntity :execrows
+DELETE FROM saved_entities
+WHERE user_id = $1
+  AND entity_id = $2
+`
+
+type UnsaveEntityParams struct {
+	UserID   int64
+	EntityID int64
+}
+
+func (q *Queries) UnsaveEntity(ctx context.Context, arg UnsaveEntityParams) (int64, error) {
+	result, err := q.db.Exec(ctx, unsaveEntity, arg.UserID, arg.EntityID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getSavedPosts = `-- name: GetSavedPosts :many
+SELECT
+    p.id,
+    p.post_body,
+    p.creator_id,
+    p.group_id,
+    p.audience,
+    p.comments_count,
+    p.reactions_count,
+    p.shares_count,
+    p.shared_post_id,
+    p.last_commented_at,
+    p.created_at,
+    p.updated_at,
+    se.created_at AS saved_at,
+    COALESCE(sc.name, '') AS collection,
+
+    EXISTS (
+        SELECT 1 FROM reactions r
+        WHERE r.content_id = p.id
+          AND r.user_id = $1
+          AND r.deleted_at IS NULL
+    ) AS liked_by_user,
+
+COALESCE(
+    (SELECT i.id
+     FROM images i
+     WHERE i.parent_id = p.id AND i.deleted_at IS NULL
+     ORDER BY i.sort_order ASC
+     LIMIT 1
+    ), 0
+)::bigint AS image
+
+FROM saved_entities se
+JOIN posts p ON p.id = se.entity_id
+LEFT JOIN saved_collections sc ON sc.id = se.collection_id
+WHERE se.user_id = $1
+  AND ($2::text = '' OR sc.name = $2::text)
+  AND p.deleted_at IS NULL
+  AND p.status = 'published'
+ORDER BY se.created_at DESC
+OFFSET $3 LIMIT $4
+`
+
+type GetSavedPostsParams struct {
+	UserID     int64
+	Collection string
+	Offset     int32
+	Limit      int32
+}
+
+type GetSavedPostsRow struct {
+	ID              int64
+	PostBody        string
+	CreatorID       int64
+	GroupID         pgtype.Int8
+	Audience        IntendedAudience
+	CommentsCount   int32
+	ReactionsCount  int32
+	SharesCount     int32
+	SharedPostID    pgtype.Int8
+	LastCommentedAt pgtype.Timestamptz
+	CreatedAt       pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+	SavedAt         pgtype.Timestamptz
+	Collection      string
+	LikedByUser     bool
+	Image           int64
+}
+
+// empty collection returns saved posts from all collections, newest saves first
+func (q *Queries) GetSavedPosts(ctx context.Context, arg GetSavedPostsParams) ([]GetSavedPostsRow, error) {
+	rows, err := q.db.Query(ctx, getSavedPosts,
+		arg.UserID,
+		arg.Collection,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetSavedPostsRow{}
+	for rows.Next() {
+		var i GetSavedPostsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostBody,
+			&i.CreatorID,
+			&i.GroupID,
+			&i.Audience,
+			&i.CommentsCount,
+			&i.ReactionsCount,
+			&i.SharesCount,
+			&i.SharedPostID,
+			&i.LastCommentedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SavedAt,
+			&i.Collection,
+			&i.LikedByUser,
+			&i.Image,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSavedCollections = `-- name: GetSavedCollections :many
+SELECT
+    sc.name,
+    COUNT(se.entity_id)::int AS saved_count
+FROM saved_collections sc
+JOIN saved_entities se ON se.collection_id = sc.id
+WHERE sc.user_id = $1
+GROUP BY sc.id, sc.name
+ORDER BY sc.name
+`
+
+type GetSavedCollectionsRow struct {
+	Name       string
+	SavedCount int32
+}
+
+// collections that hold no saved entities are left out
+func (q *Queries) GetSavedCollections(ctx context.Context, userID int64) ([]GetSavedCollectionsRow, error) {
+	rows, err := q.db.Query(ctx, getSavedCollections, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetSavedCollectionsRow{}
+	for rows.Next() {
+		var i GetSavedCollectionsRow
+		if err := rows.Scan(&i.Name, &i.SavedCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,152 @@
+package dbservice
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createShare = `-- name: CreateShare :one
+INSERT INTO posts (post_body, creator_id, group_id, audience, shared_post_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id
+`
+
+type CreateShareParams struct {
+	PostBody     string
+	CreatorID    int64
+	GroupID      pgtype.Int8
+	Audience     IntendedAudience
+	SharedPostID int64
+}
+
+// shares are always published right away
+func (q *Queries) CreateShare(ctx context.Context, arg CreateShareParams) (int64, error) {
+	row := q.db.QueryRow(ctx, createShare,
+		arg.PostBody,
+		arg.CreatorID,
+		arg.GroupID,
+		arg.Audience,
+		arg.SharedPostID,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getPostForShare = `-- name: GetPostForShare :one
+SELECT
+    p.id,
+    p.post_body,
+    p.creator_id,
+    p.group_id,
+    p.audience,
+    p.shared_post_id
+FROM posts p
+WHERE p.id = $1
+  AND p.deleted_at IS NULL
+  AND p.status = 'published'
+`
+
+type GetPostForShareRow struct {
+	ID           int64
+	PostBody     string
+	CreatorID    int64
+	GroupID      pgtype.Int8
+	Audience     IntendedAudience
+	SharedPostID pgtype.Int8
+}
+
+func (q *Queries) GetPostForShare(ctx context.Context, id int64) (GetPostForShareRow, error) {
+	row := q.db.QueryRow(ctx, getPostForShare, id)
+	var i GetPostForShareRow
+	err := row.Scan(
+		&i.ID,
+		&i.PostBody,
+		&i.CreatorID,
+		&i.GroupID,
+		&i.Audience,
+		&i.SharedPostID,
+	)
+	return i, err
+}
+
+const getSharedPosts = `-- name: GetSharedPosts :many
+SELECT
+    p.id,
+    p.post_body,
+    p.creator_id,
+    p.group_id,
+    p.audience,
+    p.created_at,
+    p.updated_at,
+
+    -- is the user in the selected audience of the post?
+    EXISTS (
+        SELECT 1 FROM post_audience pa
+        WHERE pa.post_id = p.id
+          AND pa.allowed_user_id = $2::bigint
+    ) AS user_in_audience,
+
+COALESCE(
+    (SELECT i.id
+     FROM images i
+     WHERE i.parent_id = p.id AND i.deleted_at IS NULL
+     ORDER BY i.sort_order ASC
+     LIMIT 1
+    ), 0
+)::bigint AS image
+
+FROM posts p
+WHERE p.id = ANY($1::bigint[])
+  AND p.deleted_at IS NULL
+  AND p.status = 'published'
+`
+
+type GetSharedPostsParams struct {
+	Ids    []int64
+	UserID int64
+}
+
+type GetSharedPostsRow struct {
+	ID             int64
+	PostBody       string
+	CreatorID      int64
+	GroupID        pgtype.Int8
+	Audience       IntendedAudience
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+	UserInAudience bool
+	Image          int64
+}
+
+// returns the originals of shared posts. Deleted or unpublished originals are left out
+func (q *Queries) GetSharedPosts(ctx context.Context, arg GetSharedPostsParams) ([]GetSharedPostsRow, error) {
+	rows, err := q.db.Query(ctx, getSharedPosts, arg.Ids, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetSharedPostsRow{}
+	for rows.Next() {
+		var i GetSharedPostsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostBody,
+			&i.CreatorID,
+			&i.GroupID,
+			&i.Audience,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserInAudience,
+			&i.Image,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
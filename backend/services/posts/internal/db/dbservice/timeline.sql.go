@@ -0,0 +1,193 @@
+package dbservice
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getPostForTimeline = `-- name: GetPostForTimeline :one
+SELECT
+    p.id,
+    p.creator_id,
+    p.group_id,
+    p.audience,
+    p.status,
+    p.created_at,
+    p.deleted_at
+FROM posts p
+WHERE p.id = $1
+`
+
+type GetPostForTimelineRow struct {
+	ID        int64
+	CreatorID int64
+	GroupID   pgtype.Int8
+	Audience  IntendedAudience
+	Status    PostStatus
+	CreatedAt pgtype.Timestamptz
+	DeletedAt pgtype.Timestamptz
+}
+
+// returns the fields deciding whether a post belongs on its audience's timelines,
+// including deleted and unpublished posts so they can be removed
+func (q *Queries) GetPostForTimeline(ctx context.Context, id int64) (GetPostForTimelineRow, error) {
+	row := q.db.QueryRow(ctx, getPostForTimeline, id)
+	var i GetPostForTimelineRow
+	err := row.Scan(
+		&i.ID,
+		&i.CreatorID,
+		&i.GroupID,
+		&i.Audience,
+		&i.Status,
+		&i.CreatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getTimelinePosts = `-- name: GetTimelinePosts :many
+SELECT
+    p.id,
+    p.post_body,
+    p.creator_id,
+    p.comments_count,
+    p.reactions_count,
+    p.shares_count,
+    p.shared_post_id,
+    p.last_commented_at,
+    p.created_at,
+    p.updated_at,
+
+    EXISTS (
+        SELECT 1 FROM reactions r
+        WHERE r.content_id = p.id
+          AND r.user_id = $1
+          AND r.deleted_at IS NULL
+    ) AS liked_by_user,
+
+COALESCE(
+    (SELECT i.id
+     FROM images i
+     WHERE i.parent_id = p.id AND i.deleted_at IS NULL
+     ORDER BY i.sort_order ASC
+     LIMIT 1
+    ), 0
+)::bigint AS image
+
+FROM posts p
+WHERE p.id = ANY($2::bigint[])
+  AND p.deleted_at IS NULL
+  AND p.status = 'published'
+  AND p.group_id IS NULL
+  -- same audience rules as the personalized feed, checked again because
+  -- timeline entries may be stale after unfollows and audience changes
+  AND p.creator_id = ANY($3::bigint[])
+  AND (
+       p.audience = 'followers'
+       OR (p.audience = 'selected' AND EXISTS (
+           SELECT 1
+           FROM post_audience pa
+           WHERE pa.post_id = p.id
+             AND pa.allowed_user_id = $1
+       ))
+  )
+ORDER BY p.created_at DESC, p.id DESC
+`
+
+type GetTimelinePostsParams struct {
+	UserID       int64
+	Ids          []int64
+	FollowingIds []int64
+}
+
+type GetTimelinePostsRow struct {
+	ID              int64
+	PostBody        string
+	CreatorID       int64
+	CommentsCount   int32
+	ReactionsCount  int32
+	SharesCount     int32
+	SharedPostID    pgtype.Int8
+	LastCommentedAt pgtype.Timestamptz
+	CreatedAt       pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+	LikedByUser     bool
+	Image           int64
+}
+
+// returns the posts among ids that still belong in the user's personalized feed
+func (q *Queries) GetTimelinePosts(ctx context.Context, arg GetTimelinePostsParams) ([]GetTimelinePostsRow, error) {
+	rows, err := q.db.Query(ctx, getTimelinePosts, arg.UserID, arg.Ids, arg.FollowingIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTimelinePostsRow{}
+	for rows.Next() {
+		var i GetTimelinePostsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostBody,
+			&i.CreatorID,
+			&i.CommentsCount,
+			&i.ReactionsCount,
+			&i.SharesCount,
+			&i.SharedPostID,
+			&i.LastCommentedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LikedByUser,
+			&i.Image,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTimelineUserIds = `-- name: GetTimelineUserIds :many
+SELECT user_id::bigint
+FROM (
+    SELECT creator_id AS user_id FROM posts
+    UNION
+    SELECT allowed_user_id FROM post_audience
+    UNION
+    SELECT user_id FROM reactions
+    UNION
+    SELECT comment_creator_id FROM comments
+) u
+WHERE user_id > $1
+ORDER BY user_id
+LIMIT $2
+`
+
+type GetTimelineUserIdsParams struct {
+	AfterID int64
+	Limit   int32
+}
+
+// returns, in pages, the ids of every user that has interacted with posts, for timeline backfills
+func (q *Queries) GetTimelineUserIds(ctx context.Context, arg GetTimelineUserIdsParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, getTimelineUserIds, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var user_id int64
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -15,6 +15,8 @@ WITH ent AS (
     FROM posts
     WHERE id = $4::bigint
       AND deleted_at IS NULL
+      -- drafts and scheduled posts are only visible to their creator
+      AND (status = 'published' OR creator_id = $3::bigint)
 
     UNION ALL
 
@@ -86,7 +88,7 @@ const getEntityCreatorAndGroup = `-- name: GetEntityCreatorAndGroup :one
 SELECT
     mi.content_type,
 
-     -- Who created THIS content (comment author, post author, event creator)
+     -- Who created THIS content (comment author, post author, event creator, poll's post author)
     (
         CASE
             WHEN mi.content_type = 'post'
@@ -95,31 +97,35 @@ SELECT
                 THEN e.event_creator_id
             WHEN mi.content_type = 'comment'
                 THEN c.comment_creator_id
+            WHEN mi.content_type = 'poll'
+                THEN p2.creator_id
         END
     )::BIGINT AS creator_id,
 
-    -- creator of the parent post (only for comments)
+    -- creator of the parent post (only for comments and polls)
     (
         CASE
-            WHEN mi.content_type = 'comment'
+            WHEN mi.content_type IN ('comment', 'poll')
                 THEN p2.creator_id
             ELSE 0
         END
     )::BIGINT AS parent_creator_id,
 
-    -- group_id: post.group_id, event.group_id, or parent post group for comments
+    -- group_id: post.group_id, event.group_id, or parent post group for comments and polls
 COALESCE(
     CASE
         WHEN mi.content_type = 'post'    THEN p.group_id
         WHEN mi.content_type = 'event'   THEN e.group_id
         WHEN mi.content_type = 'comment' THEN p2.group_id
+        WHEN mi.content_type = 'poll'    THEN p2.group_id
     END,
     0
 )::BIGINT AS group_id,
 
--- parent post id (for comments)
+-- parent post id (for comments and polls)
     CASE
         WHEN mi.content_type = 'comment' THEN c.parent_id
+        WHEN mi.content_type = 'poll'    THEN pl.parent_id
         ELSE 0
     END::BIGINT AS parent_id
 
@@ -127,7 +133,8 @@ FROM master_index mi
 LEFT JOIN posts p ON p.id = mi.id
 LEFT JOIN events e ON e.id = mi.id
 LEFT JOIN comments c ON c.id = mi.id
-LEFT JOIN posts p2 ON p2.id = c.parent_id  -- parent post for comments
+LEFT JOIN polls pl ON pl.id = mi.id
+LEFT JOIN posts p2 ON p2.id = COALESCE(c.parent_id, pl.parent_id)  -- parent post for comments and polls
 WHERE mi.id = $1
 LIMIT 1
 `
@@ -0,0 +1,20 @@
+------------------------------------------
+-- Entity revisions (edit history)
+------------------------------------------
+-- Every edit of a post, comment or event stores a snapshot of the
+-- entity as it was right before the edit, together with the editor.
+CREATE TABLE IF NOT EXISTS entity_revisions (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    entity_id BIGINT NOT NULL REFERENCES master_index(id) ON DELETE CASCADE,
+    content_type content_type NOT NULL,
+    editor_id BIGINT NOT NULL, -- in users service
+    title TEXT, -- events only
+    body TEXT NOT NULL,
+    image_ids BIGINT[] NOT NULL DEFAULT ARRAY[]::bigint[],
+    audience intended_audience, -- posts only
+    audience_ids BIGINT[] NOT NULL DEFAULT ARRAY[]::bigint[], -- posts with audience=selected
+    event_date DATE, -- events only
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_entity_revisions_entity_created ON entity_revisions(entity_id, created_at DESC);
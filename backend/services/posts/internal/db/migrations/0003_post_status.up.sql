@@ -0,0 +1,21 @@
+------------------------------------------
+-- Drafts and scheduled posts
+------------------------------------------
+CREATE TYPE post_status AS ENUM ('draft', 'scheduled', 'published');
+
+-- Existing posts are published. publish_at is only set for scheduled posts.
+ALTER TABLE posts
+    ADD COLUMN status post_status NOT NULL DEFAULT 'published',
+    ADD COLUMN publish_at TIMESTAMPTZ;
+
+ALTER TABLE posts
+    ADD CONSTRAINT posts_scheduled_has_publish_at
+    CHECK (status <> 'scheduled' OR publish_at IS NOT NULL);
+
+-- Publish worker looks up due scheduled posts
+CREATE INDEX idx_posts_scheduled_publish_at ON posts(publish_at)
+    WHERE status = 'scheduled' AND deleted_at IS NULL;
+
+-- Listing a user's own drafts and scheduled posts
+CREATE INDEX idx_posts_creator_unpublished ON posts(creator_id, created_at DESC)
+    WHERE status <> 'published' AND deleted_at IS NULL;
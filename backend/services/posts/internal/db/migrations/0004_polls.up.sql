@@ -0,0 +1,70 @@
+------------------------------------------
+-- Polls
+------------------------------------------
+-- A poll is attached to a post (its parent), so it appears in feeds and
+-- group pages together with the post and shares the post's audience.
+ALTER TYPE content_type ADD VALUE IF NOT EXISTS 'poll';
+
+CREATE TABLE IF NOT EXISTS polls (
+    id BIGINT PRIMARY KEY REFERENCES master_index(id) ON DELETE CASCADE,
+    parent_id BIGINT NOT NULL UNIQUE REFERENCES posts(id) ON DELETE CASCADE,
+    question TEXT NOT NULL,
+    multiple_choice BOOLEAN NOT NULL DEFAULT FALSE,
+    closes_at TIMESTAMPTZ, -- null means the poll never closes
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS poll_options (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    poll_id BIGINT NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    option_text TEXT NOT NULL,
+    sort_order INT NOT NULL,
+    UNIQUE (poll_id, sort_order)
+);
+
+CREATE TABLE IF NOT EXISTS poll_votes (
+    poll_id BIGINT NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    option_id BIGINT NOT NULL REFERENCES poll_options(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL, -- in users service
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (option_id, user_id)
+);
+CREATE INDEX idx_poll_votes_poll_user ON poll_votes(poll_id, user_id);
+
+------------------------------------------
+-- Register polls in master_index
+------------------------------------------
+CREATE OR REPLACE FUNCTION add_to_master_index()
+RETURNS TRIGGER AS $$
+DECLARE
+    new_id BIGINT;
+    ctype content_type;
+BEGIN
+    -- Safely convert text argument to enum
+    CASE TG_ARGV[0]
+        WHEN 'post' THEN ctype := 'post'::content_type;
+        WHEN 'comment' THEN ctype := 'comment'::content_type;
+        WHEN 'event' THEN ctype := 'event'::content_type;
+        WHEN 'poll' THEN ctype := 'poll'::content_type;
+        ELSE RAISE EXCEPTION 'Unknown content_type: %', TG_ARGV[0];
+    END CASE;
+    
+    INSERT INTO master_index (content_type)
+    VALUES (ctype)
+    RETURNING id INTO new_id;
+    NEW.id := new_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_before_insert_poll
+BEFORE INSERT ON polls
+FOR EACH ROW
+EXECUTE FUNCTION add_to_master_index('poll');
+
+CREATE TRIGGER trg_update_poll_modtime
+BEFORE UPDATE ON polls
+FOR EACH ROW
+EXECUTE FUNCTION update_timestamp();
@@ -0,0 +1,56 @@
+------------------------------------------
+-- Shares (reposts)
+------------------------------------------
+-- A share is a regular post pointing at the post it shares. Its body is the
+-- optional quote text. Shares of a deleted post stay in place and are
+-- rendered without the original.
+ALTER TABLE posts
+    ADD COLUMN shared_post_id BIGINT REFERENCES posts(id) ON DELETE SET NULL,
+    ADD COLUMN shares_count INT DEFAULT 0 NOT NULL;
+
+CREATE INDEX idx_posts_shared_post_id ON posts(shared_post_id)
+    WHERE shared_post_id IS NOT NULL;
+
+------------------------------------------
+-- Trigger to maintain shares_count
+------------------------------------------
+-- Only published, non deleted shares are counted
+CREATE OR REPLACE FUNCTION update_post_shares_count()
+RETURNS TRIGGER AS $$
+DECLARE
+    was_counted BOOLEAN := FALSE;
+    is_counted BOOLEAN;
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        was_counted := OLD.shared_post_id IS NOT NULL
+            AND OLD.deleted_at IS NULL
+            AND OLD.status = 'published';
+    END IF;
+
+    is_counted := NEW.shared_post_id IS NOT NULL
+        AND NEW.deleted_at IS NULL
+        AND NEW.status = 'published';
+
+    IF was_counted AND NOT is_counted THEN
+        UPDATE posts
+        SET shares_count = GREATEST(shares_count - 1, 0)
+        WHERE id = OLD.shared_post_id;
+    ELSIF is_counted AND NOT was_counted THEN
+        UPDATE posts
+        SET shares_count = shares_count + 1
+        WHERE id = NEW.shared_post_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_posts_shares_insert
+AFTER INSERT ON posts
+FOR EACH ROW
+EXECUTE FUNCTION update_post_shares_count();
+
+CREATE TRIGGER trg_posts_shares_update
+AFTER UPDATE OF shared_post_id, deleted_at, status ON posts
+FOR EACH ROW
+EXECUTE FUNCTION update_post_shares_count();
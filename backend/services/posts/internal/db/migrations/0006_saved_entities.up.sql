@@ -0,0 +1,23 @@
+------------------------------------------
+-- Saved posts (bookmarks)
+------------------------------------------
+-- Named collections are created on first use and only listed while they hold saved posts
+CREATE TABLE IF NOT EXISTS saved_collections (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id BIGINT NOT NULL, -- in users service
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+-- A post is saved at most once per user, either unsorted (no collection) or in one collection
+CREATE TABLE IF NOT EXISTS saved_entities (
+    user_id BIGINT NOT NULL, -- in users service
+    entity_id BIGINT NOT NULL REFERENCES master_index(id) ON DELETE CASCADE,
+    collection_id BIGINT REFERENCES saved_collections(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, entity_id)
+);
+
+CREATE INDEX idx_saved_entities_user_created ON saved_entities(user_id, created_at DESC);
+CREATE INDEX idx_saved_entities_collection ON saved_entities(collection_id) WHERE collection_id IS NOT NULL;
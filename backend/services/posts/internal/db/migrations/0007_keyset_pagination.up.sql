@@ -0,0 +1,8 @@
+------------------------------------------
+-- Cursor (keyset) pagination
+------------------------------------------
+-- Feeds and comments page on (created_at, id) so rows with equal timestamps keep a stable order
+CREATE INDEX idx_posts_created_id_desc ON posts(created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_posts_group_created_id ON posts(group_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_posts_creator_created_id ON posts(creator_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_comments_parent_created_id ON comments(parent_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
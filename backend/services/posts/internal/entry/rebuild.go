@@ -0,0 +1,77 @@
+package entry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"social-network/services/posts/internal/application"
+	"social-network/services/posts/internal/client"
+	ds "social-network/services/posts/internal/db/dbservice"
+	"social-network/shared/gen-go/users"
+	"social-network/shared/go/ct"
+	"social-network/shared/go/gorpc"
+	postgresql "social-network/shared/go/postgre"
+	rds "social-network/shared/go/redis"
+	tele "social-network/shared/go/telemetry"
+	"syscall"
+)
+
+// RebuildTimelines rebuilds the cached personalized feed timelines of userIds, or of every user if all is set.
+// Only the users service is needed to rebuild timelines, so no other service is connected.
+func RebuildTimelines(userIds []int64, all bool) error {
+	ctx, stopSignal := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignal()
+
+	cfgs := getConfigs()
+
+	closeTelemetry, err := tele.InitTelemetry(ctx, "posts", "PST", cfgs.TelemetryCollectorAddress, ct.CommonKeys(), cfgs.EnableDebugLogs, cfgs.SimplePrint)
+	if err != nil {
+		tele.Fatalf("failed to init telemetry: %s", err.Error())
+	}
+	defer closeTelemetry()
+
+	pool, err := postgresql.NewPool(ctx, os.Getenv("DATABASE_URL"))
+	if err != nil {
+		return fmt.Errorf("failed to connect db: %v", err)
+	}
+	defer pool.Close()
+
+	UsersService, err := gorpc.GetGRpcClient(
+		users.NewUserServiceClient,
+		cfgs.UsersGRPCAddr,
+		ct.CommonKeys(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to connect to users service: %v", err)
+	}
+
+	redisConnector := rds.NewRedisClient(cfgs.SentinelAddrs, cfgs.RedisPassword, cfgs.RedisDB, cfgs.RedisMasterName)
+	if err := redisConnector.TestRedisConnection(); err != nil {
+		return fmt.Errorf("redis connection test failed: %v", err)
+	}
+
+	clients := client.NewClients(UsersService, nil, nil)
+
+	app, err := application.NewApplication(ds.New(pool), pool, clients, redisConnector, nil, nil, application.RankingConfig{})
+	if err != nil {
+		return fmt.Errorf("failed to create posts application: %v", err)
+	}
+
+	if all {
+		rebuilt, err := app.RebuildAllTimelines(ctx)
+		if err != nil {
+			return fmt.Errorf("rebuilt %d timelines before failing: %w", rebuilt, err)
+		}
+		tele.Info(ctx, "Rebuilt timelines. @1", "count", rebuilt)
+		return nil
+	}
+
+	for _, id := range userIds {
+		if err := app.RebuildTimeline(ctx, id); err != nil {
+			return fmt.Errorf("failed to rebuild timeline of user %d: %w", id, err)
+		}
+		tele.Info(ctx, "Rebuilt timeline. @1", "userId", id)
+	}
+	return nil
+}
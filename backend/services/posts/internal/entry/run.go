@@ -24,6 +24,7 @@ import (
 	"social-network/shared/go/gorpc"
 	postgresql "social-network/shared/go/postgre"
 	"syscall"
+	"time"
 
 	"github.com/dgraph-io/ristretto/v2"
 )
@@ -129,11 +130,21 @@ func Run() error {
 	}
 	defer localCache.Close()
 
-	app, err := application.NewApplication(ds.New(pool), pool, clients, redisConnector, eventProducer, localCache)
+	app, err := application.NewApplication(ds.New(pool), pool, clients, redisConnector, eventProducer, localCache, application.RankingConfig{
+		ReactionWeight: &cfgs.FeedRankReactionWeight,
+		CommentWeight:  &cfgs.FeedRankCommentWeight,
+		ActivityWeight: &cfgs.FeedRankActivityWeight,
+		HalfLife:       time.Duration(cfgs.FeedRankHalfLifeHours) * time.Hour,
+		Window:         time.Duration(cfgs.FeedRankWindowHours) * time.Hour,
+		SnapshotSize:   int32(cfgs.FeedRankSnapshotSize),
+		SnapshotTTL:    time.Duration(cfgs.FeedRankSnapshotTTLMinutes) * time.Minute,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create posts application: %v", err)
 	}
 
+	app.StartScheduledPostsWorker(ctx, time.Duration(cfgs.PublishWorkerIntervalSeconds)*time.Second)
+
 	service := handler.NewPostsHandler(app)
 	tele.Info(ctx, "Running gRpc service...")
 
@@ -198,6 +209,17 @@ type configs struct {
 	HTTPAddr        string `env:"HTTP_ADDR"`
 	ShutdownTimeout int    `env:"SHUTDOWN_TIMEOUT_SECONDS"`
 
+	PublishWorkerIntervalSeconds int `env:"PUBLISH_WORKER_INTERVAL_SECONDS"` // how often scheduled posts are checked for publishing
+
+	// "top" feed ranking, see application.RankingConfig
+	FeedRankReactionWeight     float64 `env:"FEED_RANK_REACTION_WEIGHT"`
+	FeedRankCommentWeight      float64 `env:"FEED_RANK_COMMENT_WEIGHT"`
+	FeedRankActivityWeight     float64 `env:"FEED_RANK_ACTIVITY_WEIGHT"`
+	FeedRankHalfLifeHours      int     `env:"FEED_RANK_HALF_LIFE_HOURS"`
+	FeedRankWindowHours        int     `env:"FEED_RANK_WINDOW_HOURS"`
+	FeedRankSnapshotSize       int     `env:"FEED_RANK_SNAPSHOT_SIZE"`
+	FeedRankSnapshotTTLMinutes int     `env:"FEED_RANK_SNAPSHOT_TTL_MINUTES"`
+
 	EnableDebugLogs bool `env:"ENABLE_DEBUG_LOGS"`
 	SimplePrint     bool `env:"ENABLE_SIMPLE_PRINT"`
 
@@ -226,6 +248,16 @@ func getConfigs() configs { // sensible defaults
 		GrpcServerPort:  ":50051",
 		PprofPort:       "127.0.0.1:6062",
 
+		PublishWorkerIntervalSeconds: 30,
+
+		FeedRankReactionWeight:     1,
+		FeedRankCommentWeight:      2,
+		FeedRankActivityWeight:     3,
+		FeedRankHalfLifeHours:      12,
+		FeedRankWindowHours:        7 * 24,
+		FeedRankSnapshotSize:       500,
+		FeedRankSnapshotTTLMinutes: 30,
+
 		KafkaBrokers: "kafka:9092",
 
 		EnableDebugLogs:           true,
@@ -59,23 +59,61 @@ func (s *PostsHandler) GetPostById(ctx context.Context, req *pb.GenericReq) (*pb
 		Audience:        post.Audience.String(),
 		CommentsCount:   int32(post.CommentsCount),
 		ReactionsCount:  int32(post.ReactionsCount),
+		SharesCount:     int32(post.SharesCount),
+		SharedPost:      sharedPostToProto(post.SharedPost),
 		LastCommentedAt: post.LastCommentedAt.ToProto(),
 		CreatedAt:       post.CreatedAt.ToProto(),
 		UpdatedAt:       post.UpdatedAt.ToProto(),
 		LikedByUser:     post.LikedByUser,
 		ImageId:         int64(post.ImageId),
 		ImageUrl:        post.ImageUrl,
+		Poll:            pollToProto(post.Poll),
 		SelectedAudienceUsers: &cm.ListUsers{
 			Users: selectedUsers,
 		},
+		Status:    post.Status.String(),
+		PublishAt: post.PublishAt.ToProto(),
 	}, nil
 }
 
+func (s *PostsHandler) CanViewEntity(ctx context.Context, req *pb.GenericReq) (*wrapperspb.BoolValue, error) {
+	tele.Info(ctx, "CanViewEntity gRPC method called. @1", "request", req.String())
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	canView, err := s.Application.CanViewEntity(ctx, models.GenericReq{
+		RequesterId: ct.Id(req.RequesterId),
+		EntityId:    ct.Id(req.EntityId),
+	})
+	if err != nil {
+		tele.Error(ctx, "Error in CanViewEntity. @1 @2", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	return wrapperspb.Bool(canView), nil
+}
+
+func (s *PostsHandler) GetUsedImageIds(ctx context.Context, req *pb.ImageIds) (*pb.ImageIds, error) {
+	tele.Info(ctx, "GetUsedImageIds gRPC method called. @1", "request", req.String())
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	used, err := s.Application.GetUsedImageIds(ctx, ct.FromInt64s(req.ImageIds))
+	if err != nil {
+		tele.Error(ctx, "Error in GetUsedImageIds. @1 @2", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	return &pb.ImageIds{ImageIds: used.Int64()}, nil
+}
+
 func (s *PostsHandler) CreatePost(ctx context.Context, req *pb.CreatePostReq) (*pb.IdResp, error) {
 	tele.Info(ctx, "CreatePost gRPC method called. @1", "request", req.String())
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request is nil")
 	}
+	var publishAt ct.GenDateTime
+	if req.PublishAt != nil {
+		publishAt = ct.GenDateTime(req.PublishAt.AsTime())
+	}
 	postId, err := s.Application.CreatePost(ctx, models.CreatePostReq{
 		CreatorId:   ct.Id(req.CreatorId),
 		Body:        ct.PostBody(req.Body),
@@ -83,6 +121,9 @@ func (s *PostsHandler) CreatePost(ctx context.Context, req *pb.CreatePostReq) (*
 		Audience:    ct.Audience(req.Audience),
 		AudienceIds: ct.FromInt64s(req.AudienceIds.Values),
 		ImageId:     ct.Id(req.ImageId),
+		Status:      ct.PostStatus(req.Status),
+		PublishAt:   publishAt,
+		Poll:        pollFromProto(req.Poll),
 	})
 	if err != nil {
 		tele.Error(ctx, "Error in CreatePost. @1 @2", "request", req.String(), "error", err.Error())
@@ -155,12 +196,15 @@ func (s *PostsHandler) GetMostPopularPostInGroup(ctx context.Context, req *pb.Si
 		Audience:        post.Audience.String(),
 		CommentsCount:   int32(post.CommentsCount),
 		ReactionsCount:  int32(post.ReactionsCount),
+		SharesCount:     int32(post.SharesCount),
+		SharedPost:      sharedPostToProto(post.SharedPost),
 		LastCommentedAt: post.LastCommentedAt.ToProto(),
 		CreatedAt:       post.CreatedAt.ToProto(),
 		UpdatedAt:       post.UpdatedAt.ToProto(),
 		LikedByUser:     post.LikedByUser,
 		ImageId:         int64(post.ImageId),
 		ImageUrl:        post.ImageUrl,
+		Poll:            pollToProto(post.Poll),
 	}, nil
 }
 
@@ -173,6 +217,7 @@ func (s *PostsHandler) GetPersonalizedFeed(ctx context.Context, req *pb.GetPerso
 		RequesterId: ct.Id(req.RequesterId),
 		Limit:       ct.Limit(req.Limit),
 		Offset:      ct.Offset(req.Offset),
+		Cursor:      ct.Cursor(req.Cursor),
 	})
 	if err != nil {
 		tele.Error(ctx, "Error in GetPersonalizedFeed. @1 @2", "request", req.String(), "error", err.Error())
@@ -193,15 +238,23 @@ func (s *PostsHandler) GetPersonalizedFeed(ctx context.Context, req *pb.GetPerso
 			Audience:        p.Audience.String(),
 			CommentsCount:   int32(p.CommentsCount),
 			ReactionsCount:  int32(p.ReactionsCount),
+			SharesCount:     int32(p.SharesCount),
+			SharedPost:      sharedPostToProto(p.SharedPost),
 			LastCommentedAt: p.LastCommentedAt.ToProto(),
 			CreatedAt:       p.CreatedAt.ToProto(),
 			UpdatedAt:       p.UpdatedAt.ToProto(),
 			LikedByUser:     p.LikedByUser,
 			ImageId:         int64(p.ImageId),
 			ImageUrl:        p.ImageUrl,
+			Poll:            pollToProto(p.Poll),
 		})
 	}
-	return &pb.ListPosts{Posts: pbPosts}, nil
+	nextCursor, err := nextPostsCursor(posts, ct.Limit(req.Limit))
+	if err != nil {
+		tele.Error(ctx, "Error in GetPersonalizedFeed @1 @2", "request", req.String(), "error", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ListPosts{Posts: pbPosts, NextCursor: nextCursor.String()}, nil
 }
 
 func (s *PostsHandler) GetPublicFeed(ctx context.Context, req *pb.GenericPaginatedReq) (*pb.ListPosts, error) {
@@ -209,11 +262,23 @@ func (s *PostsHandler) GetPublicFeed(ctx context.Context, req *pb.GenericPaginat
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request is nil")
 	}
-	posts, err := s.Application.GetPublicFeed(ctx, models.GenericPaginatedReq{
+	feedReq := models.GenericPaginatedReq{
 		RequesterId: ct.Id(req.RequesterId),
 		Limit:       ct.Limit(req.Limit),
 		Offset:      ct.Offset(req.Offset),
-	})
+		Cursor:      ct.Cursor(req.Cursor),
+		Sort:        ct.FeedSort(req.Sort),
+	}
+	var (
+		posts      []models.Post
+		nextCursor ct.Cursor
+		err        error
+	)
+	if feedReq.Sort.IsTop() {
+		posts, nextCursor, err = s.Application.GetTopPublicFeed(ctx, feedReq)
+	} else {
+		posts, err = s.Application.GetPublicFeed(ctx, feedReq)
+	}
 	if err != nil {
 		tele.Error(ctx, "Error in GetPublicFeed @1 @2", "request", req.String(), "error", err.Error())
 		return nil, ce.EncodeProto(err)
@@ -233,15 +298,74 @@ func (s *PostsHandler) GetPublicFeed(ctx context.Context, req *pb.GenericPaginat
 			Audience:        p.Audience.String(),
 			CommentsCount:   int32(p.CommentsCount),
 			ReactionsCount:  int32(p.ReactionsCount),
+			SharesCount:     int32(p.SharesCount),
+			SharedPost:      sharedPostToProto(p.SharedPost),
 			LastCommentedAt: p.LastCommentedAt.ToProto(),
 			CreatedAt:       p.CreatedAt.ToProto(),
 			UpdatedAt:       p.UpdatedAt.ToProto(),
 			LikedByUser:     p.LikedByUser,
 			ImageId:         int64(p.ImageId),
 			ImageUrl:        p.ImageUrl,
+			Poll:            pollToProto(p.Poll),
 		})
 	}
-	return &pb.ListPosts{Posts: pbPosts}, nil
+	if !feedReq.Sort.IsTop() {
+		nextCursor, err = nextPostsCursor(posts, ct.Limit(req.Limit))
+		if err != nil {
+			tele.Error(ctx, "Error in GetPublicFeed @1 @2", "request", req.String(), "error", err.Error())
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return &pb.ListPosts{Posts: pbPosts, NextCursor: nextCursor.String()}, nil
+}
+
+func (s *PostsHandler) GetHomeTimeline(ctx context.Context, req *pb.GenericPaginatedReq) (*pb.ListPosts, error) {
+	tele.Info(ctx, "GetHomeTimeline gRPC method called. @1", "request", req.String())
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	posts, err := s.Application.GetHomeTimeline(ctx, models.GenericPaginatedReq{
+		RequesterId: ct.Id(req.RequesterId),
+		Limit:       ct.Limit(req.Limit),
+		Offset:      ct.Offset(req.Offset),
+		Cursor:      ct.Cursor(req.Cursor),
+	})
+	if err != nil {
+		tele.Error(ctx, "Error in GetHomeTimeline @1 @2", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	pbPosts := make([]*pb.Post, 0, len(posts))
+	for _, p := range posts {
+		pbPosts = append(pbPosts, &pb.Post{
+			PostId:   int64(p.PostId),
+			PostBody: string(p.Body),
+			User: &cm.User{
+				UserId:    p.User.UserId.Int64(),
+				Username:  p.User.Username.String(),
+				Avatar:    p.User.AvatarId.Int64(),
+				AvatarUrl: p.User.AvatarURL,
+			},
+			GroupId:         int64(p.GroupId),
+			Audience:        p.Audience.String(),
+			CommentsCount:   int32(p.CommentsCount),
+			ReactionsCount:  int32(p.ReactionsCount),
+			SharesCount:     int32(p.SharesCount),
+			SharedPost:      sharedPostToProto(p.SharedPost),
+			LastCommentedAt: p.LastCommentedAt.ToProto(),
+			CreatedAt:       p.CreatedAt.ToProto(),
+			UpdatedAt:       p.UpdatedAt.ToProto(),
+			LikedByUser:     p.LikedByUser,
+			ImageId:         int64(p.ImageId),
+			ImageUrl:        p.ImageUrl,
+			Poll:            pollToProto(p.Poll),
+		})
+	}
+	nextCursor, err := nextPostsCursor(posts, ct.Limit(req.Limit))
+	if err != nil {
+		tele.Error(ctx, "Error in GetHomeTimeline @1 @2", "request", req.String(), "error", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ListPosts{Posts: pbPosts, NextCursor: nextCursor.String()}, nil
 }
 
 func (s *PostsHandler) GetUserPostsPaginated(ctx context.Context, req *pb.GetUserPostsReq) (*pb.ListPosts, error) {
@@ -254,6 +378,7 @@ func (s *PostsHandler) GetUserPostsPaginated(ctx context.Context, req *pb.GetUse
 		RequesterId: ct.Id(req.RequesterId),
 		Limit:       ct.Limit(req.Limit),
 		Offset:      ct.Offset(req.Offset),
+		Cursor:      ct.Cursor(req.Cursor),
 	})
 	if err != nil {
 		tele.Error(ctx, "Error in GetUserPostsPaginated @1 @2", "request", req.String(), "error", err.Error())
@@ -274,15 +399,23 @@ func (s *PostsHandler) GetUserPostsPaginated(ctx context.Context, req *pb.GetUse
 			Audience:        p.Audience.String(),
 			CommentsCount:   int32(p.CommentsCount),
 			ReactionsCount:  int32(p.ReactionsCount),
+			SharesCount:     int32(p.SharesCount),
+			SharedPost:      sharedPostToProto(p.SharedPost),
 			LastCommentedAt: p.LastCommentedAt.ToProto(),
 			CreatedAt:       p.CreatedAt.ToProto(),
 			UpdatedAt:       p.UpdatedAt.ToProto(),
 			LikedByUser:     p.LikedByUser,
 			ImageId:         int64(p.ImageId),
 			ImageUrl:        p.ImageUrl,
+			Poll:            pollToProto(p.Poll),
 		})
 	}
-	return &pb.ListPosts{Posts: pbPosts}, nil
+	nextCursor, err := nextPostsCursor(posts, ct.Limit(req.Limit))
+	if err != nil {
+		tele.Error(ctx, "Error in GetUserPostsPaginated @1 @2", "request", req.String(), "error", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ListPosts{Posts: pbPosts, NextCursor: nextCursor.String()}, nil
 }
 
 func (s *PostsHandler) GetGroupPostsPaginated(ctx context.Context, req *pb.GetGroupPostsReq) (*pb.ListPosts, error) {
@@ -290,12 +423,24 @@ func (s *PostsHandler) GetGroupPostsPaginated(ctx context.Context, req *pb.GetGr
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request is nil")
 	}
-	posts, err := s.Application.GetGroupPostsPaginated(ctx, models.GetGroupPostsReq{
+	groupReq := models.GetGroupPostsReq{
 		GroupId:     ct.Id(req.GroupId),
 		RequesterId: ct.Id(req.RequesterId),
 		Limit:       ct.Limit(req.Limit),
 		Offset:      ct.Offset(req.Offset),
-	})
+		Cursor:      ct.Cursor(req.Cursor),
+		Sort:        ct.FeedSort(req.Sort),
+	}
+	var (
+		posts      []models.Post
+		nextCursor ct.Cursor
+		err        error
+	)
+	if groupReq.Sort.IsTop() {
+		posts, nextCursor, err = s.Application.GetTopGroupPosts(ctx, groupReq)
+	} else {
+		posts, err = s.Application.GetGroupPostsPaginated(ctx, groupReq)
+	}
 	if err != nil {
 		tele.Error(ctx, "Error in GetGroupPostsPaginated @1 @2", "request", req.String(), "error", err.Error())
 		return nil, ce.EncodeProto(err)
@@ -315,15 +460,25 @@ func (s *PostsHandler) GetGroupPostsPaginated(ctx context.Context, req *pb.GetGr
 			Audience:        p.Audience.String(),
 			CommentsCount:   int32(p.CommentsCount),
 			ReactionsCount:  int32(p.ReactionsCount),
+			SharesCount:     int32(p.SharesCount),
+			SharedPost:      sharedPostToProto(p.SharedPost),
 			LastCommentedAt: p.LastCommentedAt.ToProto(),
 			CreatedAt:       p.CreatedAt.ToProto(),
 			UpdatedAt:       p.UpdatedAt.ToProto(),
 			LikedByUser:     p.LikedByUser,
 			ImageId:         int64(p.ImageId),
 			ImageUrl:        p.ImageUrl,
+			Poll:            pollToProto(p.Poll),
 		})
 	}
-	return &pb.ListPosts{Posts: pbPosts}, nil
+	if !groupReq.Sort.IsTop() {
+		nextCursor, err = nextPostsCursor(posts, ct.Limit(req.Limit))
+		if err != nil {
+			tele.Error(ctx, "Error in GetGroupPostsPaginated @1 @2", "request", req.String(), "error", err.Error())
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return &pb.ListPosts{Posts: pbPosts, NextCursor: nextCursor.String()}, nil
 }
 
 func (s *PostsHandler) CreateComment(ctx context.Context, req *pb.CreateCommentReq) (*pb.IdResp, error) {
@@ -389,6 +544,7 @@ func (s *PostsHandler) GetCommentsByParentId(ctx context.Context, req *pb.Entity
 		EntityId:    ct.Id(req.EntityId),
 		Limit:       ct.Limit(req.Limit),
 		Offset:      ct.Offset(req.Offset),
+		Cursor:      ct.Cursor(req.Cursor),
 	})
 	if err != nil {
 		tele.Error(ctx, "Error in GetCommentsByParentId @1 @2", "request", req.String(), "error", err.Error())
@@ -414,7 +570,12 @@ func (s *PostsHandler) GetCommentsByParentId(ctx context.Context, req *pb.Entity
 			ImageUrl:       c.ImageUrl,
 		})
 	}
-	return &pb.ListComments{Comments: pbComments}, nil
+	nextCursor, err := nextCommentsCursor(comments, ct.Limit(req.Limit))
+	if err != nil {
+		tele.Error(ctx, "Error in GetCommentsByParentId @1 @2", "request", req.String(), "error", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ListComments{Comments: pbComments, NextCursor: nextCursor.String()}, nil
 }
 
 func (s *PostsHandler) CreateEvent(ctx context.Context, req *pb.CreateEventReq) (*pb.IdResp, error) {
@@ -635,3 +796,349 @@ func (s *PostsHandler) GetPostAudienceForComment(ctx context.Context, req *pb.Si
 		Audience: audience,
 	}, nil
 }
+
+func (s *PostsHandler) GetEntityRevisions(ctx context.Context, req *pb.EntityIdPaginatedReq) (*pb.ListRevisions, error) {
+	tele.Info(ctx, "GetEntityRevisions gRPC method called. @1", "request", req.String())
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	revisions, err := s.Application.GetEntityRevisions(ctx, models.EntityIdPaginatedReq{
+		RequesterId: ct.Id(req.RequesterId),
+		EntityId:    ct.Id(req.EntityId),
+		Limit:       ct.Limit(req.Limit),
+		Offset:      ct.Offset(req.Offset),
+	})
+	if err != nil {
+		tele.Error(ctx, "Error in GetEntityRevisions @1 @2", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	pbRevisions := make([]*pb.Revision, 0, len(revisions))
+	for _, r := range revisions {
+		selectedUsers := make([]*cm.User, 0, len(r.SelectedAudienceUsers))
+		for _, u := range r.SelectedAudienceUsers {
+			selectedUsers = append(selectedUsers, &cm.User{
+				UserId:    u.UserId.Int64(),
+				Username:  u.Username.String(),
+				Avatar:    u.AvatarId.Int64(),
+				AvatarUrl: u.AvatarURL,
+			})
+		}
+		pbRevisions = append(pbRevisions, &pb.Revision{
+			RevisionId:  r.RevisionId.Int64(),
+			EntityId:    r.EntityId.Int64(),
+			ContentType: r.ContentType,
+			Editor: &cm.User{
+				UserId:    r.Editor.UserId.Int64(),
+				Username:  r.Editor.Username.String(),
+				Avatar:    r.Editor.AvatarId.Int64(),
+				AvatarUrl: r.Editor.AvatarURL,
+			},
+			Title:     r.Title.String(),
+			Body:      r.Body,
+			ImageIds:  r.ImageIds.Int64(),
+			ImageUrls: r.ImageUrls,
+			Audience:  r.Audience.String(),
+			SelectedAudienceUsers: &cm.ListUsers{
+				Users: selectedUsers,
+			},
+			EventDate: r.EventDate.ToProto(),
+			CreatedAt: r.CreatedAt.ToProto(),
+		})
+	}
+	return &pb.ListRevisions{Revisions: pbRevisions}, nil
+}
+
+func (s *PostsHandler) GetUserDrafts(ctx context.Context, req *pb.GenericPaginatedReq) (*pb.ListPosts, error) {
+	tele.Info(ctx, "GetUserDrafts gRPC method called. @1", "request", req.String())
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	posts, err := s.Application.GetUserDrafts(ctx, models.GenericPaginatedReq{
+		RequesterId: ct.Id(req.RequesterId),
+		Limit:       ct.Limit(req.Limit),
+		Offset:      ct.Offset(req.Offset),
+	})
+	if err != nil {
+		tele.Error(ctx, "Error in GetUserDrafts @1 @2", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	pbPosts := make([]*pb.Post, 0, len(posts))
+	for _, p := range posts {
+		selectedUsers := make([]*cm.User, 0, len(p.SelectedAudienceUsers))
+		for _, u := range p.SelectedAudienceUsers {
+			selectedUsers = append(selectedUsers, &cm.User{
+				UserId:    u.UserId.Int64(),
+				Username:  u.Username.String(),
+				Avatar:    u.AvatarId.Int64(),
+				AvatarUrl: u.AvatarURL,
+			})
+		}
+		pbPosts = append(pbPosts, &pb.Post{
+			PostId:   int64(p.PostId),
+			PostBody: string(p.Body),
+			User: &cm.User{
+				UserId:    p.User.UserId.Int64(),
+				Username:  p.User.Username.String(),
+				Avatar:    p.User.AvatarId.Int64(),
+				AvatarUrl: p.User.AvatarURL,
+			},
+			GroupId:   int64(p.GroupId),
+			Audience:  p.Audience.String(),
+			CreatedAt: p.CreatedAt.ToProto(),
+			UpdatedAt: p.UpdatedAt.ToProto(),
+			ImageId:   int64(p.ImageId),
+			ImageUrl:  p.ImageUrl,
+			Poll:      pollToProto(p.Poll),
+			SelectedAudienceUsers: &cm.ListUsers{
+				Users: selectedUsers,
+			},
+			Status:    p.Status.String(),
+			PublishAt: p.PublishAt.ToProto(),
+		})
+	}
+	return &pb.ListPosts{Posts: pbPosts}, nil
+}
+
+func (s *PostsHandler) UpdatePostStatus(ctx context.Context, req *pb.UpdatePostStatusReq) (*emptypb.Empty, error) {
+	tele.Info(ctx, "UpdatePostStatus gRPC method called. @1", "request", req.String())
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	var publishAt ct.GenDateTime
+	if req.PublishAt != nil {
+		publishAt = ct.GenDateTime(req.PublishAt.AsTime())
+	}
+	err := s.Application.UpdatePostStatus(ctx, models.UpdatePostStatusReq{
+		RequesterId: ct.Id(req.RequesterId),
+		PostId:      ct.Id(req.PostId),
+		Status:      ct.PostStatus(req.Status),
+		PublishAt:   publishAt,
+	})
+	if err != nil {
+		tele.Error(ctx, "Error in UpdatePostStatus. @1 @2", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *PostsHandler) VotePoll(ctx context.Context, req *pb.VotePollReq) (*emptypb.Empty, error) {
+	tele.Info(ctx, "VotePoll gRPC method called. @1", "request", req.String())
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	err := s.Application.VotePoll(ctx, models.VotePollReq{
+		RequesterId: ct.Id(req.RequesterId),
+		PollId:      ct.Id(req.PollId),
+		OptionIds:   ct.FromInt64s(req.OptionIds),
+	})
+	if err != nil {
+		tele.Error(ctx, "Error in VotePoll. @1 @2", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func pollFromProto(p *pb.CreatePollReq) *models.CreatePollReq {
+	if p == nil {
+		return nil
+	}
+	var closesAt ct.GenDateTime
+	if p.ClosesAt != nil {
+		closesAt = ct.GenDateTime(p.ClosesAt.AsTime())
+	}
+	return &models.CreatePollReq{
+		Question:       ct.PollQuestion(p.Question),
+		Options:        ct.PollOptions(p.Options),
+		MultipleChoice: p.MultipleChoice,
+		ClosesAt:       closesAt,
+	}
+}
+
+func pollToProto(p *models.Poll) *pb.Poll {
+	if p == nil {
+		return nil
+	}
+	options := make([]*pb.PollOption, 0, len(p.Options))
+	for _, o := range p.Options {
+		options = append(options, &pb.PollOption{
+			OptionId:    o.OptionId.Int64(),
+			Text:        o.Text,
+			VotesCount:  int32(o.VotesCount),
+			VotedByUser: o.VotedByUser,
+		})
+	}
+	return &pb.Poll{
+		PollId:         p.PollId.Int64(),
+		Question:       p.Question.String(),
+		Options:        options,
+		MultipleChoice: p.MultipleChoice,
+		ClosesAt:       p.ClosesAt.ToProto(),
+		Closed:         p.Closed,
+		VotersCount:    int32(p.VotersCount),
+		ResultsVisible: p.ResultsVisible,
+	}
+}
+
+func (s *PostsHandler) SharePost(ctx context.Context, req *pb.SharePostReq) (*pb.IdResp, error) {
+	tele.Info(ctx, "SharePost gRPC method called. @1", "request", req.String())
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	shareId, err := s.Application.SharePost(ctx, models.SharePostReq{
+		RequesterId: ct.Id(req.RequesterId),
+		PostId:      ct.Id(req.PostId),
+		Body:        ct.PostBody(req.Body),
+		GroupId:     ct.Id(req.GroupId),
+		Audience:    ct.Audience(req.Audience),
+		AudienceIds: ct.FromInt64s(req.AudienceIds.GetValues()),
+	})
+	if err != nil {
+		tele.Error(ctx, "Error in SharePost. @1 @2", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	return &pb.IdResp{Id: shareId}, nil
+}
+
+func sharedPostToProto(p *models.SharedPost) *pb.SharedPost {
+	if p == nil {
+		return nil
+	}
+	if !p.Available {
+		return &pb.SharedPost{PostId: p.PostId.Int64()}
+	}
+	return &pb.SharedPost{
+		PostId:    p.PostId.Int64(),
+		Available: true,
+		PostBody:  p.Body.String(),
+		User: &cm.User{
+			UserId:    p.User.UserId.Int64(),
+			Username:  p.User.Username.String(),
+			Avatar:    p.User.AvatarId.Int64(),
+			AvatarUrl: p.User.AvatarURL,
+		},
+		GroupId:   p.GroupId.Int64(),
+		Audience:  p.Audience.String(),
+		CreatedAt: p.CreatedAt.ToProto(),
+		UpdatedAt: p.UpdatedAt.ToProto(),
+		ImageId:   p.ImageId.Int64(),
+		ImageUrl:  p.ImageUrl,
+	}
+}
+
+func (s *PostsHandler) SaveEntity(ctx context.Context, req *pb.SaveEntityReq) (*emptypb.Empty, error) {
+	tele.Info(ctx, "SaveEntity gRPC method called. @1", "request", req.String())
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	err := s.Application.SaveEntity(ctx, models.SaveEntityReq{
+		RequesterId: ct.Id(req.RequesterId),
+		EntityId:    ct.Id(req.EntityId),
+		Collection:  ct.Title(req.Collection),
+	})
+	if err != nil {
+		tele.Error(ctx, "Error in SaveEntity. @1 @2", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *PostsHandler) UnsaveEntity(ctx context.Context, req *pb.GenericReq) (*emptypb.Empty, error) {
+	tele.Info(ctx, "UnsaveEntity gRPC method called. @1", "request", req.String())
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	err := s.Application.UnsaveEntity(ctx, models.GenericReq{
+		RequesterId: ct.Id(req.RequesterId),
+		EntityId:    ct.Id(req.EntityId),
+	})
+	if err != nil {
+		tele.Error(ctx, "Error in UnsaveEntity. @1 @2", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *PostsHandler) ListSaved(ctx context.Context, req *pb.ListSavedReq) (*pb.ListPosts, error) {
+	tele.Info(ctx, "ListSaved gRPC method called. @1", "request", req.String())
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	posts, err := s.Application.ListSaved(ctx, models.ListSavedReq{
+		RequesterId: ct.Id(req.RequesterId),
+		Collection:  ct.Title(req.Collection),
+		Limit:       ct.Limit(req.Limit),
+		Offset:      ct.Offset(req.Offset),
+	})
+	if err != nil {
+		tele.Error(ctx, "Error in ListSaved @1 @2", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	pbPosts := make([]*pb.Post, 0, len(posts))
+	for _, p := range posts {
+		pbPosts = append(pbPosts, &pb.Post{
+			PostId:   int64(p.PostId),
+			PostBody: string(p.Body),
+			User: &cm.User{
+				UserId:    p.User.UserId.Int64(),
+				Username:  p.User.Username.String(),
+				Avatar:    p.User.AvatarId.Int64(),
+				AvatarUrl: p.User.AvatarURL,
+			},
+			GroupId:         int64(p.GroupId),
+			Audience:        p.Audience.String(),
+			CommentsCount:   int32(p.CommentsCount),
+			ReactionsCount:  int32(p.ReactionsCount),
+			SharesCount:     int32(p.SharesCount),
+			SharedPost:      sharedPostToProto(p.SharedPost),
+			LastCommentedAt: p.LastCommentedAt.ToProto(),
+			CreatedAt:       p.CreatedAt.ToProto(),
+			UpdatedAt:       p.UpdatedAt.ToProto(),
+			LikedByUser:     p.LikedByUser,
+			ImageId:         int64(p.ImageId),
+			ImageUrl:        p.ImageUrl,
+			Poll:            pollToProto(p.Poll),
+		})
+	}
+	return &pb.ListPosts{Posts: pbPosts}, nil
+}
+
+func (s *PostsHandler) GetSavedCollections(ctx context.Context, req *pb.SimpleIdReq) (*pb.ListSavedCollections, error) {
+	tele.Info(ctx, "GetSavedCollections gRPC method called. @1", "request", req.String())
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	collections, err := s.Application.GetSavedCollections(ctx, models.SimpleIdReq{
+		Id: ct.Id(req.Id),
+	})
+	if err != nil {
+		tele.Error(ctx, "Error in GetSavedCollections @1 @2", "request", req.String(), "error", err.Error())
+		return nil, ce.EncodeProto(err)
+	}
+	pbCollections := make([]*pb.SavedCollection, 0, len(collections))
+	for _, c := range collections {
+		pbCollections = append(pbCollections, &pb.SavedCollection{
+			Name:       c.Name.String(),
+			SavedCount: int32(c.SavedCount),
+		})
+	}
+	return &pb.ListSavedCollections{Collections: pbCollections}, nil
+}
+
+// nextPostsCursor returns the cursor of the page after posts, or an empty cursor
+// when posts is shorter than limit and there is nothing left to fetch.
+func nextPostsCursor(posts []models.Post, limit ct.Limit) (ct.Cursor, error) {
+	if len(posts) == 0 || len(posts) < int(limit) {
+		return "", nil
+	}
+	last := posts[len(posts)-1]
+	return ct.NewCursor(last.CreatedAt.Time(), last.PostId.Int64())
+}
+
+// nextCommentsCursor is nextPostsCursor for comments.
+func nextCommentsCursor(comments []models.Comment, limit ct.Limit) (ct.Cursor, error) {
+	if len(comments) == 0 || len(comments) < int(limit) {
+		return "", nil
+	}
+	last := comments[len(comments)-1]
+	return ct.NewCursor(last.CreatedAt.Time(), last.CommentId.Int64())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"social-network/services/users/internal/client"
 	ds "social-network/services/users/internal/db/dbservice"
+	"social-network/shared/gen-go/media"
 	"social-network/shared/go/kafgo"
 	"social-network/shared/go/models"
 	"social-network/shared/go/notifevents"
@@ -43,6 +44,7 @@ type ClientsInterface interface {
 	GetObj(ctx context.Context, key string, dest any) error
 	SetObj(ctx context.Context, key string, value any, exp time.Duration) error
 	Del(ctx context.Context, key string) error
+	AttachImage(ctx context.Context, imageId, ownerId, entityId int64, entityType media.AttachmentType) error
 	CreateNotification(ctx context.Context, req models.CreateNotificationRequest) error
 	CreateFollowRequestNotification(ctx context.Context, targetUserID, requesterUserID int64, requesterUsername string) error
 	CreateNewFollower(ctx context.Context, targetUserID, followerUserID int64, followerUsername string) error
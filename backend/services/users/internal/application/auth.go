@@ -56,6 +56,13 @@ func (s *Application) RegisterUser(ctx context.Context, req models.RegisterUserR
 		}
 		newId = ct.Id(userId)
 
+		// the avatar was uploaded before the user existed, attaching it makes the user its owner
+		if req.AvatarId != 0 {
+			if err := s.clients.AttachImage(ctx, req.AvatarId.Int64(), userId, userId, media.AttachmentType_ATTACHMENT_AVATAR); err != nil {
+				return ce.DecodeProto(err, input)
+			}
+		}
+
 		// Insert auth
 		return q.InsertNewUserAuth(ctx, ds.InsertNewUserAuthParams{
 			UserID:       newId.Int64(),
@@ -71,6 +78,10 @@ func (s *Application) RegisterUser(ctx context.Context, req models.RegisterUserR
 				return models.RegisterUserResponse{}, ce.New(ce.ErrAlreadyExists, err, input).WithPublic("email already exists")
 			}
 		}
+		var commonErr *ce.Error
+		if errors.As(err, &commonErr) {
+			return models.RegisterUserResponse{}, ce.Wrap(nil, err)
+		}
 		return models.RegisterUserResponse{}, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
 	}
 
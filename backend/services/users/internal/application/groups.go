@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	ds "social-network/services/users/internal/db/dbservice"
 	"social-network/shared/gen-go/media"
@@ -733,11 +734,25 @@ func (s *Application) CreateGroup(ctx context.Context, req *models.CreateGroupRe
 		return 0, ce.Wrap(ce.ErrInvalidArgument, err, "request validation failed", input).WithPublic("invalid data received")
 	}
 
-	groupId, err := s.db.CreateGroup(ctx, ds.CreateGroupParams{
-		GroupOwner:       req.OwnerId.Int64(),
-		GroupTitle:       req.GroupTitle.String(),
-		GroupDescription: req.GroupDescription.String(),
-		GroupImageID:     req.GroupImage.Int64(),
+	var groupId int64
+	err := s.txRunner.RunTx(ctx, func(q *ds.Queries) error {
+		var err error
+		groupId, err = q.CreateGroup(ctx, ds.CreateGroupParams{
+			GroupOwner:       req.OwnerId.Int64(),
+			GroupTitle:       req.GroupTitle.String(),
+			GroupDescription: req.GroupDescription.String(),
+			GroupImageID:     req.GroupImage.Int64(),
+		})
+		if err != nil {
+			return err
+		}
+
+		if req.GroupImage != 0 {
+			if err := s.clients.AttachImage(ctx, req.GroupImage.Int64(), req.OwnerId.Int64(), groupId, media.AttachmentType_ATTACHMENT_GROUP); err != nil {
+				return ce.DecodeProto(err, input)
+			}
+		}
+		return nil
 	})
 	if err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok {
@@ -745,6 +760,10 @@ func (s *Application) CreateGroup(ctx context.Context, req *models.CreateGroupRe
 				return 0, ce.New(ce.ErrAlreadyExists, err, input).WithPublic("group already exists")
 			}
 		}
+		var commonErr *ce.Error
+		if errors.As(err, &commonErr) {
+			return 0, ce.Wrap(nil, err)
+		}
 		return 0, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
 	}
 
@@ -775,6 +794,12 @@ func (s *Application) UpdateGroup(ctx context.Context, req *models.UpdateGroupRe
 		groupImageId = 0
 	}
 
+	if groupImageId != 0 {
+		if err := s.clients.AttachImage(ctx, groupImageId, req.RequesterId.Int64(), req.GroupId.Int64(), media.AttachmentType_ATTACHMENT_GROUP); err != nil {
+			return ce.DecodeProto(err, input)
+		}
+	}
+
 	rowsAffected, err := s.db.UpdateGroup(ctx, ds.UpdateGroupParams{
 		ID:               req.GroupId.Int64(),
 		GroupTitle:       req.GroupTitle.String(),
@@ -236,6 +236,12 @@ func (s *Application) UpdateUserProfile(ctx context.Context, req models.UpdatePr
 		avatarId = 0
 	}
 
+	if avatarId != 0 {
+		if err := s.clients.AttachImage(ctx, avatarId, req.UserId.Int64(), req.UserId.Int64(), media.AttachmentType_ATTACHMENT_AVATAR); err != nil {
+			return models.UserProfileResponse{}, ce.DecodeProto(err, input)
+		}
+	}
+
 	row, err := s.db.UpdateUserProfile(ctx, ds.UpdateUserProfileParams{
 		ID:          req.UserId.Int64(),
 		Username:    req.Username.String(),
@@ -295,6 +301,22 @@ func (s *Application) UpdateProfilePrivacy(ctx context.Context, req models.Updat
 	return nil
 }
 
+// Returns which of the given images are still used as a user avatar or a group image.
+// Used by the media service to collect unreferenced files.
+func (s *Application) GetUsedImageIds(ctx context.Context, imageIds ct.Ids) (ct.Ids, error) {
+	input := fmt.Sprintf("image ids: %v", imageIds)
+
+	if len(imageIds) == 0 {
+		return ct.Ids{}, nil
+	}
+
+	used, err := s.db.GetUsedImageIds(ctx, imageIds.Int64())
+	if err != nil {
+		return nil, ce.New(ce.ErrInternal, err, input).WithPublic(genericPublic)
+	}
+	return ct.FromInt64s(used), nil
+}
+
 func (s *Application) RemoveImages(ctx context.Context, failedImages []int64) error {
 	//input := fmt.Sprintf("%#v", failedImages)
 
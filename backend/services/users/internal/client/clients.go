@@ -41,6 +41,18 @@ func (c *Clients) GetImage(ctx context.Context, imageId int64, variant media.Fil
 	return resp.DownloadUrl, nil
 }
 
+// AttachImage records in the media service that an image is used by an entity of ownerId.
+// Fails with permission denied if the image belongs to another user.
+func (c *Clients) AttachImage(ctx context.Context, imageId, ownerId, entityId int64, entityType media.AttachmentType) error {
+	_, err := c.MediaClient.AttachFile(ctx, &mediapb.FileAttachment{
+		FileId:   imageId,
+		OwnerId:  ownerId,
+		Type:     entityType,
+		EntityId: entityId,
+	})
+	return err
+}
+
 func (c *Clients) GetObj(ctx context.Context, key string, dest any) error {
 	return c.RedisClient.GetObj(ctx, key, dest)
 }
@@ -311,3 +311,35 @@ func (q *Queries) RemoveImages(ctx context.Context, arg []int64) error {
 	_, err := q.db.Exec(ctx, removeImages, arg)
 	return err
 }
+
+const getUsedImageIds = `-- name: GetUsedImageIds :many
+SELECT avatar_id AS id
+FROM users
+WHERE avatar_id = ANY($1::bigint[])
+  AND deleted_at IS NULL
+UNION
+SELECT group_image_id AS id
+FROM groups
+WHERE group_image_id = ANY($1::bigint[])
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) GetUsedImageIds(ctx context.Context, arg []int64) ([]int64, error) {
+	rows, err := q.db.Query(ctx, getUsedImageIds, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
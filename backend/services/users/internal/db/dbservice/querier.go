@@ -37,6 +37,7 @@ type Querier interface {
 	GetMutualFollowers(ctx context.Context, arg GetMutualFollowersParams) ([]GetMutualFollowersRow, error)
 	GetPendingGroupJoinRequests(ctx context.Context, arg GetPendingGroupJoinRequestsParams) ([]GetPendingGroupJoinRequestsRow, error)
 	GetPendingGroupJoinRequestsCount(ctx context.Context, arg GetPendingGroupJoinRequestsCountParams) (int64, error)
+	GetUsedImageIds(ctx context.Context, arg []int64) ([]int64, error)
 	GetUserBasic(ctx context.Context, id int64) (GetUserBasicRow, error)
 	GetUserForLogin(ctx context.Context, arg GetUserForLoginParams) (GetUserForLoginRow, error)
 	GetUserGroupRole(ctx context.Context, arg GetUserGroupRoleParams) (NullGroupRole, error)
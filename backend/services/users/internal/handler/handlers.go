@@ -1253,6 +1253,21 @@ func (s *UsersHandler) RemoveImages(ctx context.Context, req *pb.FailedImageIds)
 	return &emptypb.Empty{}, nil
 }
 
+func (s *UsersHandler) GetUsedImageIds(ctx context.Context, req *pb.Ids) (*pb.Ids, error) {
+	tele.Info(ctx, "GetUsedImageIds called with @1", "request", req.String())
+
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "GetUsedImageIds: request is nil")
+	}
+
+	used, err := s.Application.GetUsedImageIds(ctx, ct.FromInt64s(req.Ids))
+	if err != nil {
+		tele.Error(ctx, "Error in GetUsedImageIds. @1", "error", err.Error(), "request", req.String())
+		return nil, ce.EncodeProto(err)
+	}
+	return &pb.Ids{Ids: used.Int64()}, nil
+}
+
 // CONVERTORS
 func usersToPB(dbUsers []models.User) *cm.ListUsers {
 	pbUsers := make([]*cm.User, 0, len(dbUsers))
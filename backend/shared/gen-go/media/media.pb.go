@@ -9,7 +9,7 @@ package media
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	_ "google.golang.org/protobuf/types/known/emptypb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,7 +23,8 @@ const (
 )
 
 // Describes the type of file
-// original, thumb, small, medium, large, original
+// original, thumb, small, medium, large, original for images
+// mp4, webm, poster for videos
 type FileVariant int32
 
 const (
@@ -33,6 +34,9 @@ const (
 	FileVariant_MEDIUM                  FileVariant = 3 // Medium size variant
 	FileVariant_LARGE                   FileVariant = 4 // Large size variant
 	FileVariant_ORIGINAL                FileVariant = 5 // Original size variant
+	FileVariant_VIDEO_MP4               FileVariant = 6 // H.264/AAC MP4 rendition of a video
+	FileVariant_VIDEO_WEBM              FileVariant = 7 // VP9/Opus WebM rendition of a video
+	FileVariant_VIDEO_POSTER            FileVariant = 8 // WebP still frame of a video
 )
 
 // Enum value maps for FileVariant.
@@ -44,6 +48,9 @@ var (
 		3: "MEDIUM",
 		4: "LARGE",
 		5: "ORIGINAL",
+		6: "VIDEO_MP4",
+		7: "VIDEO_WEBM",
+		8: "VIDEO_POSTER",
 	}
 	FileVariant_value = map[string]int32{
 		"IMG_VARIANT_UNSPECIFIED": 0,
@@ -52,6 +59,9 @@ var (
 		"MEDIUM":                  3,
 		"LARGE":                   4,
 		"ORIGINAL":                5,
+		"VIDEO_MP4":               6,
+		"VIDEO_WEBM":              7,
+		"VIDEO_POSTER":            8,
 	}
 )
 
@@ -134,7 +144,7 @@ func (FileVisibility) EnumDescriptor() ([]byte, []int) {
 }
 
 // Describes the file upload status:
-// pending, processing, complete, failed
+// pending, processing, complete, failed, quarantined
 type UploadStatus int32
 
 const (
@@ -143,6 +153,7 @@ const (
 	UploadStatus_UPLOAD_STATUS_PROCESSING  UploadStatus = 2
 	UploadStatus_UPLOAD_STATUS_COMPLETE    UploadStatus = 3
 	UploadStatus_UPLOAD_STATUS_FAILED      UploadStatus = 4
+	UploadStatus_UPLOAD_STATUS_QUARANTINED UploadStatus = 5 // flagged by the content scanner
 )
 
 // Enum value maps for UploadStatus.
@@ -153,6 +164,7 @@ var (
 		2: "UPLOAD_STATUS_PROCESSING",
 		3: "UPLOAD_STATUS_COMPLETE",
 		4: "UPLOAD_STATUS_FAILED",
+		5: "UPLOAD_STATUS_QUARANTINED",
 	}
 	UploadStatus_value = map[string]int32{
 		"UPLOAD_STATUS_UNSPECIFIED": 0,
@@ -160,6 +172,7 @@ var (
 		"UPLOAD_STATUS_PROCESSING":  2,
 		"UPLOAD_STATUS_COMPLETE":    3,
 		"UPLOAD_STATUS_FAILED":      4,
+		"UPLOAD_STATUS_QUARANTINED": 5,
 	}
 )
 
@@ -190,6 +203,69 @@ func (UploadStatus) EnumDescriptor() ([]byte, []int) {
 	return file_media_proto_rawDescGZIP(), []int{2}
 }
 
+// Describes the kind of entity a file is attached to
+// post, comment, event, avatar, group, message
+type AttachmentType int32
+
+const (
+	AttachmentType_ATTACHMENT_TYPE_UNSPECIFIED AttachmentType = 0
+	AttachmentType_ATTACHMENT_POST             AttachmentType = 1
+	AttachmentType_ATTACHMENT_COMMENT          AttachmentType = 2
+	AttachmentType_ATTACHMENT_EVENT            AttachmentType = 3
+	AttachmentType_ATTACHMENT_AVATAR           AttachmentType = 4
+	AttachmentType_ATTACHMENT_GROUP            AttachmentType = 5
+	AttachmentType_ATTACHMENT_MESSAGE          AttachmentType = 6
+)
+
+// Enum value maps for AttachmentType.
+var (
+	AttachmentType_name = map[int32]string{
+		0: "ATTACHMENT_TYPE_UNSPECIFIED",
+		1: "ATTACHMENT_POST",
+		2: "ATTACHMENT_COMMENT",
+		3: "ATTACHMENT_EVENT",
+		4: "ATTACHMENT_AVATAR",
+		5: "ATTACHMENT_GROUP",
+		6: "ATTACHMENT_MESSAGE",
+	}
+	AttachmentType_value = map[string]int32{
+		"ATTACHMENT_TYPE_UNSPECIFIED": 0,
+		"ATTACHMENT_POST":             1,
+		"ATTACHMENT_COMMENT":          2,
+		"ATTACHMENT_EVENT":            3,
+		"ATTACHMENT_AVATAR":           4,
+		"ATTACHMENT_GROUP":            5,
+		"ATTACHMENT_MESSAGE":          6,
+	}
+)
+
+func (x AttachmentType) Enum() *AttachmentType {
+	p := new(AttachmentType)
+	*p = x
+	return p
+}
+
+func (x AttachmentType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AttachmentType) Descriptor() protoreflect.EnumDescriptor {
+	return file_media_proto_enumTypes[3].Descriptor()
+}
+
+func (AttachmentType) Type() protoreflect.EnumType {
+	return &file_media_proto_enumTypes[3]
+}
+
+func (x AttachmentType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AttachmentType.Descriptor instead.
+func (AttachmentType) EnumDescriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{3}
+}
+
 // Request message for uploading an image
 type UploadImageRequest struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -199,6 +275,7 @@ type UploadImageRequest struct {
 	Visibility        FileVisibility         `protobuf:"varint,4,opt,name=visibility,proto3,enum=media.FileVisibility" json:"visibility,omitempty"`
 	ExpirationSeconds int64                  `protobuf:"varint,5,opt,name=expiration_seconds,json=expirationSeconds,proto3" json:"expiration_seconds,omitempty"` // Expiration time for the upload URL in seconds
 	Variants          []FileVariant          `protobuf:"varint,6,rep,packed,name=variants,proto3,enum=media.FileVariant" json:"variants,omitempty"`              // List of image variants to generate
+	OwnerId           int64                  `protobuf:"varint,7,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`                               // Uploader, unset when uploading before the user exists
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -275,6 +352,13 @@ func (x *UploadImageRequest) GetVariants() []FileVariant {
 	return nil
 }
 
+func (x *UploadImageRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
 // Response message for uploading an image
 type UploadImageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -328,29 +412,34 @@ func (x *UploadImageResponse) GetUploadUrl() string {
 	return ""
 }
 
-// Request message for retrieving an image
-type GetImageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ImageId       int64                  `protobuf:"varint,1,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`         // Unique identifier of the image
-	Variant       FileVariant            `protobuf:"varint,2,opt,name=variant,proto3,enum=media.FileVariant" json:"variant,omitempty"` // Desired variant of the image
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Request message for uploading a video
+type UploadVideoRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Filename          string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	MimeType          string                 `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	SizeBytes         int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Visibility        FileVisibility         `protobuf:"varint,4,opt,name=visibility,proto3,enum=media.FileVisibility" json:"visibility,omitempty"`
+	ExpirationSeconds int64                  `protobuf:"varint,5,opt,name=expiration_seconds,json=expirationSeconds,proto3" json:"expiration_seconds,omitempty"` // Expiration time for the upload URL in seconds
+	Variants          []FileVariant          `protobuf:"varint,6,rep,packed,name=variants,proto3,enum=media.FileVariant" json:"variants,omitempty"`              // Renditions to transcode, video variants only
+	OwnerId           int64                  `protobuf:"varint,7,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`                               // Uploader
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *GetImageRequest) Reset() {
-	*x = GetImageRequest{}
+func (x *UploadVideoRequest) Reset() {
+	*x = UploadVideoRequest{}
 	mi := &file_media_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetImageRequest) String() string {
+func (x *UploadVideoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetImageRequest) ProtoMessage() {}
+func (*UploadVideoRequest) ProtoMessage() {}
 
-func (x *GetImageRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadVideoRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_media_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -362,47 +451,83 @@ func (x *GetImageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetImageRequest.ProtoReflect.Descriptor instead.
-func (*GetImageRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadVideoRequest.ProtoReflect.Descriptor instead.
+func (*UploadVideoRequest) Descriptor() ([]byte, []int) {
 	return file_media_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetImageRequest) GetImageId() int64 {
+func (x *UploadVideoRequest) GetFilename() string {
 	if x != nil {
-		return x.ImageId
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadVideoRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *UploadVideoRequest) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
 	}
 	return 0
 }
 
-func (x *GetImageRequest) GetVariant() FileVariant {
+func (x *UploadVideoRequest) GetVisibility() FileVisibility {
 	if x != nil {
-		return x.Variant
+		return x.Visibility
 	}
-	return FileVariant_IMG_VARIANT_UNSPECIFIED
+	return FileVisibility_FILE_VISIBILITY_UNSPECIFIED
 }
 
-// Response message for retrieving an image
-type GetImageResponse struct {
+func (x *UploadVideoRequest) GetExpirationSeconds() int64 {
+	if x != nil {
+		return x.ExpirationSeconds
+	}
+	return 0
+}
+
+func (x *UploadVideoRequest) GetVariants() []FileVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *UploadVideoRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+// Response message for uploading a video
+type UploadVideoResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DownloadUrl   string                 `protobuf:"bytes,1,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"` // Pre-signed URL for downloading the image
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`         // Unique identifier of the uploaded file
+	UploadUrl     string                 `protobuf:"bytes,2,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"` // Pre-signed URL for uploading the file
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetImageResponse) Reset() {
-	*x = GetImageResponse{}
+func (x *UploadVideoResponse) Reset() {
+	*x = UploadVideoResponse{}
 	mi := &file_media_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetImageResponse) String() string {
+func (x *UploadVideoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetImageResponse) ProtoMessage() {}
+func (*UploadVideoResponse) ProtoMessage() {}
 
-func (x *GetImageResponse) ProtoReflect() protoreflect.Message {
+func (x *UploadVideoResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_media_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -414,41 +539,49 @@ func (x *GetImageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetImageResponse.ProtoReflect.Descriptor instead.
-func (*GetImageResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadVideoResponse.ProtoReflect.Descriptor instead.
+func (*UploadVideoResponse) Descriptor() ([]byte, []int) {
 	return file_media_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetImageResponse) GetDownloadUrl() string {
+func (x *UploadVideoResponse) GetFileId() int64 {
 	if x != nil {
-		return x.DownloadUrl
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *UploadVideoResponse) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
 	}
 	return ""
 }
 
-// Request message for validating an upload
-type ValidateUploadRequest struct {
+// Request message for retrieving an image
+type GetImageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`          // Metadata of the upload to validate
-	ReturnUrl     bool                   `protobuf:"varint,2,opt,name=return_url,json=returnUrl,proto3" json:"return_url,omitempty"` // Request download url after validation
+	ImageId       int64                  `protobuf:"varint,1,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`             // Unique identifier of the image
+	Variant       FileVariant            `protobuf:"varint,2,opt,name=variant,proto3,enum=media.FileVariant" json:"variant,omitempty"`     // Desired variant of the image
+	RequesterId   int64                  `protobuf:"varint,3,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"` // User asking for the image. Unset for trusted service calls
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValidateUploadRequest) Reset() {
-	*x = ValidateUploadRequest{}
+func (x *GetImageRequest) Reset() {
+	*x = GetImageRequest{}
 	mi := &file_media_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidateUploadRequest) String() string {
+func (x *GetImageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateUploadRequest) ProtoMessage() {}
+func (*GetImageRequest) ProtoMessage() {}
 
-func (x *ValidateUploadRequest) ProtoReflect() protoreflect.Message {
+func (x *GetImageRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_media_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -460,47 +593,55 @@ func (x *ValidateUploadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateUploadRequest.ProtoReflect.Descriptor instead.
-func (*ValidateUploadRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetImageRequest.ProtoReflect.Descriptor instead.
+func (*GetImageRequest) Descriptor() ([]byte, []int) {
 	return file_media_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ValidateUploadRequest) GetFileId() int64 {
+func (x *GetImageRequest) GetImageId() int64 {
 	if x != nil {
-		return x.FileId
+		return x.ImageId
 	}
 	return 0
 }
 
-func (x *ValidateUploadRequest) GetReturnUrl() bool {
+func (x *GetImageRequest) GetVariant() FileVariant {
 	if x != nil {
-		return x.ReturnUrl
+		return x.Variant
 	}
-	return false
+	return FileVariant_IMG_VARIANT_UNSPECIFIED
 }
 
-// Response on validate upload containing download url only if requested
-type ValidateUploadResponse struct {
+func (x *GetImageRequest) GetRequesterId() int64 {
+	if x != nil {
+		return x.RequesterId
+	}
+	return 0
+}
+
+// Response message for retrieving an image
+type GetImageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DownloadUrl   string                 `protobuf:"bytes,1,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"` // Pre-signed URL for downloading the image
+	DownloadUrl   string                 `protobuf:"bytes,1,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"` // Pre-signed URL for downloading the image, stable URL for public files
+	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`      // Unix seconds, unset when the URL does not expire. Cache the URL until shortly before
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValidateUploadResponse) Reset() {
-	*x = ValidateUploadResponse{}
+func (x *GetImageResponse) Reset() {
+	*x = GetImageResponse{}
 	mi := &file_media_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidateUploadResponse) String() string {
+func (x *GetImageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateUploadResponse) ProtoMessage() {}
+func (*GetImageResponse) ProtoMessage() {}
 
-func (x *ValidateUploadResponse) ProtoReflect() protoreflect.Message {
+func (x *GetImageResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_media_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -512,39 +653,48 @@ func (x *ValidateUploadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateUploadResponse.ProtoReflect.Descriptor instead.
-func (*ValidateUploadResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetImageResponse.ProtoReflect.Descriptor instead.
+func (*GetImageResponse) Descriptor() ([]byte, []int) {
 	return file_media_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ValidateUploadResponse) GetDownloadUrl() string {
+func (x *GetImageResponse) GetDownloadUrl() string {
 	if x != nil {
 		return x.DownloadUrl
 	}
 	return ""
 }
 
-type ImageIds struct {
+func (x *GetImageResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// Request message for a public file served by the gateway
+type PublicFileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ImgIds        []int64                `protobuf:"varint,1,rep,packed,name=img_ids,json=imgIds,proto3" json:"img_ids,omitempty"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Variant       FileVariant            `protobuf:"varint,2,opt,name=variant,proto3,enum=media.FileVariant" json:"variant,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ImageIds) Reset() {
-	*x = ImageIds{}
+func (x *PublicFileRequest) Reset() {
+	*x = PublicFileRequest{}
 	mi := &file_media_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ImageIds) String() string {
+func (x *PublicFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ImageIds) ProtoMessage() {}
+func (*PublicFileRequest) ProtoMessage() {}
 
-func (x *ImageIds) ProtoReflect() protoreflect.Message {
+func (x *PublicFileRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_media_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -556,43 +706,51 @@ func (x *ImageIds) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ImageIds.ProtoReflect.Descriptor instead.
-func (*ImageIds) Descriptor() ([]byte, []int) {
+// Deprecated: Use PublicFileRequest.ProtoReflect.Descriptor instead.
+func (*PublicFileRequest) Descriptor() ([]byte, []int) {
 	return file_media_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ImageIds) GetImgIds() []int64 {
+func (x *PublicFileRequest) GetFileId() int64 {
 	if x != nil {
-		return x.ImgIds
+		return x.FileId
 	}
-	return nil
+	return 0
 }
 
-// Request with an array of file ids and the prefered variant.
-// Variant is common for all ids. If a variant is not present
-// returns the original.
-type GetImagesRequest struct {
+func (x *PublicFileRequest) GetVariant() FileVariant {
+	if x != nil {
+		return x.Variant
+	}
+	return FileVariant_IMG_VARIANT_UNSPECIFIED
+}
+
+// Size, fit and format of an image generated on demand. Images are never upscaled.
+type ImageTransform struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ImgIds        *ImageIds              `protobuf:"bytes,1,opt,name=img_ids,json=imgIds,proto3" json:"img_ids,omitempty"`
-	Variant       FileVariant            `protobuf:"varint,2,opt,name=variant,proto3,enum=media.FileVariant" json:"variant,omitempty"`
+	Width         int32                  `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`                          // Maximum width in pixels. Defaults to the limit with fit contain
+	Height        int32                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`                        // Maximum height in pixels. Defaults to the limit with fit contain
+	Fit           string                 `protobuf:"bytes,3,opt,name=fit,proto3" json:"fit,omitempty"`                               // contain (default) or cover, cover crops around the center
+	Format        string                 `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`                         // webp (default), jpeg or png
+	Preset        FileVariant            `protobuf:"varint,5,opt,name=preset,proto3,enum=media.FileVariant" json:"preset,omitempty"` // Named image variant whose transform is used instead of the fields above
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetImagesRequest) Reset() {
-	*x = GetImagesRequest{}
+func (x *ImageTransform) Reset() {
+	*x = ImageTransform{}
 	mi := &file_media_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetImagesRequest) String() string {
+func (x *ImageTransform) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetImagesRequest) ProtoMessage() {}
+func (*ImageTransform) ProtoMessage() {}
 
-func (x *GetImagesRequest) ProtoReflect() protoreflect.Message {
+func (x *ImageTransform) ProtoReflect() protoreflect.Message {
 	mi := &file_media_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -604,49 +762,71 @@ func (x *GetImagesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetImagesRequest.ProtoReflect.Descriptor instead.
-func (*GetImagesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImageTransform.ProtoReflect.Descriptor instead.
+func (*ImageTransform) Descriptor() ([]byte, []int) {
 	return file_media_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetImagesRequest) GetImgIds() *ImageIds {
+func (x *ImageTransform) GetWidth() int32 {
 	if x != nil {
-		return x.ImgIds
+		return x.Width
 	}
-	return nil
+	return 0
 }
 
-func (x *GetImagesRequest) GetVariant() FileVariant {
+func (x *ImageTransform) GetHeight() int32 {
 	if x != nil {
-		return x.Variant
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ImageTransform) GetFit() string {
+	if x != nil {
+		return x.Fit
+	}
+	return ""
+}
+
+func (x *ImageTransform) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImageTransform) GetPreset() FileVariant {
+	if x != nil {
+		return x.Preset
 	}
 	return FileVariant_IMG_VARIANT_UNSPECIFIED
 }
 
-// Pair of file id and upload status representing the files that
-// not marked as completed.
-type FailedId struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	Status        UploadStatus           `protobuf:"varint,2,opt,name=status,proto3,enum=media.UploadStatus" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Request message for signing the url of an image transform
+type SignImageTransformRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ImageId           int64                  `protobuf:"varint,1,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	Transform         *ImageTransform        `protobuf:"bytes,2,opt,name=transform,proto3" json:"transform,omitempty"`
+	RequesterId       int64                  `protobuf:"varint,3,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`                   // User asking for the url. Unset for trusted service calls
+	ExpirationSeconds int64                  `protobuf:"varint,4,opt,name=expiration_seconds,json=expirationSeconds,proto3" json:"expiration_seconds,omitempty"` // Lifetime of the signed url, capped by the visibility of the image
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *FailedId) Reset() {
-	*x = FailedId{}
+func (x *SignImageTransformRequest) Reset() {
+	*x = SignImageTransformRequest{}
 	mi := &file_media_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FailedId) String() string {
+func (x *SignImageTransformRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FailedId) ProtoMessage() {}
+func (*SignImageTransformRequest) ProtoMessage() {}
 
-func (x *FailedId) ProtoReflect() protoreflect.Message {
+func (x *SignImageTransformRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_media_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -658,51 +838,1155 @@ func (x *FailedId) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FailedId.ProtoReflect.Descriptor instead.
-func (*FailedId) Descriptor() ([]byte, []int) {
+// Deprecated: Use SignImageTransformRequest.ProtoReflect.Descriptor instead.
+func (*SignImageTransformRequest) Descriptor() ([]byte, []int) {
 	return file_media_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *FailedId) GetFileId() int64 {
+func (x *SignImageTransformRequest) GetImageId() int64 {
 	if x != nil {
-		return x.FileId
+		return x.ImageId
 	}
 	return 0
 }
 
-func (x *FailedId) GetStatus() UploadStatus {
+func (x *SignImageTransformRequest) GetTransform() *ImageTransform {
 	if x != nil {
-		return x.Status
+		return x.Transform
 	}
-	return UploadStatus_UPLOAD_STATUS_UNSPECIFIED
+	return nil
 }
 
-// map of image ids and dowload urls and array of failed ids.
-type GetImagesResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// map[ct.Id]string
-	DownloadUrls map[int64]string `protobuf:"bytes,1,rep,name=download_urls,json=downloadUrls,proto3" json:"download_urls,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// []FailedId
-	FailedIds     []*FailedId `protobuf:"bytes,2,rep,name=failed_ids,json=failedIds,proto3" json:"failed_ids,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SignImageTransformRequest) GetRequesterId() int64 {
+	if x != nil {
+		return x.RequesterId
+	}
+	return 0
+}
+
+func (x *SignImageTransformRequest) GetExpirationSeconds() int64 {
+	if x != nil {
+		return x.ExpirationSeconds
+	}
+	return 0
+}
+
+// Signed transform. The fields are passed as they are to GetImageTransform.
+type SignImageTransformResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transform     *ImageTransform        `protobuf:"bytes,1,opt,name=transform,proto3" json:"transform,omitempty"`                   // Transform with defaults applied and preset resolved
+	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix seconds
+	Signature     string                 `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignImageTransformResponse) Reset() {
+	*x = SignImageTransformResponse{}
+	mi := &file_media_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignImageTransformResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignImageTransformResponse) ProtoMessage() {}
+
+func (x *SignImageTransformResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignImageTransformResponse.ProtoReflect.Descriptor instead.
+func (*SignImageTransformResponse) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SignImageTransformResponse) GetTransform() *ImageTransform {
+	if x != nil {
+		return x.Transform
+	}
+	return nil
+}
+
+func (x *SignImageTransformResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *SignImageTransformResponse) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+// Request message for retrieving an image transform from a signed url
+type GetImageTransformRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImageId       int64                  `protobuf:"varint,1,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	Transform     *ImageTransform        `protobuf:"bytes,2,opt,name=transform,proto3" json:"transform,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix seconds
+	Signature     string                 `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImageTransformRequest) Reset() {
+	*x = GetImageTransformRequest{}
+	mi := &file_media_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImageTransformRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImageTransformRequest) ProtoMessage() {}
+
+func (x *GetImageTransformRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImageTransformRequest.ProtoReflect.Descriptor instead.
+func (*GetImageTransformRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetImageTransformRequest) GetImageId() int64 {
+	if x != nil {
+		return x.ImageId
+	}
+	return 0
+}
+
+func (x *GetImageTransformRequest) GetTransform() *ImageTransform {
+	if x != nil {
+		return x.Transform
+	}
+	return nil
+}
+
+func (x *GetImageTransformRequest) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *GetImageTransformRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+// Request message for validating an upload
+type ValidateUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`                // Metadata of the upload to validate
+	ReturnUrl     bool                   `protobuf:"varint,2,opt,name=return_url,json=returnUrl,proto3" json:"return_url,omitempty"`       // Request download url after validation
+	RequesterId   int64                  `protobuf:"varint,3,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"` // Must be the uploader of owned files
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateUploadRequest) Reset() {
+	*x = ValidateUploadRequest{}
+	mi := &file_media_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateUploadRequest) ProtoMessage() {}
+
+func (x *ValidateUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateUploadRequest.ProtoReflect.Descriptor instead.
+func (*ValidateUploadRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ValidateUploadRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *ValidateUploadRequest) GetReturnUrl() bool {
+	if x != nil {
+		return x.ReturnUrl
+	}
+	return false
+}
+
+func (x *ValidateUploadRequest) GetRequesterId() int64 {
+	if x != nil {
+		return x.RequesterId
+	}
+	return 0
+}
+
+// Response on validate upload containing download url only if requested
+type ValidateUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DownloadUrl   string                 `protobuf:"bytes,1,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"` // Pre-signed URL for downloading the image
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateUploadResponse) Reset() {
+	*x = ValidateUploadResponse{}
+	mi := &file_media_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateUploadResponse) ProtoMessage() {}
+
+func (x *ValidateUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateUploadResponse.ProtoReflect.Descriptor instead.
+func (*ValidateUploadResponse) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ValidateUploadResponse) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+type ImageIds struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImgIds        []int64                `protobuf:"varint,1,rep,packed,name=img_ids,json=imgIds,proto3" json:"img_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageIds) Reset() {
+	*x = ImageIds{}
+	mi := &file_media_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageIds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageIds) ProtoMessage() {}
+
+func (x *ImageIds) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageIds.ProtoReflect.Descriptor instead.
+func (*ImageIds) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ImageIds) GetImgIds() []int64 {
+	if x != nil {
+		return x.ImgIds
+	}
+	return nil
+}
+
+// Request with an array of file ids and the prefered variant.
+// Variant is common for all ids. If a variant is not present
+// returns the original.
+// Only for trusted service calls, which check the access to the entities of the images.
+type GetImagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImgIds        *ImageIds              `protobuf:"bytes,1,opt,name=img_ids,json=imgIds,proto3" json:"img_ids,omitempty"`
+	Variant       FileVariant            `protobuf:"varint,2,opt,name=variant,proto3,enum=media.FileVariant" json:"variant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImagesRequest) Reset() {
+	*x = GetImagesRequest{}
+	mi := &file_media_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImagesRequest) ProtoMessage() {}
+
+func (x *GetImagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImagesRequest.ProtoReflect.Descriptor instead.
+func (*GetImagesRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetImagesRequest) GetImgIds() *ImageIds {
+	if x != nil {
+		return x.ImgIds
+	}
+	return nil
+}
+
+func (x *GetImagesRequest) GetVariant() FileVariant {
+	if x != nil {
+		return x.Variant
+	}
+	return FileVariant_IMG_VARIANT_UNSPECIFIED
+}
+
+// Pair of file id and upload status representing the files that
+// not marked as completed.
+type FailedId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Status        UploadStatus           `protobuf:"varint,2,opt,name=status,proto3,enum=media.UploadStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailedId) Reset() {
+	*x = FailedId{}
+	mi := &file_media_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailedId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailedId) ProtoMessage() {}
+
+func (x *FailedId) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailedId.ProtoReflect.Descriptor instead.
+func (*FailedId) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FailedId) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *FailedId) GetStatus() UploadStatus {
+	if x != nil {
+		return x.Status
+	}
+	return UploadStatus_UPLOAD_STATUS_UNSPECIFIED
+}
+
+// map of image ids and dowload urls and array of failed ids.
+type GetImagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// map[ct.Id]string
+	DownloadUrls map[int64]string `protobuf:"bytes,1,rep,name=download_urls,json=downloadUrls,proto3" json:"download_urls,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// []FailedId
+	FailedIds []*FailedId `protobuf:"bytes,2,rep,name=failed_ids,json=failedIds,proto3" json:"failed_ids,omitempty"`
+	// map[ct.Id]string of blurhash placeholders, for the images that have one
+	Placeholders map[int64]string `protobuf:"bytes,3,rep,name=placeholders,proto3" json:"placeholders,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Unix seconds, earliest expiry of the download urls, unset when none expires
+	ExpiresAt     int64 `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImagesResponse) Reset() {
+	*x = GetImagesResponse{}
+	mi := &file_media_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImagesResponse) ProtoMessage() {}
+
+func (x *GetImagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImagesResponse.ProtoReflect.Descriptor instead.
+func (*GetImagesResponse) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetImagesResponse) GetDownloadUrls() map[int64]string {
+	if x != nil {
+		return x.DownloadUrls
+	}
+	return nil
+}
+
+func (x *GetImagesResponse) GetFailedIds() []*FailedId {
+	if x != nil {
+		return x.FailedIds
+	}
+	return nil
+}
+
+func (x *GetImagesResponse) GetPlaceholders() map[int64]string {
+	if x != nil {
+		return x.Placeholders
+	}
+	return nil
+}
+
+func (x *GetImagesResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// Attachment of a file to the entity using it
+type FileAttachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	OwnerId       int64                  `protobuf:"varint,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // Owner of the entity, must own the file if it is owned
+	Type          AttachmentType         `protobuf:"varint,3,opt,name=type,proto3,enum=media.AttachmentType" json:"type,omitempty"`
+	EntityId      int64                  `protobuf:"varint,4,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileAttachment) Reset() {
+	*x = FileAttachment{}
+	mi := &file_media_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileAttachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileAttachment) ProtoMessage() {}
+
+func (x *FileAttachment) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileAttachment.ProtoReflect.Descriptor instead.
+func (*FileAttachment) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FileAttachment) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *FileAttachment) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *FileAttachment) GetType() AttachmentType {
+	if x != nil {
+		return x.Type
+	}
+	return AttachmentType_ATTACHMENT_TYPE_UNSPECIFIED
+}
+
+func (x *FileAttachment) GetEntityId() int64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+// Request message for deleting a file
+type DeleteFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	RequesterId   int64                  `protobuf:"varint,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"` // Must be the owner of the file
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileRequest) Reset() {
+	*x = DeleteFileRequest{}
+	mi := &file_media_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileRequest) ProtoMessage() {}
+
+func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteFileRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *DeleteFileRequest) GetRequesterId() int64 {
+	if x != nil {
+		return x.RequesterId
+	}
+	return 0
+}
+
+// Request message for a garbage collection run
+type CollectGarbageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // Only report what would be collected
+	Reconcile     bool                   `protobuf:"varint,2,opt,name=reconcile,proto3" json:"reconcile,omitempty"`         // Also compare the buckets with the database
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectGarbageRequest) Reset() {
+	*x = CollectGarbageRequest{}
+	mi := &file_media_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectGarbageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectGarbageRequest) ProtoMessage() {}
+
+func (x *CollectGarbageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectGarbageRequest.ProtoReflect.Descriptor instead.
+func (*CollectGarbageRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CollectGarbageRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *CollectGarbageRequest) GetReconcile() bool {
+	if x != nil {
+		return x.Reconcile
+	}
+	return false
+}
+
+// Counts of a garbage collection run, of what would be collected on dry runs
+type CollectGarbageResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	DryRun            bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Reconciled        bool                   `protobuf:"varint,2,opt,name=reconciled,proto3" json:"reconciled,omitempty"`
+	ExpiredUploads    int64                  `protobuf:"varint,3,opt,name=expired_uploads,json=expiredUploads,proto3" json:"expired_uploads,omitempty"`          // Pending files whose upload url expired
+	StuckVariants     int64                  `protobuf:"varint,4,opt,name=stuck_variants,json=stuckVariants,proto3" json:"stuck_variants,omitempty"`             // Processing variants marked failed
+	FailedVariants    int64                  `protobuf:"varint,5,opt,name=failed_variants,json=failedVariants,proto3" json:"failed_variants,omitempty"`          // Failed variants deleted
+	FailedFiles       int64                  `protobuf:"varint,6,opt,name=failed_files,json=failedFiles,proto3" json:"failed_files,omitempty"`                   // Failed files deleted
+	UnreferencedFiles int64                  `protobuf:"varint,7,opt,name=unreferenced_files,json=unreferencedFiles,proto3" json:"unreferenced_files,omitempty"` // Complete files no service uses
+	OrphanObjects     int64                  `protobuf:"varint,8,opt,name=orphan_objects,json=orphanObjects,proto3" json:"orphan_objects,omitempty"`             // Objects without file or variant
+	MissingOriginals  int64                  `protobuf:"varint,9,opt,name=missing_originals,json=missingOriginals,proto3" json:"missing_originals,omitempty"`    // Files marked failed as their object is missing
+	MissingVariants   int64                  `protobuf:"varint,10,opt,name=missing_variants,json=missingVariants,proto3" json:"missing_variants,omitempty"`      // Variants generated again as their object is missing
+	FreedBytes        int64                  `protobuf:"varint,11,opt,name=freed_bytes,json=freedBytes,proto3" json:"freed_bytes,omitempty"`                     // Size of the deleted objects
+	AbandonedUploads  int64                  `protobuf:"varint,12,opt,name=abandoned_uploads,json=abandonedUploads,proto3" json:"abandoned_uploads,omitempty"`   // Multipart uploads aborted with their parts
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CollectGarbageResponse) Reset() {
+	*x = CollectGarbageResponse{}
+	mi := &file_media_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectGarbageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectGarbageResponse) ProtoMessage() {}
+
+func (x *CollectGarbageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectGarbageResponse.ProtoReflect.Descriptor instead.
+func (*CollectGarbageResponse) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CollectGarbageResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *CollectGarbageResponse) GetReconciled() bool {
+	if x != nil {
+		return x.Reconciled
+	}
+	return false
+}
+
+func (x *CollectGarbageResponse) GetExpiredUploads() int64 {
+	if x != nil {
+		return x.ExpiredUploads
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetStuckVariants() int64 {
+	if x != nil {
+		return x.StuckVariants
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetFailedVariants() int64 {
+	if x != nil {
+		return x.FailedVariants
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetFailedFiles() int64 {
+	if x != nil {
+		return x.FailedFiles
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetUnreferencedFiles() int64 {
+	if x != nil {
+		return x.UnreferencedFiles
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetOrphanObjects() int64 {
+	if x != nil {
+		return x.OrphanObjects
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetMissingOriginals() int64 {
+	if x != nil {
+		return x.MissingOriginals
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetMissingVariants() int64 {
+	if x != nil {
+		return x.MissingVariants
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetFreedBytes() int64 {
+	if x != nil {
+		return x.FreedBytes
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetAbandonedUploads() int64 {
+	if x != nil {
+		return x.AbandonedUploads
+	}
+	return 0
+}
+
+// Request message for starting a multipart upload of an image or a video
+type InitiateMultipartUploadRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Filename          string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	MimeType          string                 `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	SizeBytes         int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"` // Size of the whole file, the parts must add up to it
+	Visibility        FileVisibility         `protobuf:"varint,4,opt,name=visibility,proto3,enum=media.FileVisibility" json:"visibility,omitempty"`
+	ExpirationSeconds int64                  `protobuf:"varint,5,opt,name=expiration_seconds,json=expirationSeconds,proto3" json:"expiration_seconds,omitempty"` // Time to upload all parts and complete the upload
+	Variants          []FileVariant          `protobuf:"varint,6,rep,packed,name=variants,proto3,enum=media.FileVariant" json:"variants,omitempty"`              // Image or video variants, as for UploadImage and UploadVideo
+	OwnerId           int64                  `protobuf:"varint,7,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`                               // Uploader
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *InitiateMultipartUploadRequest) Reset() {
+	*x = InitiateMultipartUploadRequest{}
+	mi := &file_media_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitiateMultipartUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitiateMultipartUploadRequest) ProtoMessage() {}
+
+func (x *InitiateMultipartUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitiateMultipartUploadRequest.ProtoReflect.Descriptor instead.
+func (*InitiateMultipartUploadRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *InitiateMultipartUploadRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *InitiateMultipartUploadRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *InitiateMultipartUploadRequest) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *InitiateMultipartUploadRequest) GetVisibility() FileVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return FileVisibility_FILE_VISIBILITY_UNSPECIFIED
+}
+
+func (x *InitiateMultipartUploadRequest) GetExpirationSeconds() int64 {
+	if x != nil {
+		return x.ExpirationSeconds
+	}
+	return 0
+}
+
+func (x *InitiateMultipartUploadRequest) GetVariants() []FileVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *InitiateMultipartUploadRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+// Response message for starting a multipart upload
+type InitiateMultipartUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	PartSize      int64                  `protobuf:"varint,2,opt,name=part_size,json=partSize,proto3" json:"part_size,omitempty"`    // Size of every part but the last
+	PartCount     int32                  `protobuf:"varint,3,opt,name=part_count,json=partCount,proto3" json:"part_count,omitempty"` // Parts are numbered from 1 to part_count
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix seconds, parts are not signed after it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitiateMultipartUploadResponse) Reset() {
+	*x = InitiateMultipartUploadResponse{}
+	mi := &file_media_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitiateMultipartUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitiateMultipartUploadResponse) ProtoMessage() {}
+
+func (x *InitiateMultipartUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitiateMultipartUploadResponse.ProtoReflect.Descriptor instead.
+func (*InitiateMultipartUploadResponse) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *InitiateMultipartUploadResponse) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *InitiateMultipartUploadResponse) GetPartSize() int64 {
+	if x != nil {
+		return x.PartSize
+	}
+	return 0
+}
+
+func (x *InitiateMultipartUploadResponse) GetPartCount() int32 {
+	if x != nil {
+		return x.PartCount
+	}
+	return 0
+}
+
+func (x *InitiateMultipartUploadResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// Request message for signing parts of a multipart upload
+type SignUploadPartsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	RequesterId   int64                  `protobuf:"varint,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`        // Must be the uploader
+	PartNumbers   []int32                `protobuf:"varint,3,rep,packed,name=part_numbers,json=partNumbers,proto3" json:"part_numbers,omitempty"` // Parts not uploaded yet if empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignUploadPartsRequest) Reset() {
+	*x = SignUploadPartsRequest{}
+	mi := &file_media_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignUploadPartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignUploadPartsRequest) ProtoMessage() {}
+
+func (x *SignUploadPartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignUploadPartsRequest.ProtoReflect.Descriptor instead.
+func (*SignUploadPartsRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SignUploadPartsRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *SignUploadPartsRequest) GetRequesterId() int64 {
+	if x != nil {
+		return x.RequesterId
+	}
+	return 0
+}
+
+func (x *SignUploadPartsRequest) GetPartNumbers() []int32 {
+	if x != nil {
+		return x.PartNumbers
+	}
+	return nil
+}
+
+// Pre-signed URL uploading a part with a PUT request
+type UploadPart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartNumber    int32                  `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	UploadUrl     string                 `protobuf:"bytes,2,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadPart) Reset() {
+	*x = UploadPart{}
+	mi := &file_media_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadPart) ProtoMessage() {}
+
+func (x *UploadPart) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadPart.ProtoReflect.Descriptor instead.
+func (*UploadPart) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UploadPart) GetPartNumber() int32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *UploadPart) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
+	}
+	return ""
+}
+
+// Response message for signing parts of a multipart upload
+type SignUploadPartsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Parts         []*UploadPart          `protobuf:"bytes,1,rep,name=parts,proto3" json:"parts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignUploadPartsResponse) Reset() {
+	*x = SignUploadPartsResponse{}
+	mi := &file_media_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignUploadPartsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignUploadPartsResponse) ProtoMessage() {}
+
+func (x *SignUploadPartsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignUploadPartsResponse.ProtoReflect.Descriptor instead.
+func (*SignUploadPartsResponse) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SignUploadPartsResponse) GetParts() []*UploadPart {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+// Request message for operations on a multipart upload in progress
+type MultipartUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	RequesterId   int64                  `protobuf:"varint,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"` // Must be the uploader
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetImagesResponse) Reset() {
-	*x = GetImagesResponse{}
-	mi := &file_media_proto_msgTypes[9]
+func (x *MultipartUploadRequest) Reset() {
+	*x = MultipartUploadRequest{}
+	mi := &file_media_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetImagesResponse) String() string {
+func (x *MultipartUploadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetImagesResponse) ProtoMessage() {}
+func (*MultipartUploadRequest) ProtoMessage() {}
 
-func (x *GetImagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_media_proto_msgTypes[9]
+func (x *MultipartUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -713,21 +1997,321 @@ func (x *GetImagesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetImagesResponse.ProtoReflect.Descriptor instead.
-func (*GetImagesResponse) Descriptor() ([]byte, []int) {
-	return file_media_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use MultipartUploadRequest.ProtoReflect.Descriptor instead.
+func (*MultipartUploadRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *GetImagesResponse) GetDownloadUrls() map[int64]string {
+func (x *MultipartUploadRequest) GetFileId() int64 {
 	if x != nil {
-		return x.DownloadUrls
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *MultipartUploadRequest) GetRequesterId() int64 {
+	if x != nil {
+		return x.RequesterId
+	}
+	return 0
+}
+
+// Progress of a multipart upload
+type UploadProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	PartSize      int64                  `protobuf:"varint,2,opt,name=part_size,json=partSize,proto3" json:"part_size,omitempty"`
+	PartCount     int32                  `protobuf:"varint,3,opt,name=part_count,json=partCount,proto3" json:"part_count,omitempty"`
+	UploadedParts []int32                `protobuf:"varint,4,rep,packed,name=uploaded_parts,json=uploadedParts,proto3" json:"uploaded_parts,omitempty"` // Part numbers uploaded so far
+	UploadedBytes int64                  `protobuf:"varint,5,opt,name=uploaded_bytes,json=uploadedBytes,proto3" json:"uploaded_bytes,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadProgress) Reset() {
+	*x = UploadProgress{}
+	mi := &file_media_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadProgress) ProtoMessage() {}
+
+func (x *UploadProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadProgress.ProtoReflect.Descriptor instead.
+func (*UploadProgress) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UploadProgress) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *UploadProgress) GetPartSize() int64 {
+	if x != nil {
+		return x.PartSize
+	}
+	return 0
+}
+
+func (x *UploadProgress) GetPartCount() int32 {
+	if x != nil {
+		return x.PartCount
+	}
+	return 0
+}
+
+func (x *UploadProgress) GetUploadedParts() []int32 {
+	if x != nil {
+		return x.UploadedParts
 	}
 	return nil
 }
 
-func (x *GetImagesResponse) GetFailedIds() []*FailedId {
+func (x *UploadProgress) GetUploadedBytes() int64 {
 	if x != nil {
-		return x.FailedIds
+		return x.UploadedBytes
+	}
+	return 0
+}
+
+func (x *UploadProgress) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// Request message for listing quarantined files
+type ListQuarantinedFilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AfterId       int64                  `protobuf:"varint,1,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"` // Files are listed in id order after it, 0 from the start
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                    // From 1 to 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuarantinedFilesRequest) Reset() {
+	*x = ListQuarantinedFilesRequest{}
+	mi := &file_media_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuarantinedFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuarantinedFilesRequest) ProtoMessage() {}
+
+func (x *ListQuarantinedFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuarantinedFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListQuarantinedFilesRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListQuarantinedFilesRequest) GetAfterId() int64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+func (x *ListQuarantinedFilesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// File quarantined by the content scanner
+type QuarantinedFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	OwnerId       int64                  `protobuf:"varint,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // Uploader, 0 when unknown
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	MimeType      string                 `protobuf:"bytes,4,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	ScanSignature string                 `protobuf:"bytes,6,opt,name=scan_signature,json=scanSignature,proto3" json:"scan_signature,omitempty"` // Signature the scanner flagged the file with
+	Bucket        string                 `protobuf:"bytes,7,opt,name=bucket,proto3" json:"bucket,omitempty"`                                    // Location of the object kept for inspection
+	ObjectKey     string                 `protobuf:"bytes,8,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	QuarantinedAt int64                  `protobuf:"varint,9,opt,name=quarantined_at,json=quarantinedAt,proto3" json:"quarantined_at,omitempty"` // Unix seconds
+	DeleteAt      int64                  `protobuf:"varint,10,opt,name=delete_at,json=deleteAt,proto3" json:"delete_at,omitempty"`               // Unix seconds, collected by the garbage collector after it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuarantinedFile) Reset() {
+	*x = QuarantinedFile{}
+	mi := &file_media_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuarantinedFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuarantinedFile) ProtoMessage() {}
+
+func (x *QuarantinedFile) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuarantinedFile.ProtoReflect.Descriptor instead.
+func (*QuarantinedFile) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *QuarantinedFile) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *QuarantinedFile) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *QuarantinedFile) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *QuarantinedFile) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *QuarantinedFile) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *QuarantinedFile) GetScanSignature() string {
+	if x != nil {
+		return x.ScanSignature
+	}
+	return ""
+}
+
+func (x *QuarantinedFile) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *QuarantinedFile) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *QuarantinedFile) GetQuarantinedAt() int64 {
+	if x != nil {
+		return x.QuarantinedAt
+	}
+	return 0
+}
+
+func (x *QuarantinedFile) GetDeleteAt() int64 {
+	if x != nil {
+		return x.DeleteAt
+	}
+	return 0
+}
+
+// Response message for listing quarantined files
+type ListQuarantinedFilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*QuarantinedFile     `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuarantinedFilesResponse) Reset() {
+	*x = ListQuarantinedFilesResponse{}
+	mi := &file_media_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuarantinedFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuarantinedFilesResponse) ProtoMessage() {}
+
+func (x *ListQuarantinedFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuarantinedFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListQuarantinedFilesResponse) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListQuarantinedFilesResponse) GetFiles() []*QuarantinedFile {
+	if x != nil {
+		return x.Files
 	}
 	return nil
 }
@@ -736,7 +2320,7 @@ var File_media_proto protoreflect.FileDescriptor
 
 const file_media_proto_rawDesc = "" +
 	"\n" +
-	"\vmedia.proto\x12\x05media\x1a\x1bgoogle/protobuf/empty.proto\"\x82\x02\n" +
+	"\vmedia.proto\x12\x05media\x1a\x1bgoogle/protobuf/empty.proto\"\x9d\x02\n" +
 	"\x12UploadImageRequest\x12\x1a\n" +
 	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x1b\n" +
 	"\tmime_type\x18\x02 \x01(\tR\bmimeType\x12\x1d\n" +
@@ -746,20 +2330,65 @@ const file_media_proto_rawDesc = "" +
 	"visibility\x18\x04 \x01(\x0e2\x15.media.FileVisibilityR\n" +
 	"visibility\x12-\n" +
 	"\x12expiration_seconds\x18\x05 \x01(\x03R\x11expirationSeconds\x12.\n" +
-	"\bvariants\x18\x06 \x03(\x0e2\x12.media.FileVariantR\bvariants\"M\n" +
+	"\bvariants\x18\x06 \x03(\x0e2\x12.media.FileVariantR\bvariants\x12\x19\n" +
+	"\bowner_id\x18\a \x01(\x03R\aownerId\"M\n" +
 	"\x13UploadImageResponse\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x1d\n" +
 	"\n" +
-	"upload_url\x18\x02 \x01(\tR\tuploadUrl\"Z\n" +
+	"upload_url\x18\x02 \x01(\tR\tuploadUrl\"\x9d\x02\n" +
+	"\x12UploadVideoRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x1b\n" +
+	"\tmime_type\x18\x02 \x01(\tR\bmimeType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x125\n" +
+	"\n" +
+	"visibility\x18\x04 \x01(\x0e2\x15.media.FileVisibilityR\n" +
+	"visibility\x12-\n" +
+	"\x12expiration_seconds\x18\x05 \x01(\x03R\x11expirationSeconds\x12.\n" +
+	"\bvariants\x18\x06 \x03(\x0e2\x12.media.FileVariantR\bvariants\x12\x19\n" +
+	"\bowner_id\x18\a \x01(\x03R\aownerId\"M\n" +
+	"\x13UploadVideoResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x02 \x01(\tR\tuploadUrl\"}\n" +
 	"\x0fGetImageRequest\x12\x19\n" +
 	"\bimage_id\x18\x01 \x01(\x03R\aimageId\x12,\n" +
-	"\avariant\x18\x02 \x01(\x0e2\x12.media.FileVariantR\avariant\"5\n" +
+	"\avariant\x18\x02 \x01(\x0e2\x12.media.FileVariantR\avariant\x12!\n" +
+	"\frequester_id\x18\x03 \x01(\x03R\vrequesterId\"T\n" +
 	"\x10GetImageResponse\x12!\n" +
-	"\fdownload_url\x18\x01 \x01(\tR\vdownloadUrl\"O\n" +
+	"\fdownload_url\x18\x01 \x01(\tR\vdownloadUrl\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\"Z\n" +
+	"\x11PublicFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12,\n" +
+	"\avariant\x18\x02 \x01(\x0e2\x12.media.FileVariantR\avariant\"\x94\x01\n" +
+	"\x0eImageTransform\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x05R\x06height\x12\x10\n" +
+	"\x03fit\x18\x03 \x01(\tR\x03fit\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\x12*\n" +
+	"\x06preset\x18\x05 \x01(\x0e2\x12.media.FileVariantR\x06preset\"\xbd\x01\n" +
+	"\x19SignImageTransformRequest\x12\x19\n" +
+	"\bimage_id\x18\x01 \x01(\x03R\aimageId\x123\n" +
+	"\ttransform\x18\x02 \x01(\v2\x15.media.ImageTransformR\ttransform\x12!\n" +
+	"\frequester_id\x18\x03 \x01(\x03R\vrequesterId\x12-\n" +
+	"\x12expiration_seconds\x18\x04 \x01(\x03R\x11expirationSeconds\"\x8e\x01\n" +
+	"\x1aSignImageTransformResponse\x123\n" +
+	"\ttransform\x18\x01 \x01(\v2\x15.media.ImageTransformR\ttransform\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\x12\x1c\n" +
+	"\tsignature\x18\x03 \x01(\tR\tsignature\"\xa7\x01\n" +
+	"\x18GetImageTransformRequest\x12\x19\n" +
+	"\bimage_id\x18\x01 \x01(\x03R\aimageId\x123\n" +
+	"\ttransform\x18\x02 \x01(\v2\x15.media.ImageTransformR\ttransform\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03R\texpiresAt\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\tR\tsignature\"r\n" +
 	"\x15ValidateUploadRequest\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x1d\n" +
 	"\n" +
-	"return_url\x18\x02 \x01(\bR\treturnUrl\";\n" +
+	"return_url\x18\x02 \x01(\bR\treturnUrl\x12!\n" +
+	"\frequester_id\x18\x03 \x01(\x03R\vrequesterId\";\n" +
 	"\x16ValidateUploadResponse\x12!\n" +
 	"\fdownload_url\x18\x01 \x01(\tR\vdownloadUrl\"#\n" +
 	"\bImageIds\x12\x17\n" +
@@ -769,14 +2398,109 @@ const file_media_proto_rawDesc = "" +
 	"\avariant\x18\x02 \x01(\x0e2\x12.media.FileVariantR\avariant\"P\n" +
 	"\bFailedId\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12+\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x13.media.UploadStatusR\x06status\"\xd5\x01\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x13.media.UploadStatusR\x06status\"\x85\x03\n" +
 	"\x11GetImagesResponse\x12O\n" +
 	"\rdownload_urls\x18\x01 \x03(\v2*.media.GetImagesResponse.DownloadUrlsEntryR\fdownloadUrls\x12.\n" +
 	"\n" +
-	"failed_ids\x18\x02 \x03(\v2\x0f.media.FailedIdR\tfailedIds\x1a?\n" +
+	"failed_ids\x18\x02 \x03(\v2\x0f.media.FailedIdR\tfailedIds\x12N\n" +
+	"\fplaceholders\x18\x03 \x03(\v2*.media.GetImagesResponse.PlaceholdersEntryR\fplaceholders\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\x1a?\n" +
 	"\x11DownloadUrlsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01*i\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a?\n" +
+	"\x11PlaceholdersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8c\x01\n" +
+	"\x0eFileAttachment\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\x03R\aownerId\x12)\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x15.media.AttachmentTypeR\x04type\x12\x1b\n" +
+	"\tentity_id\x18\x04 \x01(\x03R\bentityId\"O\n" +
+	"\x11DeleteFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\x03R\vrequesterId\"N\n" +
+	"\x15CollectGarbageRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x1c\n" +
+	"\treconcile\x18\x02 \x01(\bR\treconcile\"\xe9\x03\n" +
+	"\x16CollectGarbageResponse\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x1e\n" +
+	"\n" +
+	"reconciled\x18\x02 \x01(\bR\n" +
+	"reconciled\x12'\n" +
+	"\x0fexpired_uploads\x18\x03 \x01(\x03R\x0eexpiredUploads\x12%\n" +
+	"\x0estuck_variants\x18\x04 \x01(\x03R\rstuckVariants\x12'\n" +
+	"\x0ffailed_variants\x18\x05 \x01(\x03R\x0efailedVariants\x12!\n" +
+	"\ffailed_files\x18\x06 \x01(\x03R\vfailedFiles\x12-\n" +
+	"\x12unreferenced_files\x18\a \x01(\x03R\x11unreferencedFiles\x12%\n" +
+	"\x0eorphan_objects\x18\b \x01(\x03R\rorphanObjects\x12+\n" +
+	"\x11missing_originals\x18\t \x01(\x03R\x10missingOriginals\x12)\n" +
+	"\x10missing_variants\x18\n" +
+	" \x01(\x03R\x0fmissingVariants\x12\x1f\n" +
+	"\vfreed_bytes\x18\v \x01(\x03R\n" +
+	"freedBytes\x12+\n" +
+	"\x11abandoned_uploads\x18\f \x01(\x03R\x10abandonedUploads\"\xa9\x02\n" +
+	"\x1eInitiateMultipartUploadRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x1b\n" +
+	"\tmime_type\x18\x02 \x01(\tR\bmimeType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x125\n" +
+	"\n" +
+	"visibility\x18\x04 \x01(\x0e2\x15.media.FileVisibilityR\n" +
+	"visibility\x12-\n" +
+	"\x12expiration_seconds\x18\x05 \x01(\x03R\x11expirationSeconds\x12.\n" +
+	"\bvariants\x18\x06 \x03(\x0e2\x12.media.FileVariantR\bvariants\x12\x19\n" +
+	"\bowner_id\x18\a \x01(\x03R\aownerId\"\x95\x01\n" +
+	"\x1fInitiateMultipartUploadResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x1b\n" +
+	"\tpart_size\x18\x02 \x01(\x03R\bpartSize\x12\x1d\n" +
+	"\n" +
+	"part_count\x18\x03 \x01(\x05R\tpartCount\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\"w\n" +
+	"\x16SignUploadPartsRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\x03R\vrequesterId\x12!\n" +
+	"\fpart_numbers\x18\x03 \x03(\x05R\vpartNumbers\"L\n" +
+	"\n" +
+	"UploadPart\x12\x1f\n" +
+	"\vpart_number\x18\x01 \x01(\x05R\n" +
+	"partNumber\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x02 \x01(\tR\tuploadUrl\"B\n" +
+	"\x17SignUploadPartsResponse\x12'\n" +
+	"\x05parts\x18\x01 \x03(\v2\x11.media.UploadPartR\x05parts\"T\n" +
+	"\x16MultipartUploadRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\x03R\vrequesterId\"\xd2\x01\n" +
+	"\x0eUploadProgress\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x1b\n" +
+	"\tpart_size\x18\x02 \x01(\x03R\bpartSize\x12\x1d\n" +
+	"\n" +
+	"part_count\x18\x03 \x01(\x05R\tpartCount\x12%\n" +
+	"\x0euploaded_parts\x18\x04 \x03(\x05R\ruploadedParts\x12%\n" +
+	"\x0euploaded_bytes\x18\x05 \x01(\x03R\ruploadedBytes\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03R\texpiresAt\"N\n" +
+	"\x1bListQuarantinedFilesRequest\x12\x19\n" +
+	"\bafter_id\x18\x01 \x01(\x03R\aafterId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xbf\x02\n" +
+	"\x0fQuarantinedFile\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\x03R\aownerId\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x1b\n" +
+	"\tmime_type\x18\x04 \x01(\tR\bmimeType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes\x12%\n" +
+	"\x0escan_signature\x18\x06 \x01(\tR\rscanSignature\x12\x16\n" +
+	"\x06bucket\x18\a \x01(\tR\x06bucket\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\b \x01(\tR\tobjectKey\x12%\n" +
+	"\x0equarantined_at\x18\t \x01(\x03R\rquarantinedAt\x12\x1b\n" +
+	"\tdelete_at\x18\n" +
+	" \x01(\x03R\bdeleteAt\"L\n" +
+	"\x1cListQuarantinedFilesResponse\x12,\n" +
+	"\x05files\x18\x01 \x03(\v2\x16.media.QuarantinedFileR\x05files*\x9a\x01\n" +
 	"\vFileVariant\x12\x1b\n" +
 	"\x17IMG_VARIANT_UNSPECIFIED\x10\x00\x12\r\n" +
 	"\tTHUMBNAIL\x10\x01\x12\t\n" +
@@ -784,23 +2508,54 @@ const file_media_proto_rawDesc = "" +
 	"\n" +
 	"\x06MEDIUM\x10\x03\x12\t\n" +
 	"\x05LARGE\x10\x04\x12\f\n" +
-	"\bORIGINAL\x10\x05*J\n" +
+	"\bORIGINAL\x10\x05\x12\r\n" +
+	"\tVIDEO_MP4\x10\x06\x12\x0e\n" +
+	"\n" +
+	"VIDEO_WEBM\x10\a\x12\x10\n" +
+	"\fVIDEO_POSTER\x10\b*J\n" +
 	"\x0eFileVisibility\x12\x1f\n" +
 	"\x1bFILE_VISIBILITY_UNSPECIFIED\x10\x00\x12\v\n" +
 	"\aPRIVATE\x10\x01\x12\n" +
 	"\n" +
-	"\x06PUBLIC\x10\x02*\x9c\x01\n" +
+	"\x06PUBLIC\x10\x02*\xbb\x01\n" +
 	"\fUploadStatus\x12\x1d\n" +
 	"\x19UPLOAD_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15UPLOAD_STATUS_PENDING\x10\x01\x12\x1c\n" +
 	"\x18UPLOAD_STATUS_PROCESSING\x10\x02\x12\x1a\n" +
 	"\x16UPLOAD_STATUS_COMPLETE\x10\x03\x12\x18\n" +
-	"\x14UPLOAD_STATUS_FAILED\x10\x042\xa0\x02\n" +
+	"\x14UPLOAD_STATUS_FAILED\x10\x04\x12\x1d\n" +
+	"\x19UPLOAD_STATUS_QUARANTINED\x10\x05*\xb9\x01\n" +
+	"\x0eAttachmentType\x12\x1f\n" +
+	"\x1bATTACHMENT_TYPE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fATTACHMENT_POST\x10\x01\x12\x16\n" +
+	"\x12ATTACHMENT_COMMENT\x10\x02\x12\x14\n" +
+	"\x10ATTACHMENT_EVENT\x10\x03\x12\x15\n" +
+	"\x11ATTACHMENT_AVATAR\x10\x04\x12\x14\n" +
+	"\x10ATTACHMENT_GROUP\x10\x05\x12\x16\n" +
+	"\x12ATTACHMENT_MESSAGE\x10\x062\xe6\n" +
+	"\n" +
 	"\fMediaService\x12D\n" +
-	"\vUploadImage\x12\x19.media.UploadImageRequest\x1a\x1a.media.UploadImageResponse\x12;\n" +
+	"\vUploadImage\x12\x19.media.UploadImageRequest\x1a\x1a.media.UploadImageResponse\x12D\n" +
+	"\vUploadVideo\x12\x19.media.UploadVideoRequest\x1a\x1a.media.UploadVideoResponse\x12h\n" +
+	"\x17InitiateMultipartUpload\x12%.media.InitiateMultipartUploadRequest\x1a&.media.InitiateMultipartUploadResponse\x12P\n" +
+	"\x0fSignUploadParts\x12\x1d.media.SignUploadPartsRequest\x1a\x1e.media.SignUploadPartsResponse\x12I\n" +
+	"\x11GetUploadProgress\x12\x1d.media.MultipartUploadRequest\x1a\x15.media.UploadProgress\x12P\n" +
+	"\x17CompleteMultipartUpload\x12\x1d.media.MultipartUploadRequest\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\x14AbortMultipartUpload\x12\x1d.media.MultipartUploadRequest\x1a\x16.google.protobuf.Empty\x12;\n" +
 	"\bGetImage\x12\x16.media.GetImageRequest\x1a\x17.media.GetImageResponse\x12>\n" +
-	"\tGetImages\x12\x17.media.GetImagesRequest\x1a\x18.media.GetImagesResponse\x12M\n" +
-	"\x0eValidateUpload\x12\x1c.media.ValidateUploadRequest\x1a\x1d.media.ValidateUploadResponseB*Z(social-network/shared/gen-go/media;mediab\x06proto3"
+	"\tGetImages\x12\x17.media.GetImagesRequest\x1a\x18.media.GetImagesResponse\x12B\n" +
+	"\rGetPublicFile\x12\x18.media.PublicFileRequest\x1a\x17.media.GetImageResponse\x12Y\n" +
+	"\x12SignImageTransform\x12 .media.SignImageTransformRequest\x1a!.media.SignImageTransformResponse\x12M\n" +
+	"\x11GetImageTransform\x12\x1f.media.GetImageTransformRequest\x1a\x17.media.GetImageResponse\x12M\n" +
+	"\x0eValidateUpload\x12\x1c.media.ValidateUploadRequest\x1a\x1d.media.ValidateUploadResponse\x12;\n" +
+	"\n" +
+	"AttachFile\x12\x15.media.FileAttachment\x1a\x16.google.protobuf.Empty\x12;\n" +
+	"\n" +
+	"DetachFile\x12\x15.media.FileAttachment\x1a\x16.google.protobuf.Empty\x12>\n" +
+	"\n" +
+	"DeleteFile\x12\x18.media.DeleteFileRequest\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\x0eCollectGarbage\x12\x1c.media.CollectGarbageRequest\x1a\x1d.media.CollectGarbageResponse\x12_\n" +
+	"\x14ListQuarantinedFiles\x12\".media.ListQuarantinedFilesRequest\x1a#.media.ListQuarantinedFilesResponseB*Z(social-network/shared/gen-go/media;mediab\x06proto3"
 
 var (
 	file_media_proto_rawDescOnce sync.Once
@@ -814,46 +2569,111 @@ func file_media_proto_rawDescGZIP() []byte {
 	return file_media_proto_rawDescData
 }
 
-var file_media_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_media_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_media_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_media_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_media_proto_goTypes = []any{
-	(FileVariant)(0),               // 0: media.FileVariant
-	(FileVisibility)(0),            // 1: media.FileVisibility
-	(UploadStatus)(0),              // 2: media.UploadStatus
-	(*UploadImageRequest)(nil),     // 3: media.UploadImageRequest
-	(*UploadImageResponse)(nil),    // 4: media.UploadImageResponse
-	(*GetImageRequest)(nil),        // 5: media.GetImageRequest
-	(*GetImageResponse)(nil),       // 6: media.GetImageResponse
-	(*ValidateUploadRequest)(nil),  // 7: media.ValidateUploadRequest
-	(*ValidateUploadResponse)(nil), // 8: media.ValidateUploadResponse
-	(*ImageIds)(nil),               // 9: media.ImageIds
-	(*GetImagesRequest)(nil),       // 10: media.GetImagesRequest
-	(*FailedId)(nil),               // 11: media.FailedId
-	(*GetImagesResponse)(nil),      // 12: media.GetImagesResponse
-	nil,                            // 13: media.GetImagesResponse.DownloadUrlsEntry
+	(FileVariant)(0),                        // 0: media.FileVariant
+	(FileVisibility)(0),                     // 1: media.FileVisibility
+	(UploadStatus)(0),                       // 2: media.UploadStatus
+	(AttachmentType)(0),                     // 3: media.AttachmentType
+	(*UploadImageRequest)(nil),              // 4: media.UploadImageRequest
+	(*UploadImageResponse)(nil),             // 5: media.UploadImageResponse
+	(*UploadVideoRequest)(nil),              // 6: media.UploadVideoRequest
+	(*UploadVideoResponse)(nil),             // 7: media.UploadVideoResponse
+	(*GetImageRequest)(nil),                 // 8: media.GetImageRequest
+	(*GetImageResponse)(nil),                // 9: media.GetImageResponse
+	(*PublicFileRequest)(nil),               // 10: media.PublicFileRequest
+	(*ImageTransform)(nil),                  // 11: media.ImageTransform
+	(*SignImageTransformRequest)(nil),       // 12: media.SignImageTransformRequest
+	(*SignImageTransformResponse)(nil),      // 13: media.SignImageTransformResponse
+	(*GetImageTransformRequest)(nil),        // 14: media.GetImageTransformRequest
+	(*ValidateUploadRequest)(nil),           // 15: media.ValidateUploadRequest
+	(*ValidateUploadResponse)(nil),          // 16: media.ValidateUploadResponse
+	(*ImageIds)(nil),                        // 17: media.ImageIds
+	(*GetImagesRequest)(nil),                // 18: media.GetImagesRequest
+	(*FailedId)(nil),                        // 19: media.FailedId
+	(*GetImagesResponse)(nil),               // 20: media.GetImagesResponse
+	(*FileAttachment)(nil),                  // 21: media.FileAttachment
+	(*DeleteFileRequest)(nil),               // 22: media.DeleteFileRequest
+	(*CollectGarbageRequest)(nil),           // 23: media.CollectGarbageRequest
+	(*CollectGarbageResponse)(nil),          // 24: media.CollectGarbageResponse
+	(*InitiateMultipartUploadRequest)(nil),  // 25: media.InitiateMultipartUploadRequest
+	(*InitiateMultipartUploadResponse)(nil), // 26: media.InitiateMultipartUploadResponse
+	(*SignUploadPartsRequest)(nil),          // 27: media.SignUploadPartsRequest
+	(*UploadPart)(nil),                      // 28: media.UploadPart
+	(*SignUploadPartsResponse)(nil),         // 29: media.SignUploadPartsResponse
+	(*MultipartUploadRequest)(nil),          // 30: media.MultipartUploadRequest
+	(*UploadProgress)(nil),                  // 31: media.UploadProgress
+	(*ListQuarantinedFilesRequest)(nil),     // 32: media.ListQuarantinedFilesRequest
+	(*QuarantinedFile)(nil),                 // 33: media.QuarantinedFile
+	(*ListQuarantinedFilesResponse)(nil),    // 34: media.ListQuarantinedFilesResponse
+	nil,                                     // 35: media.GetImagesResponse.DownloadUrlsEntry
+	nil,                                     // 36: media.GetImagesResponse.PlaceholdersEntry
+	(*emptypb.Empty)(nil),                   // 37: google.protobuf.Empty
 }
 var file_media_proto_depIdxs = []int32{
 	1,  // 0: media.UploadImageRequest.visibility:type_name -> media.FileVisibility
 	0,  // 1: media.UploadImageRequest.variants:type_name -> media.FileVariant
-	0,  // 2: media.GetImageRequest.variant:type_name -> media.FileVariant
-	9,  // 3: media.GetImagesRequest.img_ids:type_name -> media.ImageIds
-	0,  // 4: media.GetImagesRequest.variant:type_name -> media.FileVariant
-	2,  // 5: media.FailedId.status:type_name -> media.UploadStatus
-	13, // 6: media.GetImagesResponse.download_urls:type_name -> media.GetImagesResponse.DownloadUrlsEntry
-	11, // 7: media.GetImagesResponse.failed_ids:type_name -> media.FailedId
-	3,  // 8: media.MediaService.UploadImage:input_type -> media.UploadImageRequest
-	5,  // 9: media.MediaService.GetImage:input_type -> media.GetImageRequest
-	10, // 10: media.MediaService.GetImages:input_type -> media.GetImagesRequest
-	7,  // 11: media.MediaService.ValidateUpload:input_type -> media.ValidateUploadRequest
-	4,  // 12: media.MediaService.UploadImage:output_type -> media.UploadImageResponse
-	6,  // 13: media.MediaService.GetImage:output_type -> media.GetImageResponse
-	12, // 14: media.MediaService.GetImages:output_type -> media.GetImagesResponse
-	8,  // 15: media.MediaService.ValidateUpload:output_type -> media.ValidateUploadResponse
-	12, // [12:16] is the sub-list for method output_type
-	8,  // [8:12] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	1,  // 2: media.UploadVideoRequest.visibility:type_name -> media.FileVisibility
+	0,  // 3: media.UploadVideoRequest.variants:type_name -> media.FileVariant
+	0,  // 4: media.GetImageRequest.variant:type_name -> media.FileVariant
+	0,  // 5: media.PublicFileRequest.variant:type_name -> media.FileVariant
+	0,  // 6: media.ImageTransform.preset:type_name -> media.FileVariant
+	11, // 7: media.SignImageTransformRequest.transform:type_name -> media.ImageTransform
+	11, // 8: media.SignImageTransformResponse.transform:type_name -> media.ImageTransform
+	11, // 9: media.GetImageTransformRequest.transform:type_name -> media.ImageTransform
+	17, // 10: media.GetImagesRequest.img_ids:type_name -> media.ImageIds
+	0,  // 11: media.GetImagesRequest.variant:type_name -> media.FileVariant
+	2,  // 12: media.FailedId.status:type_name -> media.UploadStatus
+	35, // 13: media.GetImagesResponse.download_urls:type_name -> media.GetImagesResponse.DownloadUrlsEntry
+	19, // 14: media.GetImagesResponse.failed_ids:type_name -> media.FailedId
+	36, // 15: media.GetImagesResponse.placeholders:type_name -> media.GetImagesResponse.PlaceholdersEntry
+	3,  // 16: media.FileAttachment.type:type_name -> media.AttachmentType
+	1,  // 17: media.InitiateMultipartUploadRequest.visibility:type_name -> media.FileVisibility
+	0,  // 18: media.InitiateMultipartUploadRequest.variants:type_name -> media.FileVariant
+	28, // 19: media.SignUploadPartsResponse.parts:type_name -> media.UploadPart
+	33, // 20: media.ListQuarantinedFilesResponse.files:type_name -> media.QuarantinedFile
+	4,  // 21: media.MediaService.UploadImage:input_type -> media.UploadImageRequest
+	6,  // 22: media.MediaService.UploadVideo:input_type -> media.UploadVideoRequest
+	25, // 23: media.MediaService.InitiateMultipartUpload:input_type -> media.InitiateMultipartUploadRequest
+	27, // 24: media.MediaService.SignUploadParts:input_type -> media.SignUploadPartsRequest
+	30, // 25: media.MediaService.GetUploadProgress:input_type -> media.MultipartUploadRequest
+	30, // 26: media.MediaService.CompleteMultipartUpload:input_type -> media.MultipartUploadRequest
+	30, // 27: media.MediaService.AbortMultipartUpload:input_type -> media.MultipartUploadRequest
+	8,  // 28: media.MediaService.GetImage:input_type -> media.GetImageRequest
+	18, // 29: media.MediaService.GetImages:input_type -> media.GetImagesRequest
+	10, // 30: media.MediaService.GetPublicFile:input_type -> media.PublicFileRequest
+	12, // 31: media.MediaService.SignImageTransform:input_type -> media.SignImageTransformRequest
+	14, // 32: media.MediaService.GetImageTransform:input_type -> media.GetImageTransformRequest
+	15, // 33: media.MediaService.ValidateUpload:input_type -> media.ValidateUploadRequest
+	21, // 34: media.MediaService.AttachFile:input_type -> media.FileAttachment
+	21, // 35: media.MediaService.DetachFile:input_type -> media.FileAttachment
+	22, // 36: media.MediaService.DeleteFile:input_type -> media.DeleteFileRequest
+	23, // 37: media.MediaService.CollectGarbage:input_type -> media.CollectGarbageRequest
+	32, // 38: media.MediaService.ListQuarantinedFiles:input_type -> media.ListQuarantinedFilesRequest
+	5,  // 39: media.MediaService.UploadImage:output_type -> media.UploadImageResponse
+	7,  // 40: media.MediaService.UploadVideo:output_type -> media.UploadVideoResponse
+	26, // 41: media.MediaService.InitiateMultipartUpload:output_type -> media.InitiateMultipartUploadResponse
+	29, // 42: media.MediaService.SignUploadParts:output_type -> media.SignUploadPartsResponse
+	31, // 43: media.MediaService.GetUploadProgress:output_type -> media.UploadProgress
+	37, // 44: media.MediaService.CompleteMultipartUpload:output_type -> google.protobuf.Empty
+	37, // 45: media.MediaService.AbortMultipartUpload:output_type -> google.protobuf.Empty
+	9,  // 46: media.MediaService.GetImage:output_type -> media.GetImageResponse
+	20, // 47: media.MediaService.GetImages:output_type -> media.GetImagesResponse
+	9,  // 48: media.MediaService.GetPublicFile:output_type -> media.GetImageResponse
+	13, // 49: media.MediaService.SignImageTransform:output_type -> media.SignImageTransformResponse
+	9,  // 50: media.MediaService.GetImageTransform:output_type -> media.GetImageResponse
+	16, // 51: media.MediaService.ValidateUpload:output_type -> media.ValidateUploadResponse
+	37, // 52: media.MediaService.AttachFile:output_type -> google.protobuf.Empty
+	37, // 53: media.MediaService.DetachFile:output_type -> google.protobuf.Empty
+	37, // 54: media.MediaService.DeleteFile:output_type -> google.protobuf.Empty
+	24, // 55: media.MediaService.CollectGarbage:output_type -> media.CollectGarbageResponse
+	34, // 56: media.MediaService.ListQuarantinedFiles:output_type -> media.ListQuarantinedFilesResponse
+	39, // [39:57] is the sub-list for method output_type
+	21, // [21:39] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_media_proto_init() }
@@ -866,8 +2686,8 @@ func file_media_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_media_proto_rawDesc), len(file_media_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   11,
+			NumEnums:      4,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
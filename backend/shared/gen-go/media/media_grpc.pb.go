@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -19,10 +20,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MediaService_UploadImage_FullMethodName    = "/media.MediaService/UploadImage"
-	MediaService_GetImage_FullMethodName       = "/media.MediaService/GetImage"
-	MediaService_GetImages_FullMethodName      = "/media.MediaService/GetImages"
-	MediaService_ValidateUpload_FullMethodName = "/media.MediaService/ValidateUpload"
+	MediaService_UploadImage_FullMethodName             = "/media.MediaService/UploadImage"
+	MediaService_UploadVideo_FullMethodName             = "/media.MediaService/UploadVideo"
+	MediaService_InitiateMultipartUpload_FullMethodName = "/media.MediaService/InitiateMultipartUpload"
+	MediaService_SignUploadParts_FullMethodName         = "/media.MediaService/SignUploadParts"
+	MediaService_GetUploadProgress_FullMethodName       = "/media.MediaService/GetUploadProgress"
+	MediaService_CompleteMultipartUpload_FullMethodName = "/media.MediaService/CompleteMultipartUpload"
+	MediaService_AbortMultipartUpload_FullMethodName    = "/media.MediaService/AbortMultipartUpload"
+	MediaService_GetImage_FullMethodName                = "/media.MediaService/GetImage"
+	MediaService_GetImages_FullMethodName               = "/media.MediaService/GetImages"
+	MediaService_GetPublicFile_FullMethodName           = "/media.MediaService/GetPublicFile"
+	MediaService_SignImageTransform_FullMethodName      = "/media.MediaService/SignImageTransform"
+	MediaService_GetImageTransform_FullMethodName       = "/media.MediaService/GetImageTransform"
+	MediaService_ValidateUpload_FullMethodName          = "/media.MediaService/ValidateUpload"
+	MediaService_AttachFile_FullMethodName              = "/media.MediaService/AttachFile"
+	MediaService_DetachFile_FullMethodName              = "/media.MediaService/DetachFile"
+	MediaService_DeleteFile_FullMethodName              = "/media.MediaService/DeleteFile"
+	MediaService_CollectGarbage_FullMethodName          = "/media.MediaService/CollectGarbage"
+	MediaService_ListQuarantinedFiles_FullMethodName    = "/media.MediaService/ListQuarantinedFiles"
 )
 
 // MediaServiceClient is the client API for MediaService service.
@@ -37,6 +52,25 @@ type MediaServiceClient interface {
 	// Before accessing the upload a success response from ValidateUpload
 	// is nessecary. Validation expiration is set to 24 hours.
 	UploadImage(ctx context.Context, in *UploadImageRequest, opts ...grpc.CallOption) (*UploadImageResponse, error)
+	// Same flow as UploadImage for short videos. ValidateUpload probes the
+	// container and codecs, renditions and the poster frame are transcoded
+	// by the variant worker afterwards. Videos are downloaded with GetImage.
+	UploadVideo(ctx context.Context, in *UploadVideoRequest, opts ...grpc.CallOption) (*UploadVideoResponse, error)
+	// Starts a multipart upload of a large image or video. The original is uploaded
+	// in parts, each with its own pre-signed URL from SignUploadParts, in any order
+	// and again after failures. CompleteMultipartUpload assembles the parts, then
+	// the file is validated with ValidateUpload as any other upload.
+	InitiateMultipartUpload(ctx context.Context, in *InitiateMultipartUploadRequest, opts ...grpc.CallOption) (*InitiateMultipartUploadResponse, error)
+	// Returns upload URLs of the requested parts, or of those not uploaded yet.
+	// The URLs expire with the upload at the latest, uploads resume with new ones.
+	SignUploadParts(ctx context.Context, in *SignUploadPartsRequest, opts ...grpc.CallOption) (*SignUploadPartsResponse, error)
+	// Returns the parts uploaded so far, to resume an interrupted upload.
+	GetUploadProgress(ctx context.Context, in *MultipartUploadRequest, opts ...grpc.CallOption) (*UploadProgress, error)
+	// Assembles the uploaded parts. Fails with failed precondition while parts
+	// are missing or their size does not add up to the declared size.
+	CompleteMultipartUpload(ctx context.Context, in *MultipartUploadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Aborts a multipart upload and deletes its file and uploaded parts.
+	AbortMultipartUpload(ctx context.Context, in *MultipartUploadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Returns an image download URL for the requested imageId and Variant.
 	// If the variant is not available it falls back to the original file.
 	GetImage(ctx context.Context, in *GetImageRequest, opts ...grpc.CallOption) (*GetImageResponse, error)
@@ -46,10 +80,38 @@ type MediaServiceClient interface {
 	// returns url for the original format.
 	// GetImages does not accept original variants in batch request
 	GetImages(ctx context.Context, in *GetImagesRequest, opts ...grpc.CallOption) (*GetImagesResponse, error)
+	// Returns a short lived URL of a public file on the internal file service endpoint.
+	// Used by the gateway to serve the stable URLs GetImage and GetImages return for
+	// public files. Private files are not found.
+	GetPublicFile(ctx context.Context, in *PublicFileRequest, opts ...grpc.CallOption) (*GetImageResponse, error)
+	// Signs a transform of an image for the requester allowed to download it.
+	// Sizes are limited by configuration and so is the number of transforms per image.
+	SignImageTransform(ctx context.Context, in *SignImageTransformRequest, opts ...grpc.CallOption) (*SignImageTransformResponse, error)
+	// Returns a download URL of a signed image transform. The transform is generated
+	// on the first request and cached. Transforms of a named variant use its variant.
+	GetImageTransform(ctx context.Context, in *GetImageTransformRequest, opts ...grpc.CallOption) (*GetImageResponse, error)
 	// This is a call to validate an already uploaded file.
 	// Unvalidated files expire in 24 hours and are automatically
 	// deleted from file service. If requested returns a download url.
+	// An upload with the same content as a complete file shares its objects.
 	ValidateUpload(ctx context.Context, in *ValidateUploadRequest, opts ...grpc.CallOption) (*ValidateUploadResponse, error)
+	// Records that a file is used by an entity. Files without an owner are
+	// claimed by the attachment owner, files of another owner are rejected.
+	// Private files can be downloaded by the users that can see one of their entities.
+	AttachFile(ctx context.Context, in *FileAttachment, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Removes the attachment of a file to an entity.
+	DetachFile(ctx context.Context, in *FileAttachment, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Deletes a file with its variants and attachments. Uploads of the same
+	// content share their objects, which are deleted with the last of them.
+	DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Runs the garbage collector once, as the gc worker does periodically.
+	// Expired uploads, failed and unreferenced files, orphan objects and
+	// abandoned multipart uploads are collected.
+	// Returns unavailable while another run is in progress.
+	CollectGarbage(ctx context.Context, in *CollectGarbageRequest, opts ...grpc.CallOption) (*CollectGarbageResponse, error)
+	// Lists the files quarantined by the content scanner for the admins, with the
+	// signature they were flagged with and the object kept for inspection.
+	ListQuarantinedFiles(ctx context.Context, in *ListQuarantinedFilesRequest, opts ...grpc.CallOption) (*ListQuarantinedFilesResponse, error)
 }
 
 type mediaServiceClient struct {
@@ -70,6 +132,66 @@ func (c *mediaServiceClient) UploadImage(ctx context.Context, in *UploadImageReq
 	return out, nil
 }
 
+func (c *mediaServiceClient) UploadVideo(ctx context.Context, in *UploadVideoRequest, opts ...grpc.CallOption) (*UploadVideoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadVideoResponse)
+	err := c.cc.Invoke(ctx, MediaService_UploadVideo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) InitiateMultipartUpload(ctx context.Context, in *InitiateMultipartUploadRequest, opts ...grpc.CallOption) (*InitiateMultipartUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InitiateMultipartUploadResponse)
+	err := c.cc.Invoke(ctx, MediaService_InitiateMultipartUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) SignUploadParts(ctx context.Context, in *SignUploadPartsRequest, opts ...grpc.CallOption) (*SignUploadPartsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignUploadPartsResponse)
+	err := c.cc.Invoke(ctx, MediaService_SignUploadParts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetUploadProgress(ctx context.Context, in *MultipartUploadRequest, opts ...grpc.CallOption) (*UploadProgress, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadProgress)
+	err := c.cc.Invoke(ctx, MediaService_GetUploadProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) CompleteMultipartUpload(ctx context.Context, in *MultipartUploadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MediaService_CompleteMultipartUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) AbortMultipartUpload(ctx context.Context, in *MultipartUploadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MediaService_AbortMultipartUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mediaServiceClient) GetImage(ctx context.Context, in *GetImageRequest, opts ...grpc.CallOption) (*GetImageResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetImageResponse)
@@ -90,6 +212,36 @@ func (c *mediaServiceClient) GetImages(ctx context.Context, in *GetImagesRequest
 	return out, nil
 }
 
+func (c *mediaServiceClient) GetPublicFile(ctx context.Context, in *PublicFileRequest, opts ...grpc.CallOption) (*GetImageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetImageResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetPublicFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) SignImageTransform(ctx context.Context, in *SignImageTransformRequest, opts ...grpc.CallOption) (*SignImageTransformResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignImageTransformResponse)
+	err := c.cc.Invoke(ctx, MediaService_SignImageTransform_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetImageTransform(ctx context.Context, in *GetImageTransformRequest, opts ...grpc.CallOption) (*GetImageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetImageResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetImageTransform_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mediaServiceClient) ValidateUpload(ctx context.Context, in *ValidateUploadRequest, opts ...grpc.CallOption) (*ValidateUploadResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ValidateUploadResponse)
@@ -100,6 +252,56 @@ func (c *mediaServiceClient) ValidateUpload(ctx context.Context, in *ValidateUpl
 	return out, nil
 }
 
+func (c *mediaServiceClient) AttachFile(ctx context.Context, in *FileAttachment, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MediaService_AttachFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) DetachFile(ctx context.Context, in *FileAttachment, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MediaService_DetachFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MediaService_DeleteFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) CollectGarbage(ctx context.Context, in *CollectGarbageRequest, opts ...grpc.CallOption) (*CollectGarbageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectGarbageResponse)
+	err := c.cc.Invoke(ctx, MediaService_CollectGarbage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ListQuarantinedFiles(ctx context.Context, in *ListQuarantinedFilesRequest, opts ...grpc.CallOption) (*ListQuarantinedFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQuarantinedFilesResponse)
+	err := c.cc.Invoke(ctx, MediaService_ListQuarantinedFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MediaServiceServer is the server API for MediaService service.
 // All implementations must embed UnimplementedMediaServiceServer
 // for forward compatibility.
@@ -112,6 +314,25 @@ type MediaServiceServer interface {
 	// Before accessing the upload a success response from ValidateUpload
 	// is nessecary. Validation expiration is set to 24 hours.
 	UploadImage(context.Context, *UploadImageRequest) (*UploadImageResponse, error)
+	// Same flow as UploadImage for short videos. ValidateUpload probes the
+	// container and codecs, renditions and the poster frame are transcoded
+	// by the variant worker afterwards. Videos are downloaded with GetImage.
+	UploadVideo(context.Context, *UploadVideoRequest) (*UploadVideoResponse, error)
+	// Starts a multipart upload of a large image or video. The original is uploaded
+	// in parts, each with its own pre-signed URL from SignUploadParts, in any order
+	// and again after failures. CompleteMultipartUpload assembles the parts, then
+	// the file is validated with ValidateUpload as any other upload.
+	InitiateMultipartUpload(context.Context, *InitiateMultipartUploadRequest) (*InitiateMultipartUploadResponse, error)
+	// Returns upload URLs of the requested parts, or of those not uploaded yet.
+	// The URLs expire with the upload at the latest, uploads resume with new ones.
+	SignUploadParts(context.Context, *SignUploadPartsRequest) (*SignUploadPartsResponse, error)
+	// Returns the parts uploaded so far, to resume an interrupted upload.
+	GetUploadProgress(context.Context, *MultipartUploadRequest) (*UploadProgress, error)
+	// Assembles the uploaded parts. Fails with failed precondition while parts
+	// are missing or their size does not add up to the declared size.
+	CompleteMultipartUpload(context.Context, *MultipartUploadRequest) (*emptypb.Empty, error)
+	// Aborts a multipart upload and deletes its file and uploaded parts.
+	AbortMultipartUpload(context.Context, *MultipartUploadRequest) (*emptypb.Empty, error)
 	// Returns an image download URL for the requested imageId and Variant.
 	// If the variant is not available it falls back to the original file.
 	GetImage(context.Context, *GetImageRequest) (*GetImageResponse, error)
@@ -121,10 +342,38 @@ type MediaServiceServer interface {
 	// returns url for the original format.
 	// GetImages does not accept original variants in batch request
 	GetImages(context.Context, *GetImagesRequest) (*GetImagesResponse, error)
+	// Returns a short lived URL of a public file on the internal file service endpoint.
+	// Used by the gateway to serve the stable URLs GetImage and GetImages return for
+	// public files. Private files are not found.
+	GetPublicFile(context.Context, *PublicFileRequest) (*GetImageResponse, error)
+	// Signs a transform of an image for the requester allowed to download it.
+	// Sizes are limited by configuration and so is the number of transforms per image.
+	SignImageTransform(context.Context, *SignImageTransformRequest) (*SignImageTransformResponse, error)
+	// Returns a download URL of a signed image transform. The transform is generated
+	// on the first request and cached. Transforms of a named variant use its variant.
+	GetImageTransform(context.Context, *GetImageTransformRequest) (*GetImageResponse, error)
 	// This is a call to validate an already uploaded file.
 	// Unvalidated files expire in 24 hours and are automatically
 	// deleted from file service. If requested returns a download url.
+	// An upload with the same content as a complete file shares its objects.
 	ValidateUpload(context.Context, *ValidateUploadRequest) (*ValidateUploadResponse, error)
+	// Records that a file is used by an entity. Files without an owner are
+	// claimed by the attachment owner, files of another owner are rejected.
+	// Private files can be downloaded by the users that can see one of their entities.
+	AttachFile(context.Context, *FileAttachment) (*emptypb.Empty, error)
+	// Removes the attachment of a file to an entity.
+	DetachFile(context.Context, *FileAttachment) (*emptypb.Empty, error)
+	// Deletes a file with its variants and attachments. Uploads of the same
+	// content share their objects, which are deleted with the last of them.
+	DeleteFile(context.Context, *DeleteFileRequest) (*emptypb.Empty, error)
+	// Runs the garbage collector once, as the gc worker does periodically.
+	// Expired uploads, failed and unreferenced files, orphan objects and
+	// abandoned multipart uploads are collected.
+	// Returns unavailable while another run is in progress.
+	CollectGarbage(context.Context, *CollectGarbageRequest) (*CollectGarbageResponse, error)
+	// Lists the files quarantined by the content scanner for the admins, with the
+	// signature they were flagged with and the object kept for inspection.
+	ListQuarantinedFiles(context.Context, *ListQuarantinedFilesRequest) (*ListQuarantinedFilesResponse, error)
 	mustEmbedUnimplementedMediaServiceServer()
 }
 
@@ -138,15 +387,57 @@ type UnimplementedMediaServiceServer struct{}
 func (UnimplementedMediaServiceServer) UploadImage(context.Context, *UploadImageRequest) (*UploadImageResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UploadImage not implemented")
 }
+func (UnimplementedMediaServiceServer) UploadVideo(context.Context, *UploadVideoRequest) (*UploadVideoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UploadVideo not implemented")
+}
+func (UnimplementedMediaServiceServer) InitiateMultipartUpload(context.Context, *InitiateMultipartUploadRequest) (*InitiateMultipartUploadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InitiateMultipartUpload not implemented")
+}
+func (UnimplementedMediaServiceServer) SignUploadParts(context.Context, *SignUploadPartsRequest) (*SignUploadPartsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SignUploadParts not implemented")
+}
+func (UnimplementedMediaServiceServer) GetUploadProgress(context.Context, *MultipartUploadRequest) (*UploadProgress, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUploadProgress not implemented")
+}
+func (UnimplementedMediaServiceServer) CompleteMultipartUpload(context.Context, *MultipartUploadRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteMultipartUpload not implemented")
+}
+func (UnimplementedMediaServiceServer) AbortMultipartUpload(context.Context, *MultipartUploadRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method AbortMultipartUpload not implemented")
+}
 func (UnimplementedMediaServiceServer) GetImage(context.Context, *GetImageRequest) (*GetImageResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetImage not implemented")
 }
 func (UnimplementedMediaServiceServer) GetImages(context.Context, *GetImagesRequest) (*GetImagesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetImages not implemented")
 }
+func (UnimplementedMediaServiceServer) GetPublicFile(context.Context, *PublicFileRequest) (*GetImageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPublicFile not implemented")
+}
+func (UnimplementedMediaServiceServer) SignImageTransform(context.Context, *SignImageTransformRequest) (*SignImageTransformResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SignImageTransform not implemented")
+}
+func (UnimplementedMediaServiceServer) GetImageTransform(context.Context, *GetImageTransformRequest) (*GetImageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetImageTransform not implemented")
+}
 func (UnimplementedMediaServiceServer) ValidateUpload(context.Context, *ValidateUploadRequest) (*ValidateUploadResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ValidateUpload not implemented")
 }
+func (UnimplementedMediaServiceServer) AttachFile(context.Context, *FileAttachment) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method AttachFile not implemented")
+}
+func (UnimplementedMediaServiceServer) DetachFile(context.Context, *FileAttachment) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DetachFile not implemented")
+}
+func (UnimplementedMediaServiceServer) DeleteFile(context.Context, *DeleteFileRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteFile not implemented")
+}
+func (UnimplementedMediaServiceServer) CollectGarbage(context.Context, *CollectGarbageRequest) (*CollectGarbageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CollectGarbage not implemented")
+}
+func (UnimplementedMediaServiceServer) ListQuarantinedFiles(context.Context, *ListQuarantinedFilesRequest) (*ListQuarantinedFilesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListQuarantinedFiles not implemented")
+}
 func (UnimplementedMediaServiceServer) mustEmbedUnimplementedMediaServiceServer() {}
 func (UnimplementedMediaServiceServer) testEmbeddedByValue()                      {}
 
@@ -186,6 +477,114 @@ func _MediaService_UploadImage_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MediaService_UploadVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).UploadVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_UploadVideo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).UploadVideo(ctx, req.(*UploadVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_InitiateMultipartUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitiateMultipartUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).InitiateMultipartUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_InitiateMultipartUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).InitiateMultipartUpload(ctx, req.(*InitiateMultipartUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_SignUploadParts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignUploadPartsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).SignUploadParts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_SignUploadParts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).SignUploadParts(ctx, req.(*SignUploadPartsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetUploadProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultipartUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetUploadProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetUploadProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetUploadProgress(ctx, req.(*MultipartUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_CompleteMultipartUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultipartUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).CompleteMultipartUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_CompleteMultipartUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).CompleteMultipartUpload(ctx, req.(*MultipartUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_AbortMultipartUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultipartUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).AbortMultipartUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_AbortMultipartUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).AbortMultipartUpload(ctx, req.(*MultipartUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MediaService_GetImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetImageRequest)
 	if err := dec(in); err != nil {
@@ -222,6 +621,60 @@ func _MediaService_GetImages_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MediaService_GetPublicFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetPublicFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetPublicFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetPublicFile(ctx, req.(*PublicFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_SignImageTransform_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignImageTransformRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).SignImageTransform(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_SignImageTransform_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).SignImageTransform(ctx, req.(*SignImageTransformRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetImageTransform_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImageTransformRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetImageTransform(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetImageTransform_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetImageTransform(ctx, req.(*GetImageTransformRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MediaService_ValidateUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ValidateUploadRequest)
 	if err := dec(in); err != nil {
@@ -240,6 +693,96 @@ func _MediaService_ValidateUpload_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MediaService_AttachFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileAttachment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).AttachFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_AttachFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).AttachFile(ctx, req.(*FileAttachment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_DetachFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileAttachment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).DetachFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_DetachFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).DetachFile(ctx, req.(*FileAttachment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).DeleteFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_DeleteFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).DeleteFile(ctx, req.(*DeleteFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_CollectGarbage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectGarbageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).CollectGarbage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_CollectGarbage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).CollectGarbage(ctx, req.(*CollectGarbageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ListQuarantinedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQuarantinedFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ListQuarantinedFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ListQuarantinedFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ListQuarantinedFiles(ctx, req.(*ListQuarantinedFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MediaService_ServiceDesc is the grpc.ServiceDesc for MediaService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -251,6 +794,30 @@ var MediaService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UploadImage",
 			Handler:    _MediaService_UploadImage_Handler,
 		},
+		{
+			MethodName: "UploadVideo",
+			Handler:    _MediaService_UploadVideo_Handler,
+		},
+		{
+			MethodName: "InitiateMultipartUpload",
+			Handler:    _MediaService_InitiateMultipartUpload_Handler,
+		},
+		{
+			MethodName: "SignUploadParts",
+			Handler:    _MediaService_SignUploadParts_Handler,
+		},
+		{
+			MethodName: "GetUploadProgress",
+			Handler:    _MediaService_GetUploadProgress_Handler,
+		},
+		{
+			MethodName: "CompleteMultipartUpload",
+			Handler:    _MediaService_CompleteMultipartUpload_Handler,
+		},
+		{
+			MethodName: "AbortMultipartUpload",
+			Handler:    _MediaService_AbortMultipartUpload_Handler,
+		},
 		{
 			MethodName: "GetImage",
 			Handler:    _MediaService_GetImage_Handler,
@@ -259,10 +826,42 @@ var MediaService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetImages",
 			Handler:    _MediaService_GetImages_Handler,
 		},
+		{
+			MethodName: "GetPublicFile",
+			Handler:    _MediaService_GetPublicFile_Handler,
+		},
+		{
+			MethodName: "SignImageTransform",
+			Handler:    _MediaService_SignImageTransform_Handler,
+		},
+		{
+			MethodName: "GetImageTransform",
+			Handler:    _MediaService_GetImageTransform_Handler,
+		},
 		{
 			MethodName: "ValidateUpload",
 			Handler:    _MediaService_ValidateUpload_Handler,
 		},
+		{
+			MethodName: "AttachFile",
+			Handler:    _MediaService_AttachFile_Handler,
+		},
+		{
+			MethodName: "DetachFile",
+			Handler:    _MediaService_DetachFile_Handler,
+		},
+		{
+			MethodName: "DeleteFile",
+			Handler:    _MediaService_DeleteFile_Handler,
+		},
+		{
+			MethodName: "CollectGarbage",
+			Handler:    _MediaService_CollectGarbage_Handler,
+		},
+		{
+			MethodName: "ListQuarantinedFiles",
+			Handler:    _MediaService_ListQuarantinedFiles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "media.proto",
@@ -13,6 +13,7 @@ import (
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
+	common "social-network/shared/gen-go/common"
 	sync "sync"
 	unsafe "unsafe"
 )
@@ -44,6 +45,8 @@ const (
 	NotificationType_NOTIFICATION_TYPE_GROUP_INVITE_REJECTED       NotificationType = 13
 	NotificationType_NOTIFICATION_TYPE_GROUP_JOIN_REQUEST_ACCEPTED NotificationType = 14
 	NotificationType_NOTIFICATION_TYPE_GROUP_JOIN_REQUEST_REJECTED NotificationType = 15
+	NotificationType_NOTIFICATION_TYPE_POST_PUBLISHED              NotificationType = 16
+	NotificationType_NOTIFICATION_TYPE_POST_SHARED                 NotificationType = 17
 )
 
 // Enum value maps for NotificationType.
@@ -65,6 +68,8 @@ var (
 		13: "NOTIFICATION_TYPE_GROUP_INVITE_REJECTED",
 		14: "NOTIFICATION_TYPE_GROUP_JOIN_REQUEST_ACCEPTED",
 		15: "NOTIFICATION_TYPE_GROUP_JOIN_REQUEST_REJECTED",
+		16: "NOTIFICATION_TYPE_POST_PUBLISHED",
+		17: "NOTIFICATION_TYPE_POST_SHARED",
 	}
 	NotificationType_value = map[string]int32{
 		"NOTIFICATION_TYPE_UNSPECIFIED":                 0,
@@ -83,6 +88,8 @@ var (
 		"NOTIFICATION_TYPE_GROUP_INVITE_REJECTED":       13,
 		"NOTIFICATION_TYPE_GROUP_JOIN_REQUEST_ACCEPTED": 14,
 		"NOTIFICATION_TYPE_GROUP_JOIN_REQUEST_REJECTED": 15,
+		"NOTIFICATION_TYPE_POST_PUBLISHED":              16,
+		"NOTIFICATION_TYPE_POST_SHARED":                 17,
 	}
 )
 
@@ -188,6 +195,8 @@ const (
 	EventType_GROUP_JOIN_REQUEST_REJECTED  EventType = 15
 	EventType_FOLLOW_REQUEST_CANCELLED     EventType = 16
 	EventType_GROUP_JOIN_REQUEST_CANCELLED EventType = 17
+	EventType_POST_PUBLISHED               EventType = 18
+	EventType_POST_SHARED                  EventType = 19
 )
 
 // Enum value maps for EventType.
@@ -211,6 +220,8 @@ var (
 		15: "GROUP_JOIN_REQUEST_REJECTED",
 		16: "FOLLOW_REQUEST_CANCELLED",
 		17: "GROUP_JOIN_REQUEST_CANCELLED",
+		18: "POST_PUBLISHED",
+		19: "POST_SHARED",
 	}
 	EventType_value = map[string]int32{
 		"EVENT_TYPE_UNSPECIFIED":       0,
@@ -231,6 +242,8 @@ var (
 		"GROUP_JOIN_REQUEST_REJECTED":  15,
 		"FOLLOW_REQUEST_CANCELLED":     16,
 		"GROUP_JOIN_REQUEST_CANCELLED": 17,
+		"POST_PUBLISHED":               18,
+		"POST_SHARED":                  19,
 	}
 )
 
@@ -747,6 +760,9 @@ type GetUserNotificationsRequest struct {
 	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
 	Types         []NotificationType     `protobuf:"varint,4,rep,packed,name=types,proto3,enum=notifications.NotificationType" json:"types,omitempty"` // filter by types
 	UnreadOnly    bool                   `protobuf:"varint,5,opt,name=unread_only,json=unreadOnly,proto3" json:"unread_only,omitempty"`                // if true, only return unread notifications
+	Grouped       bool                   `protobuf:"varint,6,opt,name=grouped,proto3" json:"grouped,omitempty"`                                        // if true, return groups instead of notifications, paginated with cursor instead of offset
+	Cursor        string                 `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`                                           // next_cursor of the previous page of groups, empty for the first page
+	Categories    []string               `protobuf:"bytes,8,rep,name=categories,proto3" json:"categories,omitempty"`                                   // filter groups by notification category
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -816,31 +832,629 @@ func (x *GetUserNotificationsRequest) GetUnreadOnly() bool {
 	return false
 }
 
+func (x *GetUserNotificationsRequest) GetGrouped() bool {
+	if x != nil {
+		return x.Grouped
+	}
+	return false
+}
+
+func (x *GetUserNotificationsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetUserNotificationsRequest) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
 // Response with user notifications
 type GetUserNotificationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	UnreadCount   int32                  `protobuf:"varint,3,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	UnreadCount   int32                  `protobuf:"varint,3,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	Groups        []*NotificationGroup   `protobuf:"bytes,4,rep,name=groups,proto3" json:"groups,omitempty"`                           // set instead of notifications for grouped requests
+	NextCursor    string                 `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // empty on the last page of groups
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserNotificationsResponse) Reset() {
+	*x = GetUserNotificationsResponse{}
+	mi := &file_notifications_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserNotificationsResponse) ProtoMessage() {}
+
+func (x *GetUserNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetUserNotificationsResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *GetUserNotificationsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *GetUserNotificationsResponse) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+func (x *GetUserNotificationsResponse) GetGroups() []*NotificationGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *GetUserNotificationsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Notifications about the same entity within a time bucket, shown as one,
+// e.g. the likes of a post on the same day or the new followers of the day
+type NotificationGroup struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Type            string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	SourceService   string                 `protobuf:"bytes,2,opt,name=source_service,json=sourceService,proto3" json:"source_service,omitempty"`
+	SourceEntityId  int64                  `protobuf:"varint,3,opt,name=source_entity_id,json=sourceEntityId,proto3" json:"source_entity_id,omitempty"`         // of the latest notification
+	NotificationIds []int64                `protobuf:"varint,4,rep,packed,name=notification_ids,json=notificationIds,proto3" json:"notification_ids,omitempty"` // latest first
+	Count           int64                  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`                                                   // events in the group, aggregated notifications count for several
+	Actors          []*common.User         `protobuf:"bytes,6,rep,name=actors,proto3" json:"actors,omitempty"`                                                  // latest first, a few at most
+	OtherActors     int64                  `protobuf:"varint,7,opt,name=other_actors,json=otherActors,proto3" json:"other_actors,omitempty"`                    // events by users not in actors
+	ActorsSummary   string                 `protobuf:"bytes,8,opt,name=actors_summary,json=actorsSummary,proto3" json:"actors_summary,omitempty"`               // e.g. "Alice, Bob and 12 others"
+	Seen            bool                   `protobuf:"varint,9,opt,name=seen,proto3" json:"seen,omitempty"`                                                     // whether every notification of the group is read
+	NeedsAction     bool                   `protobuf:"varint,10,opt,name=needs_action,json=needsAction,proto3" json:"needs_action,omitempty"`
+	Acted           bool                   `protobuf:"varint,11,opt,name=acted,proto3" json:"acted,omitempty"`
+	Payload         map[string]string      `protobuf:"bytes,12,rep,name=payload,proto3" json:"payload,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // of the latest notification
+	LatestAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=latest_at,json=latestAt,proto3" json:"latest_at,omitempty"`
+	EarliestAt      *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=earliest_at,json=earliestAt,proto3" json:"earliest_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *NotificationGroup) Reset() {
+	*x = NotificationGroup{}
+	mi := &file_notifications_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationGroup) ProtoMessage() {}
+
+func (x *NotificationGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationGroup.ProtoReflect.Descriptor instead.
+func (*NotificationGroup) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NotificationGroup) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *NotificationGroup) GetSourceService() string {
+	if x != nil {
+		return x.SourceService
+	}
+	return ""
+}
+
+func (x *NotificationGroup) GetSourceEntityId() int64 {
+	if x != nil {
+		return x.SourceEntityId
+	}
+	return 0
+}
+
+func (x *NotificationGroup) GetNotificationIds() []int64 {
+	if x != nil {
+		return x.NotificationIds
+	}
+	return nil
+}
+
+func (x *NotificationGroup) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *NotificationGroup) GetActors() []*common.User {
+	if x != nil {
+		return x.Actors
+	}
+	return nil
+}
+
+func (x *NotificationGroup) GetOtherActors() int64 {
+	if x != nil {
+		return x.OtherActors
+	}
+	return 0
+}
+
+func (x *NotificationGroup) GetActorsSummary() string {
+	if x != nil {
+		return x.ActorsSummary
+	}
+	return ""
+}
+
+func (x *NotificationGroup) GetSeen() bool {
+	if x != nil {
+		return x.Seen
+	}
+	return false
+}
+
+func (x *NotificationGroup) GetNeedsAction() bool {
+	if x != nil {
+		return x.NeedsAction
+	}
+	return false
+}
+
+func (x *NotificationGroup) GetActed() bool {
+	if x != nil {
+		return x.Acted
+	}
+	return false
+}
+
+func (x *NotificationGroup) GetPayload() map[string]string {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *NotificationGroup) GetLatestAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LatestAt
+	}
+	return nil
+}
+
+func (x *NotificationGroup) GetEarliestAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EarliestAt
+	}
+	return nil
+}
+
+// Request to mark notification as read
+type MarkNotificationAsReadRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId int64                  `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	UserId         int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MarkNotificationAsReadRequest) Reset() {
+	*x = MarkNotificationAsReadRequest{}
+	mi := &file_notifications_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationAsReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationAsReadRequest) ProtoMessage() {}
+
+func (x *MarkNotificationAsReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationAsReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkNotificationAsReadRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MarkNotificationAsReadRequest) GetNotificationId() int64 {
+	if x != nil {
+		return x.NotificationId
+	}
+	return 0
+}
+
+func (x *MarkNotificationAsReadRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Request to mark notification as acted
+type MarkNotificationAsActedRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId int64                  `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	UserId         int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MarkNotificationAsActedRequest) Reset() {
+	*x = MarkNotificationAsActedRequest{}
+	mi := &file_notifications_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationAsActedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationAsActedRequest) ProtoMessage() {}
+
+func (x *MarkNotificationAsActedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationAsActedRequest.ProtoReflect.Descriptor instead.
+func (*MarkNotificationAsActedRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MarkNotificationAsActedRequest) GetNotificationId() int64 {
+	if x != nil {
+		return x.NotificationId
+	}
+	return 0
+}
+
+func (x *MarkNotificationAsActedRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Request to mark the notifications about an entity as read
+type MarkReadByEntityRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SourceService  string                 `protobuf:"bytes,2,opt,name=source_service,json=sourceService,proto3" json:"source_service,omitempty"`       // e.g. "chat", "posts"
+	SourceEntityId int64                  `protobuf:"varint,3,opt,name=source_entity_id,json=sourceEntityId,proto3" json:"source_entity_id,omitempty"` // e.g. the conversation or the post
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MarkReadByEntityRequest) Reset() {
+	*x = MarkReadByEntityRequest{}
+	mi := &file_notifications_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadByEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadByEntityRequest) ProtoMessage() {}
+
+func (x *MarkReadByEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadByEntityRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadByEntityRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MarkReadByEntityRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *MarkReadByEntityRequest) GetSourceService() string {
+	if x != nil {
+		return x.SourceService
+	}
+	return ""
+}
+
+func (x *MarkReadByEntityRequest) GetSourceEntityId() int64 {
+	if x != nil {
+		return x.SourceEntityId
+	}
+	return 0
+}
+
+// Request to mark the notifications of a category as read
+type MarkReadByCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadByCategoryRequest) Reset() {
+	*x = MarkReadByCategoryRequest{}
+	mi := &file_notifications_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadByCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadByCategoryRequest) ProtoMessage() {}
+
+func (x *MarkReadByCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadByCategoryRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadByCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MarkReadByCategoryRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *MarkReadByCategoryRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+// Request to delete notification
+type DeleteNotificationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId int64                  `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	UserId         int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteNotificationRequest) Reset() {
+	*x = DeleteNotificationRequest{}
+	mi := &file_notifications_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNotificationRequest) ProtoMessage() {}
+
+func (x *DeleteNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNotificationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteNotificationRequest) GetNotificationId() int64 {
+	if x != nil {
+		return x.NotificationId
+	}
+	return 0
+}
+
+func (x *DeleteNotificationRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Notification preferences
+type NotificationPreferences struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Preferences   map[string]bool        `protobuf:"bytes,2,rep,name=preferences,proto3" json:"preferences,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // map of notification type to enabled status
+	Categories    map[string]bool        `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`   // categories the user has set, a type setting overrides its category
+	Mutes         []*NotificationMute    `protobuf:"bytes,4,rep,name=mutes,proto3" json:"mutes,omitempty"`                                                                                        // active mutes
+	EmailMode     string                 `protobuf:"bytes,5,opt,name=email_mode,json=emailMode,proto3" json:"email_mode,omitempty"`                                                               // off, immediate, daily or weekly
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationPreferences) Reset() {
+	*x = NotificationPreferences{}
+	mi := &file_notifications_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferences) ProtoMessage() {}
+
+func (x *NotificationPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferences.ProtoReflect.Descriptor instead.
+func (*NotificationPreferences) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NotificationPreferences) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *NotificationPreferences) GetPreferences() map[string]bool {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+func (x *NotificationPreferences) GetCategories() map[string]bool {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *NotificationPreferences) GetMutes() []*NotificationMute {
+	if x != nil {
+		return x.Mutes
+	}
+	return nil
+}
+
+func (x *NotificationPreferences) GetEmailMode() string {
+	if x != nil {
+		return x.EmailMode
+	}
+	return ""
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Preferences   map[string]bool        `protobuf:"bytes,2,rep,name=preferences,proto3" json:"preferences,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // notification type to enabled status
+	Categories    map[string]bool        `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`   // notification category to enabled status
+	EmailMode     string                 `protobuf:"bytes,4,opt,name=email_mode,json=emailMode,proto3" json:"email_mode,omitempty"`                                                               // off, immediate, daily or weekly, empty keeps the current mode
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserNotificationsResponse) Reset() {
-	*x = GetUserNotificationsResponse{}
-	mi := &file_notifications_proto_msgTypes[7]
+func (x *UpdateNotificationPreferencesRequest) Reset() {
+	*x = UpdateNotificationPreferencesRequest{}
+	mi := &file_notifications_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserNotificationsResponse) String() string {
+func (x *UpdateNotificationPreferencesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserNotificationsResponse) ProtoMessage() {}
+func (*UpdateNotificationPreferencesRequest) ProtoMessage() {}
 
-func (x *GetUserNotificationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[7]
+func (x *UpdateNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -851,56 +1465,63 @@ func (x *GetUserNotificationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserNotificationsResponse.ProtoReflect.Descriptor instead.
-func (*GetUserNotificationsResponse) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use UpdateNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *GetUserNotificationsResponse) GetNotifications() []*Notification {
+func (x *UpdateNotificationPreferencesRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Notifications
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetPreferences() map[string]bool {
+	if x != nil {
+		return x.Preferences
 	}
 	return nil
 }
 
-func (x *GetUserNotificationsResponse) GetTotalCount() int32 {
+func (x *UpdateNotificationPreferencesRequest) GetCategories() map[string]bool {
 	if x != nil {
-		return x.TotalCount
+		return x.Categories
 	}
-	return 0
+	return nil
 }
 
-func (x *GetUserNotificationsResponse) GetUnreadCount() int32 {
+func (x *UpdateNotificationPreferencesRequest) GetEmailMode() string {
 	if x != nil {
-		return x.UnreadCount
+		return x.EmailMode
 	}
-	return 0
+	return ""
 }
 
-// Request to mark notification as read
-type MarkNotificationAsReadRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	NotificationId int64                  `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
-	UserId         int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type NotificationMute struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetType    string                 `protobuf:"bytes,1,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"` // group, conversation or user
+	TargetId      int64                  `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	MutedUntil    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=muted_until,json=mutedUntil,proto3" json:"muted_until,omitempty"` // unset until unmuted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MarkNotificationAsReadRequest) Reset() {
-	*x = MarkNotificationAsReadRequest{}
-	mi := &file_notifications_proto_msgTypes[8]
+func (x *NotificationMute) Reset() {
+	*x = NotificationMute{}
+	mi := &file_notifications_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MarkNotificationAsReadRequest) String() string {
+func (x *NotificationMute) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MarkNotificationAsReadRequest) ProtoMessage() {}
+func (*NotificationMute) ProtoMessage() {}
 
-func (x *MarkNotificationAsReadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[8]
+func (x *NotificationMute) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -911,49 +1532,57 @@ func (x *MarkNotificationAsReadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MarkNotificationAsReadRequest.ProtoReflect.Descriptor instead.
-func (*MarkNotificationAsReadRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use NotificationMute.ProtoReflect.Descriptor instead.
+func (*NotificationMute) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *MarkNotificationAsReadRequest) GetNotificationId() int64 {
+func (x *NotificationMute) GetTargetType() string {
 	if x != nil {
-		return x.NotificationId
+		return x.TargetType
 	}
-	return 0
+	return ""
 }
 
-func (x *MarkNotificationAsReadRequest) GetUserId() int64 {
+func (x *NotificationMute) GetTargetId() int64 {
 	if x != nil {
-		return x.UserId
+		return x.TargetId
 	}
 	return 0
 }
 
-// Request to mark notification as acted
-type MarkNotificationAsActedRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	NotificationId int64                  `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
-	UserId         int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *NotificationMute) GetMutedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MutedUntil
+	}
+	return nil
 }
 
-func (x *MarkNotificationAsActedRequest) Reset() {
-	*x = MarkNotificationAsActedRequest{}
-	mi := &file_notifications_proto_msgTypes[9]
+type MuteNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetType    string                 `protobuf:"bytes,2,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"` // group, conversation or user
+	TargetId      int64                  `protobuf:"varint,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	MutedUntil    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=muted_until,json=mutedUntil,proto3" json:"muted_until,omitempty"` // optional, unset mutes until unmuted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuteNotificationsRequest) Reset() {
+	*x = MuteNotificationsRequest{}
+	mi := &file_notifications_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MarkNotificationAsActedRequest) String() string {
+func (x *MuteNotificationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MarkNotificationAsActedRequest) ProtoMessage() {}
+func (*MuteNotificationsRequest) ProtoMessage() {}
 
-func (x *MarkNotificationAsActedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[9]
+func (x *MuteNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -964,49 +1593,63 @@ func (x *MarkNotificationAsActedRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MarkNotificationAsActedRequest.ProtoReflect.Descriptor instead.
-func (*MarkNotificationAsActedRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use MuteNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*MuteNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *MarkNotificationAsActedRequest) GetNotificationId() int64 {
+func (x *MuteNotificationsRequest) GetUserId() int64 {
 	if x != nil {
-		return x.NotificationId
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *MarkNotificationAsActedRequest) GetUserId() int64 {
+func (x *MuteNotificationsRequest) GetTargetType() string {
 	if x != nil {
-		return x.UserId
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *MuteNotificationsRequest) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
 	}
 	return 0
 }
 
-// Request to delete notification
-type DeleteNotificationRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	NotificationId int64                  `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
-	UserId         int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *MuteNotificationsRequest) GetMutedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MutedUntil
+	}
+	return nil
 }
 
-func (x *DeleteNotificationRequest) Reset() {
-	*x = DeleteNotificationRequest{}
-	mi := &file_notifications_proto_msgTypes[10]
+type UnmuteNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetType    string                 `protobuf:"bytes,2,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`
+	TargetId      int64                  `protobuf:"varint,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnmuteNotificationsRequest) Reset() {
+	*x = UnmuteNotificationsRequest{}
+	mi := &file_notifications_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteNotificationRequest) String() string {
+func (x *UnmuteNotificationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteNotificationRequest) ProtoMessage() {}
+func (*UnmuteNotificationsRequest) ProtoMessage() {}
 
-func (x *DeleteNotificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[10]
+func (x *UnmuteNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1017,49 +1660,103 @@ func (x *DeleteNotificationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteNotificationRequest.ProtoReflect.Descriptor instead.
-func (*DeleteNotificationRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use UnmuteNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*UnmuteNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *DeleteNotificationRequest) GetNotificationId() int64 {
+func (x *UnmuteNotificationsRequest) GetUserId() int64 {
 	if x != nil {
-		return x.NotificationId
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *DeleteNotificationRequest) GetUserId() int64 {
+func (x *UnmuteNotificationsRequest) GetTargetType() string {
 	if x != nil {
-		return x.UserId
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *UnmuteNotificationsRequest) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
 	}
 	return 0
 }
 
-// Notification preferences
-type NotificationPreferences struct {
+type UnsubscribeEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // from the unsubscribe link
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsubscribeEmailRequest) Reset() {
+	*x = UnsubscribeEmailRequest{}
+	mi := &file_notifications_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeEmailRequest) ProtoMessage() {}
+
+func (x *UnsubscribeEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeEmailRequest.ProtoReflect.Descriptor instead.
+func (*UnsubscribeEmailRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UnsubscribeEmailRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// A browser PushSubscription, as given by PushSubscription.toJSON()
+type RegisterPushSubscriptionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Preferences   map[string]bool        `protobuf:"bytes,2,rep,name=preferences,proto3" json:"preferences,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // map of notification type to enabled status
+	Endpoint      string                 `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`                    // https url of the browser's push service
+	P256Dh        string                 `protobuf:"bytes,3,opt,name=p256dh,proto3" json:"p256dh,omitempty"`                        // browser public key, base64url
+	Auth          string                 `protobuf:"bytes,4,opt,name=auth,proto3" json:"auth,omitempty"`                            // auth secret, base64url
+	UserAgent     string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"` // optional, to tell the user's browsers apart
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NotificationPreferences) Reset() {
-	*x = NotificationPreferences{}
-	mi := &file_notifications_proto_msgTypes[11]
+func (x *RegisterPushSubscriptionRequest) Reset() {
+	*x = RegisterPushSubscriptionRequest{}
+	mi := &file_notifications_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NotificationPreferences) String() string {
+func (x *RegisterPushSubscriptionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NotificationPreferences) ProtoMessage() {}
+func (*RegisterPushSubscriptionRequest) ProtoMessage() {}
 
-func (x *NotificationPreferences) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[11]
+func (x *RegisterPushSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1070,48 +1767,69 @@ func (x *NotificationPreferences) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NotificationPreferences.ProtoReflect.Descriptor instead.
-func (*NotificationPreferences) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use RegisterPushSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*RegisterPushSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *NotificationPreferences) GetUserId() int64 {
+func (x *RegisterPushSubscriptionRequest) GetUserId() int64 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *NotificationPreferences) GetPreferences() map[string]bool {
+func (x *RegisterPushSubscriptionRequest) GetEndpoint() string {
 	if x != nil {
-		return x.Preferences
+		return x.Endpoint
 	}
-	return nil
+	return ""
 }
 
-type UpdateNotificationPreferencesRequest struct {
+func (x *RegisterPushSubscriptionRequest) GetP256Dh() string {
+	if x != nil {
+		return x.P256Dh
+	}
+	return ""
+}
+
+func (x *RegisterPushSubscriptionRequest) GetAuth() string {
+	if x != nil {
+		return x.Auth
+	}
+	return ""
+}
+
+func (x *RegisterPushSubscriptionRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+type UnregisterPushSubscriptionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Preferences   map[string]bool        `protobuf:"bytes,2,rep,name=preferences,proto3" json:"preferences,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Endpoint      string                 `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateNotificationPreferencesRequest) Reset() {
-	*x = UpdateNotificationPreferencesRequest{}
-	mi := &file_notifications_proto_msgTypes[12]
+func (x *UnregisterPushSubscriptionRequest) Reset() {
+	*x = UnregisterPushSubscriptionRequest{}
+	mi := &file_notifications_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateNotificationPreferencesRequest) String() string {
+func (x *UnregisterPushSubscriptionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateNotificationPreferencesRequest) ProtoMessage() {}
+func (*UnregisterPushSubscriptionRequest) ProtoMessage() {}
 
-func (x *UpdateNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[12]
+func (x *UnregisterPushSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1122,23 +1840,23 @@ func (x *UpdateNotificationPreferencesRequest) ProtoReflect() protoreflect.Messa
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
-func (*UpdateNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use UnregisterPushSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*UnregisterPushSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *UpdateNotificationPreferencesRequest) GetUserId() int64 {
+func (x *UnregisterPushSubscriptionRequest) GetUserId() int64 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *UpdateNotificationPreferencesRequest) GetPreferences() map[string]bool {
+func (x *UnregisterPushSubscriptionRequest) GetEndpoint() string {
 	if x != nil {
-		return x.Preferences
+		return x.Endpoint
 	}
-	return nil
+	return ""
 }
 
 // Specific notification request messages
@@ -1154,7 +1872,7 @@ type CreateFollowRequestRequest struct {
 
 func (x *CreateFollowRequestRequest) Reset() {
 	*x = CreateFollowRequestRequest{}
-	mi := &file_notifications_proto_msgTypes[13]
+	mi := &file_notifications_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1166,7 +1884,7 @@ func (x *CreateFollowRequestRequest) String() string {
 func (*CreateFollowRequestRequest) ProtoMessage() {}
 
 func (x *CreateFollowRequestRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[13]
+	mi := &file_notifications_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1179,7 +1897,7 @@ func (x *CreateFollowRequestRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateFollowRequestRequest.ProtoReflect.Descriptor instead.
 func (*CreateFollowRequestRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{13}
+	return file_notifications_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *CreateFollowRequestRequest) GetTargetUserId() int64 {
@@ -1216,7 +1934,7 @@ type CreateNewFollowerRequest struct {
 
 func (x *CreateNewFollowerRequest) Reset() {
 	*x = CreateNewFollowerRequest{}
-	mi := &file_notifications_proto_msgTypes[14]
+	mi := &file_notifications_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1228,7 +1946,7 @@ func (x *CreateNewFollowerRequest) String() string {
 func (*CreateNewFollowerRequest) ProtoMessage() {}
 
 func (x *CreateNewFollowerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[14]
+	mi := &file_notifications_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1241,7 +1959,7 @@ func (x *CreateNewFollowerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateNewFollowerRequest.ProtoReflect.Descriptor instead.
 func (*CreateNewFollowerRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{14}
+	return file_notifications_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *CreateNewFollowerRequest) GetTargetUserId() int64 {
@@ -1286,7 +2004,7 @@ type CreateGroupInviteRequest struct {
 
 func (x *CreateGroupInviteRequest) Reset() {
 	*x = CreateGroupInviteRequest{}
-	mi := &file_notifications_proto_msgTypes[15]
+	mi := &file_notifications_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1298,7 +2016,7 @@ func (x *CreateGroupInviteRequest) String() string {
 func (*CreateGroupInviteRequest) ProtoMessage() {}
 
 func (x *CreateGroupInviteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[15]
+	mi := &file_notifications_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1311,7 +2029,7 @@ func (x *CreateGroupInviteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateGroupInviteRequest.ProtoReflect.Descriptor instead.
 func (*CreateGroupInviteRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{15}
+	return file_notifications_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *CreateGroupInviteRequest) GetInvitedUserId() int64 {
@@ -1363,7 +2081,7 @@ type CreateGroupInviteForMultipleUsersRequest struct {
 
 func (x *CreateGroupInviteForMultipleUsersRequest) Reset() {
 	*x = CreateGroupInviteForMultipleUsersRequest{}
-	mi := &file_notifications_proto_msgTypes[16]
+	mi := &file_notifications_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1375,7 +2093,7 @@ func (x *CreateGroupInviteForMultipleUsersRequest) String() string {
 func (*CreateGroupInviteForMultipleUsersRequest) ProtoMessage() {}
 
 func (x *CreateGroupInviteForMultipleUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[16]
+	mi := &file_notifications_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1388,7 +2106,7 @@ func (x *CreateGroupInviteForMultipleUsersRequest) ProtoReflect() protoreflect.M
 
 // Deprecated: Use CreateGroupInviteForMultipleUsersRequest.ProtoReflect.Descriptor instead.
 func (*CreateGroupInviteForMultipleUsersRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{16}
+	return file_notifications_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *CreateGroupInviteForMultipleUsersRequest) GetInvitedUserIds() []int64 {
@@ -1436,7 +2154,7 @@ type CreateGroupInviteForMultipleUsersResponse struct {
 
 func (x *CreateGroupInviteForMultipleUsersResponse) Reset() {
 	*x = CreateGroupInviteForMultipleUsersResponse{}
-	mi := &file_notifications_proto_msgTypes[17]
+	mi := &file_notifications_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1448,7 +2166,7 @@ func (x *CreateGroupInviteForMultipleUsersResponse) String() string {
 func (*CreateGroupInviteForMultipleUsersResponse) ProtoMessage() {}
 
 func (x *CreateGroupInviteForMultipleUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[17]
+	mi := &file_notifications_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1461,7 +2179,7 @@ func (x *CreateGroupInviteForMultipleUsersResponse) ProtoReflect() protoreflect.
 
 // Deprecated: Use CreateGroupInviteForMultipleUsersResponse.ProtoReflect.Descriptor instead.
 func (*CreateGroupInviteForMultipleUsersResponse) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{17}
+	return file_notifications_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *CreateGroupInviteForMultipleUsersResponse) GetCreatedNotifications() []*Notification {
@@ -1485,7 +2203,7 @@ type CreateGroupJoinRequestRequest struct {
 
 func (x *CreateGroupJoinRequestRequest) Reset() {
 	*x = CreateGroupJoinRequestRequest{}
-	mi := &file_notifications_proto_msgTypes[18]
+	mi := &file_notifications_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1497,7 +2215,7 @@ func (x *CreateGroupJoinRequestRequest) String() string {
 func (*CreateGroupJoinRequestRequest) ProtoMessage() {}
 
 func (x *CreateGroupJoinRequestRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[18]
+	mi := &file_notifications_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1510,7 +2228,7 @@ func (x *CreateGroupJoinRequestRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateGroupJoinRequestRequest.ProtoReflect.Descriptor instead.
 func (*CreateGroupJoinRequestRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{18}
+	return file_notifications_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *CreateGroupJoinRequestRequest) GetGroupOwnerId() int64 {
@@ -1563,7 +2281,7 @@ type CreateNewEventRequest struct {
 
 func (x *CreateNewEventRequest) Reset() {
 	*x = CreateNewEventRequest{}
-	mi := &file_notifications_proto_msgTypes[19]
+	mi := &file_notifications_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1575,7 +2293,7 @@ func (x *CreateNewEventRequest) String() string {
 func (*CreateNewEventRequest) ProtoMessage() {}
 
 func (x *CreateNewEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[19]
+	mi := &file_notifications_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1588,7 +2306,7 @@ func (x *CreateNewEventRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateNewEventRequest.ProtoReflect.Descriptor instead.
 func (*CreateNewEventRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{19}
+	return file_notifications_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *CreateNewEventRequest) GetUserId() int64 {
@@ -1647,7 +2365,7 @@ type CreatePostLikeRequest struct {
 
 func (x *CreatePostLikeRequest) Reset() {
 	*x = CreatePostLikeRequest{}
-	mi := &file_notifications_proto_msgTypes[20]
+	mi := &file_notifications_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1659,7 +2377,7 @@ func (x *CreatePostLikeRequest) String() string {
 func (*CreatePostLikeRequest) ProtoMessage() {}
 
 func (x *CreatePostLikeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[20]
+	mi := &file_notifications_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1672,7 +2390,7 @@ func (x *CreatePostLikeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePostLikeRequest.ProtoReflect.Descriptor instead.
 func (*CreatePostLikeRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{20}
+	return file_notifications_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *CreatePostLikeRequest) GetUserId() int64 {
@@ -1725,7 +2443,7 @@ type CreatePostCommentRequest struct {
 
 func (x *CreatePostCommentRequest) Reset() {
 	*x = CreatePostCommentRequest{}
-	mi := &file_notifications_proto_msgTypes[21]
+	mi := &file_notifications_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1737,7 +2455,7 @@ func (x *CreatePostCommentRequest) String() string {
 func (*CreatePostCommentRequest) ProtoMessage() {}
 
 func (x *CreatePostCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[21]
+	mi := &file_notifications_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1750,7 +2468,7 @@ func (x *CreatePostCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePostCommentRequest.ProtoReflect.Descriptor instead.
 func (*CreatePostCommentRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{21}
+	return file_notifications_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *CreatePostCommentRequest) GetUserId() int64 {
@@ -1810,7 +2528,7 @@ type CreateMentionRequest struct {
 
 func (x *CreateMentionRequest) Reset() {
 	*x = CreateMentionRequest{}
-	mi := &file_notifications_proto_msgTypes[22]
+	mi := &file_notifications_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1822,7 +2540,7 @@ func (x *CreateMentionRequest) String() string {
 func (*CreateMentionRequest) ProtoMessage() {}
 
 func (x *CreateMentionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[22]
+	mi := &file_notifications_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1835,7 +2553,7 @@ func (x *CreateMentionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateMentionRequest.ProtoReflect.Descriptor instead.
 func (*CreateMentionRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{22}
+	return file_notifications_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *CreateMentionRequest) GetUserId() int64 {
@@ -1895,7 +2613,7 @@ type CreateNewMessageRequest struct {
 
 func (x *CreateNewMessageRequest) Reset() {
 	*x = CreateNewMessageRequest{}
-	mi := &file_notifications_proto_msgTypes[23]
+	mi := &file_notifications_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1907,7 +2625,7 @@ func (x *CreateNewMessageRequest) String() string {
 func (*CreateNewMessageRequest) ProtoMessage() {}
 
 func (x *CreateNewMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[23]
+	mi := &file_notifications_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1920,7 +2638,7 @@ func (x *CreateNewMessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateNewMessageRequest.ProtoReflect.Descriptor instead.
 func (*CreateNewMessageRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{23}
+	return file_notifications_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *CreateNewMessageRequest) GetUserId() int64 {
@@ -1980,7 +2698,7 @@ type CreateNewMessageForMultipleUsersRequest struct {
 
 func (x *CreateNewMessageForMultipleUsersRequest) Reset() {
 	*x = CreateNewMessageForMultipleUsersRequest{}
-	mi := &file_notifications_proto_msgTypes[24]
+	mi := &file_notifications_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1992,7 +2710,7 @@ func (x *CreateNewMessageForMultipleUsersRequest) String() string {
 func (*CreateNewMessageForMultipleUsersRequest) ProtoMessage() {}
 
 func (x *CreateNewMessageForMultipleUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[24]
+	mi := &file_notifications_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2005,7 +2723,7 @@ func (x *CreateNewMessageForMultipleUsersRequest) ProtoReflect() protoreflect.Me
 
 // Deprecated: Use CreateNewMessageForMultipleUsersRequest.ProtoReflect.Descriptor instead.
 func (*CreateNewMessageForMultipleUsersRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{24}
+	return file_notifications_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *CreateNewMessageForMultipleUsersRequest) GetUserIds() []int64 {
@@ -2060,7 +2778,7 @@ type CreateNewMessageForMultipleUsersResponse struct {
 
 func (x *CreateNewMessageForMultipleUsersResponse) Reset() {
 	*x = CreateNewMessageForMultipleUsersResponse{}
-	mi := &file_notifications_proto_msgTypes[25]
+	mi := &file_notifications_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2072,7 +2790,7 @@ func (x *CreateNewMessageForMultipleUsersResponse) String() string {
 func (*CreateNewMessageForMultipleUsersResponse) ProtoMessage() {}
 
 func (x *CreateNewMessageForMultipleUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[25]
+	mi := &file_notifications_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2085,7 +2803,7 @@ func (x *CreateNewMessageForMultipleUsersResponse) ProtoReflect() protoreflect.M
 
 // Deprecated: Use CreateNewMessageForMultipleUsersResponse.ProtoReflect.Descriptor instead.
 func (*CreateNewMessageForMultipleUsersResponse) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{25}
+	return file_notifications_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *CreateNewMessageForMultipleUsersResponse) GetCreatedNotifications() []*Notification {
@@ -2107,7 +2825,7 @@ type CreateFollowRequestAcceptedRequest struct {
 
 func (x *CreateFollowRequestAcceptedRequest) Reset() {
 	*x = CreateFollowRequestAcceptedRequest{}
-	mi := &file_notifications_proto_msgTypes[26]
+	mi := &file_notifications_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2119,7 +2837,7 @@ func (x *CreateFollowRequestAcceptedRequest) String() string {
 func (*CreateFollowRequestAcceptedRequest) ProtoMessage() {}
 
 func (x *CreateFollowRequestAcceptedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[26]
+	mi := &file_notifications_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2132,7 +2850,7 @@ func (x *CreateFollowRequestAcceptedRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use CreateFollowRequestAcceptedRequest.ProtoReflect.Descriptor instead.
 func (*CreateFollowRequestAcceptedRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{26}
+	return file_notifications_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *CreateFollowRequestAcceptedRequest) GetRequesterUserId() int64 {
@@ -2168,7 +2886,7 @@ type CreateFollowRequestRejectedRequest struct {
 
 func (x *CreateFollowRequestRejectedRequest) Reset() {
 	*x = CreateFollowRequestRejectedRequest{}
-	mi := &file_notifications_proto_msgTypes[27]
+	mi := &file_notifications_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2180,7 +2898,7 @@ func (x *CreateFollowRequestRejectedRequest) String() string {
 func (*CreateFollowRequestRejectedRequest) ProtoMessage() {}
 
 func (x *CreateFollowRequestRejectedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[27]
+	mi := &file_notifications_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2193,7 +2911,7 @@ func (x *CreateFollowRequestRejectedRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use CreateFollowRequestRejectedRequest.ProtoReflect.Descriptor instead.
 func (*CreateFollowRequestRejectedRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{27}
+	return file_notifications_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *CreateFollowRequestRejectedRequest) GetRequesterUserId() int64 {
@@ -2231,7 +2949,7 @@ type CreateGroupInviteAcceptedRequest struct {
 
 func (x *CreateGroupInviteAcceptedRequest) Reset() {
 	*x = CreateGroupInviteAcceptedRequest{}
-	mi := &file_notifications_proto_msgTypes[28]
+	mi := &file_notifications_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2243,7 +2961,7 @@ func (x *CreateGroupInviteAcceptedRequest) String() string {
 func (*CreateGroupInviteAcceptedRequest) ProtoMessage() {}
 
 func (x *CreateGroupInviteAcceptedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[28]
+	mi := &file_notifications_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2256,7 +2974,7 @@ func (x *CreateGroupInviteAcceptedRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateGroupInviteAcceptedRequest.ProtoReflect.Descriptor instead.
 func (*CreateGroupInviteAcceptedRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{28}
+	return file_notifications_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *CreateGroupInviteAcceptedRequest) GetInviterUserId() int64 {
@@ -2308,7 +3026,7 @@ type CreateGroupInviteRejectedRequest struct {
 
 func (x *CreateGroupInviteRejectedRequest) Reset() {
 	*x = CreateGroupInviteRejectedRequest{}
-	mi := &file_notifications_proto_msgTypes[29]
+	mi := &file_notifications_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2320,7 +3038,7 @@ func (x *CreateGroupInviteRejectedRequest) String() string {
 func (*CreateGroupInviteRejectedRequest) ProtoMessage() {}
 
 func (x *CreateGroupInviteRejectedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[29]
+	mi := &file_notifications_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2333,7 +3051,7 @@ func (x *CreateGroupInviteRejectedRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateGroupInviteRejectedRequest.ProtoReflect.Descriptor instead.
 func (*CreateGroupInviteRejectedRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{29}
+	return file_notifications_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *CreateGroupInviteRejectedRequest) GetInviterUserId() int64 {
@@ -2384,7 +3102,7 @@ type CreateGroupJoinRequestAcceptedRequest struct {
 
 func (x *CreateGroupJoinRequestAcceptedRequest) Reset() {
 	*x = CreateGroupJoinRequestAcceptedRequest{}
-	mi := &file_notifications_proto_msgTypes[30]
+	mi := &file_notifications_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2396,7 +3114,7 @@ func (x *CreateGroupJoinRequestAcceptedRequest) String() string {
 func (*CreateGroupJoinRequestAcceptedRequest) ProtoMessage() {}
 
 func (x *CreateGroupJoinRequestAcceptedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[30]
+	mi := &file_notifications_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2409,63 +3127,224 @@ func (x *CreateGroupJoinRequestAcceptedRequest) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use CreateGroupJoinRequestAcceptedRequest.ProtoReflect.Descriptor instead.
 func (*CreateGroupJoinRequestAcceptedRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{30}
+	return file_notifications_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *CreateGroupJoinRequestAcceptedRequest) GetRequesterUserId() int64 {
 	if x != nil {
-		return x.RequesterUserId
+		return x.RequesterUserId
+	}
+	return 0
+}
+
+func (x *CreateGroupJoinRequestAcceptedRequest) GetGroupOwnerId() int64 {
+	if x != nil {
+		return x.GroupOwnerId
+	}
+	return 0
+}
+
+func (x *CreateGroupJoinRequestAcceptedRequest) GetGroupId() int64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *CreateGroupJoinRequestAcceptedRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+// Request to create a group join request rejected notification
+type CreateGroupJoinRequestRejectedRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RequesterUserId int64                  `protobuf:"varint,1,opt,name=requester_user_id,json=requesterUserId,proto3" json:"requester_user_id,omitempty"` // recipient (original requester)
+	GroupOwnerId    int64                  `protobuf:"varint,2,opt,name=group_owner_id,json=groupOwnerId,proto3" json:"group_owner_id,omitempty"`          // user that rejected the request (group owner/admin)
+	GroupId         int64                  `protobuf:"varint,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`                           // id of the group
+	GroupName       string                 `protobuf:"bytes,4,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`                      // name of the group
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateGroupJoinRequestRejectedRequest) Reset() {
+	*x = CreateGroupJoinRequestRejectedRequest{}
+	mi := &file_notifications_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGroupJoinRequestRejectedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupJoinRequestRejectedRequest) ProtoMessage() {}
+
+func (x *CreateGroupJoinRequestRejectedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupJoinRequestRejectedRequest.ProtoReflect.Descriptor instead.
+func (*CreateGroupJoinRequestRejectedRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CreateGroupJoinRequestRejectedRequest) GetRequesterUserId() int64 {
+	if x != nil {
+		return x.RequesterUserId
+	}
+	return 0
+}
+
+func (x *CreateGroupJoinRequestRejectedRequest) GetGroupOwnerId() int64 {
+	if x != nil {
+		return x.GroupOwnerId
+	}
+	return 0
+}
+
+func (x *CreateGroupJoinRequestRejectedRequest) GetGroupId() int64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *CreateGroupJoinRequestRejectedRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+// Specific event payload messages
+type PostCommentCreated struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PostCreatorId     int64                  `protobuf:"varint,1,opt,name=post_creator_id,json=postCreatorId,proto3" json:"post_creator_id,omitempty"`
+	PostId            int64                  `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	CommentId         int64                  `protobuf:"varint,3,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	CommenterUserId   int64                  `protobuf:"varint,4,opt,name=commenter_user_id,json=commenterUserId,proto3" json:"commenter_user_id,omitempty"`
+	CommenterUsername string                 `protobuf:"bytes,5,opt,name=commenter_username,json=commenterUsername,proto3" json:"commenter_username,omitempty"`
+	Body              string                 `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	Aggregate         bool                   `protobuf:"varint,7,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PostCommentCreated) Reset() {
+	*x = PostCommentCreated{}
+	mi := &file_notifications_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostCommentCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostCommentCreated) ProtoMessage() {}
+
+func (x *PostCommentCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostCommentCreated.ProtoReflect.Descriptor instead.
+func (*PostCommentCreated) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PostCommentCreated) GetPostCreatorId() int64 {
+	if x != nil {
+		return x.PostCreatorId
+	}
+	return 0
+}
+
+func (x *PostCommentCreated) GetPostId() int64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *PostCommentCreated) GetCommentId() int64 {
+	if x != nil {
+		return x.CommentId
+	}
+	return 0
+}
+
+func (x *PostCommentCreated) GetCommenterUserId() int64 {
+	if x != nil {
+		return x.CommenterUserId
 	}
 	return 0
 }
 
-func (x *CreateGroupJoinRequestAcceptedRequest) GetGroupOwnerId() int64 {
+func (x *PostCommentCreated) GetCommenterUsername() string {
 	if x != nil {
-		return x.GroupOwnerId
+		return x.CommenterUsername
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateGroupJoinRequestAcceptedRequest) GetGroupId() int64 {
+func (x *PostCommentCreated) GetBody() string {
 	if x != nil {
-		return x.GroupId
+		return x.Body
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateGroupJoinRequestAcceptedRequest) GetGroupName() string {
+func (x *PostCommentCreated) GetAggregate() bool {
 	if x != nil {
-		return x.GroupName
+		return x.Aggregate
 	}
-	return ""
+	return false
 }
 
-// Request to create a group join request rejected notification
-type CreateGroupJoinRequestRejectedRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	RequesterUserId int64                  `protobuf:"varint,1,opt,name=requester_user_id,json=requesterUserId,proto3" json:"requester_user_id,omitempty"` // recipient (original requester)
-	GroupOwnerId    int64                  `protobuf:"varint,2,opt,name=group_owner_id,json=groupOwnerId,proto3" json:"group_owner_id,omitempty"`          // user that rejected the request (group owner/admin)
-	GroupId         int64                  `protobuf:"varint,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`                           // id of the group
-	GroupName       string                 `protobuf:"bytes,4,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`                      // name of the group
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+// Sent when a scheduled post goes live
+type PostPublished struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostCreatorId int64                  `protobuf:"varint,1,opt,name=post_creator_id,json=postCreatorId,proto3" json:"post_creator_id,omitempty"`
+	PostId        int64                  `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateGroupJoinRequestRejectedRequest) Reset() {
-	*x = CreateGroupJoinRequestRejectedRequest{}
-	mi := &file_notifications_proto_msgTypes[31]
+func (x *PostPublished) Reset() {
+	*x = PostPublished{}
+	mi := &file_notifications_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateGroupJoinRequestRejectedRequest) String() string {
+func (x *PostPublished) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateGroupJoinRequestRejectedRequest) ProtoMessage() {}
+func (*PostPublished) ProtoMessage() {}
 
-func (x *CreateGroupJoinRequestRejectedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[31]
+func (x *PostPublished) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2476,68 +3355,60 @@ func (x *CreateGroupJoinRequestRejectedRequest) ProtoReflect() protoreflect.Mess
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateGroupJoinRequestRejectedRequest.ProtoReflect.Descriptor instead.
-func (*CreateGroupJoinRequestRejectedRequest) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{31}
-}
-
-func (x *CreateGroupJoinRequestRejectedRequest) GetRequesterUserId() int64 {
-	if x != nil {
-		return x.RequesterUserId
-	}
-	return 0
+// Deprecated: Use PostPublished.ProtoReflect.Descriptor instead.
+func (*PostPublished) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *CreateGroupJoinRequestRejectedRequest) GetGroupOwnerId() int64 {
+func (x *PostPublished) GetPostCreatorId() int64 {
 	if x != nil {
-		return x.GroupOwnerId
+		return x.PostCreatorId
 	}
 	return 0
 }
 
-func (x *CreateGroupJoinRequestRejectedRequest) GetGroupId() int64 {
+func (x *PostPublished) GetPostId() int64 {
 	if x != nil {
-		return x.GroupId
+		return x.PostId
 	}
 	return 0
 }
 
-func (x *CreateGroupJoinRequestRejectedRequest) GetGroupName() string {
+func (x *PostPublished) GetBody() string {
 	if x != nil {
-		return x.GroupName
+		return x.Body
 	}
 	return ""
 }
 
-// Specific event payload messages
-type PostCommentCreated struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	PostCreatorId     int64                  `protobuf:"varint,1,opt,name=post_creator_id,json=postCreatorId,proto3" json:"post_creator_id,omitempty"`
-	PostId            int64                  `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
-	CommentId         int64                  `protobuf:"varint,3,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
-	CommenterUserId   int64                  `protobuf:"varint,4,opt,name=commenter_user_id,json=commenterUserId,proto3" json:"commenter_user_id,omitempty"`
-	CommenterUsername string                 `protobuf:"bytes,5,opt,name=commenter_username,json=commenterUsername,proto3" json:"commenter_username,omitempty"`
-	Body              string                 `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
-	Aggregate         bool                   `protobuf:"varint,7,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// Sent when someone shares a user's post
+type PostShared struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PostCreatorId  int64                  `protobuf:"varint,1,opt,name=post_creator_id,json=postCreatorId,proto3" json:"post_creator_id,omitempty"`
+	PostId         int64                  `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	ShareId        int64                  `protobuf:"varint,3,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	SharerUserId   int64                  `protobuf:"varint,4,opt,name=sharer_user_id,json=sharerUserId,proto3" json:"sharer_user_id,omitempty"`
+	SharerUsername string                 `protobuf:"bytes,5,opt,name=sharer_username,json=sharerUsername,proto3" json:"sharer_username,omitempty"`
+	Body           string                 `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"` // quote text, can be empty
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *PostCommentCreated) Reset() {
-	*x = PostCommentCreated{}
-	mi := &file_notifications_proto_msgTypes[32]
+func (x *PostShared) Reset() {
+	*x = PostShared{}
+	mi := &file_notifications_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PostCommentCreated) String() string {
+func (x *PostShared) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostCommentCreated) ProtoMessage() {}
+func (*PostShared) ProtoMessage() {}
 
-func (x *PostCommentCreated) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[32]
+func (x *PostShared) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2548,60 +3419,53 @@ func (x *PostCommentCreated) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostCommentCreated.ProtoReflect.Descriptor instead.
-func (*PostCommentCreated) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use PostShared.ProtoReflect.Descriptor instead.
+func (*PostShared) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *PostCommentCreated) GetPostCreatorId() int64 {
+func (x *PostShared) GetPostCreatorId() int64 {
 	if x != nil {
 		return x.PostCreatorId
 	}
 	return 0
 }
 
-func (x *PostCommentCreated) GetPostId() int64 {
+func (x *PostShared) GetPostId() int64 {
 	if x != nil {
 		return x.PostId
 	}
 	return 0
 }
 
-func (x *PostCommentCreated) GetCommentId() int64 {
+func (x *PostShared) GetShareId() int64 {
 	if x != nil {
-		return x.CommentId
+		return x.ShareId
 	}
 	return 0
 }
 
-func (x *PostCommentCreated) GetCommenterUserId() int64 {
+func (x *PostShared) GetSharerUserId() int64 {
 	if x != nil {
-		return x.CommenterUserId
+		return x.SharerUserId
 	}
 	return 0
 }
 
-func (x *PostCommentCreated) GetCommenterUsername() string {
+func (x *PostShared) GetSharerUsername() string {
 	if x != nil {
-		return x.CommenterUsername
+		return x.SharerUsername
 	}
 	return ""
 }
 
-func (x *PostCommentCreated) GetBody() string {
+func (x *PostShared) GetBody() string {
 	if x != nil {
 		return x.Body
 	}
 	return ""
 }
 
-func (x *PostCommentCreated) GetAggregate() bool {
-	if x != nil {
-		return x.Aggregate
-	}
-	return false
-}
-
 type PostLiked struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	EntityCreatorId int64                  `protobuf:"varint,1,opt,name=entity_creator_id,json=entityCreatorId,proto3" json:"entity_creator_id,omitempty"`
@@ -2615,7 +3479,7 @@ type PostLiked struct {
 
 func (x *PostLiked) Reset() {
 	*x = PostLiked{}
-	mi := &file_notifications_proto_msgTypes[33]
+	mi := &file_notifications_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2627,7 +3491,7 @@ func (x *PostLiked) String() string {
 func (*PostLiked) ProtoMessage() {}
 
 func (x *PostLiked) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[33]
+	mi := &file_notifications_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2640,7 +3504,7 @@ func (x *PostLiked) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostLiked.ProtoReflect.Descriptor instead.
 func (*PostLiked) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{33}
+	return file_notifications_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *PostLiked) GetEntityCreatorId() int64 {
@@ -2689,7 +3553,7 @@ type FollowRequestCreated struct {
 
 func (x *FollowRequestCreated) Reset() {
 	*x = FollowRequestCreated{}
-	mi := &file_notifications_proto_msgTypes[34]
+	mi := &file_notifications_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2701,7 +3565,7 @@ func (x *FollowRequestCreated) String() string {
 func (*FollowRequestCreated) ProtoMessage() {}
 
 func (x *FollowRequestCreated) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[34]
+	mi := &file_notifications_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2714,7 +3578,7 @@ func (x *FollowRequestCreated) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FollowRequestCreated.ProtoReflect.Descriptor instead.
 func (*FollowRequestCreated) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{34}
+	return file_notifications_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *FollowRequestCreated) GetTargetUserId() int64 {
@@ -2750,7 +3614,7 @@ type NewFollowerCreated struct {
 
 func (x *NewFollowerCreated) Reset() {
 	*x = NewFollowerCreated{}
-	mi := &file_notifications_proto_msgTypes[35]
+	mi := &file_notifications_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2762,7 +3626,7 @@ func (x *NewFollowerCreated) String() string {
 func (*NewFollowerCreated) ProtoMessage() {}
 
 func (x *NewFollowerCreated) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[35]
+	mi := &file_notifications_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2775,7 +3639,7 @@ func (x *NewFollowerCreated) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewFollowerCreated.ProtoReflect.Descriptor instead.
 func (*NewFollowerCreated) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{35}
+	return file_notifications_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *NewFollowerCreated) GetTargetUserId() int64 {
@@ -2819,7 +3683,7 @@ type GroupInviteCreated struct {
 
 func (x *GroupInviteCreated) Reset() {
 	*x = GroupInviteCreated{}
-	mi := &file_notifications_proto_msgTypes[36]
+	mi := &file_notifications_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2831,7 +3695,7 @@ func (x *GroupInviteCreated) String() string {
 func (*GroupInviteCreated) ProtoMessage() {}
 
 func (x *GroupInviteCreated) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[36]
+	mi := &file_notifications_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2844,7 +3708,7 @@ func (x *GroupInviteCreated) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupInviteCreated.ProtoReflect.Descriptor instead.
 func (*GroupInviteCreated) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{36}
+	return file_notifications_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *GroupInviteCreated) GetInvitedUserId() []int64 {
@@ -2895,7 +3759,7 @@ type GroupJoinRequestCreated struct {
 
 func (x *GroupJoinRequestCreated) Reset() {
 	*x = GroupJoinRequestCreated{}
-	mi := &file_notifications_proto_msgTypes[37]
+	mi := &file_notifications_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2907,7 +3771,7 @@ func (x *GroupJoinRequestCreated) String() string {
 func (*GroupJoinRequestCreated) ProtoMessage() {}
 
 func (x *GroupJoinRequestCreated) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[37]
+	mi := &file_notifications_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2920,7 +3784,7 @@ func (x *GroupJoinRequestCreated) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupJoinRequestCreated.ProtoReflect.Descriptor instead.
 func (*GroupJoinRequestCreated) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{37}
+	return file_notifications_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *GroupJoinRequestCreated) GetGroupOwnerId() int64 {
@@ -2972,7 +3836,7 @@ type NewEventCreated struct {
 
 func (x *NewEventCreated) Reset() {
 	*x = NewEventCreated{}
-	mi := &file_notifications_proto_msgTypes[38]
+	mi := &file_notifications_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2984,7 +3848,7 @@ func (x *NewEventCreated) String() string {
 func (*NewEventCreated) ProtoMessage() {}
 
 func (x *NewEventCreated) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[38]
+	mi := &file_notifications_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2997,7 +3861,7 @@ func (x *NewEventCreated) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewEventCreated.ProtoReflect.Descriptor instead.
 func (*NewEventCreated) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{38}
+	return file_notifications_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *NewEventCreated) GetUserId() []int64 {
@@ -3056,7 +3920,7 @@ type MentionCreated struct {
 
 func (x *MentionCreated) Reset() {
 	*x = MentionCreated{}
-	mi := &file_notifications_proto_msgTypes[39]
+	mi := &file_notifications_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3068,7 +3932,7 @@ func (x *MentionCreated) String() string {
 func (*MentionCreated) ProtoMessage() {}
 
 func (x *MentionCreated) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[39]
+	mi := &file_notifications_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3081,7 +3945,7 @@ func (x *MentionCreated) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MentionCreated.ProtoReflect.Descriptor instead.
 func (*MentionCreated) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{39}
+	return file_notifications_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *MentionCreated) GetMentionedUserId() int64 {
@@ -3140,7 +4004,7 @@ type NewMessageCreated struct {
 
 func (x *NewMessageCreated) Reset() {
 	*x = NewMessageCreated{}
-	mi := &file_notifications_proto_msgTypes[40]
+	mi := &file_notifications_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3152,7 +4016,7 @@ func (x *NewMessageCreated) String() string {
 func (*NewMessageCreated) ProtoMessage() {}
 
 func (x *NewMessageCreated) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[40]
+	mi := &file_notifications_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3165,7 +4029,7 @@ func (x *NewMessageCreated) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewMessageCreated.ProtoReflect.Descriptor instead.
 func (*NewMessageCreated) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{40}
+	return file_notifications_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *NewMessageCreated) GetUserId() []int64 {
@@ -3221,7 +4085,7 @@ type FollowRequestAccepted struct {
 
 func (x *FollowRequestAccepted) Reset() {
 	*x = FollowRequestAccepted{}
-	mi := &file_notifications_proto_msgTypes[41]
+	mi := &file_notifications_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3233,7 +4097,7 @@ func (x *FollowRequestAccepted) String() string {
 func (*FollowRequestAccepted) ProtoMessage() {}
 
 func (x *FollowRequestAccepted) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[41]
+	mi := &file_notifications_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3246,7 +4110,7 @@ func (x *FollowRequestAccepted) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FollowRequestAccepted.ProtoReflect.Descriptor instead.
 func (*FollowRequestAccepted) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{41}
+	return file_notifications_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *FollowRequestAccepted) GetRequesterUserId() int64 {
@@ -3281,7 +4145,7 @@ type FollowRequestRejected struct {
 
 func (x *FollowRequestRejected) Reset() {
 	*x = FollowRequestRejected{}
-	mi := &file_notifications_proto_msgTypes[42]
+	mi := &file_notifications_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3293,7 +4157,7 @@ func (x *FollowRequestRejected) String() string {
 func (*FollowRequestRejected) ProtoMessage() {}
 
 func (x *FollowRequestRejected) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[42]
+	mi := &file_notifications_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3306,7 +4170,7 @@ func (x *FollowRequestRejected) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FollowRequestRejected.ProtoReflect.Descriptor instead.
 func (*FollowRequestRejected) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{42}
+	return file_notifications_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *FollowRequestRejected) GetRequesterUserId() int64 {
@@ -3343,7 +4207,7 @@ type GroupInviteAccepted struct {
 
 func (x *GroupInviteAccepted) Reset() {
 	*x = GroupInviteAccepted{}
-	mi := &file_notifications_proto_msgTypes[43]
+	mi := &file_notifications_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3355,7 +4219,7 @@ func (x *GroupInviteAccepted) String() string {
 func (*GroupInviteAccepted) ProtoMessage() {}
 
 func (x *GroupInviteAccepted) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[43]
+	mi := &file_notifications_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3368,7 +4232,7 @@ func (x *GroupInviteAccepted) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupInviteAccepted.ProtoReflect.Descriptor instead.
 func (*GroupInviteAccepted) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{43}
+	return file_notifications_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *GroupInviteAccepted) GetInviterUserId() int64 {
@@ -3419,7 +4283,7 @@ type GroupInviteRejected struct {
 
 func (x *GroupInviteRejected) Reset() {
 	*x = GroupInviteRejected{}
-	mi := &file_notifications_proto_msgTypes[44]
+	mi := &file_notifications_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3431,7 +4295,7 @@ func (x *GroupInviteRejected) String() string {
 func (*GroupInviteRejected) ProtoMessage() {}
 
 func (x *GroupInviteRejected) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[44]
+	mi := &file_notifications_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3444,7 +4308,7 @@ func (x *GroupInviteRejected) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupInviteRejected.ProtoReflect.Descriptor instead.
 func (*GroupInviteRejected) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{44}
+	return file_notifications_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *GroupInviteRejected) GetInviterUserId() int64 {
@@ -3494,7 +4358,7 @@ type GroupJoinRequestAccepted struct {
 
 func (x *GroupJoinRequestAccepted) Reset() {
 	*x = GroupJoinRequestAccepted{}
-	mi := &file_notifications_proto_msgTypes[45]
+	mi := &file_notifications_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3506,7 +4370,7 @@ func (x *GroupJoinRequestAccepted) String() string {
 func (*GroupJoinRequestAccepted) ProtoMessage() {}
 
 func (x *GroupJoinRequestAccepted) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[45]
+	mi := &file_notifications_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3519,7 +4383,7 @@ func (x *GroupJoinRequestAccepted) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupJoinRequestAccepted.ProtoReflect.Descriptor instead.
 func (*GroupJoinRequestAccepted) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{45}
+	return file_notifications_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *GroupJoinRequestAccepted) GetRequesterUserId() int64 {
@@ -3562,7 +4426,7 @@ type GroupJoinRequestRejected struct {
 
 func (x *GroupJoinRequestRejected) Reset() {
 	*x = GroupJoinRequestRejected{}
-	mi := &file_notifications_proto_msgTypes[46]
+	mi := &file_notifications_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3574,7 +4438,7 @@ func (x *GroupJoinRequestRejected) String() string {
 func (*GroupJoinRequestRejected) ProtoMessage() {}
 
 func (x *GroupJoinRequestRejected) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[46]
+	mi := &file_notifications_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3587,7 +4451,7 @@ func (x *GroupJoinRequestRejected) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupJoinRequestRejected.ProtoReflect.Descriptor instead.
 func (*GroupJoinRequestRejected) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{46}
+	return file_notifications_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *GroupJoinRequestRejected) GetRequesterUserId() int64 {
@@ -3628,7 +4492,7 @@ type FollowRequestCancelled struct {
 
 func (x *FollowRequestCancelled) Reset() {
 	*x = FollowRequestCancelled{}
-	mi := &file_notifications_proto_msgTypes[47]
+	mi := &file_notifications_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3640,7 +4504,7 @@ func (x *FollowRequestCancelled) String() string {
 func (*FollowRequestCancelled) ProtoMessage() {}
 
 func (x *FollowRequestCancelled) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[47]
+	mi := &file_notifications_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3653,7 +4517,7 @@ func (x *FollowRequestCancelled) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FollowRequestCancelled.ProtoReflect.Descriptor instead.
 func (*FollowRequestCancelled) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{47}
+	return file_notifications_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *FollowRequestCancelled) GetTargetUserId() int64 {
@@ -3681,7 +4545,7 @@ type GroupJoinRequestCancelled struct {
 
 func (x *GroupJoinRequestCancelled) Reset() {
 	*x = GroupJoinRequestCancelled{}
-	mi := &file_notifications_proto_msgTypes[48]
+	mi := &file_notifications_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3693,7 +4557,7 @@ func (x *GroupJoinRequestCancelled) String() string {
 func (*GroupJoinRequestCancelled) ProtoMessage() {}
 
 func (x *GroupJoinRequestCancelled) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[48]
+	mi := &file_notifications_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3706,7 +4570,7 @@ func (x *GroupJoinRequestCancelled) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupJoinRequestCancelled.ProtoReflect.Descriptor instead.
 func (*GroupJoinRequestCancelled) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{48}
+	return file_notifications_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *GroupJoinRequestCancelled) GetGroupOwnerId() int64 {
@@ -3756,6 +4620,8 @@ type NotificationEvent struct {
 	//	*NotificationEvent_GroupJoinRequestRejected
 	//	*NotificationEvent_FollowRequestCancelled
 	//	*NotificationEvent_GroupJoinRequestCancelled
+	//	*NotificationEvent_PostPublished
+	//	*NotificationEvent_PostShared
 	Payload       isNotificationEvent_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -3763,7 +4629,7 @@ type NotificationEvent struct {
 
 func (x *NotificationEvent) Reset() {
 	*x = NotificationEvent{}
-	mi := &file_notifications_proto_msgTypes[49]
+	mi := &file_notifications_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3775,7 +4641,7 @@ func (x *NotificationEvent) String() string {
 func (*NotificationEvent) ProtoMessage() {}
 
 func (x *NotificationEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[49]
+	mi := &file_notifications_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3788,7 +4654,7 @@ func (x *NotificationEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NotificationEvent.ProtoReflect.Descriptor instead.
 func (*NotificationEvent) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{49}
+	return file_notifications_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *NotificationEvent) GetEventId() string {
@@ -3979,6 +4845,24 @@ func (x *NotificationEvent) GetGroupJoinRequestCancelled() *GroupJoinRequestCanc
 	return nil
 }
 
+func (x *NotificationEvent) GetPostPublished() *PostPublished {
+	if x != nil {
+		if x, ok := x.Payload.(*NotificationEvent_PostPublished); ok {
+			return x.PostPublished
+		}
+	}
+	return nil
+}
+
+func (x *NotificationEvent) GetPostShared() *PostShared {
+	if x != nil {
+		if x, ok := x.Payload.(*NotificationEvent_PostShared); ok {
+			return x.PostShared
+		}
+	}
+	return nil
+}
+
 type isNotificationEvent_Payload interface {
 	isNotificationEvent_Payload()
 }
@@ -4051,6 +4935,14 @@ type NotificationEvent_GroupJoinRequestCancelled struct {
 	GroupJoinRequestCancelled *GroupJoinRequestCancelled `protobuf:"bytes,26,opt,name=group_join_request_cancelled,json=groupJoinRequestCancelled,proto3,oneof"`
 }
 
+type NotificationEvent_PostPublished struct {
+	PostPublished *PostPublished `protobuf:"bytes,27,opt,name=post_published,json=postPublished,proto3,oneof"`
+}
+
+type NotificationEvent_PostShared struct {
+	PostShared *PostShared `protobuf:"bytes,28,opt,name=post_shared,json=postShared,proto3,oneof"`
+}
+
 func (*NotificationEvent_PostCommentCreated) isNotificationEvent_Payload() {}
 
 func (*NotificationEvent_PostLiked) isNotificationEvent_Payload() {}
@@ -4085,6 +4977,10 @@ func (*NotificationEvent_FollowRequestCancelled) isNotificationEvent_Payload() {
 
 func (*NotificationEvent_GroupJoinRequestCancelled) isNotificationEvent_Payload() {}
 
+func (*NotificationEvent_PostPublished) isNotificationEvent_Payload() {}
+
+func (*NotificationEvent_PostShared) isNotificationEvent_Payload() {}
+
 // Message for notification deletion events
 type NotificationDeletion struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -4097,7 +4993,7 @@ type NotificationDeletion struct {
 
 func (x *NotificationDeletion) Reset() {
 	*x = NotificationDeletion{}
-	mi := &file_notifications_proto_msgTypes[50]
+	mi := &file_notifications_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4109,7 +5005,7 @@ func (x *NotificationDeletion) String() string {
 func (*NotificationDeletion) ProtoMessage() {}
 
 func (x *NotificationDeletion) ProtoReflect() protoreflect.Message {
-	mi := &file_notifications_proto_msgTypes[50]
+	mi := &file_notifications_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4122,7 +5018,7 @@ func (x *NotificationDeletion) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NotificationDeletion.ProtoReflect.Descriptor instead.
 func (*NotificationDeletion) Descriptor() ([]byte, []int) {
-	return file_notifications_proto_rawDescGZIP(), []int{50}
+	return file_notifications_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *NotificationDeletion) GetNotificationId() int64 {
@@ -4146,11 +5042,80 @@ func (x *NotificationDeletion) GetDeletedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+// Published to the user's live connections when notifications are marked as read, so badges update
+type UnreadCountUpdate struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	UserId              int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UnreadCount         int64                  `protobuf:"varint,2,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`                                  // unread notifications left
+	ReadNotificationIds []int64                `protobuf:"varint,3,rep,packed,name=read_notification_ids,json=readNotificationIds,proto3" json:"read_notification_ids,omitempty"` // notifications just marked as read, empty when all were
+	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *UnreadCountUpdate) Reset() {
+	*x = UnreadCountUpdate{}
+	mi := &file_notifications_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnreadCountUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnreadCountUpdate) ProtoMessage() {}
+
+func (x *UnreadCountUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnreadCountUpdate.ProtoReflect.Descriptor instead.
+func (*UnreadCountUpdate) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *UnreadCountUpdate) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UnreadCountUpdate) GetUnreadCount() int64 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+func (x *UnreadCountUpdate) GetReadNotificationIds() []int64 {
+	if x != nil {
+		return x.ReadNotificationIds
+	}
+	return nil
+}
+
+func (x *UnreadCountUpdate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
 var File_notifications_proto protoreflect.FileDescriptor
 
 const file_notifications_proto_rawDesc = "" +
 	"\n" +
-	"\x13notifications.proto\x12\rnotifications\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1egoogle/protobuf/wrappers.proto\"\xcc\x04\n" +
+	"\x13notifications.proto\x12\rnotifications\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\fcommon.proto\"\xcc\x04\n" +
 	"\fNotification\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x12\n" +
@@ -4199,40 +5164,122 @@ const file_notifications_proto_rawDesc = "" +
 	"\vevent_title\x18\x06 \x01(\tR\n" +
 	"eventTitle\"z\n" +
 	"&CreateNewEventForMultipleUsersResponse\x12P\n" +
-	"\x15created_notifications\x18\x01 \x03(\v2\x1b.notifications.NotificationR\x14createdNotifications\"\xbc\x01\n" +
+	"\x15created_notifications\x18\x01 \x03(\v2\x1b.notifications.NotificationR\x14createdNotifications\"\x8e\x02\n" +
 	"\x1bGetUserNotificationsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x03 \x01(\x05R\x06offset\x125\n" +
 	"\x05types\x18\x04 \x03(\x0e2\x1f.notifications.NotificationTypeR\x05types\x12\x1f\n" +
 	"\vunread_only\x18\x05 \x01(\bR\n" +
-	"unreadOnly\"\xa5\x01\n" +
+	"unreadOnly\x12\x18\n" +
+	"\agrouped\x18\x06 \x01(\bR\agrouped\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\x12\x1e\n" +
+	"\n" +
+	"categories\x18\b \x03(\tR\n" +
+	"categories\"\x80\x02\n" +
 	"\x1cGetUserNotificationsResponse\x12A\n" +
 	"\rnotifications\x18\x01 \x03(\v2\x1b.notifications.NotificationR\rnotifications\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
 	"totalCount\x12!\n" +
-	"\funread_count\x18\x03 \x01(\x05R\vunreadCount\"a\n" +
+	"\funread_count\x18\x03 \x01(\x05R\vunreadCount\x128\n" +
+	"\x06groups\x18\x04 \x03(\v2 .notifications.NotificationGroupR\x06groups\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\"\xf1\x04\n" +
+	"\x11NotificationGroup\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12%\n" +
+	"\x0esource_service\x18\x02 \x01(\tR\rsourceService\x12(\n" +
+	"\x10source_entity_id\x18\x03 \x01(\x03R\x0esourceEntityId\x12)\n" +
+	"\x10notification_ids\x18\x04 \x03(\x03R\x0fnotificationIds\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x03R\x05count\x12$\n" +
+	"\x06actors\x18\x06 \x03(\v2\f.common.UserR\x06actors\x12!\n" +
+	"\fother_actors\x18\a \x01(\x03R\votherActors\x12%\n" +
+	"\x0eactors_summary\x18\b \x01(\tR\ractorsSummary\x12\x12\n" +
+	"\x04seen\x18\t \x01(\bR\x04seen\x12!\n" +
+	"\fneeds_action\x18\n" +
+	" \x01(\bR\vneedsAction\x12\x14\n" +
+	"\x05acted\x18\v \x01(\bR\x05acted\x12G\n" +
+	"\apayload\x18\f \x03(\v2-.notifications.NotificationGroup.PayloadEntryR\apayload\x127\n" +
+	"\tlatest_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\blatestAt\x12;\n" +
+	"\vearliest_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"earliestAt\x1a:\n" +
+	"\fPayloadEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"a\n" +
 	"\x1dMarkNotificationAsReadRequest\x12'\n" +
 	"\x0fnotification_id\x18\x01 \x01(\x03R\x0enotificationId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x03R\x06userId\"b\n" +
 	"\x1eMarkNotificationAsActedRequest\x12'\n" +
 	"\x0fnotification_id\x18\x01 \x01(\x03R\x0enotificationId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\x03R\x06userId\"]\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\x83\x01\n" +
+	"\x17MarkReadByEntityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12%\n" +
+	"\x0esource_service\x18\x02 \x01(\tR\rsourceService\x12(\n" +
+	"\x10source_entity_id\x18\x03 \x01(\x03R\x0esourceEntityId\"P\n" +
+	"\x19MarkReadByCategoryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\"]\n" +
 	"\x19DeleteNotificationRequest\x12'\n" +
 	"\x0fnotification_id\x18\x01 \x01(\x03R\x0enotificationId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\xcd\x01\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\xba\x03\n" +
 	"\x17NotificationPreferences\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12Y\n" +
-	"\vpreferences\x18\x02 \x03(\v27.notifications.NotificationPreferences.PreferencesEntryR\vpreferences\x1a>\n" +
+	"\vpreferences\x18\x02 \x03(\v27.notifications.NotificationPreferences.PreferencesEntryR\vpreferences\x12V\n" +
+	"\n" +
+	"categories\x18\x03 \x03(\v26.notifications.NotificationPreferences.CategoriesEntryR\n" +
+	"categories\x125\n" +
+	"\x05mutes\x18\x04 \x03(\v2\x1f.notifications.NotificationMuteR\x05mutes\x12\x1d\n" +
+	"\n" +
+	"email_mode\x18\x05 \x01(\tR\temailMode\x1a>\n" +
 	"\x10PreferencesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"\xe7\x01\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\x1a=\n" +
+	"\x0fCategoriesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"\xaa\x03\n" +
 	"$UpdateNotificationPreferencesRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12f\n" +
-	"\vpreferences\x18\x02 \x03(\v2D.notifications.UpdateNotificationPreferencesRequest.PreferencesEntryR\vpreferences\x1a>\n" +
+	"\vpreferences\x18\x02 \x03(\v2D.notifications.UpdateNotificationPreferencesRequest.PreferencesEntryR\vpreferences\x12c\n" +
+	"\n" +
+	"categories\x18\x03 \x03(\v2C.notifications.UpdateNotificationPreferencesRequest.CategoriesEntryR\n" +
+	"categories\x12\x1d\n" +
+	"\n" +
+	"email_mode\x18\x04 \x01(\tR\temailMode\x1a>\n" +
 	"\x10PreferencesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"\x9d\x01\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\x1a=\n" +
+	"\x0fCategoriesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"\x8d\x01\n" +
+	"\x10NotificationMute\x12\x1f\n" +
+	"\vtarget_type\x18\x01 \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\x03R\btargetId\x12;\n" +
+	"\vmuted_until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"mutedUntil\"\xae\x01\n" +
+	"\x18MuteNotificationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1f\n" +
+	"\vtarget_type\x18\x02 \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\x03R\btargetId\x12;\n" +
+	"\vmuted_until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"mutedUntil\"s\n" +
+	"\x1aUnmuteNotificationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1f\n" +
+	"\vtarget_type\x18\x02 \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\x03R\btargetId\"/\n" +
+	"\x17UnsubscribeEmailRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xa1\x01\n" +
+	"\x1fRegisterPushSubscriptionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
+	"\bendpoint\x18\x02 \x01(\tR\bendpoint\x12\x16\n" +
+	"\x06p256dh\x18\x03 \x01(\tR\x06p256dh\x12\x12\n" +
+	"\x04auth\x18\x04 \x01(\tR\x04auth\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x05 \x01(\tR\tuserAgent\"X\n" +
+	"!UnregisterPushSubscriptionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
+	"\bendpoint\x18\x02 \x01(\tR\bendpoint\"\x9d\x01\n" +
 	"\x1aCreateFollowRequestRequest\x12$\n" +
 	"\x0etarget_user_id\x18\x01 \x01(\x03R\ftargetUserId\x12*\n" +
 	"\x11requester_user_id\x18\x02 \x01(\x03R\x0frequesterUserId\x12-\n" +
@@ -4352,7 +5399,19 @@ const file_notifications_proto_rawDesc = "" +
 	"\x11commenter_user_id\x18\x04 \x01(\x03R\x0fcommenterUserId\x12-\n" +
 	"\x12commenter_username\x18\x05 \x01(\tR\x11commenterUsername\x12\x12\n" +
 	"\x04body\x18\x06 \x01(\tR\x04body\x12\x1c\n" +
-	"\taggregate\x18\a \x01(\bR\taggregate\"\xb9\x01\n" +
+	"\taggregate\x18\a \x01(\bR\taggregate\"d\n" +
+	"\rPostPublished\x12&\n" +
+	"\x0fpost_creator_id\x18\x01 \x01(\x03R\rpostCreatorId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\x03R\x06postId\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\"\xcb\x01\n" +
+	"\n" +
+	"PostShared\x12&\n" +
+	"\x0fpost_creator_id\x18\x01 \x01(\x03R\rpostCreatorId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\x03R\x06postId\x12\x19\n" +
+	"\bshare_id\x18\x03 \x01(\x03R\ashareId\x12$\n" +
+	"\x0esharer_user_id\x18\x04 \x01(\x03R\fsharerUserId\x12'\n" +
+	"\x0fsharer_username\x18\x05 \x01(\tR\x0esharerUsername\x12\x12\n" +
+	"\x04body\x18\x06 \x01(\tR\x04body\"\xb9\x01\n" +
 	"\tPostLiked\x12*\n" +
 	"\x11entity_creator_id\x18\x01 \x01(\x03R\x0fentityCreatorId\x12\x17\n" +
 	"\apost_id\x18\x02 \x01(\x03R\x06postId\x12\"\n" +
@@ -4445,7 +5504,7 @@ const file_notifications_proto_rawDesc = "" +
 	"\x19GroupJoinRequestCancelled\x12$\n" +
 	"\x0egroup_owner_id\x18\x01 \x01(\x03R\fgroupOwnerId\x12*\n" +
 	"\x11requester_user_id\x18\x02 \x01(\x03R\x0frequesterUserId\x12\x19\n" +
-	"\bgroup_id\x18\x03 \x01(\x03R\agroupId\"\xc0\x0e\n" +
+	"\bgroup_id\x18\x03 \x01(\x03R\agroupId\"\xc5\x0f\n" +
 	"\x11NotificationEvent\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12;\n" +
 	"\voccurred_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
@@ -4471,7 +5530,10 @@ const file_notifications_proto_rawDesc = "" +
 	"\x1bgroup_join_request_accepted\x18\x17 \x01(\v2'.notifications.GroupJoinRequestAcceptedH\x00R\x18groupJoinRequestAccepted\x12h\n" +
 	"\x1bgroup_join_request_rejected\x18\x18 \x01(\v2'.notifications.GroupJoinRequestRejectedH\x00R\x18groupJoinRequestRejected\x12a\n" +
 	"\x18follow_request_cancelled\x18\x19 \x01(\v2%.notifications.FollowRequestCancelledH\x00R\x16followRequestCancelled\x12k\n" +
-	"\x1cgroup_join_request_cancelled\x18\x1a \x01(\v2(.notifications.GroupJoinRequestCancelledH\x00R\x19groupJoinRequestCancelled\x1a;\n" +
+	"\x1cgroup_join_request_cancelled\x18\x1a \x01(\v2(.notifications.GroupJoinRequestCancelledH\x00R\x19groupJoinRequestCancelled\x12E\n" +
+	"\x0epost_published\x18\x1b \x01(\v2\x1c.notifications.PostPublishedH\x00R\rpostPublished\x12<\n" +
+	"\vpost_shared\x18\x1c \x01(\v2\x19.notifications.PostSharedH\x00R\n" +
+	"postShared\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\t\n" +
@@ -4480,7 +5542,13 @@ const file_notifications_proto_rawDesc = "" +
 	"\x0fnotification_id\x18\x01 \x01(\x03R\x0enotificationId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x03R\x06userId\x129\n" +
 	"\n" +
-	"deleted_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt*\x93\x05\n" +
+	"deleted_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"\xbe\x01\n" +
+	"\x11UnreadCountUpdate\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12!\n" +
+	"\funread_count\x18\x02 \x01(\x03R\vunreadCount\x122\n" +
+	"\x15read_notification_ids\x18\x03 \x03(\x03R\x13readNotificationIds\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt*\xdc\x05\n" +
 	"\x10NotificationType\x12!\n" +
 	"\x1dNOTIFICATION_TYPE_UNSPECIFIED\x10\x00\x12$\n" +
 	" NOTIFICATION_TYPE_FOLLOW_REQUEST\x10\x01\x12\"\n" +
@@ -4498,12 +5566,14 @@ const file_notifications_proto_rawDesc = "" +
 	"'NOTIFICATION_TYPE_GROUP_INVITE_ACCEPTED\x10\f\x12+\n" +
 	"'NOTIFICATION_TYPE_GROUP_INVITE_REJECTED\x10\r\x121\n" +
 	"-NOTIFICATION_TYPE_GROUP_JOIN_REQUEST_ACCEPTED\x10\x0e\x121\n" +
-	"-NOTIFICATION_TYPE_GROUP_JOIN_REQUEST_REJECTED\x10\x0f*\x98\x01\n" +
+	"-NOTIFICATION_TYPE_GROUP_JOIN_REQUEST_REJECTED\x10\x0f\x12$\n" +
+	" NOTIFICATION_TYPE_POST_PUBLISHED\x10\x10\x12!\n" +
+	"\x1dNOTIFICATION_TYPE_POST_SHARED\x10\x11*\x98\x01\n" +
 	"\x12NotificationStatus\x12#\n" +
 	"\x1fNOTIFICATION_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aNOTIFICATION_STATUS_UNREAD\x10\x01\x12\x1c\n" +
 	"\x18NOTIFICATION_STATUS_READ\x10\x02\x12\x1f\n" +
-	"\x1bNOTIFICATION_STATUS_DELETED\x10\x03*\xf8\x03\n" +
+	"\x1bNOTIFICATION_STATUS_DELETED\x10\x03*\x9d\x04\n" +
 	"\tEventType\x12\x1a\n" +
 	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14POST_COMMENT_CREATED\x10\x01\x12\x0e\n" +
@@ -4524,7 +5594,9 @@ const file_notifications_proto_rawDesc = "" +
 	"\x1bGROUP_JOIN_REQUEST_ACCEPTED\x10\x0e\x12\x1f\n" +
 	"\x1bGROUP_JOIN_REQUEST_REJECTED\x10\x0f\x12\x1c\n" +
 	"\x18FOLLOW_REQUEST_CANCELLED\x10\x10\x12 \n" +
-	"\x1cGROUP_JOIN_REQUEST_CANCELLED\x10\x112\xe3\x16\n" +
+	"\x1cGROUP_JOIN_REQUEST_CANCELLED\x10\x11\x12\x12\n" +
+	"\x0ePOST_PUBLISHED\x10\x12\x12\x0f\n" +
+	"\vPOST_SHARED\x10\x132\xac\x1c\n" +
 	"\x13NotificationService\x12[\n" +
 	"\x12CreateNotification\x12(.notifications.CreateNotificationRequest\x1a\x1b.notifications.Notification\x12l\n" +
 	"\x13CreateNotifications\x12).notifications.CreateNotificationsRequest\x1a*.notifications.CreateNotificationsResponse\x12]\n" +
@@ -4550,10 +5622,18 @@ const file_notifications_proto_rawDesc = "" +
 	"\x1bGetUnreadNotificationsCount\x12\x1b.google.protobuf.Int64Value\x1a\x1b.google.protobuf.Int64Value\x12^\n" +
 	"\x16MarkNotificationAsRead\x12,.notifications.MarkNotificationAsReadRequest\x1a\x16.google.protobuf.Empty\x12`\n" +
 	"\x17MarkNotificationAsActed\x12-.notifications.MarkNotificationAsActedRequest\x1a\x16.google.protobuf.Empty\x12D\n" +
-	"\rMarkAllAsRead\x12\x1b.google.protobuf.Int64Value\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\rMarkAllAsRead\x12\x1b.google.protobuf.Int64Value\x1a\x16.google.protobuf.Empty\x12R\n" +
+	"\x10MarkReadByEntity\x12&.notifications.MarkReadByEntityRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\x12MarkReadByCategory\x12(.notifications.MarkReadByCategoryRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
 	"\x12DeleteNotification\x12(.notifications.DeleteNotificationRequest\x1a\x16.google.protobuf.Empty\x12a\n" +
 	"\x1aGetNotificationPreferences\x12\x1b.google.protobuf.Int64Value\x1a&.notifications.NotificationPreferences\x12l\n" +
-	"\x1dUpdateNotificationPreferences\x123.notifications.UpdateNotificationPreferencesRequest\x1a\x16.google.protobuf.EmptyB:Z8social-network/shared/gen-go/notifications;notificationsb\x06proto3"
+	"\x1dUpdateNotificationPreferences\x123.notifications.UpdateNotificationPreferencesRequest\x1a\x16.google.protobuf.Empty\x12T\n" +
+	"\x11MuteNotifications\x12'.notifications.MuteNotificationsRequest\x1a\x16.google.protobuf.Empty\x12X\n" +
+	"\x13UnmuteNotifications\x12).notifications.UnmuteNotificationsRequest\x1a\x16.google.protobuf.Empty\x12R\n" +
+	"\x10UnsubscribeEmail\x12&.notifications.UnsubscribeEmailRequest\x1a\x16.google.protobuf.Empty\x12K\n" +
+	"\x13GetWebPushPublicKey\x12\x16.google.protobuf.Empty\x1a\x1c.google.protobuf.StringValue\x12b\n" +
+	"\x18RegisterPushSubscription\x12..notifications.RegisterPushSubscriptionRequest\x1a\x16.google.protobuf.Empty\x12f\n" +
+	"\x1aUnregisterPushSubscription\x120.notifications.UnregisterPushSubscriptionRequest\x1a\x16.google.protobuf.EmptyB:Z8social-network/shared/gen-go/notifications;notificationsb\x06proto3"
 
 var (
 	file_notifications_proto_rawDescOnce sync.Once
@@ -4568,7 +5648,7 @@ func file_notifications_proto_rawDescGZIP() []byte {
 }
 
 var file_notifications_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_notifications_proto_msgTypes = make([]protoimpl.MessageInfo, 56)
+var file_notifications_proto_msgTypes = make([]protoimpl.MessageInfo, 71)
 var file_notifications_proto_goTypes = []any{
 	(NotificationType)(0),                             // 0: notifications.NotificationType
 	(NotificationStatus)(0),                           // 1: notifications.NotificationStatus
@@ -4581,156 +5661,202 @@ var file_notifications_proto_goTypes = []any{
 	(*CreateNewEventForMultipleUsersResponse)(nil),    // 8: notifications.CreateNewEventForMultipleUsersResponse
 	(*GetUserNotificationsRequest)(nil),               // 9: notifications.GetUserNotificationsRequest
 	(*GetUserNotificationsResponse)(nil),              // 10: notifications.GetUserNotificationsResponse
-	(*MarkNotificationAsReadRequest)(nil),             // 11: notifications.MarkNotificationAsReadRequest
-	(*MarkNotificationAsActedRequest)(nil),            // 12: notifications.MarkNotificationAsActedRequest
-	(*DeleteNotificationRequest)(nil),                 // 13: notifications.DeleteNotificationRequest
-	(*NotificationPreferences)(nil),                   // 14: notifications.NotificationPreferences
-	(*UpdateNotificationPreferencesRequest)(nil),      // 15: notifications.UpdateNotificationPreferencesRequest
-	(*CreateFollowRequestRequest)(nil),                // 16: notifications.CreateFollowRequestRequest
-	(*CreateNewFollowerRequest)(nil),                  // 17: notifications.CreateNewFollowerRequest
-	(*CreateGroupInviteRequest)(nil),                  // 18: notifications.CreateGroupInviteRequest
-	(*CreateGroupInviteForMultipleUsersRequest)(nil),  // 19: notifications.CreateGroupInviteForMultipleUsersRequest
-	(*CreateGroupInviteForMultipleUsersResponse)(nil), // 20: notifications.CreateGroupInviteForMultipleUsersResponse
-	(*CreateGroupJoinRequestRequest)(nil),             // 21: notifications.CreateGroupJoinRequestRequest
-	(*CreateNewEventRequest)(nil),                     // 22: notifications.CreateNewEventRequest
-	(*CreatePostLikeRequest)(nil),                     // 23: notifications.CreatePostLikeRequest
-	(*CreatePostCommentRequest)(nil),                  // 24: notifications.CreatePostCommentRequest
-	(*CreateMentionRequest)(nil),                      // 25: notifications.CreateMentionRequest
-	(*CreateNewMessageRequest)(nil),                   // 26: notifications.CreateNewMessageRequest
-	(*CreateNewMessageForMultipleUsersRequest)(nil),   // 27: notifications.CreateNewMessageForMultipleUsersRequest
-	(*CreateNewMessageForMultipleUsersResponse)(nil),  // 28: notifications.CreateNewMessageForMultipleUsersResponse
-	(*CreateFollowRequestAcceptedRequest)(nil),        // 29: notifications.CreateFollowRequestAcceptedRequest
-	(*CreateFollowRequestRejectedRequest)(nil),        // 30: notifications.CreateFollowRequestRejectedRequest
-	(*CreateGroupInviteAcceptedRequest)(nil),          // 31: notifications.CreateGroupInviteAcceptedRequest
-	(*CreateGroupInviteRejectedRequest)(nil),          // 32: notifications.CreateGroupInviteRejectedRequest
-	(*CreateGroupJoinRequestAcceptedRequest)(nil),     // 33: notifications.CreateGroupJoinRequestAcceptedRequest
-	(*CreateGroupJoinRequestRejectedRequest)(nil),     // 34: notifications.CreateGroupJoinRequestRejectedRequest
-	(*PostCommentCreated)(nil),                        // 35: notifications.PostCommentCreated
-	(*PostLiked)(nil),                                 // 36: notifications.PostLiked
-	(*FollowRequestCreated)(nil),                      // 37: notifications.FollowRequestCreated
-	(*NewFollowerCreated)(nil),                        // 38: notifications.NewFollowerCreated
-	(*GroupInviteCreated)(nil),                        // 39: notifications.GroupInviteCreated
-	(*GroupJoinRequestCreated)(nil),                   // 40: notifications.GroupJoinRequestCreated
-	(*NewEventCreated)(nil),                           // 41: notifications.NewEventCreated
-	(*MentionCreated)(nil),                            // 42: notifications.MentionCreated
-	(*NewMessageCreated)(nil),                         // 43: notifications.NewMessageCreated
-	(*FollowRequestAccepted)(nil),                     // 44: notifications.FollowRequestAccepted
-	(*FollowRequestRejected)(nil),                     // 45: notifications.FollowRequestRejected
-	(*GroupInviteAccepted)(nil),                       // 46: notifications.GroupInviteAccepted
-	(*GroupInviteRejected)(nil),                       // 47: notifications.GroupInviteRejected
-	(*GroupJoinRequestAccepted)(nil),                  // 48: notifications.GroupJoinRequestAccepted
-	(*GroupJoinRequestRejected)(nil),                  // 49: notifications.GroupJoinRequestRejected
-	(*FollowRequestCancelled)(nil),                    // 50: notifications.FollowRequestCancelled
-	(*GroupJoinRequestCancelled)(nil),                 // 51: notifications.GroupJoinRequestCancelled
-	(*NotificationEvent)(nil),                         // 52: notifications.NotificationEvent
-	(*NotificationDeletion)(nil),                      // 53: notifications.NotificationDeletion
-	nil,                                               // 54: notifications.Notification.PayloadEntry
-	nil,                                               // 55: notifications.CreateNotificationRequest.PayloadEntry
-	nil,                                               // 56: notifications.NotificationPreferences.PreferencesEntry
-	nil,                                               // 57: notifications.UpdateNotificationPreferencesRequest.PreferencesEntry
-	nil,                                               // 58: notifications.NotificationEvent.MetadataEntry
-	(*timestamppb.Timestamp)(nil),                     // 59: google.protobuf.Timestamp
-	(*wrapperspb.Int64Value)(nil),                     // 60: google.protobuf.Int64Value
-	(*emptypb.Empty)(nil),                             // 61: google.protobuf.Empty
+	(*NotificationGroup)(nil),                         // 11: notifications.NotificationGroup
+	(*MarkNotificationAsReadRequest)(nil),             // 12: notifications.MarkNotificationAsReadRequest
+	(*MarkNotificationAsActedRequest)(nil),            // 13: notifications.MarkNotificationAsActedRequest
+	(*MarkReadByEntityRequest)(nil),                   // 14: notifications.MarkReadByEntityRequest
+	(*MarkReadByCategoryRequest)(nil),                 // 15: notifications.MarkReadByCategoryRequest
+	(*DeleteNotificationRequest)(nil),                 // 16: notifications.DeleteNotificationRequest
+	(*NotificationPreferences)(nil),                   // 17: notifications.NotificationPreferences
+	(*UpdateNotificationPreferencesRequest)(nil),      // 18: notifications.UpdateNotificationPreferencesRequest
+	(*NotificationMute)(nil),                          // 19: notifications.NotificationMute
+	(*MuteNotificationsRequest)(nil),                  // 20: notifications.MuteNotificationsRequest
+	(*UnmuteNotificationsRequest)(nil),                // 21: notifications.UnmuteNotificationsRequest
+	(*UnsubscribeEmailRequest)(nil),                   // 22: notifications.UnsubscribeEmailRequest
+	(*RegisterPushSubscriptionRequest)(nil),           // 23: notifications.RegisterPushSubscriptionRequest
+	(*UnregisterPushSubscriptionRequest)(nil),         // 24: notifications.UnregisterPushSubscriptionRequest
+	(*CreateFollowRequestRequest)(nil),                // 25: notifications.CreateFollowRequestRequest
+	(*CreateNewFollowerRequest)(nil),                  // 26: notifications.CreateNewFollowerRequest
+	(*CreateGroupInviteRequest)(nil),                  // 27: notifications.CreateGroupInviteRequest
+	(*CreateGroupInviteForMultipleUsersRequest)(nil),  // 28: notifications.CreateGroupInviteForMultipleUsersRequest
+	(*CreateGroupInviteForMultipleUsersResponse)(nil), // 29: notifications.CreateGroupInviteForMultipleUsersResponse
+	(*CreateGroupJoinRequestRequest)(nil),             // 30: notifications.CreateGroupJoinRequestRequest
+	(*CreateNewEventRequest)(nil),                     // 31: notifications.CreateNewEventRequest
+	(*CreatePostLikeRequest)(nil),                     // 32: notifications.CreatePostLikeRequest
+	(*CreatePostCommentRequest)(nil),                  // 33: notifications.CreatePostCommentRequest
+	(*CreateMentionRequest)(nil),                      // 34: notifications.CreateMentionRequest
+	(*CreateNewMessageRequest)(nil),                   // 35: notifications.CreateNewMessageRequest
+	(*CreateNewMessageForMultipleUsersRequest)(nil),   // 36: notifications.CreateNewMessageForMultipleUsersRequest
+	(*CreateNewMessageForMultipleUsersResponse)(nil),  // 37: notifications.CreateNewMessageForMultipleUsersResponse
+	(*CreateFollowRequestAcceptedRequest)(nil),        // 38: notifications.CreateFollowRequestAcceptedRequest
+	(*CreateFollowRequestRejectedRequest)(nil),        // 39: notifications.CreateFollowRequestRejectedRequest
+	(*CreateGroupInviteAcceptedRequest)(nil),          // 40: notifications.CreateGroupInviteAcceptedRequest
+	(*CreateGroupInviteRejectedRequest)(nil),          // 41: notifications.CreateGroupInviteRejectedRequest
+	(*CreateGroupJoinRequestAcceptedRequest)(nil),     // 42: notifications.CreateGroupJoinRequestAcceptedRequest
+	(*CreateGroupJoinRequestRejectedRequest)(nil),     // 43: notifications.CreateGroupJoinRequestRejectedRequest
+	(*PostCommentCreated)(nil),                        // 44: notifications.PostCommentCreated
+	(*PostPublished)(nil),                             // 45: notifications.PostPublished
+	(*PostShared)(nil),                                // 46: notifications.PostShared
+	(*PostLiked)(nil),                                 // 47: notifications.PostLiked
+	(*FollowRequestCreated)(nil),                      // 48: notifications.FollowRequestCreated
+	(*NewFollowerCreated)(nil),                        // 49: notifications.NewFollowerCreated
+	(*GroupInviteCreated)(nil),                        // 50: notifications.GroupInviteCreated
+	(*GroupJoinRequestCreated)(nil),                   // 51: notifications.GroupJoinRequestCreated
+	(*NewEventCreated)(nil),                           // 52: notifications.NewEventCreated
+	(*MentionCreated)(nil),                            // 53: notifications.MentionCreated
+	(*NewMessageCreated)(nil),                         // 54: notifications.NewMessageCreated
+	(*FollowRequestAccepted)(nil),                     // 55: notifications.FollowRequestAccepted
+	(*FollowRequestRejected)(nil),                     // 56: notifications.FollowRequestRejected
+	(*GroupInviteAccepted)(nil),                       // 57: notifications.GroupInviteAccepted
+	(*GroupInviteRejected)(nil),                       // 58: notifications.GroupInviteRejected
+	(*GroupJoinRequestAccepted)(nil),                  // 59: notifications.GroupJoinRequestAccepted
+	(*GroupJoinRequestRejected)(nil),                  // 60: notifications.GroupJoinRequestRejected
+	(*FollowRequestCancelled)(nil),                    // 61: notifications.FollowRequestCancelled
+	(*GroupJoinRequestCancelled)(nil),                 // 62: notifications.GroupJoinRequestCancelled
+	(*NotificationEvent)(nil),                         // 63: notifications.NotificationEvent
+	(*NotificationDeletion)(nil),                      // 64: notifications.NotificationDeletion
+	(*UnreadCountUpdate)(nil),                         // 65: notifications.UnreadCountUpdate
+	nil,                                               // 66: notifications.Notification.PayloadEntry
+	nil,                                               // 67: notifications.CreateNotificationRequest.PayloadEntry
+	nil,                                               // 68: notifications.NotificationGroup.PayloadEntry
+	nil,                                               // 69: notifications.NotificationPreferences.PreferencesEntry
+	nil,                                               // 70: notifications.NotificationPreferences.CategoriesEntry
+	nil,                                               // 71: notifications.UpdateNotificationPreferencesRequest.PreferencesEntry
+	nil,                                               // 72: notifications.UpdateNotificationPreferencesRequest.CategoriesEntry
+	nil,                                               // 73: notifications.NotificationEvent.MetadataEntry
+	(*timestamppb.Timestamp)(nil),                     // 74: google.protobuf.Timestamp
+	(*common.User)(nil),                               // 75: common.User
+	(*wrapperspb.Int64Value)(nil),                     // 76: google.protobuf.Int64Value
+	(*emptypb.Empty)(nil),                             // 77: google.protobuf.Empty
+	(*wrapperspb.StringValue)(nil),                    // 78: google.protobuf.StringValue
 }
 var file_notifications_proto_depIdxs = []int32{
-	54, // 0: notifications.Notification.payload:type_name -> notifications.Notification.PayloadEntry
-	59, // 1: notifications.Notification.created_at:type_name -> google.protobuf.Timestamp
-	59, // 2: notifications.Notification.expires_at:type_name -> google.protobuf.Timestamp
+	66, // 0: notifications.Notification.payload:type_name -> notifications.Notification.PayloadEntry
+	74, // 1: notifications.Notification.created_at:type_name -> google.protobuf.Timestamp
+	74, // 2: notifications.Notification.expires_at:type_name -> google.protobuf.Timestamp
 	1,  // 3: notifications.Notification.status:type_name -> notifications.NotificationStatus
 	0,  // 4: notifications.CreateNotificationRequest.type:type_name -> notifications.NotificationType
-	55, // 5: notifications.CreateNotificationRequest.payload:type_name -> notifications.CreateNotificationRequest.PayloadEntry
+	67, // 5: notifications.CreateNotificationRequest.payload:type_name -> notifications.CreateNotificationRequest.PayloadEntry
 	4,  // 6: notifications.CreateNotificationsRequest.notifications:type_name -> notifications.CreateNotificationRequest
 	3,  // 7: notifications.CreateNotificationsResponse.created_notifications:type_name -> notifications.Notification
 	3,  // 8: notifications.CreateNewEventForMultipleUsersResponse.created_notifications:type_name -> notifications.Notification
 	0,  // 9: notifications.GetUserNotificationsRequest.types:type_name -> notifications.NotificationType
 	3,  // 10: notifications.GetUserNotificationsResponse.notifications:type_name -> notifications.Notification
-	56, // 11: notifications.NotificationPreferences.preferences:type_name -> notifications.NotificationPreferences.PreferencesEntry
-	57, // 12: notifications.UpdateNotificationPreferencesRequest.preferences:type_name -> notifications.UpdateNotificationPreferencesRequest.PreferencesEntry
-	3,  // 13: notifications.CreateGroupInviteForMultipleUsersResponse.created_notifications:type_name -> notifications.Notification
-	3,  // 14: notifications.CreateNewMessageForMultipleUsersResponse.created_notifications:type_name -> notifications.Notification
-	59, // 15: notifications.NotificationEvent.occurred_at:type_name -> google.protobuf.Timestamp
-	2,  // 16: notifications.NotificationEvent.event_type:type_name -> notifications.EventType
-	58, // 17: notifications.NotificationEvent.metadata:type_name -> notifications.NotificationEvent.MetadataEntry
-	35, // 18: notifications.NotificationEvent.post_comment_created:type_name -> notifications.PostCommentCreated
-	36, // 19: notifications.NotificationEvent.post_liked:type_name -> notifications.PostLiked
-	37, // 20: notifications.NotificationEvent.follow_request_created:type_name -> notifications.FollowRequestCreated
-	38, // 21: notifications.NotificationEvent.new_follower_created:type_name -> notifications.NewFollowerCreated
-	39, // 22: notifications.NotificationEvent.group_invite_created:type_name -> notifications.GroupInviteCreated
-	40, // 23: notifications.NotificationEvent.group_join_request_created:type_name -> notifications.GroupJoinRequestCreated
-	41, // 24: notifications.NotificationEvent.new_event_created:type_name -> notifications.NewEventCreated
-	42, // 25: notifications.NotificationEvent.mention_created:type_name -> notifications.MentionCreated
-	43, // 26: notifications.NotificationEvent.new_message_created:type_name -> notifications.NewMessageCreated
-	44, // 27: notifications.NotificationEvent.follow_request_accepted:type_name -> notifications.FollowRequestAccepted
-	45, // 28: notifications.NotificationEvent.follow_request_rejected:type_name -> notifications.FollowRequestRejected
-	46, // 29: notifications.NotificationEvent.group_invite_accepted:type_name -> notifications.GroupInviteAccepted
-	47, // 30: notifications.NotificationEvent.group_invite_rejected:type_name -> notifications.GroupInviteRejected
-	48, // 31: notifications.NotificationEvent.group_join_request_accepted:type_name -> notifications.GroupJoinRequestAccepted
-	49, // 32: notifications.NotificationEvent.group_join_request_rejected:type_name -> notifications.GroupJoinRequestRejected
-	50, // 33: notifications.NotificationEvent.follow_request_cancelled:type_name -> notifications.FollowRequestCancelled
-	51, // 34: notifications.NotificationEvent.group_join_request_cancelled:type_name -> notifications.GroupJoinRequestCancelled
-	59, // 35: notifications.NotificationDeletion.deleted_at:type_name -> google.protobuf.Timestamp
-	4,  // 36: notifications.NotificationService.CreateNotification:input_type -> notifications.CreateNotificationRequest
-	5,  // 37: notifications.NotificationService.CreateNotifications:input_type -> notifications.CreateNotificationsRequest
-	16, // 38: notifications.NotificationService.CreateFollowRequest:input_type -> notifications.CreateFollowRequestRequest
-	17, // 39: notifications.NotificationService.CreateNewFollower:input_type -> notifications.CreateNewFollowerRequest
-	18, // 40: notifications.NotificationService.CreateGroupInvite:input_type -> notifications.CreateGroupInviteRequest
-	19, // 41: notifications.NotificationService.CreateGroupInviteForMultipleUsers:input_type -> notifications.CreateGroupInviteForMultipleUsersRequest
-	21, // 42: notifications.NotificationService.CreateGroupJoinRequest:input_type -> notifications.CreateGroupJoinRequestRequest
-	22, // 43: notifications.NotificationService.CreateNewEvent:input_type -> notifications.CreateNewEventRequest
-	7,  // 44: notifications.NotificationService.CreateNewEventForMultipleUsers:input_type -> notifications.CreateNewEventForMultipleUsersRequest
-	23, // 45: notifications.NotificationService.CreatePostLike:input_type -> notifications.CreatePostLikeRequest
-	24, // 46: notifications.NotificationService.CreatePostComment:input_type -> notifications.CreatePostCommentRequest
-	25, // 47: notifications.NotificationService.CreateMention:input_type -> notifications.CreateMentionRequest
-	26, // 48: notifications.NotificationService.CreateNewMessage:input_type -> notifications.CreateNewMessageRequest
-	27, // 49: notifications.NotificationService.CreateNewMessageForMultipleUsers:input_type -> notifications.CreateNewMessageForMultipleUsersRequest
-	29, // 50: notifications.NotificationService.CreateFollowRequestAccepted:input_type -> notifications.CreateFollowRequestAcceptedRequest
-	30, // 51: notifications.NotificationService.CreateFollowRequestRejected:input_type -> notifications.CreateFollowRequestRejectedRequest
-	31, // 52: notifications.NotificationService.CreateGroupInviteAccepted:input_type -> notifications.CreateGroupInviteAcceptedRequest
-	32, // 53: notifications.NotificationService.CreateGroupInviteRejected:input_type -> notifications.CreateGroupInviteRejectedRequest
-	33, // 54: notifications.NotificationService.CreateGroupJoinRequestAccepted:input_type -> notifications.CreateGroupJoinRequestAcceptedRequest
-	34, // 55: notifications.NotificationService.CreateGroupJoinRequestRejected:input_type -> notifications.CreateGroupJoinRequestRejectedRequest
-	9,  // 56: notifications.NotificationService.GetUserNotifications:input_type -> notifications.GetUserNotificationsRequest
-	60, // 57: notifications.NotificationService.GetUnreadNotificationsCount:input_type -> google.protobuf.Int64Value
-	11, // 58: notifications.NotificationService.MarkNotificationAsRead:input_type -> notifications.MarkNotificationAsReadRequest
-	12, // 59: notifications.NotificationService.MarkNotificationAsActed:input_type -> notifications.MarkNotificationAsActedRequest
-	60, // 60: notifications.NotificationService.MarkAllAsRead:input_type -> google.protobuf.Int64Value
-	13, // 61: notifications.NotificationService.DeleteNotification:input_type -> notifications.DeleteNotificationRequest
-	60, // 62: notifications.NotificationService.GetNotificationPreferences:input_type -> google.protobuf.Int64Value
-	15, // 63: notifications.NotificationService.UpdateNotificationPreferences:input_type -> notifications.UpdateNotificationPreferencesRequest
-	3,  // 64: notifications.NotificationService.CreateNotification:output_type -> notifications.Notification
-	6,  // 65: notifications.NotificationService.CreateNotifications:output_type -> notifications.CreateNotificationsResponse
-	3,  // 66: notifications.NotificationService.CreateFollowRequest:output_type -> notifications.Notification
-	3,  // 67: notifications.NotificationService.CreateNewFollower:output_type -> notifications.Notification
-	3,  // 68: notifications.NotificationService.CreateGroupInvite:output_type -> notifications.Notification
-	20, // 69: notifications.NotificationService.CreateGroupInviteForMultipleUsers:output_type -> notifications.CreateGroupInviteForMultipleUsersResponse
-	3,  // 70: notifications.NotificationService.CreateGroupJoinRequest:output_type -> notifications.Notification
-	3,  // 71: notifications.NotificationService.CreateNewEvent:output_type -> notifications.Notification
-	8,  // 72: notifications.NotificationService.CreateNewEventForMultipleUsers:output_type -> notifications.CreateNewEventForMultipleUsersResponse
-	3,  // 73: notifications.NotificationService.CreatePostLike:output_type -> notifications.Notification
-	3,  // 74: notifications.NotificationService.CreatePostComment:output_type -> notifications.Notification
-	3,  // 75: notifications.NotificationService.CreateMention:output_type -> notifications.Notification
-	3,  // 76: notifications.NotificationService.CreateNewMessage:output_type -> notifications.Notification
-	28, // 77: notifications.NotificationService.CreateNewMessageForMultipleUsers:output_type -> notifications.CreateNewMessageForMultipleUsersResponse
-	3,  // 78: notifications.NotificationService.CreateFollowRequestAccepted:output_type -> notifications.Notification
-	3,  // 79: notifications.NotificationService.CreateFollowRequestRejected:output_type -> notifications.Notification
-	3,  // 80: notifications.NotificationService.CreateGroupInviteAccepted:output_type -> notifications.Notification
-	3,  // 81: notifications.NotificationService.CreateGroupInviteRejected:output_type -> notifications.Notification
-	3,  // 82: notifications.NotificationService.CreateGroupJoinRequestAccepted:output_type -> notifications.Notification
-	3,  // 83: notifications.NotificationService.CreateGroupJoinRequestRejected:output_type -> notifications.Notification
-	10, // 84: notifications.NotificationService.GetUserNotifications:output_type -> notifications.GetUserNotificationsResponse
-	60, // 85: notifications.NotificationService.GetUnreadNotificationsCount:output_type -> google.protobuf.Int64Value
-	61, // 86: notifications.NotificationService.MarkNotificationAsRead:output_type -> google.protobuf.Empty
-	61, // 87: notifications.NotificationService.MarkNotificationAsActed:output_type -> google.protobuf.Empty
-	61, // 88: notifications.NotificationService.MarkAllAsRead:output_type -> google.protobuf.Empty
-	61, // 89: notifications.NotificationService.DeleteNotification:output_type -> google.protobuf.Empty
-	14, // 90: notifications.NotificationService.GetNotificationPreferences:output_type -> notifications.NotificationPreferences
-	61, // 91: notifications.NotificationService.UpdateNotificationPreferences:output_type -> google.protobuf.Empty
-	64, // [64:92] is the sub-list for method output_type
-	36, // [36:64] is the sub-list for method input_type
-	36, // [36:36] is the sub-list for extension type_name
-	36, // [36:36] is the sub-list for extension extendee
-	0,  // [0:36] is the sub-list for field type_name
+	11, // 11: notifications.GetUserNotificationsResponse.groups:type_name -> notifications.NotificationGroup
+	75, // 12: notifications.NotificationGroup.actors:type_name -> common.User
+	68, // 13: notifications.NotificationGroup.payload:type_name -> notifications.NotificationGroup.PayloadEntry
+	74, // 14: notifications.NotificationGroup.latest_at:type_name -> google.protobuf.Timestamp
+	74, // 15: notifications.NotificationGroup.earliest_at:type_name -> google.protobuf.Timestamp
+	69, // 16: notifications.NotificationPreferences.preferences:type_name -> notifications.NotificationPreferences.PreferencesEntry
+	70, // 17: notifications.NotificationPreferences.categories:type_name -> notifications.NotificationPreferences.CategoriesEntry
+	19, // 18: notifications.NotificationPreferences.mutes:type_name -> notifications.NotificationMute
+	71, // 19: notifications.UpdateNotificationPreferencesRequest.preferences:type_name -> notifications.UpdateNotificationPreferencesRequest.PreferencesEntry
+	72, // 20: notifications.UpdateNotificationPreferencesRequest.categories:type_name -> notifications.UpdateNotificationPreferencesRequest.CategoriesEntry
+	74, // 21: notifications.NotificationMute.muted_until:type_name -> google.protobuf.Timestamp
+	74, // 22: notifications.MuteNotificationsRequest.muted_until:type_name -> google.protobuf.Timestamp
+	3,  // 23: notifications.CreateGroupInviteForMultipleUsersResponse.created_notifications:type_name -> notifications.Notification
+	3,  // 24: notifications.CreateNewMessageForMultipleUsersResponse.created_notifications:type_name -> notifications.Notification
+	74, // 25: notifications.NotificationEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	2,  // 26: notifications.NotificationEvent.event_type:type_name -> notifications.EventType
+	73, // 27: notifications.NotificationEvent.metadata:type_name -> notifications.NotificationEvent.MetadataEntry
+	44, // 28: notifications.NotificationEvent.post_comment_created:type_name -> notifications.PostCommentCreated
+	47, // 29: notifications.NotificationEvent.post_liked:type_name -> notifications.PostLiked
+	48, // 30: notifications.NotificationEvent.follow_request_created:type_name -> notifications.FollowRequestCreated
+	49, // 31: notifications.NotificationEvent.new_follower_created:type_name -> notifications.NewFollowerCreated
+	50, // 32: notifications.NotificationEvent.group_invite_created:type_name -> notifications.GroupInviteCreated
+	51, // 33: notifications.NotificationEvent.group_join_request_created:type_name -> notifications.GroupJoinRequestCreated
+	52, // 34: notifications.NotificationEvent.new_event_created:type_name -> notifications.NewEventCreated
+	53, // 35: notifications.NotificationEvent.mention_created:type_name -> notifications.MentionCreated
+	54, // 36: notifications.NotificationEvent.new_message_created:type_name -> notifications.NewMessageCreated
+	55, // 37: notifications.NotificationEvent.follow_request_accepted:type_name -> notifications.FollowRequestAccepted
+	56, // 38: notifications.NotificationEvent.follow_request_rejected:type_name -> notifications.FollowRequestRejected
+	57, // 39: notifications.NotificationEvent.group_invite_accepted:type_name -> notifications.GroupInviteAccepted
+	58, // 40: notifications.NotificationEvent.group_invite_rejected:type_name -> notifications.GroupInviteRejected
+	59, // 41: notifications.NotificationEvent.group_join_request_accepted:type_name -> notifications.GroupJoinRequestAccepted
+	60, // 42: notifications.NotificationEvent.group_join_request_rejected:type_name -> notifications.GroupJoinRequestRejected
+	61, // 43: notifications.NotificationEvent.follow_request_cancelled:type_name -> notifications.FollowRequestCancelled
+	62, // 44: notifications.NotificationEvent.group_join_request_cancelled:type_name -> notifications.GroupJoinRequestCancelled
+	45, // 45: notifications.NotificationEvent.post_published:type_name -> notifications.PostPublished
+	46, // 46: notifications.NotificationEvent.post_shared:type_name -> notifications.PostShared
+	74, // 47: notifications.NotificationDeletion.deleted_at:type_name -> google.protobuf.Timestamp
+	74, // 48: notifications.UnreadCountUpdate.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 49: notifications.NotificationService.CreateNotification:input_type -> notifications.CreateNotificationRequest
+	5,  // 50: notifications.NotificationService.CreateNotifications:input_type -> notifications.CreateNotificationsRequest
+	25, // 51: notifications.NotificationService.CreateFollowRequest:input_type -> notifications.CreateFollowRequestRequest
+	26, // 52: notifications.NotificationService.CreateNewFollower:input_type -> notifications.CreateNewFollowerRequest
+	27, // 53: notifications.NotificationService.CreateGroupInvite:input_type -> notifications.CreateGroupInviteRequest
+	28, // 54: notifications.NotificationService.CreateGroupInviteForMultipleUsers:input_type -> notifications.CreateGroupInviteForMultipleUsersRequest
+	30, // 55: notifications.NotificationService.CreateGroupJoinRequest:input_type -> notifications.CreateGroupJoinRequestRequest
+	31, // 56: notifications.NotificationService.CreateNewEvent:input_type -> notifications.CreateNewEventRequest
+	7,  // 57: notifications.NotificationService.CreateNewEventForMultipleUsers:input_type -> notifications.CreateNewEventForMultipleUsersRequest
+	32, // 58: notifications.NotificationService.CreatePostLike:input_type -> notifications.CreatePostLikeRequest
+	33, // 59: notifications.NotificationService.CreatePostComment:input_type -> notifications.CreatePostCommentRequest
+	34, // 60: notifications.NotificationService.CreateMention:input_type -> notifications.CreateMentionRequest
+	35, // 61: notifications.NotificationService.CreateNewMessage:input_type -> notifications.CreateNewMessageRequest
+	36, // 62: notifications.NotificationService.CreateNewMessageForMultipleUsers:input_type -> notifications.CreateNewMessageForMultipleUsersRequest
+	38, // 63: notifications.NotificationService.CreateFollowRequestAccepted:input_type -> notifications.CreateFollowRequestAcceptedRequest
+	39, // 64: notifications.NotificationService.CreateFollowRequestRejected:input_type -> notifications.CreateFollowRequestRejectedRequest
+	40, // 65: notifications.NotificationService.CreateGroupInviteAccepted:input_type -> notifications.CreateGroupInviteAcceptedRequest
+	41, // 66: notifications.NotificationService.CreateGroupInviteRejected:input_type -> notifications.CreateGroupInviteRejectedRequest
+	42, // 67: notifications.NotificationService.CreateGroupJoinRequestAccepted:input_type -> notifications.CreateGroupJoinRequestAcceptedRequest
+	43, // 68: notifications.NotificationService.CreateGroupJoinRequestRejected:input_type -> notifications.CreateGroupJoinRequestRejectedRequest
+	9,  // 69: notifications.NotificationService.GetUserNotifications:input_type -> notifications.GetUserNotificationsRequest
+	76, // 70: notifications.NotificationService.GetUnreadNotificationsCount:input_type -> google.protobuf.Int64Value
+	12, // 71: notifications.NotificationService.MarkNotificationAsRead:input_type -> notifications.MarkNotificationAsReadRequest
+	13, // 72: notifications.NotificationService.MarkNotificationAsActed:input_type -> notifications.MarkNotificationAsActedRequest
+	76, // 73: notifications.NotificationService.MarkAllAsRead:input_type -> google.protobuf.Int64Value
+	14, // 74: notifications.NotificationService.MarkReadByEntity:input_type -> notifications.MarkReadByEntityRequest
+	15, // 75: notifications.NotificationService.MarkReadByCategory:input_type -> notifications.MarkReadByCategoryRequest
+	16, // 76: notifications.NotificationService.DeleteNotification:input_type -> notifications.DeleteNotificationRequest
+	76, // 77: notifications.NotificationService.GetNotificationPreferences:input_type -> google.protobuf.Int64Value
+	18, // 78: notifications.NotificationService.UpdateNotificationPreferences:input_type -> notifications.UpdateNotificationPreferencesRequest
+	20, // 79: notifications.NotificationService.MuteNotifications:input_type -> notifications.MuteNotificationsRequest
+	21, // 80: notifications.NotificationService.UnmuteNotifications:input_type -> notifications.UnmuteNotificationsRequest
+	22, // 81: notifications.NotificationService.UnsubscribeEmail:input_type -> notifications.UnsubscribeEmailRequest
+	77, // 82: notifications.NotificationService.GetWebPushPublicKey:input_type -> google.protobuf.Empty
+	23, // 83: notifications.NotificationService.RegisterPushSubscription:input_type -> notifications.RegisterPushSubscriptionRequest
+	24, // 84: notifications.NotificationService.UnregisterPushSubscription:input_type -> notifications.UnregisterPushSubscriptionRequest
+	3,  // 85: notifications.NotificationService.CreateNotification:output_type -> notifications.Notification
+	6,  // 86: notifications.NotificationService.CreateNotifications:output_type -> notifications.CreateNotificationsResponse
+	3,  // 87: notifications.NotificationService.CreateFollowRequest:output_type -> notifications.Notification
+	3,  // 88: notifications.NotificationService.CreateNewFollower:output_type -> notifications.Notification
+	3,  // 89: notifications.NotificationService.CreateGroupInvite:output_type -> notifications.Notification
+	29, // 90: notifications.NotificationService.CreateGroupInviteForMultipleUsers:output_type -> notifications.CreateGroupInviteForMultipleUsersResponse
+	3,  // 91: notifications.NotificationService.CreateGroupJoinRequest:output_type -> notifications.Notification
+	3,  // 92: notifications.NotificationService.CreateNewEvent:output_type -> notifications.Notification
+	8,  // 93: notifications.NotificationService.CreateNewEventForMultipleUsers:output_type -> notifications.CreateNewEventForMultipleUsersResponse
+	3,  // 94: notifications.NotificationService.CreatePostLike:output_type -> notifications.Notification
+	3,  // 95: notifications.NotificationService.CreatePostComment:output_type -> notifications.Notification
+	3,  // 96: notifications.NotificationService.CreateMention:output_type -> notifications.Notification
+	3,  // 97: notifications.NotificationService.CreateNewMessage:output_type -> notifications.Notification
+	37, // 98: notifications.NotificationService.CreateNewMessageForMultipleUsers:output_type -> notifications.CreateNewMessageForMultipleUsersResponse
+	3,  // 99: notifications.NotificationService.CreateFollowRequestAccepted:output_type -> notifications.Notification
+	3,  // 100: notifications.NotificationService.CreateFollowRequestRejected:output_type -> notifications.Notification
+	3,  // 101: notifications.NotificationService.CreateGroupInviteAccepted:output_type -> notifications.Notification
+	3,  // 102: notifications.NotificationService.CreateGroupInviteRejected:output_type -> notifications.Notification
+	3,  // 103: notifications.NotificationService.CreateGroupJoinRequestAccepted:output_type -> notifications.Notification
+	3,  // 104: notifications.NotificationService.CreateGroupJoinRequestRejected:output_type -> notifications.Notification
+	10, // 105: notifications.NotificationService.GetUserNotifications:output_type -> notifications.GetUserNotificationsResponse
+	76, // 106: notifications.NotificationService.GetUnreadNotificationsCount:output_type -> google.protobuf.Int64Value
+	77, // 107: notifications.NotificationService.MarkNotificationAsRead:output_type -> google.protobuf.Empty
+	77, // 108: notifications.NotificationService.MarkNotificationAsActed:output_type -> google.protobuf.Empty
+	77, // 109: notifications.NotificationService.MarkAllAsRead:output_type -> google.protobuf.Empty
+	77, // 110: notifications.NotificationService.MarkReadByEntity:output_type -> google.protobuf.Empty
+	77, // 111: notifications.NotificationService.MarkReadByCategory:output_type -> google.protobuf.Empty
+	77, // 112: notifications.NotificationService.DeleteNotification:output_type -> google.protobuf.Empty
+	17, // 113: notifications.NotificationService.GetNotificationPreferences:output_type -> notifications.NotificationPreferences
+	77, // 114: notifications.NotificationService.UpdateNotificationPreferences:output_type -> google.protobuf.Empty
+	77, // 115: notifications.NotificationService.MuteNotifications:output_type -> google.protobuf.Empty
+	77, // 116: notifications.NotificationService.UnmuteNotifications:output_type -> google.protobuf.Empty
+	77, // 117: notifications.NotificationService.UnsubscribeEmail:output_type -> google.protobuf.Empty
+	78, // 118: notifications.NotificationService.GetWebPushPublicKey:output_type -> google.protobuf.StringValue
+	77, // 119: notifications.NotificationService.RegisterPushSubscription:output_type -> google.protobuf.Empty
+	77, // 120: notifications.NotificationService.UnregisterPushSubscription:output_type -> google.protobuf.Empty
+	85, // [85:121] is the sub-list for method output_type
+	49, // [49:85] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_notifications_proto_init() }
@@ -4738,7 +5864,7 @@ func file_notifications_proto_init() {
 	if File_notifications_proto != nil {
 		return
 	}
-	file_notifications_proto_msgTypes[49].OneofWrappers = []any{
+	file_notifications_proto_msgTypes[60].OneofWrappers = []any{
 		(*NotificationEvent_PostCommentCreated)(nil),
 		(*NotificationEvent_PostLiked)(nil),
 		(*NotificationEvent_FollowRequestCreated)(nil),
@@ -4756,6 +5882,8 @@ func file_notifications_proto_init() {
 		(*NotificationEvent_GroupJoinRequestRejected)(nil),
 		(*NotificationEvent_FollowRequestCancelled)(nil),
 		(*NotificationEvent_GroupJoinRequestCancelled)(nil),
+		(*NotificationEvent_PostPublished)(nil),
+		(*NotificationEvent_PostShared)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -4763,7 +5891,7 @@ func file_notifications_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notifications_proto_rawDesc), len(file_notifications_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   56,
+			NumMessages:   71,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
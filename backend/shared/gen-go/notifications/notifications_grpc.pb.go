@@ -46,9 +46,17 @@ const (
 	NotificationService_MarkNotificationAsRead_FullMethodName            = "/notifications.NotificationService/MarkNotificationAsRead"
 	NotificationService_MarkNotificationAsActed_FullMethodName           = "/notifications.NotificationService/MarkNotificationAsActed"
 	NotificationService_MarkAllAsRead_FullMethodName                     = "/notifications.NotificationService/MarkAllAsRead"
+	NotificationService_MarkReadByEntity_FullMethodName                  = "/notifications.NotificationService/MarkReadByEntity"
+	NotificationService_MarkReadByCategory_FullMethodName                = "/notifications.NotificationService/MarkReadByCategory"
 	NotificationService_DeleteNotification_FullMethodName                = "/notifications.NotificationService/DeleteNotification"
 	NotificationService_GetNotificationPreferences_FullMethodName        = "/notifications.NotificationService/GetNotificationPreferences"
 	NotificationService_UpdateNotificationPreferences_FullMethodName     = "/notifications.NotificationService/UpdateNotificationPreferences"
+	NotificationService_MuteNotifications_FullMethodName                 = "/notifications.NotificationService/MuteNotifications"
+	NotificationService_UnmuteNotifications_FullMethodName               = "/notifications.NotificationService/UnmuteNotifications"
+	NotificationService_UnsubscribeEmail_FullMethodName                  = "/notifications.NotificationService/UnsubscribeEmail"
+	NotificationService_GetWebPushPublicKey_FullMethodName               = "/notifications.NotificationService/GetWebPushPublicKey"
+	NotificationService_RegisterPushSubscription_FullMethodName          = "/notifications.NotificationService/RegisterPushSubscription"
+	NotificationService_UnregisterPushSubscription_FullMethodName        = "/notifications.NotificationService/UnregisterPushSubscription"
 )
 
 // NotificationServiceClient is the client API for NotificationService service.
@@ -105,7 +113,8 @@ type NotificationServiceClient interface {
 	CreateGroupJoinRequestRejected(ctx context.Context, in *CreateGroupJoinRequestRejectedRequest, opts ...grpc.CallOption) (*Notification, error)
 	// Get user notifications
 	// Retrieves a user's notifications with optional filtering.
-	// Returns Error: INVALID_ARGUMENT when user_id is zero; INTERNAL otherwise. Desired: PERMISSION_DENIED/NOT_FOUND as applicable.
+	// Grouped requests return groups of notifications about the same entity, see NotificationGroup.
+	// Returns Error: INVALID_ARGUMENT when user_id is zero or the cursor is malformed; INTERNAL otherwise. Desired: PERMISSION_DENIED/NOT_FOUND as applicable.
 	GetUserNotifications(ctx context.Context, in *GetUserNotificationsRequest, opts ...grpc.CallOption) (*GetUserNotificationsResponse, error)
 	// Returns the unread notifications count for a user.
 	// Returns Error: INVALID_ARGUMENT when user_id is zero; INTERNAL otherwise. Desired: NOT_FOUND for missing user.
@@ -120,16 +129,41 @@ type NotificationServiceClient interface {
 	// Marks all notifications as read for the user.
 	// Returns Error: INVALID_ARGUMENT when user_id is zero; INTERNAL otherwise. Desired: NOT_FOUND/PERMISSION_DENIED as applicable.
 	MarkAllAsRead(ctx context.Context, in *wrapperspb.Int64Value, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Marks the notifications of a user about an entity as read, e.g. the new messages of a conversation once it is opened.
+	// Returns Error: INVALID_ARGUMENT when user_id, source_service or source_entity_id is missing; INTERNAL otherwise.
+	MarkReadByEntity(ctx context.Context, in *MarkReadByEntityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Marks the notifications of a user in a category as read.
+	// Returns Error: INVALID_ARGUMENT when user_id or category is missing; INTERNAL otherwise.
+	MarkReadByCategory(ctx context.Context, in *MarkReadByCategoryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Deletes a single notification for the owner.
 	// Returns Error: INVALID_ARGUMENT when ids are zero; INTERNAL otherwise. Desired: NOT_FOUND/PERMISSION_DENIED as applicable.
 	DeleteNotification(ctx context.Context, in *DeleteNotificationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Notification preferences
-	// Retrieves notification preferences for a user.
-	// Returns Error: always returns defaults. Desired: NOT_FOUND/PERMISSION_DENIED when implemented.
+	// Retrieves the effective notification preferences of a user: every type with defaults applied, the categories set and the active mutes.
+	// Returns Error: INVALID_ARGUMENT when user id is zero; INTERNAL otherwise.
 	GetNotificationPreferences(ctx context.Context, in *wrapperspb.Int64Value, opts ...grpc.CallOption) (*NotificationPreferences, error)
-	// Updates notification preferences.
-	// Returns Error: always succeeds. Desired: INVALID_ARGUMENT/NOT_FOUND/PERMISSION_DENIED when implemented.
+	// Enables or disables notification types and categories and sets the email mode. Settings not in the request are kept.
+	// Returns Error: INVALID_ARGUMENT when user id is zero or a type/category/email mode is unknown; INTERNAL otherwise.
 	UpdateNotificationPreferences(ctx context.Context, in *UpdateNotificationPreferencesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Silences notifications about a group or conversation, or caused by a user, until muted_until or until unmuted.
+	// Returns Error: INVALID_ARGUMENT when ids are zero, the target type is unknown or muted_until is past; INTERNAL otherwise.
+	MuteNotifications(ctx context.Context, in *MuteNotificationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Removes a mute. Removing a missing mute succeeds.
+	// Returns Error: INVALID_ARGUMENT when user id is zero or the target type is unknown; INTERNAL otherwise.
+	UnmuteNotifications(ctx context.Context, in *UnmuteNotificationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Turns email off for the user an unsubscribe link was sent to. Needs no login, the token is signed.
+	// Returns Error: INVALID_ARGUMENT when the token is bad; INTERNAL otherwise.
+	UnsubscribeEmail(ctx context.Context, in *UnsubscribeEmailRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Web Push
+	// Returns the VAPID public key browsers subscribe with (applicationServerKey).
+	// Returns Error: UNAVAILABLE when push is not configured.
+	GetWebPushPublicKey(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.StringValue, error)
+	// Stores a browser push subscription of a user. Offline users get their new notifications pushed to it.
+	// Returns Error: INVALID_ARGUMENT when user id is zero or the subscription is malformed; UNAVAILABLE when push is not configured; INTERNAL otherwise.
+	RegisterPushSubscription(ctx context.Context, in *RegisterPushSubscriptionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Removes a browser push subscription of a user. Removing a missing subscription succeeds.
+	// Returns Error: INVALID_ARGUMENT when user id or endpoint is empty; UNAVAILABLE when push is not configured; INTERNAL otherwise.
+	UnregisterPushSubscription(ctx context.Context, in *UnregisterPushSubscriptionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type notificationServiceClient struct {
@@ -390,6 +424,26 @@ func (c *notificationServiceClient) MarkAllAsRead(ctx context.Context, in *wrapp
 	return out, nil
 }
 
+func (c *notificationServiceClient) MarkReadByEntity(ctx context.Context, in *MarkReadByEntityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NotificationService_MarkReadByEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) MarkReadByCategory(ctx context.Context, in *MarkReadByCategoryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NotificationService_MarkReadByCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *notificationServiceClient) DeleteNotification(ctx context.Context, in *DeleteNotificationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -420,6 +474,66 @@ func (c *notificationServiceClient) UpdateNotificationPreferences(ctx context.Co
 	return out, nil
 }
 
+func (c *notificationServiceClient) MuteNotifications(ctx context.Context, in *MuteNotificationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NotificationService_MuteNotifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) UnmuteNotifications(ctx context.Context, in *UnmuteNotificationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NotificationService_UnmuteNotifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) UnsubscribeEmail(ctx context.Context, in *UnsubscribeEmailRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NotificationService_UnsubscribeEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) GetWebPushPublicKey(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.StringValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(wrapperspb.StringValue)
+	err := c.cc.Invoke(ctx, NotificationService_GetWebPushPublicKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) RegisterPushSubscription(ctx context.Context, in *RegisterPushSubscriptionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NotificationService_RegisterPushSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) UnregisterPushSubscription(ctx context.Context, in *UnregisterPushSubscriptionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NotificationService_UnregisterPushSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotificationServiceServer is the server API for NotificationService service.
 // All implementations must embed UnimplementedNotificationServiceServer
 // for forward compatibility.
@@ -474,7 +588,8 @@ type NotificationServiceServer interface {
 	CreateGroupJoinRequestRejected(context.Context, *CreateGroupJoinRequestRejectedRequest) (*Notification, error)
 	// Get user notifications
 	// Retrieves a user's notifications with optional filtering.
-	// Returns Error: INVALID_ARGUMENT when user_id is zero; INTERNAL otherwise. Desired: PERMISSION_DENIED/NOT_FOUND as applicable.
+	// Grouped requests return groups of notifications about the same entity, see NotificationGroup.
+	// Returns Error: INVALID_ARGUMENT when user_id is zero or the cursor is malformed; INTERNAL otherwise. Desired: PERMISSION_DENIED/NOT_FOUND as applicable.
 	GetUserNotifications(context.Context, *GetUserNotificationsRequest) (*GetUserNotificationsResponse, error)
 	// Returns the unread notifications count for a user.
 	// Returns Error: INVALID_ARGUMENT when user_id is zero; INTERNAL otherwise. Desired: NOT_FOUND for missing user.
@@ -489,16 +604,41 @@ type NotificationServiceServer interface {
 	// Marks all notifications as read for the user.
 	// Returns Error: INVALID_ARGUMENT when user_id is zero; INTERNAL otherwise. Desired: NOT_FOUND/PERMISSION_DENIED as applicable.
 	MarkAllAsRead(context.Context, *wrapperspb.Int64Value) (*emptypb.Empty, error)
+	// Marks the notifications of a user about an entity as read, e.g. the new messages of a conversation once it is opened.
+	// Returns Error: INVALID_ARGUMENT when user_id, source_service or source_entity_id is missing; INTERNAL otherwise.
+	MarkReadByEntity(context.Context, *MarkReadByEntityRequest) (*emptypb.Empty, error)
+	// Marks the notifications of a user in a category as read.
+	// Returns Error: INVALID_ARGUMENT when user_id or category is missing; INTERNAL otherwise.
+	MarkReadByCategory(context.Context, *MarkReadByCategoryRequest) (*emptypb.Empty, error)
 	// Deletes a single notification for the owner.
 	// Returns Error: INVALID_ARGUMENT when ids are zero; INTERNAL otherwise. Desired: NOT_FOUND/PERMISSION_DENIED as applicable.
 	DeleteNotification(context.Context, *DeleteNotificationRequest) (*emptypb.Empty, error)
 	// Notification preferences
-	// Retrieves notification preferences for a user.
-	// Returns Error: always returns defaults. Desired: NOT_FOUND/PERMISSION_DENIED when implemented.
+	// Retrieves the effective notification preferences of a user: every type with defaults applied, the categories set and the active mutes.
+	// Returns Error: INVALID_ARGUMENT when user id is zero; INTERNAL otherwise.
 	GetNotificationPreferences(context.Context, *wrapperspb.Int64Value) (*NotificationPreferences, error)
-	// Updates notification preferences.
-	// Returns Error: always succeeds. Desired: INVALID_ARGUMENT/NOT_FOUND/PERMISSION_DENIED when implemented.
+	// Enables or disables notification types and categories and sets the email mode. Settings not in the request are kept.
+	// Returns Error: INVALID_ARGUMENT when user id is zero or a type/category/email mode is unknown; INTERNAL otherwise.
 	UpdateNotificationPreferences(context.Context, *UpdateNotificationPreferencesRequest) (*emptypb.Empty, error)
+	// Silences notifications about a group or conversation, or caused by a user, until muted_until or until unmuted.
+	// Returns Error: INVALID_ARGUMENT when ids are zero, the target type is unknown or muted_until is past; INTERNAL otherwise.
+	MuteNotifications(context.Context, *MuteNotificationsRequest) (*emptypb.Empty, error)
+	// Removes a mute. Removing a missing mute succeeds.
+	// Returns Error: INVALID_ARGUMENT when user id is zero or the target type is unknown; INTERNAL otherwise.
+	UnmuteNotifications(context.Context, *UnmuteNotificationsRequest) (*emptypb.Empty, error)
+	// Turns email off for the user an unsubscribe link was sent to. Needs no login, the token is signed.
+	// Returns Error: INVALID_ARGUMENT when the token is bad; INTERNAL otherwise.
+	UnsubscribeEmail(context.Context, *UnsubscribeEmailRequest) (*emptypb.Empty, error)
+	// Web Push
+	// Returns the VAPID public key browsers subscribe with (applicationServerKey).
+	// Returns Error: UNAVAILABLE when push is not configured.
+	GetWebPushPublicKey(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error)
+	// Stores a browser push subscription of a user. Offline users get their new notifications pushed to it.
+	// Returns Error: INVALID_ARGUMENT when user id is zero or the subscription is malformed; UNAVAILABLE when push is not configured; INTERNAL otherwise.
+	RegisterPushSubscription(context.Context, *RegisterPushSubscriptionRequest) (*emptypb.Empty, error)
+	// Removes a browser push subscription of a user. Removing a missing subscription succeeds.
+	// Returns Error: INVALID_ARGUMENT when user id or endpoint is empty; UNAVAILABLE when push is not configured; INTERNAL otherwise.
+	UnregisterPushSubscription(context.Context, *UnregisterPushSubscriptionRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedNotificationServiceServer()
 }
 
@@ -584,6 +724,12 @@ func (UnimplementedNotificationServiceServer) MarkNotificationAsActed(context.Co
 func (UnimplementedNotificationServiceServer) MarkAllAsRead(context.Context, *wrapperspb.Int64Value) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method MarkAllAsRead not implemented")
 }
+func (UnimplementedNotificationServiceServer) MarkReadByEntity(context.Context, *MarkReadByEntityRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method MarkReadByEntity not implemented")
+}
+func (UnimplementedNotificationServiceServer) MarkReadByCategory(context.Context, *MarkReadByCategoryRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method MarkReadByCategory not implemented")
+}
 func (UnimplementedNotificationServiceServer) DeleteNotification(context.Context, *DeleteNotificationRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteNotification not implemented")
 }
@@ -593,6 +739,24 @@ func (UnimplementedNotificationServiceServer) GetNotificationPreferences(context
 func (UnimplementedNotificationServiceServer) UpdateNotificationPreferences(context.Context, *UpdateNotificationPreferencesRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateNotificationPreferences not implemented")
 }
+func (UnimplementedNotificationServiceServer) MuteNotifications(context.Context, *MuteNotificationsRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method MuteNotifications not implemented")
+}
+func (UnimplementedNotificationServiceServer) UnmuteNotifications(context.Context, *UnmuteNotificationsRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnmuteNotifications not implemented")
+}
+func (UnimplementedNotificationServiceServer) UnsubscribeEmail(context.Context, *UnsubscribeEmailRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnsubscribeEmail not implemented")
+}
+func (UnimplementedNotificationServiceServer) GetWebPushPublicKey(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWebPushPublicKey not implemented")
+}
+func (UnimplementedNotificationServiceServer) RegisterPushSubscription(context.Context, *RegisterPushSubscriptionRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterPushSubscription not implemented")
+}
+func (UnimplementedNotificationServiceServer) UnregisterPushSubscription(context.Context, *UnregisterPushSubscriptionRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnregisterPushSubscription not implemented")
+}
 func (UnimplementedNotificationServiceServer) mustEmbedUnimplementedNotificationServiceServer() {}
 func (UnimplementedNotificationServiceServer) testEmbeddedByValue()                             {}
 
@@ -1064,6 +1228,42 @@ func _NotificationService_MarkAllAsRead_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotificationService_MarkReadByEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkReadByEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).MarkReadByEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_MarkReadByEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).MarkReadByEntity(ctx, req.(*MarkReadByEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_MarkReadByCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkReadByCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).MarkReadByCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_MarkReadByCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).MarkReadByCategory(ctx, req.(*MarkReadByCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NotificationService_DeleteNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteNotificationRequest)
 	if err := dec(in); err != nil {
@@ -1118,6 +1318,114 @@ func _NotificationService_UpdateNotificationPreferences_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotificationService_MuteNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).MuteNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_MuteNotifications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).MuteNotifications(ctx, req.(*MuteNotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_UnmuteNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnmuteNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).UnmuteNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_UnmuteNotifications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).UnmuteNotifications(ctx, req.(*UnmuteNotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_UnsubscribeEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).UnsubscribeEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_UnsubscribeEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).UnsubscribeEmail(ctx, req.(*UnsubscribeEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_GetWebPushPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).GetWebPushPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_GetWebPushPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).GetWebPushPublicKey(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_RegisterPushSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterPushSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).RegisterPushSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_RegisterPushSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).RegisterPushSubscription(ctx, req.(*RegisterPushSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_UnregisterPushSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnregisterPushSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).UnregisterPushSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_UnregisterPushSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).UnregisterPushSubscription(ctx, req.(*UnregisterPushSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NotificationService_ServiceDesc is the grpc.ServiceDesc for NotificationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1225,6 +1533,14 @@ var NotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MarkAllAsRead",
 			Handler:    _NotificationService_MarkAllAsRead_Handler,
 		},
+		{
+			MethodName: "MarkReadByEntity",
+			Handler:    _NotificationService_MarkReadByEntity_Handler,
+		},
+		{
+			MethodName: "MarkReadByCategory",
+			Handler:    _NotificationService_MarkReadByCategory_Handler,
+		},
 		{
 			MethodName: "DeleteNotification",
 			Handler:    _NotificationService_DeleteNotification_Handler,
@@ -1237,6 +1553,30 @@ var NotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateNotificationPreferences",
 			Handler:    _NotificationService_UpdateNotificationPreferences_Handler,
 		},
+		{
+			MethodName: "MuteNotifications",
+			Handler:    _NotificationService_MuteNotifications_Handler,
+		},
+		{
+			MethodName: "UnmuteNotifications",
+			Handler:    _NotificationService_UnmuteNotifications_Handler,
+		},
+		{
+			MethodName: "UnsubscribeEmail",
+			Handler:    _NotificationService_UnsubscribeEmail_Handler,
+		},
+		{
+			MethodName: "GetWebPushPublicKey",
+			Handler:    _NotificationService_GetWebPushPublicKey_Handler,
+		},
+		{
+			MethodName: "RegisterPushSubscription",
+			Handler:    _NotificationService_RegisterPushSubscription_Handler,
+		},
+		{
+			MethodName: "UnregisterPushSubscription",
+			Handler:    _NotificationService_UnregisterPushSubscription_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "notifications.proto",
@@ -117,6 +117,50 @@ func (x *IdResp) GetId() int64 {
 	return 0
 }
 
+type ImageIds struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImageIds      []int64                `protobuf:"varint,1,rep,packed,name=image_ids,json=imageIds,proto3" json:"image_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageIds) Reset() {
+	*x = ImageIds{}
+	mi := &file_posts_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageIds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageIds) ProtoMessage() {}
+
+func (x *ImageIds) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageIds.ProtoReflect.Descriptor instead.
+func (*ImageIds) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ImageIds) GetImageIds() []int64 {
+	if x != nil {
+		return x.ImageIds
+	}
+	return nil
+}
+
 // response message for a post's audience
 type AudienceResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -127,7 +171,7 @@ type AudienceResp struct {
 
 func (x *AudienceResp) Reset() {
 	*x = AudienceResp{}
-	mi := &file_posts_proto_msgTypes[2]
+	mi := &file_posts_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -139,7 +183,7 @@ func (x *AudienceResp) String() string {
 func (*AudienceResp) ProtoMessage() {}
 
 func (x *AudienceResp) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[2]
+	mi := &file_posts_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -152,7 +196,7 @@ func (x *AudienceResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AudienceResp.ProtoReflect.Descriptor instead.
 func (*AudienceResp) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{2}
+	return file_posts_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *AudienceResp) GetAudience() string {
@@ -175,7 +219,7 @@ type GenericReq struct {
 
 func (x *GenericReq) Reset() {
 	*x = GenericReq{}
-	mi := &file_posts_proto_msgTypes[3]
+	mi := &file_posts_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -187,7 +231,7 @@ func (x *GenericReq) String() string {
 func (*GenericReq) ProtoMessage() {}
 
 func (x *GenericReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[3]
+	mi := &file_posts_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -200,7 +244,7 @@ func (x *GenericReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenericReq.ProtoReflect.Descriptor instead.
 func (*GenericReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{3}
+	return file_posts_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GenericReq) GetRequesterId() int64 {
@@ -222,18 +266,20 @@ func (x *GenericReq) GetEntityId() int64 {
 // an entity id (can be post, comment, event, etc)
 // and pagination information
 type EntityIdPaginatedReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RequesterId   int64                  `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
-	EntityId      int64                  `protobuf:"varint,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	RequesterId int64                  `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	EntityId    int64                  `protobuf:"varint,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Limit       int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Deprecated: Marked as deprecated in posts.proto.
+	Offset        int32  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"` // use cursor, ignored when cursor is set
+	Cursor        string `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`  // next_cursor of the previous page, empty for the first page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *EntityIdPaginatedReq) Reset() {
 	*x = EntityIdPaginatedReq{}
-	mi := &file_posts_proto_msgTypes[4]
+	mi := &file_posts_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -245,7 +291,7 @@ func (x *EntityIdPaginatedReq) String() string {
 func (*EntityIdPaginatedReq) ProtoMessage() {}
 
 func (x *EntityIdPaginatedReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[4]
+	mi := &file_posts_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -258,7 +304,7 @@ func (x *EntityIdPaginatedReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EntityIdPaginatedReq.ProtoReflect.Descriptor instead.
 func (*EntityIdPaginatedReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{4}
+	return file_posts_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *EntityIdPaginatedReq) GetRequesterId() int64 {
@@ -282,6 +328,7 @@ func (x *EntityIdPaginatedReq) GetLimit() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in posts.proto.
 func (x *EntityIdPaginatedReq) GetOffset() int32 {
 	if x != nil {
 		return x.Offset
@@ -289,21 +336,31 @@ func (x *EntityIdPaginatedReq) GetOffset() int32 {
 	return 0
 }
 
+func (x *EntityIdPaginatedReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 // generic request message that includes
 // the id of the requesting user
 // and pagination information
 type GenericPaginatedReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RequesterId   int64                  `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	RequesterId int64                  `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	Limit       int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Deprecated: Marked as deprecated in posts.proto.
+	Offset        int32  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"` // use cursor, ignored when cursor is set
+	Cursor        string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`  // next_cursor of the previous page, empty for the first page
+	Sort          string `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`      // "recent" (default) or "top", only used by GetPublicFeed
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GenericPaginatedReq) Reset() {
 	*x = GenericPaginatedReq{}
-	mi := &file_posts_proto_msgTypes[5]
+	mi := &file_posts_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -315,7 +372,7 @@ func (x *GenericPaginatedReq) String() string {
 func (*GenericPaginatedReq) ProtoMessage() {}
 
 func (x *GenericPaginatedReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[5]
+	mi := &file_posts_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -328,7 +385,7 @@ func (x *GenericPaginatedReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenericPaginatedReq.ProtoReflect.Descriptor instead.
 func (*GenericPaginatedReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{5}
+	return file_posts_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GenericPaginatedReq) GetRequesterId() int64 {
@@ -345,6 +402,7 @@ func (x *GenericPaginatedReq) GetLimit() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in posts.proto.
 func (x *GenericPaginatedReq) GetOffset() int32 {
 	if x != nil {
 		return x.Offset
@@ -352,6 +410,20 @@ func (x *GenericPaginatedReq) GetOffset() int32 {
 	return 0
 }
 
+func (x *GenericPaginatedReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GenericPaginatedReq) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
 // generic request message that includes
 // the entity id (eg post, comment)
 // and pagination information
@@ -366,7 +438,7 @@ type GenericEntityPaginatedReq struct {
 
 func (x *GenericEntityPaginatedReq) Reset() {
 	*x = GenericEntityPaginatedReq{}
-	mi := &file_posts_proto_msgTypes[6]
+	mi := &file_posts_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -378,7 +450,7 @@ func (x *GenericEntityPaginatedReq) String() string {
 func (*GenericEntityPaginatedReq) ProtoMessage() {}
 
 func (x *GenericEntityPaginatedReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[6]
+	mi := &file_posts_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,7 +463,7 @@ func (x *GenericEntityPaginatedReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenericEntityPaginatedReq.ProtoReflect.Descriptor instead.
 func (*GenericEntityPaginatedReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{6}
+	return file_posts_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GenericEntityPaginatedReq) GetEntityId() int64 {
@@ -432,13 +504,18 @@ type Post struct {
 	ImageId               int64                  `protobuf:"varint,12,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`                                            //can be 0, meaning no associated image
 	ImageUrl              string                 `protobuf:"bytes,13,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`                                          // can be an empty string if image_id is 0
 	SelectedAudienceUsers *common.ListUsers      `protobuf:"bytes,14,opt,name=selected_audience_users,json=selectedAudienceUsers,proto3" json:"selected_audience_users,omitempty"` //empty unless audience="selected"
+	Status                string                 `protobuf:"bytes,15,opt,name=status,proto3" json:"status,omitempty"`                                                              // one of "draft", "scheduled", "published"
+	PublishAt             *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`                                       // only set for scheduled posts
+	Poll                  *Poll                  `protobuf:"bytes,17,opt,name=poll,proto3" json:"poll,omitempty"`                                                                  // null unless a poll is attached to the post
+	SharesCount           int32                  `protobuf:"varint,18,opt,name=shares_count,json=sharesCount,proto3" json:"shares_count,omitempty"`
+	SharedPost            *SharedPost            `protobuf:"bytes,19,opt,name=shared_post,json=sharedPost,proto3" json:"shared_post,omitempty"` // null unless the post is a share
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
 
 func (x *Post) Reset() {
 	*x = Post{}
-	mi := &file_posts_proto_msgTypes[7]
+	mi := &file_posts_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -450,7 +527,7 @@ func (x *Post) String() string {
 func (*Post) ProtoMessage() {}
 
 func (x *Post) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[7]
+	mi := &file_posts_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -463,7 +540,7 @@ func (x *Post) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Post.ProtoReflect.Descriptor instead.
 func (*Post) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{7}
+	return file_posts_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Post) GetPostId() int64 {
@@ -564,29 +641,75 @@ func (x *Post) GetSelectedAudienceUsers() *common.ListUsers {
 	return nil
 }
 
-// Response message with multiple posts
-type ListPosts struct {
+func (x *Post) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Post) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *Post) GetPoll() *Poll {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+func (x *Post) GetSharesCount() int32 {
+	if x != nil {
+		return x.SharesCount
+	}
+	return 0
+}
+
+func (x *Post) GetSharedPost() *SharedPost {
+	if x != nil {
+		return x.SharedPost
+	}
+	return nil
+}
+
+// Original post of a share.
+// If available is false the original was deleted or can no longer be seen,
+// and only post_id is set.
+type SharedPost struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Posts         []*Post                `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	PostId        int64                  `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Available     bool                   `protobuf:"varint,2,opt,name=available,proto3" json:"available,omitempty"`
+	PostBody      string                 `protobuf:"bytes,3,opt,name=post_body,json=postBody,proto3" json:"post_body,omitempty"`
+	User          *common.User           `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	GroupId       int64                  `protobuf:"varint,5,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Audience      string                 `protobuf:"bytes,6,opt,name=audience,proto3" json:"audience,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ImageId       int64                  `protobuf:"varint,9,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,10,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPosts) Reset() {
-	*x = ListPosts{}
-	mi := &file_posts_proto_msgTypes[8]
+func (x *SharedPost) Reset() {
+	*x = SharedPost{}
+	mi := &file_posts_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPosts) String() string {
+func (x *SharedPost) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPosts) ProtoMessage() {}
+func (*SharedPost) ProtoMessage() {}
 
-func (x *ListPosts) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[8]
+func (x *SharedPost) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -597,46 +720,113 @@ func (x *ListPosts) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPosts.ProtoReflect.Descriptor instead.
-func (*ListPosts) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use SharedPost.ProtoReflect.Descriptor instead.
+func (*SharedPost) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ListPosts) GetPosts() []*Post {
+func (x *SharedPost) GetPostId() int64 {
 	if x != nil {
-		return x.Posts
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *SharedPost) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *SharedPost) GetPostBody() string {
+	if x != nil {
+		return x.PostBody
+	}
+	return ""
+}
+
+func (x *SharedPost) GetUser() *common.User {
+	if x != nil {
+		return x.User
 	}
 	return nil
 }
 
-// Request message for creating a post
-type CreatePostReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CreatorId     int64                  `protobuf:"varint,1,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
-	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
-	GroupId       int64                  `protobuf:"varint,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`            //can be 0 if audience is not "group"
-	Audience      string                 `protobuf:"bytes,4,opt,name=audience,proto3" json:"audience,omitempty"`                          // one of "everyone", "followers","selected","group"
-	AudienceIds   *common.UserIds        `protobuf:"bytes,5,opt,name=audience_ids,json=audienceIds,proto3" json:"audience_ids,omitempty"` // empty unless audience="selected"
-	ImageId       int64                  `protobuf:"varint,6,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`            //can be 0 if no image
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SharedPost) GetGroupId() int64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
 }
 
-func (x *CreatePostReq) Reset() {
-	*x = CreatePostReq{}
-	mi := &file_posts_proto_msgTypes[9]
+func (x *SharedPost) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *SharedPost) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SharedPost) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *SharedPost) GetImageId() int64 {
+	if x != nil {
+		return x.ImageId
+	}
+	return 0
+}
+
+func (x *SharedPost) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+// Poll attached to a post.
+// Option vote counts are 0 unless results_visible is true,
+// which is once the requester has voted or the poll has closed.
+type Poll struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PollId         int64                  `protobuf:"varint,1,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`
+	Question       string                 `protobuf:"bytes,2,opt,name=question,proto3" json:"question,omitempty"`
+	Options        []*PollOption          `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty"`
+	MultipleChoice bool                   `protobuf:"varint,4,opt,name=multiple_choice,json=multipleChoice,proto3" json:"multiple_choice,omitempty"`
+	ClosesAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"` // null if the poll never closes
+	Closed         bool                   `protobuf:"varint,6,opt,name=closed,proto3" json:"closed,omitempty"`
+	VotersCount    int32                  `protobuf:"varint,7,opt,name=voters_count,json=votersCount,proto3" json:"voters_count,omitempty"`
+	ResultsVisible bool                   `protobuf:"varint,8,opt,name=results_visible,json=resultsVisible,proto3" json:"results_visible,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Poll) Reset() {
+	*x = Poll{}
+	mi := &file_posts_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePostReq) String() string {
+func (x *Poll) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePostReq) ProtoMessage() {}
+func (*Poll) ProtoMessage() {}
 
-func (x *CreatePostReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[9]
+func (x *Poll) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -647,51 +837,805 @@ func (x *CreatePostReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePostReq.ProtoReflect.Descriptor instead.
-func (*CreatePostReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use Poll.ProtoReflect.Descriptor instead.
+func (*Poll) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CreatePostReq) GetCreatorId() int64 {
+func (x *Poll) GetPollId() int64 {
 	if x != nil {
-		return x.CreatorId
+		return x.PollId
+	}
+	return 0
+}
+
+func (x *Poll) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *Poll) GetOptions() []*PollOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Poll) GetMultipleChoice() bool {
+	if x != nil {
+		return x.MultipleChoice
+	}
+	return false
+}
+
+func (x *Poll) GetClosesAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return nil
+}
+
+func (x *Poll) GetClosed() bool {
+	if x != nil {
+		return x.Closed
+	}
+	return false
+}
+
+func (x *Poll) GetVotersCount() int32 {
+	if x != nil {
+		return x.VotersCount
 	}
 	return 0
 }
 
-func (x *CreatePostReq) GetBody() string {
-	if x != nil {
-		return x.Body
-	}
-	return ""
+func (x *Poll) GetResultsVisible() bool {
+	if x != nil {
+		return x.ResultsVisible
+	}
+	return false
+}
+
+type PollOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OptionId      int64                  `protobuf:"varint,1,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	VotesCount    int32                  `protobuf:"varint,3,opt,name=votes_count,json=votesCount,proto3" json:"votes_count,omitempty"`
+	VotedByUser   bool                   `protobuf:"varint,4,opt,name=voted_by_user,json=votedByUser,proto3" json:"voted_by_user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollOption) Reset() {
+	*x = PollOption{}
+	mi := &file_posts_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollOption) ProtoMessage() {}
+
+func (x *PollOption) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollOption.ProtoReflect.Descriptor instead.
+func (*PollOption) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PollOption) GetOptionId() int64 {
+	if x != nil {
+		return x.OptionId
+	}
+	return 0
+}
+
+func (x *PollOption) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *PollOption) GetVotesCount() int32 {
+	if x != nil {
+		return x.VotesCount
+	}
+	return 0
+}
+
+func (x *PollOption) GetVotedByUser() bool {
+	if x != nil {
+		return x.VotedByUser
+	}
+	return false
+}
+
+// Response message with multiple posts
+type ListPosts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Posts         []*Post                `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // empty when there are no more pages
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPosts) Reset() {
+	*x = ListPosts{}
+	mi := &file_posts_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPosts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPosts) ProtoMessage() {}
+
+func (x *ListPosts) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPosts.ProtoReflect.Descriptor instead.
+func (*ListPosts) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListPosts) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *ListPosts) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Request message for creating a post
+type CreatePostReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatorId     int64                  `protobuf:"varint,1,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	GroupId       int64                  `protobuf:"varint,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`            //can be 0 if audience is not "group"
+	Audience      string                 `protobuf:"bytes,4,opt,name=audience,proto3" json:"audience,omitempty"`                          // one of "everyone", "followers","selected","group"
+	AudienceIds   *common.UserIds        `protobuf:"bytes,5,opt,name=audience_ids,json=audienceIds,proto3" json:"audience_ids,omitempty"` // empty unless audience="selected"
+	ImageId       int64                  `protobuf:"varint,6,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`            //can be 0 if no image
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`                              // one of "draft", "scheduled", "published". Empty means published
+	PublishAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`       // required if status is "scheduled"
+	Poll          *CreatePollReq         `protobuf:"bytes,9,opt,name=poll,proto3" json:"poll,omitempty"`                                  // optional poll attached to the post
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePostReq) Reset() {
+	*x = CreatePostReq{}
+	mi := &file_posts_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePostReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePostReq) ProtoMessage() {}
+
+func (x *CreatePostReq) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePostReq.ProtoReflect.Descriptor instead.
+func (*CreatePostReq) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreatePostReq) GetCreatorId() int64 {
+	if x != nil {
+		return x.CreatorId
+	}
+	return 0
+}
+
+func (x *CreatePostReq) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *CreatePostReq) GetGroupId() int64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *CreatePostReq) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *CreatePostReq) GetAudienceIds() *common.UserIds {
+	if x != nil {
+		return x.AudienceIds
+	}
+	return nil
+}
+
+func (x *CreatePostReq) GetImageId() int64 {
+	if x != nil {
+		return x.ImageId
+	}
+	return 0
+}
+
+func (x *CreatePostReq) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreatePostReq) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *CreatePostReq) GetPoll() *CreatePollReq {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+// Poll part of a create post request
+type CreatePollReq struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Question       string                 `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	Options        []string               `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"` // 2-10 options, in display order
+	MultipleChoice bool                   `protobuf:"varint,3,opt,name=multiple_choice,json=multipleChoice,proto3" json:"multiple_choice,omitempty"`
+	ClosesAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"` // optional
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreatePollReq) Reset() {
+	*x = CreatePollReq{}
+	mi := &file_posts_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePollReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePollReq) ProtoMessage() {}
+
+func (x *CreatePollReq) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePollReq.ProtoReflect.Descriptor instead.
+func (*CreatePollReq) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreatePollReq) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *CreatePollReq) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *CreatePollReq) GetMultipleChoice() bool {
+	if x != nil {
+		return x.MultipleChoice
+	}
+	return false
+}
+
+func (x *CreatePollReq) GetClosesAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return nil
+}
+
+// Request message for voting on a poll
+type VotePollReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequesterId   int64                  `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	PollId        int64                  `protobuf:"varint,2,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`
+	OptionIds     []int64                `protobuf:"varint,3,rep,packed,name=option_ids,json=optionIds,proto3" json:"option_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VotePollReq) Reset() {
+	*x = VotePollReq{}
+	mi := &file_posts_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VotePollReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VotePollReq) ProtoMessage() {}
+
+func (x *VotePollReq) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VotePollReq.ProtoReflect.Descriptor instead.
+func (*VotePollReq) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *VotePollReq) GetRequesterId() int64 {
+	if x != nil {
+		return x.RequesterId
+	}
+	return 0
+}
+
+func (x *VotePollReq) GetPollId() int64 {
+	if x != nil {
+		return x.PollId
+	}
+	return 0
+}
+
+func (x *VotePollReq) GetOptionIds() []int64 {
+	if x != nil {
+		return x.OptionIds
+	}
+	return nil
+}
+
+// Request message for sharing a post
+type SharePostReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequesterId   int64                  `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	PostId        int64                  `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`               // the post being shared
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`                                  // optional quote text
+	GroupId       int64                  `protobuf:"varint,4,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`            //can be 0 if audience is not "group"
+	Audience      string                 `protobuf:"bytes,5,opt,name=audience,proto3" json:"audience,omitempty"`                          // one of "everyone", "followers","selected","group"
+	AudienceIds   *common.UserIds        `protobuf:"bytes,6,opt,name=audience_ids,json=audienceIds,proto3" json:"audience_ids,omitempty"` // empty unless audience="selected"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharePostReq) Reset() {
+	*x = SharePostReq{}
+	mi := &file_posts_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharePostReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharePostReq) ProtoMessage() {}
+
+func (x *SharePostReq) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharePostReq.ProtoReflect.Descriptor instead.
+func (*SharePostReq) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SharePostReq) GetRequesterId() int64 {
+	if x != nil {
+		return x.RequesterId
+	}
+	return 0
+}
+
+func (x *SharePostReq) GetPostId() int64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *SharePostReq) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *SharePostReq) GetGroupId() int64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *SharePostReq) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *SharePostReq) GetAudienceIds() *common.UserIds {
+	if x != nil {
+		return x.AudienceIds
+	}
+	return nil
+}
+
+// Request message for saving a post
+type SaveEntityReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequesterId   int64                  `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	EntityId      int64                  `protobuf:"varint,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"` // optional collection name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveEntityReq) Reset() {
+	*x = SaveEntityReq{}
+	mi := &file_posts_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveEntityReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveEntityReq) ProtoMessage() {}
+
+func (x *SaveEntityReq) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveEntityReq.ProtoReflect.Descriptor instead.
+func (*SaveEntityReq) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SaveEntityReq) GetRequesterId() int64 {
+	if x != nil {
+		return x.RequesterId
+	}
+	return 0
+}
+
+func (x *SaveEntityReq) GetEntityId() int64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *SaveEntityReq) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+// Request message for listing saved posts
+type ListSavedReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequesterId   int64                  `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	Collection    string                 `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"` // optional, empty means all collections
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedReq) Reset() {
+	*x = ListSavedReq{}
+	mi := &file_posts_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedReq) ProtoMessage() {}
+
+func (x *ListSavedReq) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedReq.ProtoReflect.Descriptor instead.
+func (*ListSavedReq) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListSavedReq) GetRequesterId() int64 {
+	if x != nil {
+		return x.RequesterId
+	}
+	return 0
+}
+
+func (x *ListSavedReq) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *ListSavedReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSavedReq) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type SavedCollection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SavedCount    int32                  `protobuf:"varint,2,opt,name=saved_count,json=savedCount,proto3" json:"saved_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavedCollection) Reset() {
+	*x = SavedCollection{}
+	mi := &file_posts_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedCollection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedCollection) ProtoMessage() {}
+
+func (x *SavedCollection) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedCollection.ProtoReflect.Descriptor instead.
+func (*SavedCollection) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SavedCollection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedCollection) GetSavedCount() int32 {
+	if x != nil {
+		return x.SavedCount
+	}
+	return 0
+}
+
+type ListSavedCollections struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collections   []*SavedCollection     `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedCollections) Reset() {
+	*x = ListSavedCollections{}
+	mi := &file_posts_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedCollections) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedCollections) ProtoMessage() {}
+
+func (x *ListSavedCollections) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedCollections.ProtoReflect.Descriptor instead.
+func (*ListSavedCollections) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListSavedCollections) GetCollections() []*SavedCollection {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+// Request message for changing the status of a draft or scheduled post
+type UpdatePostStatusReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequesterId   int64                  `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	PostId        int64                  `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                        // one of "draft", "scheduled", "published"
+	PublishAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"` // required if status is "scheduled"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePostStatusReq) Reset() {
+	*x = UpdatePostStatusReq{}
+	mi := &file_posts_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePostStatusReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePostStatusReq) ProtoMessage() {}
+
+func (x *UpdatePostStatusReq) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePostStatusReq.ProtoReflect.Descriptor instead.
+func (*UpdatePostStatusReq) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *CreatePostReq) GetGroupId() int64 {
+func (x *UpdatePostStatusReq) GetRequesterId() int64 {
 	if x != nil {
-		return x.GroupId
+		return x.RequesterId
 	}
 	return 0
 }
 
-func (x *CreatePostReq) GetAudience() string {
+func (x *UpdatePostStatusReq) GetPostId() int64 {
 	if x != nil {
-		return x.Audience
+		return x.PostId
 	}
-	return ""
+	return 0
 }
 
-func (x *CreatePostReq) GetAudienceIds() *common.UserIds {
+func (x *UpdatePostStatusReq) GetStatus() string {
 	if x != nil {
-		return x.AudienceIds
+		return x.Status
 	}
-	return nil
+	return ""
 }
 
-func (x *CreatePostReq) GetImageId() int64 {
+func (x *UpdatePostStatusReq) GetPublishAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ImageId
+		return x.PublishAt
 	}
-	return 0
+	return nil
 }
 
 // Request message for editing a post
@@ -710,7 +1654,7 @@ type EditPostReq struct {
 
 func (x *EditPostReq) Reset() {
 	*x = EditPostReq{}
-	mi := &file_posts_proto_msgTypes[10]
+	mi := &file_posts_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -722,7 +1666,7 @@ func (x *EditPostReq) String() string {
 func (*EditPostReq) ProtoMessage() {}
 
 func (x *EditPostReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[10]
+	mi := &file_posts_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -735,7 +1679,7 @@ func (x *EditPostReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EditPostReq.ProtoReflect.Descriptor instead.
 func (*EditPostReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{10}
+	return file_posts_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *EditPostReq) GetRequesterId() int64 {
@@ -789,18 +1733,20 @@ func (x *EditPostReq) GetDeleteImage() bool {
 
 // Request message for retrieving a user's posts
 type GetUserPostsReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CreatorId     int64                  `protobuf:"varint,1,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
-	RequesterId   int64                  `protobuf:"varint,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CreatorId   int64                  `protobuf:"varint,1,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	RequesterId int64                  `protobuf:"varint,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	Limit       int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Deprecated: Marked as deprecated in posts.proto.
+	Offset        int32  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"` // use cursor, ignored when cursor is set
+	Cursor        string `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetUserPostsReq) Reset() {
 	*x = GetUserPostsReq{}
-	mi := &file_posts_proto_msgTypes[11]
+	mi := &file_posts_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -812,7 +1758,7 @@ func (x *GetUserPostsReq) String() string {
 func (*GetUserPostsReq) ProtoMessage() {}
 
 func (x *GetUserPostsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[11]
+	mi := &file_posts_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -825,7 +1771,7 @@ func (x *GetUserPostsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserPostsReq.ProtoReflect.Descriptor instead.
 func (*GetUserPostsReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{11}
+	return file_posts_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetUserPostsReq) GetCreatorId() int64 {
@@ -849,6 +1795,7 @@ func (x *GetUserPostsReq) GetLimit() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in posts.proto.
 func (x *GetUserPostsReq) GetOffset() int32 {
 	if x != nil {
 		return x.Offset
@@ -856,20 +1803,29 @@ func (x *GetUserPostsReq) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetUserPostsReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 // Request message for retrieving the personalized feed
 // (posts by followers with restricted visibility that requester is allowed to view)
 type GetPersonalizedFeedReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RequesterId   int64                  `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	RequesterId int64                  `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	Limit       int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Deprecated: Marked as deprecated in posts.proto.
+	Offset        int32  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"` // use cursor, ignored when cursor is set
+	Cursor        string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetPersonalizedFeedReq) Reset() {
 	*x = GetPersonalizedFeedReq{}
-	mi := &file_posts_proto_msgTypes[12]
+	mi := &file_posts_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -881,7 +1837,7 @@ func (x *GetPersonalizedFeedReq) String() string {
 func (*GetPersonalizedFeedReq) ProtoMessage() {}
 
 func (x *GetPersonalizedFeedReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[12]
+	mi := &file_posts_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -894,7 +1850,7 @@ func (x *GetPersonalizedFeedReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPersonalizedFeedReq.ProtoReflect.Descriptor instead.
 func (*GetPersonalizedFeedReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{12}
+	return file_posts_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetPersonalizedFeedReq) GetRequesterId() int64 {
@@ -911,6 +1867,7 @@ func (x *GetPersonalizedFeedReq) GetLimit() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in posts.proto.
 func (x *GetPersonalizedFeedReq) GetOffset() int32 {
 	if x != nil {
 		return x.Offset
@@ -918,20 +1875,30 @@ func (x *GetPersonalizedFeedReq) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetPersonalizedFeedReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 // Request message for retrieving posts belonging to a group
 type GetGroupPostsReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RequesterId   int64                  `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
-	GroupId       int64                  `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	RequesterId int64                  `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	GroupId     int64                  `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Limit       int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Deprecated: Marked as deprecated in posts.proto.
+	Offset        int32  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"` // use cursor, ignored when cursor is set
+	Cursor        string `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Sort          string `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"` // "recent" (default) or "top"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetGroupPostsReq) Reset() {
 	*x = GetGroupPostsReq{}
-	mi := &file_posts_proto_msgTypes[13]
+	mi := &file_posts_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -943,7 +1910,7 @@ func (x *GetGroupPostsReq) String() string {
 func (*GetGroupPostsReq) ProtoMessage() {}
 
 func (x *GetGroupPostsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[13]
+	mi := &file_posts_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -956,7 +1923,7 @@ func (x *GetGroupPostsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGroupPostsReq.ProtoReflect.Descriptor instead.
 func (*GetGroupPostsReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{13}
+	return file_posts_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GetGroupPostsReq) GetRequesterId() int64 {
@@ -980,6 +1947,7 @@ func (x *GetGroupPostsReq) GetLimit() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in posts.proto.
 func (x *GetGroupPostsReq) GetOffset() int32 {
 	if x != nil {
 		return x.Offset
@@ -987,6 +1955,20 @@ func (x *GetGroupPostsReq) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetGroupPostsReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetGroupPostsReq) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
 // Response message that describes a comment
 type Comment struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -1006,7 +1988,7 @@ type Comment struct {
 
 func (x *Comment) Reset() {
 	*x = Comment{}
-	mi := &file_posts_proto_msgTypes[14]
+	mi := &file_posts_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1018,7 +2000,7 @@ func (x *Comment) String() string {
 func (*Comment) ProtoMessage() {}
 
 func (x *Comment) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[14]
+	mi := &file_posts_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1031,7 +2013,7 @@ func (x *Comment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Comment.ProtoReflect.Descriptor instead.
 func (*Comment) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{14}
+	return file_posts_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *Comment) GetCommentId() int64 {
@@ -1108,13 +2090,14 @@ func (x *Comment) GetImageUrl() string {
 type ListComments struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // empty when there are no more pages
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListComments) Reset() {
 	*x = ListComments{}
-	mi := &file_posts_proto_msgTypes[15]
+	mi := &file_posts_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1126,7 +2109,7 @@ func (x *ListComments) String() string {
 func (*ListComments) ProtoMessage() {}
 
 func (x *ListComments) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[15]
+	mi := &file_posts_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1139,7 +2122,7 @@ func (x *ListComments) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListComments.ProtoReflect.Descriptor instead.
 func (*ListComments) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{15}
+	return file_posts_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *ListComments) GetComments() []*Comment {
@@ -1149,6 +2132,13 @@ func (x *ListComments) GetComments() []*Comment {
 	return nil
 }
 
+func (x *ListComments) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 // Request message for creating a comment
 type CreateCommentReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1162,7 +2152,7 @@ type CreateCommentReq struct {
 
 func (x *CreateCommentReq) Reset() {
 	*x = CreateCommentReq{}
-	mi := &file_posts_proto_msgTypes[16]
+	mi := &file_posts_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1174,7 +2164,7 @@ func (x *CreateCommentReq) String() string {
 func (*CreateCommentReq) ProtoMessage() {}
 
 func (x *CreateCommentReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[16]
+	mi := &file_posts_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1187,7 +2177,7 @@ func (x *CreateCommentReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateCommentReq.ProtoReflect.Descriptor instead.
 func (*CreateCommentReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{16}
+	return file_posts_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *CreateCommentReq) GetCreatorId() int64 {
@@ -1232,7 +2222,7 @@ type EditCommentReq struct {
 
 func (x *EditCommentReq) Reset() {
 	*x = EditCommentReq{}
-	mi := &file_posts_proto_msgTypes[17]
+	mi := &file_posts_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1244,7 +2234,7 @@ func (x *EditCommentReq) String() string {
 func (*EditCommentReq) ProtoMessage() {}
 
 func (x *EditCommentReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[17]
+	mi := &file_posts_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1257,7 +2247,7 @@ func (x *EditCommentReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EditCommentReq.ProtoReflect.Descriptor instead.
 func (*EditCommentReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{17}
+	return file_posts_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *EditCommentReq) GetCreatorId() int64 {
@@ -1317,7 +2307,7 @@ type Event struct {
 
 func (x *Event) Reset() {
 	*x = Event{}
-	mi := &file_posts_proto_msgTypes[18]
+	mi := &file_posts_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1329,7 +2319,7 @@ func (x *Event) String() string {
 func (*Event) ProtoMessage() {}
 
 func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[18]
+	mi := &file_posts_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1342,7 +2332,7 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event.ProtoReflect.Descriptor instead.
 func (*Event) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{18}
+	return file_posts_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *Event) GetEventId() int64 {
@@ -1446,7 +2436,7 @@ type ListEvents struct {
 
 func (x *ListEvents) Reset() {
 	*x = ListEvents{}
-	mi := &file_posts_proto_msgTypes[19]
+	mi := &file_posts_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1458,7 +2448,7 @@ func (x *ListEvents) String() string {
 func (*ListEvents) ProtoMessage() {}
 
 func (x *ListEvents) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[19]
+	mi := &file_posts_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1471,7 +2461,7 @@ func (x *ListEvents) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListEvents.ProtoReflect.Descriptor instead.
 func (*ListEvents) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{19}
+	return file_posts_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *ListEvents) GetEvents() []*Event {
@@ -1496,7 +2486,7 @@ type CreateEventReq struct {
 
 func (x *CreateEventReq) Reset() {
 	*x = CreateEventReq{}
-	mi := &file_posts_proto_msgTypes[20]
+	mi := &file_posts_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1508,7 +2498,7 @@ func (x *CreateEventReq) String() string {
 func (*CreateEventReq) ProtoMessage() {}
 
 func (x *CreateEventReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[20]
+	mi := &file_posts_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1521,7 +2511,7 @@ func (x *CreateEventReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateEventReq.ProtoReflect.Descriptor instead.
 func (*CreateEventReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{20}
+	return file_posts_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *CreateEventReq) GetTitle() string {
@@ -1582,7 +2572,7 @@ type EditEventReq struct {
 
 func (x *EditEventReq) Reset() {
 	*x = EditEventReq{}
-	mi := &file_posts_proto_msgTypes[21]
+	mi := &file_posts_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1594,7 +2584,7 @@ func (x *EditEventReq) String() string {
 func (*EditEventReq) ProtoMessage() {}
 
 func (x *EditEventReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[21]
+	mi := &file_posts_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1607,7 +2597,7 @@ func (x *EditEventReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EditEventReq.ProtoReflect.Descriptor instead.
 func (*EditEventReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{21}
+	return file_posts_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *EditEventReq) GetEventId() int64 {
@@ -1671,7 +2661,7 @@ type RespondToEventReq struct {
 
 func (x *RespondToEventReq) Reset() {
 	*x = RespondToEventReq{}
-	mi := &file_posts_proto_msgTypes[22]
+	mi := &file_posts_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1683,7 +2673,7 @@ func (x *RespondToEventReq) String() string {
 func (*RespondToEventReq) ProtoMessage() {}
 
 func (x *RespondToEventReq) ProtoReflect() protoreflect.Message {
-	mi := &file_posts_proto_msgTypes[22]
+	mi := &file_posts_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1696,7 +2686,7 @@ func (x *RespondToEventReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RespondToEventReq.ProtoReflect.Descriptor instead.
 func (*RespondToEventReq) Descriptor() ([]byte, []int) {
-	return file_posts_proto_rawDescGZIP(), []int{22}
+	return file_posts_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *RespondToEventReq) GetEventId() int64 {
@@ -1720,6 +2710,184 @@ func (x *RespondToEventReq) GetGoing() bool {
 	return false
 }
 
+// Response message that describes a previous version of a post, comment or event
+type Revision struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	RevisionId            int64                  `protobuf:"varint,1,opt,name=revision_id,json=revisionId,proto3" json:"revision_id,omitempty"`
+	EntityId              int64                  `protobuf:"varint,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	ContentType           string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // one of "post", "comment", "event"
+	Editor                *common.User           `protobuf:"bytes,4,opt,name=editor,proto3" json:"editor,omitempty"`                              // includes id, username and avatar url
+	Title                 string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`                                // empty unless content_type="event"
+	Body                  string                 `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	ImageIds              []int64                `protobuf:"varint,7,rep,packed,name=image_ids,json=imageIds,proto3" json:"image_ids,omitempty"`
+	ImageUrls             []string               `protobuf:"bytes,8,rep,name=image_urls,json=imageUrls,proto3" json:"image_urls,omitempty"`                                        // same order as image_ids, empty string if an image is no longer available
+	Audience              string                 `protobuf:"bytes,9,opt,name=audience,proto3" json:"audience,omitempty"`                                                           // empty unless content_type="post"
+	SelectedAudienceUsers *common.ListUsers      `protobuf:"bytes,10,opt,name=selected_audience_users,json=selectedAudienceUsers,proto3" json:"selected_audience_users,omitempty"` //empty unless audience="selected"
+	EventDate             *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=event_date,json=eventDate,proto3" json:"event_date,omitempty"`                                       // nil unless content_type="event"
+	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                                       // when the edit that replaced this version was made
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Revision) Reset() {
+	*x = Revision{}
+	mi := &file_posts_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Revision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revision) ProtoMessage() {}
+
+func (x *Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revision.ProtoReflect.Descriptor instead.
+func (*Revision) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Revision) GetRevisionId() int64 {
+	if x != nil {
+		return x.RevisionId
+	}
+	return 0
+}
+
+func (x *Revision) GetEntityId() int64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *Revision) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Revision) GetEditor() *common.User {
+	if x != nil {
+		return x.Editor
+	}
+	return nil
+}
+
+func (x *Revision) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Revision) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Revision) GetImageIds() []int64 {
+	if x != nil {
+		return x.ImageIds
+	}
+	return nil
+}
+
+func (x *Revision) GetImageUrls() []string {
+	if x != nil {
+		return x.ImageUrls
+	}
+	return nil
+}
+
+func (x *Revision) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *Revision) GetSelectedAudienceUsers() *common.ListUsers {
+	if x != nil {
+		return x.SelectedAudienceUsers
+	}
+	return nil
+}
+
+func (x *Revision) GetEventDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EventDate
+	}
+	return nil
+}
+
+func (x *Revision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Response message with multiple revisions
+type ListRevisions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*Revision            `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRevisions) Reset() {
+	*x = ListRevisions{}
+	mi := &file_posts_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRevisions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRevisions) ProtoMessage() {}
+
+func (x *ListRevisions) ProtoReflect() protoreflect.Message {
+	mi := &file_posts_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRevisions.ProtoReflect.Descriptor instead.
+func (*ListRevisions) Descriptor() ([]byte, []int) {
+	return file_posts_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListRevisions) GetRevisions() []*Revision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
 var File_posts_proto protoreflect.FileDescriptor
 
 const file_posts_proto_rawDesc = "" +
@@ -1728,26 +2896,31 @@ const file_posts_proto_rawDesc = "" +
 	"\vSimpleIdReq\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\"\x18\n" +
 	"\x06IdResp\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"*\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"'\n" +
+	"\bImageIds\x12\x1b\n" +
+	"\timage_ids\x18\x01 \x03(\x03R\bimageIds\"*\n" +
 	"\fAudienceResp\x12\x1a\n" +
 	"\baudience\x18\x01 \x01(\tR\baudience\"L\n" +
 	"\n" +
 	"GenericReq\x12!\n" +
 	"\frequester_id\x18\x01 \x01(\x03R\vrequesterId\x12\x1b\n" +
-	"\tentity_id\x18\x02 \x01(\x03R\bentityId\"\x84\x01\n" +
+	"\tentity_id\x18\x02 \x01(\x03R\bentityId\"\xa0\x01\n" +
 	"\x14EntityIdPaginatedReq\x12!\n" +
 	"\frequester_id\x18\x01 \x01(\x03R\vrequesterId\x12\x1b\n" +
 	"\tentity_id\x18\x02 \x01(\x03R\bentityId\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x04 \x01(\x05R\x06offset\"f\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1a\n" +
+	"\x06offset\x18\x04 \x01(\x05B\x02\x18\x01R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\"\x96\x01\n" +
 	"\x13GenericPaginatedReq\x12!\n" +
 	"\frequester_id\x18\x01 \x01(\x03R\vrequesterId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"f\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1a\n" +
+	"\x06offset\x18\x03 \x01(\x05B\x02\x18\x01R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\"f\n" +
 	"\x19GenericEntityPaginatedReq\x12\x1b\n" +
 	"\tentity_id\x18\x01 \x01(\x03R\bentityId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xca\x04\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\x95\x06\n" +
 	"\x04Post\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\x03R\x06postId\x12\x1b\n" +
 	"\tpost_body\x18\x02 \x01(\tR\bpostBody\x12 \n" +
@@ -1765,9 +2938,49 @@ const file_posts_proto_rawDesc = "" +
 	"\rliked_by_user\x18\v \x01(\bR\vlikedByUser\x12\x19\n" +
 	"\bimage_id\x18\f \x01(\x03R\aimageId\x12\x1b\n" +
 	"\timage_url\x18\r \x01(\tR\bimageUrl\x12I\n" +
-	"\x17selected_audience_users\x18\x0e \x01(\v2\x11.common.ListUsersR\x15selectedAudienceUsers\".\n" +
+	"\x17selected_audience_users\x18\x0e \x01(\v2\x11.common.ListUsersR\x15selectedAudienceUsers\x12\x16\n" +
+	"\x06status\x18\x0f \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"publish_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12\x1f\n" +
+	"\x04poll\x18\x11 \x01(\v2\v.posts.PollR\x04poll\x12!\n" +
+	"\fshares_count\x18\x12 \x01(\x05R\vsharesCount\x122\n" +
+	"\vshared_post\x18\x13 \x01(\v2\x11.posts.SharedPostR\n" +
+	"sharedPost\"\xe7\x02\n" +
+	"\n" +
+	"SharedPost\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\x03R\x06postId\x12\x1c\n" +
+	"\tavailable\x18\x02 \x01(\bR\tavailable\x12\x1b\n" +
+	"\tpost_body\x18\x03 \x01(\tR\bpostBody\x12 \n" +
+	"\x04user\x18\x04 \x01(\v2\f.common.UserR\x04user\x12\x19\n" +
+	"\bgroup_id\x18\x05 \x01(\x03R\agroupId\x12\x1a\n" +
+	"\baudience\x18\x06 \x01(\tR\baudience\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x19\n" +
+	"\bimage_id\x18\t \x01(\x03R\aimageId\x12\x1b\n" +
+	"\timage_url\x18\n" +
+	" \x01(\tR\bimageUrl\"\xae\x02\n" +
+	"\x04Poll\x12\x17\n" +
+	"\apoll_id\x18\x01 \x01(\x03R\x06pollId\x12\x1a\n" +
+	"\bquestion\x18\x02 \x01(\tR\bquestion\x12+\n" +
+	"\aoptions\x18\x03 \x03(\v2\x11.posts.PollOptionR\aoptions\x12'\n" +
+	"\x0fmultiple_choice\x18\x04 \x01(\bR\x0emultipleChoice\x127\n" +
+	"\tcloses_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bclosesAt\x12\x16\n" +
+	"\x06closed\x18\x06 \x01(\bR\x06closed\x12!\n" +
+	"\fvoters_count\x18\a \x01(\x05R\vvotersCount\x12'\n" +
+	"\x0fresults_visible\x18\b \x01(\bR\x0eresultsVisible\"\x82\x01\n" +
+	"\n" +
+	"PollOption\x12\x1b\n" +
+	"\toption_id\x18\x01 \x01(\x03R\boptionId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1f\n" +
+	"\vvotes_count\x18\x03 \x01(\x05R\n" +
+	"votesCount\x12\"\n" +
+	"\rvoted_by_user\x18\x04 \x01(\bR\vvotedByUser\"O\n" +
 	"\tListPosts\x12!\n" +
-	"\x05posts\x18\x01 \x03(\v2\v.posts.PostR\x05posts\"\xc8\x01\n" +
+	"\x05posts\x18\x01 \x03(\v2\v.posts.PostR\x05posts\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xc5\x02\n" +
 	"\rCreatePostReq\x12\x1d\n" +
 	"\n" +
 	"creator_id\x18\x01 \x01(\x03R\tcreatorId\x12\x12\n" +
@@ -1775,7 +2988,53 @@ const file_posts_proto_rawDesc = "" +
 	"\bgroup_id\x18\x03 \x01(\x03R\agroupId\x12\x1a\n" +
 	"\baudience\x18\x04 \x01(\tR\baudience\x122\n" +
 	"\faudience_ids\x18\x05 \x01(\v2\x0f.common.UserIdsR\vaudienceIds\x12\x19\n" +
-	"\bimage_id\x18\x06 \x01(\x03R\aimageId\"\xeb\x01\n" +
+	"\bimage_id\x18\x06 \x01(\x03R\aimageId\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"publish_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12(\n" +
+	"\x04poll\x18\t \x01(\v2\x14.posts.CreatePollReqR\x04poll\"\xa7\x01\n" +
+	"\rCreatePollReq\x12\x1a\n" +
+	"\bquestion\x18\x01 \x01(\tR\bquestion\x12\x18\n" +
+	"\aoptions\x18\x02 \x03(\tR\aoptions\x12'\n" +
+	"\x0fmultiple_choice\x18\x03 \x01(\bR\x0emultipleChoice\x127\n" +
+	"\tcloses_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bclosesAt\"h\n" +
+	"\vVotePollReq\x12!\n" +
+	"\frequester_id\x18\x01 \x01(\x03R\vrequesterId\x12\x17\n" +
+	"\apoll_id\x18\x02 \x01(\x03R\x06pollId\x12\x1d\n" +
+	"\n" +
+	"option_ids\x18\x03 \x03(\x03R\toptionIds\"\xc9\x01\n" +
+	"\fSharePostReq\x12!\n" +
+	"\frequester_id\x18\x01 \x01(\x03R\vrequesterId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\x03R\x06postId\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x12\x19\n" +
+	"\bgroup_id\x18\x04 \x01(\x03R\agroupId\x12\x1a\n" +
+	"\baudience\x18\x05 \x01(\tR\baudience\x122\n" +
+	"\faudience_ids\x18\x06 \x01(\v2\x0f.common.UserIdsR\vaudienceIds\"o\n" +
+	"\rSaveEntityReq\x12!\n" +
+	"\frequester_id\x18\x01 \x01(\x03R\vrequesterId\x12\x1b\n" +
+	"\tentity_id\x18\x02 \x01(\x03R\bentityId\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\"\x7f\n" +
+	"\fListSavedReq\x12!\n" +
+	"\frequester_id\x18\x01 \x01(\x03R\vrequesterId\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x02 \x01(\tR\n" +
+	"collection\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"F\n" +
+	"\x0fSavedCollection\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vsaved_count\x18\x02 \x01(\x05R\n" +
+	"savedCount\"P\n" +
+	"\x14ListSavedCollections\x128\n" +
+	"\vcollections\x18\x01 \x03(\v2\x16.posts.SavedCollectionR\vcollections\"\xa4\x01\n" +
+	"\x13UpdatePostStatusReq\x12!\n" +
+	"\frequester_id\x18\x01 \x01(\x03R\vrequesterId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\x03R\x06postId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"publish_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\"\xeb\x01\n" +
 	"\vEditPostReq\x12!\n" +
 	"\frequester_id\x18\x01 \x01(\x03R\vrequesterId\x12\x17\n" +
 	"\apost_id\x18\x02 \x01(\x03R\x06postId\x12\x12\n" +
@@ -1783,22 +3042,26 @@ const file_posts_proto_rawDesc = "" +
 	"\bimage_id\x18\x04 \x01(\x03R\aimageId\x12\x1a\n" +
 	"\baudience\x18\x05 \x01(\tR\baudience\x122\n" +
 	"\faudience_ids\x18\x06 \x01(\v2\x0f.common.UserIdsR\vaudienceIds\x12!\n" +
-	"\fdelete_image\x18\a \x01(\bR\vdeleteImage\"\x81\x01\n" +
+	"\fdelete_image\x18\a \x01(\bR\vdeleteImage\"\x9d\x01\n" +
 	"\x0fGetUserPostsReq\x12\x1d\n" +
 	"\n" +
 	"creator_id\x18\x01 \x01(\x03R\tcreatorId\x12!\n" +
 	"\frequester_id\x18\x02 \x01(\x03R\vrequesterId\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x04 \x01(\x05R\x06offset\"i\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1a\n" +
+	"\x06offset\x18\x04 \x01(\x05B\x02\x18\x01R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\"\x85\x01\n" +
 	"\x16GetPersonalizedFeedReq\x12!\n" +
 	"\frequester_id\x18\x01 \x01(\x03R\vrequesterId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"~\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1a\n" +
+	"\x06offset\x18\x03 \x01(\x05B\x02\x18\x01R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"\xae\x01\n" +
 	"\x10GetGroupPostsReq\x12!\n" +
 	"\frequester_id\x18\x01 \x01(\x03R\vrequesterId\x12\x19\n" +
 	"\bgroup_id\x18\x02 \x01(\x03R\agroupId\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x04 \x01(\x05R\x06offset\"\xf6\x02\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1a\n" +
+	"\x06offset\x18\x04 \x01(\x05B\x02\x18\x01R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\tR\x04sort\"\xf6\x02\n" +
 	"\aComment\x12\x1d\n" +
 	"\n" +
 	"comment_id\x18\x01 \x01(\x03R\tcommentId\x12\x1b\n" +
@@ -1813,9 +3076,11 @@ const file_posts_proto_rawDesc = "" +
 	"\rliked_by_user\x18\b \x01(\bR\vlikedByUser\x12\x19\n" +
 	"\bimage_id\x18\t \x01(\x03R\aimageId\x12\x1b\n" +
 	"\timage_url\x18\n" +
-	" \x01(\tR\bimageUrl\":\n" +
+	" \x01(\tR\bimageUrl\"[\n" +
 	"\fListComments\x12*\n" +
-	"\bcomments\x18\x01 \x03(\v2\x0e.posts.CommentR\bcomments\"}\n" +
+	"\bcomments\x18\x01 \x03(\v2\x0e.posts.CommentR\bcomments\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"}\n" +
 	"\x10CreateCommentReq\x12\x1d\n" +
 	"\n" +
 	"creator_id\x18\x01 \x01(\x03R\tcreatorId\x12\x1b\n" +
@@ -1873,9 +3138,31 @@ const file_posts_proto_rawDesc = "" +
 	"\x11RespondToEventReq\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12!\n" +
 	"\fresponder_id\x18\x02 \x01(\x03R\vresponderId\x12\x14\n" +
-	"\x05going\x18\x03 \x01(\bR\x05going2\xbd\v\n" +
+	"\x05going\x18\x03 \x01(\bR\x05going\"\xd4\x03\n" +
+	"\bRevision\x12\x1f\n" +
+	"\vrevision_id\x18\x01 \x01(\x03R\n" +
+	"revisionId\x12\x1b\n" +
+	"\tentity_id\x18\x02 \x01(\x03R\bentityId\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12$\n" +
+	"\x06editor\x18\x04 \x01(\v2\f.common.UserR\x06editor\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x06 \x01(\tR\x04body\x12\x1b\n" +
+	"\timage_ids\x18\a \x03(\x03R\bimageIds\x12\x1d\n" +
+	"\n" +
+	"image_urls\x18\b \x03(\tR\timageUrls\x12\x1a\n" +
+	"\baudience\x18\t \x01(\tR\baudience\x12I\n" +
+	"\x17selected_audience_users\x18\n" +
+	" \x01(\v2\x11.common.ListUsersR\x15selectedAudienceUsers\x129\n" +
+	"\n" +
+	"event_date\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\teventDate\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\">\n" +
+	"\rListRevisions\x12-\n" +
+	"\trevisions\x18\x01 \x03(\v2\x0f.posts.RevisionR\trevisions2\x9f\x11\n" +
 	"\fPostsService\x12-\n" +
-	"\vGetPostById\x12\x11.posts.GenericReq\x1a\v.posts.Post\x121\n" +
+	"\vGetPostById\x12\x11.posts.GenericReq\x1a\v.posts.Post\x12>\n" +
+	"\rCanViewEntity\x12\x11.posts.GenericReq\x1a\x1a.google.protobuf.BoolValue\x123\n" +
+	"\x0fGetUsedImageIds\x12\x0f.posts.ImageIds\x1a\x0f.posts.ImageIds\x121\n" +
 	"\n" +
 	"CreatePost\x12\x14.posts.CreatePostReq\x1a\r.posts.IdResp\x127\n" +
 	"\n" +
@@ -1883,7 +3170,8 @@ const file_posts_proto_rawDesc = "" +
 	"\bEditPost\x12\x12.posts.EditPostReq\x1a\x16.google.protobuf.Empty\x12<\n" +
 	"\x19GetMostPopularPostInGroup\x12\x12.posts.SimpleIdReq\x1a\v.posts.Post\x12F\n" +
 	"\x13GetPersonalizedFeed\x12\x1d.posts.GetPersonalizedFeedReq\x1a\x10.posts.ListPosts\x12=\n" +
-	"\rGetPublicFeed\x12\x1a.posts.GenericPaginatedReq\x1a\x10.posts.ListPosts\x12A\n" +
+	"\rGetPublicFeed\x12\x1a.posts.GenericPaginatedReq\x1a\x10.posts.ListPosts\x12?\n" +
+	"\x0fGetHomeTimeline\x12\x1a.posts.GenericPaginatedReq\x1a\x10.posts.ListPosts\x12A\n" +
 	"\x15GetUserPostsPaginated\x12\x16.posts.GetUserPostsReq\x1a\x10.posts.ListPosts\x12C\n" +
 	"\x16GetGroupPostsPaginated\x12\x17.posts.GetGroupPostsReq\x1a\x10.posts.ListPosts\x127\n" +
 	"\rCreateComment\x12\x17.posts.CreateCommentReq\x1a\r.posts.IdResp\x12<\n" +
@@ -1899,7 +3187,17 @@ const file_posts_proto_rawDesc = "" +
 	"\x13RemoveEventResponse\x12\x11.posts.GenericReq\x1a\x16.google.protobuf.Empty\x12C\n" +
 	"\x1aSuggestUsersByPostActivity\x12\x12.posts.SimpleIdReq\x1a\x11.common.ListUsers\x12C\n" +
 	"\x16ToggleOrInsertReaction\x12\x11.posts.GenericReq\x1a\x16.google.protobuf.Empty\x12J\n" +
-	"\x13GetWhoLikedEntityId\x12 .posts.GenericEntityPaginatedReq\x1a\x11.common.ListUsersB*Z(social-network/shared/gen-go/posts;postsb\x06proto3"
+	"\x13GetWhoLikedEntityId\x12 .posts.GenericEntityPaginatedReq\x1a\x11.common.ListUsers\x12G\n" +
+	"\x12GetEntityRevisions\x12\x1b.posts.EntityIdPaginatedReq\x1a\x14.posts.ListRevisions\x12=\n" +
+	"\rGetUserDrafts\x12\x1a.posts.GenericPaginatedReq\x1a\x10.posts.ListPosts\x12F\n" +
+	"\x10UpdatePostStatus\x12\x1a.posts.UpdatePostStatusReq\x1a\x16.google.protobuf.Empty\x126\n" +
+	"\bVotePoll\x12\x12.posts.VotePollReq\x1a\x16.google.protobuf.Empty\x12/\n" +
+	"\tSharePost\x12\x13.posts.SharePostReq\x1a\r.posts.IdResp\x12:\n" +
+	"\n" +
+	"SaveEntity\x12\x14.posts.SaveEntityReq\x1a\x16.google.protobuf.Empty\x129\n" +
+	"\fUnsaveEntity\x12\x11.posts.GenericReq\x1a\x16.google.protobuf.Empty\x122\n" +
+	"\tListSaved\x12\x13.posts.ListSavedReq\x1a\x10.posts.ListPosts\x12F\n" +
+	"\x13GetSavedCollections\x12\x12.posts.SimpleIdReq\x1a\x1b.posts.ListSavedCollectionsB*Z(social-network/shared/gen-go/posts;postsb\x06proto3"
 
 var (
 	file_posts_proto_rawDescOnce sync.Once
@@ -1913,110 +3211,167 @@ func file_posts_proto_rawDescGZIP() []byte {
 	return file_posts_proto_rawDescData
 }
 
-var file_posts_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_posts_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_posts_proto_goTypes = []any{
 	(*SimpleIdReq)(nil),               // 0: posts.SimpleIdReq
 	(*IdResp)(nil),                    // 1: posts.IdResp
-	(*AudienceResp)(nil),              // 2: posts.AudienceResp
-	(*GenericReq)(nil),                // 3: posts.GenericReq
-	(*EntityIdPaginatedReq)(nil),      // 4: posts.EntityIdPaginatedReq
-	(*GenericPaginatedReq)(nil),       // 5: posts.GenericPaginatedReq
-	(*GenericEntityPaginatedReq)(nil), // 6: posts.GenericEntityPaginatedReq
-	(*Post)(nil),                      // 7: posts.Post
-	(*ListPosts)(nil),                 // 8: posts.ListPosts
-	(*CreatePostReq)(nil),             // 9: posts.CreatePostReq
-	(*EditPostReq)(nil),               // 10: posts.EditPostReq
-	(*GetUserPostsReq)(nil),           // 11: posts.GetUserPostsReq
-	(*GetPersonalizedFeedReq)(nil),    // 12: posts.GetPersonalizedFeedReq
-	(*GetGroupPostsReq)(nil),          // 13: posts.GetGroupPostsReq
-	(*Comment)(nil),                   // 14: posts.Comment
-	(*ListComments)(nil),              // 15: posts.ListComments
-	(*CreateCommentReq)(nil),          // 16: posts.CreateCommentReq
-	(*EditCommentReq)(nil),            // 17: posts.EditCommentReq
-	(*Event)(nil),                     // 18: posts.Event
-	(*ListEvents)(nil),                // 19: posts.ListEvents
-	(*CreateEventReq)(nil),            // 20: posts.CreateEventReq
-	(*EditEventReq)(nil),              // 21: posts.EditEventReq
-	(*RespondToEventReq)(nil),         // 22: posts.RespondToEventReq
-	(*common.User)(nil),               // 23: common.User
-	(*timestamppb.Timestamp)(nil),     // 24: google.protobuf.Timestamp
-	(*common.ListUsers)(nil),          // 25: common.ListUsers
-	(*common.UserIds)(nil),            // 26: common.UserIds
-	(*wrapperspb.BoolValue)(nil),      // 27: google.protobuf.BoolValue
-	(*emptypb.Empty)(nil),             // 28: google.protobuf.Empty
+	(*ImageIds)(nil),                  // 2: posts.ImageIds
+	(*AudienceResp)(nil),              // 3: posts.AudienceResp
+	(*GenericReq)(nil),                // 4: posts.GenericReq
+	(*EntityIdPaginatedReq)(nil),      // 5: posts.EntityIdPaginatedReq
+	(*GenericPaginatedReq)(nil),       // 6: posts.GenericPaginatedReq
+	(*GenericEntityPaginatedReq)(nil), // 7: posts.GenericEntityPaginatedReq
+	(*Post)(nil),                      // 8: posts.Post
+	(*SharedPost)(nil),                // 9: posts.SharedPost
+	(*Poll)(nil),                      // 10: posts.Poll
+	(*PollOption)(nil),                // 11: posts.PollOption
+	(*ListPosts)(nil),                 // 12: posts.ListPosts
+	(*CreatePostReq)(nil),             // 13: posts.CreatePostReq
+	(*CreatePollReq)(nil),             // 14: posts.CreatePollReq
+	(*VotePollReq)(nil),               // 15: posts.VotePollReq
+	(*SharePostReq)(nil),              // 16: posts.SharePostReq
+	(*SaveEntityReq)(nil),             // 17: posts.SaveEntityReq
+	(*ListSavedReq)(nil),              // 18: posts.ListSavedReq
+	(*SavedCollection)(nil),           // 19: posts.SavedCollection
+	(*ListSavedCollections)(nil),      // 20: posts.ListSavedCollections
+	(*UpdatePostStatusReq)(nil),       // 21: posts.UpdatePostStatusReq
+	(*EditPostReq)(nil),               // 22: posts.EditPostReq
+	(*GetUserPostsReq)(nil),           // 23: posts.GetUserPostsReq
+	(*GetPersonalizedFeedReq)(nil),    // 24: posts.GetPersonalizedFeedReq
+	(*GetGroupPostsReq)(nil),          // 25: posts.GetGroupPostsReq
+	(*Comment)(nil),                   // 26: posts.Comment
+	(*ListComments)(nil),              // 27: posts.ListComments
+	(*CreateCommentReq)(nil),          // 28: posts.CreateCommentReq
+	(*EditCommentReq)(nil),            // 29: posts.EditCommentReq
+	(*Event)(nil),                     // 30: posts.Event
+	(*ListEvents)(nil),                // 31: posts.ListEvents
+	(*CreateEventReq)(nil),            // 32: posts.CreateEventReq
+	(*EditEventReq)(nil),              // 33: posts.EditEventReq
+	(*RespondToEventReq)(nil),         // 34: posts.RespondToEventReq
+	(*Revision)(nil),                  // 35: posts.Revision
+	(*ListRevisions)(nil),             // 36: posts.ListRevisions
+	(*common.User)(nil),               // 37: common.User
+	(*timestamppb.Timestamp)(nil),     // 38: google.protobuf.Timestamp
+	(*common.ListUsers)(nil),          // 39: common.ListUsers
+	(*common.UserIds)(nil),            // 40: common.UserIds
+	(*wrapperspb.BoolValue)(nil),      // 41: google.protobuf.BoolValue
+	(*emptypb.Empty)(nil),             // 42: google.protobuf.Empty
 }
 var file_posts_proto_depIdxs = []int32{
-	23, // 0: posts.Post.user:type_name -> common.User
-	24, // 1: posts.Post.last_commented_at:type_name -> google.protobuf.Timestamp
-	24, // 2: posts.Post.created_at:type_name -> google.protobuf.Timestamp
-	24, // 3: posts.Post.updated_at:type_name -> google.protobuf.Timestamp
-	25, // 4: posts.Post.selected_audience_users:type_name -> common.ListUsers
-	7,  // 5: posts.ListPosts.posts:type_name -> posts.Post
-	26, // 6: posts.CreatePostReq.audience_ids:type_name -> common.UserIds
-	26, // 7: posts.EditPostReq.audience_ids:type_name -> common.UserIds
-	23, // 8: posts.Comment.user:type_name -> common.User
-	24, // 9: posts.Comment.created_at:type_name -> google.protobuf.Timestamp
-	24, // 10: posts.Comment.updated_at:type_name -> google.protobuf.Timestamp
-	14, // 11: posts.ListComments.comments:type_name -> posts.Comment
-	23, // 12: posts.Event.user:type_name -> common.User
-	24, // 13: posts.Event.event_date:type_name -> google.protobuf.Timestamp
-	24, // 14: posts.Event.created_at:type_name -> google.protobuf.Timestamp
-	24, // 15: posts.Event.updated_at:type_name -> google.protobuf.Timestamp
-	27, // 16: posts.Event.user_response:type_name -> google.protobuf.BoolValue
-	18, // 17: posts.ListEvents.events:type_name -> posts.Event
-	24, // 18: posts.CreateEventReq.event_date:type_name -> google.protobuf.Timestamp
-	24, // 19: posts.EditEventReq.event_date:type_name -> google.protobuf.Timestamp
-	3,  // 20: posts.PostsService.GetPostById:input_type -> posts.GenericReq
-	9,  // 21: posts.PostsService.CreatePost:input_type -> posts.CreatePostReq
-	3,  // 22: posts.PostsService.DeletePost:input_type -> posts.GenericReq
-	10, // 23: posts.PostsService.EditPost:input_type -> posts.EditPostReq
-	0,  // 24: posts.PostsService.GetMostPopularPostInGroup:input_type -> posts.SimpleIdReq
-	12, // 25: posts.PostsService.GetPersonalizedFeed:input_type -> posts.GetPersonalizedFeedReq
-	5,  // 26: posts.PostsService.GetPublicFeed:input_type -> posts.GenericPaginatedReq
-	11, // 27: posts.PostsService.GetUserPostsPaginated:input_type -> posts.GetUserPostsReq
-	13, // 28: posts.PostsService.GetGroupPostsPaginated:input_type -> posts.GetGroupPostsReq
-	16, // 29: posts.PostsService.CreateComment:input_type -> posts.CreateCommentReq
-	17, // 30: posts.PostsService.EditComment:input_type -> posts.EditCommentReq
-	3,  // 31: posts.PostsService.DeleteComment:input_type -> posts.GenericReq
-	4,  // 32: posts.PostsService.GetCommentsByParentId:input_type -> posts.EntityIdPaginatedReq
-	0,  // 33: posts.PostsService.GetPostAudienceForComment:input_type -> posts.SimpleIdReq
-	20, // 34: posts.PostsService.CreateEvent:input_type -> posts.CreateEventReq
-	3,  // 35: posts.PostsService.DeleteEvent:input_type -> posts.GenericReq
-	21, // 36: posts.PostsService.EditEvent:input_type -> posts.EditEventReq
-	4,  // 37: posts.PostsService.GetEventsByGroupId:input_type -> posts.EntityIdPaginatedReq
-	22, // 38: posts.PostsService.RespondToEvent:input_type -> posts.RespondToEventReq
-	3,  // 39: posts.PostsService.RemoveEventResponse:input_type -> posts.GenericReq
-	0,  // 40: posts.PostsService.SuggestUsersByPostActivity:input_type -> posts.SimpleIdReq
-	3,  // 41: posts.PostsService.ToggleOrInsertReaction:input_type -> posts.GenericReq
-	6,  // 42: posts.PostsService.GetWhoLikedEntityId:input_type -> posts.GenericEntityPaginatedReq
-	7,  // 43: posts.PostsService.GetPostById:output_type -> posts.Post
-	1,  // 44: posts.PostsService.CreatePost:output_type -> posts.IdResp
-	28, // 45: posts.PostsService.DeletePost:output_type -> google.protobuf.Empty
-	28, // 46: posts.PostsService.EditPost:output_type -> google.protobuf.Empty
-	7,  // 47: posts.PostsService.GetMostPopularPostInGroup:output_type -> posts.Post
-	8,  // 48: posts.PostsService.GetPersonalizedFeed:output_type -> posts.ListPosts
-	8,  // 49: posts.PostsService.GetPublicFeed:output_type -> posts.ListPosts
-	8,  // 50: posts.PostsService.GetUserPostsPaginated:output_type -> posts.ListPosts
-	8,  // 51: posts.PostsService.GetGroupPostsPaginated:output_type -> posts.ListPosts
-	1,  // 52: posts.PostsService.CreateComment:output_type -> posts.IdResp
-	28, // 53: posts.PostsService.EditComment:output_type -> google.protobuf.Empty
-	28, // 54: posts.PostsService.DeleteComment:output_type -> google.protobuf.Empty
-	15, // 55: posts.PostsService.GetCommentsByParentId:output_type -> posts.ListComments
-	2,  // 56: posts.PostsService.GetPostAudienceForComment:output_type -> posts.AudienceResp
-	1,  // 57: posts.PostsService.CreateEvent:output_type -> posts.IdResp
-	28, // 58: posts.PostsService.DeleteEvent:output_type -> google.protobuf.Empty
-	28, // 59: posts.PostsService.EditEvent:output_type -> google.protobuf.Empty
-	19, // 60: posts.PostsService.GetEventsByGroupId:output_type -> posts.ListEvents
-	28, // 61: posts.PostsService.RespondToEvent:output_type -> google.protobuf.Empty
-	28, // 62: posts.PostsService.RemoveEventResponse:output_type -> google.protobuf.Empty
-	25, // 63: posts.PostsService.SuggestUsersByPostActivity:output_type -> common.ListUsers
-	28, // 64: posts.PostsService.ToggleOrInsertReaction:output_type -> google.protobuf.Empty
-	25, // 65: posts.PostsService.GetWhoLikedEntityId:output_type -> common.ListUsers
-	43, // [43:66] is the sub-list for method output_type
-	20, // [20:43] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	37, // 0: posts.Post.user:type_name -> common.User
+	38, // 1: posts.Post.last_commented_at:type_name -> google.protobuf.Timestamp
+	38, // 2: posts.Post.created_at:type_name -> google.protobuf.Timestamp
+	38, // 3: posts.Post.updated_at:type_name -> google.protobuf.Timestamp
+	39, // 4: posts.Post.selected_audience_users:type_name -> common.ListUsers
+	38, // 5: posts.Post.publish_at:type_name -> google.protobuf.Timestamp
+	10, // 6: posts.Post.poll:type_name -> posts.Poll
+	9,  // 7: posts.Post.shared_post:type_name -> posts.SharedPost
+	37, // 8: posts.SharedPost.user:type_name -> common.User
+	38, // 9: posts.SharedPost.created_at:type_name -> google.protobuf.Timestamp
+	38, // 10: posts.SharedPost.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 11: posts.Poll.options:type_name -> posts.PollOption
+	38, // 12: posts.Poll.closes_at:type_name -> google.protobuf.Timestamp
+	8,  // 13: posts.ListPosts.posts:type_name -> posts.Post
+	40, // 14: posts.CreatePostReq.audience_ids:type_name -> common.UserIds
+	38, // 15: posts.CreatePostReq.publish_at:type_name -> google.protobuf.Timestamp
+	14, // 16: posts.CreatePostReq.poll:type_name -> posts.CreatePollReq
+	38, // 17: posts.CreatePollReq.closes_at:type_name -> google.protobuf.Timestamp
+	40, // 18: posts.SharePostReq.audience_ids:type_name -> common.UserIds
+	19, // 19: posts.ListSavedCollections.collections:type_name -> posts.SavedCollection
+	38, // 20: posts.UpdatePostStatusReq.publish_at:type_name -> google.protobuf.Timestamp
+	40, // 21: posts.EditPostReq.audience_ids:type_name -> common.UserIds
+	37, // 22: posts.Comment.user:type_name -> common.User
+	38, // 23: posts.Comment.created_at:type_name -> google.protobuf.Timestamp
+	38, // 24: posts.Comment.updated_at:type_name -> google.protobuf.Timestamp
+	26, // 25: posts.ListComments.comments:type_name -> posts.Comment
+	37, // 26: posts.Event.user:type_name -> common.User
+	38, // 27: posts.Event.event_date:type_name -> google.protobuf.Timestamp
+	38, // 28: posts.Event.created_at:type_name -> google.protobuf.Timestamp
+	38, // 29: posts.Event.updated_at:type_name -> google.protobuf.Timestamp
+	41, // 30: posts.Event.user_response:type_name -> google.protobuf.BoolValue
+	30, // 31: posts.ListEvents.events:type_name -> posts.Event
+	38, // 32: posts.CreateEventReq.event_date:type_name -> google.protobuf.Timestamp
+	38, // 33: posts.EditEventReq.event_date:type_name -> google.protobuf.Timestamp
+	37, // 34: posts.Revision.editor:type_name -> common.User
+	39, // 35: posts.Revision.selected_audience_users:type_name -> common.ListUsers
+	38, // 36: posts.Revision.event_date:type_name -> google.protobuf.Timestamp
+	38, // 37: posts.Revision.created_at:type_name -> google.protobuf.Timestamp
+	35, // 38: posts.ListRevisions.revisions:type_name -> posts.Revision
+	4,  // 39: posts.PostsService.GetPostById:input_type -> posts.GenericReq
+	4,  // 40: posts.PostsService.CanViewEntity:input_type -> posts.GenericReq
+	2,  // 41: posts.PostsService.GetUsedImageIds:input_type -> posts.ImageIds
+	13, // 42: posts.PostsService.CreatePost:input_type -> posts.CreatePostReq
+	4,  // 43: posts.PostsService.DeletePost:input_type -> posts.GenericReq
+	22, // 44: posts.PostsService.EditPost:input_type -> posts.EditPostReq
+	0,  // 45: posts.PostsService.GetMostPopularPostInGroup:input_type -> posts.SimpleIdReq
+	24, // 46: posts.PostsService.GetPersonalizedFeed:input_type -> posts.GetPersonalizedFeedReq
+	6,  // 47: posts.PostsService.GetPublicFeed:input_type -> posts.GenericPaginatedReq
+	6,  // 48: posts.PostsService.GetHomeTimeline:input_type -> posts.GenericPaginatedReq
+	23, // 49: posts.PostsService.GetUserPostsPaginated:input_type -> posts.GetUserPostsReq
+	25, // 50: posts.PostsService.GetGroupPostsPaginated:input_type -> posts.GetGroupPostsReq
+	28, // 51: posts.PostsService.CreateComment:input_type -> posts.CreateCommentReq
+	29, // 52: posts.PostsService.EditComment:input_type -> posts.EditCommentReq
+	4,  // 53: posts.PostsService.DeleteComment:input_type -> posts.GenericReq
+	5,  // 54: posts.PostsService.GetCommentsByParentId:input_type -> posts.EntityIdPaginatedReq
+	0,  // 55: posts.PostsService.GetPostAudienceForComment:input_type -> posts.SimpleIdReq
+	32, // 56: posts.PostsService.CreateEvent:input_type -> posts.CreateEventReq
+	4,  // 57: posts.PostsService.DeleteEvent:input_type -> posts.GenericReq
+	33, // 58: posts.PostsService.EditEvent:input_type -> posts.EditEventReq
+	5,  // 59: posts.PostsService.GetEventsByGroupId:input_type -> posts.EntityIdPaginatedReq
+	34, // 60: posts.PostsService.RespondToEvent:input_type -> posts.RespondToEventReq
+	4,  // 61: posts.PostsService.RemoveEventResponse:input_type -> posts.GenericReq
+	0,  // 62: posts.PostsService.SuggestUsersByPostActivity:input_type -> posts.SimpleIdReq
+	4,  // 63: posts.PostsService.ToggleOrInsertReaction:input_type -> posts.GenericReq
+	7,  // 64: posts.PostsService.GetWhoLikedEntityId:input_type -> posts.GenericEntityPaginatedReq
+	5,  // 65: posts.PostsService.GetEntityRevisions:input_type -> posts.EntityIdPaginatedReq
+	6,  // 66: posts.PostsService.GetUserDrafts:input_type -> posts.GenericPaginatedReq
+	21, // 67: posts.PostsService.UpdatePostStatus:input_type -> posts.UpdatePostStatusReq
+	15, // 68: posts.PostsService.VotePoll:input_type -> posts.VotePollReq
+	16, // 69: posts.PostsService.SharePost:input_type -> posts.SharePostReq
+	17, // 70: posts.PostsService.SaveEntity:input_type -> posts.SaveEntityReq
+	4,  // 71: posts.PostsService.UnsaveEntity:input_type -> posts.GenericReq
+	18, // 72: posts.PostsService.ListSaved:input_type -> posts.ListSavedReq
+	0,  // 73: posts.PostsService.GetSavedCollections:input_type -> posts.SimpleIdReq
+	8,  // 74: posts.PostsService.GetPostById:output_type -> posts.Post
+	41, // 75: posts.PostsService.CanViewEntity:output_type -> google.protobuf.BoolValue
+	2,  // 76: posts.PostsService.GetUsedImageIds:output_type -> posts.ImageIds
+	1,  // 77: posts.PostsService.CreatePost:output_type -> posts.IdResp
+	42, // 78: posts.PostsService.DeletePost:output_type -> google.protobuf.Empty
+	42, // 79: posts.PostsService.EditPost:output_type -> google.protobuf.Empty
+	8,  // 80: posts.PostsService.GetMostPopularPostInGroup:output_type -> posts.Post
+	12, // 81: posts.PostsService.GetPersonalizedFeed:output_type -> posts.ListPosts
+	12, // 82: posts.PostsService.GetPublicFeed:output_type -> posts.ListPosts
+	12, // 83: posts.PostsService.GetHomeTimeline:output_type -> posts.ListPosts
+	12, // 84: posts.PostsService.GetUserPostsPaginated:output_type -> posts.ListPosts
+	12, // 85: posts.PostsService.GetGroupPostsPaginated:output_type -> posts.ListPosts
+	1,  // 86: posts.PostsService.CreateComment:output_type -> posts.IdResp
+	42, // 87: posts.PostsService.EditComment:output_type -> google.protobuf.Empty
+	42, // 88: posts.PostsService.DeleteComment:output_type -> google.protobuf.Empty
+	27, // 89: posts.PostsService.GetCommentsByParentId:output_type -> posts.ListComments
+	3,  // 90: posts.PostsService.GetPostAudienceForComment:output_type -> posts.AudienceResp
+	1,  // 91: posts.PostsService.CreateEvent:output_type -> posts.IdResp
+	42, // 92: posts.PostsService.DeleteEvent:output_type -> google.protobuf.Empty
+	42, // 93: posts.PostsService.EditEvent:output_type -> google.protobuf.Empty
+	31, // 94: posts.PostsService.GetEventsByGroupId:output_type -> posts.ListEvents
+	42, // 95: posts.PostsService.RespondToEvent:output_type -> google.protobuf.Empty
+	42, // 96: posts.PostsService.RemoveEventResponse:output_type -> google.protobuf.Empty
+	39, // 97: posts.PostsService.SuggestUsersByPostActivity:output_type -> common.ListUsers
+	42, // 98: posts.PostsService.ToggleOrInsertReaction:output_type -> google.protobuf.Empty
+	39, // 99: posts.PostsService.GetWhoLikedEntityId:output_type -> common.ListUsers
+	36, // 100: posts.PostsService.GetEntityRevisions:output_type -> posts.ListRevisions
+	12, // 101: posts.PostsService.GetUserDrafts:output_type -> posts.ListPosts
+	42, // 102: posts.PostsService.UpdatePostStatus:output_type -> google.protobuf.Empty
+	42, // 103: posts.PostsService.VotePoll:output_type -> google.protobuf.Empty
+	1,  // 104: posts.PostsService.SharePost:output_type -> posts.IdResp
+	42, // 105: posts.PostsService.SaveEntity:output_type -> google.protobuf.Empty
+	42, // 106: posts.PostsService.UnsaveEntity:output_type -> google.protobuf.Empty
+	12, // 107: posts.PostsService.ListSaved:output_type -> posts.ListPosts
+	20, // 108: posts.PostsService.GetSavedCollections:output_type -> posts.ListSavedCollections
+	74, // [74:109] is the sub-list for method output_type
+	39, // [39:74] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
 }
 
 func init() { file_posts_proto_init() }
@@ -2030,7 +3385,7 @@ func file_posts_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_posts_proto_rawDesc), len(file_posts_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   23,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -12,6 +12,7 @@ import (
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	common "social-network/shared/gen-go/common"
 )
 
@@ -22,12 +23,15 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	PostsService_GetPostById_FullMethodName                = "/posts.PostsService/GetPostById"
+	PostsService_CanViewEntity_FullMethodName              = "/posts.PostsService/CanViewEntity"
+	PostsService_GetUsedImageIds_FullMethodName            = "/posts.PostsService/GetUsedImageIds"
 	PostsService_CreatePost_FullMethodName                 = "/posts.PostsService/CreatePost"
 	PostsService_DeletePost_FullMethodName                 = "/posts.PostsService/DeletePost"
 	PostsService_EditPost_FullMethodName                   = "/posts.PostsService/EditPost"
 	PostsService_GetMostPopularPostInGroup_FullMethodName  = "/posts.PostsService/GetMostPopularPostInGroup"
 	PostsService_GetPersonalizedFeed_FullMethodName        = "/posts.PostsService/GetPersonalizedFeed"
 	PostsService_GetPublicFeed_FullMethodName              = "/posts.PostsService/GetPublicFeed"
+	PostsService_GetHomeTimeline_FullMethodName            = "/posts.PostsService/GetHomeTimeline"
 	PostsService_GetUserPostsPaginated_FullMethodName      = "/posts.PostsService/GetUserPostsPaginated"
 	PostsService_GetGroupPostsPaginated_FullMethodName     = "/posts.PostsService/GetGroupPostsPaginated"
 	PostsService_CreateComment_FullMethodName              = "/posts.PostsService/CreateComment"
@@ -44,6 +48,15 @@ const (
 	PostsService_SuggestUsersByPostActivity_FullMethodName = "/posts.PostsService/SuggestUsersByPostActivity"
 	PostsService_ToggleOrInsertReaction_FullMethodName     = "/posts.PostsService/ToggleOrInsertReaction"
 	PostsService_GetWhoLikedEntityId_FullMethodName        = "/posts.PostsService/GetWhoLikedEntityId"
+	PostsService_GetEntityRevisions_FullMethodName         = "/posts.PostsService/GetEntityRevisions"
+	PostsService_GetUserDrafts_FullMethodName              = "/posts.PostsService/GetUserDrafts"
+	PostsService_UpdatePostStatus_FullMethodName           = "/posts.PostsService/UpdatePostStatus"
+	PostsService_VotePoll_FullMethodName                   = "/posts.PostsService/VotePoll"
+	PostsService_SharePost_FullMethodName                  = "/posts.PostsService/SharePost"
+	PostsService_SaveEntity_FullMethodName                 = "/posts.PostsService/SaveEntity"
+	PostsService_UnsaveEntity_FullMethodName               = "/posts.PostsService/UnsaveEntity"
+	PostsService_ListSaved_FullMethodName                  = "/posts.PostsService/ListSaved"
+	PostsService_GetSavedCollections_FullMethodName        = "/posts.PostsService/GetSavedCollections"
 )
 
 // PostsServiceClient is the client API for PostsService service.
@@ -57,6 +70,13 @@ type PostsServiceClient interface {
 	// Includes comment and reaction count, audience and selected audience ids, and whether requester has reacted.
 	// Calls users and media service for post creator info and avatar url.
 	GetPostById(ctx context.Context, in *GenericReq, opts ...grpc.CallOption) (*Post, error)
+	// Returns whether requester can see a post, comment or event.
+	// Used by the media service to authorize downloads of private attachments.
+	CanViewEntity(ctx context.Context, in *GenericReq, opts ...grpc.CallOption) (*wrapperspb.BoolValue, error)
+	// Returns which of the given image ids are still attached to a post, comment or event
+	// that is not deleted, including the images kept by their edit history.
+	// Used by the media service to garbage collect unreferenced files.
+	GetUsedImageIds(ctx context.Context, in *ImageIds, opts ...grpc.CallOption) (*ImageIds, error)
 	// Creates a new post in a user feed or group.
 	// For a group post, returns permission denied if creator is not a member of the group.
 	// Post audience can be set to everyone, followers, selected and group.
@@ -84,6 +104,12 @@ type PostsServiceClient interface {
 	// Every post includes comment count, reaction count and whether requester has reacted.
 	// A call to users and media service is made for user information and images.
 	GetPublicFeed(ctx context.Context, in *GenericPaginatedReq, opts ...grpc.CallOption) (*ListPosts, error)
+	// Returns the requester's home timeline, paginated with next_cursor.
+	// Merges posts of followed users (every audience the requester is allowed to see),
+	// the requester's own posts and posts of the requester's groups, newest first.
+	// Every post includes comment count, reaction count and whether requester has reacted.
+	// A call to users and media service is made for group membership, user information and images.
+	GetHomeTimeline(ctx context.Context, in *GenericPaginatedReq, opts ...grpc.CallOption) (*ListPosts, error)
 	// Returns all of a user's posts visible to the requester, paginated.
 	// Every post includes comment count, reaction count and whether requester has reacted.
 	// A call to users and media service is made for user information and images.
@@ -149,6 +175,44 @@ type PostsServiceClient interface {
 	// Returns a paginated list of users who liked given entity id.
 	// A call to users and media service is made for user information and images.
 	GetWhoLikedEntityId(ctx context.Context, in *GenericEntityPaginatedReq, opts ...grpc.CallOption) (*common.ListUsers, error)
+	// Returns the edit history of a post, comment or event, newest revision first, paginated.
+	// Each revision is the entity as it was before an edit, along with who made the edit.
+	// Returns permission denied if requester has no right to view the entity.
+	// A call to users and media service is made for user information and images.
+	GetEntityRevisions(ctx context.Context, in *EntityIdPaginatedReq, opts ...grpc.CallOption) (*ListRevisions, error)
+	// Returns the requester's drafts and scheduled posts, newest first, paginated.
+	// These posts are not included in any feed until they are published.
+	// A call to users and media service is made for user information and images.
+	GetUserDrafts(ctx context.Context, in *GenericPaginatedReq, opts ...grpc.CallOption) (*ListPosts, error)
+	// Changes the status of a draft or scheduled post authored by requester.
+	// Status can be "draft", "scheduled" (publish_at must be in the future) or "published" (publishes now).
+	// Already published posts can't be moved back to draft.
+	UpdatePostStatus(ctx context.Context, in *UpdatePostStatusReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Casts or replaces the requester's vote on a poll.
+	// Single choice polls take exactly one option, multiple choice polls one or more.
+	// Returns permission denied if requester is not allowed to view the poll's post,
+	// and failed precondition if the poll has closed.
+	VotePoll(ctx context.Context, in *VotePollReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Shares (reposts) a post, with optional quote text. Sharing a share shares its original.
+	// The share's audience can't be wider than the original's: everyone who can see the share
+	// must be able to see the original (e.g. a "selected" or "group" post can't be shared publicly).
+	// Returns permission denied if the requester can't see the original or the audience is not allowed.
+	// A notification is sent to the creator of the original.
+	SharePost(ctx context.Context, in *SharePostReq, opts ...grpc.CallOption) (*IdResp, error)
+	// Saves a post for the requester, optionally in a named collection (created on first use).
+	// Saving an already saved post moves it to the given collection.
+	// Returns permission denied if requester is not allowed to view the post.
+	SaveEntity(ctx context.Context, in *SaveEntityReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Removes a post from the requester's saved posts.
+	// Returns not found if the post was not saved.
+	UnsaveEntity(ctx context.Context, in *GenericReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Returns the requester's saved posts, newest saves first, paginated.
+	// Access is re-checked on every call, so posts the requester can no longer see are left out
+	// (pages can therefore hold fewer posts than the limit).
+	// A call to users and media service is made for user information and images.
+	ListSaved(ctx context.Context, in *ListSavedReq, opts ...grpc.CallOption) (*ListPosts, error)
+	// Returns the requester's non empty saved post collections with their post counts.
+	GetSavedCollections(ctx context.Context, in *SimpleIdReq, opts ...grpc.CallOption) (*ListSavedCollections, error)
 }
 
 type postsServiceClient struct {
@@ -169,6 +233,26 @@ func (c *postsServiceClient) GetPostById(ctx context.Context, in *GenericReq, op
 	return out, nil
 }
 
+func (c *postsServiceClient) CanViewEntity(ctx context.Context, in *GenericReq, opts ...grpc.CallOption) (*wrapperspb.BoolValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(wrapperspb.BoolValue)
+	err := c.cc.Invoke(ctx, PostsService_CanViewEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postsServiceClient) GetUsedImageIds(ctx context.Context, in *ImageIds, opts ...grpc.CallOption) (*ImageIds, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImageIds)
+	err := c.cc.Invoke(ctx, PostsService_GetUsedImageIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *postsServiceClient) CreatePost(ctx context.Context, in *CreatePostReq, opts ...grpc.CallOption) (*IdResp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(IdResp)
@@ -229,6 +313,16 @@ func (c *postsServiceClient) GetPublicFeed(ctx context.Context, in *GenericPagin
 	return out, nil
 }
 
+func (c *postsServiceClient) GetHomeTimeline(ctx context.Context, in *GenericPaginatedReq, opts ...grpc.CallOption) (*ListPosts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPosts)
+	err := c.cc.Invoke(ctx, PostsService_GetHomeTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *postsServiceClient) GetUserPostsPaginated(ctx context.Context, in *GetUserPostsReq, opts ...grpc.CallOption) (*ListPosts, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListPosts)
@@ -389,6 +483,96 @@ func (c *postsServiceClient) GetWhoLikedEntityId(ctx context.Context, in *Generi
 	return out, nil
 }
 
+func (c *postsServiceClient) GetEntityRevisions(ctx context.Context, in *EntityIdPaginatedReq, opts ...grpc.CallOption) (*ListRevisions, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRevisions)
+	err := c.cc.Invoke(ctx, PostsService_GetEntityRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postsServiceClient) GetUserDrafts(ctx context.Context, in *GenericPaginatedReq, opts ...grpc.CallOption) (*ListPosts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPosts)
+	err := c.cc.Invoke(ctx, PostsService_GetUserDrafts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postsServiceClient) UpdatePostStatus(ctx context.Context, in *UpdatePostStatusReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PostsService_UpdatePostStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postsServiceClient) VotePoll(ctx context.Context, in *VotePollReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PostsService_VotePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postsServiceClient) SharePost(ctx context.Context, in *SharePostReq, opts ...grpc.CallOption) (*IdResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IdResp)
+	err := c.cc.Invoke(ctx, PostsService_SharePost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postsServiceClient) SaveEntity(ctx context.Context, in *SaveEntityReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PostsService_SaveEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postsServiceClient) UnsaveEntity(ctx context.Context, in *GenericReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PostsService_UnsaveEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postsServiceClient) ListSaved(ctx context.Context, in *ListSavedReq, opts ...grpc.CallOption) (*ListPosts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPosts)
+	err := c.cc.Invoke(ctx, PostsService_ListSaved_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postsServiceClient) GetSavedCollections(ctx context.Context, in *SimpleIdReq, opts ...grpc.CallOption) (*ListSavedCollections, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSavedCollections)
+	err := c.cc.Invoke(ctx, PostsService_GetSavedCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PostsServiceServer is the server API for PostsService service.
 // All implementations must embed UnimplementedPostsServiceServer
 // for forward compatibility.
@@ -400,6 +584,13 @@ type PostsServiceServer interface {
 	// Includes comment and reaction count, audience and selected audience ids, and whether requester has reacted.
 	// Calls users and media service for post creator info and avatar url.
 	GetPostById(context.Context, *GenericReq) (*Post, error)
+	// Returns whether requester can see a post, comment or event.
+	// Used by the media service to authorize downloads of private attachments.
+	CanViewEntity(context.Context, *GenericReq) (*wrapperspb.BoolValue, error)
+	// Returns which of the given image ids are still attached to a post, comment or event
+	// that is not deleted, including the images kept by their edit history.
+	// Used by the media service to garbage collect unreferenced files.
+	GetUsedImageIds(context.Context, *ImageIds) (*ImageIds, error)
 	// Creates a new post in a user feed or group.
 	// For a group post, returns permission denied if creator is not a member of the group.
 	// Post audience can be set to everyone, followers, selected and group.
@@ -427,6 +618,12 @@ type PostsServiceServer interface {
 	// Every post includes comment count, reaction count and whether requester has reacted.
 	// A call to users and media service is made for user information and images.
 	GetPublicFeed(context.Context, *GenericPaginatedReq) (*ListPosts, error)
+	// Returns the requester's home timeline, paginated with next_cursor.
+	// Merges posts of followed users (every audience the requester is allowed to see),
+	// the requester's own posts and posts of the requester's groups, newest first.
+	// Every post includes comment count, reaction count and whether requester has reacted.
+	// A call to users and media service is made for group membership, user information and images.
+	GetHomeTimeline(context.Context, *GenericPaginatedReq) (*ListPosts, error)
 	// Returns all of a user's posts visible to the requester, paginated.
 	// Every post includes comment count, reaction count and whether requester has reacted.
 	// A call to users and media service is made for user information and images.
@@ -492,6 +689,44 @@ type PostsServiceServer interface {
 	// Returns a paginated list of users who liked given entity id.
 	// A call to users and media service is made for user information and images.
 	GetWhoLikedEntityId(context.Context, *GenericEntityPaginatedReq) (*common.ListUsers, error)
+	// Returns the edit history of a post, comment or event, newest revision first, paginated.
+	// Each revision is the entity as it was before an edit, along with who made the edit.
+	// Returns permission denied if requester has no right to view the entity.
+	// A call to users and media service is made for user information and images.
+	GetEntityRevisions(context.Context, *EntityIdPaginatedReq) (*ListRevisions, error)
+	// Returns the requester's drafts and scheduled posts, newest first, paginated.
+	// These posts are not included in any feed until they are published.
+	// A call to users and media service is made for user information and images.
+	GetUserDrafts(context.Context, *GenericPaginatedReq) (*ListPosts, error)
+	// Changes the status of a draft or scheduled post authored by requester.
+	// Status can be "draft", "scheduled" (publish_at must be in the future) or "published" (publishes now).
+	// Already published posts can't be moved back to draft.
+	UpdatePostStatus(context.Context, *UpdatePostStatusReq) (*emptypb.Empty, error)
+	// Casts or replaces the requester's vote on a poll.
+	// Single choice polls take exactly one option, multiple choice polls one or more.
+	// Returns permission denied if requester is not allowed to view the poll's post,
+	// and failed precondition if the poll has closed.
+	VotePoll(context.Context, *VotePollReq) (*emptypb.Empty, error)
+	// Shares (reposts) a post, with optional quote text. Sharing a share shares its original.
+	// The share's audience can't be wider than the original's: everyone who can see the share
+	// must be able to see the original (e.g. a "selected" or "group" post can't be shared publicly).
+	// Returns permission denied if the requester can't see the original or the audience is not allowed.
+	// A notification is sent to the creator of the original.
+	SharePost(context.Context, *SharePostReq) (*IdResp, error)
+	// Saves a post for the requester, optionally in a named collection (created on first use).
+	// Saving an already saved post moves it to the given collection.
+	// Returns permission denied if requester is not allowed to view the post.
+	SaveEntity(context.Context, *SaveEntityReq) (*emptypb.Empty, error)
+	// Removes a post from the requester's saved posts.
+	// Returns not found if the post was not saved.
+	UnsaveEntity(context.Context, *GenericReq) (*emptypb.Empty, error)
+	// Returns the requester's saved posts, newest saves first, paginated.
+	// Access is re-checked on every call, so posts the requester can no longer see are left out
+	// (pages can therefore hold fewer posts than the limit).
+	// A call to users and media service is made for user information and images.
+	ListSaved(context.Context, *ListSavedReq) (*ListPosts, error)
+	// Returns the requester's non empty saved post collections with their post counts.
+	GetSavedCollections(context.Context, *SimpleIdReq) (*ListSavedCollections, error)
 	mustEmbedUnimplementedPostsServiceServer()
 }
 
@@ -505,6 +740,12 @@ type UnimplementedPostsServiceServer struct{}
 func (UnimplementedPostsServiceServer) GetPostById(context.Context, *GenericReq) (*Post, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPostById not implemented")
 }
+func (UnimplementedPostsServiceServer) CanViewEntity(context.Context, *GenericReq) (*wrapperspb.BoolValue, error) {
+	return nil, status.Error(codes.Unimplemented, "method CanViewEntity not implemented")
+}
+func (UnimplementedPostsServiceServer) GetUsedImageIds(context.Context, *ImageIds) (*ImageIds, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUsedImageIds not implemented")
+}
 func (UnimplementedPostsServiceServer) CreatePost(context.Context, *CreatePostReq) (*IdResp, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreatePost not implemented")
 }
@@ -523,6 +764,9 @@ func (UnimplementedPostsServiceServer) GetPersonalizedFeed(context.Context, *Get
 func (UnimplementedPostsServiceServer) GetPublicFeed(context.Context, *GenericPaginatedReq) (*ListPosts, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPublicFeed not implemented")
 }
+func (UnimplementedPostsServiceServer) GetHomeTimeline(context.Context, *GenericPaginatedReq) (*ListPosts, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHomeTimeline not implemented")
+}
 func (UnimplementedPostsServiceServer) GetUserPostsPaginated(context.Context, *GetUserPostsReq) (*ListPosts, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetUserPostsPaginated not implemented")
 }
@@ -571,6 +815,33 @@ func (UnimplementedPostsServiceServer) ToggleOrInsertReaction(context.Context, *
 func (UnimplementedPostsServiceServer) GetWhoLikedEntityId(context.Context, *GenericEntityPaginatedReq) (*common.ListUsers, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetWhoLikedEntityId not implemented")
 }
+func (UnimplementedPostsServiceServer) GetEntityRevisions(context.Context, *EntityIdPaginatedReq) (*ListRevisions, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEntityRevisions not implemented")
+}
+func (UnimplementedPostsServiceServer) GetUserDrafts(context.Context, *GenericPaginatedReq) (*ListPosts, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserDrafts not implemented")
+}
+func (UnimplementedPostsServiceServer) UpdatePostStatus(context.Context, *UpdatePostStatusReq) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdatePostStatus not implemented")
+}
+func (UnimplementedPostsServiceServer) VotePoll(context.Context, *VotePollReq) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method VotePoll not implemented")
+}
+func (UnimplementedPostsServiceServer) SharePost(context.Context, *SharePostReq) (*IdResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method SharePost not implemented")
+}
+func (UnimplementedPostsServiceServer) SaveEntity(context.Context, *SaveEntityReq) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveEntity not implemented")
+}
+func (UnimplementedPostsServiceServer) UnsaveEntity(context.Context, *GenericReq) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnsaveEntity not implemented")
+}
+func (UnimplementedPostsServiceServer) ListSaved(context.Context, *ListSavedReq) (*ListPosts, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSaved not implemented")
+}
+func (UnimplementedPostsServiceServer) GetSavedCollections(context.Context, *SimpleIdReq) (*ListSavedCollections, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSavedCollections not implemented")
+}
 func (UnimplementedPostsServiceServer) mustEmbedUnimplementedPostsServiceServer() {}
 func (UnimplementedPostsServiceServer) testEmbeddedByValue()                      {}
 
@@ -610,6 +881,42 @@ func _PostsService_GetPostById_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostsService_CanViewEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenericReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostsServiceServer).CanViewEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostsService_CanViewEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostsServiceServer).CanViewEntity(ctx, req.(*GenericReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostsService_GetUsedImageIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImageIds)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostsServiceServer).GetUsedImageIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostsService_GetUsedImageIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostsServiceServer).GetUsedImageIds(ctx, req.(*ImageIds))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PostsService_CreatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreatePostReq)
 	if err := dec(in); err != nil {
@@ -718,6 +1025,24 @@ func _PostsService_GetPublicFeed_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostsService_GetHomeTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenericPaginatedReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostsServiceServer).GetHomeTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostsService_GetHomeTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostsServiceServer).GetHomeTimeline(ctx, req.(*GenericPaginatedReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PostsService_GetUserPostsPaginated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetUserPostsReq)
 	if err := dec(in); err != nil {
@@ -1006,6 +1331,168 @@ func _PostsService_GetWhoLikedEntityId_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostsService_GetEntityRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityIdPaginatedReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostsServiceServer).GetEntityRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostsService_GetEntityRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostsServiceServer).GetEntityRevisions(ctx, req.(*EntityIdPaginatedReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostsService_GetUserDrafts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenericPaginatedReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostsServiceServer).GetUserDrafts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostsService_GetUserDrafts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostsServiceServer).GetUserDrafts(ctx, req.(*GenericPaginatedReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostsService_UpdatePostStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePostStatusReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostsServiceServer).UpdatePostStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostsService_UpdatePostStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostsServiceServer).UpdatePostStatus(ctx, req.(*UpdatePostStatusReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostsService_VotePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VotePollReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostsServiceServer).VotePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostsService_VotePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostsServiceServer).VotePoll(ctx, req.(*VotePollReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostsService_SharePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharePostReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostsServiceServer).SharePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostsService_SharePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostsServiceServer).SharePost(ctx, req.(*SharePostReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostsService_SaveEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveEntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostsServiceServer).SaveEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostsService_SaveEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostsServiceServer).SaveEntity(ctx, req.(*SaveEntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostsService_UnsaveEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenericReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostsServiceServer).UnsaveEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostsService_UnsaveEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostsServiceServer).UnsaveEntity(ctx, req.(*GenericReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostsService_ListSaved_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavedReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostsServiceServer).ListSaved(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostsService_ListSaved_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostsServiceServer).ListSaved(ctx, req.(*ListSavedReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostsService_GetSavedCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimpleIdReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostsServiceServer).GetSavedCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostsService_GetSavedCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostsServiceServer).GetSavedCollections(ctx, req.(*SimpleIdReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PostsService_ServiceDesc is the grpc.ServiceDesc for PostsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1017,6 +1504,14 @@ var PostsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPostById",
 			Handler:    _PostsService_GetPostById_Handler,
 		},
+		{
+			MethodName: "CanViewEntity",
+			Handler:    _PostsService_CanViewEntity_Handler,
+		},
+		{
+			MethodName: "GetUsedImageIds",
+			Handler:    _PostsService_GetUsedImageIds_Handler,
+		},
 		{
 			MethodName: "CreatePost",
 			Handler:    _PostsService_CreatePost_Handler,
@@ -1041,6 +1536,10 @@ var PostsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPublicFeed",
 			Handler:    _PostsService_GetPublicFeed_Handler,
 		},
+		{
+			MethodName: "GetHomeTimeline",
+			Handler:    _PostsService_GetHomeTimeline_Handler,
+		},
 		{
 			MethodName: "GetUserPostsPaginated",
 			Handler:    _PostsService_GetUserPostsPaginated_Handler,
@@ -1105,6 +1604,42 @@ var PostsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetWhoLikedEntityId",
 			Handler:    _PostsService_GetWhoLikedEntityId_Handler,
 		},
+		{
+			MethodName: "GetEntityRevisions",
+			Handler:    _PostsService_GetEntityRevisions_Handler,
+		},
+		{
+			MethodName: "GetUserDrafts",
+			Handler:    _PostsService_GetUserDrafts_Handler,
+		},
+		{
+			MethodName: "UpdatePostStatus",
+			Handler:    _PostsService_UpdatePostStatus_Handler,
+		},
+		{
+			MethodName: "VotePoll",
+			Handler:    _PostsService_VotePoll_Handler,
+		},
+		{
+			MethodName: "SharePost",
+			Handler:    _PostsService_SharePost_Handler,
+		},
+		{
+			MethodName: "SaveEntity",
+			Handler:    _PostsService_SaveEntity_Handler,
+		},
+		{
+			MethodName: "UnsaveEntity",
+			Handler:    _PostsService_UnsaveEntity_Handler,
+		},
+		{
+			MethodName: "ListSaved",
+			Handler:    _PostsService_ListSaved_Handler,
+		},
+		{
+			MethodName: "GetSavedCollections",
+			Handler:    _PostsService_GetSavedCollections_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "posts.proto",
@@ -2444,7 +2444,7 @@ const file_users_proto_rawDesc = "" +
 	"\fdelete_image\x18\b \x01(\bR\vdeleteImage\"N\n" +
 	"\x1bUpdateProfilePrivacyRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
-	"\x06public\x18\x02 \x01(\bR\x06public2\xd3\x15\n" +
+	"\x06public\x18\x02 \x01(\bR\x06public2\xfe\x15\n" +
 	"\vUserService\x12G\n" +
 	"\fRegisterUser\x12\x1a.users.RegisterUserRequest\x1a\x1b.users.RegisterUserResponse\x12.\n" +
 	"\tLoginUser\x12\x13.users.LoginRequest\x1a\f.common.User\x12J\n" +
@@ -2488,7 +2488,10 @@ const file_users_proto_rawDesc = "" +
 	"\vSearchUsers\x12\x18.users.UserSearchRequest\x1a\x11.common.ListUsers\x12L\n" +
 	"\x11UpdateUserProfile\x12\x1b.users.UpdateProfileRequest\x1a\x1a.users.UserProfileResponse\x12R\n" +
 	"\x14UpdateProfilePrivacy\x12\".users.UpdateProfilePrivacyRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
-	"\fRemoveImages\x12\x15.users.FailedImageIds\x1a\x16.google.protobuf.EmptyB*Z(social-network/shared/gen-go/users;usersb\x06proto3"
+	"\fRemoveImages\x12\x15.users.FailedImageIds\x1a\x16.google.protobuf.Empty\x12)\n" +
+	"\x0fGetUsedImageIds\x12\n" +
+	".users.Ids\x1a\n" +
+	".users.IdsB*Z(social-network/shared/gen-go/users;usersb\x06proto3"
 
 var (
 	file_users_proto_rawDescOnce sync.Once
@@ -2594,48 +2597,50 @@ var file_users_proto_depIdxs = []int32{
 	32, // 44: users.UserService.UpdateUserProfile:input_type -> users.UpdateProfileRequest
 	33, // 45: users.UserService.UpdateProfilePrivacy:input_type -> users.UpdateProfilePrivacyRequest
 	3,  // 46: users.UserService.RemoveImages:input_type -> users.FailedImageIds
-	6,  // 47: users.UserService.RegisterUser:output_type -> users.RegisterUserResponse
-	37, // 48: users.UserService.LoginUser:output_type -> common.User
-	38, // 49: users.UserService.UpdateUserPassword:output_type -> google.protobuf.Empty
-	38, // 50: users.UserService.UpdateUserEmail:output_type -> google.protobuf.Empty
-	39, // 51: users.UserService.GetFollowersPaginated:output_type -> common.ListUsers
-	39, // 52: users.UserService.GetFollowingPaginated:output_type -> common.ListUsers
-	12, // 53: users.UserService.FollowUser:output_type -> users.FollowUserResponse
-	38, // 54: users.UserService.UnFollowUser:output_type -> google.protobuf.Empty
-	38, // 55: users.UserService.HandleFollowRequest:output_type -> google.protobuf.Empty
-	35, // 56: users.UserService.GetFollowingIds:output_type -> common.UserIds
-	39, // 57: users.UserService.GetFollowSuggestions:output_type -> common.ListUsers
-	40, // 58: users.UserService.IsFollowing:output_type -> google.protobuf.BoolValue
-	15, // 59: users.UserService.AreFollowingEachOther:output_type -> users.AreFollowingEachOtherResponse
-	17, // 60: users.UserService.GetAllGroupsPaginated:output_type -> users.GroupArr
-	17, // 61: users.UserService.GetUserGroupsPaginated:output_type -> users.GroupArr
-	16, // 62: users.UserService.GetGroupInfo:output_type -> users.Group
-	16, // 63: users.UserService.GetGroupBasicInfo:output_type -> users.Group
-	21, // 64: users.UserService.GetGroupMembers:output_type -> users.GroupUserArr
-	2,  // 65: users.UserService.GetAllGroupMemberIds:output_type -> users.Ids
-	39, // 66: users.UserService.GetPendingGroupJoinRequests:output_type -> common.ListUsers
-	1,  // 67: users.UserService.GetPendingGroupJoinRequestsCount:output_type -> users.CountResp
-	39, // 68: users.UserService.GetFollowersNotInvitedToGroup:output_type -> common.ListUsers
-	17, // 69: users.UserService.SearchGroups:output_type -> users.GroupArr
-	38, // 70: users.UserService.InviteToGroup:output_type -> google.protobuf.Empty
-	40, // 71: users.UserService.IsGroupMember:output_type -> google.protobuf.BoolValue
-	38, // 72: users.UserService.RequestJoinGroup:output_type -> google.protobuf.Empty
-	38, // 73: users.UserService.CancelJoinGroupRequest:output_type -> google.protobuf.Empty
-	38, // 74: users.UserService.RespondToGroupInvite:output_type -> google.protobuf.Empty
-	38, // 75: users.UserService.HandleGroupJoinRequest:output_type -> google.protobuf.Empty
-	38, // 76: users.UserService.LeaveGroup:output_type -> google.protobuf.Empty
-	38, // 77: users.UserService.RemoveFromGroup:output_type -> google.protobuf.Empty
-	36, // 78: users.UserService.CreateGroup:output_type -> google.protobuf.Int64Value
-	38, // 79: users.UserService.UpdateGroup:output_type -> google.protobuf.Empty
-	37, // 80: users.UserService.GetBasicUserInfo:output_type -> common.User
-	39, // 81: users.UserService.GetBatchBasicUserInfo:output_type -> common.ListUsers
-	4,  // 82: users.UserService.GetUserProfile:output_type -> users.UserProfileResponse
-	39, // 83: users.UserService.SearchUsers:output_type -> common.ListUsers
-	4,  // 84: users.UserService.UpdateUserProfile:output_type -> users.UserProfileResponse
-	38, // 85: users.UserService.UpdateProfilePrivacy:output_type -> google.protobuf.Empty
-	38, // 86: users.UserService.RemoveImages:output_type -> google.protobuf.Empty
-	47, // [47:87] is the sub-list for method output_type
-	7,  // [7:47] is the sub-list for method input_type
+	2,  // 47: users.UserService.GetUsedImageIds:input_type -> users.Ids
+	6,  // 48: users.UserService.RegisterUser:output_type -> users.RegisterUserResponse
+	37, // 49: users.UserService.LoginUser:output_type -> common.User
+	38, // 50: users.UserService.UpdateUserPassword:output_type -> google.protobuf.Empty
+	38, // 51: users.UserService.UpdateUserEmail:output_type -> google.protobuf.Empty
+	39, // 52: users.UserService.GetFollowersPaginated:output_type -> common.ListUsers
+	39, // 53: users.UserService.GetFollowingPaginated:output_type -> common.ListUsers
+	12, // 54: users.UserService.FollowUser:output_type -> users.FollowUserResponse
+	38, // 55: users.UserService.UnFollowUser:output_type -> google.protobuf.Empty
+	38, // 56: users.UserService.HandleFollowRequest:output_type -> google.protobuf.Empty
+	35, // 57: users.UserService.GetFollowingIds:output_type -> common.UserIds
+	39, // 58: users.UserService.GetFollowSuggestions:output_type -> common.ListUsers
+	40, // 59: users.UserService.IsFollowing:output_type -> google.protobuf.BoolValue
+	15, // 60: users.UserService.AreFollowingEachOther:output_type -> users.AreFollowingEachOtherResponse
+	17, // 61: users.UserService.GetAllGroupsPaginated:output_type -> users.GroupArr
+	17, // 62: users.UserService.GetUserGroupsPaginated:output_type -> users.GroupArr
+	16, // 63: users.UserService.GetGroupInfo:output_type -> users.Group
+	16, // 64: users.UserService.GetGroupBasicInfo:output_type -> users.Group
+	21, // 65: users.UserService.GetGroupMembers:output_type -> users.GroupUserArr
+	2,  // 66: users.UserService.GetAllGroupMemberIds:output_type -> users.Ids
+	39, // 67: users.UserService.GetPendingGroupJoinRequests:output_type -> common.ListUsers
+	1,  // 68: users.UserService.GetPendingGroupJoinRequestsCount:output_type -> users.CountResp
+	39, // 69: users.UserService.GetFollowersNotInvitedToGroup:output_type -> common.ListUsers
+	17, // 70: users.UserService.SearchGroups:output_type -> users.GroupArr
+	38, // 71: users.UserService.InviteToGroup:output_type -> google.protobuf.Empty
+	40, // 72: users.UserService.IsGroupMember:output_type -> google.protobuf.BoolValue
+	38, // 73: users.UserService.RequestJoinGroup:output_type -> google.protobuf.Empty
+	38, // 74: users.UserService.CancelJoinGroupRequest:output_type -> google.protobuf.Empty
+	38, // 75: users.UserService.RespondToGroupInvite:output_type -> google.protobuf.Empty
+	38, // 76: users.UserService.HandleGroupJoinRequest:output_type -> google.protobuf.Empty
+	38, // 77: users.UserService.LeaveGroup:output_type -> google.protobuf.Empty
+	38, // 78: users.UserService.RemoveFromGroup:output_type -> google.protobuf.Empty
+	36, // 79: users.UserService.CreateGroup:output_type -> google.protobuf.Int64Value
+	38, // 80: users.UserService.UpdateGroup:output_type -> google.protobuf.Empty
+	37, // 81: users.UserService.GetBasicUserInfo:output_type -> common.User
+	39, // 82: users.UserService.GetBatchBasicUserInfo:output_type -> common.ListUsers
+	4,  // 83: users.UserService.GetUserProfile:output_type -> users.UserProfileResponse
+	39, // 84: users.UserService.SearchUsers:output_type -> common.ListUsers
+	4,  // 85: users.UserService.UpdateUserProfile:output_type -> users.UserProfileResponse
+	38, // 86: users.UserService.UpdateProfilePrivacy:output_type -> google.protobuf.Empty
+	38, // 87: users.UserService.RemoveImages:output_type -> google.protobuf.Empty
+	2,  // 88: users.UserService.GetUsedImageIds:output_type -> users.Ids
+	48, // [48:89] is the sub-list for method output_type
+	7,  // [7:48] is the sub-list for method input_type
 	7,  // [7:7] is the sub-list for extension type_name
 	7,  // [7:7] is the sub-list for extension extendee
 	0,  // [0:7] is the sub-list for field type_name
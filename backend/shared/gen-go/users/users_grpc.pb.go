@@ -62,6 +62,7 @@ const (
 	UserService_UpdateUserProfile_FullMethodName                = "/users.UserService/UpdateUserProfile"
 	UserService_UpdateProfilePrivacy_FullMethodName             = "/users.UserService/UpdateProfilePrivacy"
 	UserService_RemoveImages_FullMethodName                     = "/users.UserService/RemoveImages"
+	UserService_GetUsedImageIds_FullMethodName                  = "/users.UserService/GetUsedImageIds"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -211,6 +212,10 @@ type UserServiceClient interface {
 	UpdateProfilePrivacy(ctx context.Context, in *UpdateProfilePrivacyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Resets failed or missing avatar ids to 0
 	RemoveImages(ctx context.Context, in *FailedImageIds, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Returns which of the given image ids are still used as the avatar of a user
+	// or the image of a group that is not deleted.
+	// Used by the media service to garbage collect unreferenced files.
+	GetUsedImageIds(ctx context.Context, in *Ids, opts ...grpc.CallOption) (*Ids, error)
 }
 
 type userServiceClient struct {
@@ -621,6 +626,16 @@ func (c *userServiceClient) RemoveImages(ctx context.Context, in *FailedImageIds
 	return out, nil
 }
 
+func (c *userServiceClient) GetUsedImageIds(ctx context.Context, in *Ids, opts ...grpc.CallOption) (*Ids, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ids)
+	err := c.cc.Invoke(ctx, UserService_GetUsedImageIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -768,6 +783,10 @@ type UserServiceServer interface {
 	UpdateProfilePrivacy(context.Context, *UpdateProfilePrivacyRequest) (*emptypb.Empty, error)
 	// Resets failed or missing avatar ids to 0
 	RemoveImages(context.Context, *FailedImageIds) (*emptypb.Empty, error)
+	// Returns which of the given image ids are still used as the avatar of a user
+	// or the image of a group that is not deleted.
+	// Used by the media service to garbage collect unreferenced files.
+	GetUsedImageIds(context.Context, *Ids) (*Ids, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -898,6 +917,9 @@ func (UnimplementedUserServiceServer) UpdateProfilePrivacy(context.Context, *Upd
 func (UnimplementedUserServiceServer) RemoveImages(context.Context, *FailedImageIds) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method RemoveImages not implemented")
 }
+func (UnimplementedUserServiceServer) GetUsedImageIds(context.Context, *Ids) (*Ids, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUsedImageIds not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -1639,6 +1661,24 @@ func _UserService_RemoveImages_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetUsedImageIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Ids)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUsedImageIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUsedImageIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUsedImageIds(ctx, req.(*Ids))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1806,6 +1846,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveImages",
 			Handler:    _UserService_RemoveImages_Handler,
 		},
+		{
+			MethodName: "GetUsedImageIds",
+			Handler:    _UserService_GetUsedImageIds_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "users.proto",
@@ -0,0 +1,64 @@
+package ct
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ------------------------------------------------------------
+// FeedSort
+// ------------------------------------------------------------
+
+// Order of a feed: "recent" (newest first) or "top" (ranked by engagement with time decay).
+// Empty means recent, so use `validate:"nullable"` in structs.
+type FeedSort string
+
+const (
+	FeedSortRecent FeedSort = "recent"
+	FeedSortTop    FeedSort = "top"
+)
+
+func (fs FeedSort) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(fs))
+}
+
+func (fs *FeedSort) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*fs = FeedSort(s)
+	return nil
+}
+
+func (fs FeedSort) isValid() bool {
+	if fs == "" {
+		return false
+	}
+	for _, permittedValue := range permittedFeedSortValues {
+		if strings.EqualFold(fs.String(), permittedValue) {
+			return true
+		}
+	}
+	return false
+}
+
+func (fs FeedSort) Validate() error {
+	if !fs.isValid() {
+		return fmt.Errorf("%w: feed sort must be one of the following: %v",
+			ErrValidation,
+			permittedFeedSortValues,
+		)
+	}
+	return nil
+}
+
+// IsTop reports whether the feed is ranked instead of chronological.
+func (fs FeedSort) IsTop() bool {
+	return strings.EqualFold(fs.String(), FeedSortTop.String())
+}
+
+func (fs FeedSort) String() string {
+	return string(fs)
+}
@@ -99,7 +99,8 @@ func (v FileVisibility) Value() (driver.Value, error) {
 // =======================
 
 // FileVariant represents the variant or size of a file.
-// It can be "original", "thumb", "small", "medium", or "large".
+// It can be "original", "thumb", "small", "medium", or "large" for images
+// and "mp4", "webm" or "poster" for videos.
 type FileVariant string
 
 const (
@@ -108,6 +109,9 @@ const (
 	ImgMedium    FileVariant = "medium"
 	ImgLarge     FileVariant = "large"
 	Original     FileVariant = "original"
+	VidMP4       FileVariant = "mp4"    // H.264/AAC rendition
+	VidWebM      FileVariant = "webm"   // VP9/Opus rendition
+	VidPoster    FileVariant = "poster" // still frame shown before playback
 )
 
 func (v FileVariant) String() string {
@@ -116,7 +120,17 @@ func (v FileVariant) String() string {
 
 func (v FileVariant) isValid() bool {
 	switch v {
-	case ImgThumbnail, ImgSmall, ImgMedium, ImgLarge, Original:
+	case ImgThumbnail, ImgSmall, ImgMedium, ImgLarge, Original, VidMP4, VidWebM, VidPoster:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsVideo reports whether the variant is generated from a video.
+func (v FileVariant) IsVideo() bool {
+	switch v {
+	case VidMP4, VidWebM, VidPoster:
 		return true
 	default:
 		return false
@@ -190,14 +204,15 @@ func (v FileVariant) Value() (driver.Value, error) {
 // =======================
 
 // Describes the file upload status:
-// pending, processing, complete, failed
+// pending, processing, complete, failed, quarantined
 type UploadStatus string
 
 const (
-	Pending    UploadStatus = "pending"
-	Processing UploadStatus = "processing"
-	Complete   UploadStatus = "complete"
-	Failed     UploadStatus = "failed"
+	Pending     UploadStatus = "pending"
+	Processing  UploadStatus = "processing"
+	Complete    UploadStatus = "complete"
+	Failed      UploadStatus = "failed"
+	Quarantined UploadStatus = "quarantined" // flagged by the content scanner, never served
 )
 
 func (v UploadStatus) String() string {
@@ -206,7 +221,7 @@ func (v UploadStatus) String() string {
 
 func (v UploadStatus) isValid() bool {
 	switch v {
-	case Pending, Complete, Failed, Processing:
+	case Pending, Complete, Failed, Processing, Quarantined:
 		return true
 	default:
 		return false
@@ -274,3 +289,95 @@ func (v UploadStatus) Value() (driver.Value, error) {
 	}
 	return string(v), nil
 }
+
+// =======================
+// AttachmentType
+// =======================
+
+// AttachmentType is the kind of entity a file is attached to:
+// post, comment, event, avatar, group, message
+type AttachmentType string
+
+const (
+	AttachPost    AttachmentType = "post"
+	AttachComment AttachmentType = "comment"
+	AttachEvent   AttachmentType = "event"
+	AttachAvatar  AttachmentType = "avatar"
+	AttachGroup   AttachmentType = "group"
+	AttachMessage AttachmentType = "message"
+)
+
+func (v AttachmentType) String() string {
+	return string(v)
+}
+
+func (v AttachmentType) isValid() bool {
+	switch v {
+	case AttachPost, AttachComment, AttachEvent, AttachAvatar, AttachGroup, AttachMessage:
+		return true
+	default:
+		return false
+	}
+}
+
+func (v AttachmentType) Validate() error {
+	if !v.isValid() {
+		return fmt.Errorf("invalid AttachmentType: %q", v)
+	}
+	return nil
+}
+
+func (v AttachmentType) MarshalJSON() ([]byte, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(v))
+}
+
+func (v *AttachmentType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	val := AttachmentType(s)
+	if !val.isValid() {
+		return fmt.Errorf("invalid AttachmentType: %q", s)
+	}
+
+	*v = val
+	return nil
+}
+
+func (v *AttachmentType) Scan(src any) error {
+	if src == nil {
+		*v = ""
+		return nil
+	}
+
+	switch s := src.(type) {
+	case string:
+		val := AttachmentType(s)
+		if !val.isValid() {
+			return fmt.Errorf("invalid AttachmentType: %q", s)
+		}
+		*v = val
+		return nil
+	case []byte:
+		val := AttachmentType(string(s))
+		if !val.isValid() {
+			return fmt.Errorf("invalid AttachmentType: %q", s)
+		}
+		*v = val
+		return nil
+	default:
+		return fmt.Errorf("cannot scan AttachmentType from %T", src)
+	}
+}
+
+func (v AttachmentType) Value() (driver.Value, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	return string(v), nil
+}
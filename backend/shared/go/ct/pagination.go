@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 // ------------------------------------------------------------
@@ -162,3 +163,94 @@ func (o Offset) Value() (driver.Value, error) {
 func (o Offset) Int32() int32 {
 	return int32(o)
 }
+
+// ------------------------------------------------------------
+// Cursor
+// ------------------------------------------------------------
+
+// Opaque keyset pagination cursor pointing at the last item of a page, encoded with the same
+// hashids encoder as Id. An empty cursor requests the first page, so use `validate:"nullable"` in structs.
+//
+// Ranked feeds page over a stored snapshot of the ranking instead, their cursors hold the
+// snapshot id and the position of the next item. See NewRankedCursor.
+type Cursor string
+
+// appended to the values of ranked cursors so they can't be mistaken for keyset cursors
+const rankedCursorTag = 1
+
+// NewCursor encodes the position of an item ordered by (created_at, id).
+func NewCursor(createdAt time.Time, id int64) (Cursor, error) {
+	hash, err := hd.EncodeInt64([]int64{createdAt.UnixMicro(), id})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return Cursor(hash), nil
+}
+
+func (c Cursor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(c))
+}
+
+func (c *Cursor) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*c = Cursor(s)
+	return nil
+}
+
+// Decode returns the created_at and id of the item the cursor points at.
+func (c Cursor) Decode() (createdAt time.Time, id int64, err error) {
+	decoded, err := hd.DecodeInt64WithError(string(c))
+	if err != nil || len(decoded) != 2 {
+		return time.Time{}, 0, fmt.Errorf("failed to decode cursor: %w and decoded length: %d (should be 2)", err, len(decoded))
+	}
+	return time.UnixMicro(decoded[0]).UTC(), decoded[1], nil
+}
+
+// NewRankedCursor encodes the position of the next item in a stored ranking snapshot.
+func NewRankedCursor(snapshotId int64, position int64) (Cursor, error) {
+	hash, err := hd.EncodeInt64([]int64{snapshotId, position, rankedCursorTag})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode ranked cursor: %w", err)
+	}
+	return Cursor(hash), nil
+}
+
+// DecodeRanked returns the snapshot id and the position of the next item of a ranked cursor.
+func (c Cursor) DecodeRanked() (snapshotId int64, position int64, err error) {
+	decoded, err := hd.DecodeInt64WithError(string(c))
+	if err != nil || len(decoded) != 3 || decoded[2] != rankedCursorTag {
+		return 0, 0, fmt.Errorf("failed to decode ranked cursor: %w and decoded length: %d (should be 3)", err, len(decoded))
+	}
+	return decoded[0], decoded[1], nil
+}
+
+// IsRanked reports whether the cursor was built with NewRankedCursor.
+func (c Cursor) IsRanked() bool {
+	_, _, err := c.DecodeRanked()
+	return err == nil
+}
+
+func (c Cursor) isValid() bool {
+	if c == "" {
+		return false
+	}
+	if c.IsRanked() {
+		return true
+	}
+	_, id, err := c.Decode()
+	return err == nil && id > 0
+}
+
+func (c Cursor) Validate() error {
+	if !c.isValid() {
+		return errors.Join(ErrValidation, errors.New("invalid pagination cursor"))
+	}
+	return nil
+}
+
+func (c Cursor) String() string {
+	return string(c)
+}
@@ -0,0 +1,92 @@
+package ct
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ------------------------------------------------------------
+// PollQuestion
+// ------------------------------------------------------------
+
+// Question of a poll.
+type PollQuestion string
+
+func (q PollQuestion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(q))
+}
+
+func (q *PollQuestion) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*q = PollQuestion(s)
+	return nil
+}
+
+func (q PollQuestion) isValid() bool {
+	s := strings.TrimSpace(string(q))
+	return len(s) >= pollQuestionCharsMin && len(s) <= pollQuestionCharsMax
+}
+
+func (q PollQuestion) Validate() error {
+	if !q.isValid() {
+		return fmt.Errorf("%w poll question must be %d–%d chars and contain no control characters. question length: %v",
+			ErrValidation,
+			pollQuestionCharsMin,
+			pollQuestionCharsMax,
+			len(q),
+		)
+	}
+
+	if err := controlCharsFree(q.String()); err != nil {
+		return fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+	return nil
+}
+
+func (q PollQuestion) String() string {
+	return string(q)
+}
+
+// ------------------------------------------------------------
+// PollOptions
+// ------------------------------------------------------------
+
+// Answer options of a poll, in display order.
+type PollOptions []string
+
+func (o PollOptions) Validate() error {
+	if len(o) < pollOptionsMin || len(o) > pollOptionsMax {
+		return fmt.Errorf("%w: poll must have %d-%d options, got %d",
+			ErrValidation,
+			pollOptionsMin,
+			pollOptionsMax,
+			len(o),
+		)
+	}
+
+	seen := make(map[string]struct{}, len(o))
+	for i, opt := range o {
+		s := strings.TrimSpace(opt)
+		if len(s) < pollOptionCharsMin || len(s) > pollOptionCharsMax {
+			return fmt.Errorf("%w: poll option %d must be %d-%d chars",
+				ErrValidation,
+				i+1,
+				pollOptionCharsMin,
+				pollOptionCharsMax,
+			)
+		}
+		if err := controlCharsFree(opt); err != nil {
+			return fmt.Errorf("%w: %v", ErrValidation, err)
+		}
+		key := strings.ToLower(s)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("%w: duplicate poll option %q", ErrValidation, s)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
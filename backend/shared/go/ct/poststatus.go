@@ -0,0 +1,53 @@
+package ct
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ------------------------------------------------------------
+// PostStatus
+// ------------------------------------------------------------
+
+// Publication status of a post: draft, scheduled or published.
+type PostStatus string
+
+func (ps PostStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(ps))
+}
+
+func (ps *PostStatus) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*ps = PostStatus(s)
+	return nil
+}
+
+func (ps PostStatus) isValid() bool {
+	if ps == "" {
+		return false
+	}
+	for _, permittedValue := range permittedPostStatusValues {
+		if strings.EqualFold(ps.String(), permittedValue) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ps PostStatus) Validate() error {
+	if !ps.isValid() {
+		return fmt.Errorf("%w: post status must be one of the following: %v",
+			ErrValidation,
+			permittedPostStatusValues,
+		)
+	}
+	return nil
+}
+
+func (ps PostStatus) String() string {
+	return string(ps)
+}
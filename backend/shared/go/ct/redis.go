@@ -50,3 +50,84 @@ func (k IsGroupMemberKey) GenKey() (string, error) {
 func (k IsGroupMemberKey) String() string {
 	return fmt.Sprintf("is_group:%d.member:%d", k.GroupId.Int64(), k.UserId.Int64())
 }
+
+// TimelineKey is the sorted set of post ids fanned out to a user's timeline, scored by creation time.
+type TimelineKey struct {
+	UserId Id
+}
+
+func (k TimelineKey) GenKey() (string, error) {
+	if err := k.UserId.Validate(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("timeline:%d", k.UserId), nil
+}
+
+func (k TimelineKey) String() string {
+	return fmt.Sprintf("timeline:%d", k.UserId)
+}
+
+// TimelineReadyKey marks a user's timeline as fully built (backfilled), not only filled by new posts.
+type TimelineReadyKey struct {
+	UserId Id
+}
+
+func (k TimelineReadyKey) GenKey() (string, error) {
+	if err := k.UserId.Validate(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("timeline_ready:%d", k.UserId), nil
+}
+
+func (k TimelineReadyKey) String() string {
+	return fmt.Sprintf("timeline_ready:%d", k.UserId)
+}
+
+// FollowingIdsKey caches the ids of the users a user follows. Returns []int64
+type FollowingIdsKey struct {
+	UserId Id
+}
+
+func (k FollowingIdsKey) GenKey() (string, error) {
+	if err := k.UserId.Validate(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("following_ids:%d", k.UserId), nil
+}
+
+func (k FollowingIdsKey) String() string {
+	return fmt.Sprintf("following_ids:%d", k.UserId)
+}
+
+// FeedSnapshotKey holds a ranked feed snapshot, the post ids of a "top" feed in rank order.
+type FeedSnapshotKey struct {
+	SnapshotId int64
+}
+
+func (k FeedSnapshotKey) GenKey() (string, error) {
+	if k.SnapshotId <= 0 {
+		return "", fmt.Errorf("%w: snapshot id must be positive", ErrValidation)
+	}
+	return fmt.Sprintf("feed_snapshot:%d", k.SnapshotId), nil
+}
+
+func (k FeedSnapshotKey) String() string {
+	return fmt.Sprintf("feed_snapshot:%d", k.SnapshotId)
+}
+
+// PresenceKey is the sorted set of a user's open live connections, scored by when each one expires.
+// Connections refresh their score while open, so a user is online while a score is in the future.
+type PresenceKey struct {
+	UserId Id
+}
+
+func (k PresenceKey) GenKey() (string, error) {
+	if err := k.UserId.Validate(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("presence:%d", k.UserId), nil
+}
+
+func (k PresenceKey) String() string {
+	return fmt.Sprintf("presence:%d", k.UserId)
+}
@@ -40,10 +40,20 @@ const (
 	maxLimit                = 500
 	minTitleChars           = 1
 	maxTitleChars           = 50
+	pollQuestionCharsMin    = 3
+	pollQuestionCharsMax    = 300
+	pollOptionCharsMin      = 1
+	pollOptionCharsMax      = 100
+	pollOptionsMin          = 2
+	pollOptionsMax          = 10
 )
 
 var permittedAudienceValues = []string{"everyone", "group", "followers", "selected"}
 
+var permittedPostStatusValues = []string{"draft", "scheduled", "published"}
+
+var permittedFeedSortValues = []string{"recent", "top"}
+
 // emailRegex validates a basic email address format.
 // - Requires exactly one '@' character
 // - Disallows spaces anywhere in the address
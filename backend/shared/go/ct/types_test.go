@@ -123,6 +123,91 @@ func TestOffsetValidation(t *testing.T) {
 	}
 }
 
+// ------------------------------------------------------------
+// Cursor
+// ------------------------------------------------------------
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 123456000, time.UTC)
+	c, err := ct.NewCursor(createdAt, 42)
+	if err != nil {
+		t.Fatalf("unexpected: %v", err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected: %v", err)
+	}
+	gotTime, gotId, err := c.Decode()
+	if err != nil {
+		t.Fatalf("unexpected: %v", err)
+	}
+	if !gotTime.Equal(createdAt) || gotId != 42 {
+		t.Fatalf("got (%v, %d), want (%v, 42)", gotTime, gotId, createdAt)
+	}
+}
+
+func TestCursorValidation(t *testing.T) {
+	if err := ct.Cursor("").Validate(); err == nil {
+		t.Fatal("expected error for empty cursor")
+	}
+	if err := ct.Cursor("not-a-cursor").Validate(); err == nil {
+		t.Fatal("expected error for garbage cursor")
+	}
+	id, err := ct.EncodeId(5)
+	if err != nil {
+		t.Fatalf("unexpected: %v", err)
+	}
+	if err := ct.Cursor(id).Validate(); err == nil {
+		t.Fatal("expected error for encoded id used as cursor")
+	}
+}
+
+func TestRankedCursorRoundTrip(t *testing.T) {
+	c, err := ct.NewRankedCursor(987654321, 20)
+	if err != nil {
+		t.Fatalf("unexpected: %v", err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected: %v", err)
+	}
+	if !c.IsRanked() {
+		t.Fatal("expected ranked cursor")
+	}
+	snapshotId, position, err := c.DecodeRanked()
+	if err != nil {
+		t.Fatalf("unexpected: %v", err)
+	}
+	if snapshotId != 987654321 || position != 20 {
+		t.Fatalf("got (%d, %d), want (987654321, 20)", snapshotId, position)
+	}
+	if _, _, err := c.Decode(); err == nil {
+		t.Fatal("expected error decoding ranked cursor as keyset cursor")
+	}
+
+	keyset, err := ct.NewCursor(time.Now(), 7)
+	if err != nil {
+		t.Fatalf("unexpected: %v", err)
+	}
+	if keyset.IsRanked() {
+		t.Fatal("keyset cursor reported as ranked")
+	}
+}
+
+// ------------------------------------------------------------
+// FeedSort
+// ------------------------------------------------------------
+func TestFeedSortValidation(t *testing.T) {
+	for _, v := range []ct.FeedSort{"recent", "top", "TOP"} {
+		if err := v.Validate(); err != nil {
+			t.Fatalf("unexpected error for %q: %v", v, err)
+		}
+	}
+	if err := ct.FeedSort("hot").Validate(); err == nil {
+		t.Fatal("expected error for unknown sort")
+	}
+	if !ct.FeedSort("Top").IsTop() || ct.FeedSort("").IsTop() {
+		t.Fatal("IsTop mismatch")
+	}
+}
+
 // ------------------------------------------------------------
 // Password
 // ------------------------------------------------------------
@@ -225,6 +310,30 @@ func TestTitleValidation(t *testing.T) {
 	}
 }
 
+// ------------------------------------------------------------
+// Poll
+// ------------------------------------------------------------
+func TestPollValidation(t *testing.T) {
+	if err := ct.PollQuestion("Tabs or spaces?").Validate(); err != nil {
+		t.Fatalf("unexpected: %v", err)
+	}
+	if err := ct.PollQuestion("  ").Validate(); err == nil {
+		t.Fatal("expected trimmed length error")
+	}
+	if err := (ct.PollOptions{"tabs", "spaces"}).Validate(); err != nil {
+		t.Fatalf("unexpected: %v", err)
+	}
+	if err := (ct.PollOptions{"tabs"}).Validate(); err == nil {
+		t.Fatal("expected min options error")
+	}
+	if err := (ct.PollOptions{"tabs", "Tabs "}).Validate(); err == nil {
+		t.Fatal("expected duplicate option error")
+	}
+	if err := (ct.PollOptions{"tabs", " "}).Validate(); err == nil {
+		t.Fatal("expected empty option error")
+	}
+}
+
 // ------------------------------------------------------------
 // ValidateStruct
 // ------------------------------------------------------------
@@ -72,6 +72,7 @@ func (m *MiddleSystem) AllowedMethod(methods ...string) *MiddleSystem {
 		w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", ")+", OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Request-Id, X-Timestamp, Authorization")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.Header().Set("Access-Control-Expose-Headers", utils.NextCursorHeader)
 		// TODO fix this, return cors to be
 		// w.Header().Set("Access-Control-Allow-Origin", "http://localhost:8081")
 		// w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -71,6 +71,17 @@ func WriteJSON(ctx context.Context, w http.ResponseWriter, code int, v any) erro
 	return err
 }
 
+// NextCursorHeader carries the cursor of the next page on paginated list responses,
+// so the JSON body can stay a plain array. Absent on the last page.
+const NextCursorHeader = "X-Next-Cursor"
+
+// SetNextCursor sets NextCursorHeader on w if cursor is not empty. Call before WriteJSON.
+func SetNextCursor(w http.ResponseWriter, cursor string) {
+	if cursor != "" {
+		w.Header().Set(NextCursorHeader, cursor)
+	}
+}
+
 func ErrorJSON(ctx context.Context, w http.ResponseWriter, code int, msg string) {
 	tele.Warn(ctx, "Sending error response @1, @2", "code", code, "error_message", msg)
 	err := WriteJSON(ctx, w, code, map[string]string{"error": msg})
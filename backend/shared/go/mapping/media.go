@@ -19,6 +19,12 @@ func PbToCtFileVariant(v pb.FileVariant) ct.FileVariant {
 		return ct.ImgLarge
 	case pb.FileVariant_ORIGINAL:
 		return ct.Original
+	case pb.FileVariant_VIDEO_MP4:
+		return ct.VidMP4
+	case pb.FileVariant_VIDEO_WEBM:
+		return ct.VidWebM
+	case pb.FileVariant_VIDEO_POSTER:
+		return ct.VidPoster
 	default:
 		return ct.FileVariant("") // invalid, but handle gracefully
 	}
@@ -37,6 +43,12 @@ func CtToPbFileVariant(v ct.FileVariant) pb.FileVariant {
 		return pb.FileVariant_LARGE
 	case ct.Original:
 		return pb.FileVariant_ORIGINAL
+	case ct.VidMP4:
+		return pb.FileVariant_VIDEO_MP4
+	case ct.VidWebM:
+		return pb.FileVariant_VIDEO_WEBM
+	case ct.VidPoster:
+		return pb.FileVariant_VIDEO_POSTER
 	default:
 		return pb.FileVariant_IMG_VARIANT_UNSPECIFIED
 	}
@@ -77,6 +89,8 @@ func CtToPbUploadStatus(v ct.UploadStatus) pb.UploadStatus {
 		return pb.UploadStatus_UPLOAD_STATUS_COMPLETE
 	case ct.Failed:
 		return pb.UploadStatus_UPLOAD_STATUS_FAILED
+	case ct.Quarantined:
+		return pb.UploadStatus_UPLOAD_STATUS_QUARANTINED
 	default:
 		return pb.UploadStatus_UPLOAD_STATUS_UNSPECIFIED
 	}
@@ -93,8 +107,50 @@ func PbToCtUploadStatus(v pb.UploadStatus) ct.UploadStatus {
 		return ct.Complete
 	case pb.UploadStatus_UPLOAD_STATUS_FAILED:
 		return ct.Failed
+	case pb.UploadStatus_UPLOAD_STATUS_QUARANTINED:
+		return ct.Quarantined
 	default:
 		return ct.UploadStatus("") // invalid
 
 	}
 }
+
+// PbToCtAttachmentType converts protobuf AttachmentType to customtypes AttachmentType
+func PbToCtAttachmentType(v pb.AttachmentType) ct.AttachmentType {
+	switch v {
+	case pb.AttachmentType_ATTACHMENT_POST:
+		return ct.AttachPost
+	case pb.AttachmentType_ATTACHMENT_COMMENT:
+		return ct.AttachComment
+	case pb.AttachmentType_ATTACHMENT_EVENT:
+		return ct.AttachEvent
+	case pb.AttachmentType_ATTACHMENT_AVATAR:
+		return ct.AttachAvatar
+	case pb.AttachmentType_ATTACHMENT_GROUP:
+		return ct.AttachGroup
+	case pb.AttachmentType_ATTACHMENT_MESSAGE:
+		return ct.AttachMessage
+	default:
+		return ct.AttachmentType("") // invalid
+	}
+}
+
+// CtToPbAttachmentType converts customtypes AttachmentType to protobuf AttachmentType
+func CtToPbAttachmentType(v ct.AttachmentType) pb.AttachmentType {
+	switch v {
+	case ct.AttachPost:
+		return pb.AttachmentType_ATTACHMENT_POST
+	case ct.AttachComment:
+		return pb.AttachmentType_ATTACHMENT_COMMENT
+	case ct.AttachEvent:
+		return pb.AttachmentType_ATTACHMENT_EVENT
+	case ct.AttachAvatar:
+		return pb.AttachmentType_ATTACHMENT_AVATAR
+	case ct.AttachGroup:
+		return pb.AttachmentType_ATTACHMENT_GROUP
+	case ct.AttachMessage:
+		return pb.AttachmentType_ATTACHMENT_MESSAGE
+	default:
+		return pb.AttachmentType_ATTACHMENT_TYPE_UNSPECIFIED
+	}
+}
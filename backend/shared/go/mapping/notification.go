@@ -3,6 +3,7 @@ package mapping
 import (
 	pb "social-network/shared/gen-go/notifications"
 	ct "social-network/shared/go/ct"
+	md "social-network/shared/go/models"
 	"time"
 )
 
@@ -55,3 +56,56 @@ func PbToNotifications(ns []*pb.Notification) []Notification {
 	}
 	return notifs
 }
+
+type NotificationGroup struct {
+	Type            string            `json:"type"`
+	SourceService   string            `json:"source_service"`
+	SourceEntityID  ct.Id             `json:"source_entity_id"`
+	NotificationIDs []ct.Id           `json:"notification_ids"`
+	Count           int64             `json:"count"`
+	Actors          []md.User         `json:"actors"`
+	OtherActors     int64             `json:"other_actors"`
+	ActorsSummary   string            `json:"actors_summary"`
+	Seen            bool              `json:"seen"`
+	NeedsAction     bool              `json:"needs_action"`
+	Acted           bool              `json:"acted"`
+	Payload         map[string]string `json:"payload"`
+	LatestAt        time.Time         `json:"latest_at"`
+	EarliestAt      time.Time         `json:"earliest_at"`
+}
+
+func PbToNotificationGroup(g *pb.NotificationGroup) NotificationGroup {
+	ids := make([]ct.Id, len(g.NotificationIds))
+	for i, id := range g.NotificationIds {
+		ids[i] = ct.Id(id)
+	}
+	actors := make([]md.User, len(g.Actors))
+	for i, actor := range g.Actors {
+		actors[i] = MapUserFromProto(actor)
+	}
+
+	return NotificationGroup{
+		Type:            g.Type,
+		SourceService:   g.SourceService,
+		SourceEntityID:  ct.Id(g.SourceEntityId),
+		NotificationIDs: ids,
+		Count:           g.Count,
+		Actors:          actors,
+		OtherActors:     g.OtherActors,
+		ActorsSummary:   g.ActorsSummary,
+		Seen:            g.Seen,
+		NeedsAction:     g.NeedsAction,
+		Acted:           g.Acted,
+		Payload:         g.Payload,
+		LatestAt:        g.LatestAt.AsTime(),
+		EarliestAt:      g.EarliestAt.AsTime(),
+	}
+}
+
+func PbToNotificationGroups(gs []*pb.NotificationGroup) []NotificationGroup {
+	groups := make([]NotificationGroup, 0, len(gs))
+	for _, g := range gs {
+		groups = append(groups, PbToNotificationGroup(g))
+	}
+	return groups
+}
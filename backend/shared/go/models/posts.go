@@ -17,13 +17,16 @@ type EntityIdPaginatedReq struct {
 	RequesterId ct.Id
 	EntityId    ct.Id     `json:"entity_id"`
 	Limit       ct.Limit  `json:"limit"`
-	Offset      ct.Offset `json:"offset"`
+	Offset      ct.Offset `json:"offset"` // deprecated, ignored when Cursor is set
+	Cursor      ct.Cursor `json:"cursor" validate:"nullable"`
 }
 
 type GenericPaginatedReq struct {
 	RequesterId ct.Id
-	Limit       ct.Limit  `json:"limit"`
-	Offset      ct.Offset `json:"offset"`
+	Limit       ct.Limit    `json:"limit"`
+	Offset      ct.Offset   `json:"offset"` // deprecated, ignored when Cursor is set
+	Cursor      ct.Cursor   `json:"cursor" validate:"nullable"`
+	Sort        ct.FeedSort `json:"sort" validate:"nullable"` // only used by the public feed, "top" ignores Offset
 }
 
 type GenericEntityPaginatedReq struct {
@@ -43,6 +46,7 @@ type Post struct {
 	Audience              ct.Audience    `json:"audience"`
 	CommentsCount         int            `json:"comments_count"`
 	ReactionsCount        int            `json:"reactions_count"`
+	SharesCount           int            `json:"shares_count"`
 	LastCommentedAt       ct.GenDateTime `json:"last_commented_at"`
 	CreatedAt             ct.GenDateTime `json:"created_at"`
 	UpdatedAt             ct.GenDateTime `json:"updated_at" validate:"nullable"`
@@ -50,15 +54,118 @@ type Post struct {
 	ImageId               ct.Id          `json:"image" validate:"nullable"`
 	ImageUrl              string         `json:"image_url"`
 	SelectedAudienceUsers []User         `json:"selected_audience_users"`
+	Status                ct.PostStatus  `json:"status,omitempty" validate:"nullable"`
+	PublishAt             ct.GenDateTime `json:"publish_at" validate:"nullable"`
+	Poll                  *Poll          `json:"poll,omitempty"`        // nil unless a poll is attached to the post
+	SharedPost            *SharedPost    `json:"shared_post,omitempty"` // nil unless the post is a share
 }
 
 type CreatePostReq struct {
 	CreatorId   ct.Id
-	Body        ct.PostBody `json:"post_body"`
+	Body        ct.PostBody    `json:"post_body"`
+	GroupId     ct.Id          `json:"group_id" validate:"nullable"`
+	Audience    ct.Audience    `json:"audience"`
+	AudienceIds ct.Ids         `json:"audience_ids" validate:"nullable"`
+	ImageId     ct.Id          `json:"image" validate:"nullable"`
+	Status      ct.PostStatus  `json:"status" validate:"nullable"`     // empty means published
+	PublishAt   ct.GenDateTime `json:"publish_at" validate:"nullable"` // required if status is scheduled
+	Poll        *CreatePollReq `json:"poll"`                           // optional poll attached to the post
+}
+
+// -------------------------------------------
+// Polls
+// -------------------------------------------
+
+// A poll is attached to a post and shares its audience.
+// Vote counts are only filled in when ResultsVisible is true,
+// which is once the requester has voted or the poll has closed.
+type Poll struct {
+	PollId         ct.Id           `json:"poll_id"`
+	Question       ct.PollQuestion `json:"question"`
+	Options        []PollOption    `json:"options"`
+	MultipleChoice bool            `json:"multiple_choice"`
+	ClosesAt       ct.GenDateTime  `json:"closes_at" validate:"nullable"`
+	Closed         bool            `json:"closed"`
+	VotersCount    int             `json:"voters_count"`
+	ResultsVisible bool            `json:"results_visible"`
+}
+
+type PollOption struct {
+	OptionId    ct.Id  `json:"option_id"`
+	Text        string `json:"text"`
+	VotesCount  int    `json:"votes_count"`
+	VotedByUser bool   `json:"voted_by_user"`
+}
+
+type CreatePollReq struct {
+	Question       ct.PollQuestion `json:"question"`
+	Options        ct.PollOptions  `json:"options"`
+	MultipleChoice bool            `json:"multiple_choice"`
+	ClosesAt       ct.GenDateTime  `json:"closes_at" validate:"nullable"`
+}
+
+type VotePollReq struct {
+	RequesterId ct.Id
+	PollId      ct.Id  `json:"poll_id"`
+	OptionIds   ct.Ids `json:"option_ids"`
+}
+
+// -------------------------------------------
+// Shares
+// -------------------------------------------
+
+// The original post of a share.
+// If Available is false the original was deleted or the requester
+// can no longer see it, and only PostId is set.
+type SharedPost struct {
+	PostId    ct.Id          `json:"post_id"`
+	Available bool           `json:"available"`
+	Body      ct.PostBody    `json:"post_body"`
+	User      User           `json:"post_user"`
+	GroupId   ct.Id          `json:"group_id,omitempty" validate:"nullable"`
+	Audience  ct.Audience    `json:"audience"`
+	CreatedAt ct.GenDateTime `json:"created_at"`
+	UpdatedAt ct.GenDateTime `json:"updated_at" validate:"nullable"`
+	ImageId   ct.Id          `json:"image" validate:"nullable"`
+	ImageUrl  string         `json:"image_url"`
+}
+
+type SharePostReq struct {
+	RequesterId ct.Id
+	PostId      ct.Id       `json:"post_id"`
+	Body        ct.PostBody `json:"post_body" validate:"nullable"` // optional quote text
 	GroupId     ct.Id       `json:"group_id" validate:"nullable"`
 	Audience    ct.Audience `json:"audience"`
 	AudienceIds ct.Ids      `json:"audience_ids" validate:"nullable"`
-	ImageId     ct.Id       `json:"image" validate:"nullable"`
+}
+
+// -------------------------------------------
+// Saved posts
+// -------------------------------------------
+
+type SaveEntityReq struct {
+	RequesterId ct.Id
+	EntityId    ct.Id    `json:"entity_id"`
+	Collection  ct.Title `json:"collection" validate:"nullable"` // empty means unsorted
+}
+
+type ListSavedReq struct {
+	RequesterId ct.Id
+	Collection  ct.Title  `json:"collection" validate:"nullable"` // empty means all collections
+	Limit       ct.Limit  `json:"limit"`
+	Offset      ct.Offset `json:"offset"`
+}
+
+type SavedCollection struct {
+	Name       ct.Title `json:"name"`
+	SavedCount int      `json:"saved_count"`
+}
+
+type UpdatePostStatusReq struct {
+	RequesterId ct.Id
+	PostId      ct.Id          `json:"post_id"`
+	Status      ct.PostStatus  `json:"status"`
+	PublishAt   ct.GenDateTime `json:"publish_at" validate:"nullable"` // required if status is scheduled
 }
 
 type EditPostReq struct {
@@ -75,20 +182,24 @@ type GetUserPostsReq struct {
 	CreatorId   ct.Id `json:"creator_id"`
 	RequesterId ct.Id
 	Limit       ct.Limit  `json:"limit"`
-	Offset      ct.Offset `json:"offset"`
+	Offset      ct.Offset `json:"offset"` // deprecated, ignored when Cursor is set
+	Cursor      ct.Cursor `json:"cursor" validate:"nullable"`
 }
 
 type GetPersonalizedFeedReq struct {
 	RequesterId ct.Id
 	Limit       ct.Limit  `json:"limit"`
-	Offset      ct.Offset `json:"offset"`
+	Offset      ct.Offset `json:"offset"` // deprecated, ignored when Cursor is set
+	Cursor      ct.Cursor `json:"cursor" validate:"nullable"`
 }
 
 type GetGroupPostsReq struct {
 	RequesterId ct.Id
-	GroupId     ct.Id     `json:"group_id"`
-	Limit       ct.Limit  `json:"limit"`
-	Offset      ct.Offset `json:"offset"`
+	GroupId     ct.Id       `json:"group_id"`
+	Limit       ct.Limit    `json:"limit"`
+	Offset      ct.Offset   `json:"offset"` // deprecated, ignored when Cursor is set
+	Cursor      ct.Cursor   `json:"cursor" validate:"nullable"`
+	Sort        ct.FeedSort `json:"sort" validate:"nullable"` // "top" ignores Offset
 }
 
 //-------------------------------------------
@@ -168,3 +279,23 @@ type RespondToEventReq struct {
 	ResponderId ct.Id
 	Going       bool `json:"going"`
 }
+
+//-------------------------------------------
+// Revisions
+//-------------------------------------------
+
+// Revision is a snapshot of a post, comment or event as it was before an edit
+type Revision struct {
+	RevisionId            ct.Id          `json:"revision_id"`
+	EntityId              ct.Id          `json:"entity_id"`
+	ContentType           string         `json:"content_type"`
+	Editor                User           `json:"editor"`
+	Title                 ct.Title       `json:"title,omitempty" validate:"nullable"`
+	Body                  string         `json:"body"`
+	ImageIds              ct.Ids         `json:"image_ids" validate:"nullable"`
+	ImageUrls             []string       `json:"image_urls"`
+	Audience              ct.Audience    `json:"audience,omitempty" validate:"nullable"`
+	SelectedAudienceUsers []User         `json:"selected_audience_users"`
+	EventDate             ct.GenDateTime `json:"event_date,omitempty" validate:"nullable"`
+	CreatedAt             ct.GenDateTime `json:"created_at"`
+}
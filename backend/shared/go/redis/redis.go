@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -141,3 +142,113 @@ func (c *RedisClient) TestRedisConnection() error {
 
 	return nil
 }
+
+// ZEntry is a member of a sorted set with its score.
+type ZEntry struct {
+	Member string
+	Score  float64
+}
+
+// ZAddCapped adds member with score to every sorted set in keys, keeps only the maxLen highest
+// scored members of each set and refreshes their expiration to exp. Runs in a single pipeline.
+func (c *RedisClient) ZAddCapped(ctx context.Context, keys []string, score float64, member string, maxLen int64, exp time.Duration) error {
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.ZAdd(ctx, key, redis.Z{Score: score, Member: member})
+			pipe.ZRemRangeByRank(ctx, key, 0, -maxLen-1)
+			pipe.Expire(ctx, key, exp)
+		}
+		return nil
+	})
+	return err
+}
+
+// ZRemFromMany removes member from every sorted set in keys. Runs in a single pipeline.
+func (c *RedisClient) ZRemFromMany(ctx context.Context, keys []string, member string) error {
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.ZRem(ctx, key, member)
+		}
+		return nil
+	})
+	return err
+}
+
+// ZRem removes members from the sorted set at key.
+func (c *RedisClient) ZRem(ctx context.Context, key string, members ...string) error {
+	if len(members) == 0 {
+		return nil
+	}
+	args := make([]any, len(members))
+	for i, m := range members {
+		args[i] = m
+	}
+	return c.client.ZRem(ctx, key, args...).Err()
+}
+
+// ZRevRangeByScore returns up to count members of the sorted set at key with a score of at most max,
+// highest score first.
+func (c *RedisClient) ZRevRangeByScore(ctx context.Context, key string, max float64, count int64) ([]ZEntry, error) {
+	zs, err := c.client.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Max:   strconv.FormatFloat(max, 'f', -1, 64),
+		Min:   "-inf",
+		Count: count,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]ZEntry, 0, len(zs))
+	for _, z := range zs {
+		member, _ := z.Member.(string)
+		entries = append(entries, ZEntry{Member: member, Score: z.Score})
+	}
+	return entries, nil
+}
+
+// ZCard returns the number of members of the sorted set at key.
+func (c *RedisClient) ZCard(ctx context.Context, key string) (int64, error) {
+	return c.client.ZCard(ctx, key).Result()
+}
+
+// ZCountAbove returns the number of members of the sorted set at key with a score over min.
+func (c *RedisClient) ZCountAbove(ctx context.Context, key string, min float64) (int64, error) {
+	return c.client.ZCount(ctx, key, "("+strconv.FormatFloat(min, 'f', -1, 64), "+inf").Result()
+}
+
+// ReplaceSortedSet atomically replaces the sorted set at key with entries and sets its expiration to exp.
+func (c *RedisClient) ReplaceSortedSet(ctx context.Context, key string, entries []ZEntry, exp time.Duration) error {
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(entries) == 0 {
+			return nil
+		}
+		zs := make([]redis.Z, len(entries))
+		for i, e := range entries {
+			zs[i] = redis.Z{Score: e.Score, Member: e.Member}
+		}
+		pipe.ZAdd(ctx, key, zs...)
+		pipe.Expire(ctx, key, exp)
+		return nil
+	})
+	return err
+}
+
+// SAdd adds members to the set at key.
+func (c *RedisClient) SAdd(ctx context.Context, key string, members ...string) error {
+	args := make([]any, len(members))
+	for i, m := range members {
+		args[i] = m
+	}
+	return c.client.SAdd(ctx, key, args...).Err()
+}
+
+// SMembers returns all members of the set at key.
+func (c *RedisClient) SMembers(ctx context.Context, key string) ([]string, error) {
+	return c.client.SMembers(ctx, key).Result()
+}
+
+// Exists reports whether key exists.
+func (c *RedisClient) Exists(ctx context.Context, key string) (bool, error) {
+	n, err := c.client.Exists(ctx, key).Result()
+	return n > 0, err
+}
@@ -34,3 +34,16 @@ type MediaRetriever struct {
 func NewMediaRetriever(client MediaInfoRetriever, cache RedisCache, ttl time.Duration) *MediaRetriever {
 	return &MediaRetriever{client: client, cache: cache, ttl: ttl}
 }
+
+// Time left to a client to use an url handed out from the cache.
+const urlExpiryMargin = time.Minute
+
+// Cache ttl of urls expiring at expiresAt, unix seconds, zero for urls that don't expire.
+// Urls leave the cache a margin before they expire so the cache never hands out expired links,
+// a ttl below zero means the urls must not be cached.
+func (h *MediaRetriever) cacheTTL(expiresAt int64) time.Duration {
+	if expiresAt == 0 {
+		return h.ttl
+	}
+	return min(h.ttl, time.Until(time.Unix(expiresAt, 0))-urlExpiryMargin)
+}
@@ -13,6 +13,8 @@ import (
 )
 
 // GetImages returns a map[imageId]imageUrl, using cache + batch RPC.
+// Calls are trusted by the media service and get private images too, callers only
+// pass on the urls of entities the viewer can see. Cached urls are shared between viewers.
 func (h *MediaRetriever) GetImages(ctx context.Context, imageIds ct.Ids, variant media.FileVariant) (map[int64]string, []int64, error) {
 	input := fmt.Sprintf("ids %v, variant: %v", imageIds, variant)
 
@@ -71,11 +73,15 @@ func (h *MediaRetriever) GetImages(ctx context.Context, imageIds ct.Ids, variant
 		// merge with redis map
 		maps.Copy(images, resp.DownloadUrls)
 
-		// Cache the new results
+		// Cache the new results, until shortly before the urls expire
+		ttl := h.cacheTTL(resp.ExpiresAt)
 		for id, url := range resp.DownloadUrls {
+			if ttl <= 0 {
+				break
+			}
 			key, err := ct.ImageKey{Id: ct.Id(id), Variant: ctVariant}.GenKey()
 			if err == nil {
-				_ = h.cache.SetStr(ctx, key, url, h.ttl)
+				_ = h.cache.SetStr(ctx, key, url, ttl)
 			} else {
 				tele.Warn(ctx, "failed to construct redis key for image @1: @2", "imageId", id, "error", err.Error())
 			}
@@ -94,9 +100,10 @@ func (h *MediaRetriever) GetImages(ctx context.Context, imageIds ct.Ids, variant
 			delete(requested, id)
 		}
 
-		// Remove images in failedIds unless status failed
+		// Remove images in failedIds unless status failed or quarantined
 		for _, failed := range resp.FailedIds {
-			if failed.GetStatus() != media.UploadStatus_UPLOAD_STATUS_FAILED {
+			if failed.GetStatus() != media.UploadStatus_UPLOAD_STATUS_FAILED &&
+				failed.GetStatus() != media.UploadStatus_UPLOAD_STATUS_QUARANTINED {
 				delete(requested, failed.FileId)
 			}
 		}
@@ -127,6 +134,7 @@ func (h *MediaRetriever) GetImages(ctx context.Context, imageIds ct.Ids, variant
 // }
 
 // GetImage returns a single image url, using cache + batch RPC.
+// Trusted like GetImages, without requester.
 func (h *MediaRetriever) GetImage(ctx context.Context, imageId int64, variant media.FileVariant) (string, *ce.Error) {
 	input := fmt.Sprintf("id %v, variant: %v", imageId, variant)
 
@@ -161,11 +169,11 @@ func (h *MediaRetriever) GetImage(ctx context.Context, imageId int64, variant me
 
 	//if err, check if need to delete image
 
-	// Cache the new result
+	// Cache the new result, until shortly before the url expires
 	key, err = ct.ImageKey{Id: ct.Id(imageId), Variant: ctVariant}.GenKey()
-	if err == nil {
-		_ = h.cache.SetStr(ctx, key, resp.DownloadUrl, h.ttl)
-	} else {
+	if ttl := h.cacheTTL(resp.ExpiresAt); err == nil && ttl > 0 {
+		_ = h.cache.SetStr(ctx, key, resp.DownloadUrl, ttl)
+	} else if err != nil {
 		tele.Warn(ctx, "failed to construct redis key for image @1: @2", "imageId", imageId, "error", err.Error())
 	}
 